@@ -30,6 +30,11 @@ type CreateExperimentSuccess struct {
 	Data externalRef0.Experiment `json:"data"`
 }
 
+// CreateProjectPasskeySuccess defines model for CreateProjectPasskeySuccess.
+type CreateProjectPasskeySuccess struct {
+	Data externalRef0.CreatedProjectPasskey `json:"data"`
+}
+
 // CreateProjectSettingsSuccess defines model for CreateProjectSettingsSuccess.
 type CreateProjectSettingsSuccess struct {
 	Data externalRef0.ProjectSettings `json:"data"`
@@ -130,6 +135,16 @@ type ListExperimentsSuccess struct {
 	Paging *externalRef0.Paging      `json:"paging,omitempty"`
 }
 
+// ListProjectPasskeyHashesSuccess defines model for ListProjectPasskeyHashesSuccess.
+type ListProjectPasskeyHashesSuccess struct {
+	Data []externalRef0.ProjectPasskeyHash `json:"data"`
+}
+
+// ListProjectPasskeysSuccess defines model for ListProjectPasskeysSuccess.
+type ListProjectPasskeysSuccess struct {
+	Data []externalRef0.ProjectPasskey `json:"data"`
+}
+
 // ListProjectsSuccess defines model for ListProjectsSuccess.
 type ListProjectsSuccess struct {
 	Data []externalRef0.Project `json:"data"`
@@ -167,6 +182,11 @@ type ListTreatmentsSuccess struct {
 // NotFound defines model for NotFound.
 type NotFound externalRef0.Error
 
+// RevokeProjectPasskeySuccess defines model for RevokeProjectPasskeySuccess.
+type RevokeProjectPasskeySuccess struct {
+	Name *string `json:"name,omitempty"`
+}
+
 // UpdateExperimentSuccess defines model for UpdateExperimentSuccess.
 type UpdateExperimentSuccess struct {
 	Data externalRef0.Experiment `json:"data"`
@@ -194,17 +214,31 @@ type UpdateTreatmentSuccess struct {
 
 // CreateExperimentRequestBody defines model for CreateExperimentRequestBody.
 type CreateExperimentRequestBody struct {
-	Description *string                            `json:"description"`
-	EndTime     time.Time                          `json:"end_time"`
-	Interval    *int32                             `json:"interval"`
-	Name        string                             `json:"name"`
-	Segment     externalRef0.ExperimentSegment     `json:"segment"`
-	StartTime   time.Time                          `json:"start_time"`
-	Status      externalRef0.ExperimentStatus      `json:"status"`
-	Tier        *externalRef0.ExperimentTier       `json:"tier,omitempty"`
-	Treatments  []externalRef0.ExperimentTreatment `json:"treatments"`
-	Type        externalRef0.ExperimentType        `json:"type"`
-	UpdatedBy   *string                            `json:"updated_by,omitempty"`
+	Description *string                             `json:"description"`
+	EndTime     time.Time                           `json:"end_time"`
+	Guardrails  *[]externalRef0.ExperimentGuardrail `json:"guardrails,omitempty"`
+	Interval    *int32                              `json:"interval"`
+	Name        string                              `json:"name"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
+	Segment    externalRef0.ExperimentSegment     `json:"segment"`
+	StartTime  time.Time                          `json:"start_time"`
+	Status     externalRef0.ExperimentStatus      `json:"status"`
+	Tier       *externalRef0.ExperimentTier       `json:"tier,omitempty"`
+	Treatments []externalRef0.ExperimentTreatment `json:"treatments"`
+	Type       externalRef0.ExperimentType        `json:"type"`
+	UpdatedBy  *string                            `json:"updated_by,omitempty"`
+}
+
+// CreateProjectPasskeyRequestBody defines model for CreateProjectPasskeyRequestBody.
+type CreateProjectPasskeyRequestBody struct {
+
+	// Time after which the passkey is no longer accepted. The passkey does not expire if unset.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
 }
 
 // CreateProjectSettingsRequestBody defines model for CreateProjectSettingsRequestBody.
@@ -220,20 +254,30 @@ type CreateProjectSettingsRequestBody struct {
 
 // CreateSegmentRequestBody defines model for CreateSegmentRequestBody.
 type CreateSegmentRequestBody struct {
-	Name      string                         `json:"name"`
+	Name string `json:"name"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
 	Segment   externalRef0.ExperimentSegment `json:"segment"`
 	UpdatedBy *string                        `json:"updated_by,omitempty"`
 }
 
 // CreateSegmenterRequestBody defines model for CreateSegmenterRequestBody.
 type CreateSegmenterRequestBody struct {
-	Constraints *[]externalRef0.Constraint     `json:"constraints,omitempty"`
-	Description *string                        `json:"description,omitempty"`
-	MultiValued bool                           `json:"multi_valued"`
-	Name        string                         `json:"name"`
-	Options     *externalRef0.SegmenterOptions `json:"options,omitempty"`
-	Required    bool                           `json:"required"`
-	Type        externalRef0.SegmenterType     `json:"type"`
+	Constraints *[]externalRef0.Constraint `json:"constraints,omitempty"`
+	Description *string                    `json:"description,omitempty"`
+	Expression  *string                    `json:"expression,omitempty"`
+
+	// How the values of a string segmenter are matched against the value in the treatment request. The values are exact strings by default, or prefixes or RE2 regular expressions when the prefix or regex mode is chosen.
+	MatchMode     *externalRef0.SegmenterMatchMode `json:"match_mode,omitempty"`
+	MultiValued   bool                             `json:"multi_valued"`
+	Name          string                           `json:"name"`
+	Options       *externalRef0.SegmenterOptions   `json:"options,omitempty"`
+	RequestFields *[]string                        `json:"request_fields,omitempty"`
+	Required      bool                             `json:"required"`
+	Type          externalRef0.SegmenterType       `json:"type"`
 }
 
 // CreateTreatmentRequestBody defines model for CreateTreatmentRequestBody.
@@ -245,16 +289,22 @@ type CreateTreatmentRequestBody struct {
 
 // UpdateExperimentRequestBody defines model for UpdateExperimentRequestBody.
 type UpdateExperimentRequestBody struct {
-	Description *string                            `json:"description"`
-	EndTime     time.Time                          `json:"end_time"`
-	Interval    *int32                             `json:"interval"`
-	Segment     externalRef0.ExperimentSegment     `json:"segment"`
-	StartTime   time.Time                          `json:"start_time"`
-	Status      externalRef0.ExperimentStatus      `json:"status"`
-	Tier        *externalRef0.ExperimentTier       `json:"tier,omitempty"`
-	Treatments  []externalRef0.ExperimentTreatment `json:"treatments"`
-	Type        externalRef0.ExperimentType        `json:"type"`
-	UpdatedBy   *string                            `json:"updated_by,omitempty"`
+	Description *string                             `json:"description"`
+	EndTime     time.Time                           `json:"end_time"`
+	Guardrails  *[]externalRef0.ExperimentGuardrail `json:"guardrails,omitempty"`
+	Interval    *int32                              `json:"interval"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
+	Segment    externalRef0.ExperimentSegment     `json:"segment"`
+	StartTime  time.Time                          `json:"start_time"`
+	Status     externalRef0.ExperimentStatus      `json:"status"`
+	Tier       *externalRef0.ExperimentTier       `json:"tier,omitempty"`
+	Treatments []externalRef0.ExperimentTreatment `json:"treatments"`
+	Type       externalRef0.ExperimentType        `json:"type"`
+	UpdatedBy  *string                            `json:"updated_by,omitempty"`
 }
 
 // UpdateProjectSettingsRequestBody defines model for UpdateProjectSettingsRequestBody.
@@ -270,6 +320,11 @@ type UpdateProjectSettingsRequestBody struct {
 
 // UpdateSegmentRequestBody defines model for UpdateSegmentRequestBody.
 type UpdateSegmentRequestBody struct {
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
 	Segment   externalRef0.ExperimentSegment `json:"segment"`
 	UpdatedBy *string                        `json:"updated_by,omitempty"`
 }
@@ -417,6 +472,9 @@ type CreateExperimentJSONRequestBody CreateExperimentRequestBody
 // UpdateExperimentJSONRequestBody defines body for UpdateExperiment for application/json ContentType.
 type UpdateExperimentJSONRequestBody UpdateExperimentRequestBody
 
+// CreateProjectPasskeyJSONRequestBody defines body for CreateProjectPasskey for application/json ContentType.
+type CreateProjectPasskeyJSONRequestBody CreateProjectPasskeyRequestBody
+
 // CreateSegmenterJSONRequestBody defines body for CreateSegmenter for application/json ContentType.
 type CreateSegmenterJSONRequestBody CreateSegmenterRequestBody
 
@@ -551,6 +609,20 @@ type ClientInterface interface {
 	// GetExperimentHistory request
 	GetExperimentHistory(ctx context.Context, projectId int64, experimentId int64, version int64, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ListProjectPasskeys request
+	ListProjectPasskeys(ctx context.Context, projectId int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateProjectPasskey request  with any body
+	CreateProjectPasskeyWithBody(ctx context.Context, projectId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateProjectPasskey(ctx context.Context, projectId int64, body CreateProjectPasskeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListProjectPasskeyHashes request
+	ListProjectPasskeyHashes(ctx context.Context, projectId int64, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// RevokeProjectPasskey request
+	RevokeProjectPasskey(ctx context.Context, projectId int64, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListSegmenters request
 	ListSegmenters(ctx context.Context, projectId int64, params *ListSegmentersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -786,6 +858,66 @@ func (c *Client) GetExperimentHistory(ctx context.Context, projectId int64, expe
 	return c.Client.Do(req)
 }
 
+func (c *Client) ListProjectPasskeys(ctx context.Context, projectId int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListProjectPasskeysRequest(c.Server, projectId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateProjectPasskeyWithBody(ctx context.Context, projectId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateProjectPasskeyRequestWithBody(c.Server, projectId, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateProjectPasskey(ctx context.Context, projectId int64, body CreateProjectPasskeyJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateProjectPasskeyRequest(c.Server, projectId, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListProjectPasskeyHashes(ctx context.Context, projectId int64, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListProjectPasskeyHashesRequest(c.Server, projectId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) RevokeProjectPasskey(ctx context.Context, projectId int64, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewRevokeProjectPasskeyRequest(c.Server, projectId, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ListSegmenters(ctx context.Context, projectId int64, params *ListSegmentersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewListSegmentersRequest(c.Server, projectId, params)
 	if err != nil {
@@ -1854,6 +1986,162 @@ func NewGetExperimentHistoryRequest(server string, projectId int64, experimentId
 	return req, nil
 }
 
+// NewListProjectPasskeysRequest generates requests for ListProjectPasskeys
+func NewListProjectPasskeysRequest(server string, projectId int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/projects/%s/passkeys", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateProjectPasskeyRequest calls the generic CreateProjectPasskey builder with application/json body
+func NewCreateProjectPasskeyRequest(server string, projectId int64, body CreateProjectPasskeyJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateProjectPasskeyRequestWithBody(server, projectId, "application/json", bodyReader)
+}
+
+// NewCreateProjectPasskeyRequestWithBody generates requests for CreateProjectPasskey with any type of body
+func NewCreateProjectPasskeyRequestWithBody(server string, projectId int64, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/projects/%s/passkeys", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewListProjectPasskeyHashesRequest generates requests for ListProjectPasskeyHashes
+func NewListProjectPasskeyHashesRequest(server string, projectId int64) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/projects/%s/passkeys/hashes", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewRevokeProjectPasskeyRequest generates requests for RevokeProjectPasskey
+func NewRevokeProjectPasskeyRequest(server string, projectId int64, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/projects/%s/passkeys/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewListSegmentersRequest generates requests for ListSegmenters
 func NewListSegmentersRequest(server string, projectId int64, params *ListSegmentersParams) (*http.Request, error) {
 	var err error
@@ -3247,6 +3535,20 @@ type ClientWithResponsesInterface interface {
 	// GetExperimentHistory request
 	GetExperimentHistoryWithResponse(ctx context.Context, projectId int64, experimentId int64, version int64, reqEditors ...RequestEditorFn) (*GetExperimentHistoryResponse, error)
 
+	// ListProjectPasskeys request
+	ListProjectPasskeysWithResponse(ctx context.Context, projectId int64, reqEditors ...RequestEditorFn) (*ListProjectPasskeysResponse, error)
+
+	// CreateProjectPasskey request  with any body
+	CreateProjectPasskeyWithBodyWithResponse(ctx context.Context, projectId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProjectPasskeyResponse, error)
+
+	CreateProjectPasskeyWithResponse(ctx context.Context, projectId int64, body CreateProjectPasskeyJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProjectPasskeyResponse, error)
+
+	// ListProjectPasskeyHashes request
+	ListProjectPasskeyHashesWithResponse(ctx context.Context, projectId int64, reqEditors ...RequestEditorFn) (*ListProjectPasskeyHashesResponse, error)
+
+	// RevokeProjectPasskey request
+	RevokeProjectPasskeyWithResponse(ctx context.Context, projectId int64, name string, reqEditors ...RequestEditorFn) (*RevokeProjectPasskeyResponse, error)
+
 	// ListSegmenters request
 	ListSegmentersWithResponse(ctx context.Context, projectId int64, params *ListSegmentersParams, reqEditors ...RequestEditorFn) (*ListSegmentersResponse, error)
 
@@ -3593,6 +3895,112 @@ func (r GetExperimentHistoryResponse) StatusCode() int {
 	return 0
 }
 
+type ListProjectPasskeysResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Data []externalRef0.ProjectPasskey `json:"data"`
+	}
+	JSON404 *externalRef0.Error
+	JSON500 *externalRef0.Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListProjectPasskeysResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListProjectPasskeysResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateProjectPasskeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Data externalRef0.CreatedProjectPasskey `json:"data"`
+	}
+	JSON400 *externalRef0.Error
+	JSON404 *externalRef0.Error
+	JSON500 *externalRef0.Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateProjectPasskeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateProjectPasskeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListProjectPasskeyHashesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Data []externalRef0.ProjectPasskeyHash `json:"data"`
+	}
+	JSON404 *externalRef0.Error
+	JSON500 *externalRef0.Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListProjectPasskeyHashesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListProjectPasskeyHashesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type RevokeProjectPasskeyResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *struct {
+		Name *string `json:"name,omitempty"`
+	}
+	JSON400 *externalRef0.Error
+	JSON404 *externalRef0.Error
+	JSON500 *externalRef0.Error
+}
+
+// Status returns HTTPResponse.Status
+func (r RevokeProjectPasskeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r RevokeProjectPasskeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ListSegmentersResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -4323,6 +4731,50 @@ func (c *ClientWithResponses) GetExperimentHistoryWithResponse(ctx context.Conte
 	return ParseGetExperimentHistoryResponse(rsp)
 }
 
+// ListProjectPasskeysWithResponse request returning *ListProjectPasskeysResponse
+func (c *ClientWithResponses) ListProjectPasskeysWithResponse(ctx context.Context, projectId int64, reqEditors ...RequestEditorFn) (*ListProjectPasskeysResponse, error) {
+	rsp, err := c.ListProjectPasskeys(ctx, projectId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListProjectPasskeysResponse(rsp)
+}
+
+// CreateProjectPasskeyWithBodyWithResponse request with arbitrary body returning *CreateProjectPasskeyResponse
+func (c *ClientWithResponses) CreateProjectPasskeyWithBodyWithResponse(ctx context.Context, projectId int64, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateProjectPasskeyResponse, error) {
+	rsp, err := c.CreateProjectPasskeyWithBody(ctx, projectId, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateProjectPasskeyResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateProjectPasskeyWithResponse(ctx context.Context, projectId int64, body CreateProjectPasskeyJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateProjectPasskeyResponse, error) {
+	rsp, err := c.CreateProjectPasskey(ctx, projectId, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateProjectPasskeyResponse(rsp)
+}
+
+// ListProjectPasskeyHashesWithResponse request returning *ListProjectPasskeyHashesResponse
+func (c *ClientWithResponses) ListProjectPasskeyHashesWithResponse(ctx context.Context, projectId int64, reqEditors ...RequestEditorFn) (*ListProjectPasskeyHashesResponse, error) {
+	rsp, err := c.ListProjectPasskeyHashes(ctx, projectId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListProjectPasskeyHashesResponse(rsp)
+}
+
+// RevokeProjectPasskeyWithResponse request returning *RevokeProjectPasskeyResponse
+func (c *ClientWithResponses) RevokeProjectPasskeyWithResponse(ctx context.Context, projectId int64, name string, reqEditors ...RequestEditorFn) (*RevokeProjectPasskeyResponse, error) {
+	rsp, err := c.RevokeProjectPasskey(ctx, projectId, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRevokeProjectPasskeyResponse(rsp)
+}
+
 // ListSegmentersWithResponse request returning *ListSegmentersResponse
 func (c *ClientWithResponses) ListSegmentersWithResponse(ctx context.Context, projectId int64, params *ListSegmentersParams, reqEditors ...RequestEditorFn) (*ListSegmentersResponse, error) {
 	rsp, err := c.ListSegmenters(ctx, projectId, params, reqEditors...)
@@ -5008,6 +5460,188 @@ func ParseGetExperimentHistoryResponse(rsp *http.Response) (*GetExperimentHistor
 	return response, nil
 }
 
+// ParseListProjectPasskeysResponse parses an HTTP response from a ListProjectPasskeysWithResponse call
+func ParseListProjectPasskeysResponse(rsp *http.Response) (*ListProjectPasskeysResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListProjectPasskeysResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Data []externalRef0.ProjectPasskey `json:"data"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateProjectPasskeyResponse parses an HTTP response from a CreateProjectPasskeyWithResponse call
+func ParseCreateProjectPasskeyResponse(rsp *http.Response) (*CreateProjectPasskeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateProjectPasskeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Data externalRef0.CreatedProjectPasskey `json:"data"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListProjectPasskeyHashesResponse parses an HTTP response from a ListProjectPasskeyHashesWithResponse call
+func ParseListProjectPasskeyHashesResponse(rsp *http.Response) (*ListProjectPasskeyHashesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListProjectPasskeyHashesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Data []externalRef0.ProjectPasskeyHash `json:"data"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseRevokeProjectPasskeyResponse parses an HTTP response from a RevokeProjectPasskeyWithResponse call
+func ParseRevokeProjectPasskeyResponse(rsp *http.Response) (*RevokeProjectPasskeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &RevokeProjectPasskeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest struct {
+			Name *string `json:"name,omitempty"`
+		}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseListSegmentersResponse parses an HTTP response from a ListSegmentersWithResponse call
 func ParseListSegmentersResponse(rsp *http.Response) (*ListSegmentersResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
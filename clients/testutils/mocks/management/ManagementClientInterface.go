@@ -78,6 +78,66 @@ func (_m *ClientInterface) CreateExperimentWithBody(ctx context.Context, project
 	return r0, r1
 }
 
+// CreateProjectPasskey provides a mock function with given fields: ctx, projectId, body, reqEditors
+func (_m *ClientInterface) CreateProjectPasskey(ctx context.Context, projectId int64, body management.CreateProjectPasskeyJSONRequestBody, reqEditors ...management.RequestEditorFn) (*http.Response, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectId, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *http.Response
+	if rf, ok := ret.Get(0).(func(context.Context, int64, management.CreateProjectPasskeyJSONRequestBody, ...management.RequestEditorFn) *http.Response); ok {
+		r0 = rf(ctx, projectId, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, management.CreateProjectPasskeyJSONRequestBody, ...management.RequestEditorFn) error); ok {
+		r1 = rf(ctx, projectId, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProjectPasskeyWithBody provides a mock function with given fields: ctx, projectId, contentType, body, reqEditors
+func (_m *ClientInterface) CreateProjectPasskeyWithBody(ctx context.Context, projectId int64, contentType string, body io.Reader, reqEditors ...management.RequestEditorFn) (*http.Response, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectId, contentType, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *http.Response
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, io.Reader, ...management.RequestEditorFn) *http.Response); ok {
+		r0 = rf(ctx, projectId, contentType, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, io.Reader, ...management.RequestEditorFn) error); ok {
+		r1 = rf(ctx, projectId, contentType, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateProjectSettings provides a mock function with given fields: ctx, projectId, body, reqEditors
 func (_m *ClientInterface) CreateProjectSettings(ctx context.Context, projectId int64, body management.CreateProjectSettingsJSONRequestBody, reqEditors ...management.RequestEditorFn) (*http.Response, error) {
 	_va := make([]interface{}, len(reqEditors))
@@ -828,6 +888,66 @@ func (_m *ClientInterface) ListExperiments(ctx context.Context, projectId int64,
 	return r0, r1
 }
 
+// ListProjectPasskeyHashes provides a mock function with given fields: ctx, projectId, reqEditors
+func (_m *ClientInterface) ListProjectPasskeyHashes(ctx context.Context, projectId int64, reqEditors ...management.RequestEditorFn) (*http.Response, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectId)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *http.Response
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...management.RequestEditorFn) *http.Response); ok {
+		r0 = rf(ctx, projectId, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, ...management.RequestEditorFn) error); ok {
+		r1 = rf(ctx, projectId, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProjectPasskeys provides a mock function with given fields: ctx, projectId, reqEditors
+func (_m *ClientInterface) ListProjectPasskeys(ctx context.Context, projectId int64, reqEditors ...management.RequestEditorFn) (*http.Response, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectId)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *http.Response
+	if rf, ok := ret.Get(0).(func(context.Context, int64, ...management.RequestEditorFn) *http.Response); ok {
+		r0 = rf(ctx, projectId, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, ...management.RequestEditorFn) error); ok {
+		r1 = rf(ctx, projectId, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListProjects provides a mock function with given fields: ctx, reqEditors
 func (_m *ClientInterface) ListProjects(ctx context.Context, reqEditors ...management.RequestEditorFn) (*http.Response, error) {
 	_va := make([]interface{}, len(reqEditors))
@@ -1008,6 +1128,36 @@ func (_m *ClientInterface) ListTreatments(ctx context.Context, projectId int64,
 	return r0, r1
 }
 
+// RevokeProjectPasskey provides a mock function with given fields: ctx, projectId, name, reqEditors
+func (_m *ClientInterface) RevokeProjectPasskey(ctx context.Context, projectId int64, name string, reqEditors ...management.RequestEditorFn) (*http.Response, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, projectId, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *http.Response
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, ...management.RequestEditorFn) *http.Response); ok {
+		r0 = rf(ctx, projectId, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*http.Response)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, ...management.RequestEditorFn) error); ok {
+		r1 = rf(ctx, projectId, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateExperiment provides a mock function with given fields: ctx, projectId, experimentId, body, reqEditors
 func (_m *ClientInterface) UpdateExperiment(ctx context.Context, projectId int64, experimentId int64, body management.UpdateExperimentJSONRequestBody, reqEditors ...management.RequestEditorFn) (*http.Response, error) {
 	_va := make([]interface{}, len(reqEditors))
@@ -18,6 +18,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// BadRequest defines model for BadRequest.
+type BadRequest externalRef0.Error
+
 // FetchTreatmentBadRequest defines model for FetchTreatmentBadRequest.
 type FetchTreatmentBadRequest externalRef0.Error
 
@@ -26,19 +29,39 @@ type FetchTreatmentSuccess struct {
 	Data *externalRef0.SelectedTreatment `json:"data,omitempty"`
 }
 
+// FetchTreatmentTooManyRequests defines model for FetchTreatmentTooManyRequests.
+type FetchTreatmentTooManyRequests externalRef0.Error
+
 // InternalServerError defines model for InternalServerError.
 type InternalServerError externalRef0.Error
 
+// NotFound defines model for NotFound.
+type NotFound externalRef0.Error
+
 // FetchTreatmentRequestBody defines model for FetchTreatmentRequestBody.
 type FetchTreatmentRequestBody struct {
 	AdditionalProperties map[string]interface{} `json:"-"`
 }
 
+// LogExposureEventRequestBody defines model for LogExposureEventRequestBody.
+type LogExposureEventRequestBody externalRef0.ExposureEvent
+
+// LogExposureEventParams defines parameters for LogExposureEvent.
+type LogExposureEventParams struct {
+	PassKey string `json:"pass-key"`
+}
+
 // FetchTreatmentParams defines parameters for FetchTreatment.
 type FetchTreatmentParams struct {
 	PassKey string `json:"pass-key"`
+
+	// Id of the request, which is generated when not provided
+	XPRequestID *string `json:"XP-Request-ID,omitempty"`
 }
 
+// LogExposureEventJSONRequestBody defines body for LogExposureEvent for application/json ContentType.
+type LogExposureEventJSONRequestBody LogExposureEventRequestBody
+
 // FetchTreatmentJSONRequestBody defines body for FetchTreatment for application/json ContentType.
 type FetchTreatmentJSONRequestBody FetchTreatmentRequestBody
 
@@ -168,12 +191,41 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// LogExposureEvent request  with any body
+	LogExposureEventWithBody(ctx context.Context, projectId int64, params *LogExposureEventParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	LogExposureEvent(ctx context.Context, projectId int64, params *LogExposureEventParams, body LogExposureEventJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// FetchTreatment request  with any body
 	FetchTreatmentWithBody(ctx context.Context, projectId int64, params *FetchTreatmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	FetchTreatment(ctx context.Context, projectId int64, params *FetchTreatmentParams, body FetchTreatmentJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) LogExposureEventWithBody(ctx context.Context, projectId int64, params *LogExposureEventParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLogExposureEventRequestWithBody(c.Server, projectId, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) LogExposureEvent(ctx context.Context, projectId int64, params *LogExposureEventParams, body LogExposureEventJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLogExposureEventRequest(c.Server, projectId, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) FetchTreatmentWithBody(ctx context.Context, projectId int64, params *FetchTreatmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewFetchTreatmentRequestWithBody(c.Server, projectId, params, contentType, body)
 	if err != nil {
@@ -198,6 +250,62 @@ func (c *Client) FetchTreatment(ctx context.Context, projectId int64, params *Fe
 	return c.Client.Do(req)
 }
 
+// NewLogExposureEventRequest calls the generic LogExposureEvent builder with application/json body
+func NewLogExposureEventRequest(server string, projectId int64, params *LogExposureEventParams, body LogExposureEventJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewLogExposureEventRequestWithBody(server, projectId, params, "application/json", bodyReader)
+}
+
+// NewLogExposureEventRequestWithBody generates requests for LogExposureEvent with any type of body
+func NewLogExposureEventRequestWithBody(server string, projectId int64, params *LogExposureEventParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "project_id", runtime.ParamLocationPath, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/projects/%s/exposure-events", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = operationPath[1:]
+	}
+	operationURL := url.URL{
+		Path: operationPath,
+	}
+
+	queryURL := serverURL.ResolveReference(&operationURL)
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	var headerParam0 string
+
+	headerParam0, err = runtime.StyleParamWithLocation("simple", false, "pass-key", runtime.ParamLocationHeader, params.PassKey)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("pass-key", headerParam0)
+
+	return req, nil
+}
+
 // NewFetchTreatmentRequest calls the generic FetchTreatment builder with application/json body
 func NewFetchTreatmentRequest(server string, projectId int64, params *FetchTreatmentParams, body FetchTreatmentJSONRequestBody) (*http.Request, error) {
 	var bodyReader io.Reader
@@ -251,6 +359,17 @@ func NewFetchTreatmentRequestWithBody(server string, projectId int64, params *Fe
 
 	req.Header.Set("pass-key", headerParam0)
 
+	if params.XPRequestID != nil {
+		var headerParam1 string
+
+		headerParam1, err = runtime.StyleParamWithLocation("simple", false, "XP-Request-ID", runtime.ParamLocationHeader, *params.XPRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("XP-Request-ID", headerParam1)
+	}
+
 	return req, nil
 }
 
@@ -297,12 +416,41 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// LogExposureEvent request  with any body
+	LogExposureEventWithBodyWithResponse(ctx context.Context, projectId int64, params *LogExposureEventParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LogExposureEventResponse, error)
+
+	LogExposureEventWithResponse(ctx context.Context, projectId int64, params *LogExposureEventParams, body LogExposureEventJSONRequestBody, reqEditors ...RequestEditorFn) (*LogExposureEventResponse, error)
+
 	// FetchTreatment request  with any body
 	FetchTreatmentWithBodyWithResponse(ctx context.Context, projectId int64, params *FetchTreatmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*FetchTreatmentResponse, error)
 
 	FetchTreatmentWithResponse(ctx context.Context, projectId int64, params *FetchTreatmentParams, body FetchTreatmentJSONRequestBody, reqEditors ...RequestEditorFn) (*FetchTreatmentResponse, error)
 }
 
+type LogExposureEventResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON400      *externalRef0.Error
+	JSON404      *externalRef0.Error
+	JSON500      *externalRef0.Error
+}
+
+// Status returns HTTPResponse.Status
+func (r LogExposureEventResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LogExposureEventResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type FetchTreatmentResponse struct {
 	Body         []byte
 	HTTPResponse *http.Response
@@ -310,6 +458,7 @@ type FetchTreatmentResponse struct {
 		Data *externalRef0.SelectedTreatment `json:"data,omitempty"`
 	}
 	JSON400 *externalRef0.Error
+	JSON429 *externalRef0.Error
 	JSON500 *externalRef0.Error
 }
 
@@ -329,6 +478,23 @@ func (r FetchTreatmentResponse) StatusCode() int {
 	return 0
 }
 
+// LogExposureEventWithBodyWithResponse request with arbitrary body returning *LogExposureEventResponse
+func (c *ClientWithResponses) LogExposureEventWithBodyWithResponse(ctx context.Context, projectId int64, params *LogExposureEventParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*LogExposureEventResponse, error) {
+	rsp, err := c.LogExposureEventWithBody(ctx, projectId, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogExposureEventResponse(rsp)
+}
+
+func (c *ClientWithResponses) LogExposureEventWithResponse(ctx context.Context, projectId int64, params *LogExposureEventParams, body LogExposureEventJSONRequestBody, reqEditors ...RequestEditorFn) (*LogExposureEventResponse, error) {
+	rsp, err := c.LogExposureEvent(ctx, projectId, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLogExposureEventResponse(rsp)
+}
+
 // FetchTreatmentWithBodyWithResponse request with arbitrary body returning *FetchTreatmentResponse
 func (c *ClientWithResponses) FetchTreatmentWithBodyWithResponse(ctx context.Context, projectId int64, params *FetchTreatmentParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*FetchTreatmentResponse, error) {
 	rsp, err := c.FetchTreatmentWithBody(ctx, projectId, params, contentType, body, reqEditors...)
@@ -346,6 +512,46 @@ func (c *ClientWithResponses) FetchTreatmentWithResponse(ctx context.Context, pr
 	return ParseFetchTreatmentResponse(rsp)
 }
 
+// ParseLogExposureEventResponse parses an HTTP response from a LogExposureEventWithResponse call
+func ParseLogExposureEventResponse(rsp *http.Response) (*LogExposureEventResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer rsp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LogExposureEventResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseFetchTreatmentResponse parses an HTTP response from a FetchTreatmentWithResponse call
 func ParseFetchTreatmentResponse(rsp *http.Response) (*FetchTreatmentResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -376,6 +582,13 @@ func ParseFetchTreatmentResponse(rsp *http.Response) (*FetchTreatmentResponse, e
 		}
 		response.JSON400 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 429:
+		var dest externalRef0.Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON429 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
 		var dest externalRef0.Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -71,6 +71,13 @@ const (
 	ExperimentTypeSwitchback ExperimentType = "Switchback"
 )
 
+// Defines values for GuardrailComparator.
+const (
+	GuardrailComparatorGreaterThan GuardrailComparator = "greater_than"
+
+	GuardrailComparatorLessThan GuardrailComparator = "less_than"
+)
+
 // Defines values for MessageQueueKind.
 const (
 	MessageQueueKindNoop MessageQueueKind = "noop"
@@ -78,6 +85,24 @@ const (
 	MessageQueueKindPubsub MessageQueueKind = "pubsub"
 )
 
+// Defines values for OutboxMessageStatus.
+const (
+	OutboxMessageStatusFailed OutboxMessageStatus = "failed"
+
+	OutboxMessageStatusPending OutboxMessageStatus = "pending"
+
+	OutboxMessageStatusSent OutboxMessageStatus = "sent"
+)
+
+// Defines values for OutboxMessageType.
+const (
+	OutboxMessageTypeExperiment OutboxMessageType = "experiment"
+
+	OutboxMessageTypeProjectSegmenter OutboxMessageType = "project_segmenter"
+
+	OutboxMessageTypeProjectSettings OutboxMessageType = "project_settings"
+)
+
 // Defines values for SegmentField.
 const (
 	SegmentFieldId SegmentField = "id"
@@ -85,6 +110,15 @@ const (
 	SegmentFieldName SegmentField = "name"
 )
 
+// Defines values for SegmenterMatchMode.
+const (
+	SegmenterMatchModeExact SegmenterMatchMode = "exact"
+
+	SegmenterMatchModePrefix SegmenterMatchMode = "prefix"
+
+	SegmenterMatchModeRegex SegmenterMatchMode = "regex"
+)
+
 // Defines values for SegmenterScope.
 const (
 	SegmenterScopeGlobal SegmenterScope = "global"
@@ -124,6 +158,16 @@ type Constraint struct {
 	PreRequisites []PreRequisite    `json:"pre_requisites"`
 }
 
+// CreatedProjectPasskey defines model for CreatedProjectPasskey.
+type CreatedProjectPasskey struct {
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
+
+	// Plaintext value of the passkey, which is only returned when the passkey is created
+	Passkey string `json:"passkey"`
+}
+
 // Error defines model for Error.
 type Error struct {
 	Code    string `json:"code"`
@@ -133,16 +177,25 @@ type Error struct {
 
 // Experiment defines model for Experiment.
 type Experiment struct {
-	CreatedAt   *time.Time         `json:"created_at,omitempty"`
-	Description *string            `json:"description"`
-	EndTime     *time.Time         `json:"end_time,omitempty"`
-	Id          *int64             `json:"id,omitempty"`
-	Interval    *int32             `json:"interval"`
-	Name        *string            `json:"name,omitempty"`
-	ProjectId   *int64             `json:"project_id,omitempty"`
-	Segment     *ExperimentSegment `json:"segment,omitempty"`
-	StartTime   *time.Time         `json:"start_time,omitempty"`
-	Status      *ExperimentStatus  `json:"status,omitempty"`
+	CreatedAt   *time.Time             `json:"created_at,omitempty"`
+	Description *string                `json:"description"`
+	EndTime     *time.Time             `json:"end_time,omitempty"`
+	Guardrails  *[]ExperimentGuardrail `json:"guardrails,omitempty"`
+	Id          *int64                 `json:"id,omitempty"`
+	Interval    *int32                 `json:"interval"`
+	Name        *string                `json:"name,omitempty"`
+	ProjectId   *int64                 `json:"project_id,omitempty"`
+
+	// The GeoJSON geometry from which the s2_ids of the segment were computed
+	S2Covering *S2Covering `json:"s2_covering,omitempty"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
+	Segment   *ExperimentSegment `json:"segment,omitempty"`
+	StartTime *time.Time         `json:"start_time,omitempty"`
+	Status    *ExperimentStatus  `json:"status,omitempty"`
 
 	// The user-friendly classification of experiment statuses. The categories are
 	// self-explanatory. Note that the current time plays a role in the definition
@@ -156,30 +209,80 @@ type Experiment struct {
 	Version        *int64                    `json:"version,omitempty"`
 }
 
+// ExperimentAssignment defines model for ExperimentAssignment.
+type ExperimentAssignment struct {
+	ExperimentVersion int64  `json:"experiment_version"`
+	Treatment         string `json:"treatment"`
+
+	// The value of the randomization key that the treatment was assigned to
+	UnitId string `json:"unit_id"`
+}
+
 // ExperimentField defines model for ExperimentField.
 type ExperimentField string
 
+// Threshold on the mean value of a metric, per treatment. When any treatment breaches it,
+// the experiment is disabled automatically.
+type ExperimentGuardrail struct {
+
+	// The guardrail is breached when the mean value is greater than / less than the threshold
+	Comparator GuardrailComparator `json:"comparator"`
+
+	// Name of the metric, as ingested in the metric observations
+	Metric string `json:"metric"`
+
+	// Min. no. of observations of the metric for a treatment, before the guardrail is evaluated
+	MinSampleSize *int64  `json:"min_sample_size,omitempty"`
+	Threshold     float64 `json:"threshold"`
+}
+
 // ExperimentHistory defines model for ExperimentHistory.
 type ExperimentHistory struct {
-	CreatedAt    time.Time             `json:"created_at"`
-	Description  *string               `json:"description"`
-	EndTime      time.Time             `json:"end_time"`
-	ExperimentId int64                 `json:"experiment_id"`
-	Id           int64                 `json:"id"`
-	Interval     *int32                `json:"interval"`
-	Name         string                `json:"name"`
-	Segment      ExperimentSegment     `json:"segment"`
-	StartTime    time.Time             `json:"start_time"`
-	Status       ExperimentStatus      `json:"status"`
-	Tier         ExperimentTier        `json:"tier"`
-	Treatments   []ExperimentTreatment `json:"treatments"`
-	Type         ExperimentType        `json:"type"`
-	UpdatedAt    time.Time             `json:"updated_at"`
-	UpdatedBy    string                `json:"updated_by"`
-	Version      int64                 `json:"version"`
-}
-
-// ExperimentSegment defines model for ExperimentSegment.
+	CreatedAt    time.Time              `json:"created_at"`
+	Description  *string                `json:"description"`
+	EndTime      time.Time              `json:"end_time"`
+	ExperimentId int64                  `json:"experiment_id"`
+	Guardrails   *[]ExperimentGuardrail `json:"guardrails,omitempty"`
+	Id           int64                  `json:"id"`
+	Interval     *int32                 `json:"interval"`
+	Name         string                 `json:"name"`
+
+	// The GeoJSON geometry from which the s2_ids of the segment were computed
+	S2Covering *S2Covering `json:"s2_covering,omitempty"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
+	Segment    ExperimentSegment     `json:"segment"`
+	StartTime  time.Time             `json:"start_time"`
+	Status     ExperimentStatus      `json:"status"`
+	Tier       ExperimentTier        `json:"tier"`
+	Treatments []ExperimentTreatment `json:"treatments"`
+	Type       ExperimentType        `json:"type"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+	UpdatedBy  string                `json:"updated_by"`
+	Version    int64                 `json:"version"`
+}
+
+// ExperimentResult defines model for ExperimentResult.
+type ExperimentResult struct {
+	ConfidenceLevel   float64           `json:"confidence_level"`
+	ControlTreatment  string            `json:"control_treatment"`
+	CreatedAt         time.Time         `json:"created_at"`
+	Cuped             bool              `json:"cuped"`
+	ExperimentId      int64             `json:"experiment_id"`
+	ExperimentVersion int64             `json:"experiment_version"`
+	Id                int64             `json:"id"`
+	Metric            string            `json:"metric"`
+	Treatments        []TreatmentResult `json:"treatments"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+}
+
+// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+// and real segmenters may include SegmenterRange objects, which match all the values within the range.
 type ExperimentSegment map[string]interface{}
 
 // ExperimentStatus defines model for ExperimentStatus.
@@ -210,6 +313,40 @@ type ExperimentTreatment struct {
 // ExperimentType defines model for ExperimentType.
 type ExperimentType string
 
+// ExposureEvent defines model for ExposureEvent.
+type ExposureEvent struct {
+
+	// The time at which the treatment was exposed to the user, as reported by the client
+	ClientTimestamp time.Time `json:"client_timestamp"`
+	ExperimentId    int64     `json:"experiment_id"`
+
+	// The request id that was returned by Fetch Treatment, in the XP-Request-ID header
+	RequestId     string `json:"request_id"`
+	TreatmentName string `json:"treatment_name"`
+}
+
+// GuardrailBreach defines model for GuardrailBreach.
+type GuardrailBreach struct {
+
+	// The guardrail is breached when the mean value is greater than / less than the threshold
+	Comparator        GuardrailComparator `json:"comparator"`
+	CreatedAt         time.Time           `json:"created_at"`
+	ExperimentId      int64               `json:"experiment_id"`
+	ExperimentVersion int64               `json:"experiment_version"`
+	Id                int64               `json:"id"`
+	Metric            string              `json:"metric"`
+	SampleSize        int64               `json:"sample_size"`
+	Threshold         float64             `json:"threshold"`
+	Treatment         string              `json:"treatment"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+
+	// Mean value of the metric for the treatment, at the time of the breach
+	Value float64 `json:"value"`
+}
+
+// The guardrail is breached when the mean value is greater than / less than the threshold
+type GuardrailComparator string
+
 // MessageQueueConfig defines model for MessageQueueConfig.
 type MessageQueueConfig struct {
 
@@ -221,6 +358,39 @@ type MessageQueueConfig struct {
 // Kind of message queue
 type MessageQueueKind string
 
+// MetricObservation defines model for MetricObservation.
+type MetricObservation struct {
+
+	// The pre-experiment value of the metric for the unit, used for CUPED variance reduction
+	Covariate *float64 `json:"covariate"`
+	Metric    string   `json:"metric"`
+
+	// The value of the randomization key that the observation was made for
+	UnitId string  `json:"unit_id"`
+	Value  float64 `json:"value"`
+}
+
+// OutboxMessage defines model for OutboxMessage.
+type OutboxMessage struct {
+	Attempts      int32               `json:"attempts"`
+	CreatedAt     time.Time           `json:"created_at"`
+	Id            int64               `json:"id"`
+	LastError     *string             `json:"last_error"`
+	MessageType   OutboxMessageType   `json:"message_type"`
+	NextAttemptAt time.Time           `json:"next_attempt_at"`
+	ProjectId     int64               `json:"project_id"`
+	SentAt        *time.Time          `json:"sent_at"`
+	Status        OutboxMessageStatus `json:"status"`
+	UpdateType    string              `json:"update_type"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+}
+
+// OutboxMessageStatus defines model for OutboxMessageStatus.
+type OutboxMessageStatus string
+
+// OutboxMessageType defines model for OutboxMessageType.
+type OutboxMessageType string
+
 // Paging defines model for Paging.
 type Paging struct {
 
@@ -250,6 +420,29 @@ type Project struct {
 	Username         string    `json:"username"`
 }
 
+// ProjectPasskey defines model for ProjectPasskey.
+type ProjectPasskey struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// Time after which the passkey is no longer accepted. The passkey does not expire if unset.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// First 8 characters of the hex-encoded SHA-256 hash of the passkey, to tell the keys apart
+	Fingerprint string `json:"fingerprint"`
+	Name        string `json:"name"`
+}
+
+// ProjectPasskeyHash defines model for ProjectPasskeyHash.
+type ProjectPasskeyHash struct {
+
+	// Time after which the passkey is no longer accepted. The passkey does not expire if unset.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+
+	// Hex-encoded SHA-256 hash of the passkey
+	Hash string `json:"hash"`
+	Name string `json:"name"`
+}
+
 // ProjectSegmenters defines model for ProjectSegmenters.
 type ProjectSegmenters struct {
 
@@ -267,12 +460,17 @@ type ProjectSegmenters_Variables struct {
 
 // ProjectSettings defines model for ProjectSettings.
 type ProjectSettings struct {
-	CreatedAt            time.Time         `json:"created_at"`
-	EnableS2idClustering bool              `json:"enable_s2id_clustering"`
-	Passkey              string            `json:"passkey"`
-	ProjectId            int64             `json:"project_id"`
-	RandomizationKey     string            `json:"randomization_key"`
-	Segmenters           ProjectSegmenters `json:"segmenters"`
+	CreatedAt            time.Time `json:"created_at"`
+	EnableS2idClustering bool      `json:"enable_s2id_clustering"`
+
+	// Plaintext value of the default passkey, only returned when the project settings are created
+	Passkey *string `json:"passkey,omitempty"`
+
+	// Passkeys of the project that have not been revoked
+	Passkeys         *[]ProjectPasskey `json:"passkeys,omitempty"`
+	ProjectId        int64             `json:"project_id"`
+	RandomizationKey string            `json:"randomization_key"`
+	Segmenters       ProjectSegmenters `json:"segmenters"`
 
 	// Object containing information to define a valid treatment schema
 	TreatmentSchema *TreatmentSchema `json:"treatment_schema,omitempty"`
@@ -302,12 +500,56 @@ type Rule struct {
 // List of rules that define a valid treatment schema
 type Rules []Rule
 
+// The GeoJSON geometry from which the s2_ids of the segment were computed
+type S2Covering struct {
+
+	// No. of S2 cells in the covering
+	CellCount int32 `json:"cell_count"`
+
+	// Area of the covering outside of the geometry, as a fraction of the area of the geometry
+	CoverageError float64 `json:"coverage_error"`
+
+	// The GeoJSON geometry, as given in the segment
+	Geometry map[string]interface{} `json:"geometry"`
+	MaxLevel int32                  `json:"max_level"`
+	MinLevel int32                  `json:"min_level"`
+}
+
+// Chi-squared test of the no. of units assigned to each treatment against the configured traffic. A mismatch is reported when the p-value is below the configured threshold.
+type SRMCheck struct {
+	ChiSquared        float64             `json:"chi_squared"`
+	CreatedAt         time.Time           `json:"created_at"`
+	ExperimentId      int64               `json:"experiment_id"`
+	ExperimentVersion int64               `json:"experiment_version"`
+	Id                int64               `json:"id"`
+	Mismatch          bool                `json:"mismatch"`
+	PValue            float64             `json:"p_value"`
+	Treatments        []SRMTreatmentCount `json:"treatments"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+}
+
+// SRMTreatmentCount defines model for SRMTreatmentCount.
+type SRMTreatmentCount struct {
+	Count         int64   `json:"count"`
+	ExpectedCount float64 `json:"expected_count"`
+	Traffic       int32   `json:"traffic"`
+	Treatment     string  `json:"treatment"`
+}
+
 // Segment defines model for Segment.
 type Segment struct {
-	CreatedAt *time.Time         `json:"created_at,omitempty"`
-	Id        *int64             `json:"id,omitempty"`
-	Name      *string            `json:"name,omitempty"`
-	ProjectId *int64             `json:"project_id,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Id        *int64     `json:"id,omitempty"`
+	Name      *string    `json:"name,omitempty"`
+	ProjectId *int64     `json:"project_id,omitempty"`
+
+	// The GeoJSON geometry from which the s2_ids of the segment were computed
+	S2Covering *S2Covering `json:"s2_covering,omitempty"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
 	Segment   *ExperimentSegment `json:"segment,omitempty"`
 	UpdatedAt *time.Time         `json:"updated_at,omitempty"`
 	UpdatedBy *string            `json:"updated_by,omitempty"`
@@ -318,9 +560,17 @@ type SegmentField string
 
 // SegmentHistory defines model for SegmentHistory.
 type SegmentHistory struct {
-	CreatedAt time.Time         `json:"created_at"`
-	Id        int64             `json:"id"`
-	Name      string            `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	Id        int64     `json:"id"`
+	Name      string    `json:"name"`
+
+	// The GeoJSON geometry from which the s2_ids of the segment were computed
+	S2Covering *S2Covering `json:"s2_covering,omitempty"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
 	Segment   ExperimentSegment `json:"segment"`
 	SegmentId int64             `json:"segment_id"`
 	UpdatedAt time.Time         `json:"updated_at"`
@@ -330,15 +580,24 @@ type SegmentHistory struct {
 
 // Segmenter defines model for Segmenter.
 type Segmenter struct {
-	Constraints []Constraint     `json:"constraints"`
-	CreatedAt   *time.Time       `json:"created_at,omitempty"`
-	Description *string          `json:"description,omitempty"`
-	MultiValued bool             `json:"multi_valued"`
-	Name        string           `json:"name"`
-	Options     SegmenterOptions `json:"options"`
-	Required    bool             `json:"required"`
-	Scope       *SegmenterScope  `json:"scope,omitempty"`
-	Status      *SegmenterStatus `json:"status,omitempty"`
+	Constraints []Constraint `json:"constraints"`
+	CreatedAt   *time.Time   `json:"created_at,omitempty"`
+	Description *string      `json:"description,omitempty"`
+
+	// CEL expression over the fetch treatment request body, available as `request`, that computes the value of the segmenter, eg. `request.signup_days < 7`. The segmenter's value is read from the request field with the segmenter's name when the expression is not set.
+	Expression *string `json:"expression,omitempty"`
+
+	// How the values of a string segmenter are matched against the value in the treatment request. The values are exact strings by default, or prefixes or RE2 regular expressions when the prefix or regex mode is chosen.
+	MatchMode   *SegmenterMatchMode `json:"match_mode,omitempty"`
+	MultiValued bool                `json:"multi_valued"`
+	Name        string              `json:"name"`
+	Options     SegmenterOptions    `json:"options"`
+
+	// Alternative fetch treatment request fields from which the segmenter's value is read, in priority order, when the field is not named after the segmenter. Nested fields are written as dot-separated paths, eg. `user.profile.tier`. The first field present in the request is used.
+	RequestFields *[]string        `json:"request_fields,omitempty"`
+	Required      bool             `json:"required"`
+	Scope         *SegmenterScope  `json:"scope,omitempty"`
+	Status        *SegmenterStatus `json:"status,omitempty"`
 
 	// List of varying combination of variables in which this segmenter is can be derived from
 	TreatmentRequestFields [][]string    `json:"treatment_request_fields"`
@@ -349,11 +608,23 @@ type Segmenter struct {
 // SegmenterConfig defines model for SegmenterConfig.
 type SegmenterConfig map[string]interface{}
 
+// How the values of a string segmenter are matched against the value in the treatment request. The values are exact strings by default, or prefixes or RE2 regular expressions when the prefix or regex mode is chosen.
+type SegmenterMatchMode string
+
 // SegmenterOptions defines model for SegmenterOptions.
 type SegmenterOptions struct {
 	AdditionalProperties map[string]interface{} `json:"-"`
 }
 
+// A range of integer or real segmenter values. At least one of the bounds must be set, and the range is
+// unbounded on the side whose bound is not set.
+type SegmenterRange struct {
+	Max          *float64 `json:"max,omitempty"`
+	MaxExclusive *bool    `json:"max_exclusive,omitempty"`
+	Min          *float64 `json:"min,omitempty"`
+	MinExclusive *bool    `json:"min_exclusive,omitempty"`
+}
+
 // SegmenterScope defines model for SegmenterScope.
 type SegmenterScope string
 
@@ -425,6 +696,23 @@ type TreatmentHistory struct {
 	Version       int64                  `json:"version"`
 }
 
+// The statistics of the metric for a treatment. The comparison against the control (difference,
+// relative lift and p-value) is not set for the control treatment.
+type TreatmentResult struct {
+	Count             int64    `json:"count"`
+	Difference        *float64 `json:"difference,omitempty"`
+	DifferenceLower   *float64 `json:"difference_lower,omitempty"`
+	DifferenceUpper   *float64 `json:"difference_upper,omitempty"`
+	Mean              float64  `json:"mean"`
+	MeanLower         float64  `json:"mean_lower"`
+	MeanUpper         float64  `json:"mean_upper"`
+	PValue            *float64 `json:"p_value,omitempty"`
+	RelativeLift      *float64 `json:"relative_lift,omitempty"`
+	RelativeLiftLower *float64 `json:"relative_lift_lower,omitempty"`
+	RelativeLiftUpper *float64 `json:"relative_lift_upper,omitempty"`
+	Treatment         string   `json:"treatment"`
+}
+
 // Object containing information to define a valid treatment schema
 type TreatmentSchema struct {
 
@@ -547,40 +835,76 @@ func (a SegmenterOptions) MarshalJSON() ([]byte, error) {
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+waXY/jtvGvEGr75tsr0qIPfkvTpgXay12zi/QhexBoaWQzR5E6fnjjHva/F0NSEiXR",
-	"kuwzklsgTytLM8P5/uJ+ygpZN1KAMDrbfsp0cYCausdvpNBGUSYM/mqUbEAZBu4b5Vw+QZkfKbf+DTNQ",
-	"u4ffK6iybfa71z3h14Hq63vY1yAMqB883vMmM6cGsm1GlaIn/C0bw6RYT+ltgH/eZI2CXMFHyzQzFzD1",
-	"TsH3LdaUo+dN5mgqKLPtj+MzNmNNvO/w5e4nKAwS/LtSUk11WMgS8G+A10YxsUd4aOEnX2rQmu5TWCM2",
-	"He0evqWZ5O7nBhRDZSZYVEANlDl13yqpanzKSmrglWE1Ep7wWIIuFHNWQSRhOac7DtnWKAsJeBBl7mit",
-	"PoGVA1gmzF/+3MMxYWAPygGigxwpH4P/6atsc46xCF3QOm2gRknUXr6aEe29dckTe1ME93a4hipzoYa0",
-	"ocbqC47z8B1mXikGouSnS0l82+JhHDFQ6/EfmFeVQZer23S0KoAjIi1yKrP436tJIfTzJrNNeXEItDi7",
-	"U9J9jqB0iI5F33mejdhvGXDngyBsjXHPyiz4bcCbWjQYZuBYURQOJB6Y431C0p6VfzJtpDq9lBwCHePr",
-	"o/hXzzsvJ438Fvu3if24pg9dtic1DJdBKDu4zhu7zND60SgHBHN3CSIyR5dNomgeZYpI8Pk247734jmo",
-	"zve63CZoYdgRuQgP8xlpVJG2n4ZpJXs4ALEa1Ks2NZKCU61ZxQqKIERWpNc58doBfUcQsaAG9lIx0IQq",
-	"eBQaePUKfm44FRTz4B35Thog5kANMQhvlUIqqGrScHrShBIlORAmHEAJFRMMz30UsiJa1oAMmANo6M9+",
-	"9Ab2ClFWCJR647r20nJAc6NnczDuuQSnKbTLgrIeQsCWUFHLnZeHp/68/o08glKsXLJAH5KJ5ldUbG8V",
-	"bXP80DbfxJ8J1VoWDKUgT8wcnL727AiCdC6aJVyuzaND0t/RTrMp9F4Oo2hVsWJK4b8H8DaLvINpUlOD",
-	"Zti4T38gAZ0YSXZASqagQAGMHJ589yj8CEM5qaQi90/MFIcdLT6QXpHB8JNaspAxhkoOCpkPzoeQKFub",
-	"f/36r9km65lKWvyNHzP+Y8GCt9zU4B+YKJcycEznXwiPvbbd5druFoc4u7u3u3S/NCE7sSi+RZ8I8xL5",
-	"iKCR5wspm8zxgqykVPCO7vFpInYTxrWRD9p6B6r1wjY3NH5UW7TyxlHViYwmDeVEdMQ92CqKBlGXKSrQ",
-	"lpvg60zsHf8fLagTKRQzoBi9wk/94V6srJUu5aeDUX2ia93uBPKlDgrUzTcXI5FGvCROTsvnBsvbNNGr",
-	"21VFRSlr9j+XJvIPcJpX3VBp07Q5ar2uaqI0qDM2HOnZdTgzTUlLKCXlQKYZc9wPJB8aBoknIvHfTBuM",
-	"l/4AgpCufDFBAuENFv9GMamYORGpSlB32LCt1u2RKoazhN/JlSXzheTdgMU0Zx0q8vB0YIUvqxq4r1Md",
-	"51jasNC11QuLGSh2hJJUStYX8Ttk5Q1tGswhsZ7a+tiWLijjKtvLO7HWyC+8XWINzRrYGCb2+jZxBwIP",
-	"zPVXrMwLbjVmRV8aAuhOSg5U+ESu9bmAu3jLdE0czy9Gx+4fz2i5B1si0vWA9x789hkBjcxZ6aW2ii8n",
-	"jUizK5NHa6fFNHLW/En3803LtGnoy8AwYoJFfC4J3YMnQrTdxUPgNBRlw4o83RI/4LfLiaZ6re8tTxzw",
-	"NVGWh2kI7a1JQ5VLQzQafPxvZ8y+PybBzTaJxHsmbKDECS7Jxj8kMVA3nBrXvSvQOEJ7xmqrDVFgrBKE",
-	"khCkxFXrpOzjdJPFZ78/o5uZjIwq0p4VpxOYU8aqpsUZI5GGown8F2wzvox1+s13OqkoCOfNrGdTM0TA",
-	"uukm9fOt81lLR/+43rJf1sYtYj/s0vqd22SVdvVmrKuvyS1JuIJdP6lE17aJ0L/BNn56K2m5YX6sKdNt",
-	"zlnn+ozb3t5QqRN1IZc3vh3Zewe9euPd4/UL764tQr5Am7zC4J9vwE/Y/Ray3jHRbRyTfTnTw368oGKu",
-	"D08fmOqjN+TpAIJYDSWeV0jxkxUFIm7Ghwy5uKjtv2Yb3+n4+mV8ukaHRXbreSP3nbHkZhCOEe3ZoO73",
-	"Yedh3vZRkB7iBjdFCQL3rbe3hWbP5c6vVEIrOVNvOjeO8LtNe7d0nyUw3hoGkI2LyHAFsXdOo4DyeVo/",
-	"dIsZKeBtlW1/nHpYIuK7V35ZlT2/d0T9NDuziL7mIjDCOZvZajC0pIYu+/mIxTctYpxVLqbyN0dh4QZp",
-	"LEd8YCRB2r9TB1685rfayJoUt9j2X9zp/HYtsHQtcN4358LourvWiMAlHdsm051m8icmSvkU4nh62ec/",
-	"E1YSzUQBTuE72DN3izZexgcm2teR/ntO7x7FA1ZFVyDIE+OcSMFPaFgNZmy3Hk8TKkpHNmLJUIUfDPnj",
-	"1KoXXg/3XerYLCkrX3JLN0F+IRPjLzL1dYq8cO7r8M5Pfl+oHfpe6YVOeAMB4vEu/keqcb68etIb70In",
-	"WeqtA8V6aChzWYkJL5LbUskVi6Gh46h25bS0JtIT1XjUeTFAHVlx9so33Kbm7jY1LzqotVfAge7g2m4d",
-	"lXH3nQjXZ/d/WpXMtsJyjvMACNqwbJu5da85aP/l+f8BAAD//8NWob8iLQAA",
+	"H4sIAAAAAAAC/+xc73LkuHF/FRSTVOwqSnu1TpyUvq11u3dJrF1lpdipsq54GLJnBl4Q4AHgSOMtvXuq",
+	"AZAESZBDzir2re1voxmg0ehu9J8fGvqc5LKspABhdHL1OdH5HkpqP15LoY2iTBj8q1KyAmUY2N8o5/IR",
+	"iuxAee2+YQZK++EfFWyTq+QfXnWEX3mqr+5gV4IwoH7n5j2niTlWkFwlVCl6xL9lZZgUyyl98OOf06RS",
+	"kCn4qWaamRVM3Sr42Mwac/ScJpamgiK5+sNwjXQoiR/a+XLzR8gNErxWQA0Ut0riN7dU609wHMs0d8My",
+	"auW9larET0lBDVwYVkLSktZGMbFD0vBUMQV61RxBS8DRox+qjrMCdK6YFW1yldxytAJ4MsTuksgtMXsg",
+	"fnxKHvcs3xOmiRT8SBSYWgkoyOMeRDgQR/hNjvkayNky2bGUhtKJyfitUlJFZCqL+F6hGT/6pQSt6S42",
+	"a8Cipd2Nb2hGuXuqQDE02JdRe089nxNRc043HJIro2qIjAdRZJbW4hV2NVWFoowvP0fdJr9rJscOOCt6",
+	"XDBhfv0vHQdMGNiBsgOFAXWgfDj8V6+TdGrLwfRpM3fnMFvMiH6d5fIAdv4pt/T6uhmJE52TWi4479Xs",
+	"XEOVWak0baipV+jpzo1vZ2ZbxUAU/LiWxLtmHuqbgVo+/545GRs8BWUThVba230zOWZv7u/FpHD0c5rU",
+	"VbH6VDZzNseo3R1AaX9gTxrd86wTeaM124m4O4F2VLZmwUADUeZrwZoT048N93voRwVFRSFL9ieKAwi6",
+	"fbOnxv7ULkEeqSbU7gIKYuTJcNAsn8b2F/I+733fMeB2DyDqEulakj7W2Hnjo+AtunciA4/aM5WeHf8Q",
+	"MZGYj4yIVIHeS14Q6eJnCVR0QqakBKNYnpIKVCfTS/J7jLdUHLvvyEYBzfegCTPpg0BanfwwHBdMoxct",
+	"CK2NLKlhOeX8ePmAQh1G0rKiihp58nC3O7vupti4ikyPd/uelq3tNBujmjCxA22gIEwEPxG50RgUXM4X",
+	"EXDJRKZpWXHINPsTjJe7YeKSCHmJS4bE+iyQrVSEdoJMyQa2UoEd0sZGlCCgXnxKs+SUNbrtuxVZYyxr",
+	"J4i63IAanQIvwjRURkhy3vi/Z9pIdfxaMpDgoC+O1H/FWcvfQBLy98zhZTKH0GX0T1FHqn+Ce/HMjmvN",
+	"uA2PjR0NAqFXdxslA3W0ITVwMINwGWx83nl9BF3zWPUkxZYVIHLIOByAL3Kr6ECFUZJn80nPOY4xryso",
+	"AmobKTlQca5HOzudW7xCF5dHeznjaLUHyisscqzWH5CBTQ/zwOm8MAiYQ303mkrHJjQw4inrnTfYu87t",
+	"9hMQB3016YY/VaB0lyZ3WyG0qjgDTYy8JJhp69cZK7TLBTUp6ZFsgOzYAQTmTJR8B/I/7z68fxC3kh93",
+	"UhCpyE3NDWv+/oXNa94BNbUC8qr5dC05h9xm7CgqygQTO2Sm/CVhQhugRQDzKKg4zaEgm6NLKlnRbuju",
+	"NcmBc02aaIWpJ9mAeQSPBll572oFBSkZJqwFKekTsbLXbpuHVkbeUB8EDlNAeSgx3D8TOa8LIC0g+JGK",
+	"HRCnEt0wXVKT7wnlnJiO/CMze59dKpzk0t45nbahra0fBM0NO0CSJv7DfNY/KJej1VStQV005QfJOdZJ",
+	"W5a7ekpuQ/Nwzhe81HJqYCcV2gtV8CA08O0FPFWcCkwVj5fkvTTQ2VleK4VU8NCRitMjWpCSHJqcu4At",
+	"EwzXfRByS7RsM3UN3doPLn44gahaoOkkqUWSi5r7I4YZuUuSC7CSsinzvLDufT5QwJZa599+6tbrvkFz",
+	"U6w4pYH70ONHYsmuVrTJavu6uQ5/xupV5gx3Ye3IFQb2IIY+ZmRMTX43XQNFpocemW63sSrq9w3W2q/v",
+	"rN1Dkdqf/on46cRI9BsFU5AbW4D3V758EA5Wp9wWQnePzOT7Dc0/kU6QXvGjHPdEQtIXshfIvCu993lY",
+	"o/M3r36TpEnH1JTGpa4VvD3Edc0Zhgo0fW1oWcWPIv5MqPFOZAxhAC7SCRBPri1eFVRSGesg3Umzq4XS",
+	"evHSB2UMehqj8b8TVjgH8Eh1h9RvjuQdoIe87wpe7wP+9/bio5t68R/fkj3QAlTHQCRXyCZKmIEdBPyO",
+	"g/mAWDpWV8xi2gLuNxb1iB3wL8Qwzryi+bqyvgF88uKgxkmo8YwCykb0CNjTA84G8E7vOKekwShZ54od",
+	"epakp/f05RlqLzONwzvNNvsqWpegxgw76i96IJcTRHilF0CSTJOd5QBFSgV5RTho7T5biQYbaFy4n5Dh",
+	"oCRNcIL7HHPlN+6G7b9rqMEF4fHR/sREcepQh3T+C8fjZVC9yXS9OXlHXG/u6k0clx+RHckTv0Wb8leF",
+	"5CccGkhDSFkllhdkJS4CNJMPHV4Zc24Hqhg1ENdnpeAiyA3mDkUtMGWvMbDhV9f/c/v2W2KJixyIgqLO",
+	"ffAen4sJhKs7+zOO50XuGAJM18a4khZAtu4kTXqNs4HY7l7C0YoduQ+12cinm+5aua83agyUldE9PqaS",
+	"qfNC0OKowKk2WXs1fhLU9facLQHKelJosDIBTybzAli1o/V3uOh2Z1Y4uddlsGZvlx2y6ZxyK6cXCHmx",
+	"gBMIZaCbPgcBaNca31gX6+JKbONByl6BKHxZ6BNhyvhEATi2lIBQ58KCDWswhomd7n3l4YDoErd053H0",
+	"/lms/AkdVGfWCTS+p6ma7dh0yZHFkTri1aShnIiWuBu2iKLBqacpKovB+SrQAzoYfNSR5IoZUIyeUcG5",
+	"xd22kmZ3MZvoNVaNZN1qKJu+82iHvHSf2WBLA14iK8f3Z23tZS7Ulhd6YezLfL/WtOj6QhuN+3JwNk2w",
+	"7F1W9FnPNHMb0BCK7bK3pxl1/D/21w1OmwUHtgZUgA8ErW5CEi7FDhSheQ6VgcLBdM2QQgIOMsQtQtiW",
+	"1EKDuVyME2wZkq8Ui+HM75jShvw7yfdU0dzipd6D7eHpAkQuCyjI3fdvLl7/66/Jnur9qK/PSGLAY6af",
+	"AOHBiiqzoqcw3tQXsn2ysa+v1e+p3kd7Xn7GWtp7nvt8fb9MCV8sbLv6jGDveo6iL1ckEQlcv2XaIJsB",
+	"Eo8jGzzdE7YIUqWYVMwciVQFKJTZcldkK44N91lyUTCHSN72WIxz1k5FHjq1a+AO8Gw5RwvAlKKBQREV",
+	"BcUOWPooWa7idwA90KrCkBvKqcEJgxuQXknW7HekrYhqdRJKaFbBPjl6GYcocMFMv2ZFlvNam7YjYXzf",
+	"ubaf2GP5nf+Zaid2GyNN2keogunG4paPiL14r9J6xoayrSb39AD25G8ABFFwkJ+gCC1ivqm8F4wi5rK6",
+	"hDkn8C9gMXAAPRjXDVt833vnhr98CmFLa1a4XdeKn/Z8vTroJbKNSbOPHjsHFY1riy5bHBih+8H5UG+I",
+	"jgjR9aYbGRGbkRXLs/id0j3+tp5oDOH6WPPIAm+Iqrm/TkQta4LZgetQ7G4O3d9WhcHliTeuNBJwJnq2",
+	"ocAr0Cgb30lioKw4Nfb6S4HWzHZOUkPKWhvvQggl3jmRBkdd9gahXfuHCdnMRCIUkb/atzKBOWEs8ixW",
+	"GRF/EnR9RQE03xpAdiBLMOpoA1wYHF1rQb8vgTwC+lZZVrVzroMIApxnuaxj+ed712PZ9gOw5vbfM7mo",
+	"0LWjEcZoQamB8hXQtjD3lImsjWZFa/bNhlPXIrHFbNgbJv5MAxLN0CWQf5q0oxfJ2y7vboiZCGUcuyYu",
+	"6VOkn2laTtj2unz8wM6DXXd0Qh7SUNEjpcROxd3Hm+s95J8it+h7dqF/qqmCghhwhwSF4Vtya8FMrzWc",
+	"4M1DcFjojjKhzTCP8jfbl+QNKZl27R4suIjtkoeL9tZiA1w+jgg1txXRJug9yzzzSxvN/npuDL1YJ3K9",
+	"bDmYflZj2d3HmzbXuLaG+JdtLeu3iAV20ckikNk6MHW818htTy36m5y3DSxwsvGkORW1nSZLAMmZG92B",
+	"iHv9LX6RNGl8y4DXqHS6zro/I+z2lT8ne/Gu5Odpzcy8somB8X7Wiz5P+HK1/kW05Ocut6WfV7N5wL5v",
+	"I+/85aiL/Oym8LZgjXbw+SfrywNL8Mw9ElFe4G1MLL77GiWSHr39bVjDoF3ZDGVr27O6NKhp59rIAjPL",
+	"A2X2+hJzzB/9bz+mru7w2bvuWl9Hzccpgd1lO/ESk6+6ygrsCH2ov/nmVzn5tx99A3Iz5Z911/6hgDq8",
+	"zFJtWNuiG+iaI8OZDi4MWhab/TKHtSK++hCteG04zUr/rHvRHdANTrnBGTgfm6FdfJ7o0p/0CF/wnwma",
+	"Zjcrkki1+IYbUIIadpjWtJs7qtqm9BGBX9NO5E43Xtq448Jj5D2al+S9e4Pn16YKyKNixrh280KaCw22",
+	"iwgKUlGz196Qag3qslJyyzhcGgbKW8/W3km4xVHnuL+mA9tvk2nbeeLUvxx87dxQTKc6l9Vyg7mzoxff",
+	"+XfzupdMLYp2SvMBYn3E2jWX5YaJttc7CmQz3QewcyrmgOv4gjHg2RsIyh/Xy6X4Yy1ssZwOF+lzsUpV",
+	"5zyzamV8/iurOLhjB3Vne+AgZjSZ9oJNQHs2ZHXta9NjOn81vjTy9Wr3PoISt7/AIvCU+r7vXrXs3YMY",
+	"9DE3Tj98eIEk4InmxlPX2CHs4fmUSIWHd8uekAVFPr59TRTsak5V4Mp1iNfjYByqYAdPBL23tdu91CD6",
+	"7wfsqg5027InK9cdPM3ljZ2jnbwm6rX0RCRun4xEAU73lqR9huL2ED5C8RK7JG8M4UDxbImuf1TWotAO",
+	"g9wARrXUPndpX5wQph9ELew4aJ97W/DqEYXjKIyiYj/pKenTwpoOER14QvyaHaD3smJLuYY04jlLJpYS",
+	"Z2Id8ec5ldw1HrvtGOVy49pOPI4+axLjDqT2nU77ZGeWwLDvyA9JLfuWikuB0wTtYZ7W79rmFSngwza5",
+	"+sPYS0ai1uehjH+wRN0V5swzli+EjybznxIMLaihp331gMWbZuIQKFhF5VtL4cTz1uE++n3V7Q7iPjq2",
+	"4OpHQrU2siT5S7wVWl3E/v1R0alHRdO2OXeMznsIfjYkq1vJZI9MFPJxsina/UxYQTQTufvvFBvYMfsG",
+	"b9iw6Jlovg7k33F6+SDuMbNrajfO3SW8i15DvXXzdBvXApYMVfiDId+Mtbry7XqHIwzVEtPymjd+o8lf",
+	"CYr4ZwH0WkGuhPTaedOg3s9UD12+/5VicL0NhABc+K+Ohv7ybCxu+Nw/6qWwjGbasPzEP9nxr5jtwySm",
+	"pehVL/4FP/lFwbZbUCBySB+EAu5QE862xvogf7/3yyBpbh+2NDTCmBT5R0fLb1Y6XhYmyd2EDP9tpFo/",
+	"ra6qxdNKoGLF0FUs2QlrmFl3Q9goNkPFnjNn1Wb6M9fs6syLr+a6y2qoJ/2eZGeP3F3boNU/ch/s0PD/",
+	"ODDhdmK7YuSCRpT+iVBNi8upthQ92rObOr8NUAeWTz7sa96x2DdzWd6OWvrQz9PtvSZYRmUI2kQiJH6F",
+	"wk2u8P1QmsgKBK1YcpWgEBEQdb88/98AwPh9ZmdXAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
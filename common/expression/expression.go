@@ -0,0 +1,181 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/google/cel-go/cel"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+// RequestVariable is the name of the variable holding the fetch treatment request body in the expressions
+const RequestVariable = "request"
+
+// costLimit bounds the cost of evaluating an expression, so that an expression that iterates over large lists in
+// the request cannot delay the fetch treatment requests
+const costLimit = 10000
+
+// indexFunction is the name of the CEL function that indexes a map, as in request["signup_days"]
+const indexFunction = "_[_]"
+
+// outputTypes are the CEL types of the values of each type of segmenter, where integers are also accepted as real
+// values. Expressions that use the request fields as they are have the dyn type, which is converted when the
+// expression is evaluated.
+var outputTypes = map[_segmenters.SegmenterValueType][]*cel.Type{
+	_segmenters.SegmenterValueType_STRING:  {cel.StringType, cel.DynType},
+	_segmenters.SegmenterValueType_BOOL:    {cel.BoolType, cel.DynType},
+	_segmenters.SegmenterValueType_INTEGER: {cel.IntType, cel.DynType},
+	_segmenters.SegmenterValueType_REAL:    {cel.DoubleType, cel.IntType, cel.DynType},
+}
+
+// Expression is a compiled CEL expression that computes the value of a segmenter from the fetch treatment request
+type Expression struct {
+	program       cel.Program
+	segmenterType _segmenters.SegmenterValueType
+	requestFields []string
+}
+
+// Compile parses and type-checks the expression, which must evaluate to a value of the segmenter type
+func Compile(expression string, segmenterType _segmenters.SegmenterValueType) (*Expression, error) {
+	acceptedTypes, ok := outputTypes[segmenterType]
+	if !ok {
+		return nil, fmt.Errorf("expressions are not supported for %s segmenters", segmenterType)
+	}
+
+	env, err := cel.NewEnv(
+		cel.Variable(RequestVariable, cel.MapType(cel.StringType, cel.DynType)),
+		// The numbers in the JSON request are doubles, so allow comparing them with integers, eg. request.age < 18
+		cel.CrossTypeNumericComparisons(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid expression: %s", issues.Err())
+	}
+	isAccepted := false
+	for _, acceptedType := range acceptedTypes {
+		isAccepted = isAccepted || ast.OutputType().IsExactType(acceptedType)
+	}
+	if !isAccepted {
+		return nil, fmt.Errorf("expression returns %s, expected %s", ast.OutputType(), acceptedTypes[0])
+	}
+
+	checkedExpr, err := cel.AstToCheckedExpr(ast)
+	if err != nil {
+		return nil, err
+	}
+	requestFields := map[string]struct{}{}
+	collectRequestFields(checkedExpr.GetExpr(), requestFields)
+	if len(requestFields) == 0 {
+		return nil, fmt.Errorf("expression must use at least one field of the %s, eg. %s.user_id",
+			RequestVariable, RequestVariable)
+	}
+
+	program, err := env.Program(ast, cel.CostLimit(costLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []string{}
+	for field := range requestFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return &Expression{program: program, segmenterType: segmenterType, requestFields: fields}, nil
+}
+
+// RequestFields returns the names of the top-level request fields used by the expression, in sorted order. The
+// fields whose presence is only tested, as in has(request.signup_days), are not included.
+func (e *Expression) RequestFields() []string {
+	return e.requestFields
+}
+
+// Evaluate computes the value of the segmenter from the fetch treatment request
+func (e *Expression) Evaluate(request map[string]interface{}) (*_segmenters.SegmenterValue, error) {
+	out, _, err := e.program.Eval(map[string]interface{}{RequestVariable: request})
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := out.Value().(type) {
+	case string:
+		if e.segmenterType == _segmenters.SegmenterValueType_STRING {
+			return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_String_{String_: value}}, nil
+		}
+	case bool:
+		if e.segmenterType == _segmenters.SegmenterValueType_BOOL {
+			return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Bool{Bool: value}}, nil
+		}
+	case int64:
+		switch e.segmenterType {
+		case _segmenters.SegmenterValueType_INTEGER:
+			return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Integer{Integer: value}}, nil
+		case _segmenters.SegmenterValueType_REAL:
+			return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Real{Real: float64(value)}}, nil
+		}
+	case float64:
+		switch e.segmenterType {
+		case _segmenters.SegmenterValueType_INTEGER:
+			// The numbers in the JSON request are doubles, so they are accepted as integers if they are whole
+			if value == math.Trunc(value) && math.Abs(value) <= math.MaxInt64 {
+				return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Integer{Integer: int64(value)}}, nil
+			}
+		case _segmenters.SegmenterValueType_REAL:
+			return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Real{Real: value}}, nil
+		}
+	}
+	return nil, fmt.Errorf("expression returned %v, expected a %s value", out, e.segmenterType)
+}
+
+// collectRequestFields adds the names of the fields of the request that are selected or indexed with a constant
+// in the expression to the fields
+func collectRequestFields(expr *exprpb.Expr, fields map[string]struct{}) {
+	if expr == nil {
+		return
+	}
+	isRequest := func(operand *exprpb.Expr) bool {
+		return operand.GetIdentExpr().GetName() == RequestVariable
+	}
+
+	switch e := expr.GetExprKind().(type) {
+	case *exprpb.Expr_SelectExpr:
+		if isRequest(e.SelectExpr.GetOperand()) {
+			if !e.SelectExpr.GetTestOnly() {
+				fields[e.SelectExpr.GetField()] = struct{}{}
+			}
+			return
+		}
+		collectRequestFields(e.SelectExpr.GetOperand(), fields)
+	case *exprpb.Expr_CallExpr:
+		args := e.CallExpr.GetArgs()
+		if e.CallExpr.GetFunction() == indexFunction && len(args) == 2 && isRequest(args[0]) {
+			if field, ok := args[1].GetConstExpr().GetConstantKind().(*exprpb.Constant_StringValue); ok {
+				fields[field.StringValue] = struct{}{}
+			}
+		}
+		collectRequestFields(e.CallExpr.GetTarget(), fields)
+		for _, arg := range args {
+			collectRequestFields(arg, fields)
+		}
+	case *exprpb.Expr_ListExpr:
+		for _, element := range e.ListExpr.GetElements() {
+			collectRequestFields(element, fields)
+		}
+	case *exprpb.Expr_StructExpr:
+		for _, entry := range e.StructExpr.GetEntries() {
+			collectRequestFields(entry.GetMapKey(), fields)
+			collectRequestFields(entry.GetValue(), fields)
+		}
+	case *exprpb.Expr_ComprehensionExpr:
+		collectRequestFields(e.ComprehensionExpr.GetIterRange(), fields)
+		collectRequestFields(e.ComprehensionExpr.GetAccuInit(), fields)
+		collectRequestFields(e.ComprehensionExpr.GetLoopCondition(), fields)
+		collectRequestFields(e.ComprehensionExpr.GetLoopStep(), fields)
+		collectRequestFields(e.ComprehensionExpr.GetResult(), fields)
+	}
+}
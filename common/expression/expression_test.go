@@ -0,0 +1,140 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+func TestCompile(t *testing.T) {
+	tests := map[string]struct {
+		expression    string
+		segmenterType _segmenters.SegmenterValueType
+		requestFields []string
+		errString     string
+	}{
+		"success | bool": {
+			expression:    "request.signup_days < 7",
+			segmenterType: _segmenters.SegmenterValueType_BOOL,
+			requestFields: []string{"signup_days"},
+		},
+		"success | dyn": {
+			expression:    "request.city",
+			segmenterType: _segmenters.SegmenterValueType_STRING,
+			requestFields: []string{"city"},
+		},
+		"success | indexed and tested fields": {
+			expression:    `has(request.tier) ? request["order_value"] * 2.0 : request.order_value`,
+			segmenterType: _segmenters.SegmenterValueType_REAL,
+			requestFields: []string{"order_value"},
+		},
+		"success | macro": {
+			expression:    `request.items.exists(item, item.price > request.threshold)`,
+			segmenterType: _segmenters.SegmenterValueType_BOOL,
+			requestFields: []string{"items", "threshold"},
+		},
+		"failure | syntax error": {
+			expression:    "request.signup_days <",
+			segmenterType: _segmenters.SegmenterValueType_BOOL,
+			errString:     "invalid expression: ERROR: <input>:1:22: Syntax error: mismatched input '<EOF>'",
+		},
+		"failure | type mismatch": {
+			expression:    "request.signup_days < 7",
+			segmenterType: _segmenters.SegmenterValueType_INTEGER,
+			errString:     "expression returns bool, expected int",
+		},
+		"failure | undeclared variable": {
+			expression:    "signup_days < 7",
+			segmenterType: _segmenters.SegmenterValueType_BOOL,
+			errString:     "invalid expression: ERROR: <input>:1:1: undeclared reference to 'signup_days'",
+		},
+		"failure | no request fields": {
+			expression:    "has(request.signup_days)",
+			segmenterType: _segmenters.SegmenterValueType_BOOL,
+			errString:     "expression must use at least one field of the request, eg. request.user_id",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Compile(data.expression, data.segmenterType)
+			if data.errString == "" {
+				require.NoError(t, err)
+				assert.Equal(t, data.requestFields, expr.RequestFields())
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), data.errString)
+			}
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := map[string]struct {
+		expression    string
+		segmenterType _segmenters.SegmenterValueType
+		request       map[string]interface{}
+		expected      *_segmenters.SegmenterValue
+		errString     string
+	}{
+		"success | bool": {
+			expression:    "request.signup_days < 7",
+			segmenterType: _segmenters.SegmenterValueType_BOOL,
+			request:       map[string]interface{}{"signup_days": float64(3)},
+			expected:      &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Bool{Bool: true}},
+		},
+		"success | string": {
+			expression:    `request.country + "-" + request.city`,
+			segmenterType: _segmenters.SegmenterValueType_STRING,
+			request:       map[string]interface{}{"country": "ID", "city": "JK"},
+			expected:      &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_String_{String_: "ID-JK"}},
+		},
+		"success | whole number as integer": {
+			expression:    "request.age",
+			segmenterType: _segmenters.SegmenterValueType_INTEGER,
+			request:       map[string]interface{}{"age": float64(30)},
+			expected:      &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Integer{Integer: 30}},
+		},
+		"success | integer as real": {
+			expression:    "size(request.items)",
+			segmenterType: _segmenters.SegmenterValueType_REAL,
+			request:       map[string]interface{}{"items": []interface{}{"a", "b"}},
+			expected:      &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Real{Real: 2}},
+		},
+		"failure | fraction as integer": {
+			expression:    "request.age",
+			segmenterType: _segmenters.SegmenterValueType_INTEGER,
+			request:       map[string]interface{}{"age": 30.5},
+			errString:     "expression returned 30.5, expected a INTEGER value",
+		},
+		"failure | string as bool": {
+			expression:    "request.is_new",
+			segmenterType: _segmenters.SegmenterValueType_BOOL,
+			request:       map[string]interface{}{"is_new": "true"},
+			errString:     "expression returned true, expected a BOOL value",
+		},
+		"failure | missing field": {
+			expression:    "request.signup_days < 7",
+			segmenterType: _segmenters.SegmenterValueType_BOOL,
+			request:       map[string]interface{}{},
+			errString:     "no such key: signup_days",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			expr, err := Compile(data.expression, data.segmenterType)
+			require.NoError(t, err)
+			value, err := expr.Evaluate(data.request)
+			if data.errString == "" {
+				require.NoError(t, err)
+				assert.Equal(t, data.expected, value)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
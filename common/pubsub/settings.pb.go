@@ -220,25 +220,95 @@ func (x *Segmenters) GetVariables() map[string]*ExperimentVariables {
 	return nil
 }
 
+// ProjectPasskey holds the hash of a passkey that is accepted by the Fetch Treatment API,
+// until it expires, if expires_at is set
+type ProjectPasskey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Hash      string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	ExpiresAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+}
+
+func (x *ProjectPasskey) Reset() {
+	*x = ProjectPasskey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_settings_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProjectPasskey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectPasskey) ProtoMessage() {}
+
+func (x *ProjectPasskey) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_settings_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectPasskey.ProtoReflect.Descriptor instead.
+func (*ProjectPasskey) Descriptor() ([]byte, []int) {
+	return file_api_proto_settings_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProjectPasskey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProjectPasskey) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *ProjectPasskey) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
 type ProjectSettings struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	ProjectId            int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
-	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Username             string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
-	Passkey              string                 `protobuf:"bytes,5,opt,name=passkey,proto3" json:"passkey,omitempty"`
-	EnableS2IdClustering bool                   `protobuf:"varint,6,opt,name=enable_s2id_clustering,json=enableS2idClustering,proto3" json:"enable_s2id_clustering,omitempty"`
-	Segmenters           *Segmenters            `protobuf:"bytes,7,opt,name=segmenters,proto3" json:"segmenters,omitempty"`
-	RandomizationKey     string                 `protobuf:"bytes,8,opt,name=randomization_key,json=randomizationKey,proto3" json:"randomization_key,omitempty"`
+	ProjectId int64                  `protobuf:"varint,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Username  string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	// Deprecated: the plaintext passkey of the project, which is only published for the Treatment Services
+	// of the previous release, during the upgrade. Use passkeys instead.
+	//
+	// Deprecated: Do not use.
+	Passkey              string            `protobuf:"bytes,5,opt,name=passkey,proto3" json:"passkey,omitempty"`
+	EnableS2IdClustering bool              `protobuf:"varint,6,opt,name=enable_s2id_clustering,json=enableS2idClustering,proto3" json:"enable_s2id_clustering,omitempty"`
+	Segmenters           *Segmenters       `protobuf:"bytes,7,opt,name=segmenters,proto3" json:"segmenters,omitempty"`
+	RandomizationKey     string            `protobuf:"bytes,8,opt,name=randomization_key,json=randomizationKey,proto3" json:"randomization_key,omitempty"`
+	Passkeys             []*ProjectPasskey `protobuf:"bytes,9,rep,name=passkeys,proto3" json:"passkeys,omitempty"`
 }
 
 func (x *ProjectSettings) Reset() {
 	*x = ProjectSettings{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_settings_proto_msgTypes[4]
+		mi := &file_api_proto_settings_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -251,7 +321,7 @@ func (x *ProjectSettings) String() string {
 func (*ProjectSettings) ProtoMessage() {}
 
 func (x *ProjectSettings) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_settings_proto_msgTypes[4]
+	mi := &file_api_proto_settings_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -264,7 +334,7 @@ func (x *ProjectSettings) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProjectSettings.ProtoReflect.Descriptor instead.
 func (*ProjectSettings) Descriptor() ([]byte, []int) {
-	return file_api_proto_settings_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_settings_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ProjectSettings) GetProjectId() int64 {
@@ -295,6 +365,7 @@ func (x *ProjectSettings) GetUsername() string {
 	return ""
 }
 
+// Deprecated: Do not use.
 func (x *ProjectSettings) GetPasskey() string {
 	if x != nil {
 		return x.Passkey
@@ -323,6 +394,13 @@ func (x *ProjectSettings) GetRandomizationKey() string {
 	return ""
 }
 
+func (x *ProjectSettings) GetPasskeys() []*ProjectPasskey {
+	if x != nil {
+		return x.Passkeys
+	}
+	return nil
+}
+
 var File_api_proto_settings_proto protoreflect.FileDescriptor
 
 var file_api_proto_settings_proto_rawDesc = []byte{
@@ -356,32 +434,43 @@ var file_api_proto_settings_proto_rawDesc = []byte{
 	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x31, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
 	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2e, 0x45,
 	0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xf3, 0x02,
-	0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
-	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64,
-	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x02,
+	0x65, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x73, 0x0a,
+	0x0e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x50, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x39, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x73, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x73,
+	0x41, 0x74, 0x22, 0xab, 0x03, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x53, 0x65,
+	0x74, 0x74, 0x69, 0x6e, 0x67, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x6a,
+	0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x12, 0x39, 0x0a, 0x0a, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
 	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x75,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x75, 0x70, 0x64,
-	0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72, 0x6e, 0x61,
-	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x12, 0x34, 0x0a, 0x16,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x32, 0x69, 0x64, 0x5f, 0x63, 0x6c, 0x75, 0x73,
-	0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x65, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x53, 0x32, 0x69, 0x64, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x69,
-	0x6e, 0x67, 0x12, 0x32, 0x0a, 0x0a, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2e,
-	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0a, 0x73, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d,
-	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x10, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x4b, 0x65, 0x79, 0x42, 0x09, 0x5a, 0x07, 0x2f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x73, 0x65, 0x72, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x07, 0x70, 0x61, 0x73, 0x73, 0x6b,
+	0x65, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x42, 0x02, 0x18, 0x01, 0x52, 0x07, 0x70, 0x61,
+	0x73, 0x73, 0x6b, 0x65, 0x79, 0x12, 0x34, 0x0a, 0x16, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x5f,
+	0x73, 0x32, 0x69, 0x64, 0x5f, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x14, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x32, 0x69,
+	0x64, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x32, 0x0a, 0x0a, 0x73,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x52, 0x0a, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x2b, 0x0a, 0x11, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x72, 0x61, 0x6e, 0x64,
+	0x6f, 0x6d, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x12, 0x32, 0x0a, 0x08,
+	0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x2e, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x50,
+	0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x52, 0x08, 0x70, 0x61, 0x73, 0x73, 0x6b, 0x65, 0x79, 0x73,
+	0x42, 0x09, 0x5a, 0x07, 0x2f, 0x70, 0x75, 0x62, 0x73, 0x75, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -396,29 +485,32 @@ func file_api_proto_settings_proto_rawDescGZIP() []byte {
 	return file_api_proto_settings_proto_rawDescData
 }
 
-var file_api_proto_settings_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_proto_settings_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_api_proto_settings_proto_goTypes = []interface{}{
 	(*ProjectSettingsCreated)(nil), // 0: pubsub.ProjectSettingsCreated
 	(*ProjectSettingsUpdated)(nil), // 1: pubsub.ProjectSettingsUpdated
 	(*ExperimentVariables)(nil),    // 2: pubsub.ExperimentVariables
 	(*Segmenters)(nil),             // 3: pubsub.Segmenters
-	(*ProjectSettings)(nil),        // 4: pubsub.ProjectSettings
-	nil,                            // 5: pubsub.Segmenters.VariablesEntry
-	(*timestamppb.Timestamp)(nil),  // 6: google.protobuf.Timestamp
+	(*ProjectPasskey)(nil),         // 4: pubsub.ProjectPasskey
+	(*ProjectSettings)(nil),        // 5: pubsub.ProjectSettings
+	nil,                            // 6: pubsub.Segmenters.VariablesEntry
+	(*timestamppb.Timestamp)(nil),  // 7: google.protobuf.Timestamp
 }
 var file_api_proto_settings_proto_depIdxs = []int32{
-	4, // 0: pubsub.ProjectSettingsCreated.project_settings:type_name -> pubsub.ProjectSettings
-	4, // 1: pubsub.ProjectSettingsUpdated.project_settings:type_name -> pubsub.ProjectSettings
-	5, // 2: pubsub.Segmenters.variables:type_name -> pubsub.Segmenters.VariablesEntry
-	6, // 3: pubsub.ProjectSettings.created_at:type_name -> google.protobuf.Timestamp
-	6, // 4: pubsub.ProjectSettings.updated_at:type_name -> google.protobuf.Timestamp
-	3, // 5: pubsub.ProjectSettings.segmenters:type_name -> pubsub.Segmenters
-	2, // 6: pubsub.Segmenters.VariablesEntry.value:type_name -> pubsub.ExperimentVariables
-	7, // [7:7] is the sub-list for method output_type
-	7, // [7:7] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	5, // 0: pubsub.ProjectSettingsCreated.project_settings:type_name -> pubsub.ProjectSettings
+	5, // 1: pubsub.ProjectSettingsUpdated.project_settings:type_name -> pubsub.ProjectSettings
+	6, // 2: pubsub.Segmenters.variables:type_name -> pubsub.Segmenters.VariablesEntry
+	7, // 3: pubsub.ProjectPasskey.expires_at:type_name -> google.protobuf.Timestamp
+	7, // 4: pubsub.ProjectSettings.created_at:type_name -> google.protobuf.Timestamp
+	7, // 5: pubsub.ProjectSettings.updated_at:type_name -> google.protobuf.Timestamp
+	3, // 6: pubsub.ProjectSettings.segmenters:type_name -> pubsub.Segmenters
+	4, // 7: pubsub.ProjectSettings.passkeys:type_name -> pubsub.ProjectPasskey
+	2, // 8: pubsub.Segmenters.VariablesEntry.value:type_name -> pubsub.ExperimentVariables
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_settings_proto_init() }
@@ -476,6 +568,18 @@ func file_api_proto_settings_proto_init() {
 			}
 		}
 		file_api_proto_settings_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProjectPasskey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_settings_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*ProjectSettings); i {
 			case 0:
 				return &v.state
@@ -494,7 +598,7 @@ func file_api_proto_settings_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_proto_settings_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -74,6 +74,60 @@ func (SegmenterValueType) EnumDescriptor() ([]byte, []int) {
 	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{0}
 }
 
+// StringMatchMode represents how the values of a string segmenter are matched
+// against the value in the treatment request.
+type StringMatchMode int32
+
+const (
+	StringMatchMode_EXACT StringMatchMode = 0
+	// PREFIX matches the request values that start with any of the values.
+	StringMatchMode_PREFIX StringMatchMode = 1
+	// REGEX matches the request values that match any of the values, as RE2
+	// regular expressions.
+	StringMatchMode_REGEX StringMatchMode = 2
+)
+
+// Enum value maps for StringMatchMode.
+var (
+	StringMatchMode_name = map[int32]string{
+		0: "EXACT",
+		1: "PREFIX",
+		2: "REGEX",
+	}
+	StringMatchMode_value = map[string]int32{
+		"EXACT":  0,
+		"PREFIX": 1,
+		"REGEX":  2,
+	}
+)
+
+func (x StringMatchMode) Enum() *StringMatchMode {
+	p := new(StringMatchMode)
+	*p = x
+	return p
+}
+
+func (x StringMatchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StringMatchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_segmenters_proto_enumTypes[1].Descriptor()
+}
+
+func (StringMatchMode) Type() protoreflect.EnumType {
+	return &file_api_proto_segmenters_proto_enumTypes[1]
+}
+
+func (x StringMatchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StringMatchMode.Descriptor instead.
+func (StringMatchMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{1}
+}
+
 type ProjectSegmenterCreated struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -251,6 +305,8 @@ type SegmenterValue struct {
 	//	*SegmenterValue_Bool
 	//	*SegmenterValue_Integer
 	//	*SegmenterValue_Real
+	//	*SegmenterValue_IntegerRange
+	//	*SegmenterValue_RealRange
 	Value isSegmenterValue_Value `protobuf_oneof:"value"`
 }
 
@@ -321,6 +377,20 @@ func (x *SegmenterValue) GetReal() float64 {
 	return 0
 }
 
+func (x *SegmenterValue) GetIntegerRange() *IntegerRange {
+	if x, ok := x.GetValue().(*SegmenterValue_IntegerRange); ok {
+		return x.IntegerRange
+	}
+	return nil
+}
+
+func (x *SegmenterValue) GetRealRange() *RealRange {
+	if x, ok := x.GetValue().(*SegmenterValue_RealRange); ok {
+		return x.RealRange
+	}
+	return nil
+}
+
 type isSegmenterValue_Value interface {
 	isSegmenterValue_Value()
 }
@@ -341,6 +411,14 @@ type SegmenterValue_Real struct {
 	Real float64 `protobuf:"fixed64,4,opt,name=real,proto3,oneof"`
 }
 
+type SegmenterValue_IntegerRange struct {
+	IntegerRange *IntegerRange `protobuf:"bytes,5,opt,name=integer_range,json=integerRange,proto3,oneof"`
+}
+
+type SegmenterValue_RealRange struct {
+	RealRange *RealRange `protobuf:"bytes,6,opt,name=real_range,json=realRange,proto3,oneof"`
+}
+
 func (*SegmenterValue_String_) isSegmenterValue_Value() {}
 
 func (*SegmenterValue_Bool) isSegmenterValue_Value() {}
@@ -349,6 +427,160 @@ func (*SegmenterValue_Integer) isSegmenterValue_Value() {}
 
 func (*SegmenterValue_Real) isSegmenterValue_Value() {}
 
+func (*SegmenterValue_IntegerRange) isSegmenterValue_Value() {}
+
+func (*SegmenterValue_RealRange) isSegmenterValue_Value() {}
+
+// IntegerRange represents an interval of integer values, which is unbounded on
+// the sides whose bound is not set.
+type IntegerRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min *int64 `protobuf:"zigzag64,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max *int64 `protobuf:"zigzag64,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	// min_exclusive and max_exclusive represent whether the bounds are excluded
+	// from the interval.
+	MinExclusive bool `protobuf:"varint,3,opt,name=min_exclusive,json=minExclusive,proto3" json:"min_exclusive,omitempty"`
+	MaxExclusive bool `protobuf:"varint,4,opt,name=max_exclusive,json=maxExclusive,proto3" json:"max_exclusive,omitempty"`
+}
+
+func (x *IntegerRange) Reset() {
+	*x = IntegerRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_segmenters_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IntegerRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IntegerRange) ProtoMessage() {}
+
+func (x *IntegerRange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_segmenters_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IntegerRange.ProtoReflect.Descriptor instead.
+func (*IntegerRange) Descriptor() ([]byte, []int) {
+	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *IntegerRange) GetMin() int64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *IntegerRange) GetMax() int64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *IntegerRange) GetMinExclusive() bool {
+	if x != nil {
+		return x.MinExclusive
+	}
+	return false
+}
+
+func (x *IntegerRange) GetMaxExclusive() bool {
+	if x != nil {
+		return x.MaxExclusive
+	}
+	return false
+}
+
+// RealRange represents an interval of real values, which is unbounded on the
+// sides whose bound is not set.
+type RealRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Min *float64 `protobuf:"fixed64,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max *float64 `protobuf:"fixed64,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	// min_exclusive and max_exclusive represent whether the bounds are excluded
+	// from the interval.
+	MinExclusive bool `protobuf:"varint,3,opt,name=min_exclusive,json=minExclusive,proto3" json:"min_exclusive,omitempty"`
+	MaxExclusive bool `protobuf:"varint,4,opt,name=max_exclusive,json=maxExclusive,proto3" json:"max_exclusive,omitempty"`
+}
+
+func (x *RealRange) Reset() {
+	*x = RealRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_api_proto_segmenters_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RealRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RealRange) ProtoMessage() {}
+
+func (x *RealRange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_segmenters_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RealRange.ProtoReflect.Descriptor instead.
+func (*RealRange) Descriptor() ([]byte, []int) {
+	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RealRange) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *RealRange) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *RealRange) GetMinExclusive() bool {
+	if x != nil {
+		return x.MinExclusive
+	}
+	return false
+}
+
+func (x *RealRange) GetMaxExclusive() bool {
+	if x != nil {
+		return x.MaxExclusive
+	}
+	return false
+}
+
 // ListSegmenterValue is a list of SegmenterValue
 type ListSegmenterValue struct {
 	state         protoimpl.MessageState
@@ -361,7 +593,7 @@ type ListSegmenterValue struct {
 func (x *ListSegmenterValue) Reset() {
 	*x = ListSegmenterValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_segmenters_proto_msgTypes[4]
+		mi := &file_api_proto_segmenters_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -374,7 +606,7 @@ func (x *ListSegmenterValue) String() string {
 func (*ListSegmenterValue) ProtoMessage() {}
 
 func (x *ListSegmenterValue) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_segmenters_proto_msgTypes[4]
+	mi := &file_api_proto_segmenters_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -387,7 +619,7 @@ func (x *ListSegmenterValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSegmenterValue.ProtoReflect.Descriptor instead.
 func (*ListSegmenterValue) Descriptor() ([]byte, []int) {
-	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{4}
+	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ListSegmenterValue) GetValues() []*SegmenterValue {
@@ -414,7 +646,7 @@ type PreRequisite struct {
 func (x *PreRequisite) Reset() {
 	*x = PreRequisite{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_segmenters_proto_msgTypes[5]
+		mi := &file_api_proto_segmenters_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -427,7 +659,7 @@ func (x *PreRequisite) String() string {
 func (*PreRequisite) ProtoMessage() {}
 
 func (x *PreRequisite) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_segmenters_proto_msgTypes[5]
+	mi := &file_api_proto_segmenters_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -440,7 +672,7 @@ func (x *PreRequisite) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PreRequisite.ProtoReflect.Descriptor instead.
 func (*PreRequisite) Descriptor() ([]byte, []int) {
-	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{5}
+	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *PreRequisite) GetSegmenterName() string {
@@ -479,7 +711,7 @@ type Constraint struct {
 func (x *Constraint) Reset() {
 	*x = Constraint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_segmenters_proto_msgTypes[6]
+		mi := &file_api_proto_segmenters_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -492,7 +724,7 @@ func (x *Constraint) String() string {
 func (*Constraint) ProtoMessage() {}
 
 func (x *Constraint) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_segmenters_proto_msgTypes[6]
+	mi := &file_api_proto_segmenters_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +737,7 @@ func (x *Constraint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Constraint.ProtoReflect.Descriptor instead.
 func (*Constraint) Descriptor() ([]byte, []int) {
-	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{6}
+	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *Constraint) GetPreRequisites() []*PreRequisite {
@@ -541,7 +773,7 @@ type ExperimentVariables struct {
 func (x *ExperimentVariables) Reset() {
 	*x = ExperimentVariables{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_segmenters_proto_msgTypes[7]
+		mi := &file_api_proto_segmenters_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -554,7 +786,7 @@ func (x *ExperimentVariables) String() string {
 func (*ExperimentVariables) ProtoMessage() {}
 
 func (x *ExperimentVariables) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_segmenters_proto_msgTypes[7]
+	mi := &file_api_proto_segmenters_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,7 +799,7 @@ func (x *ExperimentVariables) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExperimentVariables.ProtoReflect.Descriptor instead.
 func (*ExperimentVariables) Descriptor() ([]byte, []int) {
-	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ExperimentVariables) GetValue() []string {
@@ -589,7 +821,7 @@ type ListExperimentVariables struct {
 func (x *ListExperimentVariables) Reset() {
 	*x = ListExperimentVariables{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_segmenters_proto_msgTypes[8]
+		mi := &file_api_proto_segmenters_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -602,7 +834,7 @@ func (x *ListExperimentVariables) String() string {
 func (*ListExperimentVariables) ProtoMessage() {}
 
 func (x *ListExperimentVariables) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_segmenters_proto_msgTypes[8]
+	mi := &file_api_proto_segmenters_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -615,7 +847,7 @@ func (x *ListExperimentVariables) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListExperimentVariables.ProtoReflect.Descriptor instead.
 func (*ListExperimentVariables) Descriptor() ([]byte, []int) {
-	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ListExperimentVariables) GetValues() []*ExperimentVariables {
@@ -661,12 +893,23 @@ type SegmenterConfiguration struct {
 	Required bool `protobuf:"varint,7,opt,name=required,proto3" json:"required,omitempty"`
 	// additional information about segmenter
 	Description string `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	// match_mode is the way the values of a string segmenter are matched.
+	MatchMode StringMatchMode `protobuf:"varint,9,opt,name=match_mode,json=matchMode,proto3,enum=segmenters.StringMatchMode" json:"match_mode,omitempty"`
+	// expression is an optional CEL expression over the fetch treatment request
+	// body, available as `request`, that computes the value of the segmenter.
+	// The treatment_request_fields are then the request fields that it uses.
+	Expression string `protobuf:"bytes,10,opt,name=expression,proto3" json:"expression,omitempty"`
+	// request_fields are the alternative fetch treatment request fields, in
+	// priority order, from which the value of a custom segmenter is read, when
+	// the field is not named after the segmenter. Nested fields are
+	// dot-separated paths, eg. user.profile.tier.
+	RequestFields []string `protobuf:"bytes,11,rep,name=request_fields,json=requestFields,proto3" json:"request_fields,omitempty"`
 }
 
 func (x *SegmenterConfiguration) Reset() {
 	*x = SegmenterConfiguration{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_api_proto_segmenters_proto_msgTypes[9]
+		mi := &file_api_proto_segmenters_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -679,7 +922,7 @@ func (x *SegmenterConfiguration) String() string {
 func (*SegmenterConfiguration) ProtoMessage() {}
 
 func (x *SegmenterConfiguration) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_segmenters_proto_msgTypes[9]
+	mi := &file_api_proto_segmenters_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -692,7 +935,7 @@ func (x *SegmenterConfiguration) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SegmenterConfiguration.ProtoReflect.Descriptor instead.
 func (*SegmenterConfiguration) Descriptor() ([]byte, []int) {
-	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{9}
+	return file_api_proto_segmenters_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *SegmenterConfiguration) GetName() string {
@@ -751,6 +994,27 @@ func (x *SegmenterConfiguration) GetDescription() string {
 	return ""
 }
 
+func (x *SegmenterConfiguration) GetMatchMode() StringMatchMode {
+	if x != nil {
+		return x.MatchMode
+	}
+	return StringMatchMode_EXACT
+}
+
+func (x *SegmenterConfiguration) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
+func (x *SegmenterConfiguration) GetRequestFields() []string {
+	if x != nil {
+		return x.RequestFields
+	}
+	return nil
+}
+
 var File_api_proto_segmenters_proto protoreflect.FileDescriptor
 
 var file_api_proto_segmenters_proto_rawDesc = []byte{
@@ -780,94 +1044,132 @@ var file_api_proto_segmenters_proto_rawDesc = []byte{
 	0x09, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65,
 	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d,
-	0x65, 0x22, 0x7b, 0x0a, 0x0e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x14, 0x0a,
-	0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04, 0x62,
-	0x6f, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x07, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x12, 0x48, 0x00, 0x52, 0x07, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x12,
-	0x14, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52,
-	0x04, 0x72, 0x65, 0x61, 0x6c, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x48,
-	0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56,
-	0x61, 0x6c, 0x75, 0x65, 0x12, 0x32, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72,
-	0x73, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0x80, 0x01, 0x0a, 0x0c, 0x50, 0x72, 0x65,
-	0x52, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0d, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65,
-	0x12, 0x49, 0x0a, 0x10, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x65, 0x67,
-	0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0f, 0x73, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xab, 0x02, 0x0a, 0x0a,
-	0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0e, 0x70, 0x72,
-	0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e,
-	0x50, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x52, 0x0d, 0x70, 0x72,
-	0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69, 0x74, 0x65, 0x73, 0x12, 0x45, 0x0a, 0x0e, 0x61,
-	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20,
+	0x65, 0x22, 0xf4, 0x01, 0x0a, 0x0e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x14,
+	0x0a, 0x04, 0x62, 0x6f, 0x6f, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x04,
+	0x62, 0x6f, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x07, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x12, 0x48, 0x00, 0x52, 0x07, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x65, 0x72,
+	0x12, 0x14, 0x0a, 0x04, 0x72, 0x65, 0x61, 0x6c, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00,
+	0x52, 0x04, 0x72, 0x65, 0x61, 0x6c, 0x12, 0x3f, 0x0a, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x65,
+	0x72, 0x5f, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x67,
+	0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x0c, 0x69, 0x6e, 0x74, 0x65, 0x67,
+	0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x36, 0x0a, 0x0a, 0x72, 0x65, 0x61, 0x6c, 0x5f,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x73, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x52, 0x65, 0x61, 0x6c, 0x52, 0x61, 0x6e,
+	0x67, 0x65, 0x48, 0x00, 0x52, 0x09, 0x72, 0x65, 0x61, 0x6c, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x42,
+	0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x96, 0x01, 0x0a, 0x0c, 0x49, 0x6e, 0x74,
+	0x65, 0x67, 0x65, 0x72, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x12, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01,
+	0x12, 0x15, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x12, 0x48, 0x01, 0x52,
+	0x03, 0x6d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x65,
+	0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x6d, 0x69, 0x6e, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x12, 0x23, 0x0a, 0x0d,
+	0x6d, 0x61, 0x78, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76,
+	0x65, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x69, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x61,
+	0x78, 0x22, 0x93, 0x01, 0x0a, 0x09, 0x52, 0x65, 0x61, 0x6c, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12,
+	0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x03,
+	0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a,
+	0x0d, 0x6d, 0x69, 0x6e, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x45, 0x78, 0x63, 0x6c, 0x75, 0x73, 0x69,
+	0x76, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x65, 0x78, 0x63, 0x6c, 0x75, 0x73,
+	0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6d, 0x61, 0x78, 0x45, 0x78,
+	0x63, 0x6c, 0x75, 0x73, 0x69, 0x76, 0x65, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x69, 0x6e, 0x42,
+	0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x61, 0x78, 0x22, 0x48, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x32, 0x0a,
+	0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x22, 0x80, 0x01, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x73, 0x69,
+	0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x49, 0x0a, 0x10, 0x73, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20,
 	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73,
 	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x52, 0x0d, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75,
-	0x65, 0x73, 0x12, 0x3d, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73,
-	0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x2e, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x1a, 0x56, 0x0a, 0x0c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
-	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
-	0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e,
-	0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x2b, 0x0a, 0x13, 0x45, 0x78, 0x70,
-	0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73,
-	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x52, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78,
-	0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65,
-	0x73, 0x12, 0x37, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x45,
-	0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x73, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x22, 0xfd, 0x03, 0x0a, 0x16, 0x53,
-	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x79, 0x70,
-	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e,
-	0x74, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61,
-	0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x49, 0x0a,
-	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f,
-	0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x67, 0x6d,
-	0x65, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
-	0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x75, 0x6c, 0x74,
-	0x69, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b,
-	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x64, 0x12, 0x5d, 0x0a, 0x18, 0x74,
-	0x72, 0x65, 0x61, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e,
-	0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45,
-	0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c,
-	0x65, 0x73, 0x52, 0x16, 0x74, 0x72, 0x65, 0x61, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x6f,
-	0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x43, 0x6f, 0x6e,
-	0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
-	0x69, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
-	0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
-	0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x1a, 0x56, 0x0a, 0x0c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73,
-	0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x41, 0x0a, 0x12, 0x53, 0x65,
+	0x6c, 0x75, 0x65, 0x52, 0x0f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x22, 0xab, 0x02, 0x0a, 0x0a, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61,
+	0x69, 0x6e, 0x74, 0x12, 0x3f, 0x0a, 0x0e, 0x70, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69,
+	0x73, 0x69, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x50, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x73, 0x69, 0x74, 0x65, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x69, 0x73,
+	0x69, 0x74, 0x65, 0x73, 0x12, 0x45, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0d, 0x61, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x12, 0x3d, 0x0a, 0x07, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73,
+	0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72,
+	0x61, 0x69, 0x6e, 0x74, 0x2e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x56, 0x0a, 0x0c, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x73, 0x65,
+	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x2b, 0x0a, 0x13, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e, 0x74,
+	0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0x52, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65, 0x6e,
+	0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x73, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65,
+	0x6e, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x22, 0x80, 0x05, 0x0a, 0x16, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65,
+	0x72, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x32, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x1e, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65,
 	0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65,
-	0x12, 0x0a, 0x0a, 0x06, 0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04,
-	0x42, 0x4f, 0x4f, 0x4c, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x45,
-	0x52, 0x10, 0x02, 0x12, 0x08, 0x0a, 0x04, 0x52, 0x45, 0x41, 0x4c, 0x10, 0x03, 0x42, 0x2c, 0x5a,
-	0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x61, 0x72, 0x61,
-	0x6d, 0x6c, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x78, 0x70, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e,
-	0x2f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x49, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x64, 0x12, 0x5d, 0x0a, 0x18, 0x74, 0x72, 0x65, 0x61, 0x74, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x78, 0x70, 0x65, 0x72, 0x69, 0x6d, 0x65,
+	0x6e, 0x74, 0x56, 0x61, 0x72, 0x69, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x16, 0x74, 0x72, 0x65,
+	0x61, 0x74, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x12, 0x38, 0x0a, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65,
+	0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x43, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74,
+	0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x61, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3a, 0x0a, 0x0a, 0x6d,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1b, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x6d, 0x61,
+	0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x65, 0x78, 0x70,
+	0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x0d, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x1a, 0x56,
+	0x0a, 0x0c, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x30, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x2e, 0x53, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x2a, 0x41, 0x0a, 0x12, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e,
+	0x74, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0a, 0x0a, 0x06,
+	0x53, 0x54, 0x52, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x42, 0x4f, 0x4f, 0x4c,
+	0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x54, 0x45, 0x47, 0x45, 0x52, 0x10, 0x02, 0x12,
+	0x08, 0x0a, 0x04, 0x52, 0x45, 0x41, 0x4c, 0x10, 0x03, 0x2a, 0x33, 0x0a, 0x0f, 0x53, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x09, 0x0a, 0x05,
+	0x45, 0x58, 0x41, 0x43, 0x54, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x50, 0x52, 0x45, 0x46, 0x49,
+	0x58, 0x10, 0x01, 0x12, 0x09, 0x0a, 0x05, 0x52, 0x45, 0x47, 0x45, 0x58, 0x10, 0x02, 0x42, 0x2c,
+	0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x61, 0x72,
+	0x61, 0x6d, 0x6c, 0x2d, 0x64, 0x65, 0x76, 0x2f, 0x78, 0x70, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x6f,
+	0x6e, 0x2f, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -882,43 +1184,49 @@ func file_api_proto_segmenters_proto_rawDescGZIP() []byte {
 	return file_api_proto_segmenters_proto_rawDescData
 }
 
-var file_api_proto_segmenters_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_proto_segmenters_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_api_proto_segmenters_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_proto_segmenters_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_api_proto_segmenters_proto_goTypes = []interface{}{
 	(SegmenterValueType)(0),         // 0: segmenters.SegmenterValueType
-	(*ProjectSegmenterCreated)(nil), // 1: segmenters.ProjectSegmenterCreated
-	(*ProjectSegmenterUpdated)(nil), // 2: segmenters.ProjectSegmenterUpdated
-	(*ProjectSegmenterDeleted)(nil), // 3: segmenters.ProjectSegmenterDeleted
-	(*SegmenterValue)(nil),          // 4: segmenters.SegmenterValue
-	(*ListSegmenterValue)(nil),      // 5: segmenters.ListSegmenterValue
-	(*PreRequisite)(nil),            // 6: segmenters.PreRequisite
-	(*Constraint)(nil),              // 7: segmenters.Constraint
-	(*ExperimentVariables)(nil),     // 8: segmenters.ExperimentVariables
-	(*ListExperimentVariables)(nil), // 9: segmenters.ListExperimentVariables
-	(*SegmenterConfiguration)(nil),  // 10: segmenters.SegmenterConfiguration
-	nil,                             // 11: segmenters.Constraint.OptionsEntry
-	nil,                             // 12: segmenters.SegmenterConfiguration.OptionsEntry
+	(StringMatchMode)(0),            // 1: segmenters.StringMatchMode
+	(*ProjectSegmenterCreated)(nil), // 2: segmenters.ProjectSegmenterCreated
+	(*ProjectSegmenterUpdated)(nil), // 3: segmenters.ProjectSegmenterUpdated
+	(*ProjectSegmenterDeleted)(nil), // 4: segmenters.ProjectSegmenterDeleted
+	(*SegmenterValue)(nil),          // 5: segmenters.SegmenterValue
+	(*IntegerRange)(nil),            // 6: segmenters.IntegerRange
+	(*RealRange)(nil),               // 7: segmenters.RealRange
+	(*ListSegmenterValue)(nil),      // 8: segmenters.ListSegmenterValue
+	(*PreRequisite)(nil),            // 9: segmenters.PreRequisite
+	(*Constraint)(nil),              // 10: segmenters.Constraint
+	(*ExperimentVariables)(nil),     // 11: segmenters.ExperimentVariables
+	(*ListExperimentVariables)(nil), // 12: segmenters.ListExperimentVariables
+	(*SegmenterConfiguration)(nil),  // 13: segmenters.SegmenterConfiguration
+	nil,                             // 14: segmenters.Constraint.OptionsEntry
+	nil,                             // 15: segmenters.SegmenterConfiguration.OptionsEntry
 }
 var file_api_proto_segmenters_proto_depIdxs = []int32{
-	10, // 0: segmenters.ProjectSegmenterCreated.project_segmenter:type_name -> segmenters.SegmenterConfiguration
-	10, // 1: segmenters.ProjectSegmenterUpdated.project_segmenter:type_name -> segmenters.SegmenterConfiguration
-	4,  // 2: segmenters.ListSegmenterValue.values:type_name -> segmenters.SegmenterValue
-	5,  // 3: segmenters.PreRequisite.segmenter_values:type_name -> segmenters.ListSegmenterValue
-	6,  // 4: segmenters.Constraint.pre_requisites:type_name -> segmenters.PreRequisite
-	5,  // 5: segmenters.Constraint.allowed_values:type_name -> segmenters.ListSegmenterValue
-	11, // 6: segmenters.Constraint.options:type_name -> segmenters.Constraint.OptionsEntry
-	8,  // 7: segmenters.ListExperimentVariables.values:type_name -> segmenters.ExperimentVariables
-	0,  // 8: segmenters.SegmenterConfiguration.type:type_name -> segmenters.SegmenterValueType
-	12, // 9: segmenters.SegmenterConfiguration.options:type_name -> segmenters.SegmenterConfiguration.OptionsEntry
-	9,  // 10: segmenters.SegmenterConfiguration.treatment_request_fields:type_name -> segmenters.ListExperimentVariables
-	7,  // 11: segmenters.SegmenterConfiguration.constraints:type_name -> segmenters.Constraint
-	4,  // 12: segmenters.Constraint.OptionsEntry.value:type_name -> segmenters.SegmenterValue
-	4,  // 13: segmenters.SegmenterConfiguration.OptionsEntry.value:type_name -> segmenters.SegmenterValue
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	13, // 0: segmenters.ProjectSegmenterCreated.project_segmenter:type_name -> segmenters.SegmenterConfiguration
+	13, // 1: segmenters.ProjectSegmenterUpdated.project_segmenter:type_name -> segmenters.SegmenterConfiguration
+	6,  // 2: segmenters.SegmenterValue.integer_range:type_name -> segmenters.IntegerRange
+	7,  // 3: segmenters.SegmenterValue.real_range:type_name -> segmenters.RealRange
+	5,  // 4: segmenters.ListSegmenterValue.values:type_name -> segmenters.SegmenterValue
+	8,  // 5: segmenters.PreRequisite.segmenter_values:type_name -> segmenters.ListSegmenterValue
+	9,  // 6: segmenters.Constraint.pre_requisites:type_name -> segmenters.PreRequisite
+	8,  // 7: segmenters.Constraint.allowed_values:type_name -> segmenters.ListSegmenterValue
+	14, // 8: segmenters.Constraint.options:type_name -> segmenters.Constraint.OptionsEntry
+	11, // 9: segmenters.ListExperimentVariables.values:type_name -> segmenters.ExperimentVariables
+	0,  // 10: segmenters.SegmenterConfiguration.type:type_name -> segmenters.SegmenterValueType
+	15, // 11: segmenters.SegmenterConfiguration.options:type_name -> segmenters.SegmenterConfiguration.OptionsEntry
+	12, // 12: segmenters.SegmenterConfiguration.treatment_request_fields:type_name -> segmenters.ListExperimentVariables
+	10, // 13: segmenters.SegmenterConfiguration.constraints:type_name -> segmenters.Constraint
+	1,  // 14: segmenters.SegmenterConfiguration.match_mode:type_name -> segmenters.StringMatchMode
+	5,  // 15: segmenters.Constraint.OptionsEntry.value:type_name -> segmenters.SegmenterValue
+	5,  // 16: segmenters.SegmenterConfiguration.OptionsEntry.value:type_name -> segmenters.SegmenterValue
+	17, // [17:17] is the sub-list for method output_type
+	17, // [17:17] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_segmenters_proto_init() }
@@ -976,7 +1284,7 @@ func file_api_proto_segmenters_proto_init() {
 			}
 		}
 		file_api_proto_segmenters_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListSegmenterValue); i {
+			switch v := v.(*IntegerRange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -988,7 +1296,7 @@ func file_api_proto_segmenters_proto_init() {
 			}
 		}
 		file_api_proto_segmenters_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PreRequisite); i {
+			switch v := v.(*RealRange); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1000,7 +1308,7 @@ func file_api_proto_segmenters_proto_init() {
 			}
 		}
 		file_api_proto_segmenters_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Constraint); i {
+			switch v := v.(*ListSegmenterValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1012,7 +1320,7 @@ func file_api_proto_segmenters_proto_init() {
 			}
 		}
 		file_api_proto_segmenters_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExperimentVariables); i {
+			switch v := v.(*PreRequisite); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1024,7 +1332,7 @@ func file_api_proto_segmenters_proto_init() {
 			}
 		}
 		file_api_proto_segmenters_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListExperimentVariables); i {
+			switch v := v.(*Constraint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1036,6 +1344,30 @@ func file_api_proto_segmenters_proto_init() {
 			}
 		}
 		file_api_proto_segmenters_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExperimentVariables); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_segmenters_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListExperimentVariables); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_api_proto_segmenters_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SegmenterConfiguration); i {
 			case 0:
 				return &v.state
@@ -1053,14 +1385,18 @@ func file_api_proto_segmenters_proto_init() {
 		(*SegmenterValue_Bool)(nil),
 		(*SegmenterValue_Integer)(nil),
 		(*SegmenterValue_Real)(nil),
+		(*SegmenterValue_IntegerRange)(nil),
+		(*SegmenterValue_RealRange)(nil),
 	}
+	file_api_proto_segmenters_proto_msgTypes[4].OneofWrappers = []interface{}{}
+	file_api_proto_segmenters_proto_msgTypes[5].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_api_proto_segmenters_proto_rawDesc,
-			NumEnums:      1,
-			NumMessages:   12,
+			NumEnums:      2,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExporterProtocol describes the transport used to export the spans to the OTLP collector
+type ExporterProtocol = string
+
+const (
+	// GRPCProtocol exports the spans over gRPC
+	GRPCProtocol ExporterProtocol = "grpc"
+	// HTTPProtocol exports the spans over HTTP, as protobuf
+	HTTPProtocol ExporterProtocol = "http"
+)
+
+type Config struct {
+	Enabled bool `json:"enabled" default:"false"`
+	// Endpoint is the host and port of the OTLP collector
+	Endpoint string           `json:"endpoint" default:"localhost:4317"`
+	Protocol ExporterProtocol `json:"protocol" default:"grpc"`
+	// Insecure disables TLS when connecting to the collector
+	Insecure bool `json:"insecure" default:"true"`
+	// SampleRatio is the fraction of the new traces that are sampled. Traces started upstream
+	// follow the sampling decision of the parent.
+	SampleRatio float64 `json:"sample_ratio" default:"1"`
+}
+
+// InitTracer registers a global tracer provider that exports the spans of the service to the
+// configured OTLP collector, and the W3C trace context and baggage propagators. The returned
+// function flushes the queued spans and should be called on shut down.
+func InitTracer(serviceName string, cfg Config) (func(context.Context) error, error) {
+	exporter, err := newExporter(context.Background(), cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (*otlptrace.Exporter, error) {
+	switch cfg.Protocol {
+	case GRPCProtocol:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case HTTPProtocol:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+	return nil, fmt.Errorf("invalid tracing exporter protocol (%s) was provided", cfg.Protocol)
+}
+
+// EndSpan records the error on the span, if any, and ends it
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
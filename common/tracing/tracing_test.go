@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestInitTracer(t *testing.T) {
+	tests := map[string]struct {
+		cfg Config
+		err string
+	}{
+		"success | grpc": {
+			cfg: Config{Enabled: true, Endpoint: "localhost:4317", Protocol: GRPCProtocol, Insecure: true, SampleRatio: 1},
+		},
+		"success | http": {
+			cfg: Config{Enabled: true, Endpoint: "localhost:4318", Protocol: HTTPProtocol, SampleRatio: 0.5},
+		},
+		"failure | invalid protocol": {
+			cfg: Config{Enabled: true, Endpoint: "localhost:4317", Protocol: "udp"},
+			err: "invalid tracing exporter protocol (udp) was provided",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			shutdown, err := InitTracer("xp-test", data.cfg)
+			if data.err != "" {
+				assert.EqualError(t, err, data.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.ElementsMatch(t,
+				[]string{"traceparent", "tracestate", "baggage"},
+				otel.GetTextMapPropagator().Fields(),
+			)
+
+			// Propagate the trace context of a span to the carrier
+			ctx, span := otel.Tracer("xp-test").Start(context.Background(), "test")
+			carrier := propagation.MapCarrier{}
+			otel.GetTextMapPropagator().Inject(ctx, carrier)
+			span.End()
+			if span.SpanContext().IsSampled() {
+				assert.Contains(t, carrier.Get("traceparent"), span.SpanContext().TraceID().String())
+			}
+
+			// The exporter connects lazily, shut down without waiting for the collector
+			cancelledCtx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_ = shutdown(cancelledCtx)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DatesSegmenter is the name of the segmenter whose values are calendar dates, date ranges or named calendars
+const DatesSegmenter = "dates"
+
+const (
+	// DateLayout is the format of the dates, eg. 2024-12-25
+	DateLayout = "2006-01-02"
+	// dateRangeSeparator separates the first and last dates of a date range, eg. 2024-12-20/2024-12-31
+	dateRangeSeparator = "/"
+	// MaxDateRangeDays is the max no. of days in a date range. Longer periods are targeted by the start and end
+	// times of the experiments.
+	MaxDateRangeDays = 366
+)
+
+// calendarNameRegex matches the names of calendars, which cannot be mistaken for dates since they start with a letter
+var calendarNameRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// Calendars maps the names of calendars, such as public holidays, to their dates
+type Calendars map[string][]string
+
+// LoadCalendars reads the calendars from YAML or JSON files, each of which maps the names of calendars to the lists
+// of their dates. A calendar may not be defined in more than one file.
+func LoadCalendars(paths []string) (Calendars, error) {
+	calendars := Calendars{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read calendar file %s: %s", path, err)
+		}
+		var fileCalendars map[string][]string
+		if err := yaml.Unmarshal(data, &fileCalendars); err != nil {
+			return nil, fmt.Errorf("failed to parse calendar file %s: %s", path, err)
+		}
+		for name, dates := range fileCalendars {
+			if _, ok := calendars[name]; ok {
+				return nil, fmt.Errorf("calendar %s is defined more than once", name)
+			}
+			if !IsCalendarName(name) {
+				return nil, fmt.Errorf("invalid calendar name %q", name)
+			}
+			for _, date := range dates {
+				if _, err := ParseDate(date); err != nil {
+					return nil, fmt.Errorf("invalid date in calendar %s: %s", name, err)
+				}
+			}
+			calendars[name] = dates
+		}
+	}
+	return calendars, nil
+}
+
+// Contains returns the names of the calendars that contain the date, in sorted order
+func (c Calendars) Contains(date string) []string {
+	names := []string{}
+	for name, dates := range c {
+		for _, d := range dates {
+			if d == date {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsCalendarName checks if a value of the dates segmenter is the name of a calendar, rather than a date or a
+// date range
+func IsCalendarName(value string) bool {
+	return calendarNameRegex.MatchString(value)
+}
+
+// ParseDate parses a date in the format YYYY-MM-DD
+func ParseDate(value string) (time.Time, error) {
+	date, err := time.Parse(DateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+// ExpandDateValue returns the dates of a date, or of an inclusive date range in the format
+// YYYY-MM-DD/YYYY-MM-DD. The names of calendars are returned as they are.
+func ExpandDateValue(value string) ([]string, error) {
+	if IsCalendarName(value) {
+		return []string{value}, nil
+	}
+	if !strings.Contains(value, dateRangeSeparator) {
+		if _, err := ParseDate(value); err != nil {
+			return nil, err
+		}
+		return []string{value}, nil
+	}
+
+	bounds := strings.Split(value, dateRangeSeparator)
+	if len(bounds) != 2 {
+		return nil, fmt.Errorf("invalid date range %q", value)
+	}
+	start, err := ParseDate(bounds[0])
+	if err != nil {
+		return nil, fmt.Errorf("invalid date range %q: %s", value, err)
+	}
+	end, err := ParseDate(bounds[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid date range %q: %s", value, err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("invalid date range %q: the last date is before the first", value)
+	}
+	if end.Sub(start).Hours()/24 >= MaxDateRangeDays {
+		return nil, fmt.Errorf("invalid date range %q: a date range cannot span more than %d days",
+			value, MaxDateRangeDays)
+	}
+
+	dates := []string{}
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date.Format(DateLayout))
+	}
+	return dates, nil
+}
+
+// ExpandDateValues returns the dates of the dates and date ranges, and the names of the calendars, de-duplicated
+// and in sorted order. Invalid values are skipped, since the values are validated when they are saved.
+func ExpandDateValues(values []string) []string {
+	expanded := map[string]struct{}{}
+	for _, value := range values {
+		dates, err := ExpandDateValue(value)
+		if err != nil {
+			continue
+		}
+		for _, date := range dates {
+			expanded[date] = struct{}{}
+		}
+	}
+	dates := []string{}
+	for date := range expanded {
+		dates = append(dates, date)
+	}
+	sort.Strings(dates)
+	return dates
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandDateValue(t *testing.T) {
+	tests := map[string]struct {
+		value     string
+		expected  []string
+		errString string
+	}{
+		"success | date": {
+			value:    "2024-12-25",
+			expected: []string{"2024-12-25"},
+		},
+		"success | date range across months": {
+			value:    "2024-02-28/2024-03-01",
+			expected: []string{"2024-02-28", "2024-02-29", "2024-03-01"},
+		},
+		"success | calendar": {
+			value:    "id_public_holidays",
+			expected: []string{"id_public_holidays"},
+		},
+		"failure | invalid date": {
+			value:     "2024-13-01",
+			errString: "invalid date \"2024-13-01\"",
+		},
+		"failure | invalid date range": {
+			value:     "2024-12-20/2024-12-25/2024-12-31",
+			errString: "invalid date range \"2024-12-20/2024-12-25/2024-12-31\"",
+		},
+		"failure | reversed date range": {
+			value:     "2024-12-31/2024-12-20",
+			errString: "invalid date range \"2024-12-31/2024-12-20\": the last date is before the first",
+		},
+		"failure | date range too long": {
+			value:     "2024-01-01/2025-01-01",
+			errString: "invalid date range \"2024-01-01/2025-01-01\": a date range cannot span more than 366 days",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			dates, err := ExpandDateValue(data.value)
+			if data.errString == "" {
+				require.NoError(t, err)
+				assert.Equal(t, data.expected, dates)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
+
+func TestExpandDateValues(t *testing.T) {
+	dates := ExpandDateValues([]string{"2024-12-31/2025-01-01", "2024-12-31", "new_year", "invalid/"})
+	assert.Equal(t, []string{"2024-12-31", "2025-01-01", "new_year"}, dates)
+}
+
+func TestLoadCalendars(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name string, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+	holidays := writeFile("holidays.yaml", "id_public_holidays:\n  - 2024-12-25\n  - 2025-01-01\n")
+	campaigns := writeFile("campaigns.json", `{"payday_sale": ["2024-12-25", "2025-01-25"]}`)
+	duplicate := writeFile("duplicate.yaml", "payday_sale:\n  - 2024-12-26\n")
+	invalidDate := writeFile("invalid_date.yaml", "payday_sale:\n  - 2024-12-32\n")
+	invalidName := writeFile("invalid_name.yaml", "\"2024\":\n  - 2024-12-25\n")
+
+	calendars, err := LoadCalendars([]string{holidays, campaigns})
+	require.NoError(t, err)
+	assert.Equal(t, Calendars{
+		"id_public_holidays": {"2024-12-25", "2025-01-01"},
+		"payday_sale":        {"2024-12-25", "2025-01-25"},
+	}, calendars)
+	assert.Equal(t, []string{"id_public_holidays", "payday_sale"}, calendars.Contains("2024-12-25"))
+	assert.Equal(t, []string{}, calendars.Contains("2024-12-26"))
+
+	_, err = LoadCalendars([]string{campaigns, duplicate})
+	assert.EqualError(t, err, "calendar payday_sale is defined more than once")
+	_, err = LoadCalendars([]string{invalidDate})
+	assert.EqualError(t, err, "invalid date in calendar payday_sale: invalid date \"2024-12-32\"")
+	_, err = LoadCalendars([]string{invalidName})
+	assert.EqualError(t, err, "invalid calendar name \"2024\"")
+	_, err = LoadCalendars([]string{filepath.Join(dir, "missing.yaml")})
+	assert.ErrorContains(t, err, "failed to read calendar file")
+}
@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// HashPasskey returns the hex-encoded SHA-256 hash of the passkey. The passkeys are randomly generated
+// with enough entropy that a fast, unsalted hash is sufficient.
+func HashPasskey(passkey string) string {
+	hash := sha256.Sum256([]byte(passkey))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,12 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashPasskey(t *testing.T) {
+	assert.Equal(t, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", HashPasskey("test"))
+	assert.NotEqual(t, HashPasskey("test"), HashPasskey("test2"))
+}
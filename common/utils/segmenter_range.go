@@ -0,0 +1,360 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+const (
+	rangeMinKey          = "min"
+	rangeMaxKey          = "max"
+	rangeMinExclusiveKey = "min_exclusive"
+	rangeMaxExclusiveKey = "max_exclusive"
+)
+
+// NewSegmenterRange creates an integer or real range from its JSON representation, an object with the optional
+// bounds min and max (at least one of which must be set) and the optional flags min_exclusive and max_exclusive
+func NewSegmenterRange(
+	value map[string]interface{},
+	valueType _segmenters.SegmenterValueType,
+) (*_segmenters.SegmenterValue, error) {
+	for key := range value {
+		switch key {
+		case rangeMinKey, rangeMaxKey, rangeMinExclusiveKey, rangeMaxExclusiveKey:
+		default:
+			return nil, fmt.Errorf("unknown range field %q", key)
+		}
+	}
+	min, err := getRangeBound(value, rangeMinKey)
+	if err != nil {
+		return nil, err
+	}
+	max, err := getRangeBound(value, rangeMaxKey)
+	if err != nil {
+		return nil, err
+	}
+	minExclusive, err := getRangeFlag(value, rangeMinExclusiveKey)
+	if err != nil {
+		return nil, err
+	}
+	maxExclusive, err := getRangeFlag(value, rangeMaxExclusiveKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch valueType {
+	case _segmenters.SegmenterValueType_INTEGER:
+		var intMin, intMax *int64
+		for _, bound := range []struct {
+			key   string
+			value *float64
+			dest  **int64
+		}{{rangeMinKey, min, &intMin}, {rangeMaxKey, max, &intMax}} {
+			if bound.value == nil {
+				continue
+			}
+			if *bound.value != math.Trunc(*bound.value) {
+				return nil, fmt.Errorf("range %s of an integer range must be a whole number", bound.key)
+			}
+			intVal := int64(*bound.value)
+			*bound.dest = &intVal
+		}
+		return newIntegerRange(intMin, intMax, minExclusive, maxExclusive)
+	case _segmenters.SegmenterValueType_REAL:
+		return newRealRange(min, max, minExclusive, maxExclusive)
+	default:
+		return nil, errors.New("ranges are only supported for integer and real values")
+	}
+}
+
+// IsSegmenterRange checks if the string represents a range in the interval notation
+func IsSegmenterRange(value string) bool {
+	return strings.HasPrefix(value, "[") || strings.HasPrefix(value, "(")
+}
+
+// ParseSegmenterRange parses an integer or real range in the interval notation, where square brackets mark the
+// inclusive bounds and parentheses the exclusive ones, and a missing bound leaves the range unbounded on that side,
+// eg. [18,25), (50,) or (,10]
+func ParseSegmenterRange(value string, valueType _segmenters.SegmenterValueType) (*_segmenters.SegmenterValue, error) {
+	errInvalid := fmt.Errorf("invalid range %q", value)
+	if !IsSegmenterRange(value) || !(strings.HasSuffix(value, "]") || strings.HasSuffix(value, ")")) {
+		return nil, errInvalid
+	}
+	bounds := strings.Split(value[1:len(value)-1], ",")
+	if len(bounds) != 2 {
+		return nil, errInvalid
+	}
+	minStr, maxStr := strings.TrimSpace(bounds[0]), strings.TrimSpace(bounds[1])
+	minExclusive := strings.HasPrefix(value, "(")
+	maxExclusive := strings.HasSuffix(value, ")")
+
+	switch valueType {
+	case _segmenters.SegmenterValueType_INTEGER:
+		var min, max *int64
+		for _, bound := range []struct {
+			value string
+			dest  **int64
+		}{{minStr, &min}, {maxStr, &max}} {
+			if bound.value == "" {
+				continue
+			}
+			intVal, err := strconv.ParseInt(bound.value, 10, 64)
+			if err != nil {
+				return nil, errInvalid
+			}
+			*bound.dest = &intVal
+		}
+		return newIntegerRange(min, max, minExclusive, maxExclusive)
+	case _segmenters.SegmenterValueType_REAL:
+		var min, max *float64
+		for _, bound := range []struct {
+			value string
+			dest  **float64
+		}{{minStr, &min}, {maxStr, &max}} {
+			if bound.value == "" {
+				continue
+			}
+			floatVal, err := strconv.ParseFloat(bound.value, 64)
+			if err != nil {
+				return nil, errInvalid
+			}
+			*bound.dest = &floatVal
+		}
+		return newRealRange(min, max, minExclusive, maxExclusive)
+	default:
+		return nil, errors.New("ranges are only supported for integer and real values")
+	}
+}
+
+// FormatSegmenterRange formats an integer or real range in the interval notation, returning an empty string
+// for the other segmenter values
+func FormatSegmenterRange(value *_segmenters.SegmenterValue) string {
+	switch value.Value.(type) {
+	case *_segmenters.SegmenterValue_IntegerRange:
+		r := value.GetIntegerRange()
+		min, max := "", ""
+		if r.Min != nil {
+			min = strconv.FormatInt(r.GetMin(), 10)
+		}
+		if r.Max != nil {
+			max = strconv.FormatInt(r.GetMax(), 10)
+		}
+		return formatInterval(min, max, r.GetMinExclusive(), r.GetMaxExclusive())
+	case *_segmenters.SegmenterValue_RealRange:
+		r := value.GetRealRange()
+		min, max := "", ""
+		if r.Min != nil {
+			min = strconv.FormatFloat(r.GetMin(), 'f', -1, 64)
+		}
+		if r.Max != nil {
+			max = strconv.FormatFloat(r.GetMax(), 'f', -1, 64)
+		}
+		return formatInterval(min, max, r.GetMinExclusive(), r.GetMaxExclusive())
+	default:
+		return ""
+	}
+}
+
+// SegmenterRangeToMap converts an integer or real range to its JSON representation, returning nil for the other
+// segmenter values
+func SegmenterRangeToMap(value *_segmenters.SegmenterValue) map[string]interface{} {
+	rangeMap := map[string]interface{}{}
+	switch value.Value.(type) {
+	case *_segmenters.SegmenterValue_IntegerRange:
+		r := value.GetIntegerRange()
+		if r.Min != nil {
+			rangeMap[rangeMinKey] = r.GetMin()
+		}
+		if r.Max != nil {
+			rangeMap[rangeMaxKey] = r.GetMax()
+		}
+		rangeMap[rangeMinExclusiveKey] = r.GetMinExclusive()
+		rangeMap[rangeMaxExclusiveKey] = r.GetMaxExclusive()
+	case *_segmenters.SegmenterValue_RealRange:
+		r := value.GetRealRange()
+		if r.Min != nil {
+			rangeMap[rangeMinKey] = r.GetMin()
+		}
+		if r.Max != nil {
+			rangeMap[rangeMaxKey] = r.GetMax()
+		}
+		rangeMap[rangeMinExclusiveKey] = r.GetMinExclusive()
+		rangeMap[rangeMaxExclusiveKey] = r.GetMaxExclusive()
+	default:
+		return nil
+	}
+	return rangeMap
+}
+
+// IntegerRangeContains checks if the value is within the integer range
+func IntegerRangeContains(r *_segmenters.IntegerRange, value int64) bool {
+	min, max := integerRangeBounds(r)
+	return min <= value && value <= max
+}
+
+// RealRangeContains checks if the value is within the real range
+func RealRangeContains(r *_segmenters.RealRange, value float64) bool {
+	if r.Min != nil && (value < r.GetMin() || (r.GetMinExclusive() && value == r.GetMin())) {
+		return false
+	}
+	if r.Max != nil && (value > r.GetMax() || (r.GetMaxExclusive() && value == r.GetMax())) {
+		return false
+	}
+	return true
+}
+
+// SegmenterValuesOverlap checks if the two segmenter values have any value in common. Integer and real values are
+// compared as single-value ranges, so that they can be compared with the ranges of the same type.
+func SegmenterValuesOverlap(value *_segmenters.SegmenterValue, other *_segmenters.SegmenterValue) bool {
+	if r, otherRange := toIntegerRange(value), toIntegerRange(other); r != nil && otherRange != nil {
+		min, max := integerRangeBounds(r)
+		otherMin, otherMax := integerRangeBounds(otherRange)
+		return min <= otherMax && otherMin <= max
+	}
+	if r, otherRange := toRealRange(value), toRealRange(other); r != nil && otherRange != nil {
+		return !realRangeIsBelow(r, otherRange) && !realRangeIsBelow(otherRange, r)
+	}
+	return proto.Equal(value, other)
+}
+
+func newIntegerRange(min *int64, max *int64, minExclusive bool, maxExclusive bool) (*_segmenters.SegmenterValue, error) {
+	if min == nil && max == nil {
+		return nil, errors.New("a range must have a min or a max bound")
+	}
+	// The unbounded sides are stored as inclusive, so that equal ranges have the same representation
+	r := &_segmenters.IntegerRange{
+		Min:          min,
+		Max:          max,
+		MinExclusive: min != nil && minExclusive,
+		MaxExclusive: max != nil && maxExclusive,
+	}
+	value := &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_IntegerRange{IntegerRange: r}}
+	if rangeMin, rangeMax := integerRangeBounds(r); rangeMin > rangeMax {
+		return nil, fmt.Errorf("range %s is empty", FormatSegmenterRange(value))
+	}
+	return value, nil
+}
+
+func newRealRange(min *float64, max *float64, minExclusive bool, maxExclusive bool) (*_segmenters.SegmenterValue, error) {
+	if min == nil && max == nil {
+		return nil, errors.New("a range must have a min or a max bound")
+	}
+	r := &_segmenters.RealRange{
+		Min:          min,
+		Max:          max,
+		MinExclusive: min != nil && minExclusive,
+		MaxExclusive: max != nil && maxExclusive,
+	}
+	value := &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_RealRange{RealRange: r}}
+	if min != nil && max != nil && (*min > *max || (*min == *max && (r.MinExclusive || r.MaxExclusive))) {
+		return nil, fmt.Errorf("range %s is empty", FormatSegmenterRange(value))
+	}
+	return value, nil
+}
+
+// integerRangeBounds returns the inclusive bounds of the integer range, where the min is greater than the max
+// if the range is empty
+func integerRangeBounds(r *_segmenters.IntegerRange) (int64, int64) {
+	min, max := int64(math.MinInt64), int64(math.MaxInt64)
+	if r.Min != nil {
+		min = r.GetMin()
+		if r.GetMinExclusive() {
+			if min == math.MaxInt64 {
+				return 1, 0
+			}
+			min++
+		}
+	}
+	if r.Max != nil {
+		max = r.GetMax()
+		if r.GetMaxExclusive() {
+			if max == math.MinInt64 {
+				return 1, 0
+			}
+			max--
+		}
+	}
+	return min, max
+}
+
+// realRangeIsBelow checks if all the values of the real range are less than those of the other
+func realRangeIsBelow(r *_segmenters.RealRange, other *_segmenters.RealRange) bool {
+	if r.Max == nil || other.Min == nil {
+		return false
+	}
+	return r.GetMax() < other.GetMin() ||
+		(r.GetMax() == other.GetMin() && (r.GetMaxExclusive() || other.GetMinExclusive()))
+}
+
+func toIntegerRange(value *_segmenters.SegmenterValue) *_segmenters.IntegerRange {
+	switch value.Value.(type) {
+	case *_segmenters.SegmenterValue_Integer:
+		intVal := value.GetInteger()
+		return &_segmenters.IntegerRange{Min: &intVal, Max: &intVal}
+	case *_segmenters.SegmenterValue_IntegerRange:
+		return value.GetIntegerRange()
+	default:
+		return nil
+	}
+}
+
+func toRealRange(value *_segmenters.SegmenterValue) *_segmenters.RealRange {
+	switch value.Value.(type) {
+	case *_segmenters.SegmenterValue_Real:
+		floatVal := value.GetReal()
+		return &_segmenters.RealRange{Min: &floatVal, Max: &floatVal}
+	case *_segmenters.SegmenterValue_RealRange:
+		return value.GetRealRange()
+	default:
+		return nil
+	}
+}
+
+func formatInterval(min string, max string, minExclusive bool, maxExclusive bool) string {
+	left, right := "[", "]"
+	if min == "" || minExclusive {
+		left = "("
+	}
+	if max == "" || maxExclusive {
+		right = ")"
+	}
+	return fmt.Sprintf("%s%s,%s%s", left, min, max, right)
+}
+
+func getRangeBound(value map[string]interface{}, key string) (*float64, error) {
+	bound, ok := value[key]
+	if !ok || bound == nil {
+		return nil, nil
+	}
+	switch boundVal := bound.(type) {
+	case float64:
+		return &boundVal, nil
+	case int64:
+		floatVal := float64(boundVal)
+		return &floatVal, nil
+	case int:
+		floatVal := float64(boundVal)
+		return &floatVal, nil
+	default:
+		return nil, fmt.Errorf("range %s must be a number", key)
+	}
+}
+
+func getRangeFlag(value map[string]interface{}, key string) (bool, error) {
+	flag, ok := value[key]
+	if !ok || flag == nil {
+		return false, nil
+	}
+	boolVal, ok := flag.(bool)
+	if !ok {
+		return false, fmt.Errorf("range %s must be a boolean", key)
+	}
+	return boolVal, nil
+}
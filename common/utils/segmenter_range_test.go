@@ -0,0 +1,245 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+func newTestIntegerRange(min *int64, max *int64, minExclusive bool, maxExclusive bool) *_segmenters.SegmenterValue {
+	return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_IntegerRange{
+		IntegerRange: &_segmenters.IntegerRange{Min: min, Max: max, MinExclusive: minExclusive, MaxExclusive: maxExclusive},
+	}}
+}
+
+func newTestRealRange(min *float64, max *float64, minExclusive bool, maxExclusive bool) *_segmenters.SegmenterValue {
+	return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_RealRange{
+		RealRange: &_segmenters.RealRange{Min: min, Max: max, MinExclusive: minExclusive, MaxExclusive: maxExclusive},
+	}}
+}
+
+func TestNewSegmenterRange(t *testing.T) {
+	tests := map[string]struct {
+		value     map[string]interface{}
+		valueType _segmenters.SegmenterValueType
+		expected  *_segmenters.SegmenterValue
+		errString string
+	}{
+		"success | integer range": {
+			value:     map[string]interface{}{"min": float64(18), "max": float64(24)},
+			valueType: _segmenters.SegmenterValueType_INTEGER,
+			expected:  newTestIntegerRange(proto.Int64(18), proto.Int64(24), false, false),
+		},
+		"success | open-ended real range": {
+			value:     map[string]interface{}{"min": 50.5, "min_exclusive": true, "max_exclusive": true},
+			valueType: _segmenters.SegmenterValueType_REAL,
+			expected:  newTestRealRange(proto.Float64(50.5), nil, true, false),
+		},
+		"failure | no bounds": {
+			value:     map[string]interface{}{"min_exclusive": true},
+			valueType: _segmenters.SegmenterValueType_INTEGER,
+			errString: "a range must have a min or a max bound",
+		},
+		"failure | unknown field": {
+			value:     map[string]interface{}{"min": float64(1), "step": float64(1)},
+			valueType: _segmenters.SegmenterValueType_INTEGER,
+			errString: "unknown range field \"step\"",
+		},
+		"failure | fractional integer bound": {
+			value:     map[string]interface{}{"max": 1.5},
+			valueType: _segmenters.SegmenterValueType_INTEGER,
+			errString: "range max of an integer range must be a whole number",
+		},
+		"failure | invalid bound": {
+			value:     map[string]interface{}{"min": "1"},
+			valueType: _segmenters.SegmenterValueType_REAL,
+			errString: "range min must be a number",
+		},
+		"failure | empty integer range": {
+			value:     map[string]interface{}{"min": float64(1), "max": float64(2), "min_exclusive": true, "max_exclusive": true},
+			valueType: _segmenters.SegmenterValueType_INTEGER,
+			errString: "range (1,2) is empty",
+		},
+		"failure | empty real range": {
+			value:     map[string]interface{}{"min": float64(2), "max": float64(1)},
+			valueType: _segmenters.SegmenterValueType_REAL,
+			errString: "range [2,1] is empty",
+		},
+		"failure | unsupported type": {
+			value:     map[string]interface{}{"min": float64(1)},
+			valueType: _segmenters.SegmenterValueType_STRING,
+			errString: "ranges are only supported for integer and real values",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			value, err := NewSegmenterRange(data.value, data.valueType)
+			if data.errString != "" {
+				assert.EqualError(t, err, data.errString)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(data.expected, value))
+		})
+	}
+}
+
+func TestParseAndFormatSegmenterRange(t *testing.T) {
+	tests := map[string]struct {
+		value     string
+		valueType _segmenters.SegmenterValueType
+		expected  *_segmenters.SegmenterValue
+		formatted string
+		errString string
+	}{
+		"success | integer range": {
+			value:     "[18, 25)",
+			valueType: _segmenters.SegmenterValueType_INTEGER,
+			expected:  newTestIntegerRange(proto.Int64(18), proto.Int64(25), false, true),
+			formatted: "[18,25)",
+		},
+		"success | open-ended integer range": {
+			value:     "[,10]",
+			valueType: _segmenters.SegmenterValueType_INTEGER,
+			expected:  newTestIntegerRange(nil, proto.Int64(10), false, false),
+			formatted: "(,10]",
+		},
+		"success | real range": {
+			value:     "(50.5,)",
+			valueType: _segmenters.SegmenterValueType_REAL,
+			expected:  newTestRealRange(proto.Float64(50.5), nil, true, false),
+			formatted: "(50.5,)",
+		},
+		"failure | missing bracket": {
+			value:     "[1,2",
+			valueType: _segmenters.SegmenterValueType_INTEGER,
+			errString: "invalid range \"[1,2\"",
+		},
+		"failure | invalid bound": {
+			value:     "[1.5,2]",
+			valueType: _segmenters.SegmenterValueType_INTEGER,
+			errString: "invalid range \"[1.5,2]\"",
+		},
+		"failure | no bounds": {
+			value:     "(,)",
+			valueType: _segmenters.SegmenterValueType_REAL,
+			errString: "a range must have a min or a max bound",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			value, err := ParseSegmenterRange(data.value, data.valueType)
+			if data.errString != "" {
+				assert.EqualError(t, err, data.errString)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(data.expected, value))
+			assert.Equal(t, data.formatted, FormatSegmenterRange(value))
+		})
+	}
+}
+
+func TestSegmenterRangeToMap(t *testing.T) {
+	assert.Equal(t,
+		map[string]interface{}{"min": 1.5, "max": 2.5, "min_exclusive": false, "max_exclusive": true},
+		SegmenterRangeToMap(newTestRealRange(proto.Float64(1.5), proto.Float64(2.5), false, true)),
+	)
+	assert.Nil(t, SegmenterRangeToMap(&_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Real{Real: 1}}))
+}
+
+func TestSegmenterRangeContains(t *testing.T) {
+	integerRange := newTestIntegerRange(proto.Int64(18), proto.Int64(25), false, true).GetIntegerRange()
+	assert.False(t, IntegerRangeContains(integerRange, 17))
+	assert.True(t, IntegerRangeContains(integerRange, 18))
+	assert.True(t, IntegerRangeContains(integerRange, 24))
+	assert.False(t, IntegerRangeContains(integerRange, 25))
+
+	openIntegerRange := newTestIntegerRange(nil, proto.Int64(0), false, false).GetIntegerRange()
+	assert.True(t, IntegerRangeContains(openIntegerRange, -1000))
+	assert.False(t, IntegerRangeContains(openIntegerRange, 1))
+
+	realRange := newTestRealRange(proto.Float64(50), nil, true, false).GetRealRange()
+	assert.False(t, RealRangeContains(realRange, 50))
+	assert.True(t, RealRangeContains(realRange, 50.01))
+	assert.True(t, RealRangeContains(realRange, 1e9))
+}
+
+func TestSegmenterValuesOverlap(t *testing.T) {
+	integerValue := func(value int64) *_segmenters.SegmenterValue {
+		return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Integer{Integer: value}}
+	}
+	realValue := func(value float64) *_segmenters.SegmenterValue {
+		return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Real{Real: value}}
+	}
+
+	tests := map[string]struct {
+		value    *_segmenters.SegmenterValue
+		other    *_segmenters.SegmenterValue
+		expected bool
+	}{
+		"integers | equal": {
+			value:    integerValue(1),
+			other:    integerValue(1),
+			expected: true,
+		},
+		"integers | different": {
+			value:    integerValue(1),
+			other:    integerValue(2),
+			expected: false,
+		},
+		"integer ranges | adjacent": {
+			value:    newTestIntegerRange(proto.Int64(18), proto.Int64(25), false, true),
+			other:    newTestIntegerRange(proto.Int64(25), nil, false, false),
+			expected: false,
+		},
+		"integer ranges | exclusive bounds without integers in between": {
+			value:    newTestIntegerRange(nil, proto.Int64(25), false, true),
+			other:    newTestIntegerRange(proto.Int64(24), nil, true, false),
+			expected: false,
+		},
+		"integer ranges | overlapping": {
+			value:    newTestIntegerRange(proto.Int64(18), proto.Int64(25), false, false),
+			other:    newTestIntegerRange(proto.Int64(25), nil, false, false),
+			expected: true,
+		},
+		"integer and integer range": {
+			value:    integerValue(20),
+			other:    newTestIntegerRange(proto.Int64(18), proto.Int64(25), false, false),
+			expected: true,
+		},
+		"real ranges | touching exclusive bound": {
+			value:    newTestRealRange(nil, proto.Float64(50), false, true),
+			other:    newTestRealRange(proto.Float64(50), nil, false, false),
+			expected: false,
+		},
+		"real ranges | touching inclusive bounds": {
+			value:    newTestRealRange(nil, proto.Float64(50), false, false),
+			other:    newTestRealRange(proto.Float64(50), nil, false, false),
+			expected: true,
+		},
+		"real and real range": {
+			value:    realValue(49.9),
+			other:    newTestRealRange(proto.Float64(50), nil, false, false),
+			expected: false,
+		},
+		"strings": {
+			value:    &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_String_{String_: "SG"}},
+			other:    &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_String_{String_: "SG"}},
+			expected: true,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, data.expected, SegmenterValuesOverlap(data.value, data.other))
+			assert.Equal(t, data.expected, SegmenterValuesOverlap(data.other, data.value))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+// StringMatcher matches strings against the values of a string segmenter in the prefix or regex match mode
+type StringMatcher struct {
+	mode     _segmenters.StringMatchMode
+	patterns []string
+	// prefixes holds the values in the prefix mode, which are looked up by the prefixes of the matched string
+	prefixes     map[string]struct{}
+	maxPrefixLen int
+	// regex is the alternation of the values in the regex mode
+	regex *regexp.Regexp
+}
+
+// NewStringMatcher compiles the patterns of the given match mode into a StringMatcher
+func NewStringMatcher(patterns []string, mode _segmenters.StringMatchMode) (*StringMatcher, error) {
+	for _, pattern := range patterns {
+		if err := ValidateStringPattern(pattern, mode); err != nil {
+			return nil, err
+		}
+	}
+
+	matcher := &StringMatcher{mode: mode, patterns: patterns}
+	switch mode {
+	case _segmenters.StringMatchMode_PREFIX:
+		matcher.prefixes = make(map[string]struct{}, len(patterns))
+		for _, pattern := range patterns {
+			matcher.prefixes[pattern] = struct{}{}
+			if len(pattern) > matcher.maxPrefixLen {
+				matcher.maxPrefixLen = len(pattern)
+			}
+		}
+	case _segmenters.StringMatchMode_REGEX:
+		alternatives := []string{}
+		for _, pattern := range patterns {
+			alternatives = append(alternatives, "(?:"+pattern+")")
+		}
+		// The patterns have been validated, so the alternation is valid
+		matcher.regex = regexp.MustCompile(strings.Join(alternatives, "|"))
+	default:
+		return nil, fmt.Errorf("match mode %s does not use patterns", mode)
+	}
+	return matcher, nil
+}
+
+// Match checks if the value matches any of the patterns
+func (m *StringMatcher) Match(value string) bool {
+	if m.mode == _segmenters.StringMatchMode_REGEX {
+		return m.regex.MatchString(value)
+	}
+	for i := 1; i <= len(value) && i <= m.maxPrefixLen; i++ {
+		if _, ok := m.prefixes[value[:i]]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Patterns returns the patterns of the matcher
+func (m *StringMatcher) Patterns() []string {
+	return m.patterns
+}
+
+// ValidateStringPattern checks that the value of a string segmenter is a valid pattern of the match mode. Prefixes
+// must not be empty, and regular expressions must be valid RE2 expressions.
+func ValidateStringPattern(pattern string, mode _segmenters.StringMatchMode) error {
+	switch mode {
+	case _segmenters.StringMatchMode_PREFIX:
+		if pattern == "" {
+			return errors.New("prefix cannot be empty")
+		}
+	case _segmenters.StringMatchMode_REGEX:
+		if pattern == "" {
+			return errors.New("regular expression cannot be empty")
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid regular expression %q: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// StringPatternsOverlap checks if any string may match both patterns of the match mode. The check is conservative:
+// two prefixes overlap if one is a prefix of the other, and two regular expressions always overlap, since
+// whether they match the same string cannot be checked efficiently.
+func StringPatternsOverlap(pattern string, other string, mode _segmenters.StringMatchMode) bool {
+	switch mode {
+	case _segmenters.StringMatchMode_PREFIX:
+		return strings.HasPrefix(pattern, other) || strings.HasPrefix(other, pattern)
+	case _segmenters.StringMatchMode_REGEX:
+		return true
+	default:
+		return pattern == other
+	}
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+func TestStringMatcher(t *testing.T) {
+	prefixMatcher, err := NewStringMatcher([]string{"Jak", "Sing", "Singapore-"}, _segmenters.StringMatchMode_PREFIX)
+	require.NoError(t, err)
+	assert.True(t, prefixMatcher.Match("Jakarta"))
+	assert.True(t, prefixMatcher.Match("Jak"))
+	assert.True(t, prefixMatcher.Match("Singapore"))
+	assert.False(t, prefixMatcher.Match("Ja"))
+	assert.False(t, prefixMatcher.Match("Bangkok"))
+	assert.False(t, prefixMatcher.Match(""))
+	assert.Equal(t, []string{"Jak", "Sing", "Singapore-"}, prefixMatcher.Patterns())
+
+	regexMatcher, err := NewStringMatcher([]string{"^Mozilla/5\\.0 \\(iPhone", "(?i)android"}, _segmenters.StringMatchMode_REGEX)
+	require.NoError(t, err)
+	assert.True(t, regexMatcher.Match("Mozilla/5.0 (iPhone; CPU iPhone OS 16_0 like Mac OS X)"))
+	assert.True(t, regexMatcher.Match("Mozilla/5.0 (Linux; Android 13)"))
+	assert.False(t, regexMatcher.Match("Mozilla/5.0 (Windows NT 10.0; Win64; x64)"))
+
+	_, err = NewStringMatcher([]string{"a", "("}, _segmenters.StringMatchMode_REGEX)
+	assert.EqualError(t, err, "invalid regular expression \"(\": error parsing regexp: missing closing ): `(`")
+
+	_, err = NewStringMatcher([]string{"a"}, _segmenters.StringMatchMode_EXACT)
+	assert.EqualError(t, err, "match mode EXACT does not use patterns")
+}
+
+func TestValidateStringPattern(t *testing.T) {
+	tests := map[string]struct {
+		pattern   string
+		mode      _segmenters.StringMatchMode
+		errString string
+	}{
+		"success | exact": {
+			pattern: "",
+			mode:    _segmenters.StringMatchMode_EXACT,
+		},
+		"success | prefix": {
+			pattern: "Jak",
+			mode:    _segmenters.StringMatchMode_PREFIX,
+		},
+		"success | regex": {
+			pattern: "^Jak(arta)?$",
+			mode:    _segmenters.StringMatchMode_REGEX,
+		},
+		"failure | empty prefix": {
+			pattern:   "",
+			mode:      _segmenters.StringMatchMode_PREFIX,
+			errString: "prefix cannot be empty",
+		},
+		"failure | empty regex": {
+			pattern:   "",
+			mode:      _segmenters.StringMatchMode_REGEX,
+			errString: "regular expression cannot be empty",
+		},
+		"failure | unsupported regex": {
+			pattern:   "(?!Jak)",
+			mode:      _segmenters.StringMatchMode_REGEX,
+			errString: "invalid regular expression \"(?!Jak)\": error parsing regexp: invalid or unsupported Perl syntax: `(?!`",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateStringPattern(data.pattern, data.mode)
+			if data.errString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
+
+func TestStringPatternsOverlap(t *testing.T) {
+	assert.True(t, StringPatternsOverlap("Jak", "Jakarta", _segmenters.StringMatchMode_PREFIX))
+	assert.True(t, StringPatternsOverlap("Jakarta", "Jak", _segmenters.StringMatchMode_PREFIX))
+	assert.False(t, StringPatternsOverlap("Jak", "Sing", _segmenters.StringMatchMode_PREFIX))
+	assert.True(t, StringPatternsOverlap("^Jak", "^Sing", _segmenters.StringMatchMode_REGEX))
+	assert.False(t, StringPatternsOverlap("Jak", "Jakarta", _segmenters.StringMatchMode_EXACT))
+}
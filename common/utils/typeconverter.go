@@ -71,6 +71,8 @@ func SegmenterValueToInterface(value *_segmenters.SegmenterValue) interface{} {
 		return value.GetReal()
 	case *_segmenters.SegmenterValue_Bool:
 		return value.GetBool()
+	case *_segmenters.SegmenterValue_IntegerRange, *_segmenters.SegmenterValue_RealRange:
+		return SegmenterRangeToMap(value)
 	default:
 		return nil
 	}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
 )
@@ -86,6 +87,13 @@ func TestSegmenterValueToInterface(t *testing.T) {
 			SegmenterValue: &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Bool{Bool: true}},
 			Expected:       true,
 		},
+		{
+			Name: "success | integer range",
+			SegmenterValue: &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_IntegerRange{
+				IntegerRange: &_segmenters.IntegerRange{Min: proto.Int64(18)},
+			}},
+			Expected: map[string]interface{}{"min": int64(18), "min_exclusive": false, "max_exclusive": false},
+		},
 	}
 
 	// Run tests
@@ -0,0 +1,16 @@
+package utils
+
+import "math"
+
+// UnitBucketSegmenter is the name of the segmenter whose values are the buckets of the randomization key
+const UnitBucketSegmenter = "unit_bucket"
+
+const (
+	// DefaultUnitBuckets is the no. of buckets of the randomization key, if not configured
+	DefaultUnitBuckets = 100
+	// MaxUnitBuckets is the largest no. of buckets, as the buckets are computed from a 32-bit hash
+	MaxUnitBuckets = math.MaxUint32
+	// DefaultUnitBucketSalt is the salt of the hash of the randomization key, if not configured. It is different
+	// from the seeds of the experiments' own splits, so that the buckets are independent of the splits.
+	DefaultUnitBucketSalt = "unit_bucket"
+)
@@ -0,0 +1,204 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+// AppVersionSegmenter is the name of the segmenter whose values are semantic version constraints
+const AppVersionSegmenter = "app_version"
+
+const (
+	// versionComponentBits is the no. of bits used by each component of an encoded version
+	versionComponentBits = 21
+	// MaxVersionComponent is the largest major, minor or patch version that can be encoded
+	MaxVersionComponent = 1<<(versionComponentBits-1) - 1
+)
+
+// partialVersion is a version with up to 3 specified components, where the components that are not specified
+// (or are wildcards) match any value
+type partialVersion struct {
+	components []int64
+	specified  int
+}
+
+// ParseVersion parses a semantic version into an integer that preserves the order of the versions,
+// so that version constraints can be matched as integer ranges. A leading "v" and the missing minor and
+// patch versions are allowed. The pre-release and build metadata are ignored, so 5.0.0-beta.1 is
+// treated as 5.0.0.
+func ParseVersion(version string) (int64, error) {
+	v, err := parsePartialVersion(version)
+	if err != nil {
+		return 0, err
+	}
+	if v.specified == 0 {
+		return 0, fmt.Errorf("invalid version %q", version)
+	}
+	return v.encode(), nil
+}
+
+// ParseVersionConstraint parses a semantic version constraint into the integer range of the versions that
+// satisfy it, using the encoding of ParseVersion. A constraint is one or more comparators separated by commas
+// or spaces, all of which must be satisfied, eg. ">=4.12.0, <5.0.0". The comparators are:
+//   - an exact version, eg. 4.12.3 or =4.12.3
+//   - a partial version or wildcard, eg. 4.12 or 4.12.x, which matches all versions with the given components
+//   - a comparison, eg. >=4.12.0, >4.12.0, <5.0.0 or <=4.x
+//   - a tilde range, eg. ~4.12.3, which allows patch versions (minor versions, if the minor is not specified)
+//   - a caret range, eg. ^4.12.3, which allows changes that do not modify the left-most non-zero component
+//
+// A single integer value is returned if the constraint matches only one version.
+func ParseVersionConstraint(constraint string) (*_segmenters.SegmenterValue, error) {
+	comparators := strings.FieldsFunc(constraint, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+	if len(comparators) == 0 {
+		return nil, fmt.Errorf("invalid version constraint %q", constraint)
+	}
+
+	var min, max *int64
+	for i := 0; i < len(comparators); i++ {
+		comparator := comparators[i]
+		// Allow a space between the operator and the version
+		if strings.Trim(comparator, "<>=~^") == "" && i+1 < len(comparators) {
+			i++
+			comparator += comparators[i]
+		}
+		lower, upper, err := parseVersionComparator(comparator)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %s", constraint, err)
+		}
+		if lower != nil && (min == nil || *lower > *min) {
+			min = lower
+		}
+		if upper != nil && (max == nil || *upper < *max) {
+			max = upper
+		}
+	}
+
+	if min == nil && max == nil {
+		return nil, fmt.Errorf("version constraint %q matches all versions", constraint)
+	}
+	if max != nil && *max < 0 {
+		return nil, fmt.Errorf("version constraint %q matches no versions", constraint)
+	}
+	if min != nil && max != nil {
+		if *min > *max {
+			return nil, fmt.Errorf("version constraint %q matches no versions", constraint)
+		}
+		if *min == *max {
+			return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Integer{Integer: *min}}, nil
+		}
+	}
+	return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_IntegerRange{
+		IntegerRange: &_segmenters.IntegerRange{Min: min, Max: max},
+	}}, nil
+}
+
+// parseVersionComparator returns the inclusive bounds of the encoded versions satisfying the comparator,
+// where a nil bound is unbounded
+func parseVersionComparator(comparator string) (*int64, *int64, error) {
+	operator := ""
+	for _, op := range []string{">=", "<=", ">", "<", "=", "~", "^"} {
+		if strings.HasPrefix(comparator, op) {
+			operator = op
+			break
+		}
+	}
+	v, err := parsePartialVersion(comparator[len(operator):])
+	if err != nil {
+		return nil, nil, err
+	}
+	if v.specified == 0 {
+		if operator == "" || operator == "=" || operator == ">=" || operator == "<=" {
+			// Wildcards match all versions
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("%q matches no versions", comparator)
+	}
+
+	lower, upper := v.encode(), v.next(v.specified).encode()-1
+	switch operator {
+	case "", "=":
+		return &lower, &upper, nil
+	case ">=":
+		return &lower, nil, nil
+	case ">":
+		upper++
+		return &upper, nil, nil
+	case "<":
+		lower--
+		return nil, &lower, nil
+	case "<=":
+		return nil, &upper, nil
+	case "~":
+		// Allow the patch versions, or the minor versions if only the major version is specified
+		if v.specified > 1 {
+			upper = v.next(2).encode() - 1
+		}
+		return &lower, &upper, nil
+	default:
+		// Allow the versions that do not modify the left-most non-zero component
+		position := v.specified
+		for i := 0; i < v.specified; i++ {
+			if v.components[i] != 0 {
+				position = i + 1
+				break
+			}
+		}
+		upper = v.next(position).encode() - 1
+		return &lower, &upper, nil
+	}
+}
+
+func parsePartialVersion(version string) (*partialVersion, error) {
+	errInvalid := fmt.Errorf("invalid version %q", version)
+	value := strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(version), "v"), "V")
+	// Ignore the build metadata and pre-release
+	if i := strings.IndexAny(value, "+-"); i >= 0 {
+		value = value[:i]
+	}
+
+	parts := strings.Split(value, ".")
+	if len(parts) > 3 {
+		return nil, errInvalid
+	}
+	v := &partialVersion{components: []int64{0, 0, 0}}
+	isWildcard := false
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			isWildcard = true
+			continue
+		}
+		if isWildcard {
+			// The components after a wildcard must also be wildcards
+			return nil, errInvalid
+		}
+		component, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || component < 0 {
+			return nil, errInvalid
+		}
+		if component > MaxVersionComponent {
+			return nil, fmt.Errorf("version %q has a component greater than %d", version, MaxVersionComponent)
+		}
+		v.components[i] = component
+		v.specified = i + 1
+	}
+	return v, nil
+}
+
+// next returns the smallest version that is greater than all the versions with the same first n components
+func (v *partialVersion) next(n int) *partialVersion {
+	next := &partialVersion{components: []int64{0, 0, 0}, specified: n}
+	copy(next.components, v.components[:n])
+	next.components[n-1]++
+	return next
+}
+
+// encode the version into an integer, where a component that overflows its bits increments the previous
+// component, so that the order of the versions is preserved
+func (v *partialVersion) encode() int64 {
+	return v.components[0]<<(2*versionComponentBits) + v.components[1]<<versionComponentBits + v.components[2]
+}
@@ -0,0 +1,188 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+func mustParseVersion(t *testing.T, version string) int64 {
+	encoded, err := ParseVersion(version)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]struct {
+		version   string
+		expected  int64
+		errString string
+	}{
+		"success | full version": {
+			version:  "4.12.3",
+			expected: 4<<42 + 12<<21 + 3,
+		},
+		"success | leading v and missing patch": {
+			version:  "v4.12",
+			expected: 4<<42 + 12<<21,
+		},
+		"success | pre-release and build metadata": {
+			version:  "5.0.0-beta.1+exp.sha.5114f85",
+			expected: 5 << 42,
+		},
+		"failure | empty": {
+			version:   "",
+			errString: "invalid version \"\"",
+		},
+		"failure | too many components": {
+			version:   "1.2.3.4",
+			errString: "invalid version \"1.2.3.4\"",
+		},
+		"failure | not a number": {
+			version:   "4.twelve",
+			errString: "invalid version \"4.twelve\"",
+		},
+		"failure | wildcard": {
+			version:   "x",
+			errString: "invalid version \"x\"",
+		},
+		"failure | component too large": {
+			version:   "1.1048576",
+			errString: "version \"1.1048576\" has a component greater than 1048575",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := ParseVersion(data.version)
+			if data.errString != "" {
+				assert.EqualError(t, err, data.errString)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, data.expected, encoded)
+		})
+	}
+}
+
+func TestParseVersionOrder(t *testing.T) {
+	versions := []string{"0.0.1", "0.1.0", "0.1.1048575", "1.0.0", "1.2.10", "1.10.2", "4.12.0", "10.0.0"}
+	for i := 1; i < len(versions); i++ {
+		assert.Less(t, mustParseVersion(t, versions[i-1]), mustParseVersion(t, versions[i]))
+	}
+}
+
+func TestParseVersionConstraint(t *testing.T) {
+	versionRange := func(min string, max string) *_segmenters.SegmenterValue {
+		r := &_segmenters.IntegerRange{}
+		if min != "" {
+			r.Min = proto.Int64(mustParseVersion(t, min))
+		}
+		if max != "" {
+			r.Max = proto.Int64(mustParseVersion(t, max) - 1)
+		}
+		return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_IntegerRange{IntegerRange: r}}
+	}
+
+	tests := map[string]struct {
+		constraint string
+		// expected is the range from the min version (inclusive) to the max version (exclusive)
+		expected  *_segmenters.SegmenterValue
+		errString string
+	}{
+		"success | range": {
+			constraint: ">=4.12.0, <5.0.0",
+			expected:   versionRange("4.12.0", "5.0.0"),
+		},
+		"success | range separated by spaces": {
+			constraint: ">= 4.12 < 5",
+			expected:   versionRange("4.12.0", "5.0.0"),
+		},
+		"success | exclusive min": {
+			constraint: ">4.12.3",
+			expected:   versionRange("4.12.4", ""),
+		},
+		"success | exclusive partial min": {
+			constraint: ">4.12",
+			expected:   versionRange("4.13.0", ""),
+		},
+		"success | inclusive partial max": {
+			constraint: "<=4.x",
+			expected:   versionRange("", "5.0.0"),
+		},
+		"success | exact version": {
+			constraint: "=4.12.3",
+			expected: &_segmenters.SegmenterValue{
+				Value: &_segmenters.SegmenterValue_Integer{Integer: mustParseVersion(t, "4.12.3")},
+			},
+		},
+		"success | partial version": {
+			constraint: "4.12",
+			expected:   versionRange("4.12.0", "4.13.0"),
+		},
+		"success | wildcard": {
+			constraint: "4.12.*",
+			expected:   versionRange("4.12.0", "4.13.0"),
+		},
+		"success | tilde range": {
+			constraint: "~4.12.3",
+			expected:   versionRange("4.12.3", "4.13.0"),
+		},
+		"success | tilde range of major version": {
+			constraint: "~4",
+			expected:   versionRange("4.0.0", "5.0.0"),
+		},
+		"success | caret range": {
+			constraint: "^4.12.3",
+			expected:   versionRange("4.12.3", "5.0.0"),
+		},
+		"success | caret range of zero major version": {
+			constraint: "^0.3.1",
+			expected:   versionRange("0.3.1", "0.4.0"),
+		},
+		"success | intersection of caret range and max": {
+			constraint: "^4.12.3, <4.20",
+			expected:   versionRange("4.12.3", "4.20.0"),
+		},
+		"failure | empty": {
+			constraint: " , ",
+			errString:  "invalid version constraint \" , \"",
+		},
+		"failure | invalid version": {
+			constraint: ">=4.12.0, <five",
+			errString:  "invalid version constraint \">=4.12.0, <five\": invalid version \"five\"",
+		},
+		"failure | all versions": {
+			constraint: "*",
+			errString:  "version constraint \"*\" matches all versions",
+		},
+		"failure | no versions": {
+			constraint: ">=5.0.0, <4.12.0",
+			errString:  "version constraint \">=5.0.0, <4.12.0\" matches no versions",
+		},
+		"failure | less than zero": {
+			constraint: "<0.0.0",
+			errString:  "version constraint \"<0.0.0\" matches no versions",
+		},
+		"failure | greater than wildcard": {
+			constraint: ">x",
+			errString:  "invalid version constraint \">x\": \">x\" matches no versions",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			value, err := ParseVersionConstraint(data.constraint)
+			if data.errString != "" {
+				assert.EqualError(t, err, data.errString)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(data.expected, value), "expected %s, got %s", data.expected, value)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package analysis
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Observation is the value of a metric for one unit, joined with the treatment that the unit was assigned
+type Observation struct {
+	Treatment string
+	Value     float64
+	// Covariate is the pre-experiment value of the metric for the unit, used for CUPED
+	Covariate *float64
+}
+
+// Params configures the computation of the treatment statistics
+type Params struct {
+	// ControlTreatment is the name of the treatment that the others are compared against
+	ControlTreatment string
+	// CUPED enables variance reduction, using the covariates of the observations
+	CUPED bool
+	// ConfidenceLevel is the level of the confidence intervals, in (0, 1)
+	ConfidenceLevel float64
+}
+
+// TreatmentStatistics is the summary of a metric for one treatment and, except for the control,
+// its comparison against the control
+type TreatmentStatistics struct {
+	Treatment string  `json:"treatment"`
+	Count     int     `json:"count"`
+	Mean      float64 `json:"mean"`
+	MeanLower float64 `json:"mean_lower"`
+	MeanUpper float64 `json:"mean_upper"`
+
+	Difference      *float64 `json:"difference,omitempty"`
+	DifferenceLower *float64 `json:"difference_lower,omitempty"`
+	DifferenceUpper *float64 `json:"difference_upper,omitempty"`
+	// RelativeLift is the difference, relative to the mean of the control. It is not set when
+	// the mean of the control is 0.
+	RelativeLift      *float64 `json:"relative_lift,omitempty"`
+	RelativeLiftLower *float64 `json:"relative_lift_lower,omitempty"`
+	RelativeLiftUpper *float64 `json:"relative_lift_upper,omitempty"`
+	PValue            *float64 `json:"p_value,omitempty"`
+}
+
+// Analyze computes the statistics of each treatment in the observations, with the control first and the
+// remaining treatments in the order of their names. The control must have observations, and every treatment
+// must have at least 2 observations.
+func Analyze(observations []Observation, params Params) ([]TreatmentStatistics, error) {
+	if params.ConfidenceLevel <= 0 || params.ConfidenceLevel >= 1 {
+		return nil, fmt.Errorf("confidence level must be between 0 and 1, got %v", params.ConfidenceLevel)
+	}
+
+	values := make([]float64, len(observations))
+	for i, o := range observations {
+		values[i] = o.Value
+	}
+
+	if params.CUPED {
+		covariates := make([]float64, len(observations))
+		for i, o := range observations {
+			if o.Covariate == nil {
+				return nil, errors.New("CUPED requires a covariate for every observation")
+			}
+			covariates[i] = *o.Covariate
+		}
+		theta, err := CUPEDTheta(values, covariates)
+		if err != nil {
+			return nil, err
+		}
+		values = CUPEDAdjust(values, covariates, theta, Summarize(covariates).Mean)
+	}
+
+	valuesByTreatment := map[string][]float64{}
+	for i, o := range observations {
+		valuesByTreatment[o.Treatment] = append(valuesByTreatment[o.Treatment], values[i])
+	}
+	if _, ok := valuesByTreatment[params.ControlTreatment]; !ok {
+		return nil, fmt.Errorf("no observations for the control treatment %s", params.ControlTreatment)
+	}
+
+	treatments := []string{}
+	for name := range valuesByTreatment {
+		if name != params.ControlTreatment {
+			treatments = append(treatments, name)
+		}
+	}
+	sort.Strings(treatments)
+	treatments = append([]string{params.ControlTreatment}, treatments...)
+
+	control := Summarize(valuesByTreatment[params.ControlTreatment])
+	results := make([]TreatmentStatistics, 0, len(treatments))
+	for _, name := range treatments {
+		summary := Summarize(valuesByTreatment[name])
+		if summary.Count < 2 {
+			return nil, fmt.Errorf("treatment %s has fewer than 2 observations", name)
+		}
+
+		stats := TreatmentStatistics{
+			Treatment: name,
+			Count:     summary.Count,
+			Mean:      summary.Mean,
+		}
+		stats.MeanLower, stats.MeanUpper = MeanConfidenceInterval(summary, params.ConfidenceLevel)
+
+		if name != params.ControlTreatment {
+			test, err := WelchTTest(control, summary, params.ConfidenceLevel)
+			if err != nil {
+				return nil, err
+			}
+			stats.Difference = &test.Difference
+			stats.DifferenceLower = &test.DifferenceLower
+			stats.DifferenceUpper = &test.DifferenceUpper
+			stats.PValue = &test.PValue
+
+			if control.Mean != 0 {
+				lift := test.Difference / control.Mean
+				liftLower := test.DifferenceLower / control.Mean
+				liftUpper := test.DifferenceUpper / control.Mean
+				if control.Mean < 0 {
+					liftLower, liftUpper = liftUpper, liftLower
+				}
+				stats.RelativeLift = &lift
+				stats.RelativeLiftLower = &liftLower
+				stats.RelativeLiftUpper = &liftUpper
+			}
+		}
+		results = append(results, stats)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,117 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func TestAnalyze(t *testing.T) {
+	observations := []Observation{
+		{Treatment: "treatment-b", Value: 14},
+		{Treatment: "control", Value: 9},
+		{Treatment: "treatment-a", Value: 12},
+		{Treatment: "control", Value: 11},
+		{Treatment: "treatment-a", Value: 10},
+		{Treatment: "treatment-b", Value: 16},
+		{Treatment: "control", Value: 10},
+		{Treatment: "treatment-b", Value: 15},
+	}
+
+	results, err := Analyze(observations, Params{ControlTreatment: "control", ConfidenceLevel: 0.95})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "control", results[0].Treatment)
+	assert.Equal(t, 3, results[0].Count)
+	assert.Equal(t, 10.0, results[0].Mean)
+	// t(0.975, 2) * sqrt(1 / 3)
+	assert.InDelta(t, 10-2.484138, results[0].MeanLower, 1e-5)
+	assert.InDelta(t, 10+2.484138, results[0].MeanUpper, 1e-5)
+	assert.Nil(t, results[0].Difference)
+	assert.Nil(t, results[0].PValue)
+
+	assert.Equal(t, "treatment-a", results[1].Treatment)
+	assert.Equal(t, 11.0, results[1].Mean)
+	assert.Equal(t, 1.0, *results[1].Difference)
+	assert.InDelta(t, 0.1, *results[1].RelativeLift, 1e-12)
+	assert.InDelta(t, *results[1].DifferenceLower/10, *results[1].RelativeLiftLower, 1e-12)
+	assert.InDelta(t, *results[1].DifferenceUpper/10, *results[1].RelativeLiftUpper, 1e-12)
+	assert.Greater(t, *results[1].PValue, 0.05)
+
+	assert.Equal(t, "treatment-b", results[2].Treatment)
+	assert.Equal(t, 5.0, *results[2].Difference)
+	assert.InDelta(t, 0.5, *results[2].RelativeLift, 1e-12)
+	assert.Less(t, *results[2].PValue, 0.05)
+}
+
+func TestAnalyzeCUPED(t *testing.T) {
+	// The metric is strongly correlated with the covariate, so CUPED should reduce the variance
+	observations := []Observation{
+		{Treatment: "control", Value: 10.2, Covariate: float64Ptr(10)},
+		{Treatment: "control", Value: 20.1, Covariate: float64Ptr(20)},
+		{Treatment: "control", Value: 29.8, Covariate: float64Ptr(30)},
+		{Treatment: "treatment", Value: 11.9, Covariate: float64Ptr(10)},
+		{Treatment: "treatment", Value: 22.2, Covariate: float64Ptr(20)},
+		{Treatment: "treatment", Value: 31.8, Covariate: float64Ptr(30)},
+	}
+	params := Params{ControlTreatment: "control", ConfidenceLevel: 0.95}
+
+	unadjusted, err := Analyze(observations, params)
+	require.NoError(t, err)
+	params.CUPED = true
+	adjusted, err := Analyze(observations, params)
+	require.NoError(t, err)
+
+	assert.InDelta(t, *unadjusted[1].Difference, *adjusted[1].Difference, 1e-9)
+	assert.Greater(t, *unadjusted[1].PValue, 0.05)
+	assert.Less(t, *adjusted[1].PValue, 0.05)
+	assert.Less(t,
+		*adjusted[1].DifferenceUpper-*adjusted[1].DifferenceLower,
+		*unadjusted[1].DifferenceUpper-*unadjusted[1].DifferenceLower,
+	)
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	tests := map[string]struct {
+		observations []Observation
+		params       Params
+		expectedErr  string
+	}{
+		"invalid confidence level": {
+			params:      Params{ControlTreatment: "control", ConfidenceLevel: 1},
+			expectedErr: "confidence level must be between 0 and 1, got 1",
+		},
+		"missing control": {
+			observations: []Observation{{Treatment: "a", Value: 1}, {Treatment: "a", Value: 2}},
+			params:       Params{ControlTreatment: "control", ConfidenceLevel: 0.95},
+			expectedErr:  "no observations for the control treatment control",
+		},
+		"insufficient observations": {
+			observations: []Observation{
+				{Treatment: "control", Value: 1},
+				{Treatment: "control", Value: 2},
+				{Treatment: "a", Value: 2},
+			},
+			params:      Params{ControlTreatment: "control", ConfidenceLevel: 0.95},
+			expectedErr: "treatment a has fewer than 2 observations",
+		},
+		"missing covariate": {
+			observations: []Observation{{Treatment: "control", Value: 1}},
+			params:       Params{ControlTreatment: "control", CUPED: true, ConfidenceLevel: 0.95},
+			expectedErr:  "CUPED requires a covariate for every observation",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Analyze(data.observations, data.params)
+			assert.EqualError(t, err, data.expectedErr)
+		})
+	}
+}
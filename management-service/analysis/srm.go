@@ -0,0 +1,102 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+)
+
+// ChiSquaredTest is Pearson's goodness-of-fit test of the observed counts against the expected proportions,
+// given as weights that need not sum to 1. It returns the test statistic and its p-value.
+func ChiSquaredTest(observed []int64, weights []float64) (float64, float64, error) {
+	if len(observed) != len(weights) {
+		return 0, 0, errors.New("the no. of observed counts and weights must be equal")
+	}
+	if len(observed) < 2 {
+		return 0, 0, errors.New("at least 2 categories are required")
+	}
+
+	var total int64
+	var totalWeight float64
+	for i := range observed {
+		if observed[i] < 0 {
+			return 0, 0, errors.New("observed counts must not be negative")
+		}
+		if weights[i] <= 0 {
+			return 0, 0, errors.New("weights must be positive")
+		}
+		total += observed[i]
+		totalWeight += weights[i]
+	}
+	if total == 0 {
+		return 0, 0, errors.New("no observations")
+	}
+
+	var statistic float64
+	for i := range observed {
+		expected := float64(total) * weights[i] / totalWeight
+		diff := float64(observed[i]) - expected
+		statistic += diff * diff / expected
+	}
+
+	return statistic, ChiSquaredSurvival(statistic, float64(len(observed)-1)), nil
+}
+
+// ChiSquaredSurvival is the probability that a chi-squared random variable with the given degrees of freedom
+// exceeds x, i.e., 1 - CDF(x)
+func ChiSquaredSurvival(x float64, df float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	return regularizedUpperGamma(df/2, x/2)
+}
+
+// regularizedUpperGamma computes Q(a, x) = Γ(a, x) / Γ(a), using the series representation of P(a, x) = 1 - Q(a, x)
+// for x < a + 1, and the continued fraction representation of Q(a, x) otherwise
+func regularizedUpperGamma(a float64, x float64) float64 {
+	const (
+		maxIterations = 500
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+
+	lgammaA, _ := math.Lgamma(a)
+	front := math.Exp(-x + a*math.Log(x) - lgammaA)
+
+	if x < a+1 {
+		term := 1 / a
+		sum := term
+		for n := 1; n <= maxIterations; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+		return 1 - front*sum
+	}
+
+	// Modified Lentz's method
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	result := d
+	for n := 1; n <= maxIterations; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		result *= delta
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return front * result
+}
@@ -0,0 +1,77 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChiSquaredSurvival(t *testing.T) {
+	tests := map[string]struct {
+		x        float64
+		df       float64
+		expected float64
+	}{
+		"df 1, 95th percentile": {
+			x:        3.841459,
+			df:       1,
+			expected: 0.05,
+		},
+		"df 2": {
+			x:        4,
+			df:       2,
+			expected: math.Exp(-2),
+		},
+		"df 3, 99.9th percentile": {
+			x:        16.266236,
+			df:       3,
+			expected: 0.001,
+		},
+		"df 10, small x": {
+			x:        2.558212,
+			df:       10,
+			expected: 0.99,
+		},
+		"zero": {
+			x:        0,
+			df:       4,
+			expected: 1,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, data.expected, ChiSquaredSurvival(data.x, data.df), 1e-7)
+		})
+	}
+}
+
+func TestChiSquaredTest(t *testing.T) {
+	// Matching the configured split
+	statistic, pValue, err := ChiSquaredTest([]int64{5000, 5000}, []float64{50, 50})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, statistic)
+	assert.Equal(t, 1.0, pValue)
+
+	// 50.5% vs 49.5% of 100k units is a mismatch
+	statistic, pValue, err = ChiSquaredTest([]int64{50500, 49500}, []float64{50, 50})
+	require.NoError(t, err)
+	assert.InDelta(t, 10, statistic, 1e-9)
+	assert.InDelta(t, 0.0015654, pValue, 1e-7)
+
+	// Unequal weights
+	statistic, _, err = ChiSquaredTest([]int64{180, 420, 400}, []float64{20, 40, 40})
+	require.NoError(t, err)
+	assert.InDelta(t, 3, statistic, 1e-9)
+
+	_, _, err = ChiSquaredTest([]int64{1, 2}, []float64{1})
+	assert.EqualError(t, err, "the no. of observed counts and weights must be equal")
+	_, _, err = ChiSquaredTest([]int64{1}, []float64{1})
+	assert.EqualError(t, err, "at least 2 categories are required")
+	_, _, err = ChiSquaredTest([]int64{1, 2}, []float64{1, 0})
+	assert.EqualError(t, err, "weights must be positive")
+	_, _, err = ChiSquaredTest([]int64{0, 0}, []float64{1, 1})
+	assert.EqualError(t, err, "no observations")
+}
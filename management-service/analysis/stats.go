@@ -0,0 +1,254 @@
+package analysis
+
+import (
+	"errors"
+	"math"
+)
+
+// Summary captures the sample statistics of a metric's observations
+type Summary struct {
+	Count int
+	Mean  float64
+	// Variance is the unbiased sample variance
+	Variance float64
+}
+
+// StdError is the standard error of the mean
+func (s Summary) StdError() float64 {
+	if s.Count == 0 {
+		return 0
+	}
+	return math.Sqrt(s.Variance / float64(s.Count))
+}
+
+// Summarize computes the sample statistics of the given values
+func Summarize(values []float64) Summary {
+	n := len(values)
+	if n == 0 {
+		return Summary{}
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(n)
+
+	var variance float64
+	if n > 1 {
+		var squares float64
+		for _, v := range values {
+			squares += (v - mean) * (v - mean)
+		}
+		variance = squares / float64(n-1)
+	}
+
+	return Summary{Count: n, Mean: mean, Variance: variance}
+}
+
+// CUPEDTheta computes the coefficient that minimises the variance of the metric, adjusted by the covariate
+// (typically, the same metric measured before the experiment). It should be computed over the units of all
+// the treatments together, so that the adjustment does not bias the difference between the treatments.
+func CUPEDTheta(values []float64, covariates []float64) (float64, error) {
+	if len(values) != len(covariates) {
+		return 0, errors.New("the no. of values and covariates must be equal")
+	}
+	if len(values) < 2 {
+		return 0, errors.New("at least 2 observations are required")
+	}
+
+	valueMean := Summarize(values).Mean
+	covariateSummary := Summarize(covariates)
+	if covariateSummary.Variance == 0 {
+		// The covariate carries no information
+		return 0, nil
+	}
+
+	var covariance float64
+	for i := range values {
+		covariance += (values[i] - valueMean) * (covariates[i] - covariateSummary.Mean)
+	}
+	covariance /= float64(len(values) - 1)
+
+	return covariance / covariateSummary.Variance, nil
+}
+
+// CUPEDAdjust returns the values adjusted by the covariates, as y - theta * (x - mean(x)), where the mean of
+// the covariate is the given covariateMean, computed over the units of all the treatments.
+func CUPEDAdjust(values []float64, covariates []float64, theta float64, covariateMean float64) []float64 {
+	adjusted := make([]float64, len(values))
+	for i := range values {
+		adjusted[i] = values[i] - theta*(covariates[i]-covariateMean)
+	}
+	return adjusted
+}
+
+// MeanConfidenceInterval computes the two-sided confidence interval of the mean at the given confidence level
+func MeanConfidenceInterval(s Summary, confidenceLevel float64) (float64, float64) {
+	if s.Count < 2 {
+		return s.Mean, s.Mean
+	}
+	margin := StudentTQuantile(1-(1-confidenceLevel)/2, float64(s.Count-1)) * s.StdError()
+	return s.Mean - margin, s.Mean + margin
+}
+
+// TestResult is the result of comparing the mean of a treatment against the control
+type TestResult struct {
+	// Difference is the difference between the means of the treatment and the control
+	Difference      float64
+	DifferenceLower float64
+	DifferenceUpper float64
+	// DegreesOfFreedom is the Welch–Satterthwaite approximation of the degrees of freedom
+	DegreesOfFreedom float64
+	// PValue is the two-sided p-value of the null hypothesis that the means are equal
+	PValue float64
+}
+
+// WelchTTest compares the means of the treatment and the control, without assuming equal variances
+func WelchTTest(control Summary, treatment Summary, confidenceLevel float64) (*TestResult, error) {
+	if control.Count < 2 || treatment.Count < 2 {
+		return nil, errors.New("at least 2 observations are required per treatment")
+	}
+
+	difference := treatment.Mean - control.Mean
+	controlVar := control.Variance / float64(control.Count)
+	treatmentVar := treatment.Variance / float64(treatment.Count)
+	stdError := math.Sqrt(controlVar + treatmentVar)
+
+	if stdError == 0 {
+		// Both samples are constant, so the difference is known exactly
+		pValue := 1.0
+		if difference != 0 {
+			pValue = 0
+		}
+		return &TestResult{
+			Difference:       difference,
+			DifferenceLower:  difference,
+			DifferenceUpper:  difference,
+			DegreesOfFreedom: float64(control.Count + treatment.Count - 2),
+			PValue:           pValue,
+		}, nil
+	}
+
+	df := (controlVar + treatmentVar) * (controlVar + treatmentVar) /
+		(controlVar*controlVar/float64(control.Count-1) + treatmentVar*treatmentVar/float64(treatment.Count-1))
+	t := difference / stdError
+	margin := StudentTQuantile(1-(1-confidenceLevel)/2, df) * stdError
+
+	return &TestResult{
+		Difference:       difference,
+		DifferenceLower:  difference - margin,
+		DifferenceUpper:  difference + margin,
+		DegreesOfFreedom: df,
+		PValue:           2 * (1 - StudentTCDF(math.Abs(t), df)),
+	}, nil
+}
+
+// StudentTCDF is the cumulative distribution function of Student's t-distribution
+func StudentTCDF(t float64, df float64) float64 {
+	x := df / (df + t*t)
+	tail := 0.5 * regularizedIncompleteBeta(x, df/2, 0.5)
+	if t > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+// StudentTQuantile is the inverse of StudentTCDF, for probabilities in (0, 1)
+func StudentTQuantile(p float64, df float64) float64 {
+	if p == 0.5 {
+		return 0
+	}
+	if p < 0.5 {
+		return -StudentTQuantile(1-p, df)
+	}
+
+	// Bracket the quantile, then bisect
+	low, high := 0.0, 1.0
+	for StudentTCDF(high, df) < p {
+		low, high = high, high*2
+	}
+	for i := 0; i < 100 && high-low > 1e-12*math.Max(1, high); i++ {
+		mid := (low + high) / 2
+		if StudentTCDF(mid, df) < p {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// regularizedIncompleteBeta computes I_x(a, b), using the continued fraction representation
+func regularizedIncompleteBeta(x float64, a float64, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lgammaA, _ := math.Lgamma(a)
+	lgammaB, _ := math.Lgamma(b)
+	lgammaAB, _ := math.Lgamma(a + b)
+	front := math.Exp(lgammaAB - lgammaA - lgammaB + a*math.Log(x) + b*math.Log(1-x))
+
+	// The continued fraction converges quickly for x < (a+1)/(a+b+2), otherwise use the symmetry relation
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction for the incomplete beta function, by the modified
+// Lentz's method
+func betaContinuedFraction(x float64, a float64, b float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	result := d
+
+	for m := 1; m <= maxIterations; m++ {
+		fm := float64(m)
+		// Even step
+		numerator := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		result *= d * c
+
+		// Odd step
+		numerator = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 + numerator*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + numerator/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		result *= delta
+
+		if math.Abs(delta-1) < epsilon {
+			break
+		}
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	assert.Equal(t, Summary{}, Summarize(nil))
+	assert.Equal(t, Summary{Count: 1, Mean: 3}, Summarize([]float64{3}))
+
+	s := Summarize([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	assert.Equal(t, 8, s.Count)
+	assert.InDelta(t, 5, s.Mean, 1e-12)
+	assert.InDelta(t, 32.0/7, s.Variance, 1e-12)
+	assert.InDelta(t, math.Sqrt(32.0/7/8), s.StdError(), 1e-12)
+}
+
+func TestStudentTDistribution(t *testing.T) {
+	tests := map[string]struct {
+		df       float64
+		p        float64
+		quantile float64
+	}{
+		"df 1": {
+			df:       1,
+			p:        0.975,
+			quantile: 12.706205,
+		},
+		"df 10": {
+			df:       10,
+			p:        0.975,
+			quantile: 2.228139,
+		},
+		"df 30, 90%": {
+			df:       30,
+			p:        0.95,
+			quantile: 1.697261,
+		},
+		"large df": {
+			df:       100000,
+			p:        0.975,
+			quantile: 1.959988,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.InDelta(t, data.quantile, StudentTQuantile(data.p, data.df), 1e-5)
+			assert.InDelta(t, -data.quantile, StudentTQuantile(1-data.p, data.df), 1e-5)
+			assert.InDelta(t, data.p, StudentTCDF(data.quantile, data.df), 1e-6)
+			assert.InDelta(t, 1-data.p, StudentTCDF(-data.quantile, data.df), 1e-6)
+		})
+	}
+	assert.Equal(t, 0.5, StudentTCDF(0, 5))
+}
+
+func TestMeanConfidenceInterval(t *testing.T) {
+	s := Summary{Count: 11, Mean: 10, Variance: 4}
+	lower, upper := MeanConfidenceInterval(s, 0.95)
+	margin := 2.228139 * 2 / math.Sqrt(11)
+	assert.InDelta(t, 10-margin, lower, 1e-5)
+	assert.InDelta(t, 10+margin, upper, 1e-5)
+
+	lower, upper = MeanConfidenceInterval(Summary{Count: 1, Mean: 3}, 0.95)
+	assert.Equal(t, 3.0, lower)
+	assert.Equal(t, 3.0, upper)
+}
+
+func TestWelchTTest(t *testing.T) {
+	control := Summarize([]float64{27.5, 21.0, 19.0, 23.6, 17.0, 17.9, 16.9, 20.1, 21.9, 22.6, 23.1, 19.6, 19.0, 21.7, 21.4})
+	treatment := Summarize([]float64{27.1, 22.0, 20.8, 23.4, 23.4, 23.5, 25.8, 22.0, 24.8, 20.2, 21.9, 22.1, 22.9, 20.5, 24.4})
+
+	result, err := WelchTTest(control, treatment, 0.95)
+	require.NoError(t, err)
+	// t = 2.4554, with the critical value t(0.975, 24.99) = 2.0596
+	assert.InDelta(t, 2.166667, result.Difference, 1e-6)
+	assert.InDelta(t, 24.9885, result.DegreesOfFreedom, 1e-4)
+	assert.InDelta(t, 0.02138, result.PValue, 1e-5)
+	assert.InDelta(t, 0.3492, result.DifferenceLower, 1e-4)
+	assert.InDelta(t, 3.9841, result.DifferenceUpper, 1e-4)
+
+	// Constant samples
+	result, err = WelchTTest(Summary{Count: 2, Mean: 1}, Summary{Count: 3, Mean: 2}, 0.95)
+	require.NoError(t, err)
+	assert.Equal(t, &TestResult{
+		Difference:       1,
+		DifferenceLower:  1,
+		DifferenceUpper:  1,
+		DegreesOfFreedom: 3,
+		PValue:           0,
+	}, result)
+
+	// Insufficient observations
+	_, err = WelchTTest(Summary{Count: 1}, treatment, 0.95)
+	assert.EqualError(t, err, "at least 2 observations are required per treatment")
+}
+
+func TestCUPED(t *testing.T) {
+	covariates := []float64{1, 2, 3, 4, 5, 6}
+	values := []float64{3.1, 4.9, 7.2, 8.8, 11.1, 13.0}
+
+	theta, err := CUPEDTheta(values, covariates)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.991429, theta, 1e-6)
+
+	adjusted := CUPEDAdjust(values, covariates, theta, Summarize(covariates).Mean)
+	// The adjustment preserves the mean and reduces the variance
+	assert.InDelta(t, Summarize(values).Mean, Summarize(adjusted).Mean, 1e-9)
+	assert.Less(t, Summarize(adjusted).Variance, Summarize(values).Variance/100)
+
+	// Constant covariate
+	theta, err = CUPEDTheta(values, []float64{1, 1, 1, 1, 1, 1})
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, theta)
+
+	_, err = CUPEDTheta(values, covariates[:2])
+	assert.EqualError(t, err, "the no. of values and covariates must be equal")
+	_, err = CUPEDTheta(values[:1], covariates[:1])
+	assert.EqualError(t, err, "at least 2 observations are required")
+}
@@ -28,11 +28,21 @@ const (
 // BadRequest defines model for BadRequest.
 type BadRequest externalRef0.Error
 
+// ComputeExperimentResultSuccess defines model for ComputeExperimentResultSuccess.
+type ComputeExperimentResultSuccess struct {
+	Data externalRef0.ExperimentResult `json:"data"`
+}
+
 // CreateExperimentSuccess defines model for CreateExperimentSuccess.
 type CreateExperimentSuccess struct {
 	Data externalRef0.Experiment `json:"data"`
 }
 
+// CreateProjectPasskeySuccess defines model for CreateProjectPasskeySuccess.
+type CreateProjectPasskeySuccess struct {
+	Data externalRef0.CreatedProjectPasskey `json:"data"`
+}
+
 // CreateProjectSettingsSuccess defines model for CreateProjectSettingsSuccess.
 type CreateProjectSettingsSuccess struct {
 	Data externalRef0.ProjectSettings `json:"data"`
@@ -73,6 +83,13 @@ type GetExperimentHistorySuccess struct {
 	Data externalRef0.ExperimentHistory `json:"data"`
 }
 
+// GetExperimentSRMCheckSuccess defines model for GetExperimentSRMCheckSuccess.
+type GetExperimentSRMCheckSuccess struct {
+
+	// Chi-squared test of the no. of units assigned to each treatment against the configured traffic. A mismatch is reported when the p-value is below the configured threshold.
+	Data externalRef0.SRMCheck `json:"data"`
+}
+
 // GetExperimentSuccess defines model for GetExperimentSuccess.
 type GetExperimentSuccess struct {
 	Data externalRef0.Experiment `json:"data"`
@@ -127,12 +144,38 @@ type ListExperimentHistorySuccess struct {
 	Paging *externalRef0.Paging             `json:"paging,omitempty"`
 }
 
+// ListExperimentResultsSuccess defines model for ListExperimentResultsSuccess.
+type ListExperimentResultsSuccess struct {
+	Data []externalRef0.ExperimentResult `json:"data"`
+}
+
 // ListExperimentsSuccess defines model for ListExperimentsSuccess.
 type ListExperimentsSuccess struct {
 	Data   []externalRef0.Experiment `json:"data"`
 	Paging *externalRef0.Paging      `json:"paging,omitempty"`
 }
 
+// ListGuardrailBreachesSuccess defines model for ListGuardrailBreachesSuccess.
+type ListGuardrailBreachesSuccess struct {
+	Data []externalRef0.GuardrailBreach `json:"data"`
+}
+
+// ListOutboxMessagesSuccess defines model for ListOutboxMessagesSuccess.
+type ListOutboxMessagesSuccess struct {
+	Data   []externalRef0.OutboxMessage `json:"data"`
+	Paging *externalRef0.Paging         `json:"paging,omitempty"`
+}
+
+// ListProjectPasskeyHashesSuccess defines model for ListProjectPasskeyHashesSuccess.
+type ListProjectPasskeyHashesSuccess struct {
+	Data []externalRef0.ProjectPasskeyHash `json:"data"`
+}
+
+// ListProjectPasskeysSuccess defines model for ListProjectPasskeysSuccess.
+type ListProjectPasskeysSuccess struct {
+	Data []externalRef0.ProjectPasskey `json:"data"`
+}
+
 // ListProjectsSuccess defines model for ListProjectsSuccess.
 type ListProjectsSuccess struct {
 	Data []externalRef0.Project `json:"data"`
@@ -170,6 +213,16 @@ type ListTreatmentsSuccess struct {
 // NotFound defines model for NotFound.
 type NotFound externalRef0.Error
 
+// ReplayOutboxMessageSuccess defines model for ReplayOutboxMessageSuccess.
+type ReplayOutboxMessageSuccess struct {
+	Data externalRef0.OutboxMessage `json:"data"`
+}
+
+// RevokeProjectPasskeySuccess defines model for RevokeProjectPasskeySuccess.
+type RevokeProjectPasskeySuccess struct {
+	Name *string `json:"name,omitempty"`
+}
+
 // UpdateExperimentSuccess defines model for UpdateExperimentSuccess.
 type UpdateExperimentSuccess struct {
 	Data externalRef0.Experiment `json:"data"`
@@ -195,19 +248,50 @@ type UpdateTreatmentSuccess struct {
 	Data externalRef0.Treatment `json:"data"`
 }
 
+// ComputeExperimentResultRequestBody defines model for ComputeExperimentResultRequestBody.
+type ComputeExperimentResultRequestBody struct {
+
+	// Level of the confidence intervals. It defaults to 0.95.
+	ConfidenceLevel *float64 `json:"confidence_level,omitempty"`
+
+	// Treatment to compare the others against. It defaults to "control", if present, else the first treatment.
+	ControlTreatment *string `json:"control_treatment,omitempty"`
+
+	// Apply CUPED variance reduction, using the covariates of the observations
+	Cuped *bool `json:"cuped,omitempty"`
+
+	// Version of the experiment. It defaults to the current version.
+	ExperimentVersion *int64 `json:"experiment_version,omitempty"`
+	Metric            string `json:"metric"`
+}
+
 // CreateExperimentRequestBody defines model for CreateExperimentRequestBody.
 type CreateExperimentRequestBody struct {
-	Description *string                            `json:"description"`
-	EndTime     time.Time                          `json:"end_time"`
-	Interval    *int32                             `json:"interval"`
-	Name        string                             `json:"name"`
-	Segment     externalRef0.ExperimentSegment     `json:"segment"`
-	StartTime   time.Time                          `json:"start_time"`
-	Status      externalRef0.ExperimentStatus      `json:"status"`
-	Tier        *externalRef0.ExperimentTier       `json:"tier,omitempty"`
-	Treatments  []externalRef0.ExperimentTreatment `json:"treatments"`
-	Type        externalRef0.ExperimentType        `json:"type"`
-	UpdatedBy   *string                            `json:"updated_by,omitempty"`
+	Description *string                             `json:"description"`
+	EndTime     time.Time                           `json:"end_time"`
+	Guardrails  *[]externalRef0.ExperimentGuardrail `json:"guardrails,omitempty"`
+	Interval    *int32                              `json:"interval"`
+	Name        string                              `json:"name"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
+	Segment    externalRef0.ExperimentSegment     `json:"segment"`
+	StartTime  time.Time                          `json:"start_time"`
+	Status     externalRef0.ExperimentStatus      `json:"status"`
+	Tier       *externalRef0.ExperimentTier       `json:"tier,omitempty"`
+	Treatments []externalRef0.ExperimentTreatment `json:"treatments"`
+	Type       externalRef0.ExperimentType        `json:"type"`
+	UpdatedBy  *string                            `json:"updated_by,omitempty"`
+}
+
+// CreateProjectPasskeyRequestBody defines model for CreateProjectPasskeyRequestBody.
+type CreateProjectPasskeyRequestBody struct {
+
+	// Time after which the passkey is no longer accepted. The passkey does not expire if unset.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
 }
 
 // CreateProjectSettingsRequestBody defines model for CreateProjectSettingsRequestBody.
@@ -223,20 +307,30 @@ type CreateProjectSettingsRequestBody struct {
 
 // CreateSegmentRequestBody defines model for CreateSegmentRequestBody.
 type CreateSegmentRequestBody struct {
-	Name      string                         `json:"name"`
+	Name string `json:"name"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
 	Segment   externalRef0.ExperimentSegment `json:"segment"`
 	UpdatedBy *string                        `json:"updated_by,omitempty"`
 }
 
 // CreateSegmenterRequestBody defines model for CreateSegmenterRequestBody.
 type CreateSegmenterRequestBody struct {
-	Constraints *[]externalRef0.Constraint     `json:"constraints,omitempty"`
-	Description *string                        `json:"description,omitempty"`
-	MultiValued bool                           `json:"multi_valued"`
-	Name        string                         `json:"name"`
-	Options     *externalRef0.SegmenterOptions `json:"options,omitempty"`
-	Required    bool                           `json:"required"`
-	Type        externalRef0.SegmenterType     `json:"type"`
+	Constraints *[]externalRef0.Constraint `json:"constraints,omitempty"`
+	Description *string                    `json:"description,omitempty"`
+	Expression  *string                    `json:"expression,omitempty"`
+
+	// How the values of a string segmenter are matched against the value in the treatment request. The values are exact strings by default, or prefixes or RE2 regular expressions when the prefix or regex mode is chosen.
+	MatchMode     *externalRef0.SegmenterMatchMode `json:"match_mode,omitempty"`
+	MultiValued   bool                             `json:"multi_valued"`
+	Name          string                           `json:"name"`
+	Options       *externalRef0.SegmenterOptions   `json:"options,omitempty"`
+	RequestFields *[]string                        `json:"request_fields,omitempty"`
+	Required      bool                             `json:"required"`
+	Type          externalRef0.SegmenterType       `json:"type"`
 }
 
 // CreateTreatmentRequestBody defines model for CreateTreatmentRequestBody.
@@ -246,18 +340,34 @@ type CreateTreatmentRequestBody struct {
 	UpdatedBy     *string                `json:"updated_by,omitempty"`
 }
 
+// IngestExperimentAssignmentsRequestBody defines model for IngestExperimentAssignmentsRequestBody.
+type IngestExperimentAssignmentsRequestBody struct {
+	Assignments []externalRef0.ExperimentAssignment `json:"assignments"`
+}
+
+// IngestMetricObservationsRequestBody defines model for IngestMetricObservationsRequestBody.
+type IngestMetricObservationsRequestBody struct {
+	Observations []externalRef0.MetricObservation `json:"observations"`
+}
+
 // UpdateExperimentRequestBody defines model for UpdateExperimentRequestBody.
 type UpdateExperimentRequestBody struct {
-	Description *string                            `json:"description"`
-	EndTime     time.Time                          `json:"end_time"`
-	Interval    *int32                             `json:"interval"`
-	Segment     externalRef0.ExperimentSegment     `json:"segment"`
-	StartTime   time.Time                          `json:"start_time"`
-	Status      externalRef0.ExperimentStatus      `json:"status"`
-	Tier        *externalRef0.ExperimentTier       `json:"tier,omitempty"`
-	Treatments  []externalRef0.ExperimentTreatment `json:"treatments"`
-	Type        externalRef0.ExperimentType        `json:"type"`
-	UpdatedBy   *string                            `json:"updated_by,omitempty"`
+	Description *string                             `json:"description"`
+	EndTime     time.Time                           `json:"end_time"`
+	Guardrails  *[]externalRef0.ExperimentGuardrail `json:"guardrails,omitempty"`
+	Interval    *int32                              `json:"interval"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
+	Segment    externalRef0.ExperimentSegment     `json:"segment"`
+	StartTime  time.Time                          `json:"start_time"`
+	Status     externalRef0.ExperimentStatus      `json:"status"`
+	Tier       *externalRef0.ExperimentTier       `json:"tier,omitempty"`
+	Treatments []externalRef0.ExperimentTreatment `json:"treatments"`
+	Type       externalRef0.ExperimentType        `json:"type"`
+	UpdatedBy  *string                            `json:"updated_by,omitempty"`
 }
 
 // UpdateProjectSettingsRequestBody defines model for UpdateProjectSettingsRequestBody.
@@ -273,6 +383,11 @@ type UpdateProjectSettingsRequestBody struct {
 
 // UpdateSegmentRequestBody defines model for UpdateSegmentRequestBody.
 type UpdateSegmentRequestBody struct {
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
 	Segment   externalRef0.ExperimentSegment `json:"segment"`
 	UpdatedBy *string                        `json:"updated_by,omitempty"`
 }
@@ -301,6 +416,17 @@ type ValidateEntityRequestBody struct {
 	ValidationUrl   *string                       `json:"validation_url,omitempty"`
 }
 
+// ListOutboxMessagesParams defines parameters for ListOutboxMessages.
+type ListOutboxMessagesParams struct {
+	Status *externalRef0.OutboxMessageStatus `json:"status,omitempty"`
+
+	// Result page number. It defaults to 1.
+	Page *int32 `json:"page,omitempty"`
+
+	// Number of items on each page. It defaults to 10.
+	PageSize *int32 `json:"page_size,omitempty"`
+}
+
 // ListExperimentsParams defines parameters for ListExperiments.
 type ListExperimentsParams struct {
 	Status *externalRef0.ExperimentStatus `json:"status,omitempty"`
@@ -349,6 +475,21 @@ type ListExperimentHistoryParams struct {
 	PageSize *int32 `json:"page_size,omitempty"`
 }
 
+// ListExperimentResultsParams defines parameters for ListExperimentResults.
+type ListExperimentResultsParams struct {
+
+	// Version of the experiment. It defaults to the current version.
+	ExperimentVersion *int64  `json:"experiment_version,omitempty"`
+	Metric            *string `json:"metric,omitempty"`
+}
+
+// GetExperimentSRMCheckParams defines parameters for GetExperimentSRMCheck.
+type GetExperimentSRMCheckParams struct {
+
+	// Version of the experiment. It defaults to the current version.
+	ExperimentVersion *int64 `json:"experiment_version,omitempty"`
+}
+
 // ListSegmentersParams defines parameters for ListSegmenters.
 type ListSegmentersParams struct {
 	Scope  *externalRef0.SegmenterScope  `json:"scope,omitempty"`
@@ -420,6 +561,18 @@ type CreateExperimentJSONRequestBody CreateExperimentRequestBody
 // UpdateExperimentJSONRequestBody defines body for UpdateExperiment for application/json ContentType.
 type UpdateExperimentJSONRequestBody UpdateExperimentRequestBody
 
+// IngestExperimentAssignmentsJSONRequestBody defines body for IngestExperimentAssignments for application/json ContentType.
+type IngestExperimentAssignmentsJSONRequestBody IngestExperimentAssignmentsRequestBody
+
+// IngestMetricObservationsJSONRequestBody defines body for IngestMetricObservations for application/json ContentType.
+type IngestMetricObservationsJSONRequestBody IngestMetricObservationsRequestBody
+
+// ComputeExperimentResultJSONRequestBody defines body for ComputeExperimentResult for application/json ContentType.
+type ComputeExperimentResultJSONRequestBody ComputeExperimentResultRequestBody
+
+// CreateProjectPasskeyJSONRequestBody defines body for CreateProjectPasskey for application/json ContentType.
+type CreateProjectPasskeyJSONRequestBody CreateProjectPasskeyRequestBody
+
 // CreateSegmenterJSONRequestBody defines body for CreateSegmenter for application/json ContentType.
 type CreateSegmenterJSONRequestBody CreateSegmenterRequestBody
 
@@ -449,6 +602,12 @@ type ValidateEntityJSONRequestBody ValidateEntityRequestBody
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List the messages in the outbox, to inspect those that could not be published
+	// (GET /outbox/messages)
+	ListOutboxMessages(w http.ResponseWriter, r *http.Request, params ListOutboxMessagesParams)
+	// Reschedule a pending or failed outbox message to be published immediately
+	// (PUT /outbox/messages/{message_id}/replay)
+	ReplayOutboxMessage(w http.ResponseWriter, r *http.Request, messageId int64)
 	// List info of all projects set up for Experimentation
 	// (GET /projects)
 	ListProjects(w http.ResponseWriter, r *http.Request)
@@ -467,18 +626,51 @@ type ServerInterface interface {
 	// Update an experiment with the given experiment_id and project_id
 	// (PUT /projects/{project_id}/experiments/{experiment_id})
 	UpdateExperiment(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64)
+	// Ingest the treatments that were assigned to the units of an experiment, for the analysis
+	// (POST /projects/{project_id}/experiments/{experiment_id}/assignments)
+	IngestExperimentAssignments(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64)
 	// Disable an experiment with the given experiment_id and project_id
 	// (PUT /projects/{project_id}/experiments/{experiment_id}/disable)
 	DisableExperiment(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64)
 	// Enable an experiment with the given experiment_id and project_id
 	// (PUT /projects/{project_id}/experiments/{experiment_id}/enable)
 	EnableExperiment(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64)
+	// List the guardrail breaches that disabled an experiment
+	// (GET /projects/{project_id}/experiments/{experiment_id}/guardrail-breaches)
+	ListGuardrailBreaches(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64)
 	// List an experiment's historical versions
 	// (GET /projects/{project_id}/experiments/{experiment_id}/history)
 	ListExperimentHistory(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64, params ListExperimentHistoryParams)
 	// List an experiment's historical versions
 	// (GET /projects/{project_id}/experiments/{experiment_id}/history/{version})
 	GetExperimentHistory(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64, version int64)
+	// Ingest the metric observations of the units of an experiment, for the analysis
+	// (POST /projects/{project_id}/experiments/{experiment_id}/observations)
+	IngestMetricObservations(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64)
+	// Upload a CSV file of metric observations, with the header metric,unit_id,value[,covariate]
+	// (POST /projects/{project_id}/experiments/{experiment_id}/observations/upload)
+	UploadMetricObservations(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64)
+	// List the computed results of an experiment version
+	// (GET /projects/{project_id}/experiments/{experiment_id}/results)
+	ListExperimentResults(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64, params ListExperimentResultsParams)
+	// Compute and store the per-treatment statistics of a metric, for an experiment version
+	// (POST /projects/{project_id}/experiments/{experiment_id}/results)
+	ComputeExperimentResult(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64)
+	// Get the latest sample ratio mismatch check of an experiment version
+	// (GET /projects/{project_id}/experiments/{experiment_id}/srm)
+	GetExperimentSRMCheck(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64, params GetExperimentSRMCheckParams)
+	// List the passkeys of the given project that have not been revoked
+	// (GET /projects/{project_id}/passkeys)
+	ListProjectPasskeys(w http.ResponseWriter, r *http.Request, projectId int64)
+	// Generate a new passkey for the given project
+	// (POST /projects/{project_id}/passkeys)
+	CreateProjectPasskey(w http.ResponseWriter, r *http.Request, projectId int64)
+	// List the hashes of the passkeys of the given project, for the Treatment Service to authenticate requests
+	// (GET /projects/{project_id}/passkeys/hashes)
+	ListProjectPasskeyHashes(w http.ResponseWriter, r *http.Request, projectId int64)
+	// Revoke a passkey of the given project
+	// (DELETE /projects/{project_id}/passkeys/{name})
+	RevokeProjectPasskey(w http.ResponseWriter, r *http.Request, projectId int64, name string)
 	// Get all segmenter configurations required for generating experiments for the given project
 	// (GET /projects/{project_id}/segmenters)
 	ListSegmenters(w http.ResponseWriter, r *http.Request, projectId int64, params ListSegmentersParams)
@@ -565,6 +757,93 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
 
+// ListOutboxMessages operation middleware
+func (siw *ServerInterfaceWrapper) ListOutboxMessages(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListOutboxMessagesParams
+	paramsSet := map[string]bool{}
+
+	// ------------- Optional query parameter "status" -------------
+	if paramValue := r.URL.Query().Get("status"); paramValue != "" {
+		paramsSet["status"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter status: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+	if paramValue := r.URL.Query().Get("page"); paramValue != "" {
+		paramsSet["page"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter page: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+	if paramValue := r.URL.Query().Get("page_size"); paramValue != "" {
+		paramsSet["page_size"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter page_size: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListOutboxMessages(w, r, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ReplayOutboxMessage operation middleware
+func (siw *ServerInterfaceWrapper) ReplayOutboxMessage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "message_id" -------------
+	var messageId int64
+
+	err = runtime.BindStyledParameter("simple", false, "message_id", chi.URLParam(r, "message_id"), &messageId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter message_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReplayOutboxMessage(w, r, messageId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // ListProjects operation middleware
 func (siw *ServerInterfaceWrapper) ListProjects(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -697,36 +976,407 @@ func (siw *ServerInterfaceWrapper) ListExperiments(w http.ResponseWriter, r *htt
 
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter name: %s", err), http.StatusBadRequest)
-		return
-	}
+	err = runtime.BindQueryParameter("form", true, false, "name", r.URL.Query(), &params.Name)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter name: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "updated_by" -------------
+	if paramValue := r.URL.Query().Get("updated_by"); paramValue != "" {
+		paramsSet["updated_by"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "updated_by", r.URL.Query(), &params.UpdatedBy)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter updated_by: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "search" -------------
+	if paramValue := r.URL.Query().Get("search"); paramValue != "" {
+		paramsSet["search"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "search", r.URL.Query(), &params.Search)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter search: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+	if paramValue := r.URL.Query().Get("page"); paramValue != "" {
+		paramsSet["page"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter page: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+	if paramValue := r.URL.Query().Get("page_size"); paramValue != "" {
+		paramsSet["page_size"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter page_size: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "start_time" -------------
+	if paramValue := r.URL.Query().Get("start_time"); paramValue != "" {
+		paramsSet["start_time"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "start_time", r.URL.Query(), &params.StartTime)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter start_time: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "segment" -------------
+	if paramValue := r.URL.Query().Get("segment"); paramValue != "" {
+		paramsSet["segment"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "segment", r.URL.Query(), &params.Segment)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter segment: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "include_weak_match" -------------
+	if paramValue := r.URL.Query().Get("include_weak_match"); paramValue != "" {
+		paramsSet["include_weak_match"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "include_weak_match", r.URL.Query(), &params.IncludeWeakMatch)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter include_weak_match: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+	if paramValue := r.URL.Query().Get("fields"); paramValue != "" {
+		paramsSet["fields"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", r.URL.Query(), &params.Fields)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter fields: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	allSegmenters := map[string]interface{}{}
+	for k, v := range r.URL.Query() {
+		if _, contains := paramsSet[k]; !contains {
+			allSegmenters[k] = v
+		}
+	}
+	params.Segment = &allSegmenters
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListExperiments(w, r, projectId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CreateExperiment operation middleware
+func (siw *ServerInterfaceWrapper) CreateExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateExperiment(w, r, projectId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetExperiment operation middleware
+func (siw *ServerInterfaceWrapper) GetExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "experiment_id" -------------
+	var experimentId int64
+
+	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetExperiment(w, r, projectId, experimentId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// UpdateExperiment operation middleware
+func (siw *ServerInterfaceWrapper) UpdateExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "experiment_id" -------------
+	var experimentId int64
+
+	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateExperiment(w, r, projectId, experimentId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// IngestExperimentAssignments operation middleware
+func (siw *ServerInterfaceWrapper) IngestExperimentAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "experiment_id" -------------
+	var experimentId int64
+
+	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.IngestExperimentAssignments(w, r, projectId, experimentId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// DisableExperiment operation middleware
+func (siw *ServerInterfaceWrapper) DisableExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "experiment_id" -------------
+	var experimentId int64
+
+	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DisableExperiment(w, r, projectId, experimentId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// EnableExperiment operation middleware
+func (siw *ServerInterfaceWrapper) EnableExperiment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "experiment_id" -------------
+	var experimentId int64
+
+	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.EnableExperiment(w, r, projectId, experimentId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ListGuardrailBreaches operation middleware
+func (siw *ServerInterfaceWrapper) ListGuardrailBreaches(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "experiment_id" -------------
+	var experimentId int64
+
+	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListGuardrailBreaches(w, r, projectId, experimentId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
 
-	// ------------- Optional query parameter "updated_by" -------------
-	if paramValue := r.URL.Query().Get("updated_by"); paramValue != "" {
-		paramsSet["updated_by"] = true
+// ListExperimentHistory operation middleware
+func (siw *ServerInterfaceWrapper) ListExperimentHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	}
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "updated_by", r.URL.Query(), &params.UpdatedBy)
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter updated_by: %s", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "search" -------------
-	if paramValue := r.URL.Query().Get("search"); paramValue != "" {
-		paramsSet["search"] = true
-
-	}
+	// ------------- Path parameter "experiment_id" -------------
+	var experimentId int64
 
-	err = runtime.BindQueryParameter("form", true, false, "search", r.URL.Query(), &params.Search)
+	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter search: %s", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListExperimentHistoryParams
+	paramsSet := map[string]bool{}
+
 	// ------------- Optional query parameter "page" -------------
 	if paramValue := r.URL.Query().Get("page"); paramValue != "" {
 		paramsSet["page"] = true
@@ -751,64 +1401,54 @@ func (siw *ServerInterfaceWrapper) ListExperiments(w http.ResponseWriter, r *htt
 		return
 	}
 
-	// ------------- Optional query parameter "start_time" -------------
-	if paramValue := r.URL.Query().Get("start_time"); paramValue != "" {
-		paramsSet["start_time"] = true
-
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListExperimentHistory(w, r, projectId, experimentId, params)
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "start_time", r.URL.Query(), &params.StartTime)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter start_time: %s", err), http.StatusBadRequest)
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "segment" -------------
-	if paramValue := r.URL.Query().Get("segment"); paramValue != "" {
-		paramsSet["segment"] = true
+	handler(w, r.WithContext(ctx))
+}
 
-	}
+// GetExperimentHistory operation middleware
+func (siw *ServerInterfaceWrapper) GetExperimentHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "segment", r.URL.Query(), &params.Segment)
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter segment: %s", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "include_weak_match" -------------
-	if paramValue := r.URL.Query().Get("include_weak_match"); paramValue != "" {
-		paramsSet["include_weak_match"] = true
-
-	}
+	// ------------- Path parameter "experiment_id" -------------
+	var experimentId int64
 
-	err = runtime.BindQueryParameter("form", true, false, "include_weak_match", r.URL.Query(), &params.IncludeWeakMatch)
+	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter include_weak_match: %s", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "fields" -------------
-	if paramValue := r.URL.Query().Get("fields"); paramValue != "" {
-		paramsSet["fields"] = true
-
-	}
+	// ------------- Path parameter "version" -------------
+	var version int64
 
-	err = runtime.BindQueryParameter("form", true, false, "fields", r.URL.Query(), &params.Fields)
+	err = runtime.BindStyledParameter("simple", false, "version", chi.URLParam(r, "version"), &version)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter fields: %s", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	allSegmenters := map[string]interface{}{}
-	for k, v := range r.URL.Query() {
-		if _, contains := paramsSet[k]; !contains {
-			allSegmenters[k] = v
-		}
-	}
-	params.Segment = &allSegmenters
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListExperiments(w, r, projectId, params)
+		siw.Handler.GetExperimentHistory(w, r, projectId, experimentId, version)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -818,8 +1458,8 @@ func (siw *ServerInterfaceWrapper) ListExperiments(w http.ResponseWriter, r *htt
 	handler(w, r.WithContext(ctx))
 }
 
-// CreateExperiment operation middleware
-func (siw *ServerInterfaceWrapper) CreateExperiment(w http.ResponseWriter, r *http.Request) {
+// IngestMetricObservations operation middleware
+func (siw *ServerInterfaceWrapper) IngestMetricObservations(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -833,10 +1473,19 @@ func (siw *ServerInterfaceWrapper) CreateExperiment(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// ------------- Path parameter "experiment_id" -------------
+	var experimentId int64
+
+	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateExperiment(w, r, projectId)
+		siw.Handler.IngestMetricObservations(w, r, projectId, experimentId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -846,8 +1495,8 @@ func (siw *ServerInterfaceWrapper) CreateExperiment(w http.ResponseWriter, r *ht
 	handler(w, r.WithContext(ctx))
 }
 
-// GetExperiment operation middleware
-func (siw *ServerInterfaceWrapper) GetExperiment(w http.ResponseWriter, r *http.Request) {
+// UploadMetricObservations operation middleware
+func (siw *ServerInterfaceWrapper) UploadMetricObservations(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -873,7 +1522,7 @@ func (siw *ServerInterfaceWrapper) GetExperiment(w http.ResponseWriter, r *http.
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetExperiment(w, r, projectId, experimentId)
+		siw.Handler.UploadMetricObservations(w, r, projectId, experimentId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -883,8 +1532,8 @@ func (siw *ServerInterfaceWrapper) GetExperiment(w http.ResponseWriter, r *http.
 	handler(w, r.WithContext(ctx))
 }
 
-// UpdateExperiment operation middleware
-func (siw *ServerInterfaceWrapper) UpdateExperiment(w http.ResponseWriter, r *http.Request) {
+// ListExperimentResults operation middleware
+func (siw *ServerInterfaceWrapper) ListExperimentResults(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -909,8 +1558,36 @@ func (siw *ServerInterfaceWrapper) UpdateExperiment(w http.ResponseWriter, r *ht
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListExperimentResultsParams
+	paramsSet := map[string]bool{}
+
+	// ------------- Optional query parameter "experiment_version" -------------
+	if paramValue := r.URL.Query().Get("experiment_version"); paramValue != "" {
+		paramsSet["experiment_version"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "experiment_version", r.URL.Query(), &params.ExperimentVersion)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_version: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "metric" -------------
+	if paramValue := r.URL.Query().Get("metric"); paramValue != "" {
+		paramsSet["metric"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "metric", r.URL.Query(), &params.Metric)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter metric: %s", err), http.StatusBadRequest)
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.UpdateExperiment(w, r, projectId, experimentId)
+		siw.Handler.ListExperimentResults(w, r, projectId, experimentId, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -920,8 +1597,8 @@ func (siw *ServerInterfaceWrapper) UpdateExperiment(w http.ResponseWriter, r *ht
 	handler(w, r.WithContext(ctx))
 }
 
-// DisableExperiment operation middleware
-func (siw *ServerInterfaceWrapper) DisableExperiment(w http.ResponseWriter, r *http.Request) {
+// ComputeExperimentResult operation middleware
+func (siw *ServerInterfaceWrapper) ComputeExperimentResult(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -947,7 +1624,7 @@ func (siw *ServerInterfaceWrapper) DisableExperiment(w http.ResponseWriter, r *h
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DisableExperiment(w, r, projectId, experimentId)
+		siw.Handler.ComputeExperimentResult(w, r, projectId, experimentId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -957,8 +1634,8 @@ func (siw *ServerInterfaceWrapper) DisableExperiment(w http.ResponseWriter, r *h
 	handler(w, r.WithContext(ctx))
 }
 
-// EnableExperiment operation middleware
-func (siw *ServerInterfaceWrapper) EnableExperiment(w http.ResponseWriter, r *http.Request) {
+// GetExperimentSRMCheck operation middleware
+func (siw *ServerInterfaceWrapper) GetExperimentSRMCheck(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -983,8 +1660,24 @@ func (siw *ServerInterfaceWrapper) EnableExperiment(w http.ResponseWriter, r *ht
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetExperimentSRMCheckParams
+	paramsSet := map[string]bool{}
+
+	// ------------- Optional query parameter "experiment_version" -------------
+	if paramValue := r.URL.Query().Get("experiment_version"); paramValue != "" {
+		paramsSet["experiment_version"] = true
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "experiment_version", r.URL.Query(), &params.ExperimentVersion)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_version: %s", err), http.StatusBadRequest)
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.EnableExperiment(w, r, projectId, experimentId)
+		siw.Handler.GetExperimentSRMCheck(w, r, projectId, experimentId, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -994,8 +1687,8 @@ func (siw *ServerInterfaceWrapper) EnableExperiment(w http.ResponseWriter, r *ht
 	handler(w, r.WithContext(ctx))
 }
 
-// ListExperimentHistory operation middleware
-func (siw *ServerInterfaceWrapper) ListExperimentHistory(w http.ResponseWriter, r *http.Request) {
+// ListProjectPasskeys operation middleware
+func (siw *ServerInterfaceWrapper) ListProjectPasskeys(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1009,47 +1702,66 @@ func (siw *ServerInterfaceWrapper) ListExperimentHistory(w http.ResponseWriter,
 		return
 	}
 
-	// ------------- Path parameter "experiment_id" -------------
-	var experimentId int64
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
-	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
-		return
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListProjectPasskeys(w, r, projectId)
 	}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListExperimentHistoryParams
-	paramsSet := map[string]bool{}
+	handler(w, r.WithContext(ctx))
+}
 
-	// ------------- Optional query parameter "page" -------------
-	if paramValue := r.URL.Query().Get("page"); paramValue != "" {
-		paramsSet["page"] = true
+// CreateProjectPasskey operation middleware
+func (siw *ServerInterfaceWrapper) CreateProjectPasskey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-	}
+	var err error
 
-	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter page: %s", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
 		return
 	}
 
-	// ------------- Optional query parameter "page_size" -------------
-	if paramValue := r.URL.Query().Get("page_size"); paramValue != "" {
-		paramsSet["page_size"] = true
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProjectPasskey(w, r, projectId)
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "page_size", r.URL.Query(), &params.PageSize)
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ListProjectPasskeyHashes operation middleware
+func (siw *ServerInterfaceWrapper) ListProjectPasskeyHashes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter page_size: %s", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
 		return
 	}
 
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListExperimentHistory(w, r, projectId, experimentId, params)
+		siw.Handler.ListProjectPasskeyHashes(w, r, projectId)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -1059,8 +1771,8 @@ func (siw *ServerInterfaceWrapper) ListExperimentHistory(w http.ResponseWriter,
 	handler(w, r.WithContext(ctx))
 }
 
-// GetExperimentHistory operation middleware
-func (siw *ServerInterfaceWrapper) GetExperimentHistory(w http.ResponseWriter, r *http.Request) {
+// RevokeProjectPasskey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeProjectPasskey(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
@@ -1074,28 +1786,19 @@ func (siw *ServerInterfaceWrapper) GetExperimentHistory(w http.ResponseWriter, r
 		return
 	}
 
-	// ------------- Path parameter "experiment_id" -------------
-	var experimentId int64
-
-	err = runtime.BindStyledParameter("simple", false, "experiment_id", chi.URLParam(r, "experiment_id"), &experimentId)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter experiment_id: %s", err), http.StatusBadRequest)
-		return
-	}
-
-	// ------------- Path parameter "version" -------------
-	var version int64
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	err = runtime.BindStyledParameter("simple", false, "version", chi.URLParam(r, "version"), &version)
+	err = runtime.BindStyledParameter("simple", false, "name", chi.URLParam(r, "name"), &name)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Invalid format for parameter version: %s", err), http.StatusBadRequest)
+		http.Error(w, fmt.Sprintf("Invalid format for parameter name: %s", err), http.StatusBadRequest)
 		return
 	}
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
 
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetExperimentHistory(w, r, projectId, experimentId, version)
+		siw.Handler.RevokeProjectPasskey(w, r, projectId, name)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -2151,6 +2854,12 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		HandlerMiddlewares: options.Middlewares,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/outbox/messages", wrapper.ListOutboxMessages)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/outbox/messages/{message_id}/replay", wrapper.ReplayOutboxMessage)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/projects", wrapper.ListProjects)
 	})
@@ -2169,18 +2878,51 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}", wrapper.UpdateExperiment)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/assignments", wrapper.IngestExperimentAssignments)
+	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/disable", wrapper.DisableExperiment)
 	})
 	r.Group(func(r chi.Router) {
 		r.Put(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/enable", wrapper.EnableExperiment)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/guardrail-breaches", wrapper.ListGuardrailBreaches)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/history", wrapper.ListExperimentHistory)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/history/{version}", wrapper.GetExperimentHistory)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/observations", wrapper.IngestMetricObservations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/observations/upload", wrapper.UploadMetricObservations)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/results", wrapper.ListExperimentResults)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/results", wrapper.ComputeExperimentResult)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/srm", wrapper.GetExperimentSRMCheck)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/projects/{project_id}/passkeys", wrapper.ListProjectPasskeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/projects/{project_id}/passkeys", wrapper.CreateProjectPasskey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/projects/{project_id}/passkeys/hashes", wrapper.ListProjectPasskeyHashes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/projects/{project_id}/passkeys/{name}", wrapper.RevokeProjectPasskey)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/projects/{project_id}/segmenters", wrapper.ListSegmenters)
 	})
@@ -2260,65 +3002,88 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xdW5PbthX+Kxi2M21nuJKduH3Yt8RxHM+0qcd20od4ZxcijyQkFKAAoDbKjv57BxeS",
-	"AC8SRXFFar1vtpYEcC445zsXgA9BxFZrRoFKEVw/BBx+T0HIb1lMQP/wmgOW8OaPNXCyAio/5A9s1Z8j",
-	"RiVQqf6J1+uERFgSRqe/CkbVbyJawgqrf605WwOXdtQYRMTJWj2r/kvTJMGzBIJryVMIA7ldQ3AdCMkJ",
-	"XQS7MAAa30qyAvXwnPEVlsF1EGMJV/rXmjcIlcA3OPHeIFR+/VUQNs2n3lkAV69TbCarjCtgsbIE/5XD",
-	"XP1N0zjZ4lXyl2nBzan5XUwL3n2076phJObySJKExDIV3WY2r+7CQBLgnYb4RAxnpFKIVaYvRMKq25I+",
-	"ZePoQQ2tmHO8Lf7fZVT14i4M0rViZXw729ZIcRdqPScc4uD6l0K5rNgLIXtyygXg8cCu9Sangc1+hUgG",
-	"O38WpWe70O6m95ypZz6ClIQuRD9bCqjS6FvxFYlvoyQVEjSxBfUzxhLAVHGHYxqzFflTj3z7G2z3qTrw",
-	"YwSc05a/6+rMbbH6luPlavLRvLkLgw1OSGyWnvLksHyr1Hq0HSU6S1c/IntsI3PMJihpfhemAO+HLRGj",
-	"QnJMOpqY1/nrdZal5HkqrF+liSS3G5ykisy6zdMoNaZHPWapOeP+a1/1eFw3+ZGGMZ/A2MV6mesxS5Q7",
-	"Dx6lCvl27U0V5mSRclySV7aSPdLooPz+bC3p/knP88UBpGcc9ORwkKtzoYuKclV5RGRkttEzMho7MspF",
-	"1SsSGgDwHIl0PKK/EKTz+ICmJJMTIYiR0dkhyDFa1wli/Gy2NbyhkshtTwADS1xLzbAWSS+rFVv0L2LN",
-	"qDAEfYtjy5mjuNLW3HDOuFmHt6/UtMgmzYIcBTvGKY0iEKIHQR1tF4/hrU+TIUIgTBHkw6E540guAS3I",
-	"BihaG28WNCU1zk54af7TqS9I1+tFwo6cM8KyAN0Tuaxy5pbEQTlCPjtTct94siog6y8PqUHuAYaiVaHz",
-	"3qgFfojewuadm14ncDid3tzqN9P7HSTQoyYTFxDkgeWuxarNQuJMRpW19aF7DWmFDssz0aL5sUdlOZ19",
-	"0g0834IsPMcPREjGtwP6LruC7pr9FqTWYrGGiMwJxGiphyQRTtAGuFAWnc19F1dhxEV67w8gU05d/4Vi",
-	"kJgkwniqspdCmMbOw9ZvvQVpPWqxpp8xJyqU7tG55yFPJTzxw5lTmWEhGlpjjlegoltt6LBr4AqSLx/E",
-	"KP1vBDBs3h6/vIUswB7KKvjTn8EkuI6lIP/ysFum+xly62YFLhrQKZkXUK6t3mteaARgWJC77aG2QHkB",
-	"59gEZXhQYBfgGxLBa53JGI4V3jJO3yQFABZmYOSlalDMtZLMtpp9K0zxAtzHK1y6wHCgyosOJuOd2moU",
-	"J0o+wE3e5JwJmWx+ZBaA7INh8G8iHhPjdq8A5Zu6msNd44UtVbQFEOaFziqgmKT3f5LUWAZRC5l9xoox",
-	"sHQUvKwCcdHscybo3RzpJRp7Y3Pv6B44oFRAHOrXOIg0kQJhDkhEbA0xwlHEeEzoItnqHaldnF46InTO",
-	"EKHZmzpZimYs3iKiYIGcZOKzuHNA2b0vcHi/yD8zYVapLcc1+Shd6yigBJQzpjwW7j2WNWUAfCFmwoXR",
-	"DjuBi8FZaSv4veiZBzGPi60crgzPk1GZTMvQvfbyU8kcWuMJcWcr+Hg4/1iZVAH/pWx6L2zwmCpGwM5R",
-	"KXnRKzNGWPAjk9+zlMZnBe8fQLCUR4Aok2iup69psbvItKwhIi5h59q2p8tNOxpyRD+pR6/l5vLSb5nA",
-	"HRhUaiK6xIxaiSqDpEqNN5eY+8jokt5QAqKUE7nVDS1maTPAHPg3qVzmBOiWJv1z0a66lHJt5lHWttLS",
-	"G7z+8NN36Jv370QpAnFSS2owIhM12pvSfvpPkX9SYwRhYL1wcB1sXpreLaB4TYLr4OvJi8nLQPk5udQU",
-	"TLMYSP1nAVo4ivl66Hex9fRZSBiU+my+evHCkYwnjvy5aV1MuQuDf7Z5ty6BpGWRrlaYbzMgop3YnqCu",
-	"xDLFTLwQSieyks+NGjVnxvShsD67aSGQq01W9Wpk195ameZ8VnQKrn95CIiSkpJGduDoOnAMX7nRKXQ2",
-	"idun/a9XQbUve3fTRVqtan27MHj14tXhwXLc0J+8VYilxVwU7zIeaVEvgGpx0IWLqeobGbqqwf7N4qS/",
-	"zivv0A7/ewp8W4yf92MfD80qvfJqDt92mdFv55wAjRONGjGK2GqWo1Tj5c1zaE4giUMFOCNGf01ppJ/J",
-	"XX9sU+zhZyqXWCpJxWmku1JSAfwqnyZKsBBkbn1INoljOs18ICbof0tQ8JaIQmc+UwVuU+WEMtRsng9R",
-	"0cpuUtq28x3NSaKVLcIU4UQwNAMNj9EP7B42wM0oc0Jx8pkaCI7uWZrE6kFMke6bFxC5y3W8oPoJcLS0",
-	"fxL5hJPPVHfdN8k157wn4O7ZUiPp77NBa1IjZQ34SShXyRYgl8ALURaMDJFklhwv/6klrKIULFEC2FTk",
-	"JcFJskU8pdSEJ3owQtepRBzTBUwa2OGcUajZNHsOkTTtG30q5KRdY46HNI5vjlqdMr49yFU/fnaKLx+/",
-	"Jd1OC/OBt309+AiYR0t3D6oB9S5yHsxaLYyk0QrLXOmRMCNI+EM26bx+4rh1fTCbcY0XgGi6mgGfoHcS",
-	"xTDHOk6WDL1sUir1UtBkhfXpqDor7M//o55T0ah3JWLU7HQ1dnUlL/Yt5VaQP09eT8N+zfbPeXarf2Ko",
-	"l/3qHEcqK0cO9SvMUDEJZ4lA90vDD8Z1vuEe8G9Ot4BWUxDo7179ZgkcrOJmDxJh0zI4+QcSy8wBcJ3m",
-	"0QcZ6pZOaJSkMdyqWW/1XHVUOCclymR8gwQkEEkFcxjioHgVmep+YjN12RKQYYaw5WvCjU8WOruUUgEy",
-	"1ADLuDP1F3RPksSlYvKZvs8TrDl4qzyGyBzNmFwqngIx3J2jO6XId9os3OU6fefiOZ3A5WxDYj1VA8/M",
-	"2nryet+rwWqc3U3XgKemBqpBc4vXncML/cJmV3e9hjd0P+ETOUGaw0YSwgHHTpbqZhcGayZqkG/5sMMA",
-	"oY53FKaeYc6FJtN9t5nsusi96bzHoII3i0IYUbgvn+DANZGQK+ww+OMqYjEsgF5Z3l3NWLy9suJr4GDQ",
-	"LoiaPnj9Irt9IfVQehXWDu/3uQwTpDeo2XBBuVtb984KlfK6HvO0G/Ck1WR00hrFKNcCnqRuHGnV9l1B",
-	"0MmqNRVcBrVqZlG9K9ohg9fA3I4GbxoTYS5WeKjX7+/M378k4/eqmh23XChXy4aydXY5/Ru5bjpkLjRo",
-	"VKE39FmDLBPGokBmNWPRn6VtKmmX0c5aUJ6YFj3njHoApXt7qgfcb7pC6O22v4m6nqVH2VfTBzt8y/Dm",
-	"6W6wmhmyOvXQEdSXoKv+NUWNpt65kWgUtcuIdSuSFH01eoRHKI4WMzTWRm1FpDhCowsij10A6Wy8q03Z",
-	"I6jzF6l17wxWc9W/nOXcV/Z377c6kNks2qUuJLFZez3VCXnNSqfaeNKalttX9iBj5DSkNci6jZ2cPihh",
-	"7kw4kYCEmgDdv2piDF7bVn2bB+7FXDTcsTGoSpg1FUntI9QhbERmX6Bs6855D+wItA1P2Awn02bhotkW",
-	"ZbfYNtj35iTyE5Bzp0Rxf16ioZ95NGliIjQ8eARf0QpRjwNPn9jbk90ecRYc2y4pM0ewWktzrIXaLoM7",
-	"0xtwhyjjWb+BOc0SIjKeLE67pdv+iKb1P36/0HNvyQknKXtvLCmfER28qyQ/nlnTUtLUUZLft9su6Lqw",
-	"kKvfgGt84ZZ7+yNujqrb9o/4XAta+Nvpg/1X1jZSxGc119spr19cWakNCYcV24CypXPOVqbzHEs8wwLQ",
-	"GvgKKw4lW2WsGF2Y4gyRtZk4ZX73BIVjgJMFs4bItNZeFTmOQLHQCa/4VvCrufLWWsc98h1aDgScz3pT",
-	"vW1tRA1OvahOq4j06SnCKXFqv1HqqGLUs1ijOmYe7XFb9QyULqp5Slr83C3QU7dA/aVKg5dfsy13sPRa",
-	"WPKOO6hdd8DT3kqj6wsYnVbqUuhepTxaJ+3dHocPsefXgFzSyfXy3SkjqF5UPhjRXJK2DD+UGxleQJ1y",
-	"JHu+6nVCrmSf4IcCdh/N/RNOgfrAXRS+6Jsjg4uT/MHvuZ0A5ccoeQvpu+77ZsPtf2ewEXt/cj+8d/FF",
-	"pzO3Tz2XnZ7LTpdbdsq3fu+Fp+rdjYOXnkoX/LQsPhW3eh2CWMVVYhcCrmo/d3gCrKrc4jaeIpT/kaq6",
-	"MpQj53aFqDL3glaeePpQfCWxfTmqWP65ClIDKXN9hO+ybLii1LjUOy9LubrhpYJdrjUng4/Q+xIbWpSn",
-	"nrXIzzjUq9BIilT9KdL+gPRJK0WnWLc/R9xwneo4Slbns1T1bO3koVuVryqXrj8tzT4qyB1j9HqGiPT0",
-	"SGl0ha1cgw6Wtlzbf8Iea1fgevqbbXRFrqeoo/n/r+x32K7MkcFWqud/Qu5kNFj3Xbz+WMVBcgIb6OFT",
-	"dQU7vRctS+0n+839IrWZkp/tE2+oJHIbdEBM/ggtAJPvL+zr+ssCYwBHGcvMJ/M1TQgvMKFau0vwCJm9",
-	"jhhHm4KOlCeOXHIZhL7GO1fSaxvpXkb/y42yDEIv0lhQNeZ1MN28DHY3u/8HAAD//+NEqzvqkwAA",
+	"H4sIAAAAAAAC/+w9XZPbNpJ/BcW7qrur4oycrHerbt4cx3FcFW9cntj3ELtkiGxJiCmQC4Aaa6f036/w",
+	"QRLgh0RSHJEaz1McDQk0uhv93c17L4g3SUyBCu7d3HsM/pUCFz/FIQH1w8t4k6QCXn1LgJENUPEeeBqJ",
+	"9/lzO/lUEFMBVMh/4iSJSIAFiensLx5T+RsP1rDB8l8JixNgwiwexHRJQqABzCPYQiR/C4EHjCTyfe/G",
+	"+03+jOIlEmtAxeOIUAFsiyN+jd4IFMISp5HgSMTo2fX//v3a871lzDZYeDdeGKeLCDzfE7sEvBuPppsF",
+	"MG/vK6hZHM0FAyw2Bn53/z+yP8mlJaowAwVLLNbAOMIrTCgXFSg+ZYt/8nxElihhwIEKH0HE9QJLwrhA",
+	"+dbXBYBcMEJXCsA0gbAK1IskiXbo5Yd3r35GW8wIlhhhEKaBfMBHKSd0ZTCm/i6AZziMF1wiTj7Iiy0X",
+	"cRwBpnJPyCk93wLjJKZVAD7qP2RrFq9U8KCASBmTGDTLOcQhVPzjeQGHJOtKE2cDgpFA7l1Cy95XXEqY",
+	"RM2f2XOf8zXixV8QCG/vPihYCnvfeynx7XDzEHzsYOfeo2kUYclzatcaugIN54JsQD5c8CkWcKV+rXlj",
+	"lWIWMkwitR8RsFH/+E8GS+/GwHW9w5voP2bFhZ7p3/msOO/rbB1vn++CGcM7+f/ZpXLAIlT87UfPbzqU",
+	"RTGK9YkqwHNYZberM8C35l25jMBMdMQbF1ik/VB1q1+VeCLAei3xB9GYyW/5idTLpVEd9fT/91lVvrj3",
+	"vTSRqAzni93xW5dzsCF7QWSHTjkBHBwYWDtd2Xcsls+8w5x/hd0w1xa+JYQBn+M6wU82gPBSAEN3axKs",
+	"lSRL9O6IcERjFMV0BQzhIIBEQHiN/rAeCWOQDwmkN5E6IKUchKuaDrFuw3UqEUI91QeTtyAEoSs+ECqp",
+	"lA1z/iMJ50GUcgEK2pv7Gg3DMA3jDfm3Wnn+FXaHhAawLlclP1v+rn375gX0LdfLL9ytfnPve1sckVCD",
+	"nrLoOIGqp3XO1ol05lzDkOyhxXUXcVKSIX2QAmwwm5QLhklPYf0yf71ORpcMhQrq4VvCgPOmP2+wCNbz",
+	"TRx2EfQ5ft7Kt9/Kl+VSaSTIfIujFEJrL+uaNvJHnGjrsQcIv5tXDTWBi/mSQBS6qK7sWEZjwQl1gHdU",
+	"hDlwWg/Wc6Zas4Q168FODJsLlQGdqFXKcImrMkgOULLHFXV3a3nuN3QFXBRS4gXnZEXlvwZSP7hY8DQL",
+	"q4CsynclhNh7dsLDW+Wx/G45YsMgwXHtemChAtdRFDg7tsTBB8VzTx7YQ3hgT47Wo3O0bMb2bbcrZ5UH",
+	"dL30XX1yGKbuMOSkGtRBGMEP6OgAOId+FA7AcbN8OOu7yYIuB1hPs3k1jc5u83bhul427YckinHYxZZT",
+	"iEwwEzOpUq9CLPChMy5J5OrfBaGY7arKt3Qa9V7LQ3zUsgleUUHEQFG17FwVkowrVhVYrdCifuFJTLk+",
+	"0E84NJjphJW2MpOxmGk43AjkTzhExkmW521IxN2mQQCcD0CvzjJeA9AF0e4BzYnkKfVC1fTMiKc74Vzq",
+	"EBxhamXG0DJmKoa8IlugKNF2h9cQ3z77uTUQoQtFfxS8BgpMIyGPiGcIMEdHd0SsqxiZk9BrClWfHSul",
+	"/U9niYIfFLyIm5X7IMeo9bMjJTftTr4fyJh7x+5GbsCMdVZgA54W2LHzFtru3Oe1/N7Tz5vr++bz/gwR",
+	"DMjJxLZn87jIvgXUGpAwo1EFtiF4rzmh1xU8HezQPw7ILKejT9hxk9dghXx/JVzEbDeiQjcQnKLUhOJi",
+	"nkBAlgRCtFZLkgBHaFuUxDh6v4KI2/dvX64h+Hp+WWY27o+A37AALhDHmyQCpNwltCFcZaVQoNcun/YS",
+	"Dbj3IFJGbW2NQhAy9Kv1clknI0xD62GjpV+DMPZDAdNHzIiMew1oyrTNmp2KDOOKoAQzvAEBTBst2Bbn",
+	"xZEv32STt73RXDOFb62stdeQRcPGkoHu9mcQgLYaLY5/eZZqxvuZndpPCly0+SppXhiubfle4ULZOxoF",
+	"uZEy1hUoA3COS1A2hgpLDdiWBPBShR3HQ4UDxumXpDD3uV4YOXFVFDLFJIudQt8GU7wC+/EKli7Q+ani",
+	"oofIeEMFMIojSR9gOj54zsBjtj/SACDzoO/9RvhDWvT907X5pa4mXBK8MnnFtgaEfqG/kUy4UPc/imok",
+	"A691EFzE6rgqnwJi3+eR2WGMyQw5ujfBCf9WMZPhrIqhSeBmEtxWdVV4s1a+Rm+WSIGoJbJJJaI7YIBS",
+	"DqGvXsvogRkgHsQJhLLGOWYhoatop2SWLoaWoCNClzEiNHtTpU3QIg5VlbQqeDbkywtffmKAgzWMSMQS",
+	"KA/D33nBEFqYA1c43EebmAvEIFBRMsJ4Lg5+T8Ui/vYWOMerMVHlwDEpARsryNDGoChDnJvC+BXzUTmt",
+	"Cs1wzJZZolmCZa3O2sk3rSJsMrh6MDz1xtD4qBk+qpOZp8ZgMWdXghuliYrwlIIgGVIeKqbRFTXl4MaF",
+	"mIB2iMRCJzA+OipNKeVgd68oXut88wxE4+NkUsaeQehBS++PkiFnzD4Ie9tvDxfD6UqTajDnUi69ExJy",
+	"kMongM5JMXmOqkk6NP+MxS9xSsOzBmbeA49TFoDqK12q7fe+9x6SCO8cO/3sobOSl9Cf9nLBMI0gLJn3",
+	"+qDb+OvghVInZOU1QGFmWHo13S0Xmf/UhwhLQaraZoDLze/p4/BhcnxOIfrl5bkygls2aam0/hJTV6VT",
+	"abO2VI5+iUmG7FzCWYpDkDIidqpCWoO2AMyAvUjFOj+AKvRXPxd15GshEr2PVH3VUQgv33/4Gb1494aX",
+	"3EErhyMXIyKSq70q3ae3+UNqDc/38rEy3vYH3dEAFCfEu/H+dv3s+gdPGh1irU4w01pglgd5bu69FSga",
+	"SRqoHd6ExvpyQ2ZqmaxUwbv5894jcst/paBq6LXYL3qkOmtpV+HqZfZ7/76q0tJISNsCkJ48VJmR88O1",
+	"59cCJ19yQKu0BFZrtcr7/1PtKYWZMgtRTBHgYK0AqkLy7BAoc07+3RWez6Va+h+fPbNw7CA2f27WHP/c",
+	"+97zNitYJft73/t7m1fqsm7qXqWbDWa7zMJXuUsDVWYlaib1JQYJ5YnUFmIdqzlPWKAgTqNQmWwLQEm6",
+	"iAhfq04agVdcddGq173Pcrcyw8/uzb/mJNzPmDL1lAxKay5BjSXYcAvkBSuIW2zhlZshmqj9j+eDUfuA",
+	"/dqP3M+fPT/+Sm7AD8cfhfUqq6KASvcCxQwtMakatJJZbH5AZLOBkGAB0a6JN7Lo3EEpmAUrvb43rxzt",
+	"HPgCKffqQLixpD8sXJinS8iY3Rem2H5WaKerbVZr14iugxV6rW5OsfUYN6dVheGIF0IG/xSZi5LBDEeK",
+	"1CvdJSKvieXt1xeL92WDw5fllfXcWentD22OVNvpq7aAXn2+ZARoGKl4BpZp+EUeP9Euj34OqcksvlRy",
+	"QUz/SqmaLlj4QaEp7PE/UaXnEhaHaaAq/1MO7CrfJogw52RJAmcTy47U+wG/Rv+3BqlSCS945hOVYZdU",
+	"WuRZPEc/76Oi210X0pjmeLQkkWK2AFOEI64ErQzcoF/jO9gC880ARoqjT1QHh9Cd0tILuZIeLskhsMG1",
+	"XAL5k7Kh9J94vuH1J9pgPpUw7xC4fwWCpvQv2aI1QfsyB3zg0m+IVyDWwApSFohURow+jlNToCiMGSAs",
+	"UARY1wELgqNoh1hKqQ6cqcUITVKBGKYraLImrTEGNZfmwJyJpnsjCDBnsZ4TJBrX1+N/TlnfDBeqXz+b",
+	"f5Wv3/LcVpfzkbddPrgFzIK1fQcpNrfIejAr8NaURrrOP5MRegUB30QTz6snusH1nXtLbe9rdn/Oc1vd",
+	"oSKD3FdrYkmZOfK4RwUZZqIuR3drjY+YKbfqDvBXq0ZZsSlw9N9OTdQazODe4kHCTcIAR/+D+DpTAEwl",
+	"IJSHVgc6oUGUhjCXu87VXnWnsIYpVKb3Ig4RBEKaOTFiIHEVaMcyMjmkDASkkcFN0SxhWidzlfdQ4yR9",
+	"ZWBpdSb/gu5IFNmnuP5E3+Wpv9x4qzwm51MuYrGWOAWisbtEXyQjf1Fi4UvO019se06lFlm8JaHaqgFn",
+	"GraBtN4vcrEaZdc71FBTVzhqnMHtPCm12aC7a3YtrpHCsKYEt4zj4j3vs8zexbzG8i132Y/g6jiDJuoR",
+	"Zg0inx0a27zvQ/emQQOjEl4DhTCicFceHYBrPCGb2L737SqIQ1gBvTK4u5JJyytDvgYMeu2cqNm9U6W+",
+	"P+RSj8VXfu3yDtwjOekNbDaeU25XfTkV16Ukl4M8pQYcajUJnbrYZDkx+ih5o6NUOzQKsZdUa8o+jyrV",
+	"NFCDM9oxgdeA3J4Cb1aaMFqvWA/MOX3idz47gJ7DrP+8mpTUa0GIbMJcVr5AH0HdAiv8qXw3VcCkT6Yc",
+	"QPVQSomo6yHIAqaY4mjHiW0O5j997sn2IeF65GhDyuln/ffvSefXMKPBQrliZizGMuAMr9v78ZAe9dnI",
+	"Qq/oEwcZJEyFgV7RKfFP3kh1lTVSHczpVPrMntwQE+ho7MC7LL2Z12HUdNgp7Rlm4thh4CHV4toUnrfL",
+	"LWZl6o+MD5+i9wPdy8ae+rEvmXN//ovX9TUMK+rNvZrdm+VbBpoe7wWr2cGgZvRY1vfOq+XPbRwKCVTH",
+	"RD/FA/isCTf9gwEOTS42GqA/aekcJku7jxEDsOGYpWrseTO/N41Ff+J3PmvCzXfJ7xoZCKOXtx9lDQNI",
+	"rq7hfL9wPNeAQ2DmIV9ehTkJffVlgj/9/FO3n4fkfdM62NLQN5N7Hr2hf/L3f2uLwqofHh6kplKzy8E6",
+	"pAEs99LQpgv1qNvPZKq9Y40FB/XfLnjSCXzWgJrTCxwOfy7isvjTHEZFGbmITSVXAuzKGjoosCBckMC0",
+	"2hstoYsn2rNwPzXB2aadp5oPYX5SEWOoiAEqOErjuy/rImWjkKKjk7w7Sv7ma5NNXWrTtJTNoLqA3psD",
+	"k7OmoMjLs66cZhodJV/jLZi2RKCI6VkGFnWzRvijhYQuGi6lmLD5g/InFBTWD6e4NBmhv91j6hDLn+9p",
+	"asuy2OW4MJjpWXUdZIIe5HeRgsGdQTgF6VCaFHhIVhShpXxyATKDo6XyxqlYAxUkkAxjrhnvzRX3koJ7",
+	"He+IQEBdi3N1BswUbCn1n0MLD+JyHpqAc2m90vIk1tfB6nivKx+5X4BtFCzWx14n0fMZxP2ay4rhLGqF",
+	"B2gqLXZo7Ck1nWSFHyY3ffDGsd6SuTpmcQL90UVLkjMxv7lbutwdcvjmFJ8OPmLI5ci5FBuu9su/J5hv",
+	"lXFH02kHMdi+Mp+dCAqmaaJ1GznZQuOWPoP2/Sjbhu+/jcoSGiaEe7CD3xid+g5pW/dVngmEZVZRvMDR",
+	"rJm4shHUYKhJvjc33zwCOvdqsBlOSzQMxZtMew3hyjx4AF3RyqKehj194kwEc+Dz2LHtSiiXCDaJ0INq",
+	"qenO/qJ7qr8gGrOsT1vPp/URmU7NZTvQTV95E/wPP2fhqSf/hNnogzfkl6e+j96Nnw9cr2nFb+rEN++0",
+	"dbouzOUa1uGanrtlf5kcN3vVbfvuXax5LfTt7N78K2u3L/yzmk8vS61ffE5dCRIGm3gLUpYuWbzRE7uw",
+	"wAvMVeZ8gyWGop0UVjFd6SIrImrrZqX4PeAUTsGcLJA1Rsy/9jPm03AUC55wurcKfDW3brXmcef41lmO",
+	"OJxPfFP9Nu6EBkMMwjqtPNLHxwin+KnDeqmT8lHPIo3qkNlZ47bq8Ct9euoxcfFTb99ApQD1n0kbvVkq",
+	"u3JHG6UKSd7zBrXr5XvcV2lyXXyT48rXcIwpO/OkKRg4Pvw7/5bMJU38Ln+AZwLZiwzlXUrFWlUWjkig",
+	"U0oLM7CHrS2sJfxYht2tnttvJaiPzPB3Sd/sGVwc5WvBHsiUnyLljUnf9943C+5iFNZB27v4cOJjSDqd",
+	"uXzqKe30lHa63LRTfvUHTzxVv8Y6euqp9GGUlsmn/K2jJlZ+5EsxrnKABzKrKp8CnE4SqtAKTWkoi87t",
+	"ElFl7HmtNPHsPv93h3RUAf65ElIjMXO9h2+jbLyk1LTYO09L2bzhhIJtrDUHgzvwfQkNLdJTT1zkRhzq",
+	"WWgiSarhGOmwQ/qomaKXrzucIm74Ju80Ulbnk1T1aO2loVulr/KdJhR1H5CzOzm5U/Rez+CRnu4pTS6x",
+	"lXPQ0dSWLftPuGPtElyP/7JNLsn1GHk0//8rrpuwr3TLYCvWM33bL/UbJ1uD9mrDfyyYgWAEtsAtDWzO",
+	"7LZJopApfawDY9ZH6bPHLXQ6LxqUbnFEpOJtnmn40Tzxigoidl4Pi8ldoYXBVBqmo1+Xh+VTMI4ylHHV",
+	"dqLOhPAKE6q4u2QeIX3XZThxW5wjZZFFl5wGvsvxclcIUibRLmXkAjAD9iIVa+/mz89SMnAFpJagcs0b",
+	"b7b9wdt/3v//AOfcfvfayAAA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
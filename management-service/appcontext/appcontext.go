@@ -60,8 +60,16 @@ func NewAppContext(db *gorm.DB, authorizer *mw.Authorizer, cfg *config.Config) (
 		return nil, errors.Wrapf(err, "Failed initializing MLP Service")
 	}
 
+	outboxSvc := services.NewOutboxService(&allServices, *cfg.OutboxConfig, db)
+
 	configurationSvc := services.NewConfigurationService(cfg)
 
+	analysisSvc := services.NewAnalysisService(&allServices, db)
+
+	srmSvc := services.NewSRMService(&allServices, *cfg.SRMConfig, db)
+
+	guardrailSvc := services.NewGuardrailService(&allServices, db)
+
 	allServices = services.NewServices(
 		experimentSvc,
 		experimentHistorySvc,
@@ -74,7 +82,11 @@ func NewAppContext(db *gorm.DB, authorizer *mw.Authorizer, cfg *config.Config) (
 		treatmentHistorySvc,
 		validationService,
 		messageQueueService,
+		outboxSvc,
 		configurationSvc,
+		analysisSvc,
+		srmSvc,
+		guardrailSvc,
 	)
 
 	appContext := &AppContext{
@@ -38,6 +38,8 @@ func TestNewAppContext(t *testing.T) {
 				TopicName: "update",
 			},
 		},
+		OutboxConfig: &config.OutboxConfig{},
+		SRMConfig:    &config.SRMConfig{},
 		ValidationConfig: config.ValidationConfig{
 			ValidationUrlTimeoutSeconds: 5,
 		},
@@ -67,7 +69,11 @@ func TestNewAppContext(t *testing.T) {
 	treatmentHistSvc := services.NewTreatmentHistoryService(db)
 	treatmentSvc := services.NewTreatmentService(&allServices, db)
 	mlpService := &mocks.MLPService{}
+	outboxSvc := services.NewOutboxService(&allServices, *cfg.OutboxConfig, db)
 	configurationSvc := services.NewConfigurationService(cfg)
+	analysisSvc := services.NewAnalysisService(&allServices, db)
+	srmSvc := services.NewSRMService(&allServices, *cfg.SRMConfig, db)
+	guardrailSvc := services.NewGuardrailService(&allServices, db)
 
 	// Patch functions with pointer members, so the result is deterministic
 	// Patch the openapi middleware function
@@ -121,7 +127,11 @@ func TestNewAppContext(t *testing.T) {
 		TreatmentHistoryService:  treatmentHistSvc,
 		ValidationService:        validationService,
 		MessageQueueService:      messageQueueService,
+		OutboxService:            outboxSvc,
 		ConfigurationService:     configurationSvc,
+		AnalysisService:          analysisSvc,
+		SRMService:               srmSvc,
+		GuardrailService:         guardrailSvc,
 	}
 	monkey.Patch(services.NewServices,
 		func(
@@ -136,7 +146,11 @@ func TestNewAppContext(t *testing.T) {
 			treatmentHistoryService services.TreatmentHistoryService,
 			validationService services.ValidationService,
 			messageQueueService messagequeue.MessageQueueService,
+			outboxService services.OutboxService,
 			configurationService services.ConfigurationService,
+			analysisService services.AnalysisService,
+			srmService services.SRMService,
+			guardrailService services.GuardrailService,
 		) services.Services {
 			return allServices
 		},
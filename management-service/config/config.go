@@ -9,6 +9,7 @@ import (
 
 	common_config "github.com/caraml-dev/xp/common/config"
 	common_mq_config "github.com/caraml-dev/xp/common/messagequeue"
+	"github.com/caraml-dev/xp/common/tracing"
 )
 
 type Config struct {
@@ -20,11 +21,15 @@ type Config struct {
 	DbConfig            *DatabaseConfig
 	MLPConfig           *MLPConfig
 	MessageQueueConfig  *common_mq_config.MessageQueueConfig
+	OutboxConfig        *OutboxConfig
+	SRMConfig           *SRMConfig
+	GuardrailConfig     *GuardrailConfig
 	SegmenterConfig     map[string]interface{}
 	ValidationConfig    ValidationConfig
 	DeploymentConfig    DeploymentConfig
 	NewRelicConfig      newrelic.Config
 	SentryConfig        sentry.Config
+	TracingConfig       tracing.Config
 	XpUIConfig          *XpUIConfig
 }
 
@@ -33,6 +38,9 @@ type AuthorizationConfig struct {
 	Enabled bool
 	URL     string
 	Caching *InMemoryCacheConfig
+	// PasskeyHashSubjects are the subjects, such as the service accounts of the Treatment Services, that may
+	// read the passkey hashes of the projects
+	PasskeyHashSubjects []string
 }
 
 type InMemoryCacheConfig struct {
@@ -56,6 +64,42 @@ type DatabaseConfig struct {
 	MaxOpenConns    int           `default:"0"`
 }
 
+// OutboxConfig captures the config for the dispatcher that publishes the outbox messages
+// to the message queue
+type OutboxConfig struct {
+	// PollInterval is the time between two consecutive checks for pending messages
+	PollInterval time.Duration `default:"1s"`
+	// BatchSize is the maximum number of messages published in each poll
+	BatchSize int `default:"100"`
+	// MaxAttempts is the number of failed attempts after which a message is marked as failed
+	// and requires a manual replay
+	MaxAttempts int32 `default:"10"`
+	// InitialBackoff is the delay before the first retry, which is doubled with each subsequent
+	// failed attempt, up to MaxBackoff
+	InitialBackoff time.Duration `default:"1s"`
+	MaxBackoff     time.Duration `default:"5m"`
+}
+
+// SRMConfig captures the config for the job that checks the running experiments for a sample ratio
+// mismatch, i.e., assignment counts that do not match the configured traffic of the treatments
+type SRMConfig struct {
+	Enabled bool
+	// CheckInterval is the time between two consecutive checks of the running experiments
+	CheckInterval time.Duration `default:"1h"`
+	// PValueThreshold is the p-value of the chi-squared test below which a mismatch is reported
+	PValueThreshold float64 `default:"0.001"`
+	// MinSampleSize is the no. of assigned units required before an experiment is checked
+	MinSampleSize int64 `default:"1000"`
+}
+
+// GuardrailConfig captures the config for the job that evaluates the guardrails of the running experiments
+// against the ingested metric observations, and disables the experiments whose guardrails are breached
+type GuardrailConfig struct {
+	Enabled bool
+	// EvaluationInterval is the time between two consecutive evaluations of the running experiments
+	EvaluationInterval time.Duration `default:"5m"`
+}
+
 // MLPConfig captures the configuration used to connect to the MLP API server
 type MLPConfig struct {
 	URL string
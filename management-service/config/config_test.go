@@ -8,6 +8,7 @@ import (
 	"github.com/caraml-dev/mlp/api/pkg/instrumentation/newrelic"
 	"github.com/caraml-dev/mlp/api/pkg/instrumentation/sentry"
 	common_mq_config "github.com/caraml-dev/xp/common/messagequeue"
+	"github.com/caraml-dev/xp/common/tracing"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -26,6 +27,7 @@ func TestDefaultConfigs(t *testing.T) {
 				KeyExpirySeconds:            600,
 				CacheCleanUpIntervalSeconds: 900,
 			},
+			PasskeyHashSubjects: []string{},
 		},
 		DbConfig: &DatabaseConfig{
 			Host:            "localhost",
@@ -51,6 +53,21 @@ func TestDefaultConfigs(t *testing.T) {
 				PubSubTimeoutSeconds: 30,
 			},
 		},
+		OutboxConfig: &OutboxConfig{
+			PollInterval:   time.Second,
+			BatchSize:      100,
+			MaxAttempts:    10,
+			InitialBackoff: time.Second,
+			MaxBackoff:     5 * time.Minute,
+		},
+		SRMConfig: &SRMConfig{
+			CheckInterval:   time.Hour,
+			PValueThreshold: 0.001,
+			MinSampleSize:   1000,
+		},
+		GuardrailConfig: &GuardrailConfig{
+			EvaluationInterval: 5 * time.Minute,
+		},
 		ValidationConfig: ValidationConfig{
 			ValidationUrlTimeoutSeconds: 5,
 		},
@@ -66,6 +83,13 @@ func TestDefaultConfigs(t *testing.T) {
 			Labels:            emptyInterfaceMap,
 		},
 		SentryConfig: sentry.Config{Enabled: false, Labels: emptyStringMap},
+		TracingConfig: tracing.Config{
+			Enabled:     false,
+			Endpoint:    "localhost:4317",
+			Protocol:    "grpc",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
 		XpUIConfig: &XpUIConfig{
 			Homepage: "/xp",
 		},
@@ -101,6 +125,7 @@ func TestLoadConfigFiles(t *testing.T) {
 						KeyExpirySeconds:            100,
 						CacheCleanUpIntervalSeconds: 200,
 					},
+					PasskeyHashSubjects: []string{"treatment-service@gojek.com"},
 				},
 				DbConfig: &DatabaseConfig{
 					Host:            "localhost",
@@ -131,6 +156,23 @@ func TestLoadConfigFiles(t *testing.T) {
 						PubSubTimeoutSeconds: 30,
 					},
 				},
+				OutboxConfig: &OutboxConfig{
+					PollInterval:   5 * time.Second,
+					BatchSize:      20,
+					MaxAttempts:    3,
+					InitialBackoff: time.Second,
+					MaxBackoff:     time.Minute,
+				},
+				SRMConfig: &SRMConfig{
+					Enabled:         true,
+					CheckInterval:   30 * time.Minute,
+					PValueThreshold: 0.01,
+					MinSampleSize:   1000,
+				},
+				GuardrailConfig: &GuardrailConfig{
+					Enabled:            true,
+					EvaluationInterval: time.Minute,
+				},
 				ValidationConfig: ValidationConfig{
 					ValidationUrlTimeoutSeconds: 5,
 				},
@@ -146,6 +188,13 @@ func TestLoadConfigFiles(t *testing.T) {
 					Labels:            map[string]interface{}{"env": "dev"},
 				},
 				SentryConfig: sentry.Config{Enabled: false, Labels: map[string]string{"app": "xp-management-service"}},
+				TracingConfig: tracing.Config{
+					Enabled:     true,
+					Endpoint:    "otel-collector:4317",
+					Protocol:    "grpc",
+					Insecure:    false,
+					SampleRatio: 0.5,
+				},
 				XpUIConfig: &XpUIConfig{
 					AppDirectory: "ui",
 					Homepage:     "/testxp",
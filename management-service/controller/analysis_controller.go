@@ -0,0 +1,280 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/management-service/api"
+	"github.com/caraml-dev/xp/management-service/appcontext"
+	"github.com/caraml-dev/xp/management-service/errors"
+	"github.com/caraml-dev/xp/management-service/models"
+	"github.com/caraml-dev/xp/management-service/services"
+)
+
+// maxUploadMemory is the max size of the uploaded file that is held in memory, the rest is stored on disk
+const maxUploadMemory = 32 << 20
+
+type AnalysisController struct {
+	*appcontext.AppContext
+}
+
+func NewAnalysisController(ctx *appcontext.AppContext) *AnalysisController {
+	return &AnalysisController{ctx}
+}
+
+func (a AnalysisController) IngestExperimentAssignments(
+	w http.ResponseWriter,
+	r *http.Request,
+	projectId int64,
+	experimentId int64,
+) {
+	body := api.IngestExperimentAssignmentsRequestBody{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		WriteErrorResponse(w, errors.Newf(errors.BadInput, err.Error()))
+		return
+	}
+
+	experiment, err := a.getExperiment(r.Context(), projectId, experimentId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	err = a.Services.AnalysisService.IngestAssignments(r.Context(), experiment, a.toExperimentAssignments(body))
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	Ok(w, nil)
+}
+
+func (a AnalysisController) IngestMetricObservations(
+	w http.ResponseWriter,
+	r *http.Request,
+	projectId int64,
+	experimentId int64,
+) {
+	body := api.IngestMetricObservationsRequestBody{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		WriteErrorResponse(w, errors.Newf(errors.BadInput, err.Error()))
+		return
+	}
+
+	experiment, err := a.getExperiment(r.Context(), projectId, experimentId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	err = a.Services.AnalysisService.IngestObservations(r.Context(), experiment, a.toMetricObservations(body))
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	Ok(w, nil)
+}
+
+func (a AnalysisController) UploadMetricObservations(
+	w http.ResponseWriter,
+	r *http.Request,
+	projectId int64,
+	experimentId int64,
+) {
+	err := r.ParseMultipartForm(maxUploadMemory)
+	if err != nil {
+		WriteErrorResponse(w, errors.Newf(errors.BadInput, err.Error()))
+		return
+	}
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		WriteErrorResponse(w, errors.Newf(errors.BadInput, err.Error()))
+		return
+	}
+	defer file.Close()
+
+	experiment, err := a.getExperiment(r.Context(), projectId, experimentId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	observations, err := a.Services.AnalysisService.ParseObservationsCSV(file)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	err = a.Services.AnalysisService.IngestObservations(r.Context(), experiment, observations)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	Ok(w, nil)
+}
+
+func (a AnalysisController) ListExperimentResults(
+	w http.ResponseWriter,
+	r *http.Request,
+	projectId int64,
+	experimentId int64,
+	params api.ListExperimentResultsParams,
+) {
+	experiment, err := a.getExperiment(r.Context(), projectId, experimentId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	results, err := a.Services.AnalysisService.ListExperimentResults(r.Context(), experiment, services.ListExperimentResultsParams{
+		ExperimentVersion: params.ExperimentVersion,
+		Metric:            params.Metric,
+	})
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	resultsResp := []schema.ExperimentResult{}
+	for _, result := range results {
+		resultsResp = append(resultsResp, result.ToApiSchema())
+	}
+	Ok(w, resultsResp)
+}
+
+func (a AnalysisController) GetExperimentSRMCheck(
+	w http.ResponseWriter,
+	r *http.Request,
+	projectId int64,
+	experimentId int64,
+	params api.GetExperimentSRMCheckParams,
+) {
+	experiment, err := a.getExperiment(r.Context(), projectId, experimentId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	check, err := a.Services.SRMService.GetSRMCheck(r.Context(), experiment, params.ExperimentVersion)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	Ok(w, check.ToApiSchema())
+}
+
+func (a AnalysisController) ListGuardrailBreaches(
+	w http.ResponseWriter,
+	r *http.Request,
+	projectId int64,
+	experimentId int64,
+) {
+	experiment, err := a.getExperiment(r.Context(), projectId, experimentId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	breaches, err := a.Services.GuardrailService.ListGuardrailBreaches(r.Context(), experiment)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	breachesResp := []schema.GuardrailBreach{}
+	for _, breach := range breaches {
+		breachesResp = append(breachesResp, breach.ToApiSchema())
+	}
+	Ok(w, breachesResp)
+}
+
+func (a AnalysisController) ComputeExperimentResult(
+	w http.ResponseWriter,
+	r *http.Request,
+	projectId int64,
+	experimentId int64,
+) {
+	body := api.ComputeExperimentResultRequestBody{}
+	err := json.NewDecoder(r.Body).Decode(&body)
+	if err != nil {
+		WriteErrorResponse(w, errors.Newf(errors.BadInput, err.Error()))
+		return
+	}
+
+	experiment, err := a.getExperiment(r.Context(), projectId, experimentId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	result, err := a.Services.AnalysisService.ComputeExperimentResult(r.Context(), experiment, a.toComputeExperimentResultParams(body))
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	Ok(w, result.ToApiSchema())
+}
+
+func (a AnalysisController) toExperimentAssignments(
+	body api.IngestExperimentAssignmentsRequestBody,
+) []services.ExperimentAssignment {
+	assignments := []services.ExperimentAssignment{}
+	for _, assignment := range body.Assignments {
+		assignments = append(assignments, services.ExperimentAssignment{
+			UnitID:            assignment.UnitId,
+			ExperimentVersion: assignment.ExperimentVersion,
+			Treatment:         assignment.Treatment,
+		})
+	}
+	return assignments
+}
+
+func (a AnalysisController) toMetricObservations(
+	body api.IngestMetricObservationsRequestBody,
+) []services.MetricObservation {
+	observations := []services.MetricObservation{}
+	for _, observation := range body.Observations {
+		observations = append(observations, services.MetricObservation{
+			Metric:    observation.Metric,
+			UnitID:    observation.UnitId,
+			Value:     observation.Value,
+			Covariate: observation.Covariate,
+		})
+	}
+	return observations
+}
+
+func (a AnalysisController) toComputeExperimentResultParams(
+	body api.ComputeExperimentResultRequestBody,
+) services.ComputeExperimentResultParams {
+	params := services.ComputeExperimentResultParams{
+		Metric:            body.Metric,
+		ExperimentVersion: body.ExperimentVersion,
+		ControlTreatment:  body.ControlTreatment,
+		ConfidenceLevel:   body.ConfidenceLevel,
+	}
+	if body.Cuped != nil {
+		params.CUPED = *body.Cuped
+	}
+	return params
+}
+
+func (a AnalysisController) getExperiment(ctx context.Context, projectId int64, experimentId int64) (*models.Experiment, error) {
+	// Check if the projectId is valid
+	if _, err := a.Services.MLPService.GetProject(projectId); err != nil {
+		return nil, err
+	}
+	// Check if the projectId has been set up
+	_, err := a.Services.ProjectSettingsService.GetDBRecord(ctx, models.ID(projectId))
+	if err != nil {
+		return nil, errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err)
+	}
+	experiment, err := a.Services.ExperimentService.GetDBRecord(ctx, models.ID(projectId), models.ID(experimentId))
+	if err != nil {
+		return nil, errors.Newf(errors.NotFound, "Experiment with id %d cannot be retrieved: %v", experimentId, err)
+	}
+	return experiment, nil
+}
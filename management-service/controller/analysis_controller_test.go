@@ -0,0 +1,508 @@
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/caraml-dev/xp/management-service/api"
+	"github.com/caraml-dev/xp/management-service/appcontext"
+	"github.com/caraml-dev/xp/management-service/errors"
+	"github.com/caraml-dev/xp/management-service/models"
+	"github.com/caraml-dev/xp/management-service/services"
+	"github.com/caraml-dev/xp/management-service/services/mocks"
+)
+
+type AnalysisControllerTestSuite struct {
+	suite.Suite
+	ctrl                             *AnalysisController
+	expectedExperimentResultResponse string
+	expectedErrorResponseFormat      string
+}
+
+func (s *AnalysisControllerTestSuite) SetupSuite() {
+	s.Suite.T().Log("Setting up AnalysisControllerTestSuite")
+
+	// Create mock MLP service and set up with test responses
+	mlpSvc := &mocks.MLPService{}
+	mlpSvc.On(
+		"GetProject", int64(1),
+	).Return(nil, errors.Newf(errors.NotFound, "MLP Project info for id %d not found in the cache", int64(1)))
+	mlpSvc.On("GetProject", int64(2)).Return(nil, nil)
+
+	// Create mock project settings service and set up with test responses
+	settingsSvc := &mocks.ProjectSettingsService{}
+	settingsSvc.
+		On("GetDBRecord", mock.Anything, models.ID(2)).
+		Return(nil, nil)
+
+	// Create mock experiment service and set up with test responses
+	testExperiment := &models.Experiment{ID: models.ID(10), ProjectID: models.ID(2), Version: 3}
+	expSvc := &mocks.ExperimentService{}
+	expSvc.
+		On("GetDBRecord", mock.Anything, models.ID(2), models.ID(1)).
+		Return(nil, errors.Newf(errors.NotFound, "experiment not found"))
+	expSvc.
+		On("GetDBRecord", mock.Anything, models.ID(2), models.ID(10)).
+		Return(testExperiment, nil)
+
+	// Create mock analysis service and set up with test responses
+	difference, differenceLower, differenceUpper := 1.0, 0.5, 1.5
+	lift, liftLower, liftUpper, pValue := 0.5, 0.25, 0.75, 0.01
+	testResult := &models.ExperimentResult{
+		Model: models.Model{
+			CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+			UpdatedAt: time.Date(2021, 1, 1, 2, 3, 5, 0, time.UTC),
+		},
+		ID:                models.ID(5),
+		ExperimentID:      models.ID(10),
+		ExperimentVersion: 3,
+		Metric:            "conversion",
+		ControlTreatment:  "control",
+		CUPED:             true,
+		ConfidenceLevel:   0.95,
+		Treatments: models.TreatmentResults{
+			{Treatment: "control", Count: 100, Mean: 2, MeanLower: 1.8, MeanUpper: 2.2},
+			{
+				Treatment:         "treatment",
+				Count:             120,
+				Mean:              3,
+				MeanLower:         2.7,
+				MeanUpper:         3.3,
+				Difference:        &difference,
+				DifferenceLower:   &differenceLower,
+				DifferenceUpper:   &differenceUpper,
+				RelativeLift:      &lift,
+				RelativeLiftLower: &liftLower,
+				RelativeLiftUpper: &liftUpper,
+				PValue:            &pValue,
+			},
+		},
+	}
+	metric, otherMetric := "conversion", "revenue"
+	controlTreatment := "control"
+	analysisSvc := &mocks.AnalysisService{}
+	analysisSvc.
+		On("ListExperimentResults", mock.Anything, testExperiment, services.ListExperimentResultsParams{Metric: &metric}).
+		Return([]*models.ExperimentResult{testResult}, nil)
+	analysisSvc.
+		On("ListExperimentResults", mock.Anything, testExperiment, services.ListExperimentResultsParams{Metric: &otherMetric}).
+		Return(nil, errors.Newf(errors.Unknown, "test list results error"))
+	analysisSvc.
+		On("ComputeExperimentResult", mock.Anything, testExperiment, services.ComputeExperimentResultParams{
+			Metric:           "conversion",
+			ControlTreatment: &controlTreatment,
+			CUPED:            true,
+		}).
+		Return(testResult, nil)
+	analysisSvc.
+		On("ComputeExperimentResult", mock.Anything, testExperiment, services.ComputeExperimentResultParams{Metric: "revenue"}).
+		Return(nil, errors.Newf(errors.BadInput, "treatment treatment has fewer than 2 observations"))
+	analysisSvc.
+		On("IngestAssignments", mock.Anything, testExperiment, []services.ExperimentAssignment{
+			{UnitID: "unit-1", ExperimentVersion: 3, Treatment: "control"},
+		}).
+		Return(nil)
+	analysisSvc.
+		On("IngestAssignments", mock.Anything, testExperiment, []services.ExperimentAssignment{
+			{UnitID: "unit-1", ExperimentVersion: 3, Treatment: "unknown"},
+		}).
+		Return(errors.Newf(errors.BadInput, "treatment unknown does not exist in version 3 of the experiment"))
+	analysisSvc.
+		On("ParseObservationsCSV", mock.Anything).
+		Return([]services.MetricObservation{{Metric: "conversion", UnitID: "unit-1", Value: 1}}, nil)
+	analysisSvc.
+		On("IngestObservations", mock.Anything, testExperiment, []services.MetricObservation{
+			{Metric: "conversion", UnitID: "unit-1", Value: 1},
+		}).
+		Return(nil)
+
+	// Create mock SRM service and set up with test responses
+	var previousVersion int64 = 2
+	srmSvc := &mocks.SRMService{}
+	srmSvc.
+		On("GetSRMCheck", mock.Anything, testExperiment, (*int64)(nil)).
+		Return(&models.SRMCheck{
+			Model: models.Model{
+				CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+				UpdatedAt: time.Date(2021, 1, 1, 2, 3, 5, 0, time.UTC),
+			},
+			ID:                models.ID(7),
+			ExperimentID:      models.ID(10),
+			ExperimentVersion: 3,
+			Treatments: models.SRMTreatmentCounts{
+				{Treatment: "control", Traffic: 50, Count: 5500, ExpectedCount: 5000},
+				{Treatment: "treatment", Traffic: 50, Count: 4500, ExpectedCount: 5000},
+			},
+			ChiSquared: 100,
+			PValue:     0.0001,
+			Mismatch:   true,
+		}, nil)
+	srmSvc.
+		On("GetSRMCheck", mock.Anything, testExperiment, &previousVersion).
+		Return(nil, errors.Newf(errors.NotFound, "sample ratio mismatch check not found for version 2 of the experiment"))
+
+	// Create mock guardrail service and set up with test responses
+	guardrailSvc := &mocks.GuardrailService{}
+	guardrailSvc.
+		On("ListGuardrailBreaches", mock.Anything, testExperiment).
+		Return([]*models.GuardrailBreach{
+			{
+				Model: models.Model{
+					CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+					UpdatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+				},
+				ID:                models.ID(1),
+				ExperimentID:      models.ID(10),
+				ExperimentVersion: 3,
+				Metric:            "error_rate",
+				Treatment:         "treatment",
+				Comparator:        models.GuardrailComparatorGreaterThan,
+				Threshold:         0.05,
+				Value:             0.1,
+				SampleSize:        200,
+			},
+		}, nil)
+
+	// Set up expected responses
+	s.expectedErrorResponseFormat = `{"code":"%[1]v", "error":%[2]v, "message":%[2]v}`
+	s.expectedExperimentResultResponse = `{
+		"id": 5,
+		"experiment_id": 10,
+		"experiment_version": 3,
+		"metric": "conversion",
+		"control_treatment": "control",
+		"cuped": true,
+		"confidence_level": 0.95,
+		"treatments": [
+			{"treatment": "control", "count": 100, "mean": 2, "mean_lower": 1.8, "mean_upper": 2.2},
+			{
+				"treatment": "treatment",
+				"count": 120,
+				"mean": 3,
+				"mean_lower": 2.7,
+				"mean_upper": 3.3,
+				"difference": 1,
+				"difference_lower": 0.5,
+				"difference_upper": 1.5,
+				"relative_lift": 0.5,
+				"relative_lift_lower": 0.25,
+				"relative_lift_upper": 0.75,
+				"p_value": 0.01
+			}
+		],
+		"created_at": "2021-01-01T02:03:04Z",
+		"updated_at": "2021-01-01T02:03:05Z"
+	}`
+
+	// Create test controller
+	s.ctrl = &AnalysisController{
+		AppContext: &appcontext.AppContext{
+			Services: services.Services{
+				AnalysisService:        analysisSvc,
+				ExperimentService:      expSvc,
+				MLPService:             mlpSvc,
+				ProjectSettingsService: settingsSvc,
+				SRMService:             srmSvc,
+				GuardrailService:       guardrailSvc,
+			},
+		},
+	}
+}
+
+func TestAnalysisController(t *testing.T) {
+	suite.Run(t, new(AnalysisControllerTestSuite))
+}
+
+func (s *AnalysisControllerTestSuite) TestListExperimentResults() {
+	t := s.Suite.T()
+	metric, otherMetric := "conversion", "revenue"
+
+	tests := []struct {
+		name         string
+		projectID    int64
+		experimentID int64
+		params       api.ListExperimentResultsParams
+		expected     string
+	}{
+		{
+			name:         "mlp project not found",
+			projectID:    1,
+			experimentID: 10,
+			expected:     fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"MLP Project info for id 1 not found in the cache\""),
+		},
+		{
+			name:         "experiment not found",
+			projectID:    2,
+			experimentID: 1,
+			expected:     fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"Experiment with id 1 cannot be retrieved: experiment not found\""),
+		},
+		{
+			name:         "failure | list results",
+			projectID:    2,
+			experimentID: 10,
+			params:       api.ListExperimentResultsParams{Metric: &otherMetric},
+			expected:     fmt.Sprintf(s.expectedErrorResponseFormat, 500, "\"test list results error\""),
+		},
+		{
+			name:         "success",
+			projectID:    2,
+			experimentID: 10,
+			params:       api.ListExperimentResultsParams{Metric: &metric},
+			expected:     fmt.Sprintf(`{"data": [%s]}`, s.expectedExperimentResultResponse),
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ctrl.ListExperimentResults(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.experimentID, data.params)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
+
+func (s *AnalysisControllerTestSuite) TestGetExperimentSRMCheck() {
+	t := s.Suite.T()
+	var previousVersion int64 = 2
+
+	tests := []struct {
+		name         string
+		projectID    int64
+		experimentID int64
+		params       api.GetExperimentSRMCheckParams
+		expected     string
+	}{
+		{
+			name:         "experiment not found",
+			projectID:    2,
+			experimentID: 1,
+			expected:     fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"Experiment with id 1 cannot be retrieved: experiment not found\""),
+		},
+		{
+			name:         "check not found",
+			projectID:    2,
+			experimentID: 10,
+			params:       api.GetExperimentSRMCheckParams{ExperimentVersion: &previousVersion},
+			expected:     fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"sample ratio mismatch check not found for version 2 of the experiment\""),
+		},
+		{
+			name:         "success",
+			projectID:    2,
+			experimentID: 10,
+			expected: `{
+				"data": {
+					"id": 7,
+					"experiment_id": 10,
+					"experiment_version": 3,
+					"treatments": [
+						{"treatment": "control", "traffic": 50, "count": 5500, "expected_count": 5000},
+						{"treatment": "treatment", "traffic": 50, "count": 4500, "expected_count": 5000}
+					],
+					"chi_squared": 100,
+					"p_value": 0.0001,
+					"mismatch": true,
+					"created_at": "2021-01-01T02:03:04Z",
+					"updated_at": "2021-01-01T02:03:05Z"
+				}
+			}`,
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ctrl.GetExperimentSRMCheck(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.experimentID, data.params)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
+
+func (s *AnalysisControllerTestSuite) TestListGuardrailBreaches() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name         string
+		projectID    int64
+		experimentID int64
+		expected     string
+	}{
+		{
+			name:         "experiment not found",
+			projectID:    2,
+			experimentID: 1,
+			expected:     fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"Experiment with id 1 cannot be retrieved: experiment not found\""),
+		},
+		{
+			name:         "success",
+			projectID:    2,
+			experimentID: 10,
+			expected: `{
+				"data": [{
+					"id": 1,
+					"experiment_id": 10,
+					"experiment_version": 3,
+					"metric": "error_rate",
+					"treatment": "treatment",
+					"comparator": "greater_than",
+					"threshold": 0.05,
+					"value": 0.1,
+					"sample_size": 200,
+					"created_at": "2021-01-01T02:03:04Z",
+					"updated_at": "2021-01-01T02:03:04Z"
+				}]
+			}`,
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ctrl.ListGuardrailBreaches(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.experimentID)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
+
+func (s *AnalysisControllerTestSuite) TestComputeExperimentResult() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "invalid body",
+			body:     `{"metric": 1}`,
+			expected: fmt.Sprintf(s.expectedErrorResponseFormat, 400, "\"json: cannot unmarshal number into Go struct field ComputeExperimentResultRequestBody.metric of type string\""),
+		},
+		{
+			name:     "failure | compute result",
+			body:     `{"metric": "revenue"}`,
+			expected: fmt.Sprintf(s.expectedErrorResponseFormat, 400, "\"treatment treatment has fewer than 2 observations\""),
+		},
+		{
+			name:     "success",
+			body:     `{"metric": "conversion", "control_treatment": "control", "cuped": true}`,
+			expected: fmt.Sprintf(`{"data": %s}`, s.expectedExperimentResultResponse),
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/results", bytes.NewReader([]byte(data.body)))
+			s.ctrl.ComputeExperimentResult(w, req, 2, 10)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
+
+func (s *AnalysisControllerTestSuite) TestIngestExperimentAssignments() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expected       string
+	}{
+		{
+			name:           "failure | invalid treatment",
+			body:           `{"assignments": [{"unit_id": "unit-1", "experiment_version": 3, "treatment": "unknown"}]}`,
+			expectedStatus: http.StatusBadRequest,
+			expected: fmt.Sprintf(s.expectedErrorResponseFormat,
+				400, "\"treatment unknown does not exist in version 3 of the experiment\""),
+		},
+		{
+			name:           "success",
+			body:           `{"assignments": [{"unit_id": "unit-1", "experiment_version": 3, "treatment": "control"}]}`,
+			expectedStatus: http.StatusNoContent,
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/assignments", bytes.NewReader([]byte(data.body)))
+			s.ctrl.IngestExperimentAssignments(w, req, 2, 10)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			s.Suite.Assert().Equal(data.expectedStatus, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			if data.expectedStatus != http.StatusNoContent {
+				s.Suite.Assert().JSONEq(data.expected, string(body))
+			}
+		})
+	}
+}
+
+func (s *AnalysisControllerTestSuite) TestUploadMetricObservations() {
+	t := s.Suite.T()
+
+	// Build the multipart request
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+	part, err := writer.CreateFormFile("file", "observations.csv")
+	s.Suite.Require().NoError(err)
+	_, err = part.Write([]byte("metric,unit_id,value\nconversion,unit-1,1\n"))
+	s.Suite.Require().NoError(err)
+	s.Suite.Require().NoError(writer.Close())
+
+	t.Run("missing file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/observations/upload", bytes.NewReader([]byte("")))
+		req.Header.Set("Content-Type", "multipart/form-data; boundary=x")
+		s.ctrl.UploadMetricObservations(w, req, 2, 10)
+		s.Suite.Assert().Equal(http.StatusBadRequest, w.Result().StatusCode)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/observations/upload", &buf)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		s.ctrl.UploadMetricObservations(w, req, 2, 10)
+		s.Suite.Assert().Equal(http.StatusNoContent, w.Result().StatusCode)
+	})
+}
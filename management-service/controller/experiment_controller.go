@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -36,19 +37,19 @@ func (e ExperimentController) GetExperiment(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	// Check if the projectId has been set up
-	_, err := e.Services.ProjectSettingsService.GetProjectSettings(projectId)
+	_, err := e.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId))
 		return
 	}
 
-	exp, err := e.Services.ExperimentService.GetExperiment(projectId, experimentId)
+	exp, err := e.Services.ExperimentService.GetExperiment(r.Context(), projectId, experimentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
-	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -64,24 +65,24 @@ func (e ExperimentController) ListExperiments(w http.ResponseWriter, r *http.Req
 		return
 	}
 	// Check if the projectId has been set up
-	if _, err := e.Services.ProjectSettingsService.GetProjectSettings(projectId); err != nil {
+	if _, err := e.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId); err != nil {
 		WriteErrorResponse(w, errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId))
 		return
 	}
 
-	listExperimentParams, err := e.toListExperimentParams(params, projectId)
+	listExperimentParams, err := e.toListExperimentParams(r.Context(), params, projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 	// List experiments
-	exps, paging, err := e.Services.ExperimentService.ListExperiments(projectId, *listExperimentParams)
+	exps, paging, err := e.Services.ExperimentService.ListExperiments(r.Context(), projectId, *listExperimentParams)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
-	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -124,7 +125,7 @@ func (e ExperimentController) CreateExperiment(w http.ResponseWriter, r *http.Re
 		return
 	}
 	// Check if the projectId has been set up
-	settings, err := e.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	settings, err := e.Services.ProjectSettingsService.GetDBRecord(r.Context(), models.ID(projectId))
 	if err != nil {
 		WriteErrorResponse(w, errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err))
 		return
@@ -134,12 +135,12 @@ func (e ExperimentController) CreateExperiment(w http.ResponseWriter, r *http.Re
 		WriteErrorResponse(w, err)
 		return
 	}
-	exp, err := e.Services.ExperimentService.CreateExperiment(*settings, *createExperimentBody)
+	exp, err := e.Services.ExperimentService.CreateExperiment(r.Context(), *settings, *createExperimentBody)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
-	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -174,7 +175,7 @@ func (e ExperimentController) UpdateExperiment(w http.ResponseWriter, r *http.Re
 		return
 	}
 	// Check if the projectId has been set up
-	settings, err := e.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	settings, err := e.Services.ProjectSettingsService.GetDBRecord(r.Context(), models.ID(projectId))
 	if err != nil {
 		WriteErrorResponse(w, errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err))
 		return
@@ -185,12 +186,12 @@ func (e ExperimentController) UpdateExperiment(w http.ResponseWriter, r *http.Re
 		WriteErrorResponse(w, err)
 		return
 	}
-	exp, err := e.Services.ExperimentService.UpdateExperiment(*settings, experimentId, *updateExperimentBody)
+	exp, err := e.Services.ExperimentService.UpdateExperiment(r.Context(), *settings, experimentId, *updateExperimentBody)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
-	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -205,13 +206,13 @@ func (e ExperimentController) EnableExperiment(w http.ResponseWriter, r *http.Re
 		return
 	}
 	// Check if the projectId has been set up
-	settings, err := e.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	settings, err := e.Services.ProjectSettingsService.GetDBRecord(r.Context(), models.ID(projectId))
 	if err != nil {
 		WriteErrorResponse(w, errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err))
 		return
 	}
 
-	err = e.Services.ExperimentService.EnableExperiment(*settings, experimentId)
+	err = e.Services.ExperimentService.EnableExperiment(r.Context(), *settings, experimentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -226,12 +227,12 @@ func (e ExperimentController) DisableExperiment(w http.ResponseWriter, r *http.R
 		return
 	}
 	// Check if the projectId has been set up
-	if _, err := e.Services.ProjectSettingsService.GetProjectSettings(projectId); err != nil {
+	if _, err := e.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId); err != nil {
 		WriteErrorResponse(w, errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId))
 		return
 	}
 
-	err := e.Services.ExperimentService.DisableExperiment(projectId, experimentId)
+	err := e.Services.ExperimentService.DisableExperiment(r.Context(), projectId, experimentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -244,6 +245,7 @@ func (e ExperimentController) toCreateExperimentBody(body api.CreateExperimentRe
 	for _, treatment := range body.Treatments {
 		treatments = append(treatments, models.ExperimentTreatment(treatment))
 	}
+	guardrails := toExperimentGuardrails(body.Guardrails)
 
 	reqBody := &services.CreateExperimentRequestBody{
 		Description: body.Description,
@@ -254,6 +256,7 @@ func (e ExperimentController) toCreateExperimentBody(body api.CreateExperimentRe
 		StartTime:   body.StartTime,
 		Status:      models.ExperimentStatus(body.Status),
 		Treatments:  treatments,
+		Guardrails:  guardrails,
 		Tier:        DefaultExperimentTier, // Set default
 		Type:        models.ExperimentType(body.Type),
 		UpdatedBy:   body.UpdatedBy,
@@ -272,6 +275,7 @@ func (e ExperimentController) toUpdateExperimentBody(body api.UpdateExperimentRe
 	for _, treatment := range body.Treatments {
 		treatments = append(treatments, models.ExperimentTreatment(treatment))
 	}
+	guardrails := toExperimentGuardrails(body.Guardrails)
 
 	reqBody := &services.UpdateExperimentRequestBody{
 		Description: body.Description,
@@ -281,6 +285,7 @@ func (e ExperimentController) toUpdateExperimentBody(body api.UpdateExperimentRe
 		StartTime:   body.StartTime,
 		Status:      models.ExperimentStatus(body.Status),
 		Treatments:  treatments,
+		Guardrails:  guardrails,
 		Tier:        DefaultExperimentTier, // Set default
 		Type:        models.ExperimentType(body.Type),
 		UpdatedBy:   body.UpdatedBy,
@@ -294,7 +299,27 @@ func (e ExperimentController) toUpdateExperimentBody(body api.UpdateExperimentRe
 	return reqBody, nil
 }
 
-func (e ExperimentController) toListExperimentParams(params api.ListExperimentsParams, projectId int64) (*services.ListExperimentsParams, error) {
+func toExperimentGuardrails(guardrails *[]schema.ExperimentGuardrail) models.ExperimentGuardrails {
+	if guardrails == nil {
+		return nil
+	}
+	var modelGuardrails models.ExperimentGuardrails
+	for _, guardrail := range *guardrails {
+		modelGuardrails = append(modelGuardrails, models.ExperimentGuardrail{
+			Metric:        guardrail.Metric,
+			Comparator:    models.GuardrailComparator(guardrail.Comparator),
+			Threshold:     guardrail.Threshold,
+			MinSampleSize: guardrail.MinSampleSize,
+		})
+	}
+	return modelGuardrails
+}
+
+func (e ExperimentController) toListExperimentParams(
+	ctx context.Context,
+	params api.ListExperimentsParams,
+	projectId int64,
+) (*services.ListExperimentsParams, error) {
 	var status *models.ExperimentStatus
 	if params.Status != nil {
 		val := models.ExperimentStatus(*params.Status)
@@ -319,7 +344,7 @@ func (e ExperimentController) toListExperimentParams(params api.ListExperimentsP
 
 	// Retrieve existing segmenters and remove invalid ones from request params
 	registeredSegmenters := set.New()
-	segmenters, err := e.Services.SegmenterService.ListSegmenters(projectId, services.ListSegmentersParams{})
+	segmenters, err := e.Services.SegmenterService.ListSegmenters(ctx, projectId, services.ListSegmentersParams{})
 	if err != nil {
 		return nil, err
 	}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/caraml-dev/xp/common/api/schema"
@@ -33,24 +34,24 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 	// Create mock project settings service and set up with test responses
 	settingsSvc := &mocks.ProjectSettingsService{}
 	settingsSvc.
-		On("GetDBRecord", models.ID(1)).
+		On("GetDBRecord", mock.Anything, models.ID(1)).
 		Return(nil, errors.Newf(errors.Unknown, "test find project settings error"))
 	settingsSvc.
-		On("GetDBRecord", models.ID(2)).
+		On("GetDBRecord", mock.Anything, models.ID(2)).
 		Return(&models.Settings{ProjectID: models.ID(2)}, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(1)).
+		On("GetProjectSettings", mock.Anything, int64(1)).
 		Return(nil, errors.Newf(errors.NotFound, "test get project settings error"))
 	settingsSvc.
-		On("GetProjectSettings", int64(2)).
+		On("GetProjectSettings", mock.Anything, int64(2)).
 		Return(&models.Settings{
 			Config: &models.ExperimentationConfig{},
 		}, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(3)).
+		On("GetProjectSettings", mock.Anything, int64(3)).
 		Return(nil, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(5)).
+		On("GetProjectSettings", mock.Anything, int64(5)).
 		Return(&models.Settings{
 			Config: &models.ExperimentationConfig{Segmenters: models.ProjectSegmenters{
 				Names: []string{"days_of_week"},
@@ -126,40 +127,40 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 	}
 	s.expectedErrorResponseFormat = `{"code":"%[1]v", "error":%[2]v, "message":%[2]v}`
 	expSvc.
-		On("GetExperiment", int64(2), int64(20)).
+		On("GetExperiment", mock.Anything, int64(2), int64(20)).
 		Return(nil, errors.Newf(errors.NotFound, "experiment not found"))
 	expSvc.
-		On("GetExperiment", int64(2), int64(2)).
+		On("GetExperiment", mock.Anything, int64(2), int64(2)).
 		Return(testExperiment, nil)
 	expSvc.
-		On("GetExperiment", int64(5), int64(1)).
+		On("GetExperiment", mock.Anything, int64(5), int64(1)).
 		Return(testExperiment2, nil)
 	var emptyStatus *models.ExperimentStatus
 	var emptyType *models.ExperimentType
 	updatedBy := "test-user"
 	expSvc.
-		On("ListExperiments", int64(3), services.ListExperimentsParams{
+		On("ListExperiments", mock.Anything, int64(3), services.ListExperimentsParams{
 			Status:         emptyStatus,
 			StatusFriendly: []services.ExperimentStatusFriendly{},
 			Type:           emptyType,
 			Segment:        models.ExperimentSegment{},
 		}).Return(nil, nil, fmt.Errorf("unexpected error"))
 	expSvc.
-		On("ListExperiments", int64(2), services.ListExperimentsParams{
+		On("ListExperiments", mock.Anything, int64(2), services.ListExperimentsParams{
 			Status:         emptyStatus,
 			StatusFriendly: []services.ExperimentStatusFriendly{},
 			Type:           emptyType,
 			Segment:        models.ExperimentSegment{"days_of_week": []string{"1"}},
 		}).Return([]*models.Experiment{testExperiment}, nil, nil)
 	expSvc.
-		On("ListExperiments", int64(2), services.ListExperimentsParams{
+		On("ListExperiments", mock.Anything, int64(2), services.ListExperimentsParams{
 			Status:         emptyStatus,
 			StatusFriendly: []services.ExperimentStatusFriendly{},
 			Type:           emptyType,
 			Segment:        models.ExperimentSegment{},
 		}).Return([]*models.Experiment{testExperiment}, nil, nil)
 	expSvc.
-		On("CreateExperiment",
+		On("CreateExperiment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			services.CreateExperimentRequestBody{
 				Name:      "test-exp",
@@ -169,7 +170,7 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 			}).
 		Return(nil, fmt.Errorf("experiment creation failed"))
 	expSvc.
-		On("CreateExperiment",
+		On("CreateExperiment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			services.CreateExperimentRequestBody{
 				Name:      "test-exp-2",
@@ -179,7 +180,7 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 			}).
 		Return(testExperiment, nil)
 	expSvc.
-		On("CreateExperiment",
+		On("CreateExperiment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			services.CreateExperimentRequestBody{
 				Name:      "test-exp-2",
@@ -188,10 +189,23 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 				Segment:   models.ExperimentSegmentRaw(nil),
 			}).
 		Return(testExperiment1, nil)
+	expSvc.
+		On("CreateExperiment", mock.Anything,
+			models.Settings{ProjectID: models.ID(2)},
+			services.CreateExperimentRequestBody{
+				Name:      "test-exp-3",
+				UpdatedBy: &updatedBy,
+				Tier:      models.ExperimentTierDefault,
+				Segment:   models.ExperimentSegmentRaw(nil),
+				Guardrails: models.ExperimentGuardrails{
+					{Metric: "error_rate", Comparator: models.GuardrailComparatorGreaterThan, Threshold: 0.05},
+				},
+			}).
+		Return(testExperiment, nil)
 	testDescription := "test-description-2"
 	testDaysOfWeek := []interface{}{float64(1)}
 	expSvc.
-		On("UpdateExperiment",
+		On("UpdateExperiment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			int64(1),
 			services.UpdateExperimentRequestBody{
@@ -201,7 +215,7 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 			}).
 		Return(nil, fmt.Errorf("experiment update failed"))
 	expSvc.
-		On("UpdateExperiment",
+		On("UpdateExperiment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			int64(1),
 			services.UpdateExperimentRequestBody{
@@ -212,7 +226,7 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 			}).
 		Return(testExperiment, nil)
 	expSvc.
-		On("UpdateExperiment",
+		On("UpdateExperiment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			int64(1),
 			services.UpdateExperimentRequestBody{
@@ -223,41 +237,41 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 			}).
 		Return(testExperiment1, nil)
 	expSvc.
-		On("EnableExperiment",
+		On("EnableExperiment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			int64(1)).
 		Return(nil)
 	expSvc.
-		On("EnableExperiment",
+		On("EnableExperiment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			int64(3)).
 		Return(errors.Newf(errors.BadInput, "experiment id 3 is already active"))
 	expSvc.
-		On("DisableExperiment",
+		On("DisableExperiment", mock.Anything,
 			int64(2),
 			int64(1)).
 		Return(nil)
 	expSvc.
-		On("DisableExperiment",
+		On("DisableExperiment", mock.Anything,
 			int64(2),
 			int64(3)).
 		Return(errors.Newf(errors.BadInput, "experiment id 3 is already inactive"))
 
 	segmenterSvc := &mocks.SegmenterService{}
 	segmenterSvc.
-		On("ListSegmenters", int64(3), services.ListSegmentersParams{}).
+		On("ListSegmenters", mock.Anything, int64(3), services.ListSegmentersParams{}).
 		Return([]*schema.Segmenter{
 			{
 				Name: "days_of_week",
 			}}, nil)
 	segmenterSvc.
-		On("ListSegmenters", int64(2), services.ListSegmentersParams{}).
+		On("ListSegmenters", mock.Anything, int64(2), services.ListSegmentersParams{}).
 		Return([]*schema.Segmenter{
 			{
 				Name: "days_of_week",
 			}}, nil)
 	segmenterSvc.
-		On("GetSegmenterConfigurations",
+		On("GetSegmenterConfigurations", mock.Anything,
 			[]string{"days_of_week"}).
 		Return(
 			[]*_segmenters.SegmenterConfiguration{
@@ -265,7 +279,7 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 			}, nil,
 		)
 	segmenterSvc.
-		On("GetSegmenterTypes", int64(2)).
+		On("GetSegmenterTypes", mock.Anything, int64(2)).
 		Return(
 			map[string]schema.SegmenterType{
 				"hours_of_day": schema.SegmenterTypeInteger,
@@ -274,7 +288,7 @@ func (s *ExperimentControllerTestSuite) SetupSuite() {
 			nil,
 		)
 	segmenterSvc.
-		On("GetSegmenterTypes", int64(5)).
+		On("GetSegmenterTypes", mock.Anything, int64(5)).
 		Return(
 			map[string]schema.SegmenterType{
 				"hours_of_day": schema.SegmenterTypeInteger,
@@ -357,7 +371,7 @@ func (s *ExperimentControllerTestSuite) TestGetExperiment() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			s.ctrl.GetExperiment(w, nil, data.projectID, data.experimentID)
+			s.ctrl.GetExperiment(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.experimentID)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
@@ -465,6 +479,13 @@ func (s *ExperimentControllerTestSuite) TestCreateExperiment() {
 			experimentData: `{"name": "test-exp-2", "updated_by": "test-user", "tier": "override"}`,
 			expected:       fmt.Sprintf(`{"data": %s}`, s.expectedExperimentResponses[1]),
 		},
+		{
+			name:      "success | with guardrails",
+			projectID: 2,
+			experimentData: `{"name": "test-exp-3", "updated_by": "test-user",
+				"guardrails": [{"metric": "error_rate", "comparator": "greater_than", "threshold": 0.05}]}`,
+			expected: fmt.Sprintf(`{"data": %s}`, s.expectedExperimentResponses[0]),
+		},
 	}
 
 	// Run tests
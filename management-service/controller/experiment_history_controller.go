@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/caraml-dev/xp/common/api/schema"
@@ -27,19 +28,23 @@ func (e ExperimentHistoryController) ListExperimentHistory(
 	experimentId int64,
 	params api.ListExperimentHistoryParams,
 ) {
-	err := e.checkProjectAndExperiment(projectId, experimentId)
+	err := e.checkProjectAndExperiment(r.Context(), projectId, experimentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
 	// List historical versions
-	versions, paging, err := e.Services.ExperimentHistoryService.ListExperimentHistory(experimentId, e.toListExperimentHistoryParams(params))
+	versions, paging, err := e.Services.ExperimentHistoryService.ListExperimentHistory(
+		r.Context(),
+		experimentId,
+		e.toListExperimentHistoryParams(params),
+	)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
-	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -58,19 +63,19 @@ func (e ExperimentHistoryController) GetExperimentHistory(
 	experimentId int64,
 	version int64,
 ) {
-	err := e.checkProjectAndExperiment(projectId, experimentId)
+	err := e.checkProjectAndExperiment(r.Context(), projectId, experimentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
 	// Get history record
-	exp, err := e.Services.ExperimentHistoryService.GetExperimentHistory(experimentId, version)
+	exp, err := e.Services.ExperimentHistoryService.GetExperimentHistory(r.Context(), experimentId, version)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
-	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := e.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -87,18 +92,18 @@ func (e ExperimentHistoryController) toListExperimentHistoryParams(params api.Li
 	}
 }
 
-func (e ExperimentHistoryController) checkProjectAndExperiment(projectId int64, experimentId int64) error {
+func (e ExperimentHistoryController) checkProjectAndExperiment(ctx context.Context, projectId int64, experimentId int64) error {
 	// Check if the projectId is valid
 	if _, err := e.Services.MLPService.GetProject(projectId); err != nil {
 		return err
 	}
 	// Check if the projectId has been set up
-	_, err := e.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	_, err := e.Services.ProjectSettingsService.GetDBRecord(ctx, models.ID(projectId))
 	if err != nil {
 		return errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err)
 	}
 	// Check that the experiment exists
-	_, err = e.Services.ExperimentService.GetDBRecord(models.ID(projectId), models.ID(experimentId))
+	_, err = e.Services.ExperimentService.GetDBRecord(ctx, models.ID(projectId), models.ID(experimentId))
 	if err != nil {
 		return errors.Newf(errors.NotFound, "Experiment with id %d cannot be retrieved: %v", experimentId, err)
 	}
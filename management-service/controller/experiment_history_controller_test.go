@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/caraml-dev/xp/common/api/schema"
@@ -42,19 +43,19 @@ func (s *ExperimentHistoryControllerTestSuite) SetupSuite() {
 	// Create mock project settings service and set up with test responses
 	settingsSvc := &mocks.ProjectSettingsService{}
 	settingsSvc.
-		On("GetDBRecord", models.ID(2)).
+		On("GetDBRecord", mock.Anything, models.ID(2)).
 		Return(nil, errors.Newf(errors.Unknown, "test get project settings error"))
 	settingsSvc.
-		On("GetDBRecord", models.ID(3)).
+		On("GetDBRecord", mock.Anything, models.ID(3)).
 		Return(nil, nil)
 
 	// Create mock experiment service and set up with test responses
 	expSvc := &mocks.ExperimentService{}
 	expSvc.
-		On("GetDBRecord", models.ID(3), models.ID(1)).
+		On("GetDBRecord", mock.Anything, models.ID(3), models.ID(1)).
 		Return(nil, errors.Newf(errors.NotFound, "experiment not found"))
 	expSvc.
-		On("GetDBRecord", models.ID(3), models.ID(10)).
+		On("GetDBRecord", mock.Anything, models.ID(3), models.ID(10)).
 		Return(nil, nil)
 
 	// Set up mock experiment history service
@@ -95,20 +96,20 @@ func (s *ExperimentHistoryControllerTestSuite) SetupSuite() {
 	}
 	expHistSvc := &mocks.ExperimentHistoryService{}
 	expHistSvc.
-		On("GetExperimentHistory", int64(10), int64(2)).
+		On("GetExperimentHistory", mock.Anything, int64(10), int64(2)).
 		Return(nil, errors.Newf(errors.NotFound, "experiment history not found"))
 	expHistSvc.
-		On("GetExperimentHistory", int64(10), int64(1)).
+		On("GetExperimentHistory", mock.Anything, int64(10), int64(1)).
 		Return(testExpHistory, nil)
 	expHistSvc.
-		On("ListExperimentHistory", int64(10), services.ListExperimentHistoryParams{
+		On("ListExperimentHistory", mock.Anything, int64(10), services.ListExperimentHistoryParams{
 			PaginationOptions: pagination.PaginationOptions{},
 		}).
 		Return([]*models.ExperimentHistory{testExpHistory}, &pagination.Paging{Page: 1, Total: 1, Pages: 1}, nil)
 
 	segmenterSvc := &mocks.SegmenterService{}
 	segmenterSvc.
-		On("GetSegmenterTypes", int64(3)).
+		On("GetSegmenterTypes", mock.Anything, int64(3)).
 		Return(
 			map[string]schema.SegmenterType{
 				"days_of_week": schema.SegmenterTypeInteger,
@@ -217,7 +218,7 @@ func (s *ExperimentHistoryControllerTestSuite) TestGetExperimentHistory() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			s.ctrl.GetExperimentHistory(w, nil, data.projectID, data.experimentID, data.version)
+			s.ctrl.GetExperimentHistory(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.experimentID, data.version)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/management-service/api"
+	"github.com/caraml-dev/xp/management-service/appcontext"
+	"github.com/caraml-dev/xp/management-service/models"
+	"github.com/caraml-dev/xp/management-service/pagination"
+	"github.com/caraml-dev/xp/management-service/services"
+)
+
+type OutboxController struct {
+	*appcontext.AppContext
+}
+
+func NewOutboxController(ctx *appcontext.AppContext) *OutboxController {
+	return &OutboxController{ctx}
+}
+
+func (o OutboxController) ListOutboxMessages(
+	w http.ResponseWriter,
+	r *http.Request,
+	params api.ListOutboxMessagesParams,
+) {
+	messages, paging, err := o.Services.OutboxService.ListOutboxMessages(r.Context(), o.toListOutboxMessagesParams(params))
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	messagesResp := []schema.OutboxMessage{}
+	for _, message := range messages {
+		messagesResp = append(messagesResp, message.ToApiSchema())
+	}
+	Ok(w, messagesResp, ToPagingSchema(paging))
+}
+
+func (o OutboxController) ReplayOutboxMessage(w http.ResponseWriter, r *http.Request, messageId int64) {
+	message, err := o.Services.OutboxService.ReplayOutboxMessage(r.Context(), messageId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	Ok(w, message.ToApiSchema())
+}
+
+func (o OutboxController) toListOutboxMessagesParams(params api.ListOutboxMessagesParams) services.ListOutboxMessagesParams {
+	var status *models.OutboxMessageStatus
+	if params.Status != nil {
+		outboxMessageStatus := models.OutboxMessageStatus(*params.Status)
+		status = &outboxMessageStatus
+	}
+
+	return services.ListOutboxMessagesParams{
+		PaginationOptions: pagination.PaginationOptions{
+			Page:     params.Page,
+			PageSize: params.PageSize,
+		},
+		Status: status,
+	}
+}
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/management-service/api"
+	"github.com/caraml-dev/xp/management-service/appcontext"
+	"github.com/caraml-dev/xp/management-service/errors"
+	"github.com/caraml-dev/xp/management-service/models"
+	"github.com/caraml-dev/xp/management-service/pagination"
+	"github.com/caraml-dev/xp/management-service/services"
+	"github.com/caraml-dev/xp/management-service/services/mocks"
+)
+
+type OutboxControllerTestSuite struct {
+	suite.Suite
+	ctrl                          *OutboxController
+	expectedOutboxMessageResponse string
+	expectedErrorResponseFormat   string
+}
+
+func (s *OutboxControllerTestSuite) SetupSuite() {
+	s.Suite.T().Log("Setting up OutboxControllerTestSuite")
+
+	lastError := "test publish error"
+	testMessage := &models.OutboxMessage{
+		Model: models.Model{
+			CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+			UpdatedAt: time.Date(2021, 1, 1, 2, 3, 5, 0, time.UTC),
+		},
+		ID:            models.ID(10),
+		ProjectID:     models.ID(1),
+		MessageType:   models.OutboxMessageTypeProjectSettings,
+		UpdateType:    "create",
+		Payload:       []byte("payload"),
+		Status:        models.OutboxMessageStatusFailed,
+		Attempts:      3,
+		LastError:     &lastError,
+		NextAttemptAt: time.Date(2021, 1, 1, 2, 3, 5, 0, time.UTC),
+	}
+	failedStatus := models.OutboxMessageStatusFailed
+
+	outboxSvc := &mocks.OutboxService{}
+	outboxSvc.
+		On("ListOutboxMessages", mock.Anything, services.ListOutboxMessagesParams{
+			PaginationOptions: pagination.PaginationOptions{},
+			Status:            &failedStatus,
+		}).
+		Return([]*models.OutboxMessage{testMessage}, &pagination.Paging{Page: 1, Total: 1, Pages: 1}, nil)
+	outboxSvc.
+		On("ListOutboxMessages", mock.Anything, services.ListOutboxMessagesParams{}).
+		Return(nil, nil, errors.Newf(errors.Unknown, "test list outbox messages error"))
+	outboxSvc.
+		On("ReplayOutboxMessage", mock.Anything, int64(10)).
+		Return(testMessage, nil)
+	outboxSvc.
+		On("ReplayOutboxMessage", mock.Anything, int64(20)).
+		Return(nil, errors.Newf(errors.BadInput, "outbox message id 20 has already been sent"))
+
+	// Set up expected responses
+	s.expectedErrorResponseFormat = `{"code":"%[1]v", "error":%[2]v, "message":%[2]v}`
+	s.expectedOutboxMessageResponse = `{
+		"id": 10,
+		"project_id": 1,
+		"message_type": "project_settings",
+		"update_type": "create",
+		"status": "failed",
+		"attempts": 3,
+		"last_error": "test publish error",
+		"next_attempt_at": "2021-01-01T02:03:05Z",
+		"sent_at": null,
+		"created_at": "2021-01-01T02:03:04Z",
+		"updated_at": "2021-01-01T02:03:05Z"
+	}`
+
+	// Create test controller
+	s.ctrl = &OutboxController{
+		AppContext: &appcontext.AppContext{
+			Services: services.Services{
+				OutboxService: outboxSvc,
+			},
+		},
+	}
+}
+
+func TestOutboxController(t *testing.T) {
+	suite.Run(t, new(OutboxControllerTestSuite))
+}
+
+func (s *OutboxControllerTestSuite) TestListOutboxMessages() {
+	t := s.Suite.T()
+	failedStatus := schema.OutboxMessageStatusFailed
+
+	tests := []struct {
+		name     string
+		params   api.ListOutboxMessagesParams
+		expected string
+	}{
+		{
+			name:     "failure | list outbox messages",
+			params:   api.ListOutboxMessagesParams{},
+			expected: fmt.Sprintf(s.expectedErrorResponseFormat, 500, "\"test list outbox messages error\""),
+		},
+		{
+			name:     "success",
+			params:   api.ListOutboxMessagesParams{Status: &failedStatus},
+			expected: fmt.Sprintf(`{"data": [%s], "paging": {"page": 1, "pages": 1, "total": 1}}`, s.expectedOutboxMessageResponse),
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ctrl.ListOutboxMessages(w, httptest.NewRequest(http.MethodGet, "/", nil), data.params)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
+
+func (s *OutboxControllerTestSuite) TestReplayOutboxMessage() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name      string
+		messageID int64
+		expected  string
+	}{
+		{
+			name:      "failure | message already sent",
+			messageID: 20,
+			expected:  fmt.Sprintf(s.expectedErrorResponseFormat, 400, "\"outbox message id 20 has already been sent\""),
+		},
+		{
+			name:      "success",
+			messageID: 10,
+			expected:  fmt.Sprintf(`{"data": %s}`, s.expectedOutboxMessageResponse),
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ctrl.ReplayOutboxMessage(w, httptest.NewRequest(http.MethodPost, "/", nil), data.messageID)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
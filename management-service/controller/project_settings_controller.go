@@ -27,7 +27,7 @@ func (p ProjectSettingsController) GetProjectSettings(w http.ResponseWriter, r *
 		return
 	}
 
-	settings, err := p.Services.ProjectSettingsService.GetProjectSettings(projectId)
+	settings, err := p.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -44,7 +44,7 @@ func (p ProjectSettingsController) GetProjectExperimentVariables(w http.Response
 		return
 	}
 
-	parameters, err := p.Services.ProjectSettingsService.GetExperimentVariables(projectId)
+	parameters, err := p.Services.ProjectSettingsService.GetExperimentVariables(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -54,7 +54,7 @@ func (p ProjectSettingsController) GetProjectExperimentVariables(w http.Response
 }
 
 func (p ProjectSettingsController) ListProjects(w http.ResponseWriter, r *http.Request) {
-	projects, err := p.Services.ProjectSettingsService.ListProjects()
+	projects, err := p.Services.ProjectSettingsService.ListProjects(r.Context())
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -89,6 +89,7 @@ func (p ProjectSettingsController) CreateProjectSettings(w http.ResponseWriter,
 	}
 
 	settings, err := p.Services.ProjectSettingsService.CreateProjectSettings(
+		r.Context(),
 		projectId,
 		services.CreateProjectSettingsRequestBody{
 			Segmenters: models.ProjectSegmenters{
@@ -125,12 +126,13 @@ func (p ProjectSettingsController) UpdateProjectSettings(w http.ResponseWriter,
 		return
 	}
 	// Check that the settings exists
-	if _, err := p.Services.ProjectSettingsService.GetProjectSettings(projectId); err != nil {
+	if _, err := p.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId); err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
 	settings, err := p.Services.ProjectSettingsService.UpdateProjectSettings(
+		r.Context(),
 		projectId,
 		services.UpdateProjectSettingsRequestBody{
 			Segmenters: models.ProjectSegmenters{
@@ -152,6 +154,94 @@ func (p ProjectSettingsController) UpdateProjectSettings(w http.ResponseWriter,
 	Ok(w, resp)
 }
 
+func (p ProjectSettingsController) ListProjectPasskeys(w http.ResponseWriter, r *http.Request, projectId int64) {
+	// Check if the projectId is valid
+	if _, err := p.Services.MLPService.GetProject(projectId); err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	passkeys, err := p.Services.ProjectSettingsService.ListPasskeys(r.Context(), projectId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	resp := []schema.ProjectPasskey{}
+	for _, passkey := range passkeys {
+		resp = append(resp, passkey.ToApiSchema())
+	}
+
+	Ok(w, resp)
+}
+
+func (p ProjectSettingsController) ListProjectPasskeyHashes(w http.ResponseWriter, r *http.Request, projectId int64) {
+	// Check if the projectId is valid
+	if _, err := p.Services.MLPService.GetProject(projectId); err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	passkeys, err := p.Services.ProjectSettingsService.ListPasskeys(r.Context(), projectId)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	resp := []schema.ProjectPasskeyHash{}
+	for _, passkey := range passkeys {
+		resp = append(resp, passkey.ToHashApiSchema())
+	}
+
+	Ok(w, resp)
+}
+
+func (p ProjectSettingsController) CreateProjectPasskey(w http.ResponseWriter, r *http.Request, projectId int64) {
+	passkeyData := api.CreateProjectPasskeyRequestBody{}
+	err := json.NewDecoder(r.Body).Decode(&passkeyData)
+	if err != nil {
+		WriteErrorResponse(w, errors.Newf(errors.BadInput, err.Error()))
+		return
+	}
+
+	// Check if the projectId is valid
+	if _, err := p.Services.MLPService.GetProject(projectId); err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	passkey, err := p.Services.ProjectSettingsService.CreatePasskey(
+		r.Context(),
+		projectId,
+		services.CreatePasskeyRequestBody{
+			Name:      passkeyData.Name,
+			ExpiresAt: passkeyData.ExpiresAt,
+		},
+	)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	resp := passkey.ToCreatedApiSchema()
+
+	Ok(w, resp)
+}
+
+func (p ProjectSettingsController) RevokeProjectPasskey(w http.ResponseWriter, r *http.Request, projectId int64, name string) {
+	// Check if the projectId is valid
+	if _, err := p.Services.MLPService.GetProject(projectId); err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+
+	err := p.Services.ProjectSettingsService.RevokePasskey(r.Context(), projectId, name)
+	if err != nil {
+		WriteErrorResponse(w, err)
+		return
+	}
+	resp := map[string]string{"name": name}
+
+	Ok(w, resp)
+}
+
 // parseTreatmentSchema parses treatmentSchema from an api struct into a model struct
 func parseTreatmentSchema(treatmentSchema *schema.TreatmentSchema) (parsedTreatmentSchema *models.TreatmentSchema) {
 	if treatmentSchema == nil {
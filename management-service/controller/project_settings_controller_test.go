@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/caraml-dev/mlp/api/client"
 	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
@@ -32,6 +34,7 @@ func (s *ProjectSettingsControllerTestSuite) SetupSuite() {
 	s.Suite.T().Log("Setting up ProjectSettingsControllerTestSuite")
 
 	// Create mock project settings service and set up with test responses
+	passkeyExpiresAt := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
 	projects := []models.Project{{Id: 1, Segmenters: []string{"test-seg"}}}
 	projectSettings := models.Settings{
 		ProjectID: 2,
@@ -63,7 +66,7 @@ func (s *ProjectSettingsControllerTestSuite) SetupSuite() {
 		"created_at": "0001-01-01T00:00:00Z",
 		"updated_at": "0001-01-01T00:00:00Z",
 		"username": "",
-		"passkey": "",
+		"passkeys": [],
 		"segmenters": {
 			"names": ["seg1"],
 			"variables": {
@@ -105,31 +108,31 @@ func (s *ProjectSettingsControllerTestSuite) SetupSuite() {
 	}
 	settingsSvc := &mocks.ProjectSettingsService{}
 	settingsSvc.
-		On("ListProjects").
+		On("ListProjects", mock.Anything).
 		Return(&projects, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(1)).
+		On("GetProjectSettings", mock.Anything, int64(1)).
 		Return(nil, errors.Newf(errors.NotFound, "test get project settings error"))
 	settingsSvc.
-		On("GetProjectSettings", int64(2)).
+		On("GetProjectSettings", mock.Anything, int64(2)).
 		Return(&projectSettings, nil)
 	settingsSvc.
-		On("GetExperimentVariables", int64(1)).
+		On("GetExperimentVariables", mock.Anything, int64(1)).
 		Return(nil, errors.Newf(errors.NotFound, "test get project settings error"))
 	settingsSvc.
-		On("GetExperimentVariables", int64(2)).
+		On("GetExperimentVariables", mock.Anything, int64(2)).
 		Return(&[]string{"rand", "exp_var_1", "exp_var_2"}, nil)
 	settingsSvc.
-		On("ListSegmenters", int64(2)).
+		On("ListSegmenters", mock.Anything, int64(2)).
 		Return(segmenter, nil)
 	settingsSvc.
 		On("ListProjectSettings").
 		Return(&[]models.Settings{projectSettings}, nil)
 	settingsSvc.
-		On("CreateProjectSettings", int64(2), services.CreateProjectSettingsRequestBody{}).
+		On("CreateProjectSettings", mock.Anything, int64(2), services.CreateProjectSettingsRequestBody{}).
 		Return(nil, fmt.Errorf("test create project settings error"))
 	settingsSvc.
-		On("CreateProjectSettings", int64(4), services.CreateProjectSettingsRequestBody{
+		On("CreateProjectSettings", mock.Anything, int64(4), services.CreateProjectSettingsRequestBody{
 			Username: "client-4",
 			Segmenters: models.ProjectSegmenters{
 				Names: []string{"seg1"},
@@ -153,10 +156,11 @@ func (s *ProjectSettingsControllerTestSuite) SetupSuite() {
 		}).
 		Return(&projectSettings, nil)
 	settingsSvc.
-		On("UpdateProjectSettings", int64(2), services.UpdateProjectSettingsRequestBody{RandomizationKey: "rkey1", Segmenters: models.ProjectSegmenters{}}).
+		On("UpdateProjectSettings", mock.Anything, int64(2),
+			services.UpdateProjectSettingsRequestBody{RandomizationKey: "rkey1", Segmenters: models.ProjectSegmenters{}}).
 		Return(nil, fmt.Errorf("test update project settings internal error"))
 	settingsSvc.
-		On("UpdateProjectSettings", int64(2), services.UpdateProjectSettingsRequestBody{
+		On("UpdateProjectSettings", mock.Anything, int64(2), services.UpdateProjectSettingsRequestBody{
 			RandomizationKey: "rkey2",
 			Segmenters: models.ProjectSegmenters{
 				Names: []string{"seg1"},
@@ -179,6 +183,45 @@ func (s *ProjectSettingsControllerTestSuite) SetupSuite() {
 			ValidationUrl: nil,
 		}).
 		Return(&projectSettings, nil)
+	settingsSvc.
+		On("ListPasskeys", mock.Anything, int64(1)).
+		Return(nil, errors.Newf(errors.NotFound, "test get project settings error"))
+	settingsSvc.
+		On("ListPasskeys", mock.Anything, int64(2)).
+		Return([]models.Passkey{
+			{
+				Model:     models.Model{CreatedAt: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+				ProjectID: 2,
+				Name:      "default",
+				Hash:      "0123456789abcdef",
+			},
+			{
+				Model:     models.Model{CreatedAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)},
+				ProjectID: 2,
+				Name:      "rotated",
+				Hash:      "fedcba9876543210",
+				ExpiresAt: &passkeyExpiresAt,
+			},
+		}, nil)
+	settingsSvc.
+		On("CreatePasskey", mock.Anything, int64(2), services.CreatePasskeyRequestBody{Name: "default"}).
+		Return(nil, errors.Newf(errors.BadInput, "passkey default already exists"))
+	settingsSvc.
+		On("CreatePasskey", mock.Anything, int64(2), services.CreatePasskeyRequestBody{Name: "rotated", ExpiresAt: &passkeyExpiresAt}).
+		Return(&models.Passkey{
+			Model:     models.Model{CreatedAt: time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)},
+			ProjectID: 2,
+			Name:      "rotated",
+			Hash:      "hash-2",
+			ExpiresAt: &passkeyExpiresAt,
+			Value:     "passkey-2",
+		}, nil)
+	settingsSvc.
+		On("RevokePasskey", mock.Anything, int64(2), "default").
+		Return(errors.Newf(errors.BadInput, "passkey default is the only active passkey of the project"))
+	settingsSvc.
+		On("RevokePasskey", mock.Anything, int64(2), "rotated").
+		Return(nil)
 
 	mlpSvc := &mocks.MLPService{}
 	mlpSvc.On("GetProject", int64(1)).Return(&client.Project{Name: ""}, nil)
@@ -228,7 +271,7 @@ func (s *ProjectSettingsControllerTestSuite) TestGetProjectSettings() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			s.ctrl.GetProjectSettings(w, nil, data.projectID)
+			s.ctrl.GetProjectSettings(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
@@ -270,7 +313,7 @@ func (s *ProjectSettingsControllerTestSuite) TestGetProjectExperimentVariables()
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			s.ctrl.GetProjectExperimentVariables(w, nil, data.projectID)
+			s.ctrl.GetProjectExperimentVariables(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
@@ -284,7 +327,7 @@ func (s *ProjectSettingsControllerTestSuite) TestGetProjectExperimentVariables()
 
 func (s *ProjectSettingsControllerTestSuite) TestListProjects() {
 	w := httptest.NewRecorder()
-	s.ctrl.ListProjects(w, nil)
+	s.ctrl.ListProjects(w, httptest.NewRequest(http.MethodGet, "/", nil))
 	resp := w.Result()
 	if resp != nil && resp.Body != nil {
 		defer resp.Body.Close()
@@ -494,6 +537,215 @@ func (s *ProjectSettingsControllerTestSuite) TestUpdateProjectSettings() {
 	}
 }
 
+func (s *ProjectSettingsControllerTestSuite) TestListProjectPasskeys() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name      string
+		projectID int64
+		expected  string
+	}{
+		{
+			name:      "project settings not found",
+			projectID: 1,
+			expected:  fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"test get project settings error\""),
+		},
+		{
+			name:      "mlp project not found",
+			projectID: 3,
+			expected:  fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"MLP Project info for id 3 not found in the cache\""),
+		},
+		{
+			name:      "success",
+			projectID: 2,
+			expected: `{"data": [
+				{
+					"name": "default",
+					"fingerprint": "01234567",
+					"created_at": "2021-01-01T00:00:00Z"
+				},
+				{
+					"name": "rotated",
+					"fingerprint": "fedcba98",
+					"expires_at": "2021-02-01T00:00:00Z",
+					"created_at": "2021-01-02T00:00:00Z"
+				}
+			]}`,
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ctrl.ListProjectPasskeys(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
+
+func (s *ProjectSettingsControllerTestSuite) TestListProjectPasskeyHashes() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name      string
+		projectID int64
+		expected  string
+	}{
+		{
+			name:      "project settings not found",
+			projectID: 1,
+			expected:  fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"test get project settings error\""),
+		},
+		{
+			name:      "mlp project not found",
+			projectID: 3,
+			expected:  fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"MLP Project info for id 3 not found in the cache\""),
+		},
+		{
+			name:      "success",
+			projectID: 2,
+			expected: `{"data": [
+				{
+					"name": "default",
+					"hash": "0123456789abcdef"
+				},
+				{
+					"name": "rotated",
+					"hash": "fedcba9876543210",
+					"expires_at": "2021-02-01T00:00:00Z"
+				}
+			]}`,
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ctrl.ListProjectPasskeyHashes(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
+
+func (s *ProjectSettingsControllerTestSuite) TestCreateProjectPasskey() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name      string
+		projectID int64
+		body      string
+		expected  string
+	}{
+		{
+			name:      "invalid body",
+			projectID: 2,
+			body:      `{"name": 1}`,
+			expected: fmt.Sprintf(s.expectedErrorResponseFormat, 400,
+				"\"json: cannot unmarshal number into Go struct field CreateProjectPasskeyRequestBody.name of type string\""),
+		},
+		{
+			name:      "mlp project not found",
+			projectID: 3,
+			body:      `{"name": "rotated"}`,
+			expected:  fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"MLP Project info for id 3 not found in the cache\""),
+		},
+		{
+			name:      "failure",
+			projectID: 2,
+			body:      `{"name": "default"}`,
+			expected:  fmt.Sprintf(s.expectedErrorResponseFormat, 400, "\"passkey default already exists\""),
+		},
+		{
+			name:      "success",
+			projectID: 2,
+			body:      `{"name": "rotated", "expires_at": "2021-02-01T00:00:00Z"}`,
+			expected: `{"data": {
+				"name": "rotated",
+				"passkey": "passkey-2",
+				"expires_at": "2021-02-01T00:00:00Z",
+				"created_at": "2021-01-02T00:00:00Z"
+			}}`,
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/", bytes.NewBuffer([]byte(data.body)))
+			s.Suite.Require().NoError(err)
+			w := httptest.NewRecorder()
+			s.ctrl.CreateProjectPasskey(w, req, data.projectID)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
+
+func (s *ProjectSettingsControllerTestSuite) TestRevokeProjectPasskey() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name        string
+		projectID   int64
+		passkeyName string
+		expected    string
+	}{
+		{
+			name:        "mlp project not found",
+			projectID:   3,
+			passkeyName: "rotated",
+			expected:    fmt.Sprintf(s.expectedErrorResponseFormat, 404, "\"MLP Project info for id 3 not found in the cache\""),
+		},
+		{
+			name:        "failure",
+			projectID:   2,
+			passkeyName: "default",
+			expected: fmt.Sprintf(s.expectedErrorResponseFormat, 400,
+				"\"passkey default is the only active passkey of the project\""),
+		},
+		{
+			name:        "success",
+			projectID:   2,
+			passkeyName: "rotated",
+			expected:    `{"data": {"name": "rotated"}}`,
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			s.ctrl.RevokeProjectPasskey(w, httptest.NewRequest(http.MethodDelete, "/", nil), data.projectID, data.passkeyName)
+			resp := w.Result()
+			if resp != nil && resp.Body != nil {
+				defer resp.Body.Close()
+			}
+			body, err := io.ReadAll(resp.Body)
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().JSONEq(data.expected, string(body))
+		})
+	}
+}
+
 func (s *ProjectSettingsControllerTestSuite) TestParseTreatmentSchema() {
 	tests := []struct {
 		treatmentSchema *schema.TreatmentSchema
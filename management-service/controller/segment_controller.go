@@ -29,13 +29,13 @@ func (s SegmentController) ListSegments(w http.ResponseWriter, r *http.Request,
 		return
 	}
 	// Check if the projectId has been set up
-	if _, err := s.Services.ProjectSettingsService.GetProjectSettings(projectId); err != nil {
+	if _, err := s.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId); err != nil {
 		WriteErrorResponse(w, errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId))
 		return
 	}
 
 	// List segments
-	segments, paging, err := s.Services.SegmentService.ListSegments(projectId, s.toListSegmentParams(params))
+	segments, paging, err := s.Services.SegmentService.ListSegments(r.Context(), projectId, s.toListSegmentParams(params))
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -48,7 +48,7 @@ func (s SegmentController) ListSegments(w http.ResponseWriter, r *http.Request,
 			fields = append(fields, models.SegmentField(field))
 		}
 	}
-	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -67,19 +67,19 @@ func (s SegmentController) GetSegment(w http.ResponseWriter, r *http.Request, pr
 		return
 	}
 	// Check if the projectId has been set up
-	_, err := s.Services.ProjectSettingsService.GetProjectSettings(projectId)
+	_, err := s.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId))
 		return
 	}
 
-	segment, err := s.Services.SegmentService.GetSegment(projectId, segmentId)
+	segment, err := s.Services.SegmentService.GetSegment(r.Context(), projectId, segmentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
-	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -114,7 +114,7 @@ func (s SegmentController) CreateSegment(w http.ResponseWriter, r *http.Request,
 		return
 	}
 	// Check if the projectId has been set up
-	settings, err := s.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	settings, err := s.Services.ProjectSettingsService.GetDBRecord(r.Context(), models.ID(projectId))
 	if err != nil {
 		WriteErrorResponse(w, errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err))
 		return
@@ -124,12 +124,12 @@ func (s SegmentController) CreateSegment(w http.ResponseWriter, r *http.Request,
 		WriteErrorResponse(w, err)
 		return
 	}
-	segment, err := s.Services.SegmentService.CreateSegment(*settings, *createSegmentBody)
+	segment, err := s.Services.SegmentService.CreateSegment(r.Context(), *settings, *createSegmentBody)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
-	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -163,7 +163,7 @@ func (s SegmentController) UpdateSegment(w http.ResponseWriter, r *http.Request,
 		return
 	}
 	// Check if the projectId has been set up
-	settings, err := s.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	settings, err := s.Services.ProjectSettingsService.GetDBRecord(r.Context(), models.ID(projectId))
 	if err != nil {
 		WriteErrorResponse(w, errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err))
 		return
@@ -173,12 +173,12 @@ func (s SegmentController) UpdateSegment(w http.ResponseWriter, r *http.Request,
 		WriteErrorResponse(w, err)
 		return
 	}
-	segment, err := s.Services.SegmentService.UpdateSegment(*settings, segmentId, *updateSegmentBody)
+	segment, err := s.Services.SegmentService.UpdateSegment(r.Context(), *settings, segmentId, *updateSegmentBody)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
-	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -193,13 +193,13 @@ func (s SegmentController) DeleteSegment(w http.ResponseWriter, r *http.Request,
 		return
 	}
 	// Check if the projectId has been set up
-	_, err := s.Services.ProjectSettingsService.GetProjectSettings(projectId)
+	_, err := s.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId))
 		return
 	}
 
-	err = s.Services.SegmentService.DeleteSegment(projectId, segmentId)
+	err = s.Services.SegmentService.DeleteSegment(r.Context(), projectId, segmentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
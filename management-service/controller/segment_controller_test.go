@@ -56,47 +56,47 @@ func (s *SegmentControllerTestSuite) SetupSuite() {
 	daysOfWeek := []string{"1"}
 	settingsSvc := &mocks.ProjectSettingsService{}
 	settingsSvc.
-		On("GetDBRecord", models.ID(1)).
+		On("GetDBRecord", mock.Anything, models.ID(1)).
 		Return(nil, errors.Newf(errors.Unknown, "test find project settings error"))
 	settingsSvc.
-		On("GetDBRecord", models.ID(2)).
+		On("GetDBRecord", mock.Anything, models.ID(2)).
 		Return(&models.Settings{ProjectID: models.ID(2)}, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(1)).
+		On("GetProjectSettings", mock.Anything, int64(1)).
 		Return(nil, errors.Newf(errors.NotFound, "test get project settings error"))
 	settingsSvc.
-		On("GetProjectSettings", int64(2)).
+		On("GetProjectSettings", mock.Anything, int64(2)).
 		Return(&models.Settings{
 			Config: &models.ExperimentationConfig{},
 		}, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(4)).
+		On("GetProjectSettings", mock.Anything, int64(4)).
 		Return(nil, nil)
 
 	// Create mock segment service and set up with test responses
 	segmentSvc := &mocks.SegmentService{}
 	testSegment := &models.Segment{ProjectID: 2, Segment: models.ExperimentSegment{"days_of_week": daysOfWeek}}
 	segmentSvc.
-		On("GetSegment", int64(2), int64(20)).
+		On("GetSegment", mock.Anything, int64(2), int64(20)).
 		Return(nil, errors.Newf(errors.NotFound, "segment not found"))
 	segmentSvc.
-		On("GetSegment", int64(2), int64(2)).
+		On("GetSegment", mock.Anything, int64(2), int64(2)).
 		Return(testSegment, nil)
 
 	segmentSvc.
-		On("ListSegments", int64(2), mock.Anything).
+		On("ListSegments", mock.Anything, int64(2), mock.Anything).
 		Return([]*models.Segment{testSegment}, nil, nil)
 	segmentSvc.
-		On("ListSegments", int64(4), mock.Anything).
+		On("ListSegments", mock.Anything, int64(4), mock.Anything).
 		Return(nil, nil, fmt.Errorf("unexpected error"))
 	updatedBy := "test-user"
 	segmentSvc.
-		On("CreateSegment",
+		On("CreateSegment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			services.CreateSegmentRequestBody{Name: "test-segment", UpdatedBy: &updatedBy, Segment: models.ExperimentSegmentRaw(nil)}).
 		Return(nil, fmt.Errorf("segment creation failed"))
 	segmentSvc.
-		On("CreateSegment",
+		On("CreateSegment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			services.CreateSegmentRequestBody{
 				Name:      "test-segment-2",
@@ -105,7 +105,7 @@ func (s *SegmentControllerTestSuite) SetupSuite() {
 			}).
 		Return(testSegment, nil)
 	segmentSvc.
-		On("UpdateSegment",
+		On("UpdateSegment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			int64(1),
 			services.UpdateSegmentRequestBody{
@@ -114,7 +114,7 @@ func (s *SegmentControllerTestSuite) SetupSuite() {
 			}).
 		Return(nil, fmt.Errorf("segment update failed"))
 	segmentSvc.
-		On("UpdateSegment",
+		On("UpdateSegment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			int64(1),
 			services.UpdateSegmentRequestBody{
@@ -123,7 +123,7 @@ func (s *SegmentControllerTestSuite) SetupSuite() {
 			}).
 		Return(testSegment, nil)
 	segmentSvc.
-		On("DeleteSegment",
+		On("DeleteSegment", mock.Anything,
 			int64(2), int64(2)).
 		Return(nil)
 
@@ -132,7 +132,7 @@ func (s *SegmentControllerTestSuite) SetupSuite() {
 		On("ListGlobalSegmentersNames").
 		Return([]string{"days_of_week"})
 	segmenterSvc.
-		On("GetSegmenterConfigurations",
+		On("GetSegmenterConfigurations", mock.Anything,
 			[]string{"days_of_week"}).
 		Return(
 			[]*_segmenters.SegmenterConfiguration{
@@ -140,7 +140,7 @@ func (s *SegmentControllerTestSuite) SetupSuite() {
 			}, nil,
 		)
 	segmenterSvc.
-		On("GetSegmenterTypes", int64(2)).
+		On("GetSegmenterTypes", mock.Anything, int64(2)).
 		Return(
 			map[string]schema.SegmenterType{
 				"days_of_week": schema.SegmenterTypeInteger,
@@ -215,7 +215,7 @@ func (p *SegmentControllerTestSuite) TestGetSegment() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			p.ctrl.GetSegment(w, nil, data.projectID, data.segmentID)
+			p.ctrl.GetSegment(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.segmentID)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
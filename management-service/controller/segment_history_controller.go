@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/caraml-dev/xp/common/api/schema"
@@ -27,19 +28,19 @@ func (s SegmentHistoryController) ListSegmentHistory(
 	segmentId int64,
 	params api.ListSegmentHistoryParams,
 ) {
-	err := s.checkProjectAndSegment(projectId, segmentId)
+	err := s.checkProjectAndSegment(r.Context(), projectId, segmentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
 	// List historical versions
-	versions, paging, err := s.Services.SegmentHistoryService.ListSegmentHistory(segmentId, s.toListSegmentHistoryParams(params))
+	versions, paging, err := s.Services.SegmentHistoryService.ListSegmentHistory(r.Context(), segmentId, s.toListSegmentHistoryParams(params))
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
-	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -58,19 +59,19 @@ func (s SegmentHistoryController) GetSegmentHistory(
 	segmentId int64,
 	version int64,
 ) {
-	err := s.checkProjectAndSegment(projectId, segmentId)
+	err := s.checkProjectAndSegment(r.Context(), projectId, segmentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
 	// Get history record
-	segment, err := s.Services.SegmentHistoryService.GetSegmentHistory(segmentId, version)
+	segment, err := s.Services.SegmentHistoryService.GetSegmentHistory(r.Context(), segmentId, version)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
-	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := s.Services.SegmenterService.GetSegmenterTypes(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -87,18 +88,18 @@ func (s SegmentHistoryController) toListSegmentHistoryParams(params api.ListSegm
 	}
 }
 
-func (s SegmentHistoryController) checkProjectAndSegment(projectId int64, segmentId int64) error {
+func (s SegmentHistoryController) checkProjectAndSegment(ctx context.Context, projectId int64, segmentId int64) error {
 	// Check if the projectId is valid
 	if _, err := s.Services.MLPService.GetProject(projectId); err != nil {
 		return err
 	}
 	// Check if the projectId has been set up
-	_, err := s.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	_, err := s.Services.ProjectSettingsService.GetDBRecord(ctx, models.ID(projectId))
 	if err != nil {
 		return errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err)
 	}
 	// Check that the segment exists
-	_, err = s.Services.SegmentService.GetDBRecord(models.ID(projectId), models.ID(segmentId))
+	_, err = s.Services.SegmentService.GetDBRecord(ctx, models.ID(projectId), models.ID(segmentId))
 	if err != nil {
 		return errors.Newf(errors.NotFound, "Segment with id %d cannot be retrieved: %v", segmentId, err)
 	}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/caraml-dev/xp/common/api/schema"
@@ -42,19 +43,19 @@ func (s *SegmentHistoryControllerTestSuite) SetupSuite() {
 	// Create mock project settings service and set up with test responses
 	settingsSvc := &mocks.ProjectSettingsService{}
 	settingsSvc.
-		On("GetDBRecord", models.ID(2)).
+		On("GetDBRecord", mock.Anything, models.ID(2)).
 		Return(nil, errors.Newf(errors.Unknown, "test get project settings error"))
 	settingsSvc.
-		On("GetDBRecord", models.ID(3)).
+		On("GetDBRecord", mock.Anything, models.ID(3)).
 		Return(nil, nil)
 
 	// Create mock segment service and set up with test responses
 	segmentSvc := &mocks.SegmentService{}
 	segmentSvc.
-		On("GetDBRecord", models.ID(3), models.ID(1)).
+		On("GetDBRecord", mock.Anything, models.ID(3), models.ID(1)).
 		Return(nil, errors.Newf(errors.NotFound, "segment not found"))
 	segmentSvc.
-		On("GetDBRecord", models.ID(3), models.ID(10)).
+		On("GetDBRecord", mock.Anything, models.ID(3), models.ID(10)).
 		Return(nil, nil)
 
 	// Set up mock segment history service
@@ -79,20 +80,20 @@ func (s *SegmentHistoryControllerTestSuite) SetupSuite() {
 	}
 	segmentHistSvc := &mocks.SegmentHistoryService{}
 	segmentHistSvc.
-		On("GetSegmentHistory", int64(10), int64(2)).
+		On("GetSegmentHistory", mock.Anything, int64(10), int64(2)).
 		Return(nil, errors.Newf(errors.NotFound, "segment history not found"))
 	segmentHistSvc.
-		On("GetSegmentHistory", int64(10), int64(1)).
+		On("GetSegmentHistory", mock.Anything, int64(10), int64(1)).
 		Return(testSegmentHistory, nil)
 	segmentHistSvc.
-		On("ListSegmentHistory", int64(10), services.ListSegmentHistoryParams{
+		On("ListSegmentHistory", mock.Anything, int64(10), services.ListSegmentHistoryParams{
 			PaginationOptions: pagination.PaginationOptions{},
 		}).
 		Return([]*models.SegmentHistory{testSegmentHistory}, &pagination.Paging{Page: 1, Total: 1, Pages: 1}, nil)
 
 	segmenterSvc := &mocks.SegmenterService{}
 	segmenterSvc.
-		On("GetSegmenterTypes", int64(3)).
+		On("GetSegmenterTypes", mock.Anything, int64(3)).
 		Return(
 			map[string]schema.SegmenterType{
 				"hours_of_day": schema.SegmenterTypeInteger,
@@ -188,7 +189,7 @@ func (s *SegmentHistoryControllerTestSuite) TestGetSegmentHistory() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			s.ctrl.GetSegmentHistory(w, nil, data.projectID, data.segmentID, data.version)
+			s.ctrl.GetSegmentHistory(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.segmentID, data.version)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
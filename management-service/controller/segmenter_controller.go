@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -26,7 +27,7 @@ func (s SegmenterController) ListSegmenters(w http.ResponseWriter, r *http.Reque
 	// the user if the project corresponding to the project id is not found in the db, we are returning a list of global
 	// segmenters. This temporary behaviour is implemented in order to allow the create settings UI page to run
 	// correctly after the removal of the '/segmenters' endpoint
-	if err := s.validateProjectId(projectId); err != nil {
+	if err := s.validateProjectId(r.Context(), projectId); err != nil {
 		segmenters, err := s.Services.SegmenterService.ListGlobalSegmenters()
 		if err != nil {
 			WriteErrorResponse(w, err)
@@ -60,7 +61,7 @@ func (s SegmenterController) ListSegmenters(w http.ResponseWriter, r *http.Reque
 		Status: status,
 		Search: params.Search,
 	}
-	allSegmenters, err := s.Services.SegmenterService.ListSegmenters(projectId, queryParams)
+	allSegmenters, err := s.Services.SegmenterService.ListSegmenters(r.Context(), projectId, queryParams)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -71,12 +72,12 @@ func (s SegmenterController) ListSegmenters(w http.ResponseWriter, r *http.Reque
 
 func (s SegmenterController) GetSegmenter(w http.ResponseWriter, r *http.Request, projectId int64, name string) {
 	// Perform validation checks on the projectId given
-	if err := s.validateProjectId(projectId); err != nil {
+	if err := s.validateProjectId(r.Context(), projectId); err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 	// Check all segmenters if a segmenter with a matching name exists
-	segmenter, err := s.Services.SegmenterService.GetSegmenter(projectId, name)
+	segmenter, err := s.Services.SegmenterService.GetSegmenter(r.Context(), projectId, name)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -93,13 +94,14 @@ func (s SegmenterController) CreateSegmenter(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	// Perform validation checks on the projectId given
-	if err := s.validateProjectId(projectId); err != nil {
+	if err := s.validateProjectId(r.Context(), projectId); err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
 	// Create custom segmenter
 	customSegmenter, err := s.Services.SegmenterService.CreateCustomSegmenter(
+		r.Context(),
 		projectId,
 		toCreateCustomSegmenterBody(customSegmenterData),
 	)
@@ -127,6 +129,7 @@ func (s SegmenterController) UpdateSegmenter(w http.ResponseWriter, r *http.Requ
 
 	// Update custom segmenter
 	customSegmenter, err := s.Services.SegmenterService.UpdateCustomSegmenter(
+		r.Context(),
 		projectId,
 		name,
 		toUpdateCustomSegmenterBody(customSegmenterData),
@@ -141,12 +144,12 @@ func (s SegmenterController) UpdateSegmenter(w http.ResponseWriter, r *http.Requ
 
 func (s SegmenterController) DeleteSegmenter(w http.ResponseWriter, r *http.Request, projectId int64, name string) {
 	// Perform validation checks on the projectId given
-	if err := s.validateProjectId(projectId); err != nil {
+	if err := s.validateProjectId(r.Context(), projectId); err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 	// Delete selected custom segmenter
-	err := s.Services.SegmenterService.DeleteCustomSegmenter(projectId, name)
+	err := s.Services.SegmenterService.DeleteCustomSegmenter(r.Context(), projectId, name)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -157,14 +160,21 @@ func (s SegmenterController) DeleteSegmenter(w http.ResponseWriter, r *http.Requ
 }
 
 func toCreateCustomSegmenterBody(body api.CreateSegmenterRequestBody) services.CreateCustomSegmenterRequestBody {
+	var matchMode string
+	if body.MatchMode != nil {
+		matchMode = strings.ToUpper(string(*body.MatchMode))
+	}
 	return services.CreateCustomSegmenterRequestBody{
-		Name:        body.Name,
-		Type:        strings.ToUpper(string(body.Type)),
-		Options:     parseApiOptions(body.Options),
-		MultiValued: body.MultiValued,
-		Constraints: parseApiConstraints(body.Constraints),
-		Required:    body.Required,
-		Description: body.Description,
+		Name:          body.Name,
+		Type:          strings.ToUpper(string(body.Type)),
+		MatchMode:     matchMode,
+		Expression:    body.Expression,
+		RequestFields: (*models.RequestFields)(body.RequestFields),
+		Options:       parseApiOptions(body.Options),
+		MultiValued:   body.MultiValued,
+		Constraints:   parseApiConstraints(body.Constraints),
+		Required:      body.Required,
+		Description:   body.Description,
 	}
 }
 
@@ -230,13 +240,13 @@ func parseApiConstraintSegmenterValues(
 	return segmenterValues
 }
 
-func (s SegmenterController) validateProjectId(projectId int64) error {
+func (s SegmenterController) validateProjectId(ctx context.Context, projectId int64) error {
 	// Check if the projectId is valid
 	if _, err := s.Services.MLPService.GetProject(projectId); err != nil {
 		return err
 	}
 	// Check if the projectId has been set up
-	_, err := s.Services.ProjectSettingsService.GetProjectSettings(projectId)
+	_, err := s.Services.ProjectSettingsService.GetProjectSettings(ctx, projectId)
 	if err != nil {
 		return errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId)
 	}
@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"gorm.io/gorm"
 
@@ -464,6 +465,7 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 	segmenterSvc.
 		On(
 			"ListSegmenters",
+			mock.Anything,
 			int64(3),
 			services.ListSegmentersParams{
 				Scope:  nil,
@@ -473,6 +475,7 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 	segmenterSvc.
 		On(
 			"ListSegmenters",
+			mock.Anything,
 			int64(4),
 			services.ListSegmentersParams{
 				Scope:  nil,
@@ -482,6 +485,7 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 	segmenterSvc.
 		On(
 			"ListSegmenters",
+			mock.Anything,
 			int64(5),
 			services.ListSegmentersParams{
 				Scope:  nil,
@@ -491,6 +495,7 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 	segmenterSvc.
 		On(
 			"ListSegmenters",
+			mock.Anything,
 			int64(6),
 			services.ListSegmentersParams{
 				Scope:  nil,
@@ -506,6 +511,7 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 	segmenterSvc.
 		On(
 			"ListSegmenters",
+			mock.Anything,
 			int64(7),
 			services.ListSegmentersParams{
 				Scope:  &getSegmenterScopeGlobal,
@@ -515,6 +521,7 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 	segmenterSvc.
 		On(
 			"ListSegmenters",
+			mock.Anything,
 			int64(7),
 			services.ListSegmentersParams{
 				Scope:  &getSegmenterScopeProject,
@@ -524,6 +531,7 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 	segmenterSvc.
 		On(
 			"ListSegmenters",
+			mock.Anything,
 			int64(7),
 			services.ListSegmentersParams{
 				Scope:  nil,
@@ -531,33 +539,34 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 			}).
 		Return([]*schema.Segmenter{}, nil)
 	segmenterSvc.
-		On("ListSegmenters", int64(9), services.ListSegmentersParams{}).
+		On("ListSegmenters", mock.Anything, int64(9), services.ListSegmentersParams{}).
 		Return(nil, gorm.ErrRecordNotFound)
 	segmenterSvc.
-		On("GetSegmenter", int64(3), "nonexistent-segmenter").
+		On("GetSegmenter", mock.Anything, int64(3), "nonexistent-segmenter").
 		Return(nil, fmt.Errorf("unknown segmenter: nonexistent-segmenter"))
 	segmenterSvc.
-		On("GetSegmenter", int64(3), "test-global-segmenter").
+		On("GetSegmenter", mock.Anything, int64(3), "test-global-segmenter").
 		Return(&activeGlobalSegmenterOpenApi, nil)
 	segmenterSvc.
-		On("GetSegmenter", int64(4), "test-custom-segmenter").
+		On("GetSegmenter", mock.Anything, int64(4), "test-custom-segmenter").
 		Return(&inactiveCustomSegmenterOpenApi, nil)
 	segmenterSvc.
-		On("GetSegmenter", int64(4), "test-new-custom-segmenter").
+		On("GetSegmenter", mock.Anything, int64(4), "test-new-custom-segmenter").
 		Return(nil, fmt.Errorf("unknown segmenter: test-new-custom-segmenter"))
 	segmenterSvc.
-		On("GetCustomSegmenter", int64(4), "test-custom-segmenter").
+		On("GetCustomSegmenter", mock.Anything, int64(4), "test-custom-segmenter").
 		Return(&baseCustomSegmenter, nil)
 	segmenterSvc.
-		On("GetCustomSegmenter", int64(4), "test-missing-custom-segmenter").
+		On("GetCustomSegmenter", mock.Anything, int64(4), "test-missing-custom-segmenter").
 		Return(nil, fmt.Errorf("unknown segmenter: test-missing-custom-segmenter"))
 	segmenterSvc.
-		On("GetCustomSegmenter", int64(5), "test-custom-segmenter").
+		On("GetCustomSegmenter", mock.Anything, int64(5), "test-custom-segmenter").
 		Return(&baseCustomSegmenter, nil)
 
 	segmenterSvc.
 		On(
 			"CreateCustomSegmenter",
+			mock.Anything,
 			int64(4),
 			services.CreateCustomSegmenterRequestBody{
 				Name: "already-existent-custom-segmenter",
@@ -572,6 +581,7 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 	segmenterSvc.
 		On(
 			"CreateCustomSegmenter",
+			mock.Anything,
 			int64(4),
 			services.CreateCustomSegmenterRequestBody{
 				Name: "test-new-custom-segmenter",
@@ -604,13 +614,13 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 		Return(&newCustomSegmenter, nil)
 
 	segmenterSvc.
-		On("UpdateCustomSegmenter",
+		On("UpdateCustomSegmenter", mock.Anything,
 			int64(4),
 			"nonexistent-segmenter",
 			services.UpdateCustomSegmenterRequestBody{}).
 		Return(nil, fmt.Errorf("unknown segmenter: nonexistent-segmenter"))
 	segmenterSvc.
-		On("UpdateCustomSegmenter",
+		On("UpdateCustomSegmenter", mock.Anything,
 			int64(4),
 			"test-custom-segmenter",
 			services.UpdateCustomSegmenterRequestBody{
@@ -641,53 +651,53 @@ func (s *SegmenterControllerTestSuite) SetupSuite() {
 			}).
 		Return(&updatedCustomSegmenter, nil)
 	segmenterSvc.
-		On("DeleteCustomSegmenter", int64(4), "nonexistent-segmenter").
+		On("DeleteCustomSegmenter", mock.Anything, int64(4), "nonexistent-segmenter").
 		Return(fmt.Errorf("unknown segmenter: nonexistent-segmenter"))
 	segmenterSvc.
-		On("DeleteCustomSegmenter", int64(4), "test-custom-segmenter").
+		On("DeleteCustomSegmenter", mock.Anything, int64(4), "test-custom-segmenter").
 		Return(nil)
 
 	settingsSvc := &mocks.ProjectSettingsService{}
 	settingsSvc.
-		On("GetDBRecord", models.ID(3)).
+		On("GetDBRecord", mock.Anything, models.ID(3)).
 		Return(&projectSettings3, nil)
 	settingsSvc.
-		On("GetDBRecord", models.ID(4)).
+		On("GetDBRecord", mock.Anything, models.ID(4)).
 		Return(&projectSettings4, nil)
 	settingsSvc.
-		On("GetDBRecord", models.ID(5)).
+		On("GetDBRecord", mock.Anything, models.ID(5)).
 		Return(&projectSettings5, nil)
 	settingsSvc.
-		On("GetDBRecord", models.ID(6)).
+		On("GetDBRecord", mock.Anything, models.ID(6)).
 		Return(&projectSettings6, nil)
 	settingsSvc.
-		On("GetDBRecord", models.ID(7)).
+		On("GetDBRecord", mock.Anything, models.ID(7)).
 		Return(&projectSettings7, nil)
 	settingsSvc.
-		On("GetDBRecord", models.ID(8)).
+		On("GetDBRecord", mock.Anything, models.ID(8)).
 		Return(nil, errors.Newf(errors.Unknown, "test find project settings error"))
 
 	// return values are set as (nil, nil) for convenience since the non-error return values are not used explicitly
 	settingsSvc.
-		On("GetProjectSettings", int64(3)).
+		On("GetProjectSettings", mock.Anything, int64(3)).
 		Return(nil, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(4)).
+		On("GetProjectSettings", mock.Anything, int64(4)).
 		Return(nil, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(5)).
+		On("GetProjectSettings", mock.Anything, int64(5)).
 		Return(nil, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(6)).
+		On("GetProjectSettings", mock.Anything, int64(6)).
 		Return(nil, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(7)).
+		On("GetProjectSettings", mock.Anything, int64(7)).
 		Return(nil, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(8)).
+		On("GetProjectSettings", mock.Anything, int64(8)).
 		Return(nil, errors.Newf(errors.NotFound, "test get project settings error"))
 	settingsSvc.
-		On("GetProjectSettings", int64(9)).
+		On("GetProjectSettings", mock.Anything, int64(9)).
 		Return(nil, nil)
 
 	mlpSvc := &mocks.MLPService{}
@@ -827,7 +837,7 @@ func (s *SegmenterControllerTestSuite) TestListSegmenters() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			s.ctrl.ListSegmenters(w, nil, data.projectID, data.params)
+			s.ctrl.ListSegmenters(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.params)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
@@ -880,7 +890,7 @@ func (s *SegmenterControllerTestSuite) TestGetSegmenter() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			s.ctrl.GetSegmenter(w, nil, data.projectID, data.segmenterName)
+			s.ctrl.GetSegmenter(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.segmenterName)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
@@ -1121,7 +1131,7 @@ func (s *SegmenterControllerTestSuite) TestDeleteSegmenter() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			s.ctrl.DeleteSegmenter(w, nil, data.projectID, data.segmenterName)
+			s.ctrl.DeleteSegmenter(w, httptest.NewRequest(http.MethodDelete, "/", nil), data.projectID, data.segmenterName)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
@@ -29,13 +29,13 @@ func (t TreatmentController) GetTreatment(w http.ResponseWriter, r *http.Request
 		return
 	}
 	// Check if the projectId has been set up
-	_, err := t.Services.ProjectSettingsService.GetProjectSettings(projectId)
+	_, err := t.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId))
 		return
 	}
 
-	treatment, err := t.Services.TreatmentService.GetTreatment(projectId, treatmentId)
+	treatment, err := t.Services.TreatmentService.GetTreatment(r.Context(), projectId, treatmentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -51,13 +51,13 @@ func (t TreatmentController) ListTreatments(w http.ResponseWriter, r *http.Reque
 		return
 	}
 	// Check if the projectId has been set up
-	if _, err := t.Services.ProjectSettingsService.GetProjectSettings(projectId); err != nil {
+	if _, err := t.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId); err != nil {
 		WriteErrorResponse(w, errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId))
 		return
 	}
 
 	// List treatments
-	treatments, paging, err := t.Services.TreatmentService.ListTreatments(projectId, t.toListTreatmentParams(params))
+	treatments, paging, err := t.Services.TreatmentService.ListTreatments(r.Context(), projectId, t.toListTreatmentParams(params))
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -102,12 +102,12 @@ func (t TreatmentController) CreateTreatment(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	// Check if the projectId has been set up
-	settings, err := t.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	settings, err := t.Services.ProjectSettingsService.GetDBRecord(r.Context(), models.ID(projectId))
 	if err != nil {
 		WriteErrorResponse(w, errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err))
 		return
 	}
-	treatment, err := t.Services.TreatmentService.CreateTreatment(*settings, t.toCreateTreatmentBody(treatmentData))
+	treatment, err := t.Services.TreatmentService.CreateTreatment(r.Context(), *settings, t.toCreateTreatmentBody(treatmentData))
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -141,12 +141,12 @@ func (t TreatmentController) UpdateTreatment(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	// Check if the projectId has been set up
-	settings, err := t.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	settings, err := t.Services.ProjectSettingsService.GetDBRecord(r.Context(), models.ID(projectId))
 	if err != nil {
 		WriteErrorResponse(w, errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err))
 		return
 	}
-	treatment, err := t.Services.TreatmentService.UpdateTreatment(*settings, treatmentId, t.toUpdateTreatmentBody(treatmentData))
+	treatment, err := t.Services.TreatmentService.UpdateTreatment(r.Context(), *settings, treatmentId, t.toUpdateTreatmentBody(treatmentData))
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -161,13 +161,13 @@ func (t TreatmentController) DeleteTreatment(w http.ResponseWriter, r *http.Requ
 		return
 	}
 	// Check if the projectId has been set up
-	_, err := t.Services.ProjectSettingsService.GetProjectSettings(projectId)
+	_, err := t.Services.ProjectSettingsService.GetProjectSettings(r.Context(), projectId)
 	if err != nil {
 		WriteErrorResponse(w, errors.Wrapf(err, "Settings for project_id %d cannot be retrieved", projectId))
 		return
 	}
 
-	err = t.Services.TreatmentService.DeleteTreatment(projectId, treatmentId)
+	err = t.Services.TreatmentService.DeleteTreatment(r.Context(), projectId, treatmentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
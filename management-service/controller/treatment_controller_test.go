@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/caraml-dev/xp/management-service/api"
@@ -50,16 +51,16 @@ func (s *TreatmentControllerTestSuite) SetupSuite() {
 	// Create mock project settings service and set up with test responses
 	settingsSvc := &mocks.ProjectSettingsService{}
 	settingsSvc.
-		On("GetDBRecord", models.ID(1)).
+		On("GetDBRecord", mock.Anything, models.ID(1)).
 		Return(nil, errors.Newf(errors.Unknown, "test find project settings error"))
 	settingsSvc.
-		On("GetDBRecord", models.ID(2)).
+		On("GetDBRecord", mock.Anything, models.ID(2)).
 		Return(&models.Settings{ProjectID: models.ID(2)}, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(1)).
+		On("GetProjectSettings", mock.Anything, int64(1)).
 		Return(nil, errors.Newf(errors.NotFound, "test get project settings error"))
 	settingsSvc.
-		On("GetProjectSettings", int64(2)).
+		On("GetProjectSettings", mock.Anything, int64(2)).
 		Return(&models.Settings{
 			Config: &models.ExperimentationConfig{Segmenters: models.ProjectSegmenters{
 				Names:     []string{""},
@@ -67,50 +68,50 @@ func (s *TreatmentControllerTestSuite) SetupSuite() {
 			}},
 		}, nil)
 	settingsSvc.
-		On("GetProjectSettings", int64(4)).
+		On("GetProjectSettings", mock.Anything, int64(4)).
 		Return(nil, nil)
 
 	// Create mock treatment service and set up with test responses
 	treatmentSvc := &mocks.TreatmentService{}
 	testTreatment := &models.Treatment{ProjectID: 2, Configuration: map[string]interface{}{"team": "business"}}
 	treatmentSvc.
-		On("GetTreatment", int64(2), int64(20)).
+		On("GetTreatment", mock.Anything, int64(2), int64(20)).
 		Return(nil, errors.Newf(errors.NotFound, "treatment not found"))
 	treatmentSvc.
-		On("GetTreatment", int64(2), int64(2)).
+		On("GetTreatment", mock.Anything, int64(2), int64(2)).
 		Return(testTreatment, nil)
 
 	treatmentSvc.
-		On("ListTreatments", int64(2), services.ListTreatmentsParams{}).
+		On("ListTreatments", mock.Anything, int64(2), services.ListTreatmentsParams{}).
 		Return([]*models.Treatment{testTreatment}, nil, nil)
 	treatmentSvc.
-		On("ListTreatments", int64(4), services.ListTreatmentsParams{}).
+		On("ListTreatments", mock.Anything, int64(4), services.ListTreatmentsParams{}).
 		Return(nil, nil, fmt.Errorf("unexpected error"))
 	updatedBy := "test-user"
 	treatmentSvc.
-		On("CreateTreatment",
+		On("CreateTreatment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			services.CreateTreatmentRequestBody{Name: "test-treatment", UpdatedBy: &updatedBy}).
 		Return(nil, fmt.Errorf("treatment creation failed"))
 	treatmentSvc.
-		On("CreateTreatment",
+		On("CreateTreatment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			services.CreateTreatmentRequestBody{Name: "test-treatment-2", Config: map[string]interface{}{"team": "business"}, UpdatedBy: &updatedBy}).
 		Return(testTreatment, nil)
 	treatmentSvc.
-		On("UpdateTreatment",
+		On("UpdateTreatment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			int64(1),
 			services.UpdateTreatmentRequestBody{Config: nil, UpdatedBy: &updatedBy}).
 		Return(nil, fmt.Errorf("treatment update failed"))
 	treatmentSvc.
-		On("UpdateTreatment",
+		On("UpdateTreatment", mock.Anything,
 			models.Settings{ProjectID: models.ID(2)},
 			int64(1),
 			services.UpdateTreatmentRequestBody{Config: map[string]interface{}{"team": "business"}, UpdatedBy: &updatedBy}).
 		Return(testTreatment, nil)
 	treatmentSvc.
-		On("DeleteTreatment",
+		On("DeleteTreatment", mock.Anything,
 			int64(2), int64(2)).
 		Return(nil)
 
@@ -180,7 +181,7 @@ func (p *TreatmentControllerTestSuite) TestGetTreatment() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			p.ctrl.GetTreatment(w, nil, data.projectID, data.treatmentID)
+			p.ctrl.GetTreatment(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.treatmentID)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/caraml-dev/xp/common/api/schema"
@@ -27,14 +28,14 @@ func (t TreatmentHistoryController) ListTreatmentHistory(
 	treatmentId int64,
 	params api.ListTreatmentHistoryParams,
 ) {
-	err := t.checkProjectAndTreatment(projectId, treatmentId)
+	err := t.checkProjectAndTreatment(r.Context(), projectId, treatmentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
 	// List historical versions
-	versions, paging, err := t.Services.TreatmentHistoryService.ListTreatmentHistory(treatmentId, t.toListTreatmentHistoryParams(params))
+	versions, paging, err := t.Services.TreatmentHistoryService.ListTreatmentHistory(r.Context(), treatmentId, t.toListTreatmentHistoryParams(params))
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -53,14 +54,14 @@ func (t TreatmentHistoryController) GetTreatmentHistory(
 	treatmentId int64,
 	version int64,
 ) {
-	err := t.checkProjectAndTreatment(projectId, treatmentId)
+	err := t.checkProjectAndTreatment(r.Context(), projectId, treatmentId)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
 	}
 
 	// Get history record
-	treatment, err := t.Services.TreatmentHistoryService.GetTreatmentHistory(treatmentId, version)
+	treatment, err := t.Services.TreatmentHistoryService.GetTreatmentHistory(r.Context(), treatmentId, version)
 	if err != nil {
 		WriteErrorResponse(w, err)
 		return
@@ -78,18 +79,18 @@ func (t TreatmentHistoryController) toListTreatmentHistoryParams(params api.List
 	}
 }
 
-func (t TreatmentHistoryController) checkProjectAndTreatment(projectId int64, treatmentId int64) error {
+func (t TreatmentHistoryController) checkProjectAndTreatment(ctx context.Context, projectId int64, treatmentId int64) error {
 	// Check if the projectId is valid
 	if _, err := t.Services.MLPService.GetProject(projectId); err != nil {
 		return err
 	}
 	// Check if the projectId has been set up
-	_, err := t.Services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+	_, err := t.Services.ProjectSettingsService.GetDBRecord(ctx, models.ID(projectId))
 	if err != nil {
 		return errors.Newf(errors.NotFound, "Settings for project_id %d cannot be retrieved: %v", projectId, err)
 	}
 	// Check that the treatment exists
-	_, err = t.Services.TreatmentService.GetDBRecord(models.ID(projectId), models.ID(treatmentId))
+	_, err = t.Services.TreatmentService.GetDBRecord(ctx, models.ID(projectId), models.ID(treatmentId))
 	if err != nil {
 		return errors.Newf(errors.NotFound, "Treatment with id %d cannot be retrieved: %v", treatmentId, err)
 	}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/caraml-dev/xp/management-service/api"
@@ -41,19 +42,19 @@ func (s *TreatmentHistoryControllerTestSuite) SetupSuite() {
 	// Create mock project settings service and set up with test responses
 	settingsSvc := &mocks.ProjectSettingsService{}
 	settingsSvc.
-		On("GetDBRecord", models.ID(2)).
+		On("GetDBRecord", mock.Anything, models.ID(2)).
 		Return(nil, errors.Newf(errors.Unknown, "test get project settings error"))
 	settingsSvc.
-		On("GetDBRecord", models.ID(3)).
+		On("GetDBRecord", mock.Anything, models.ID(3)).
 		Return(nil, nil)
 
 	// Create mock treatment service and set up with test responses
 	treatmentSvc := &mocks.TreatmentService{}
 	treatmentSvc.
-		On("GetDBRecord", models.ID(3), models.ID(1)).
+		On("GetDBRecord", mock.Anything, models.ID(3), models.ID(1)).
 		Return(nil, errors.Newf(errors.NotFound, "treatment not found"))
 	treatmentSvc.
-		On("GetDBRecord", models.ID(3), models.ID(10)).
+		On("GetDBRecord", mock.Anything, models.ID(3), models.ID(10)).
 		Return(nil, nil)
 
 	// Set up mock treatment history service
@@ -75,13 +76,13 @@ func (s *TreatmentHistoryControllerTestSuite) SetupSuite() {
 	}
 	treatmentHistSvc := &mocks.TreatmentHistoryService{}
 	treatmentHistSvc.
-		On("GetTreatmentHistory", int64(10), int64(2)).
+		On("GetTreatmentHistory", mock.Anything, int64(10), int64(2)).
 		Return(nil, errors.Newf(errors.NotFound, "treatment history not found"))
 	treatmentHistSvc.
-		On("GetTreatmentHistory", int64(10), int64(1)).
+		On("GetTreatmentHistory", mock.Anything, int64(10), int64(1)).
 		Return(testTreatmentHistory, nil)
 	treatmentHistSvc.
-		On("ListTreatmentHistory", int64(10), services.ListTreatmentHistoryParams{
+		On("ListTreatmentHistory", mock.Anything, int64(10), services.ListTreatmentHistoryParams{
 			PaginationOptions: pagination.PaginationOptions{},
 		}).
 		Return([]*models.TreatmentHistory{testTreatmentHistory}, &pagination.Paging{Page: 1, Total: 1, Pages: 1}, nil)
@@ -172,7 +173,7 @@ func (s *TreatmentHistoryControllerTestSuite) TestGetTreatmentHistory() {
 		t.Run(data.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			// Test error response
-			s.ctrl.GetTreatmentHistory(w, nil, data.projectID, data.treatmentID, data.version)
+			s.ctrl.GetTreatmentHistory(w, httptest.NewRequest(http.MethodGet, "/", nil), data.projectID, data.treatmentID, data.version)
 			resp := w.Result()
 			if resp != nil && resp.Body != nil {
 				defer resp.Body.Close()
@@ -11,6 +11,8 @@ type Wrapper struct {
 	*TreatmentHistoryController
 	*ValidationController
 	*ConfigurationController
+	*OutboxController
+	*AnalysisController
 }
 
 func NewWrapper(
@@ -24,6 +26,8 @@ func NewWrapper(
 	treatmentHistory *TreatmentHistoryController,
 	validation *ValidationController,
 	configuration *ConfigurationController,
+	outbox *OutboxController,
+	analysis *AnalysisController,
 ) Wrapper {
 	return Wrapper{
 		ProjectSettingsController:   settings,
@@ -36,5 +40,7 @@ func NewWrapper(
 		TreatmentHistoryController:  treatmentHistory,
 		ValidationController:        validation,
 		ConfigurationController:     configuration,
+		OutboxController:            outbox,
+		AnalysisController:          analysis,
 	}
 }
@@ -34,6 +34,9 @@ func Open(cfg *config.DatabaseConfig) (*gorm.DB, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := db.Use(&tracingPlugin{}); err != nil {
+		return nil, err
+	}
 
 	// Get the underlying SQL DB and apply connection properties
 	sqlDB, err := db.DB()
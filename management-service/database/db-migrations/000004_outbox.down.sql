@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS outbox_messages;
+DROP TYPE IF EXISTS outbox_message_status;
+DROP TYPE IF EXISTS outbox_message_type;
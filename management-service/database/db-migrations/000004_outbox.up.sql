@@ -0,0 +1,25 @@
+-- Outbox Table
+CREATE TYPE outbox_message_type as ENUM ('experiment', 'project_settings', 'project_segmenter');
+CREATE TYPE outbox_message_status as ENUM ('pending', 'sent', 'failed');
+
+CREATE TABLE IF NOT EXISTS outbox_messages
+(
+   id                bigserial               PRIMARY KEY,
+   project_id        integer                 NOT NULL,
+
+   message_type      outbox_message_type     NOT NULL,
+   update_type       varchar(16)             NOT NULL,
+   payload           bytea                   NOT NULL,
+
+   status            outbox_message_status   NOT NULL default 'pending',
+   attempts          integer                 NOT NULL default 0,
+   last_error        text,
+   next_attempt_at   timestamp               WITH TIME ZONE NOT NULL default current_timestamp,
+   sent_at           timestamp               WITH TIME ZONE,
+
+   created_at        timestamp               NOT NULL default current_timestamp,
+   updated_at        timestamp               NOT NULL default current_timestamp
+);
+
+-- Partial index used by the dispatcher to pick up messages that are due for publishing
+CREATE INDEX outbox_messages_pending ON outbox_messages (next_attempt_at, id) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS experiment_results;
+DROP TABLE IF EXISTS metric_observations;
+DROP TABLE IF EXISTS experiment_assignments;
@@ -0,0 +1,43 @@
+-- Treatments assigned to the units of an experiment version, ingested from the assignment logs
+CREATE TABLE IF NOT EXISTS experiment_assignments
+(
+   experiment_id        integer        NOT NULL references experiments (id) ON DELETE CASCADE,
+   experiment_version   integer        NOT NULL,
+   unit_id              varchar(255)   NOT NULL,
+   treatment            varchar(64)    NOT NULL,
+
+   created_at           timestamp      NOT NULL default current_timestamp,
+   updated_at           timestamp      NOT NULL default current_timestamp,
+   PRIMARY KEY (experiment_id, experiment_version, unit_id)
+);
+
+-- Metric values observed for the units of an experiment
+CREATE TABLE IF NOT EXISTS metric_observations
+(
+   experiment_id   integer            NOT NULL references experiments (id) ON DELETE CASCADE,
+   metric          varchar(64)        NOT NULL,
+   unit_id         varchar(255)       NOT NULL,
+   value           double precision   NOT NULL,
+   covariate       double precision,
+
+   created_at      timestamp          NOT NULL default current_timestamp,
+   updated_at      timestamp          NOT NULL default current_timestamp,
+   PRIMARY KEY (experiment_id, metric, unit_id)
+);
+
+-- Per-treatment statistics of a metric, computed for an experiment version
+CREATE TABLE IF NOT EXISTS experiment_results
+(
+   id                   bigserial          PRIMARY KEY,
+   experiment_id        integer            NOT NULL references experiments (id) ON DELETE CASCADE,
+   experiment_version   integer            NOT NULL,
+   metric               varchar(64)        NOT NULL,
+   control_treatment    varchar(64)        NOT NULL,
+   cuped                boolean            NOT NULL default false,
+   confidence_level     double precision   NOT NULL,
+   treatments           jsonb              NOT NULL,
+
+   created_at           timestamp          NOT NULL default current_timestamp,
+   updated_at           timestamp          NOT NULL default current_timestamp,
+   UNIQUE (experiment_id, experiment_version, metric)
+);
@@ -0,0 +1,15 @@
+-- Sample ratio mismatch checks of the assignments of an experiment version against the configured traffic
+CREATE TABLE IF NOT EXISTS experiment_srm_checks
+(
+   id                   bigserial          PRIMARY KEY,
+   experiment_id        integer            NOT NULL references experiments (id) ON DELETE CASCADE,
+   experiment_version   integer            NOT NULL,
+   treatments           jsonb              NOT NULL,
+   chi_squared          double precision   NOT NULL,
+   p_value              double precision   NOT NULL,
+   mismatch             boolean            NOT NULL default false,
+
+   created_at           timestamp          NOT NULL default current_timestamp,
+   updated_at           timestamp          NOT NULL default current_timestamp,
+   UNIQUE (experiment_id, experiment_version)
+);
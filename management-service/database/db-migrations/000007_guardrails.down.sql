@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS guardrail_breaches;
+ALTER TABLE experiment_history DROP COLUMN guardrails;
+ALTER TABLE experiments DROP COLUMN guardrails;
@@ -0,0 +1,21 @@
+ALTER TABLE experiments ADD guardrails jsonb NOT NULL DEFAULT '[]';
+ALTER TABLE experiment_history ADD guardrails jsonb NOT NULL DEFAULT '[]';
+
+-- Guardrails of an experiment version that were breached by a treatment, disabling the experiment
+CREATE TABLE IF NOT EXISTS guardrail_breaches
+(
+   id                   bigserial          PRIMARY KEY,
+   experiment_id        integer            NOT NULL references experiments (id) ON DELETE CASCADE,
+   experiment_version   integer            NOT NULL,
+   metric               varchar(64)        NOT NULL,
+   treatment            varchar(64)        NOT NULL,
+   comparator           varchar(32)        NOT NULL,
+   threshold            double precision   NOT NULL,
+   value                double precision   NOT NULL,
+   sample_size          bigint             NOT NULL,
+
+   created_at           timestamp          NOT NULL default current_timestamp,
+   updated_at           timestamp          NOT NULL default current_timestamp
+);
+
+CREATE INDEX IF NOT EXISTS guardrail_breaches_experiment_id_idx ON guardrail_breaches (experiment_id);
@@ -0,0 +1,20 @@
+-- Passkeys accepted by the Fetch Treatment API, stored as the hex-encoded SHA-256 hashes of the keys
+CREATE TABLE IF NOT EXISTS project_passkeys
+(
+   id                   bigserial      PRIMARY KEY,
+   project_id           integer        NOT NULL references settings (project_id) ON DELETE CASCADE,
+   name                 varchar(64)    NOT NULL,
+   hash                 varchar(64)    NOT NULL,
+   expires_at           timestamp,
+
+   created_at           timestamp      NOT NULL default current_timestamp,
+   updated_at           timestamp      NOT NULL default current_timestamp,
+
+   CONSTRAINT project_passkeys_project_id_name_key UNIQUE (project_id, name)
+);
+
+-- Hash the existing plaintext passkeys. The plaintext passkeys are kept for one more release, so that the
+-- Treatment Services of the previous release keep accepting them during the upgrade. The settings.passkey column
+-- is dropped in the next release (see docs/infra/treatment-service.md).
+INSERT INTO project_passkeys (project_id, name, hash)
+SELECT project_id, 'default', encode(sha256(convert_to(passkey, 'UTF8')), 'hex') FROM settings;
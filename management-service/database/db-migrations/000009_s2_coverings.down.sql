@@ -0,0 +1,4 @@
+ALTER TABLE segment_history DROP COLUMN s2_covering;
+ALTER TABLE segments DROP COLUMN s2_covering;
+ALTER TABLE experiment_history DROP COLUMN s2_covering;
+ALTER TABLE experiments DROP COLUMN s2_covering;
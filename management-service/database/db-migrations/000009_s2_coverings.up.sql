@@ -0,0 +1,5 @@
+-- GeoJSON geometries from which the s2_ids of the segments were computed, with the properties of the coverings
+ALTER TABLE experiments ADD s2_covering jsonb;
+ALTER TABLE experiment_history ADD s2_covering jsonb;
+ALTER TABLE segments ADD s2_covering jsonb;
+ALTER TABLE segment_history ADD s2_covering jsonb;
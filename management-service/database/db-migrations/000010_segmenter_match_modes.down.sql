@@ -0,0 +1,4 @@
+ALTER TABLE custom_segmenters DROP COLUMN match_mode;
+
+-- Drop segmenter_match_mode enum
+DROP TYPE IF EXISTS segmenter_match_mode;
@@ -0,0 +1,4 @@
+-- How the values of string segmenters are matched: exactly, as prefixes or as regular expressions
+CREATE TYPE segmenter_match_mode as ENUM ('EXACT', 'PREFIX', 'REGEX');
+
+ALTER TABLE custom_segmenters ADD match_mode segmenter_match_mode NOT NULL DEFAULT 'EXACT';
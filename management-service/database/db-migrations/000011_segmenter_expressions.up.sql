@@ -0,0 +1,2 @@
+-- The CEL expression over the fetch treatment request that computes the value of the segmenter, if any
+ALTER TABLE custom_segmenters ADD expression text;
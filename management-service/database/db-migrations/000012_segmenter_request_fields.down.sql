@@ -0,0 +1 @@
+ALTER TABLE custom_segmenters DROP COLUMN request_fields;
@@ -0,0 +1,2 @@
+-- The alternative request fields, in priority order, from which the value of the segmenter is read, if any
+ALTER TABLE custom_segmenters ADD request_fields jsonb;
@@ -0,0 +1,2 @@
+-- The trace context of the request that wrote the message, with which the message is published
+ALTER TABLE outbox_messages ADD trace_context jsonb;
@@ -0,0 +1,3 @@
+DROP INDEX outbox_messages_entity;
+
+ALTER TABLE outbox_messages DROP COLUMN entity_id;
@@ -0,0 +1,5 @@
+-- The id of the changed entity within the project and message type, i.e. the experiment id or the
+-- segmenter name, which identifies the messages that supersede one another
+ALTER TABLE outbox_messages ADD entity_id varchar(64);
+
+CREATE INDEX outbox_messages_entity ON outbox_messages (project_id, message_type, entity_id, id);
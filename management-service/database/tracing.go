@@ -0,0 +1,76 @@
+package database
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/xp/common/tracing"
+)
+
+const tracingSpanKey = "xp:tracing_span"
+
+var tracer = otel.Tracer("github.com/caraml-dev/xp/management-service/database")
+
+// tracingPlugin starts a span for each query, as a child of the span in the context of the statement,
+// if any. The spans are no-ops unless tracing has been initialised.
+type tracingPlugin struct{}
+
+func (p *tracingPlugin) Name() string {
+	return "xp:tracing"
+}
+
+func (p *tracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("xp:tracing:before_create", startSpan("create")),
+		cb.Create().After("gorm:create").Register("xp:tracing:after_create", endSpan),
+		cb.Query().Before("gorm:query").Register("xp:tracing:before_query", startSpan("query")),
+		cb.Query().After("gorm:query").Register("xp:tracing:after_query", endSpan),
+		cb.Update().Before("gorm:update").Register("xp:tracing:before_update", startSpan("update")),
+		cb.Update().After("gorm:update").Register("xp:tracing:after_update", endSpan),
+		cb.Delete().Before("gorm:delete").Register("xp:tracing:before_delete", startSpan("delete")),
+		cb.Delete().After("gorm:delete").Register("xp:tracing:after_delete", endSpan),
+		cb.Row().Before("gorm:row").Register("xp:tracing:before_row", startSpan("row")),
+		cb.Row().After("gorm:row").Register("xp:tracing:after_row", endSpan),
+		cb.Raw().Before("gorm:raw").Register("xp:tracing:before_raw", startSpan("raw")),
+		cb.Raw().After("gorm:raw").Register("xp:tracing:after_raw", endSpan),
+	)
+}
+
+func startSpan(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		_, span := tracer.Start(db.Statement.Context, "gorm."+operation, trace.WithSpanKind(trace.SpanKindClient))
+		db.InstanceSet(tracingSpanKey, span)
+	}
+}
+
+func endSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(tracingSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	span.SetAttributes(
+		semconv.DBSystemPostgreSQL,
+		// The statement has placeholders in place of the values
+		semconv.DBStatement(db.Statement.SQL.String()),
+	)
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBSQLTable(db.Statement.Table))
+	}
+
+	err := db.Error
+	// A missing record is an expected outcome of the query
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil
+	}
+	tracing.EndSpan(span, err)
+}
@@ -0,0 +1,42 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	pg "gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type tracedRecord struct {
+	ID   int64
+	Name string
+}
+
+func TestTracingPlugin(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+
+	// Build the statements without executing them
+	db, err := gorm.Open(pg.Open("host=localhost"), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(&tracingPlugin{}))
+
+	db.Create(&tracedRecord{Name: "test"})
+	db.Where("name = ?", "test").Find(&[]tracedRecord{})
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "gorm.create", spans[0].Name())
+	assert.Equal(t, "gorm.query", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), semconv.DBSQLTable("traced_records"))
+	assert.Contains(t, spans[1].Attributes(),
+		attribute.String(string(semconv.DBStatementKey), `SELECT * FROM "traced_records" WHERE name = $1`))
+}
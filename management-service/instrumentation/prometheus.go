@@ -0,0 +1,39 @@
+package instrumentation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Namespace is the Prometheus Namespace in all metrics published by the xp app
+	Namespace string = "mlp"
+	// Subsystem is the Prometheus Subsystem in all metrics published by the management service
+	Subsystem string = "xp_management_service"
+)
+
+// ExperimentSRMMismatch is 1 for the running experiments with a suspected sample ratio mismatch, and 0 for the
+// other running experiments that were checked
+var ExperimentSRMMismatch = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: Subsystem,
+		Name:      "experiment_srm_mismatch",
+		Help:      "Gauge for whether a sample ratio mismatch is suspected in the running experiment",
+	},
+	[]string{"project_id", "experiment_id"},
+)
+
+// ExperimentSRMPValue is the p-value of the latest sample ratio mismatch check of the running experiments
+var ExperimentSRMPValue = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: Subsystem,
+		Name:      "experiment_srm_p_value",
+		Help:      "Gauge for the p-value of the latest sample ratio mismatch check of the running experiment",
+	},
+	[]string{"project_id", "experiment_id"},
+)
+
+func init() {
+	prometheus.MustRegister(ExperimentSRMMismatch, ExperimentSRMPValue)
+}
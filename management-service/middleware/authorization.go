@@ -10,16 +10,18 @@ import (
 )
 
 const (
-	resourceSegmenters = "segmenters"
-	resourceValidate   = "validate"
+	resourceSegmenters    = "segmenters"
+	resourceValidate      = "validate"
+	resourcePasskeyHashes = "passkey-hashes"
 )
 
 type Authorizer struct {
 	authEnforcer enforcer.Enforcer
 }
 
-// NewAuthorizer creates a new authorization middleware using the given auth enforcer
-func NewAuthorizer(enforcer enforcer.Enforcer) (*Authorizer, error) {
+// NewAuthorizer creates a new authorization middleware using the given auth enforcer. The passkey hashes of
+// the projects are only readable by the given subjects.
+func NewAuthorizer(enforcer enforcer.Enforcer, passkeyHashSubjects []string) (*Authorizer, error) {
 	// Set up XP API specific policies
 	err := upsertSegmentersListAllPolicy(enforcer)
 	if err != nil {
@@ -31,6 +33,11 @@ func NewAuthorizer(enforcer enforcer.Enforcer) (*Authorizer, error) {
 		return nil, err
 	}
 
+	err = upsertPasskeyHashesPolicy(enforcer, passkeyHashSubjects)
+	if err != nil {
+		return nil, err
+	}
+
 	return &Authorizer{authEnforcer: enforcer}, nil
 }
 
@@ -80,7 +87,13 @@ func getResourceFromPath(path string) string {
 	// if a user has READ/WRITE permissions on /projects/{project_id}, they would also have the same
 	// permissions on all its sub-resources. Thus, trimming the resource identifier to aid quicker
 	// authz matching and to efficiently make use of the in-memory authz cache, if enabled.
+	//
+	// The passkey hashes, at /projects/{project_id}/passkeys/hashes, are an exception, as they are only
+	// needed by the Treatment Service, and are not readable by the users of the project.
 	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) == 4 && parts[0] == "projects" && parts[2] == "passkeys" && parts[3] == "hashes" {
+		return resourcePasskeyHashes
+	}
 	if len(parts) > 1 {
 		parts = parts[:2]
 	}
@@ -119,6 +132,23 @@ func upsertValidationPolicy(authEnforcer enforcer.Enforcer) error {
 	return err
 }
 
+func upsertPasskeyHashesPolicy(authEnforcer enforcer.Enforcer, subjects []string) error {
+	if len(subjects) == 0 {
+		return nil
+	}
+
+	// Upsert policy
+	policyName := fmt.Sprintf("%s-policy", resourcePasskeyHashes)
+	_, err := authEnforcer.UpsertPolicy(
+		policyName,
+		[]string{},
+		subjects,
+		[]string{resourcePasskeyHashes},
+		[]string{enforcer.ActionRead},
+	)
+	return err
+}
+
 func jsonError(w http.ResponseWriter, msg string, status int) {
 	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
 	w.WriteHeader(status)
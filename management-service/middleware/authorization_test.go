@@ -42,13 +42,23 @@ func TestAuthorizationMiddleware(t *testing.T) {
 		[]string{"validate"},
 		[]string{"actions:create"},
 	).Return(nil, nil)
+	authzEnforcer.On(
+		"UpsertPolicy",
+		"passkey-hashes-policy",
+		[]string{},
+		[]string{"treatment-service@gojek.com"},
+		[]string{"passkey-hashes"},
+		[]string{"actions:read"},
+	).Return(nil, nil)
 	authzEnforcer.On("Enforce", "test-user@gojek.com", "projects", "actions:read").Return(&ok, nil)
 	authzEnforcer.On("Enforce", "test-user@gojek.com", "projects:1", "actions:read").Return(&nOk, nil)
 	authzEnforcer.On("Enforce", "test-user@gojek.com", "projects", "actions:update").Return(&nOk, nil)
 	authzEnforcer.On("Enforce", "test-user-2@gojek.com", "projects", "actions:read").Return(&nOk, nil)
+	authzEnforcer.On("Enforce", "test-user@gojek.com", "passkey-hashes", "actions:read").Return(&nOk, nil)
+	authzEnforcer.On("Enforce", "treatment-service@gojek.com", "passkey-hashes", "actions:read").Return(&ok, nil)
 
 	// Create Authorizer
-	authz, err := NewAuthorizer(authzEnforcer)
+	authz, err := NewAuthorizer(authzEnforcer, []string{"treatment-service@gojek.com"})
 	assert.NoError(t, err)
 	mw := authz.Middleware(testHandler)
 
@@ -68,6 +78,21 @@ func TestAuthorizationMiddleware(t *testing.T) {
 			email:        "test-user@gojek.com",
 			expectedBody: `{"test": "value"}`,
 		},
+		"success | passkey hashes": {
+			method:       "GET",
+			url:          "/projects/1/passkeys/hashes",
+			body:         "{}",
+			email:        "treatment-service@gojek.com",
+			expectedBody: "{}",
+		},
+		"failure | passkey hashes": {
+			method:       "GET",
+			url:          "/projects/1/passkeys/hashes",
+			body:         "{}",
+			email:        "test-user@gojek.com",
+			expectedErr:  true,
+			expectedBody: `{"error":"test-user@gojek.com is not authorized to execute actions:read on passkey-hashes"}`,
+		},
 		"failure | bad action": {
 			method:       "PUT",
 			url:          "/projects",
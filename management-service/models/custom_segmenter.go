@@ -9,7 +9,9 @@ import (
 	"strings"
 
 	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/common/expression"
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
 	"github.com/caraml-dev/xp/management-service/segmenters"
 	"github.com/golang-collections/collections/set"
 )
@@ -24,6 +26,16 @@ const (
 	SegmenterValueTypeReal    SegmenterValueType = "REAL"
 )
 
+// SegmenterMatchMode represents how the values of a string segmenter are matched against the value in the
+// treatment request.
+type SegmenterMatchMode string
+
+const (
+	SegmenterMatchModeExact  SegmenterMatchMode = "EXACT"
+	SegmenterMatchModePrefix SegmenterMatchMode = "PREFIX"
+	SegmenterMatchModeRegex  SegmenterMatchMode = "REGEX"
+)
+
 type PreRequisite struct {
 	// segmenter_name is the name of the free segmenter. This must be single-valued.
 	SegmenterName string `json:"segmenter_name"`
@@ -101,6 +113,22 @@ func (op Options) Value() (driver.Value, error) {
 	return json.Marshal(op)
 }
 
+// RequestFields are the alternative fetch treatment request fields of a segmenter's value, in priority order
+type RequestFields []string
+
+func (rf *RequestFields) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &rf)
+}
+
+func (rf RequestFields) Value() (driver.Value, error) {
+	return json.Marshal(rf)
+}
+
 type CustomSegmenter struct {
 	Model
 
@@ -128,6 +156,19 @@ type CustomSegmenter struct {
 	// satisfied, all values of the segmenter described by the options field may
 	// be applicable.
 	Constraints *Constraints `json:"constraints"`
+	// MatchMode is the way the values of a string segmenter are matched. The values are prefixes or
+	// regular expressions in the PREFIX and REGEX modes. It cannot be changed once the segmenter is created.
+	MatchMode SegmenterMatchMode `json:"match_mode" gorm:"default:EXACT"`
+	// Expression is an optional CEL expression over the fetch treatment request, that computes the segmenter's
+	// value from the request fields that it uses. It cannot be changed once the segmenter is created.
+	Expression *string `json:"expression"`
+	// RequestFields are the optional alternative request fields, in priority order, from which the segmenter's
+	// value is read, when it is not named after the segmenter. Nested fields are dot-separated paths. They
+	// cannot be changed once the segmenter is created.
+	RequestFields *RequestFields `json:"request_fields"`
+
+	// expressionFields caches the request fields used by the expression, so that it is only compiled once
+	expressionFields []string
 }
 
 // NewCustomSegmenter creates a new CustomSegmenter object and ensures that its segmenter values are all of the
@@ -136,6 +177,9 @@ func NewCustomSegmenter(
 	projectId ID,
 	name string,
 	segmenterType SegmenterValueType,
+	matchMode SegmenterMatchMode,
+	expression *string,
+	requestFields *RequestFields,
 	description *string,
 	required bool,
 	multiValued bool,
@@ -144,18 +188,33 @@ func NewCustomSegmenter(
 	segmenterTypes map[string]schema.SegmenterType,
 ) (*CustomSegmenter, error) {
 	newCustomSegmenter := CustomSegmenter{
-		ProjectID:   projectId,
-		Name:        name,
-		Type:        segmenterType,
-		Description: description,
-		Required:    required,
-		MultiValued: multiValued,
-		Options:     options,
-		Constraints: constraints,
+		ProjectID:     projectId,
+		Name:          name,
+		Type:          segmenterType,
+		Description:   description,
+		Required:      required,
+		MultiValued:   multiValued,
+		Options:       options,
+		Constraints:   constraints,
+		MatchMode:     matchMode,
+		Expression:    expression,
+		RequestFields: requestFields,
+	}
+	if newCustomSegmenter.MatchMode == "" {
+		newCustomSegmenter.MatchMode = SegmenterMatchModeExact
 	}
 	if err := newCustomSegmenter.ConvertToTypedValues(segmenterTypes); err != nil {
 		return nil, err
 	}
+	if err := newCustomSegmenter.ValidateMatchMode(); err != nil {
+		return nil, err
+	}
+	if err := newCustomSegmenter.ValidateExpression(); err != nil {
+		return nil, err
+	}
+	if err := newCustomSegmenter.ValidateRequestFields(); err != nil {
+		return nil, err
+	}
 	if err := validateOptionsHaveUniqueValues(newCustomSegmenter.Options); err != nil {
 		return nil, err
 	}
@@ -182,21 +241,28 @@ func (s *CustomSegmenter) ToApiSchema() schema.Segmenter {
 		additionalProperties = *s.Options
 	}
 
+	var matchMode *schema.SegmenterMatchMode
+	if s.MatchMode != "" {
+		mode := schema.SegmenterMatchMode(strings.ToLower(string(s.MatchMode)))
+		matchMode = &mode
+	}
+
 	return schema.Segmenter{
 		Name:        s.Name,
 		Type:        schema.SegmenterType(strings.ToLower(string(s.Type))),
+		MatchMode:   matchMode,
 		Description: s.Description,
 		Required:    s.Required,
 		MultiValued: s.MultiValued,
 		Options: schema.SegmenterOptions{
 			AdditionalProperties: additionalProperties,
 		},
-		Constraints: constraints,
-		CreatedAt:   &s.CreatedAt,
-		UpdatedAt:   &s.UpdatedAt,
-		TreatmentRequestFields: [][]string{
-			{s.Name},
-		},
+		Constraints:            constraints,
+		CreatedAt:              &s.CreatedAt,
+		UpdatedAt:              &s.UpdatedAt,
+		TreatmentRequestFields: s.getTreatmentRequestFields(),
+		Expression:             s.Expression,
+		RequestFields:          (*[]string)(s.RequestFields),
 	}
 }
 
@@ -221,11 +287,91 @@ func (s *CustomSegmenter) GetConfiguration() (*_segmenters.SegmenterConfiguratio
 }
 
 func (s *CustomSegmenter) GetExperimentVariables() *_segmenters.ListExperimentVariables {
-	return &_segmenters.ListExperimentVariables{
-		Values: []*_segmenters.ExperimentVariables{
-			{Value: []string{s.Name}},
-		},
+	experimentVariables := &_segmenters.ListExperimentVariables{}
+	for _, variables := range s.getTreatmentRequestFields() {
+		experimentVariables.Values = append(experimentVariables.Values, &_segmenters.ExperimentVariables{
+			Value: variables,
+		})
+	}
+	return experimentVariables
+}
+
+// getTreatmentRequestFields returns the alternative combinations of fetch treatment request fields from which the
+// segmenter's value is computed, which are each of its request fields, if any, or else the fields used by the
+// expression, or the field with the segmenter's name
+func (s *CustomSegmenter) getTreatmentRequestFields() [][]string {
+	if s.RequestFields != nil && len(*s.RequestFields) > 0 {
+		treatmentRequestFields := [][]string{}
+		for _, field := range *s.RequestFields {
+			treatmentRequestFields = append(treatmentRequestFields, []string{field})
+		}
+		return treatmentRequestFields
+	}
+	return [][]string{s.getExpressionFields()}
+}
+
+// getExpressionFields returns the fetch treatment request fields used by the expression, if any, or else the
+// field with the segmenter's name
+func (s *CustomSegmenter) getExpressionFields() []string {
+	if s.Expression == nil || *s.Expression == "" {
+		return []string{s.Name}
+	}
+	if s.expressionFields == nil {
+		// The expression is validated when the segmenter is saved, which sets the fields of new segmenters
+		expr, err := expression.Compile(*s.Expression, s.GetType())
+		if err != nil {
+			return []string{s.Name}
+		}
+		s.expressionFields = expr.RequestFields()
 	}
+	return s.expressionFields
+}
+
+// ValidateExpression checks that the expression, if any, is a valid CEL expression that returns a value of the
+// segmenter's type
+func (s *CustomSegmenter) ValidateExpression() error {
+	if s.Expression == nil {
+		return nil
+	}
+	if *s.Expression == "" {
+		return errors.New("segmenter expression cannot be empty")
+	}
+	if _, ok := _segmenters.SegmenterValueType_value[string(s.Type)]; !ok {
+		return fmt.Errorf("error getting a segmenter value type corresponding to: %s", s.Type)
+	}
+	expr, err := expression.Compile(*s.Expression, s.GetType())
+	if err != nil {
+		return fmt.Errorf("invalid segmenter expression: %s", err)
+	}
+	s.expressionFields = expr.RequestFields()
+	return nil
+}
+
+// ValidateRequestFields checks that the request fields, if any, are unique non-empty paths, and that they are not
+// set together with an expression, which reads the request fields itself
+func (s *CustomSegmenter) ValidateRequestFields() error {
+	if s.RequestFields == nil {
+		return nil
+	}
+	if len(*s.RequestFields) == 0 {
+		return errors.New("segmenter request fields cannot be empty")
+	}
+	if s.Expression != nil {
+		return errors.New("segmenter request fields cannot be set together with an expression")
+	}
+	fieldSet := set.New()
+	for _, field := range *s.RequestFields {
+		for _, name := range strings.Split(field, ".") {
+			if name == "" {
+				return fmt.Errorf("invalid segmenter request field: %q", field)
+			}
+		}
+		if fieldSet.Has(field) {
+			return fmt.Errorf("duplicate segmenter request field: %s", field)
+		}
+		fieldSet.Insert(field)
+	}
+	return nil
 }
 
 func (s *CustomSegmenter) IsValidType(inputValues []*_segmenters.SegmenterValue) bool {
@@ -241,11 +387,15 @@ func (s *CustomSegmenter) IsValidType(inputValues []*_segmenters.SegmenterValue)
 				return false
 			}
 		case _segmenters.SegmenterValueType_INTEGER:
-			if _, ok := val.GetValue().(*_segmenters.SegmenterValue_Integer); !ok {
+			switch val.GetValue().(type) {
+			case *_segmenters.SegmenterValue_Integer, *_segmenters.SegmenterValue_IntegerRange:
+			default:
 				return false
 			}
 		case _segmenters.SegmenterValueType_REAL:
-			if _, ok := val.GetValue().(*_segmenters.SegmenterValue_Real); !ok {
+			switch val.GetValue().(type) {
+			case *_segmenters.SegmenterValue_Real, *_segmenters.SegmenterValue_RealRange:
+			default:
 				return false
 			}
 		}
@@ -258,7 +408,22 @@ func (s *CustomSegmenter) ValidateSegmenterAndConstraints(segment map[string]*_s
 	if err != nil {
 		return err
 	}
-	return baseSegmenter.ValidateSegmenterAndConstraints(segment)
+	err = baseSegmenter.ValidateSegmenterAndConstraints(segment)
+	if err != nil {
+		return err
+	}
+
+	// Additional check to see that the values are valid patterns of the match mode
+	matchMode := s.getStringMatchMode()
+	if matchMode == _segmenters.StringMatchMode_EXACT {
+		return nil
+	}
+	for _, val := range segment[s.Name].GetValues() {
+		if err := _utils.ValidateStringPattern(val.GetString_(), matchMode); err != nil {
+			return fmt.Errorf("Segmenter %s has an invalid value: %s", s.Name, err)
+		}
+	}
+	return nil
 }
 
 // GetBaseSegmenter returns a BaseSegmenter object (just as how global segmenters are registered) constructed using
@@ -273,24 +438,57 @@ func (s *CustomSegmenter) GetBaseSegmenter() (segmenters.Segmenter, error) {
 	if s.Description != nil {
 		description = *s.Description
 	}
+	expressionString := ""
+	if s.Expression != nil {
+		expressionString = *s.Expression
+	}
 
 	config := _segmenters.SegmenterConfiguration{
-		Name: s.Name,
-		Type: _segmenters.SegmenterValueType(segmenterValueType),
-		TreatmentRequestFields: &_segmenters.ListExperimentVariables{
-			Values: []*_segmenters.ExperimentVariables{
-				{Value: []string{s.Name}},
-			},
-		},
-		Options:     formatOptions(s.Options),
-		MultiValued: s.MultiValued,
-		Constraints: formatConstraints(s.Constraints),
-		Required:    s.Required,
-		Description: description,
+		Name:                   s.Name,
+		Type:                   _segmenters.SegmenterValueType(segmenterValueType),
+		TreatmentRequestFields: s.GetExperimentVariables(),
+		Options:                formatOptions(s.Options),
+		MultiValued:            s.MultiValued,
+		Constraints:            formatConstraints(s.Constraints),
+		Required:               s.Required,
+		Description:            description,
+		MatchMode:              s.getStringMatchMode(),
+		Expression:             expressionString,
+	}
+	if s.RequestFields != nil {
+		config.RequestFields = *s.RequestFields
 	}
 	return segmenters.NewBaseSegmenter(&config), nil
 }
 
+func (s *CustomSegmenter) getStringMatchMode() _segmenters.StringMatchMode {
+	return _segmenters.StringMatchMode(_segmenters.StringMatchMode_value[string(s.MatchMode)])
+}
+
+// ValidateMatchMode checks that the match mode is known, and that only string segmenters use patterns, in which
+// case the options must be valid patterns
+func (s *CustomSegmenter) ValidateMatchMode() error {
+	if _, ok := _segmenters.StringMatchMode_value[string(s.MatchMode)]; !ok {
+		return fmt.Errorf("segmenter match mode not recognised: %s", s.MatchMode)
+	}
+	matchMode := s.getStringMatchMode()
+	if matchMode == _segmenters.StringMatchMode_EXACT {
+		return nil
+	}
+	if s.Type != SegmenterValueTypeString {
+		return fmt.Errorf("match mode %s is only supported for %s segmenters", s.MatchMode, SegmenterValueTypeString)
+	}
+	if s.Options != nil {
+		for name, value := range *s.Options {
+			pattern, _ := value.(string)
+			if err := _utils.ValidateStringPattern(pattern, matchMode); err != nil {
+				return fmt.Errorf("invalid value for option %s: %s", name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // validateOptionsHaveUniqueValues checks if all the values in the options argument are unique
 func validateOptionsHaveUniqueValues(options *Options) error {
 	if options == nil {
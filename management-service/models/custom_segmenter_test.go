@@ -9,6 +9,7 @@ import (
 )
 
 var testDescription1 = "test-custom-segmenter: string"
+var testExpression = "request.age >= 18 && request.country == \"ID\""
 
 var testSegmenters = []CustomSegmenter{
 	{
@@ -125,6 +126,37 @@ func TestGetExperimentVariables(t *testing.T) {
 				},
 			},
 		},
+		"success | expression segmenter uses the request fields of the expression": {
+			customSegmenter: CustomSegmenter{
+				Name:       "adult-indonesian",
+				Type:       SegmenterValueTypeBool,
+				Expression: &testExpression,
+			},
+			expected: &segmenters.ListExperimentVariables{
+				Values: []*segmenters.ExperimentVariables{
+					{
+						Value: []string{"age", "country"},
+					},
+				},
+			},
+		},
+		"success | request fields are alternative variables in priority order": {
+			customSegmenter: CustomSegmenter{
+				Name:          "tier",
+				Type:          SegmenterValueTypeString,
+				RequestFields: &RequestFields{"user.profile.tier", "customer_tier"},
+			},
+			expected: &segmenters.ListExperimentVariables{
+				Values: []*segmenters.ExperimentVariables{
+					{
+						Value: []string{"user.profile.tier"},
+					},
+					{
+						Value: []string{"customer_tier"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, data := range tests {
@@ -133,6 +165,21 @@ func TestGetExperimentVariables(t *testing.T) {
 	}
 }
 
+func TestExpressionFieldsCached(t *testing.T) {
+	// The fields are computed when the expression is validated
+	validated := CustomSegmenter{Name: "adult-indonesian", Type: SegmenterValueTypeBool, Expression: &testExpression}
+	assert.NoError(t, validated.ValidateExpression())
+	assert.Equal(t, []string{"age", "country"}, validated.expressionFields)
+
+	// The fields of a segmenter read from the DB are computed once, on first use
+	stored := CustomSegmenter{Name: "adult-indonesian", Type: SegmenterValueTypeBool, Expression: &testExpression}
+	assert.Nil(t, stored.expressionFields)
+	stored.ToApiSchema()
+	assert.Equal(t, []string{"age", "country"}, stored.expressionFields)
+	stored.expressionFields = []string{"cached"}
+	assert.Equal(t, []string{"cached"}, stored.GetExperimentVariables().Values[0].Value)
+}
+
 func TestIsValidType(t *testing.T) {
 	tests := map[string]struct {
 		customSegmenter CustomSegmenter
@@ -186,6 +233,28 @@ func TestIsValidType(t *testing.T) {
 				{Value: &segmenters.SegmenterValue_Real{Real: 0.5}},
 			},
 		},
+		"failure | invalid type; need real but given integer range": {
+			customSegmenter: CustomSegmenter{
+				ProjectID: ID(1),
+				Name:      "dummy-segmenter",
+				Type:      SegmenterValueTypeReal,
+			},
+			values: []*segmenters.SegmenterValue{
+				{Value: &segmenters.SegmenterValue_IntegerRange{IntegerRange: &segmenters.IntegerRange{}}},
+			},
+		},
+		"success | integer values and ranges": {
+			customSegmenter: CustomSegmenter{
+				ProjectID: ID(1),
+				Name:      "dummy-segmenter",
+				Type:      SegmenterValueTypeInteger,
+			},
+			values: []*segmenters.SegmenterValue{
+				{Value: &segmenters.SegmenterValue_Integer{Integer: 10}},
+				{Value: &segmenters.SegmenterValue_IntegerRange{IntegerRange: &segmenters.IntegerRange{}}},
+			},
+			success: true,
+		},
 		"success | empty list": {
 			customSegmenter: testSegmenters[0],
 			values:          []*segmenters.SegmenterValue{},
@@ -231,6 +300,54 @@ func TestValidateSegmenterAndConstraints(t *testing.T) {
 			},
 			values: map[string]*segmenters.ListSegmenterValue{},
 		},
+		"success | regex": {
+			customSegmenter: CustomSegmenter{
+				ProjectID: ID(1),
+				Name:      "regex-segmenter",
+				Type:      SegmenterValueTypeString,
+				MatchMode: SegmenterMatchModeRegex,
+			},
+			values: map[string]*segmenters.ListSegmenterValue{
+				"regex-segmenter": {
+					Values: []*segmenters.SegmenterValue{
+						{Value: &segmenters.SegmenterValue_String_{String_: "^merchant-[0-9]+$"}},
+					},
+				},
+			},
+		},
+		"failure | invalid regex": {
+			customSegmenter: CustomSegmenter{
+				ProjectID: ID(1),
+				Name:      "regex-segmenter",
+				Type:      SegmenterValueTypeString,
+				MatchMode: SegmenterMatchModeRegex,
+			},
+			values: map[string]*segmenters.ListSegmenterValue{
+				"regex-segmenter": {
+					Values: []*segmenters.SegmenterValue{
+						{Value: &segmenters.SegmenterValue_String_{String_: "merchant-(["}},
+					},
+				},
+			},
+			errString: "Segmenter regex-segmenter has an invalid value: invalid regular expression \"merchant-([\": " +
+				"error parsing regexp: missing closing ]: `[`",
+		},
+		"failure | empty prefix": {
+			customSegmenter: CustomSegmenter{
+				ProjectID: ID(1),
+				Name:      "prefix-segmenter",
+				Type:      SegmenterValueTypeString,
+				MatchMode: SegmenterMatchModePrefix,
+			},
+			values: map[string]*segmenters.ListSegmenterValue{
+				"prefix-segmenter": {
+					Values: []*segmenters.SegmenterValue{
+						{Value: &segmenters.SegmenterValue_String_{String_: ""}},
+					},
+				},
+			},
+			errString: "Segmenter prefix-segmenter has an invalid value: prefix cannot be empty",
+		},
 	}
 
 	for _, data := range tests {
@@ -243,6 +360,198 @@ func TestValidateSegmenterAndConstraints(t *testing.T) {
 	}
 }
 
+func TestValidateMatchMode(t *testing.T) {
+	tests := map[string]struct {
+		customSegmenter CustomSegmenter
+		errString       string
+	}{
+		"success | exact": {
+			customSegmenter: CustomSegmenter{
+				Name:      "exact-segmenter",
+				Type:      SegmenterValueTypeInteger,
+				MatchMode: SegmenterMatchModeExact,
+			},
+		},
+		"success | prefix": {
+			customSegmenter: CustomSegmenter{
+				Name:      "prefix-segmenter",
+				Type:      SegmenterValueTypeString,
+				MatchMode: SegmenterMatchModePrefix,
+				Options:   &Options{"jakarta": "ID-JK"},
+			},
+		},
+		"failure | unknown match mode": {
+			customSegmenter: CustomSegmenter{
+				Name:      "glob-segmenter",
+				Type:      SegmenterValueTypeString,
+				MatchMode: SegmenterMatchMode("GLOB"),
+			},
+			errString: "segmenter match mode not recognised: GLOB",
+		},
+		"failure | non-string segmenter": {
+			customSegmenter: CustomSegmenter{
+				Name:      "regex-segmenter",
+				Type:      SegmenterValueTypeInteger,
+				MatchMode: SegmenterMatchModeRegex,
+			},
+			errString: "match mode REGEX is only supported for STRING segmenters",
+		},
+		"failure | invalid option": {
+			customSegmenter: CustomSegmenter{
+				Name:      "regex-segmenter",
+				Type:      SegmenterValueTypeString,
+				MatchMode: SegmenterMatchModeRegex,
+				Options:   &Options{"invalid": "a(b"},
+			},
+			errString: "invalid value for option invalid: invalid regular expression \"a(b\": " +
+				"error parsing regexp: missing closing ): `a(b`",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := data.customSegmenter.ValidateMatchMode()
+			if data.errString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
+
+func TestValidateExpression(t *testing.T) {
+	emptyExpression := ""
+	stringExpression := "request.country"
+	invalidExpression := "request.age >="
+
+	tests := map[string]struct {
+		customSegmenter CustomSegmenter
+		errString       string
+	}{
+		"success | no expression": {
+			customSegmenter: CustomSegmenter{
+				Name: "country",
+				Type: SegmenterValueTypeString,
+			},
+		},
+		"success | bool expression": {
+			customSegmenter: CustomSegmenter{
+				Name:       "adult-indonesian",
+				Type:       SegmenterValueTypeBool,
+				Expression: &testExpression,
+			},
+		},
+		"failure | empty expression": {
+			customSegmenter: CustomSegmenter{
+				Name:       "country",
+				Type:       SegmenterValueTypeString,
+				Expression: &emptyExpression,
+			},
+			errString: "segmenter expression cannot be empty",
+		},
+		"failure | mismatched type": {
+			customSegmenter: CustomSegmenter{
+				Name:       "adult-indonesian",
+				Type:       SegmenterValueTypeString,
+				Expression: &testExpression,
+			},
+			errString: "invalid segmenter expression: expression returns bool, expected string",
+		},
+		"failure | syntax error": {
+			customSegmenter: CustomSegmenter{
+				Name:       "adult",
+				Type:       SegmenterValueTypeBool,
+				Expression: &invalidExpression,
+			},
+			errString: "invalid segmenter expression: invalid expression",
+		},
+		"success | string expression": {
+			customSegmenter: CustomSegmenter{
+				Name:       "country",
+				Type:       SegmenterValueTypeString,
+				Expression: &stringExpression,
+			},
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := data.customSegmenter.ValidateExpression()
+			if data.errString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, data.errString)
+			}
+		})
+	}
+}
+
+func TestValidateRequestFields(t *testing.T) {
+	tests := map[string]struct {
+		customSegmenter CustomSegmenter
+		errString       string
+	}{
+		"success | no request fields": {
+			customSegmenter: CustomSegmenter{
+				Name: "tier",
+				Type: SegmenterValueTypeString,
+			},
+		},
+		"success | nested request fields": {
+			customSegmenter: CustomSegmenter{
+				Name:          "tier",
+				Type:          SegmenterValueTypeString,
+				RequestFields: &RequestFields{"user.profile.tier", "tier_name"},
+			},
+		},
+		"failure | empty request fields": {
+			customSegmenter: CustomSegmenter{
+				Name:          "tier",
+				Type:          SegmenterValueTypeString,
+				RequestFields: &RequestFields{},
+			},
+			errString: "segmenter request fields cannot be empty",
+		},
+		"failure | expression": {
+			customSegmenter: CustomSegmenter{
+				Name:          "adult-indonesian",
+				Type:          SegmenterValueTypeBool,
+				Expression:    &testExpression,
+				RequestFields: &RequestFields{"user.adult"},
+			},
+			errString: "segmenter request fields cannot be set together with an expression",
+		},
+		"failure | invalid path": {
+			customSegmenter: CustomSegmenter{
+				Name:          "tier",
+				Type:          SegmenterValueTypeString,
+				RequestFields: &RequestFields{"user..tier"},
+			},
+			errString: `invalid segmenter request field: "user..tier"`,
+		},
+		"failure | duplicate request fields": {
+			customSegmenter: CustomSegmenter{
+				Name:          "tier",
+				Type:          SegmenterValueTypeString,
+				RequestFields: &RequestFields{"tier_name", "tier_name"},
+			},
+			errString: "duplicate segmenter request field: tier_name",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := data.customSegmenter.ValidateRequestFields()
+			if data.errString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
+
 func TestValidatePreRequisiteSegmenters(t *testing.T) {
 	tests := map[string]struct {
 		customSegmenter CustomSegmenter
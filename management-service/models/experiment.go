@@ -85,8 +85,12 @@ type Experiment struct {
 	Tier ExperimentTier `json:"tier"`
 	// Treatments holds the experiment treatment configurations
 	Treatments ExperimentTreatments `json:"treatments"`
+	// Guardrails holds the metric thresholds that disable the experiment automatically, when breached
+	Guardrails ExperimentGuardrails `json:"guardrails"`
 	// Segment holds the combination of segmenters that the experiment applies to
 	Segment ExperimentSegment `json:"segment"`
+	// S2Covering holds the GeoJSON geometry from which the s2_ids of the segment were computed, if any
+	S2Covering *S2Covering `json:"s2_covering"`
 	// Status is the experiment's status
 	Status ExperimentStatus `json:"status"`
 	// StartTime describes the time at which an experiment starts
@@ -151,14 +155,21 @@ func (e *Experiment) ToApiSchema(segmentersType map[string]schema.SegmenterType,
 	treatments := e.Treatments.ToApiSchema()
 	experimentType := schema.ExperimentType(e.Type)
 	tier := schema.ExperimentTier(e.Tier)
+	var guardrails *[]schema.ExperimentGuardrail
+	if len(e.Guardrails) > 0 {
+		guardrailsSchema := e.Guardrails.ToApiSchema()
+		guardrails = &guardrailsSchema
+	}
 
 	return schema.Experiment{
 		Description:    e.Description,
 		EndTime:        &e.EndTime,
+		Guardrails:     guardrails,
 		Id:             &id,
 		Interval:       e.Interval,
 		Name:           &e.Name,
 		ProjectId:      &projectId,
+		S2Covering:     e.S2Covering.ToApiSchema(),
 		Segment:        &segment,
 		Status:         &status,
 		StatusFriendly: &statusFriendly,
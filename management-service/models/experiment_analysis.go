@@ -0,0 +1,105 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/management-service/analysis"
+)
+
+// ExperimentAssignment is the treatment that was assigned to a unit, for a version of the experiment
+type ExperimentAssignment struct {
+	Model
+
+	ExperimentID      ID     `json:"experiment_id" gorm:"primary_key"`
+	ExperimentVersion int64  `json:"experiment_version" gorm:"primary_key"`
+	UnitID            string `json:"unit_id" gorm:"primary_key"`
+	Treatment         string `json:"treatment"`
+}
+
+// MetricObservation is the value of a metric for a unit of the experiment
+type MetricObservation struct {
+	Model
+
+	ExperimentID ID      `json:"experiment_id" gorm:"primary_key"`
+	Metric       string  `json:"metric" gorm:"primary_key"`
+	UnitID       string  `json:"unit_id" gorm:"primary_key"`
+	Value        float64 `json:"value"`
+	// Covariate is the pre-experiment value of the metric for the unit, used for CUPED
+	Covariate *float64 `json:"covariate"`
+}
+
+type TreatmentResults []analysis.TreatmentStatistics
+
+func (r *TreatmentResults) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &r)
+}
+
+func (r TreatmentResults) Value() (driver.Value, error) {
+	return json.Marshal(r)
+}
+
+func (r TreatmentResults) ToApiSchema() []schema.TreatmentResult {
+	results := []schema.TreatmentResult{}
+	for _, result := range r {
+		results = append(results, schema.TreatmentResult{
+			Treatment:         result.Treatment,
+			Count:             int64(result.Count),
+			Mean:              result.Mean,
+			MeanLower:         result.MeanLower,
+			MeanUpper:         result.MeanUpper,
+			Difference:        result.Difference,
+			DifferenceLower:   result.DifferenceLower,
+			DifferenceUpper:   result.DifferenceUpper,
+			RelativeLift:      result.RelativeLift,
+			RelativeLiftLower: result.RelativeLiftLower,
+			RelativeLiftUpper: result.RelativeLiftUpper,
+			PValue:            result.PValue,
+		})
+	}
+	return results
+}
+
+// ExperimentResult is the per-treatment statistics of a metric, computed for a version of the experiment.
+// Computing the result again replaces the previous one.
+type ExperimentResult struct {
+	Model
+
+	// ID is the id of the ExperimentResult record
+	ID ID `json:"id" gorm:"primary_key"`
+
+	ExperimentID      ID     `json:"experiment_id"`
+	ExperimentVersion int64  `json:"experiment_version"`
+	Metric            string `json:"metric"`
+
+	// The parameters of the analysis
+	ControlTreatment string  `json:"control_treatment"`
+	CUPED            bool    `json:"cuped" gorm:"column:cuped"`
+	ConfidenceLevel  float64 `json:"confidence_level"`
+
+	Treatments TreatmentResults `json:"treatments"`
+}
+
+// ToApiSchema converts the experiment result DB model to a format compatible with the
+// OpenAPI specifications.
+func (r *ExperimentResult) ToApiSchema() schema.ExperimentResult {
+	return schema.ExperimentResult{
+		Id:                r.ID.ToApiSchema(),
+		ExperimentId:      r.ExperimentID.ToApiSchema(),
+		ExperimentVersion: r.ExperimentVersion,
+		Metric:            r.Metric,
+		ControlTreatment:  r.ControlTreatment,
+		Cuped:             r.CUPED,
+		ConfidenceLevel:   r.ConfidenceLevel,
+		Treatments:        r.Treatments.ToApiSchema(),
+		CreatedAt:         r.CreatedAt,
+		UpdatedAt:         r.UpdatedAt,
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/management-service/analysis"
+)
+
+func TestTreatmentResultsValueScan(t *testing.T) {
+	difference := 1.5
+	results := TreatmentResults{
+		{Treatment: "control", Count: 10, Mean: 2, MeanLower: 1, MeanUpper: 3},
+		{Treatment: "treatment", Count: 12, Mean: 3.5, MeanLower: 2, MeanUpper: 5, Difference: &difference},
+	}
+
+	value, err := results.Value()
+	require.NoError(t, err)
+
+	var scanned TreatmentResults
+	err = scanned.Scan(value)
+	require.NoError(t, err)
+	assert.Equal(t, results, scanned)
+
+	assert.EqualError(t, scanned.Scan("invalid"), "type assertion to []byte failed")
+}
+
+func TestExperimentResultToApiSchema(t *testing.T) {
+	difference, lift, pValue := 1.5, 0.75, 0.01
+	result := ExperimentResult{
+		Model: Model{
+			CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+			UpdatedAt: time.Date(2021, 1, 1, 2, 3, 10, 0, time.UTC),
+		},
+		ID:                ID(3),
+		ExperimentID:      ID(2),
+		ExperimentVersion: 4,
+		Metric:            "conversion",
+		ControlTreatment:  "control",
+		CUPED:             true,
+		ConfidenceLevel:   0.9,
+		Treatments: TreatmentResults{
+			analysis.TreatmentStatistics{Treatment: "control", Count: 10, Mean: 2, MeanLower: 1, MeanUpper: 3},
+			analysis.TreatmentStatistics{
+				Treatment:    "treatment",
+				Count:        12,
+				Mean:         3.5,
+				MeanLower:    2,
+				MeanUpper:    5,
+				Difference:   &difference,
+				RelativeLift: &lift,
+				PValue:       &pValue,
+			},
+		},
+	}
+
+	assert.Equal(t, schema.ExperimentResult{
+		Id:                int64(3),
+		ExperimentId:      int64(2),
+		ExperimentVersion: int64(4),
+		Metric:            "conversion",
+		ControlTreatment:  "control",
+		Cuped:             true,
+		ConfidenceLevel:   0.9,
+		Treatments: []schema.TreatmentResult{
+			{Treatment: "control", Count: 10, Mean: 2, MeanLower: 1, MeanUpper: 3},
+			{
+				Treatment:    "treatment",
+				Count:        12,
+				Mean:         3.5,
+				MeanLower:    2,
+				MeanUpper:    5,
+				Difference:   &difference,
+				RelativeLift: &lift,
+				PValue:       &pValue,
+			},
+		},
+		CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+		UpdatedAt: time.Date(2021, 1, 1, 2, 3, 10, 0, time.UTC),
+	}, result.ToApiSchema())
+}
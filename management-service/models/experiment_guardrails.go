@@ -0,0 +1,106 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+)
+
+type GuardrailComparator string
+
+const (
+	// GuardrailComparatorGreaterThan breaches the guardrail when the mean value is greater than the threshold
+	GuardrailComparatorGreaterThan GuardrailComparator = "greater_than"
+	// GuardrailComparatorLessThan breaches the guardrail when the mean value is less than the threshold
+	GuardrailComparatorLessThan GuardrailComparator = "less_than"
+)
+
+// IsBreached checks the given value against the threshold
+func (c GuardrailComparator) IsBreached(value float64, threshold float64) bool {
+	switch c {
+	case GuardrailComparatorGreaterThan:
+		return value > threshold
+	case GuardrailComparatorLessThan:
+		return value < threshold
+	}
+	return false
+}
+
+type ExperimentGuardrails []ExperimentGuardrail
+
+// ExperimentGuardrail is a threshold on the mean value of a metric, per treatment, beyond which
+// the experiment is disabled automatically
+type ExperimentGuardrail struct {
+	Metric     string              `json:"metric" validate:"required,notBlank"`
+	Comparator GuardrailComparator `json:"comparator" validate:"required,oneof=greater_than less_than"`
+	Threshold  float64             `json:"threshold"`
+	// MinSampleSize is the no. of observations of the metric for a treatment, required before the
+	// guardrail is evaluated
+	MinSampleSize *int64 `json:"min_sample_size,omitempty" validate:"omitempty,min=1"`
+}
+
+func (g *ExperimentGuardrails) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &g)
+}
+
+func (g ExperimentGuardrails) Value() (driver.Value, error) {
+	return json.Marshal(g)
+}
+
+func (g ExperimentGuardrails) ToApiSchema() []schema.ExperimentGuardrail {
+	var guardrails []schema.ExperimentGuardrail
+	for _, guardrail := range g {
+		guardrails = append(guardrails, schema.ExperimentGuardrail{
+			Metric:        guardrail.Metric,
+			Comparator:    schema.GuardrailComparator(guardrail.Comparator),
+			Threshold:     guardrail.Threshold,
+			MinSampleSize: guardrail.MinSampleSize,
+		})
+	}
+	return guardrails
+}
+
+// GuardrailBreach records a guardrail of the experiment that was breached by a treatment,
+// which caused the experiment to be disabled
+type GuardrailBreach struct {
+	Model
+
+	// ID is the id of the GuardrailBreach record
+	ID ID `json:"id" gorm:"primary_key"`
+
+	ExperimentID      ID    `json:"experiment_id"`
+	ExperimentVersion int64 `json:"experiment_version"`
+
+	Metric     string              `json:"metric"`
+	Treatment  string              `json:"treatment"`
+	Comparator GuardrailComparator `json:"comparator"`
+	Threshold  float64             `json:"threshold"`
+	// Value is the mean value of the metric for the treatment, at the time of the breach
+	Value      float64 `json:"value"`
+	SampleSize int64   `json:"sample_size"`
+}
+
+// ToApiSchema converts the guardrail breach DB model to a format compatible with the
+// OpenAPI specifications.
+func (b *GuardrailBreach) ToApiSchema() schema.GuardrailBreach {
+	return schema.GuardrailBreach{
+		Id:                b.ID.ToApiSchema(),
+		ExperimentId:      b.ExperimentID.ToApiSchema(),
+		ExperimentVersion: b.ExperimentVersion,
+		Metric:            b.Metric,
+		Treatment:         b.Treatment,
+		Comparator:        schema.GuardrailComparator(b.Comparator),
+		Threshold:         b.Threshold,
+		Value:             b.Value,
+		SampleSize:        b.SampleSize,
+		CreatedAt:         b.CreatedAt,
+		UpdatedAt:         b.UpdatedAt,
+	}
+}
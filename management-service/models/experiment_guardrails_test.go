@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+)
+
+func TestGuardrailComparatorIsBreached(t *testing.T) {
+	assert.True(t, GuardrailComparatorGreaterThan.IsBreached(0.06, 0.05))
+	assert.False(t, GuardrailComparatorGreaterThan.IsBreached(0.05, 0.05))
+	assert.True(t, GuardrailComparatorLessThan.IsBreached(0.9, 1))
+	assert.False(t, GuardrailComparatorLessThan.IsBreached(1, 1))
+	assert.False(t, GuardrailComparator("unknown").IsBreached(1, 0))
+}
+
+func TestGuardrailsValueScan(t *testing.T) {
+	var minSampleSize int64 = 100
+	guardrails := ExperimentGuardrails{
+		{Metric: "error_rate", Comparator: GuardrailComparatorGreaterThan, Threshold: 0.05},
+		{Metric: "conversion", Comparator: GuardrailComparatorLessThan, Threshold: 0.1, MinSampleSize: &minSampleSize},
+	}
+
+	value, err := guardrails.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"metric": "error_rate", "comparator": "greater_than", "threshold": 0.05},
+		{"metric": "conversion", "comparator": "less_than", "threshold": 0.1, "min_sample_size": 100}
+	]`, string(value.([]byte)))
+
+	var scanned ExperimentGuardrails
+	err = scanned.Scan(value)
+	require.NoError(t, err)
+	assert.Equal(t, guardrails, scanned)
+
+	assert.EqualError(t, scanned.Scan("invalid"), "type assertion to []byte failed")
+}
+
+func TestGuardrailBreachToApiSchema(t *testing.T) {
+	breach := GuardrailBreach{
+		Model: Model{
+			CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+			UpdatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+		},
+		ID:                ID(1),
+		ExperimentID:      ID(2),
+		ExperimentVersion: 3,
+		Metric:            "error_rate",
+		Treatment:         "treatment-1",
+		Comparator:        GuardrailComparatorGreaterThan,
+		Threshold:         0.05,
+		Value:             0.08,
+		SampleSize:        1200,
+	}
+
+	assert.Equal(t, schema.GuardrailBreach{
+		Id:                int64(1),
+		ExperimentId:      int64(2),
+		ExperimentVersion: int64(3),
+		Metric:            "error_rate",
+		Treatment:         "treatment-1",
+		Comparator:        schema.GuardrailComparatorGreaterThan,
+		Threshold:         0.05,
+		Value:             0.08,
+		SampleSize:        int64(1200),
+		CreatedAt:         time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+		UpdatedAt:         time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+	}, breach.ToApiSchema())
+}
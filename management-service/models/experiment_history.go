@@ -32,7 +32,9 @@ type ExperimentHistory struct {
 	Interval    *int32               `json:"interval"`
 	Tier        ExperimentTier       `json:"tier"`
 	Treatments  ExperimentTreatments `json:"treatments"`
+	Guardrails  ExperimentGuardrails `json:"guardrails"`
 	Segment     ExperimentSegment    `json:"segment"`
+	S2Covering  *S2Covering          `json:"s2_covering"`
 	Status      ExperimentStatus     `json:"status"`
 	StartTime   time.Time            `json:"start_time"`
 	EndTime     time.Time            `json:"end_time"`
@@ -59,15 +61,22 @@ func (e *ExperimentHistory) ToApiSchema(segmentersType map[string]schema.Segment
 	status := schema.ExperimentStatus(e.Status)
 	expType := schema.ExperimentType(e.Type)
 	tierType := schema.ExperimentTier(e.Tier)
+	var guardrails *[]schema.ExperimentGuardrail
+	if len(e.Guardrails) > 0 {
+		guardrailsSchema := e.Guardrails.ToApiSchema()
+		guardrails = &guardrailsSchema
+	}
 
 	return schema.ExperimentHistory{
 		Description:  e.Description,
 		EndTime:      e.EndTime,
+		Guardrails:   guardrails,
 		Id:           e.ID.ToApiSchema(),
 		Interval:     e.Interval,
 		Name:         e.Name,
 		ExperimentId: e.ExperimentID.ToApiSchema(),
 		Segment:      e.Segment.ToApiSchema(segmentersType),
+		S2Covering:   e.S2Covering.ToApiSchema(),
 		Status:       status,
 		Tier:         tierType,
 		Treatments:   e.Treatments.ToApiSchema(),
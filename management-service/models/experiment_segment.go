@@ -36,20 +36,32 @@ func (s ExperimentSegment) ToApiSchema(segmentersType map[string]schema.Segmente
 		switch segmentersType[key] {
 		case schema.SegmenterTypeString:
 			experimentSegment[key] = vals
-		case schema.SegmenterTypeInteger:
-			intVals := []int64{}
-			for _, val := range vals {
-				intVal, _ := strconv.Atoi(val)
-				intVals = append(intVals, int64(intVal))
-			}
-			experimentSegment[key] = intVals
-		case schema.SegmenterTypeReal:
-			floatVals := []float64{}
-			for _, val := range vals {
-				float64Val, _ := strconv.ParseFloat(val, 64)
-				floatVals = append(floatVals, float64Val)
+		case schema.SegmenterTypeInteger, schema.SegmenterTypeReal:
+			if containsSegmenterRanges(vals) {
+				// Ranges are returned as objects, alongside the numbers
+				values := []interface{}{}
+				for _, val := range vals {
+					segmenterValue, err := parseNumericSegmenterValue(val, segmentersType[key])
+					if err == nil {
+						values = append(values, _utils.SegmenterValueToInterface(segmenterValue))
+					}
+				}
+				experimentSegment[key] = values
+			} else if segmentersType[key] == schema.SegmenterTypeInteger {
+				intVals := []int64{}
+				for _, val := range vals {
+					intVal, _ := strconv.Atoi(val)
+					intVals = append(intVals, int64(intVal))
+				}
+				experimentSegment[key] = intVals
+			} else {
+				floatVals := []float64{}
+				for _, val := range vals {
+					float64Val, _ := strconv.ParseFloat(val, 64)
+					floatVals = append(floatVals, float64Val)
+				}
+				experimentSegment[key] = floatVals
 			}
-			experimentSegment[key] = floatVals
 		case schema.SegmenterTypeBool:
 			boolVals := []bool{}
 			for _, val := range vals {
@@ -71,21 +83,27 @@ func (s ExperimentSegment) ToProtoSchema(segmenterTypes map[string]schema.Segmen
 		if len(vals) > 0 {
 			switch segmenterTypes[key] {
 			case schema.SegmenterTypeString:
-				protoSegments[key] = _utils.StringSliceToListSegmenterValue(&vals)
-			case schema.SegmenterTypeInteger:
-				intVals := []int64{}
-				for _, val := range vals {
-					intVal, _ := strconv.Atoi(val)
-					intVals = append(intVals, int64(intVal))
+				if key == _utils.AppVersionSegmenter {
+					// Version constraints are sent as the ranges of the encoded versions
+					protoSegments[key] = versionConstraintsToListSegmenterValue(vals)
+					continue
 				}
-				protoSegments[key] = _utils.Int64ListToListSegmenterValue(&intVals)
-			case schema.SegmenterTypeReal:
-				floatVals := []float64{}
+				if key == _utils.DatesSegmenter {
+					// Date ranges are sent as the dates they contain
+					dates := _utils.ExpandDateValues(vals)
+					protoSegments[key] = _utils.StringSliceToListSegmenterValue(&dates)
+					continue
+				}
+				protoSegments[key] = _utils.StringSliceToListSegmenterValue(&vals)
+			case schema.SegmenterTypeInteger, schema.SegmenterTypeReal:
+				values := []*_segmenters.SegmenterValue{}
 				for _, val := range vals {
-					float64Val, _ := strconv.ParseFloat(val, 64)
-					floatVals = append(floatVals, float64Val)
+					segmenterValue, err := parseNumericSegmenterValue(val, segmenterTypes[key])
+					if err == nil {
+						values = append(values, segmenterValue)
+					}
 				}
-				protoSegments[key] = _utils.FloatListToListSegmenterValue(&floatVals)
+				protoSegments[key] = &_segmenters.ListSegmenterValue{Values: values}
 			case schema.SegmenterTypeBool:
 				boolVals := []bool{}
 				for _, val := range vals {
@@ -120,6 +138,14 @@ func (s ExperimentSegmentRaw) ToStorageSchema(segmenterTypes map[string]schema.S
 		case schema.SegmenterTypeInteger:
 			strVals := []string{}
 			for _, val := range vals {
+				if rangeVal, ok := val.(map[string]interface{}); ok {
+					strVal, err := formatSegmenterRange(k, rangeVal, _segmenters.SegmenterValueType_INTEGER)
+					if err != nil {
+						return nil, err
+					}
+					strVals = append(strVals, strVal)
+					continue
+				}
 				floatVal, ok := val.(float64)
 				if !ok {
 					return nil, fmt.Errorf("%s %s", errTmpl, schema.SegmenterTypeInteger)
@@ -130,6 +156,14 @@ func (s ExperimentSegmentRaw) ToStorageSchema(segmenterTypes map[string]schema.S
 		case schema.SegmenterTypeReal:
 			strVals := []string{}
 			for _, val := range vals {
+				if rangeVal, ok := val.(map[string]interface{}); ok {
+					strVal, err := formatSegmenterRange(k, rangeVal, _segmenters.SegmenterValueType_REAL)
+					if err != nil {
+						return nil, err
+					}
+					strVals = append(strVals, strVal)
+					continue
+				}
 				floatVal, ok := val.(float64)
 				if !ok {
 					return nil, fmt.Errorf("%s %s", errTmpl, schema.SegmenterTypeReal)
@@ -170,6 +204,14 @@ func (s ExperimentSegment) ToRawSchema(segmentersType map[string]schema.Segmente
 				// Raw Schema refers to JSON and numbers are treated as float64
 				floatVals := []interface{}{}
 				for _, val := range vals {
+					if _utils.IsSegmenterRange(val) {
+						rangeVal, err := _utils.ParseSegmenterRange(val, _segmenters.SegmenterValueType_INTEGER)
+						if err != nil {
+							return nil, fmt.Errorf("%s %s: %s", errTmpl, schema.SegmenterTypeInteger, err)
+						}
+						floatVals = append(floatVals, _utils.SegmenterRangeToMap(rangeVal))
+						continue
+					}
 					_, err := strconv.ParseInt(val, 10, 64)
 					if err != nil {
 						return nil, fmt.Errorf("%s %s", errTmpl, schema.SegmenterTypeInteger)
@@ -181,6 +223,14 @@ func (s ExperimentSegment) ToRawSchema(segmentersType map[string]schema.Segmente
 			case schema.SegmenterTypeReal:
 				floatVals := []interface{}{}
 				for _, val := range vals {
+					if _utils.IsSegmenterRange(val) {
+						rangeVal, err := _utils.ParseSegmenterRange(val, _segmenters.SegmenterValueType_REAL)
+						if err != nil {
+							return nil, fmt.Errorf("%s %s: %s", errTmpl, schema.SegmenterTypeReal, err)
+						}
+						floatVals = append(floatVals, _utils.SegmenterRangeToMap(rangeVal))
+						continue
+					}
 					float64Val, err := strconv.ParseFloat(val, 64)
 					if err != nil {
 						return nil, fmt.Errorf("%s %s", errTmpl, schema.SegmenterTypeReal)
@@ -204,3 +254,59 @@ func (s ExperimentSegment) ToRawSchema(segmentersType map[string]schema.Segmente
 
 	return rawSegments, nil
 }
+
+// formatSegmenterRange validates the range given in the request and formats it in the interval notation
+// for storing in the DB
+func formatSegmenterRange(
+	segmenterName string,
+	value map[string]interface{},
+	valueType _segmenters.SegmenterValueType,
+) (string, error) {
+	rangeVal, err := _utils.NewSegmenterRange(value, valueType)
+	if err != nil {
+		return "", fmt.Errorf("invalid range for segmenter %s: %s", segmenterName, err)
+	}
+	return _utils.FormatSegmenterRange(rangeVal), nil
+}
+
+// parseNumericSegmenterValue parses the DB string value of an integer or real segmenter, which may be a range
+func parseNumericSegmenterValue(val string, segmenterType schema.SegmenterType) (*_segmenters.SegmenterValue, error) {
+	if segmenterType == schema.SegmenterTypeInteger {
+		if _utils.IsSegmenterRange(val) {
+			return _utils.ParseSegmenterRange(val, _segmenters.SegmenterValueType_INTEGER)
+		}
+		intVal, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Integer{Integer: intVal}}, nil
+	}
+	if _utils.IsSegmenterRange(val) {
+		return _utils.ParseSegmenterRange(val, _segmenters.SegmenterValueType_REAL)
+	}
+	floatVal, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Real{Real: floatVal}}, nil
+}
+
+func containsSegmenterRanges(vals []string) bool {
+	for _, val := range vals {
+		if _utils.IsSegmenterRange(val) {
+			return true
+		}
+	}
+	return false
+}
+
+func versionConstraintsToListSegmenterValue(vals []string) *_segmenters.ListSegmenterValue {
+	values := []*_segmenters.SegmenterValue{}
+	for _, val := range vals {
+		segmenterValue, err := _utils.ParseVersionConstraint(val)
+		if err == nil {
+			values = append(values, segmenterValue)
+		}
+	}
+	return &_segmenters.ListSegmenterValue{Values: values}
+}
@@ -118,6 +118,95 @@ func TestSegmentToProtoSchema(t *testing.T) {
 	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
 }
 
+func TestSegmentWithRangesToApiAndProtoSchema(t *testing.T) {
+	segmenterTypes := map[string]schema.SegmenterType{
+		"integer_segmenter": schema.SegmenterTypeInteger,
+		"float_segmenter":   schema.SegmenterTypeReal,
+	}
+	segment := ExperimentSegment{
+		"integer_segmenter": []string{"1", "[18,25)"},
+		"float_segmenter":   []string{"(50.5,)"},
+	}
+
+	assert.Equal(t, schema.ExperimentSegment{
+		"integer_segmenter": []interface{}{
+			int64(1),
+			map[string]interface{}{"min": int64(18), "max": int64(25), "min_exclusive": false, "max_exclusive": true},
+		},
+		"float_segmenter": []interface{}{
+			map[string]interface{}{"min": 50.5, "min_exclusive": true, "max_exclusive": false},
+		},
+	}, segment.ToApiSchema(segmenterTypes))
+
+	minAge, maxAge, minValue := int64(18), int64(25), 50.5
+	protoSchema := map[string]*_segmenters.ListSegmenterValue{
+		"integer_segmenter": {Values: []*_segmenters.SegmenterValue{
+			{Value: &_segmenters.SegmenterValue_Integer{Integer: 1}},
+			{Value: &_segmenters.SegmenterValue_IntegerRange{
+				IntegerRange: &_segmenters.IntegerRange{Min: &minAge, Max: &maxAge, MaxExclusive: true},
+			}},
+		}},
+		"float_segmenter": {Values: []*_segmenters.SegmenterValue{
+			{Value: &_segmenters.SegmenterValue_RealRange{
+				RealRange: &_segmenters.RealRange{Min: &minValue, MinExclusive: true},
+			}},
+		}},
+	}
+	expectedJSON, err := json.Marshal(protoSchema)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(segment.ToProtoSchema(segmenterTypes))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
+
+func TestSegmentWithVersionConstraintsToProtoSchema(t *testing.T) {
+	segmenterTypes := map[string]schema.SegmenterType{
+		"app_version": schema.SegmenterTypeString,
+	}
+	segment := ExperimentSegment{
+		"app_version": []string{">=4.12.0, <5.0.0", "5.1.2"},
+	}
+
+	minVersion, _ := _utils.ParseVersion("4.12.0")
+	maxVersion, _ := _utils.ParseVersion("5.0.0")
+	exactVersion, _ := _utils.ParseVersion("5.1.2")
+	maxVersion--
+	protoSchema := map[string]*_segmenters.ListSegmenterValue{
+		"app_version": {Values: []*_segmenters.SegmenterValue{
+			{Value: &_segmenters.SegmenterValue_IntegerRange{
+				IntegerRange: &_segmenters.IntegerRange{Min: &minVersion, Max: &maxVersion},
+			}},
+			{Value: &_segmenters.SegmenterValue_Integer{Integer: exactVersion}},
+		}},
+	}
+	expectedJSON, err := json.Marshal(protoSchema)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(segment.ToProtoSchema(segmenterTypes))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expectedJSON), string(actualJSON))
+
+	// The constraints are returned as they are stored
+	assert.Equal(t, schema.ExperimentSegment{
+		"app_version": []string{">=4.12.0, <5.0.0", "5.1.2"},
+	}, segment.ToApiSchema(segmenterTypes))
+}
+
+func TestSegmentWithDatesToProtoSchema(t *testing.T) {
+	segmenterTypes := map[string]schema.SegmenterType{
+		"dates": schema.SegmenterTypeString,
+	}
+	segment := ExperimentSegment{
+		"dates": []string{"2024-12-30/2025-01-01", "id_public_holidays", "2024-12-31"},
+	}
+
+	protoSchema := segment.ToProtoSchema(segmenterTypes)
+	values := []string{}
+	for _, val := range protoSchema["dates"].GetValues() {
+		values = append(values, val.GetString_())
+	}
+	assert.Equal(t, []string{"2024-12-30", "2024-12-31", "2025-01-01", "id_public_holidays"}, values)
+}
+
 func TestSegmentToStorageSchema(t *testing.T) {
 	segmentersType := map[string]schema.SegmenterType{
 		"integer_segmenter": schema.SegmenterTypeInteger,
@@ -138,6 +227,7 @@ func TestSegmentToStorageSchema(t *testing.T) {
 	errFloat := "received wrong type of segmenter value; float_segmenter expects type real"
 	errString := "received wrong type of segmenter value; string_segmenter expects type string"
 	errBool := "received wrong type of segmenter value; bool_segmenter expects type bool"
+	errRange := "invalid range for segmenter integer_segmenter: range max of an integer range must be a whole number"
 
 	tests := []struct {
 		name           string
@@ -170,12 +260,35 @@ func TestSegmentToStorageSchema(t *testing.T) {
 			segmentersType: segmentersType,
 			err:            &errBool,
 		},
+		{
+			name:           "invalid range | fractional integer bound",
+			segment:        ExperimentSegmentRaw{"integer_segmenter": []interface{}{map[string]interface{}{"max": 1.5}}},
+			segmentersType: segmentersType,
+			err:            &errRange,
+		},
 		{
 			name:           "success | integer",
 			segment:        ExperimentSegmentRaw{"integer_segmenter": []interface{}{float64(1)}},
 			segmentersType: segmentersType,
 			expected:       experimentIntSegment,
 		},
+		{
+			name: "success | integer range",
+			segment: ExperimentSegmentRaw{"integer_segmenter": []interface{}{
+				float64(1),
+				map[string]interface{}{"min": float64(18), "max": float64(25), "max_exclusive": true},
+			}},
+			segmentersType: segmentersType,
+			expected:       ExperimentSegment{"integer_segmenter": []string{"1", "[18,25)"}},
+		},
+		{
+			name: "success | open-ended float range",
+			segment: ExperimentSegmentRaw{"float_segmenter": []interface{}{
+				map[string]interface{}{"min": 50.5, "min_exclusive": true},
+			}},
+			segmentersType: segmentersType,
+			expected:       ExperimentSegment{"float_segmenter": []string{"(50.5,)"}},
+		},
 		{
 			name:           "success | string",
 			segment:        ExperimentSegmentRaw{"string_segmenter": []interface{}{"1"}},
@@ -265,6 +378,19 @@ func TestSegmentToRawSchema(t *testing.T) {
 			segment:  experimentFloatSegment,
 			expected: ExperimentSegmentRaw{"float_segmenter": []interface{}{float64(1)}},
 		},
+		{
+			name:    "success | integer range",
+			segment: ExperimentSegment{"integer_segmenter": []string{"[18,25)"}},
+			expected: ExperimentSegmentRaw{"integer_segmenter": []interface{}{
+				map[string]interface{}{"min": int64(18), "max": int64(25), "min_exclusive": false, "max_exclusive": true},
+			}},
+		},
+		{
+			name:    "failure | invalid range",
+			segment: ExperimentSegment{"float_segmenter": []string{"[1,"}},
+			errString: "received wrong type of segmenter value; float_segmenter expects type real: " +
+				"invalid range \"[1,\"",
+		},
 		{
 			name:     "success | string",
 			segment:  experimentStringSegment,
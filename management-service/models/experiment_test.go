@@ -44,6 +44,9 @@ var testExperiment = Experiment{
 			Traffic: &testExperimentTraffic,
 		},
 	}),
+	Guardrails: ExperimentGuardrails{
+		{Metric: "error_rate", Comparator: GuardrailComparatorGreaterThan, Threshold: 0.05},
+	},
 	Type:    ExperimentTypeSwitchback,
 	Tier:    ExperimentTierDefault,
 	Version: 2,
@@ -93,6 +96,9 @@ func TestExperimentToApiSchema(t *testing.T) {
 				Traffic: &testExperimentTraffic,
 			},
 		},
+		Guardrails: &[]schema.ExperimentGuardrail{
+			{Metric: "error_rate", Comparator: schema.GuardrailComparatorGreaterThan, Threshold: 0.05},
+		},
 		Segment: &schema.ExperimentSegment{
 			"string_segmenter": []string{"seg-1"},
 		},
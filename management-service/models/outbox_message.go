@@ -0,0 +1,123 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/management-service/database"
+)
+
+type OutboxMessageType string
+type OutboxMessageStatus string
+
+// Defines values for OutboxMessageType.
+const (
+	OutboxMessageTypeExperiment OutboxMessageType = "experiment"
+
+	OutboxMessageTypeProjectSettings OutboxMessageType = "project_settings"
+
+	OutboxMessageTypeProjectSegmenter OutboxMessageType = "project_segmenter"
+)
+
+// Defines values for OutboxMessageStatus.
+const (
+	OutboxMessageStatusPending OutboxMessageStatus = "pending"
+
+	OutboxMessageStatusSent OutboxMessageStatus = "sent"
+
+	OutboxMessageStatusFailed OutboxMessageStatus = "failed"
+)
+
+// TraceContext is the trace context of the request that wrote an outbox message, in the format of the
+// configured propagators, e.g. the W3C traceparent header
+type TraceContext map[string]string
+
+func (tc *TraceContext) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &tc)
+}
+
+func (tc TraceContext) Value() (driver.Value, error) {
+	return json.Marshal(tc)
+}
+
+// OutboxMessage is a message queue update that is written in the same DB transaction as the
+// entity change that produced it, and published asynchronously by the outbox dispatcher.
+type OutboxMessage struct {
+	Model
+
+	// ID is the id of the OutboxMessage record
+	ID ID `json:"id" gorm:"primary_key"`
+
+	// ProjectID is the id of the project that the change belongs to
+	ProjectID ID `json:"project_id"`
+
+	// MessageType is the type of entity that was changed
+	MessageType OutboxMessageType `json:"message_type"`
+
+	// EntityID is the id of the changed entity within the project, i.e. the experiment id or the segmenter
+	// name. It is not set for the project settings, whose entity is the project itself, and for messages
+	// written before it was recorded.
+	EntityID *string `json:"-"`
+
+	// UpdateType is the kind of change that was made to the entity, i.e. create, update or delete
+	UpdateType string `json:"update_type"`
+
+	// Payload is the serialized message, as it will be published to the message queue
+	Payload []byte `json:"-"`
+
+	// Status is the publishing status of the message
+	Status OutboxMessageStatus `json:"status"`
+
+	// Attempts is the number of times publishing the message has failed
+	Attempts int32 `json:"attempts"`
+
+	// LastError is the error returned by the most recent failed attempt
+	LastError *string `json:"last_error"`
+
+	// NextAttemptAt is the earliest time at which the dispatcher will (re)try publishing the message
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+
+	// SentAt is the time at which the message was successfully published
+	SentAt *time.Time `json:"sent_at"`
+
+	// TraceContext is the trace context of the request that wrote the message, if it was traced
+	TraceContext *TraceContext `json:"-"`
+}
+
+// AfterFind sets the retrieved timestamps to be in UTC as opposed to Local.
+func (m *OutboxMessage) AfterFind(tx *gorm.DB) error {
+	m.NextAttemptAt = m.NextAttemptAt.In(database.UtcLoc)
+	if m.SentAt != nil {
+		sentAt := m.SentAt.In(database.UtcLoc)
+		m.SentAt = &sentAt
+	}
+	return nil
+}
+
+// ToApiSchema converts the outbox message DB model to a format compatible with the
+// OpenAPI specifications.
+func (m *OutboxMessage) ToApiSchema() schema.OutboxMessage {
+	return schema.OutboxMessage{
+		Id:            m.ID.ToApiSchema(),
+		ProjectId:     m.ProjectID.ToApiSchema(),
+		MessageType:   schema.OutboxMessageType(m.MessageType),
+		UpdateType:    m.UpdateType,
+		Status:        schema.OutboxMessageStatus(m.Status),
+		Attempts:      m.Attempts,
+		LastError:     m.LastError,
+		NextAttemptAt: m.NextAttemptAt,
+		SentAt:        m.SentAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
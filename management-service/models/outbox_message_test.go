@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+)
+
+func TestOutboxMessageToApiSchema(t *testing.T) {
+	lastError := "test publish error"
+	sentAt := time.Date(2021, 1, 1, 2, 3, 10, 0, time.UTC)
+
+	message := OutboxMessage{
+		Model: Model{
+			CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+			UpdatedAt: time.Date(2021, 1, 1, 2, 3, 10, 0, time.UTC),
+		},
+		ID:            ID(5),
+		ProjectID:     ID(1),
+		MessageType:   OutboxMessageTypeExperiment,
+		UpdateType:    "update",
+		Payload:       []byte("payload"),
+		Status:        OutboxMessageStatusSent,
+		Attempts:      2,
+		LastError:     &lastError,
+		NextAttemptAt: time.Date(2021, 1, 1, 2, 3, 8, 0, time.UTC),
+		SentAt:        &sentAt,
+	}
+
+	assert.Equal(t, schema.OutboxMessage{
+		Id:            int64(5),
+		ProjectId:     int64(1),
+		MessageType:   schema.OutboxMessageTypeExperiment,
+		UpdateType:    "update",
+		Status:        schema.OutboxMessageStatusSent,
+		Attempts:      2,
+		LastError:     &lastError,
+		NextAttemptAt: time.Date(2021, 1, 1, 2, 3, 8, 0, time.UTC),
+		SentAt:        &sentAt,
+		CreatedAt:     time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+		UpdatedAt:     time.Date(2021, 1, 1, 2, 3, 10, 0, time.UTC),
+	}, message.ToApiSchema())
+}
+
+func TestTraceContextValueScan(t *testing.T) {
+	traceContext := TraceContext{
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}
+
+	value, err := traceContext.Value()
+	require.NoError(t, err)
+
+	var scanned TraceContext
+	err = scanned.Scan(value)
+	require.NoError(t, err)
+	assert.Equal(t, traceContext, scanned)
+
+	assert.EqualError(t, scanned.Scan("invalid"), "type assertion to []byte failed")
+}
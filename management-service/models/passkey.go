@@ -0,0 +1,93 @@
+package models
+
+import (
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	_pubsub "github.com/caraml-dev/xp/common/pubsub"
+)
+
+// passkeyFingerprintLength is the number of characters of the hash that identify a passkey in the API
+const passkeyFingerprintLength = 8
+
+// Passkey is a named key used for authentication by the Fetch Treatment API. Only the hash of the key is
+// stored, and a project may have several active keys at a time, so that the keys can be rotated.
+type Passkey struct {
+	Model
+
+	// ID is the id of the Passkey record
+	ID ID `json:"id" gorm:"primary_key"`
+
+	ProjectID ID     `json:"project_id"`
+	Name      string `json:"name"`
+	// Hash is the hex-encoded SHA-256 hash of the passkey
+	Hash string `json:"hash"`
+	// ExpiresAt is the time after which the passkey is no longer accepted, if set
+	ExpiresAt *time.Time `json:"expires_at"`
+
+	// Value is the plaintext passkey, which is only available when the passkey is generated
+	Value string `json:"-" gorm:"-"`
+}
+
+// TableName overrides the default table name derived by gorm
+func (Passkey) TableName() string {
+	return "project_passkeys"
+}
+
+// IsActive returns whether the passkey is accepted at the given time
+func (p *Passkey) IsActive(t time.Time) bool {
+	return p.ExpiresAt == nil || t.Before(*p.ExpiresAt)
+}
+
+// Fingerprint returns the prefix of the hash, which tells the passkeys apart without exposing the hash
+func (p *Passkey) Fingerprint() string {
+	if len(p.Hash) <= passkeyFingerprintLength {
+		return p.Hash
+	}
+	return p.Hash[:passkeyFingerprintLength]
+}
+
+// ToApiSchema converts the passkey DB model to a format compatible with the
+// OpenAPI specifications, with the fingerprint in place of the hash.
+func (p *Passkey) ToApiSchema() schema.ProjectPasskey {
+	return schema.ProjectPasskey{
+		Name:        p.Name,
+		Fingerprint: p.Fingerprint(),
+		ExpiresAt:   p.ExpiresAt,
+		CreatedAt:   p.CreatedAt,
+	}
+}
+
+// ToHashApiSchema converts the passkey DB model to a format compatible with the
+// OpenAPI specifications, with the hash used by the Treatment Service to authenticate requests.
+func (p *Passkey) ToHashApiSchema() schema.ProjectPasskeyHash {
+	return schema.ProjectPasskeyHash{
+		Name:      p.Name,
+		Hash:      p.Hash,
+		ExpiresAt: p.ExpiresAt,
+	}
+}
+
+// ToCreatedApiSchema converts a newly generated passkey to a format compatible with the
+// OpenAPI specifications, with the plaintext value of the key.
+func (p *Passkey) ToCreatedApiSchema() schema.CreatedProjectPasskey {
+	return schema.CreatedProjectPasskey{
+		Name:      p.Name,
+		Passkey:   p.Value,
+		ExpiresAt: p.ExpiresAt,
+		CreatedAt: p.CreatedAt,
+	}
+}
+
+func (p *Passkey) ToProtoSchema() *_pubsub.ProjectPasskey {
+	passkey := &_pubsub.ProjectPasskey{
+		Name: p.Name,
+		Hash: p.Hash,
+	}
+	if p.ExpiresAt != nil {
+		passkey.ExpiresAt = timestamppb.New(*p.ExpiresAt)
+	}
+	return passkey
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	_pubsub "github.com/caraml-dev/xp/common/pubsub"
+)
+
+func TestPasskeyIsActive(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+
+	assert.True(t, (&Passkey{}).IsActive(now))
+	assert.True(t, (&Passkey{ExpiresAt: &expiresAt}).IsActive(now))
+	assert.False(t, (&Passkey{ExpiresAt: &expiresAt}).IsActive(expiresAt))
+	assert.False(t, (&Passkey{ExpiresAt: &expiresAt}).IsActive(now.Add(2*time.Hour)))
+}
+
+func TestPasskeyFingerprint(t *testing.T) {
+	assert.Equal(t, "01234567", (&Passkey{Hash: "0123456789abcdef"}).Fingerprint())
+	assert.Equal(t, "0123", (&Passkey{Hash: "0123"}).Fingerprint())
+}
+
+func TestPasskeyToApiSchema(t *testing.T) {
+	createdAt := time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC)
+	expiresAt := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	passkey := Passkey{
+		Model:     Model{CreatedAt: createdAt, UpdatedAt: createdAt},
+		ID:        ID(1),
+		ProjectID: ID(2),
+		Name:      "rotated",
+		Hash:      "0123456789abcdef",
+		ExpiresAt: &expiresAt,
+		Value:     "passkey",
+	}
+
+	assert.Equal(t, schema.ProjectPasskey{
+		Name:        "rotated",
+		Fingerprint: "01234567",
+		ExpiresAt:   &expiresAt,
+		CreatedAt:   createdAt,
+	}, passkey.ToApiSchema())
+	assert.Equal(t, schema.ProjectPasskeyHash{
+		Name:      "rotated",
+		Hash:      "0123456789abcdef",
+		ExpiresAt: &expiresAt,
+	}, passkey.ToHashApiSchema())
+	assert.Equal(t, schema.CreatedProjectPasskey{
+		Name:      "rotated",
+		Passkey:   "passkey",
+		ExpiresAt: &expiresAt,
+		CreatedAt: createdAt,
+	}, passkey.ToCreatedApiSchema())
+	assert.Equal(t, &_pubsub.ProjectPasskey{
+		Name:      "rotated",
+		Hash:      "0123456789abcdef",
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, passkey.ToProtoSchema())
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+)
+
+// S2Covering holds the GeoJSON geometry from which the s2_ids values of a segment were computed,
+// and the properties of the resulting covering
+type S2Covering struct {
+	Geometry  map[string]interface{} `json:"geometry"`
+	MinLevel  int                    `json:"min_level"`
+	MaxLevel  int                    `json:"max_level"`
+	CellCount int                    `json:"cell_count"`
+	// CoverageError is the area of the covering outside of the geometry, as a fraction of the area of the geometry
+	CoverageError float64 `json:"coverage_error"`
+}
+
+func (c *S2Covering) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &c)
+}
+
+func (c S2Covering) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+// ToApiSchema converts the covering to the OpenAPI schema, returning nil if the segment has no geometry
+func (c *S2Covering) ToApiSchema() *schema.S2Covering {
+	if c == nil {
+		return nil
+	}
+	return &schema.S2Covering{
+		Geometry:      c.Geometry,
+		MinLevel:      int32(c.MinLevel),
+		MaxLevel:      int32(c.MaxLevel),
+		CellCount:     int32(c.CellCount),
+		CoverageError: c.CoverageError,
+	}
+}
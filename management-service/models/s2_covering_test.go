@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+)
+
+var testS2Covering = S2Covering{
+	Geometry: map[string]interface{}{
+		"type": "Polygon",
+		"coordinates": []interface{}{
+			[]interface{}{
+				[]interface{}{103.899, 1.253},
+				[]interface{}{103.901, 1.253},
+				[]interface{}{103.901, 1.255},
+				[]interface{}{103.899, 1.253},
+			},
+		},
+	},
+	MinLevel:      14,
+	MaxLevel:      15,
+	CellCount:     2,
+	CoverageError: 0.5,
+}
+
+func TestS2CoveringValueScan(t *testing.T) {
+	value, err := testS2Covering.Value()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"geometry": {
+			"type": "Polygon",
+			"coordinates": [[[103.899, 1.253], [103.901, 1.253], [103.901, 1.255], [103.899, 1.253]]]
+		},
+		"min_level": 14,
+		"max_level": 15,
+		"cell_count": 2,
+		"coverage_error": 0.5
+	}`, string(value.([]byte)))
+
+	var scanned S2Covering
+	err = scanned.Scan(value)
+	require.NoError(t, err)
+	assert.Equal(t, testS2Covering, scanned)
+
+	assert.EqualError(t, scanned.Scan("invalid"), "type assertion to []byte failed")
+}
+
+func TestS2CoveringToApiSchema(t *testing.T) {
+	assert.Equal(t, &schema.S2Covering{
+		Geometry:      testS2Covering.Geometry,
+		MinLevel:      14,
+		MaxLevel:      15,
+		CellCount:     2,
+		CoverageError: 0.5,
+	}, testS2Covering.ToApiSchema())
+
+	var nilCovering *S2Covering
+	assert.Nil(t, nilCovering.ToApiSchema())
+}
@@ -27,6 +27,8 @@ type Segment struct {
 	Name string `json:"name"`
 
 	Segment ExperimentSegment `json:"segment"`
+	// S2Covering holds the GeoJSON geometry from which the s2_ids of the segment were computed, if any
+	S2Covering *S2Covering `json:"s2_covering"`
 
 	// UpdatedBy holds the details of the last person/job that updated the experiment
 	UpdatedBy string `json:"updated_by"`
@@ -56,12 +58,13 @@ func (s *Segment) ToApiSchema(segmentersType map[string]schema.SegmenterType, fi
 	segmentConfig := s.Segment.ToApiSchema(segmentersType)
 
 	return schema.Segment{
-		Name:      &s.Name,
-		CreatedAt: &s.CreatedAt,
-		UpdatedAt: &s.UpdatedAt,
-		UpdatedBy: &s.UpdatedBy,
-		Id:        &id,
-		Segment:   &segmentConfig,
-		ProjectId: &projectId,
+		Name:       &s.Name,
+		CreatedAt:  &s.CreatedAt,
+		UpdatedAt:  &s.UpdatedAt,
+		UpdatedBy:  &s.UpdatedBy,
+		Id:         &id,
+		Segment:    &segmentConfig,
+		S2Covering: s.S2Covering.ToApiSchema(),
+		ProjectId:  &projectId,
 	}
 }
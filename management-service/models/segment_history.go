@@ -19,9 +19,10 @@ type SegmentHistory struct {
 	Version int64 `json:"version"`
 
 	// The following values are copied from the segment record at the time of versioning
-	Name      string            `json:"name"`
-	Segment   ExperimentSegment `json:"segment"`
-	UpdatedBy string            `json:"updated_by"`
+	Name       string            `json:"name"`
+	Segment    ExperimentSegment `json:"segment"`
+	S2Covering *S2Covering       `json:"s2_covering"`
+	UpdatedBy  string            `json:"updated_by"`
 }
 
 // TableName overrides Gorm's default pluralised name: "segment_histories"
@@ -34,13 +35,14 @@ func (SegmentHistory) TableName() string {
 func (s *SegmentHistory) ToApiSchema(segmentersType map[string]schema.SegmenterType) schema.SegmentHistory {
 
 	return schema.SegmentHistory{
-		Id:        s.ID.ToApiSchema(),
-		Name:      s.Name,
-		SegmentId: s.SegmentID.ToApiSchema(),
-		Segment:   s.Segment.ToApiSchema(segmentersType),
-		CreatedAt: s.CreatedAt,
-		UpdatedAt: s.UpdatedAt,
-		UpdatedBy: s.UpdatedBy,
-		Version:   s.Version,
+		Id:         s.ID.ToApiSchema(),
+		Name:       s.Name,
+		SegmentId:  s.SegmentID.ToApiSchema(),
+		Segment:    s.Segment.ToApiSchema(segmentersType),
+		S2Covering: s.S2Covering.ToApiSchema(),
+		CreatedAt:  s.CreatedAt,
+		UpdatedAt:  s.UpdatedAt,
+		UpdatedBy:  s.UpdatedBy,
+		Version:    s.Version,
 	}
 }
@@ -46,8 +46,12 @@ type Settings struct {
 
 	// Username is used for authentication by the Fetch Treatment API
 	Username string `json:"username"`
-	// Passkey is in plaintext and is used for authentication by the Fetch Treatment API
-	Passkey string `json:"passkey"`
+	// Passkeys are used for authentication by the Fetch Treatment API
+	Passkeys []Passkey `json:"passkeys" gorm:"foreignKey:ProjectID;references:ProjectID"`
+	// LegacyPasskey is the plaintext passkey of the project from before the passkeys were hashed. It is only
+	// published for the Treatment Services of the previous release, until the default passkey is revoked.
+	// The column is dropped in the next release, see docs/infra/treatment-service.md.
+	LegacyPasskey string `json:"-" gorm:"column:passkey"`
 	// Config holds the project-wide experimentation configs, as configured by the user
 	Config *ExperimentationConfig `json:"config"`
 	// TreatmentSchema holds the rules that define the treatment schema
@@ -97,11 +101,21 @@ func (ts *TreatmentSchema) ToOpenApi() *schema.TreatmentSchema {
 // ToApiSchema converts the settings DB model to a format compatible with the
 // OpenAPI specifications.
 func (c *Settings) ToApiSchema() schema.ProjectSettings {
+	var passkey *string
+	passkeys := []schema.ProjectPasskey{}
+	for _, p := range c.Passkeys {
+		// The plaintext passkey is only available when it has just been generated
+		if p.Value != "" {
+			passkey = &p.Value
+		}
+		passkeys = append(passkeys, p.ToApiSchema())
+	}
 
 	user := schema.ProjectSettings{
 		CreatedAt:            c.CreatedAt,
 		EnableS2idClustering: c.Config.S2IDClusteringEnabled,
-		Passkey:              c.Passkey,
+		Passkey:              passkey,
+		Passkeys:             &passkeys,
 		ProjectId:            c.ProjectID.ToApiSchema(),
 		RandomizationKey:     c.Config.RandomizationKey,
 		Segmenters: schema.ProjectSegmenters{
@@ -132,14 +146,20 @@ func (c *Settings) ToProtoSchema() _pubsub.ProjectSettings {
 		Variables: segmentersVariables,
 	}
 
+	passkeys := []*_pubsub.ProjectPasskey{}
+	for _, p := range c.Passkeys {
+		passkeys = append(passkeys, p.ToProtoSchema())
+	}
+
 	return _pubsub.ProjectSettings{
 		ProjectId:            c.ProjectID.ToApiSchema(),
 		CreatedAt:            timestamppb.New(c.CreatedAt),
 		UpdatedAt:            timestamppb.New(c.UpdatedAt),
 		Username:             c.Username,
-		Passkey:              c.Passkey,
+		Passkey:              c.LegacyPasskey,
 		EnableS2IdClustering: c.Config.S2IDClusteringEnabled,
 		Segmenters:           &projectSegmenters,
 		RandomizationKey:     c.Config.RandomizationKey,
+		Passkeys:             passkeys,
 	}
 }
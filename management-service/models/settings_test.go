@@ -94,6 +94,8 @@ func TestExperimentationConfigScan(t *testing.T) {
 }
 
 func TestSettingsToApiSchema(t *testing.T) {
+	passkey1 := "passkey-1"
+	expiresAt := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
 	tests := []struct {
 		Name     string
 		Settings Settings
@@ -108,7 +110,15 @@ func TestSettingsToApiSchema(t *testing.T) {
 				},
 				ProjectID: ID(1),
 				Username:  "client-1",
-				Passkey:   "passkey-1",
+				Passkeys: []Passkey{
+					{
+						Model:     Model{CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC)},
+						Name:      "default",
+						Hash:      "hash-1",
+						Value:     "passkey-1",
+						ProjectID: ID(1),
+					},
+				},
 				Config: &ExperimentationConfig{
 					Segmenters: ProjectSegmenters{
 						Names: []string{"seg3", "seg4"},
@@ -125,7 +135,14 @@ func TestSettingsToApiSchema(t *testing.T) {
 				UpdatedAt: time.Date(2021, 1, 2, 3, 3, 3, 0, time.UTC),
 				ProjectId: 1,
 				Username:  "client-1",
-				Passkey:   "passkey-1",
+				Passkey:   &passkey1,
+				Passkeys: &[]schema.ProjectPasskey{
+					{
+						Name:        "default",
+						Fingerprint: "hash-1",
+						CreatedAt:   time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+					},
+				},
 				Segmenters: schema.ProjectSegmenters{
 					Names: []string{"seg3", "seg4"},
 					Variables: schema.ProjectSegmenters_Variables{
@@ -147,7 +164,21 @@ func TestSettingsToApiSchema(t *testing.T) {
 				},
 				ProjectID: ID(2),
 				Username:  "client-2",
-				Passkey:   "passkey-2",
+				Passkeys: []Passkey{
+					{
+						Model:     Model{CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC)},
+						Name:      "default",
+						Hash:      "hash-2",
+						ProjectID: ID(2),
+					},
+					{
+						Model:     Model{CreatedAt: time.Date(2021, 1, 3, 2, 3, 4, 0, time.UTC)},
+						Name:      "rotated",
+						Hash:      "hash-3",
+						ExpiresAt: &expiresAt,
+						ProjectID: ID(2),
+					},
+				},
 				Config: &ExperimentationConfig{
 					Segmenters: ProjectSegmenters{
 						Names: []string{"seg5", "seg6"},
@@ -165,7 +196,19 @@ func TestSettingsToApiSchema(t *testing.T) {
 				UpdatedAt: time.Date(2021, 1, 2, 3, 3, 3, 0, time.UTC),
 				ProjectId: 2,
 				Username:  "client-2",
-				Passkey:   "passkey-2",
+				Passkeys: &[]schema.ProjectPasskey{
+					{
+						Name:        "default",
+						Fingerprint: "hash-2",
+						CreatedAt:   time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+					},
+					{
+						Name:        "rotated",
+						Fingerprint: "hash-3",
+						ExpiresAt:   &expiresAt,
+						CreatedAt:   time.Date(2021, 1, 3, 2, 3, 4, 0, time.UTC),
+					},
+				},
 				Segmenters: schema.ProjectSegmenters{
 					Names: []string{"seg5", "seg6"},
 					Variables: schema.ProjectSegmenters_Variables{
@@ -187,7 +230,6 @@ func TestSettingsToApiSchema(t *testing.T) {
 				},
 				ProjectID: ID(3),
 				Username:  "client-3",
-				Passkey:   "passkey-3",
 				Config: &ExperimentationConfig{
 					Segmenters: ProjectSegmenters{
 						Names: []string{"seg5", "seg6"},
@@ -218,7 +260,7 @@ func TestSettingsToApiSchema(t *testing.T) {
 				UpdatedAt: time.Date(2021, 1, 2, 3, 3, 3, 0, time.UTC),
 				ProjectId: 3,
 				Username:  "client-3",
-				Passkey:   "passkey-3",
+				Passkeys:  &[]schema.ProjectPasskey{},
 				Segmenters: schema.ProjectSegmenters{
 					Names: []string{"seg5", "seg6"},
 					Variables: schema.ProjectSegmenters_Variables{
@@ -259,7 +301,7 @@ func TestSettingsToProtoSchema(t *testing.T) {
 	projectId := int64(1)
 	randomizationKey := "random"
 	username := "user1"
-	passkey := "pass1"
+	expiresAt := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
 	testSettings := Settings{
 		Model: Model{
 			CreatedAt: createdUpdatedAt,
@@ -267,7 +309,11 @@ func TestSettingsToProtoSchema(t *testing.T) {
 		},
 		ProjectID: ID(projectId),
 		Username:  username,
-		Passkey:   passkey,
+		Passkeys: []Passkey{
+			{Name: "default", Hash: "hash-1"},
+			{Name: "rotated", Hash: "hash-2", ExpiresAt: &expiresAt},
+		},
+		LegacyPasskey: "passkey-1",
 		Config: &ExperimentationConfig{
 			Segmenters: ProjectSegmenters{
 				Names: []string{"seg1"},
@@ -297,6 +343,10 @@ func TestSettingsToProtoSchema(t *testing.T) {
 		Segmenters:       &pubSubSegmenters,
 		UpdatedAt:        timestamppb.New(createdUpdatedAt),
 		Username:         username,
-		Passkey:          passkey,
+		Passkey:          "passkey-1",
+		Passkeys: []*_pubsub.ProjectPasskey{
+			{Name: "default", Hash: "hash-1"},
+			{Name: "rotated", Hash: "hash-2", ExpiresAt: timestamppb.New(expiresAt)},
+		},
 	}, testSettings.ToProtoSchema())
 }
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+)
+
+// SRMTreatmentCount is the no. of units assigned to a treatment, against the no. expected from its traffic
+type SRMTreatmentCount struct {
+	Treatment     string  `json:"treatment"`
+	Traffic       int32   `json:"traffic"`
+	Count         int64   `json:"count"`
+	ExpectedCount float64 `json:"expected_count"`
+}
+
+type SRMTreatmentCounts []SRMTreatmentCount
+
+func (c *SRMTreatmentCounts) Scan(value interface{}) error {
+	b, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(b, &c)
+}
+
+func (c SRMTreatmentCounts) Value() (driver.Value, error) {
+	return json.Marshal(c)
+}
+
+func (c SRMTreatmentCounts) ToApiSchema() []schema.SRMTreatmentCount {
+	counts := []schema.SRMTreatmentCount{}
+	for _, count := range c {
+		counts = append(counts, schema.SRMTreatmentCount{
+			Treatment:     count.Treatment,
+			Traffic:       count.Traffic,
+			Count:         count.Count,
+			ExpectedCount: count.ExpectedCount,
+		})
+	}
+	return counts
+}
+
+// SRMCheck is the latest sample ratio mismatch check of a version of the experiment, i.e., a chi-squared test
+// of the assignment counts against the configured traffic of the treatments.
+type SRMCheck struct {
+	Model
+
+	// ID is the id of the SRMCheck record
+	ID ID `json:"id" gorm:"primary_key"`
+
+	ExperimentID      ID    `json:"experiment_id"`
+	ExperimentVersion int64 `json:"experiment_version"`
+
+	Treatments SRMTreatmentCounts `json:"treatments"`
+	ChiSquared float64            `json:"chi_squared"`
+	PValue     float64            `json:"p_value"`
+	// Mismatch is set when the p-value is below the configured threshold
+	Mismatch bool `json:"mismatch"`
+}
+
+// TableName overrides the default table name derived by gorm
+func (SRMCheck) TableName() string {
+	return "experiment_srm_checks"
+}
+
+// ToApiSchema converts the SRM check DB model to a format compatible with the
+// OpenAPI specifications.
+func (c *SRMCheck) ToApiSchema() schema.SRMCheck {
+	return schema.SRMCheck{
+		Id:                c.ID.ToApiSchema(),
+		ExperimentId:      c.ExperimentID.ToApiSchema(),
+		ExperimentVersion: c.ExperimentVersion,
+		Treatments:        c.Treatments.ToApiSchema(),
+		ChiSquared:        c.ChiSquared,
+		PValue:            c.PValue,
+		Mismatch:          c.Mismatch,
+		CreatedAt:         c.CreatedAt,
+		UpdatedAt:         c.UpdatedAt,
+	}
+}
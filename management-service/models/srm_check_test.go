@@ -0,0 +1,62 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+)
+
+func TestSRMTreatmentCountsValueScan(t *testing.T) {
+	counts := SRMTreatmentCounts{
+		{Treatment: "control", Traffic: 50, Count: 520, ExpectedCount: 500},
+		{Treatment: "treatment", Traffic: 50, Count: 480, ExpectedCount: 500},
+	}
+
+	value, err := counts.Value()
+	require.NoError(t, err)
+
+	var scanned SRMTreatmentCounts
+	err = scanned.Scan(value)
+	require.NoError(t, err)
+	assert.Equal(t, counts, scanned)
+
+	assert.EqualError(t, scanned.Scan("invalid"), "type assertion to []byte failed")
+}
+
+func TestSRMCheckToApiSchema(t *testing.T) {
+	check := SRMCheck{
+		Model: Model{
+			CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+			UpdatedAt: time.Date(2021, 1, 1, 2, 3, 10, 0, time.UTC),
+		},
+		ID:                ID(3),
+		ExperimentID:      ID(2),
+		ExperimentVersion: 4,
+		Treatments: SRMTreatmentCounts{
+			{Treatment: "control", Traffic: 20, Count: 300, ExpectedCount: 200},
+			{Treatment: "treatment", Traffic: 80, Count: 700, ExpectedCount: 800},
+		},
+		ChiSquared: 62.5,
+		PValue:     2.7e-15,
+		Mismatch:   true,
+	}
+
+	assert.Equal(t, schema.SRMCheck{
+		Id:                int64(3),
+		ExperimentId:      int64(2),
+		ExperimentVersion: int64(4),
+		Treatments: []schema.SRMTreatmentCount{
+			{Treatment: "control", Traffic: 20, Count: 300, ExpectedCount: 200},
+			{Treatment: "treatment", Traffic: 80, Count: 700, ExpectedCount: 800},
+		},
+		ChiSquared: 62.5,
+		PValue:     2.7e-15,
+		Mismatch:   true,
+		CreatedAt:  time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+		UpdatedAt:  time.Date(2021, 1, 1, 2, 3, 10, 0, time.UTC),
+	}, check.ToApiSchema())
+}
@@ -0,0 +1,60 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
+)
+
+func NewAppVersionSegmenter(_ json.RawMessage) (Segmenter, error) {
+	var appVersionConfig = &_segmenters.SegmenterConfiguration{
+		Name:        _utils.AppVersionSegmenter,
+		Type:        _segmenters.SegmenterValueType_STRING,
+		Options:     map[string]*_segmenters.SegmenterValue{},
+		MultiValued: true,
+		TreatmentRequestFields: &_segmenters.ListExperimentVariables{
+			Values: []*_segmenters.ExperimentVariables{
+				{
+					Value: []string{"app_version"},
+				},
+			},
+		},
+		Required: false,
+		Description: "Semantic version constraints, eg. \">=4.12.0, <5.0.0\", \"4.12.x\" or \"^4.12.3\". " +
+			"The pre-release and build metadata of the app versions are ignored.",
+	}
+
+	return &appVersion{NewBaseSegmenter(appVersionConfig)}, nil
+}
+
+type appVersion struct {
+	Segmenter
+}
+
+func (s *appVersion) ValidateSegmenterAndConstraints(segment map[string]*_segmenters.ListSegmenterValue) error {
+	err := s.Segmenter.ValidateSegmenterAndConstraints(segment)
+	if err != nil {
+		return err
+	}
+	name := s.GetName()
+
+	// Additional check to see that the values are valid version constraints
+	listInputValues := segment[name]
+	for _, val := range listInputValues.GetValues() {
+		if _, err := _utils.ParseVersionConstraint(val.GetString_()); err != nil {
+			return fmt.Errorf("Segmenter %s has an invalid value: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	err := Register(_utils.AppVersionSegmenter, NewAppVersionSegmenter)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,64 @@
+package segmenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+func TestAppVersionValidateSegmenterAndConstraints(t *testing.T) {
+	appVersionSegmenter, _ := NewAppVersionSegmenter(nil)
+	tests := map[string]struct {
+		values    map[string]*_segmenters.ListSegmenterValue
+		errString string
+	}{
+		"success | empty map": {
+			values: map[string]*_segmenters.ListSegmenterValue{},
+		},
+		"success | valid constraints": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"app_version": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_String_{String_: ">=4.12.0, <5.0.0"}},
+						{Value: &_segmenters.SegmenterValue_String_{String_: "5.1.x"}},
+					},
+				},
+			},
+		},
+		"failure | invalid value type": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"app_version": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_Integer{Integer: 4}},
+					},
+				},
+			},
+			errString: "Segmenter app_version has one or more values that do not match the configured type",
+		},
+		"failure | invalid constraint": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"app_version": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_String_{String_: ">=4.12.0"}},
+						{Value: &_segmenters.SegmenterValue_String_{String_: ">=5.0.0, <4.0.0"}},
+					},
+				},
+			},
+			errString: "Segmenter app_version has an invalid value: " +
+				"version constraint \">=5.0.0, <4.0.0\" matches no versions",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := appVersionSegmenter.ValidateSegmenterAndConstraints(data.values)
+			if data.errString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
@@ -48,11 +48,15 @@ func (s *BaseSegmenter) IsValidType(inputValues []*_segmenters.SegmenterValue) b
 				return false
 			}
 		case _segmenters.SegmenterValueType_INTEGER:
-			if _, ok := val.GetValue().(*_segmenters.SegmenterValue_Integer); !ok {
+			switch val.GetValue().(type) {
+			case *_segmenters.SegmenterValue_Integer, *_segmenters.SegmenterValue_IntegerRange:
+			default:
 				return false
 			}
 		case _segmenters.SegmenterValueType_REAL:
-			if _, ok := val.GetValue().(*_segmenters.SegmenterValue_Real); !ok {
+			switch val.GetValue().(type) {
+			case *_segmenters.SegmenterValue_Real, *_segmenters.SegmenterValue_RealRange:
+			default:
 				return false
 			}
 		}
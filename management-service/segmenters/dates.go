@@ -0,0 +1,110 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
+)
+
+type DatesSegmenterConfig struct {
+	// CalendarFiles are the paths to the YAML or JSON files of the named calendars, each of which maps the names
+	// of calendars to the lists of their dates
+	CalendarFiles []string `json:"calendarfiles"`
+}
+
+// DatesExpander is implemented by the dates segmenter, whose values can be expanded into the dates they contain
+type DatesExpander interface {
+	ExpandDates(values []string) []string
+}
+
+func NewDatesSegmenter(configData json.RawMessage) (Segmenter, error) {
+	segmenterErrTpl := "failed to create segmenter (dates): %s"
+	var config DatesSegmenterConfig
+
+	// The segmenter has no calendars if it is not configured
+	if len(configData) > 0 {
+		err := json.Unmarshal(configData, &config)
+		if err != nil {
+			return nil, fmt.Errorf(segmenterErrTpl, err)
+		}
+	}
+	calendars, err := _utils.LoadCalendars(config.CalendarFiles)
+	if err != nil {
+		return nil, fmt.Errorf(segmenterErrTpl, err)
+	}
+
+	var datesConfig = &_segmenters.SegmenterConfiguration{
+		Name:        _utils.DatesSegmenter,
+		Type:        _segmenters.SegmenterValueType_STRING,
+		Options:     map[string]*_segmenters.SegmenterValue{},
+		MultiValued: true,
+		TreatmentRequestFields: &_segmenters.ListExperimentVariables{
+			Values: []*_segmenters.ExperimentVariables{
+				{
+					Value: []string{"tz"},
+				},
+				{
+					Value: []string{"timestamp", "tz"},
+				},
+			},
+		},
+		Required: false,
+		Description: "Calendar dates in the user's timezone, eg. \"2024-12-25\", inclusive date ranges, eg. " +
+			"\"2024-12-20/2024-12-31\", or the names of the configured calendars, eg. public holidays.",
+	}
+
+	return &dates{NewBaseSegmenter(datesConfig), calendars}, nil
+}
+
+type dates struct {
+	Segmenter
+	calendars _utils.Calendars
+}
+
+func (s *dates) ValidateSegmenterAndConstraints(segment map[string]*_segmenters.ListSegmenterValue) error {
+	err := s.Segmenter.ValidateSegmenterAndConstraints(segment)
+	if err != nil {
+		return err
+	}
+	name := s.GetName()
+
+	// Additional check to see that the values are valid dates, date ranges or calendars
+	listInputValues := segment[name]
+	for _, val := range listInputValues.GetValues() {
+		value := val.GetString_()
+		if _utils.IsCalendarName(value) {
+			if _, ok := s.calendars[value]; !ok {
+				return fmt.Errorf("Segmenter %s has an invalid value: unknown calendar %s", name, value)
+			}
+			continue
+		}
+		if _, err := _utils.ExpandDateValue(value); err != nil {
+			return fmt.Errorf("Segmenter %s has an invalid value: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ExpandDates returns the dates of the dates, date ranges and calendars, de-duplicated and in sorted order
+func (s *dates) ExpandDates(values []string) []string {
+	expandedValues := []string{}
+	for _, value := range values {
+		if calendarDates, ok := s.calendars[value]; ok {
+			expandedValues = append(expandedValues, calendarDates...)
+			continue
+		}
+		expandedValues = append(expandedValues, value)
+	}
+	return _utils.ExpandDateValues(expandedValues)
+}
+
+func init() {
+	err := Register(_utils.DatesSegmenter, NewDatesSegmenter)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,87 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+func newTestDatesSegmenter(t *testing.T) Segmenter {
+	path := filepath.Join(t.TempDir(), "calendars.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("id_public_holidays:\n  - 2024-12-25\n  - 2025-01-01\n"), 0644))
+	configData, err := json.Marshal(DatesSegmenterConfig{CalendarFiles: []string{path}})
+	require.NoError(t, err)
+
+	datesSegmenter, err := NewDatesSegmenter(configData)
+	require.NoError(t, err)
+	return datesSegmenter
+}
+
+func TestNewDatesSegmenter(t *testing.T) {
+	_, err := NewDatesSegmenter(nil)
+	assert.NoError(t, err)
+
+	_, err = NewDatesSegmenter(json.RawMessage(`{"calendarfiles": ["missing.yaml"]}`))
+	assert.EqualError(t, err, "failed to create segmenter (dates): failed to read calendar file missing.yaml: "+
+		"open missing.yaml: no such file or directory")
+}
+
+func TestDatesValidateSegmenterAndConstraints(t *testing.T) {
+	datesSegmenter := newTestDatesSegmenter(t)
+	stringValues := func(values ...string) map[string]*_segmenters.ListSegmenterValue {
+		segmenterValues := []*_segmenters.SegmenterValue{}
+		for _, value := range values {
+			segmenterValues = append(segmenterValues, &_segmenters.SegmenterValue{
+				Value: &_segmenters.SegmenterValue_String_{String_: value},
+			})
+		}
+		return map[string]*_segmenters.ListSegmenterValue{"dates": {Values: segmenterValues}}
+	}
+
+	tests := map[string]struct {
+		values    map[string]*_segmenters.ListSegmenterValue
+		errString string
+	}{
+		"success | empty map": {
+			values: map[string]*_segmenters.ListSegmenterValue{},
+		},
+		"success | dates, date ranges and calendars": {
+			values: stringValues("2024-11-11", "2024-12-20/2024-12-31", "id_public_holidays"),
+		},
+		"failure | invalid date": {
+			values:    stringValues("2024-02-30"),
+			errString: "Segmenter dates has an invalid value: invalid date \"2024-02-30\"",
+		},
+		"failure | unknown calendar": {
+			values:    stringValues("sg_public_holidays"),
+			errString: "Segmenter dates has an invalid value: unknown calendar sg_public_holidays",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := datesSegmenter.ValidateSegmenterAndConstraints(data.values)
+			if data.errString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
+
+func TestDatesExpandDates(t *testing.T) {
+	expander, ok := newTestDatesSegmenter(t).(DatesExpander)
+	require.True(t, ok)
+
+	assert.Equal(t,
+		[]string{"2024-12-24", "2024-12-25", "2024-12-26", "2025-01-01"},
+		expander.ExpandDates([]string{"2024-12-24/2024-12-26", "id_public_holidays"}),
+	)
+}
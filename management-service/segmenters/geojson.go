@@ -0,0 +1,124 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/golang/geo/s2"
+)
+
+// geoJSONObject captures the GeoJSON objects (RFC 7946) that can describe an area: Polygon and MultiPolygon
+// geometries, and the Features and FeatureCollections containing them
+type geoJSONObject struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	Geometry    *geoJSONObject  `json:"geometry"`
+	Features    []geoJSONObject `json:"features"`
+}
+
+// NewPolygonFromGeoJSON parses the GeoJSON object into an S2 polygon, combining all the polygons that it contains
+func NewPolygonFromGeoJSON(geometry map[string]interface{}) (*s2.Polygon, error) {
+	data, err := json.Marshal(geometry)
+	if err != nil {
+		return nil, err
+	}
+	var object geoJSONObject
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %s", err)
+	}
+
+	loops, err := object.loops()
+	if err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %s", err)
+	}
+	if len(loops) == 0 {
+		return nil, fmt.Errorf("invalid GeoJSON: no polygons found")
+	}
+	polygon := s2.PolygonFromLoops(loops)
+	if err := polygon.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON: %s", err)
+	}
+	return polygon, nil
+}
+
+func (o *geoJSONObject) loops() ([]*s2.Loop, error) {
+	switch o.Type {
+	case "Polygon":
+		var rings [][][]float64
+		if err := json.Unmarshal(o.Coordinates, &rings); err != nil {
+			return nil, fmt.Errorf("invalid Polygon coordinates: %s", err)
+		}
+		return ringsToLoops(rings)
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(o.Coordinates, &polygons); err != nil {
+			return nil, fmt.Errorf("invalid MultiPolygon coordinates: %s", err)
+		}
+		loops := []*s2.Loop{}
+		for _, rings := range polygons {
+			polygonLoops, err := ringsToLoops(rings)
+			if err != nil {
+				return nil, err
+			}
+			loops = append(loops, polygonLoops...)
+		}
+		return loops, nil
+	case "Feature":
+		if o.Geometry == nil {
+			return nil, fmt.Errorf("Feature has no geometry")
+		}
+		return o.Geometry.loops()
+	case "FeatureCollection":
+		loops := []*s2.Loop{}
+		for _, feature := range o.Features {
+			featureLoops, err := feature.loops()
+			if err != nil {
+				return nil, err
+			}
+			loops = append(loops, featureLoops...)
+		}
+		return loops, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %q, only Polygon and MultiPolygon areas are supported", o.Type)
+	}
+}
+
+// ringsToLoops converts the linear rings of a GeoJSON polygon to S2 loops. The first ring is the exterior
+// and the others are holes, which are identified by their nesting, so the orientation of the rings is ignored.
+func ringsToLoops(rings [][][]float64) ([]*s2.Loop, error) {
+	loops := []*s2.Loop{}
+	for _, ring := range rings {
+		if len(ring) < 4 {
+			return nil, fmt.Errorf("a linear ring must have at least 4 positions")
+		}
+		if !positionsEqual(ring[0], ring[len(ring)-1]) {
+			return nil, fmt.Errorf("a linear ring must be closed")
+		}
+
+		points := []s2.Point{}
+		// The last position repeats the first
+		for _, position := range ring[:len(ring)-1] {
+			if len(position) < 2 {
+				return nil, fmt.Errorf("a position must have a longitude and a latitude")
+			}
+			lng, lat := position[0], position[1]
+			if lng < -180 || lng > 180 || lat < -90 || lat > 90 {
+				return nil, fmt.Errorf("position [%v, %v] is out of range", lng, lat)
+			}
+			points = append(points, s2.PointFromLatLng(s2.LatLngFromDegrees(lat, lng)))
+		}
+
+		loop := s2.LoopFromPoints(points)
+		// Use the smaller of the two regions bounded by the ring
+		loop.Normalize()
+		loops = append(loops, loop)
+	}
+	return loops, nil
+}
+
+func positionsEqual(a []float64, b []float64) bool {
+	if len(a) < 2 || len(b) < 2 {
+		return false
+	}
+	return a[0] == b[0] && a[1] == b[1]
+}
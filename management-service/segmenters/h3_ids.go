@@ -0,0 +1,156 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/uber/h3-go/v4"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+const (
+	// MinH3Resolution is the permissible minimum value for H3 cell resolution
+	MinH3Resolution = 0
+	// MaxH3Resolution is the permissible maximum value for H3 cell resolution
+	MaxH3Resolution = h3.MaxResolution
+)
+
+type H3IDSegmenterConfig struct {
+	MinH3Resolution int `json:"minh3resolution"`
+	MaxH3Resolution int `json:"maxh3resolution"`
+}
+
+// H3Indexer is implemented by the h3_ids segmenter, whose values are the indexes of H3 cells
+type H3Indexer interface {
+	CellsIntersect(cellIds []string, otherCellIds []string) bool
+}
+
+func NewH3IDSegmenter(configData json.RawMessage) (Segmenter, error) {
+	segmenterErrTpl := "failed to create segmenter (h3_ids): %s"
+	config := H3IDSegmenterConfig{MinH3Resolution: MinH3Resolution, MaxH3Resolution: MaxH3Resolution}
+
+	// All resolutions are allowed if the segmenter is not configured
+	if len(configData) > 0 {
+		err := json.Unmarshal(configData, &config)
+		if err != nil {
+			return nil, fmt.Errorf(segmenterErrTpl, err)
+		}
+	}
+
+	// Verify configured resolutions
+	if config.MinH3Resolution < MinH3Resolution ||
+		config.MinH3Resolution > MaxH3Resolution ||
+		config.MaxH3Resolution < MinH3Resolution ||
+		config.MaxH3Resolution > MaxH3Resolution {
+		return nil, fmt.Errorf(segmenterErrTpl,
+			fmt.Sprintf("H3 cell resolutions should be in the range %d - %d", MinH3Resolution, MaxH3Resolution))
+	}
+	if config.MinH3Resolution > config.MaxH3Resolution {
+		return nil, fmt.Errorf(segmenterErrTpl, "Min H3 cell resolution cannot be greater than max")
+	}
+
+	h3IDConfig := &_segmenters.SegmenterConfiguration{
+		Name:        "h3_ids",
+		Type:        _segmenters.SegmenterValueType_STRING,
+		Options:     map[string]*_segmenters.SegmenterValue{},
+		MultiValued: true,
+		TreatmentRequestFields: &_segmenters.ListExperimentVariables{
+			Values: []*_segmenters.ExperimentVariables{
+				{
+					Value: []string{"h3id"},
+				},
+				{
+					Value: []string{"latitude", "longitude"},
+				},
+			},
+		},
+		Required: false,
+		Description: fmt.Sprintf("H3 cell indexes, eg. \"8928308280fffff\", between resolutions %d and %d are supported.",
+			config.MinH3Resolution, config.MaxH3Resolution),
+	}
+
+	resolutions := []int{}
+	for i := config.MinH3Resolution; i <= config.MaxH3Resolution; i++ {
+		resolutions = append(resolutions, i)
+	}
+
+	return &h3ids{
+		Segmenter:          NewBaseSegmenter(h3IDConfig),
+		AllowedResolutions: resolutions,
+	}, nil
+}
+
+type h3ids struct {
+	Segmenter
+	AllowedResolutions []int
+}
+
+func (s *h3ids) ValidateSegmenterAndConstraints(segment map[string]*_segmenters.ListSegmenterValue) error {
+	err := s.Segmenter.ValidateSegmenterAndConstraints(segment)
+	if err != nil {
+		return err
+	}
+	name := s.GetName()
+
+	// Additional check to see that the values are the indexes of valid cells, in the same format as the
+	// Treatment Service computes them
+	listInputValues := segment[name]
+	for _, val := range listInputValues.GetValues() {
+		cellID := val.GetString_()
+		cell := h3.Cell(h3.IndexFromString(cellID))
+		if !cell.IsValid() || cell.String() != cellID {
+			return fmt.Errorf("One or more %s values is invalid", name)
+		}
+		cellResolution := cell.Resolution()
+		if !s.isValidResolution(cellResolution) {
+			return fmt.Errorf("One or more %s values is at resolution %d, only the following resolutions are allowed: %v",
+				name, cellResolution, s.AllowedResolutions)
+		}
+	}
+
+	return nil
+}
+
+// CellsIntersect checks if any of the cells is the same as, or an ancestor or a descendant of, any of the other
+// cells, since the Treatment Service matches a request with the cell containing it and all of the cell's ancestors
+func (s *h3ids) CellsIntersect(cellIds []string, otherCellIds []string) bool {
+	for _, cellId := range cellIds {
+		cell := h3.Cell(h3.IndexFromString(cellId))
+		if !cell.IsValid() {
+			// Invalid cells contain no location, so they cannot intersect any other cell
+			continue
+		}
+		for _, otherCellId := range otherCellIds {
+			otherCell := h3.Cell(h3.IndexFromString(otherCellId))
+			if !otherCell.IsValid() {
+				continue
+			}
+			if cell.Resolution() <= otherCell.Resolution() {
+				if otherCell.Parent(cell.Resolution()) == cell {
+					return true
+				}
+			} else if cell.Parent(otherCell.Resolution()) == otherCell {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *h3ids) isValidResolution(resolution int) bool {
+	for _, val := range s.AllowedResolutions {
+		if val == resolution {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	err := Register("h3_ids", NewH3IDSegmenter)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,127 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+func TestNewH3IDSegmenter(t *testing.T) {
+	tests := map[string]struct {
+		configData  string
+		description string
+		errString   string
+	}{
+		"success | all resolutions": {
+			description: "H3 cell indexes, eg. \"8928308280fffff\", between resolutions 0 and 15 are supported.",
+		},
+		"success | configured resolutions": {
+			configData:  `{"minh3resolution": 7, "maxh3resolution": 9}`,
+			description: "H3 cell indexes, eg. \"8928308280fffff\", between resolutions 7 and 9 are supported.",
+		},
+		"failure | invalid resolution": {
+			configData: `{"minh3resolution": 7, "maxh3resolution": 16}`,
+			errString:  "failed to create segmenter (h3_ids): H3 cell resolutions should be in the range 0 - 15",
+		},
+		"failure | min resolution greater than max": {
+			configData: `{"minh3resolution": 9, "maxh3resolution": 7}`,
+			errString:  "failed to create segmenter (h3_ids): Min H3 cell resolution cannot be greater than max",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var configData json.RawMessage
+			if data.configData != "" {
+				configData = json.RawMessage(data.configData)
+			}
+			segmenter, err := NewH3IDSegmenter(configData)
+			if data.errString == "" {
+				require.NoError(t, err)
+				config, err := segmenter.GetConfiguration()
+				require.NoError(t, err)
+				assert.Equal(t, data.description, config.Description)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
+
+func TestH3IdsValidateSegmenterAndConstraints(t *testing.T) {
+	h3idsSegmenter, _ := NewH3IDSegmenter(json.RawMessage(`{"minh3resolution": 7, "maxh3resolution": 9}`))
+	h3idValues := func(values ...string) map[string]*_segmenters.ListSegmenterValue {
+		segmenterValues := []*_segmenters.SegmenterValue{}
+		for _, value := range values {
+			segmenterValues = append(segmenterValues, &_segmenters.SegmenterValue{
+				Value: &_segmenters.SegmenterValue_String_{String_: value},
+			})
+		}
+		return map[string]*_segmenters.ListSegmenterValue{"h3_ids": {Values: segmenterValues}}
+	}
+	tests := map[string]struct {
+		values    map[string]*_segmenters.ListSegmenterValue
+		errString string
+	}{
+		"success | empty map": {
+			values: map[string]*_segmenters.ListSegmenterValue{},
+		},
+		"success | valid cells": {
+			values: h3idValues("8928308280fffff", "872830828ffffff"),
+		},
+		"failure | invalid value type": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"h3_ids": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_Integer{Integer: 617700169958293503}},
+					},
+				},
+			},
+			errString: "Segmenter h3_ids has one or more values that do not match the configured type",
+		},
+		"failure | invalid value": {
+			values:    h3idValues("zz"),
+			errString: "One or more h3_ids values is invalid",
+		},
+		"failure | non-canonical value": {
+			values:    h3idValues("8928308280FFFFF"),
+			errString: "One or more h3_ids values is invalid",
+		},
+		"failure | invalid h3id resolution": {
+			values: h3idValues("8928308280fffff", "85283083fffffff"),
+			errString: "One or more h3_ids values is at resolution 5, " +
+				"only the following resolutions are allowed: [7 8 9]",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := h3idsSegmenter.ValidateSegmenterAndConstraints(data.values)
+			if data.errString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
+
+func TestH3IdsCellsIntersect(t *testing.T) {
+	h3idsSegmenter, _ := NewH3IDSegmenter(nil)
+	indexer := h3idsSegmenter.(H3Indexer)
+
+	// Same cell
+	assert.True(t, indexer.CellsIntersect([]string{"8928308280fffff"}, []string{"8928308280fffff"}))
+	// Ancestor, in either order
+	assert.True(t, indexer.CellsIntersect([]string{"8928308280fffff"}, []string{"878c106a4ffffff", "872830828ffffff"}))
+	assert.True(t, indexer.CellsIntersect([]string{"85283083fffffff"}, []string{"8928308280fffff"}))
+	// Unrelated cells
+	assert.False(t, indexer.CellsIntersect([]string{"8928308280fffff"}, []string{"878c106a4ffffff", "858c106bfffffff"}))
+	// Invalid cells, which are not equal to each other
+	assert.False(t, indexer.CellsIntersect([]string{"invalid"}, []string{"\"8928308280fffff\""}))
+	assert.False(t, indexer.CellsIntersect([]string{"invalid"}, []string{"invalid"}))
+}
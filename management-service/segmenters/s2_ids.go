@@ -15,11 +15,33 @@ const (
 	MinS2CellLevel = 0
 	// MaxS2CellLevel is the permissible maximum value for S2 Cell level
 	MaxS2CellLevel = 30
+	// DefaultMaxS2CoveringCells is the default limit on the no. of cells used to cover a GeoJSON geometry
+	DefaultMaxS2CoveringCells = 1000
+	// MaxS2CoveringLevel is the max level of the cells used to cover a GeoJSON geometry. The segment values are
+	// handled as JSON numbers, i.e. float64, which only hold the ids of the cells up to this level exactly.
+	MaxS2CoveringLevel = 24
 )
 
 type S2IDSegmenterConfig struct {
 	MinS2CellLevel int `json:"mins2celllevel"`
 	MaxS2CellLevel int `json:"maxs2celllevel"`
+	// MaxCoveringCells is the desired max no. of cells in the covering of a GeoJSON geometry. More cells may be
+	// used, if the geometry cannot be covered with fewer cells at the min level.
+	MaxCoveringCells int `json:"maxcoveringcells"`
+}
+
+// S2Covering is the set of S2 cells covering a GeoJSON geometry
+type S2Covering struct {
+	CellIDs  []s2.CellID
+	MinLevel int
+	MaxLevel int
+	// CoverageError is the area of the cells outside of the geometry, as a fraction of the area of the geometry
+	CoverageError float64
+}
+
+// S2Coverer is implemented by the s2_ids segmenter, whose values can be computed from a GeoJSON geometry
+type S2Coverer interface {
+	Cover(geometry map[string]interface{}) (*S2Covering, error)
 }
 
 func NewS2IDSegmenter(configData json.RawMessage) (Segmenter, error) {
@@ -42,6 +64,12 @@ func NewS2IDSegmenter(configData json.RawMessage) (Segmenter, error) {
 	if config.MinS2CellLevel > config.MaxS2CellLevel {
 		return nil, fmt.Errorf(segmenterErrTpl, "Min S2 cell level cannot be greater than max")
 	}
+	if config.MaxCoveringCells < 0 {
+		return nil, fmt.Errorf(segmenterErrTpl, "Max covering cells cannot be negative")
+	}
+	if config.MaxCoveringCells == 0 {
+		config.MaxCoveringCells = DefaultMaxS2CoveringCells
+	}
 
 	s2IDConfig := &_segmenters.SegmenterConfiguration{
 		Name:        "s2_ids",
@@ -68,14 +96,45 @@ func NewS2IDSegmenter(configData json.RawMessage) (Segmenter, error) {
 	}
 
 	return &s2ids{
-		NewBaseSegmenter(s2IDConfig),
-		levels,
+		Segmenter:     NewBaseSegmenter(s2IDConfig),
+		AllowedLevels: levels,
+		coverer: &s2.RegionCoverer{
+			MinLevel: config.MinS2CellLevel,
+			MaxLevel: min(config.MaxS2CellLevel, MaxS2CoveringLevel),
+			LevelMod: 1,
+			MaxCells: config.MaxCoveringCells,
+		},
 	}, nil
 }
 
 type s2ids struct {
 	Segmenter
 	AllowedLevels []int
+	coverer       *s2.RegionCoverer
+}
+
+// Cover computes the cells covering the GeoJSON geometry, at the allowed levels up to MaxS2CoveringLevel
+func (s *s2ids) Cover(geometry map[string]interface{}) (*S2Covering, error) {
+	if s.coverer.MinLevel > MaxS2CoveringLevel {
+		return nil, fmt.Errorf("GeoJSON geometries can only be covered with S2 cells up to level %d, "+
+			"but the min S2 cell level is %d", MaxS2CoveringLevel, s.coverer.MinLevel)
+	}
+	polygon, err := NewPolygonFromGeoJSON(geometry)
+	if err != nil {
+		return nil, err
+	}
+	area := polygon.Area()
+	if area == 0 {
+		return nil, fmt.Errorf("invalid GeoJSON: the geometry has no area")
+	}
+
+	covering := s.coverer.Covering(polygon)
+	return &S2Covering{
+		CellIDs:       covering,
+		MinLevel:      s.coverer.MinLevel,
+		MaxLevel:      s.coverer.MaxLevel,
+		CoverageError: (covering.ExactArea() - area) / area,
+	}, nil
 }
 
 func (s *s2ids) ValidateSegmenterAndConstraints(segment map[string]*_segmenters.ListSegmenterValue) error {
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/golang/geo/s2"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
 )
@@ -116,8 +118,40 @@ func TestNewS2IDSegmenter(t *testing.T) {
 					Description: fmt.Sprintf("S2 Cell IDs between levels %d and %d are supported.", 10, 12),
 				}),
 				AllowedLevels: []int{10, 11, 12},
+				coverer:       &s2.RegionCoverer{MinLevel: 10, MaxLevel: 12, LevelMod: 1, MaxCells: 1000},
 			},
 		},
+		{
+			name:       "covering level capped",
+			configData: json.RawMessage(`{"mins2celllevel": 20, "maxs2celllevel": 30}`),
+			want: &s2ids{
+				Segmenter: NewBaseSegmenter(&_segmenters.SegmenterConfiguration{
+					Name:        "s2_ids",
+					Type:        _segmenters.SegmenterValueType_INTEGER,
+					Options:     map[string]*_segmenters.SegmenterValue{},
+					MultiValued: true,
+					TreatmentRequestFields: &_segmenters.ListExperimentVariables{
+						Values: []*_segmenters.ExperimentVariables{
+							{
+								Value: []string{"s2id"},
+							},
+							{
+								Value: []string{"latitude", "longitude"},
+							},
+						},
+					},
+					Required:    false,
+					Description: fmt.Sprintf("S2 Cell IDs between levels %d and %d are supported.", 20, 30),
+				}),
+				AllowedLevels: []int{20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30},
+				coverer:       &s2.RegionCoverer{MinLevel: 20, MaxLevel: 24, LevelMod: 1, MaxCells: 1000},
+			},
+		},
+		{
+			name:       "invalid max covering cells",
+			configData: json.RawMessage(`{"mins2celllevel": 10, "maxs2celllevel": 12, "maxcoveringcells": -1}`),
+			wantErr:    "failed to create segmenter (s2_ids): Max covering cells cannot be negative",
+		},
 		{
 			name:       "invalid range, min larger than max",
 			configData: json.RawMessage(`{"mins2celllevel": 13, "maxs2celllevel": 12}`),
@@ -140,3 +174,125 @@ func TestNewS2IDSegmenter(t *testing.T) {
 		})
 	}
 }
+
+func TestS2IdsCover(t *testing.T) {
+	s2idsSegmenter, err := NewS2IDSegmenter(json.RawMessage(`{"mins2celllevel": 10, "maxs2celllevel": 14}`))
+	require.NoError(t, err)
+	coverer := s2idsSegmenter.(S2Coverer)
+
+	square := []interface{}{
+		[]interface{}{103.8, 1.3}, []interface{}{103.9, 1.3}, []interface{}{103.9, 1.4},
+		[]interface{}{103.8, 1.4}, []interface{}{103.8, 1.3},
+	}
+	hole := []interface{}{
+		[]interface{}{103.84, 1.34}, []interface{}{103.86, 1.34}, []interface{}{103.86, 1.36},
+		[]interface{}{103.84, 1.36}, []interface{}{103.84, 1.34},
+	}
+	center := s2.CellIDFromLatLng(s2.LatLngFromDegrees(1.35, 103.85))
+	corner := s2.CellIDFromLatLng(s2.LatLngFromDegrees(1.31, 103.81))
+
+	tests := map[string]struct {
+		geometry       map[string]interface{}
+		containsCenter bool
+		containsCorner bool
+		errString      string
+	}{
+		"polygon": {
+			geometry:       map[string]interface{}{"type": "Polygon", "coordinates": []interface{}{square}},
+			containsCenter: true,
+			containsCorner: true,
+		},
+		"polygon with a hole": {
+			geometry:       map[string]interface{}{"type": "Polygon", "coordinates": []interface{}{square, hole}},
+			containsCorner: true,
+		},
+		"feature collection": {
+			geometry: map[string]interface{}{
+				"type": "FeatureCollection",
+				"features": []interface{}{
+					map[string]interface{}{
+						"type": "Feature",
+						"geometry": map[string]interface{}{
+							"type":        "MultiPolygon",
+							"coordinates": []interface{}{[]interface{}{hole}},
+						},
+					},
+				},
+			},
+			containsCenter: true,
+		},
+		"unsupported type": {
+			geometry:  map[string]interface{}{"type": "Point", "coordinates": []interface{}{103.8, 1.3}},
+			errString: "invalid GeoJSON: unsupported type \"Point\", only Polygon and MultiPolygon areas are supported",
+		},
+		"open ring": {
+			geometry: map[string]interface{}{
+				"type":        "Polygon",
+				"coordinates": []interface{}{square[:4]},
+			},
+			errString: "invalid GeoJSON: a linear ring must be closed",
+		},
+		"out of range": {
+			geometry: map[string]interface{}{
+				"type": "Polygon",
+				"coordinates": []interface{}{[]interface{}{
+					[]interface{}{103.8, 91.0}, []interface{}{103.9, 1.3}, []interface{}{103.9, 1.4},
+					[]interface{}{103.8, 91.0},
+				}},
+			},
+			errString: "invalid GeoJSON: position [103.8, 91] is out of range",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			covering, err := coverer.Cover(data.geometry)
+			if data.errString != "" {
+				assert.EqualError(t, err, data.errString)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, 10, covering.MinLevel)
+			assert.Equal(t, 14, covering.MaxLevel)
+			assert.NotEmpty(t, covering.CellIDs)
+			for _, cellID := range covering.CellIDs {
+				assert.True(t, cellID.Level() >= 10 && cellID.Level() <= 14)
+			}
+			assert.Greater(t, covering.CoverageError, float64(0))
+
+			cellUnion := s2.CellUnion(covering.CellIDs)
+			assert.Equal(t, data.containsCenter, cellUnion.ContainsCellID(center))
+			assert.Equal(t, data.containsCorner, cellUnion.ContainsCellID(corner))
+		})
+	}
+}
+
+func TestS2IdsCoverLevelLimit(t *testing.T) {
+	geometry := map[string]interface{}{
+		"type": "Polygon",
+		"coordinates": []interface{}{[]interface{}{
+			[]interface{}{103.8, 1.3}, []interface{}{103.8001, 1.3}, []interface{}{103.8001, 1.3001},
+			[]interface{}{103.8, 1.3001}, []interface{}{103.8, 1.3},
+		}},
+	}
+
+	// The cells at the levels above the limit are not used
+	s2idsSegmenter, err := NewS2IDSegmenter(json.RawMessage(`{"mins2celllevel": 20, "maxs2celllevel": 30}`))
+	require.NoError(t, err)
+	covering, err := s2idsSegmenter.(S2Coverer).Cover(geometry)
+	require.NoError(t, err)
+	assert.Equal(t, MaxS2CoveringLevel, covering.MaxLevel)
+	for _, cellID := range covering.CellIDs {
+		assert.LessOrEqual(t, cellID.Level(), MaxS2CoveringLevel)
+		// The cell id is preserved by the JSON number that the segment value is handled as
+		assert.Equal(t, cellID, s2.CellID(uint64(int64(float64(int64(cellID))))))
+	}
+
+	// The geometry cannot be covered if all the allowed levels are above the limit
+	s2idsSegmenter, err = NewS2IDSegmenter(json.RawMessage(`{"mins2celllevel": 25, "maxs2celllevel": 30}`))
+	require.NoError(t, err)
+	_, err = s2idsSegmenter.(S2Coverer).Cover(geometry)
+	assert.EqualError(t, err,
+		"GeoJSON geometries can only be covered with S2 cells up to level 24, but the min S2 cell level is 25")
+}
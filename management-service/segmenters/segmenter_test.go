@@ -137,6 +137,18 @@ func (s *SegmenterTestSuite) TestSegmenterIsValidType() {
 				{Value: &_segmenters.SegmenterValue_Real{Real: 0.5}},
 			},
 		},
+		"success | integer range": {
+			values: []*_segmenters.SegmenterValue{
+				{Value: &_segmenters.SegmenterValue_Integer{Integer: 10}},
+				{Value: &_segmenters.SegmenterValue_IntegerRange{IntegerRange: &_segmenters.IntegerRange{}}},
+			},
+			success: true,
+		},
+		"failure | real range": {
+			values: []*_segmenters.SegmenterValue{
+				{Value: &_segmenters.SegmenterValue_RealRange{RealRange: &_segmenters.RealRange{}}},
+			},
+		},
 		"failure | empty list": {
 			values:  []*_segmenters.SegmenterValue{},
 			success: true,
@@ -31,25 +31,45 @@ func ToProtoValues(
 				}
 				protoSegments[key] = _utils.StringSliceToListSegmenterValue(&strVals)
 			case schema.SegmenterTypeInteger:
-				intVals := []int64{}
+				intVals := []*_segmenters.SegmenterValue{}
 				for _, val := range values {
+					if rangeVal, ok := val.(map[string]interface{}); ok {
+						segmenterValue, err := _utils.NewSegmenterRange(rangeVal, _segmenters.SegmenterValueType_INTEGER)
+						if err != nil {
+							return nil, fmt.Errorf("invalid range for segmenter %s: %s", key, err)
+						}
+						intVals = append(intVals, segmenterValue)
+						continue
+					}
 					floatVal, ok := val.(float64)
 					if !ok {
 						return nil, fmt.Errorf("%s %s", errTmpl, schema.SegmenterTypeInteger)
 					}
-					intVals = append(intVals, int64(floatVal))
+					intVals = append(intVals, &_segmenters.SegmenterValue{
+						Value: &_segmenters.SegmenterValue_Integer{Integer: int64(floatVal)},
+					})
 				}
-				protoSegments[key] = _utils.Int64ListToListSegmenterValue(&intVals)
+				protoSegments[key] = &_segmenters.ListSegmenterValue{Values: intVals}
 			case schema.SegmenterTypeReal:
-				floatVals := []float64{}
+				floatVals := []*_segmenters.SegmenterValue{}
 				for _, val := range values {
+					if rangeVal, ok := val.(map[string]interface{}); ok {
+						segmenterValue, err := _utils.NewSegmenterRange(rangeVal, _segmenters.SegmenterValueType_REAL)
+						if err != nil {
+							return nil, fmt.Errorf("invalid range for segmenter %s: %s", key, err)
+						}
+						floatVals = append(floatVals, segmenterValue)
+						continue
+					}
 					floatVal, ok := val.(float64)
 					if !ok {
 						return nil, fmt.Errorf("%s %s", errTmpl, schema.SegmenterTypeReal)
 					}
-					floatVals = append(floatVals, floatVal)
+					floatVals = append(floatVals, &_segmenters.SegmenterValue{
+						Value: &_segmenters.SegmenterValue_Real{Real: floatVal},
+					})
 				}
-				protoSegments[key] = _utils.FloatListToListSegmenterValue(&floatVals)
+				protoSegments[key] = &_segmenters.ListSegmenterValue{Values: floatVals}
 			case schema.SegmenterTypeBool:
 				boolVals := []bool{}
 				for _, val := range values {
@@ -136,7 +156,7 @@ func ProtobufSegmenterConfigToOpenAPISegmenterConfig(segmenterConfiguration *_se
 	// Format Options
 	segmenterDescription := segmenterConfiguration.GetDescription()
 
-	var segmenterTreatmentRequestFields [][]string
+	segmenterTreatmentRequestFields := [][]string{}
 	// TreatmentRequestFields is an array of array holding possible combination of variables that segmenter can derive from
 	listExperimentVariables := segmenterConfiguration.GetTreatmentRequestFields()
 	for _, experimentVariables := range listExperimentVariables.Values {
@@ -153,6 +173,16 @@ func ProtobufSegmenterConfigToOpenAPISegmenterConfig(segmenterConfiguration *_se
 		Required:               segmenterConfiguration.GetRequired(),
 		Description:            &segmenterDescription,
 	}
+	if segmenterConfiguration.GetMatchMode() != _segmenters.StringMatchMode_EXACT {
+		matchMode := schema.SegmenterMatchMode(strings.ToLower(segmenterConfiguration.GetMatchMode().String()))
+		modelConfig.MatchMode = &matchMode
+	}
+	if expression := segmenterConfiguration.GetExpression(); expression != "" {
+		modelConfig.Expression = &expression
+	}
+	if requestFields := segmenterConfiguration.GetRequestFields(); len(requestFields) > 0 {
+		modelConfig.RequestFields = &requestFields
+	}
 
 	return modelConfig, nil
 }
@@ -169,6 +199,8 @@ func typeToVal(value *_segmenters.SegmenterValue) interface{} {
 		return value.GetReal()
 	case "bool":
 		return value.GetBool()
+	case "integer_range", "real_range":
+		return _utils.SegmenterRangeToMap(value)
 	}
 	return nil
 }
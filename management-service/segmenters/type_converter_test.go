@@ -213,6 +213,8 @@ func TestToProtoValues(t *testing.T) {
 	errFloat := "received wrong type of segmenter value; float_segmenter expects type real"
 	errString := "received wrong type of segmenter value; string_segmenter expects type string"
 	errBool := "received wrong type of segmenter value; bool_segmenter expects type bool"
+	errRange := "invalid range for segmenter float_segmenter: range [2.5,1.5] is empty"
+	minValue, maxValue := 2.5, 1.5
 
 	tests := []struct {
 		name           string
@@ -251,6 +253,28 @@ func TestToProtoValues(t *testing.T) {
 			segmentersType: segmentersType,
 			expected:       experimentSegmentListInteger,
 		},
+		{
+			name: "success | float range",
+			segment: map[string]interface{}{"float_segmenter": []interface{}{
+				map[string]interface{}{"max": maxValue, "max_exclusive": true},
+			}},
+			segmentersType: segmentersType,
+			expected: map[string]*_segmenters.ListSegmenterValue{
+				"float_segmenter": {Values: []*_segmenters.SegmenterValue{
+					{Value: &_segmenters.SegmenterValue_RealRange{
+						RealRange: &_segmenters.RealRange{Max: &maxValue, MaxExclusive: true},
+					}},
+				}},
+			},
+		},
+		{
+			name: "invalid range | empty",
+			segment: map[string]interface{}{"float_segmenter": []interface{}{
+				map[string]interface{}{"min": minValue, "max": maxValue},
+			}},
+			segmentersType: segmentersType,
+			err:            &errRange,
+		},
 		{
 			name:           "success | string",
 			segment:        map[string]interface{}{"string_segmenter": []interface{}{"1"}},
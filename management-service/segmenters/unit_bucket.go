@@ -0,0 +1,107 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"google.golang.org/protobuf/proto"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
+)
+
+type UnitBucketSegmenterConfig struct {
+	// Salt is combined with the randomization key before hashing, so that changing it reshuffles the buckets
+	Salt string `json:"salt"`
+	// NumBuckets is the no. of buckets that the randomization keys are hashed into
+	NumBuckets int64 `json:"numbuckets"`
+}
+
+func NewUnitBucketSegmenter(configData json.RawMessage) (Segmenter, error) {
+	segmenterErrTpl := "failed to create segmenter (unit_bucket): %s"
+	config := UnitBucketSegmenterConfig{NumBuckets: _utils.DefaultUnitBuckets}
+
+	// The default no. of buckets is used if the segmenter is not configured
+	if len(configData) > 0 {
+		err := json.Unmarshal(configData, &config)
+		if err != nil {
+			return nil, fmt.Errorf(segmenterErrTpl, err)
+		}
+	}
+	if config.NumBuckets < 1 || config.NumBuckets > _utils.MaxUnitBuckets {
+		return nil, fmt.Errorf(segmenterErrTpl,
+			fmt.Sprintf("the no. of buckets should be in the range 1 - %d", int64(_utils.MaxUnitBuckets)))
+	}
+
+	var unitBucketConfig = &_segmenters.SegmenterConfiguration{
+		Name:        _utils.UnitBucketSegmenter,
+		Type:        _segmenters.SegmenterValueType_INTEGER,
+		Options:     map[string]*_segmenters.SegmenterValue{},
+		MultiValued: true,
+		// The experiment variable is the randomization key of the project, which is checked with the project settings
+		TreatmentRequestFields: &_segmenters.ListExperimentVariables{},
+		Required:               false,
+		Description: fmt.Sprintf("Buckets of the randomization key, from 0 to %d, eg. 0 or "+
+			"{\"min\": 0, \"max\": 19} for buckets 0-19, which are stable across experiments.", config.NumBuckets-1),
+	}
+
+	return &unitBucket{NewBaseSegmenter(unitBucketConfig), config.NumBuckets}, nil
+}
+
+type unitBucket struct {
+	Segmenter
+	numBuckets int64
+}
+
+func (s *unitBucket) ValidateSegmenterAndConstraints(segment map[string]*_segmenters.ListSegmenterValue) error {
+	err := s.Segmenter.ValidateSegmenterAndConstraints(segment)
+	if err != nil {
+		return err
+	}
+	name := s.GetName()
+
+	// Additional check to see that the buckets and bucket ranges lie within the configured buckets. Open-ended
+	// ranges are rejected, as they would change meaning if the no. of buckets is changed.
+	maxBucket := s.numBuckets - 1
+	listInputValues := segment[name]
+	for _, val := range listInputValues.GetValues() {
+		if !s.containsBuckets(val) {
+			return fmt.Errorf("Segmenter %s has an invalid value: buckets should be in the range 0 - %d",
+				name, maxBucket)
+		}
+	}
+
+	return nil
+}
+
+// containsBuckets checks that the bucket, or both bounds of the bucket range, are within the configured buckets
+func (s *unitBucket) containsBuckets(value *_segmenters.SegmenterValue) bool {
+	allBuckets := &_segmenters.IntegerRange{Min: proto.Int64(0), Max: proto.Int64(s.numBuckets - 1)}
+	switch value.Value.(type) {
+	case *_segmenters.SegmenterValue_Integer:
+		return _utils.IntegerRangeContains(allBuckets, value.GetInteger())
+	case *_segmenters.SegmenterValue_IntegerRange:
+		r := value.GetIntegerRange()
+		if r.Min == nil || r.Max == nil {
+			return false
+		}
+		min, max := r.GetMin(), r.GetMax()
+		if r.GetMinExclusive() {
+			min++
+		}
+		if r.GetMaxExclusive() {
+			max--
+		}
+		return min <= max && _utils.IntegerRangeContains(allBuckets, min) && _utils.IntegerRangeContains(allBuckets, max)
+	default:
+		return false
+	}
+}
+
+func init() {
+	err := Register(_utils.UnitBucketSegmenter, NewUnitBucketSegmenter)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
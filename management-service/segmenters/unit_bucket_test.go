@@ -0,0 +1,168 @@
+package segmenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+func TestNewUnitBucketSegmenter(t *testing.T) {
+	tests := map[string]struct {
+		configData  string
+		description string
+		errString   string
+	}{
+		"success | default buckets": {
+			description: "Buckets of the randomization key, from 0 to 99, eg. 0 or {\"min\": 0, \"max\": 19} " +
+				"for buckets 0-19, which are stable across experiments.",
+		},
+		"success | configured buckets": {
+			configData: `{"salt": "market_split", "numbuckets": 1000}`,
+			description: "Buckets of the randomization key, from 0 to 999, eg. 0 or {\"min\": 0, \"max\": 19} " +
+				"for buckets 0-19, which are stable across experiments.",
+		},
+		"failure | invalid no. of buckets": {
+			configData: `{"numbuckets": 0}`,
+			errString: "failed to create segmenter (unit_bucket): " +
+				"the no. of buckets should be in the range 1 - 4294967295",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var configData []byte
+			if data.configData != "" {
+				configData = []byte(data.configData)
+			}
+			segmenter, err := NewUnitBucketSegmenter(configData)
+			if data.errString == "" {
+				require.NoError(t, err)
+				config, err := segmenter.GetConfiguration()
+				require.NoError(t, err)
+				assert.Equal(t, _segmenters.SegmenterValueType_INTEGER, config.Type)
+				assert.Equal(t, data.description, config.Description)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
+
+func TestUnitBucketValidateSegmenterAndConstraints(t *testing.T) {
+	unitBucketSegmenter, _ := NewUnitBucketSegmenter(nil)
+	minBucket, maxBucket, outOfRangeBucket := int64(0), int64(19), int64(100)
+	lastBucket, belowFirstBucket := int64(99), int64(-1)
+	tests := map[string]struct {
+		values    map[string]*_segmenters.ListSegmenterValue
+		errString string
+	}{
+		"success | empty map": {
+			values: map[string]*_segmenters.ListSegmenterValue{},
+		},
+		"success | valid buckets": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"unit_bucket": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_IntegerRange{
+							IntegerRange: &_segmenters.IntegerRange{Min: &minBucket, Max: &maxBucket},
+						}},
+						{Value: &_segmenters.SegmenterValue_Integer{Integer: 99}},
+					},
+				},
+			},
+		},
+		"failure | bucket out of range": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"unit_bucket": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_Integer{Integer: 100}},
+					},
+				},
+			},
+			errString: "Segmenter unit_bucket has an invalid value: buckets should be in the range 0 - 99",
+		},
+		"failure | bucket range out of range": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"unit_bucket": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_IntegerRange{
+							IntegerRange: &_segmenters.IntegerRange{Min: &outOfRangeBucket},
+						}},
+					},
+				},
+			},
+			errString: "Segmenter unit_bucket has an invalid value: buckets should be in the range 0 - 99",
+		},
+		"success | exclusive bounds": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"unit_bucket": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_IntegerRange{
+							IntegerRange: &_segmenters.IntegerRange{
+								Min: &belowFirstBucket, Max: &outOfRangeBucket, MinExclusive: true, MaxExclusive: true,
+							},
+						}},
+					},
+				},
+			},
+		},
+		"failure | negative bucket": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"unit_bucket": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_Integer{Integer: -1}},
+					},
+				},
+			},
+			errString: "Segmenter unit_bucket has an invalid value: buckets should be in the range 0 - 99",
+		},
+		"failure | bucket range partially out of range": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"unit_bucket": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_IntegerRange{
+							IntegerRange: &_segmenters.IntegerRange{Min: &maxBucket, Max: &outOfRangeBucket},
+						}},
+					},
+				},
+			},
+			errString: "Segmenter unit_bucket has an invalid value: buckets should be in the range 0 - 99",
+		},
+		"failure | open-ended bucket range": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"unit_bucket": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_IntegerRange{
+							IntegerRange: &_segmenters.IntegerRange{Max: &lastBucket},
+						}},
+					},
+				},
+			},
+			errString: "Segmenter unit_bucket has an invalid value: buckets should be in the range 0 - 99",
+		},
+		"failure | invalid value type": {
+			values: map[string]*_segmenters.ListSegmenterValue{
+				"unit_bucket": {
+					Values: []*_segmenters.SegmenterValue{
+						{Value: &_segmenters.SegmenterValue_String_{String_: "0"}},
+					},
+				},
+			},
+			errString: "Segmenter unit_bucket has one or more values that do not match the configured type",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := unitBucketSegmenter.ValidateSegmenterAndConstraints(data.values)
+			if data.errString == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, data.errString)
+			}
+		})
+	}
+}
@@ -15,8 +15,10 @@ import (
 	"github.com/caraml-dev/mlp/api/pkg/instrumentation/sentry"
 	"github.com/go-chi/chi/v5"
 	"github.com/heptiolabs/healthcheck"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/cors"
 
+	"github.com/caraml-dev/xp/common/tracing"
 	"github.com/caraml-dev/xp/common/web"
 	"github.com/caraml-dev/xp/management-service/api"
 	"github.com/caraml-dev/xp/management-service/appcontext"
@@ -25,6 +27,7 @@ import (
 	"github.com/caraml-dev/xp/management-service/database"
 	"github.com/caraml-dev/xp/management-service/errors"
 	"github.com/caraml-dev/xp/management-service/middleware"
+	"github.com/caraml-dev/xp/management-service/services"
 )
 
 type Server struct {
@@ -76,6 +79,21 @@ func NewServer(configFiles []string) (*Server, error) {
 		cleanup = append(cleanup, func() { sentry.Close() })
 	}
 
+	// Init OpenTelemetry tracing
+	if cfg.TracingConfig.Enabled {
+		shutdownTracer, err := tracing.InitTracer("xp-management-service", cfg.TracingConfig)
+		if err != nil {
+			return nil, errors.Newf(errors.GetType(err), fmt.Sprintf("Failed initializing tracing: %v", err))
+		}
+		cleanup = append(cleanup, func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracer(ctx); err != nil {
+				log.Printf("Failed flushing the traces: %v", err)
+			}
+		})
+	}
+
 	// Init Authorizer
 	var authorizer *middleware.Authorizer
 	if cfg.AuthorizationConfig.Enabled {
@@ -91,7 +109,7 @@ func NewServer(configFiles []string) (*Server, error) {
 		if err != nil {
 			return nil, errors.Newf(errors.GetType(err), fmt.Sprintf("Failed initializing Authorizer: %v", err))
 		}
-		authorizer, err = middleware.NewAuthorizer(authzEnforcer)
+		authorizer, err = middleware.NewAuthorizer(authzEnforcer, cfg.AuthorizationConfig.PasskeyHashSubjects)
 		if err != nil {
 			return nil, errors.Newf(errors.GetType(err), fmt.Sprintf("Failed initializing Authorizer: %v", err))
 		}
@@ -103,6 +121,25 @@ func NewServer(configFiles []string) (*Server, error) {
 		return nil, errors.Newf(errors.GetType(err), fmt.Sprintf("Failed initializing AppContext: %v", err))
 	}
 
+	// Start publishing the outbox messages in the background
+	outboxDispatcher := services.NewOutboxDispatcher(*cfg.OutboxConfig, appCtx.Services.OutboxService)
+	outboxDispatcher.Start()
+	cleanup = append(cleanup, func() { outboxDispatcher.Stop() })
+
+	// Start checking the running experiments for a sample ratio mismatch in the background
+	if cfg.SRMConfig.Enabled {
+		srmMonitor := services.NewSRMMonitor(*cfg.SRMConfig, appCtx.Services.SRMService)
+		srmMonitor.Start()
+		cleanup = append(cleanup, func() { srmMonitor.Stop() })
+	}
+
+	// Start evaluating the guardrails of the running experiments in the background
+	if cfg.GuardrailConfig.Enabled {
+		guardrailEvaluator := services.NewGuardrailEvaluator(*cfg.GuardrailConfig, appCtx.Services.GuardrailService)
+		guardrailEvaluator.Start()
+		cleanup = append(cleanup, func() { guardrailEvaluator.Stop() })
+	}
+
 	// Create Chi router and add middlewares
 	router := chi.NewRouter()
 	router.Use(appCtx.OpenAPIValidator.Middleware())
@@ -121,6 +158,10 @@ func NewServer(configFiles []string) (*Server, error) {
 	if cfg.NewRelicConfig.Enabled {
 		router.Use(middleware.NewRelicMiddleware())
 	}
+	// Add tracing middleware
+	if cfg.TracingConfig.Enabled {
+		router.Use(middleware.TracingMiddleware())
+	}
 
 	// Register handlers
 	apiHandler := api.HandlerFromMux(
@@ -135,6 +176,8 @@ func NewServer(configFiles []string) (*Server, error) {
 			controller.NewTreatmentHistoryController(appCtx),
 			controller.NewValidationController(appCtx),
 			controller.NewConfigurationController(appCtx),
+			controller.NewOutboxController(appCtx),
+			controller.NewAnalysisController(appCtx),
 		),
 		router,
 	)
@@ -149,6 +192,7 @@ func NewServer(configFiles []string) (*Server, error) {
 	mux := http.NewServeMux()
 	mux.Handle("/v1/", http.StripPrefix("/v1", apiHandler))
 	mux.Handle("/v1/internal/", http.StripPrefix("/v1/internal", healthHandler))
+	mux.Handle("/v1/metrics", http.StripPrefix("/v1", promhttp.Handler()))
 	// Serve Swagger Specs
 	mux.Handle("/experiments.yaml", web.FileHandler(path.Join(cfg.OpenAPISpecsPath, "experiments.yaml"), false))
 	mux.Handle("/schema.yaml", web.FileHandler(path.Join(cfg.OpenAPISpecsPath, "schema.yaml"), false))
@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/caraml-dev/xp/management-service/analysis"
+	"github.com/caraml-dev/xp/management-service/errors"
+	"github.com/caraml-dev/xp/management-service/models"
+)
+
+const (
+	defaultControlTreatment = "control"
+	defaultConfidenceLevel  = 0.95
+	// analysisBatchSize is the max no. of records inserted by a single statement, during ingestion
+	analysisBatchSize = 1000
+)
+
+type ExperimentAssignment struct {
+	UnitID            string `json:"unit_id"`
+	ExperimentVersion int64  `json:"experiment_version"`
+	Treatment         string `json:"treatment"`
+}
+
+type MetricObservation struct {
+	Metric    string   `json:"metric"`
+	UnitID    string   `json:"unit_id"`
+	Value     float64  `json:"value"`
+	Covariate *float64 `json:"covariate"`
+}
+
+type ComputeExperimentResultParams struct {
+	Metric            string   `json:"metric"`
+	ExperimentVersion *int64   `json:"experiment_version,omitempty"`
+	ControlTreatment  *string  `json:"control_treatment,omitempty"`
+	CUPED             bool     `json:"cuped"`
+	ConfidenceLevel   *float64 `json:"confidence_level,omitempty"`
+}
+
+type ListExperimentResultsParams struct {
+	ExperimentVersion *int64  `json:"experiment_version,omitempty"`
+	Metric            *string `json:"metric,omitempty"`
+}
+
+type AnalysisService interface {
+	// IngestAssignments saves the treatments assigned to the units of the experiment. The assignment of a unit
+	// to an experiment version replaces any previous assignment of the unit to the same version.
+	IngestAssignments(ctx context.Context, experiment *models.Experiment, assignments []ExperimentAssignment) error
+	// IngestObservations saves the metric values observed for the units of the experiment, replacing any
+	// previous value of the same metric for the unit.
+	IngestObservations(ctx context.Context, experiment *models.Experiment, observations []MetricObservation) error
+	// ParseObservationsCSV reads the metric observations from a CSV file, with the header
+	// metric,unit_id,value and, optionally, covariate.
+	ParseObservationsCSV(reader io.Reader) ([]MetricObservation, error)
+	// ComputeExperimentResult joins the observations of the metric with the assignments of the experiment
+	// version, computes the per-treatment statistics and saves them, replacing any previous result.
+	ComputeExperimentResult(
+		ctx context.Context,
+		experiment *models.Experiment,
+		params ComputeExperimentResultParams,
+	) (*models.ExperimentResult, error)
+	ListExperimentResults(
+		ctx context.Context,
+		experiment *models.Experiment,
+		params ListExperimentResultsParams,
+	) ([]*models.ExperimentResult, error)
+}
+
+type analysisService struct {
+	services *Services
+	db       *gorm.DB
+}
+
+func NewAnalysisService(services *Services, db *gorm.DB) AnalysisService {
+	return &analysisService{
+		services: services,
+		db:       db,
+	}
+}
+
+func (svc *analysisService) IngestAssignments(
+	ctx context.Context,
+	experiment *models.Experiment,
+	assignments []ExperimentAssignment,
+) error {
+	// Validate the assignments against the treatments of each experiment version
+	versionTreatments := map[int64]models.ExperimentTreatments{}
+	records := make([]models.ExperimentAssignment, 0, len(assignments))
+	// Postgres rejects an upsert that affects the same row twice, so a repeated unit keeps only its last assignment
+	type assignmentKey struct {
+		version int64
+		unitID  string
+	}
+	recordIndex := map[assignmentKey]int{}
+	for _, assignment := range assignments {
+		if assignment.UnitID == "" {
+			return errors.Newf(errors.BadInput, "unit_id must be set for every assignment")
+		}
+
+		treatments, ok := versionTreatments[assignment.ExperimentVersion]
+		if !ok {
+			var err error
+			treatments, err = svc.getVersionTreatments(ctx, experiment, assignment.ExperimentVersion)
+			if err != nil {
+				return err
+			}
+			versionTreatments[assignment.ExperimentVersion] = treatments
+		}
+		if !hasTreatment(treatments, assignment.Treatment) {
+			return errors.Newf(errors.BadInput, "treatment %s does not exist in version %d of the experiment",
+				assignment.Treatment, assignment.ExperimentVersion)
+		}
+
+		record := models.ExperimentAssignment{
+			ExperimentID:      experiment.ID,
+			ExperimentVersion: assignment.ExperimentVersion,
+			UnitID:            assignment.UnitID,
+			Treatment:         assignment.Treatment,
+		}
+		key := assignmentKey{version: assignment.ExperimentVersion, unitID: assignment.UnitID}
+		if i, ok := recordIndex[key]; ok {
+			records[i] = record
+			continue
+		}
+		recordIndex[key] = len(records)
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	return svc.query(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "experiment_id"}, {Name: "experiment_version"}, {Name: "unit_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"treatment", "updated_at"}),
+	}).CreateInBatches(records, analysisBatchSize).Error
+}
+
+func (svc *analysisService) IngestObservations(
+	ctx context.Context,
+	experiment *models.Experiment,
+	observations []MetricObservation,
+) error {
+	records := make([]models.MetricObservation, 0, len(observations))
+	// A repeated metric and unit keeps only the last observation, as the upsert cannot affect the same row twice
+	type observationKey struct {
+		metric string
+		unitID string
+	}
+	recordIndex := map[observationKey]int{}
+	for _, observation := range observations {
+		if observation.Metric == "" || observation.UnitID == "" {
+			return errors.Newf(errors.BadInput, "metric and unit_id must be set for every observation")
+		}
+		if !isFinite(observation.Value) || (observation.Covariate != nil && !isFinite(*observation.Covariate)) {
+			return errors.Newf(errors.BadInput,
+				"observation of metric %s for unit %s is not a finite number", observation.Metric, observation.UnitID)
+		}
+
+		record := models.MetricObservation{
+			ExperimentID: experiment.ID,
+			Metric:       observation.Metric,
+			UnitID:       observation.UnitID,
+			Value:        observation.Value,
+			Covariate:    observation.Covariate,
+		}
+		key := observationKey{metric: observation.Metric, unitID: observation.UnitID}
+		if i, ok := recordIndex[key]; ok {
+			records[i] = record
+			continue
+		}
+		recordIndex[key] = len(records)
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	return svc.query(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "experiment_id"}, {Name: "metric"}, {Name: "unit_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"value", "covariate", "updated_at"}),
+	}).CreateInBatches(records, analysisBatchSize).Error
+}
+
+func (svc *analysisService) ParseObservationsCSV(reader io.Reader) ([]MetricObservation, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err == io.EOF {
+		return nil, errors.Newf(errors.BadInput, "the file is empty")
+	}
+	if err != nil {
+		return nil, errors.Newf(errors.BadInput, "error reading the file: %s", err.Error())
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"metric", "unit_id", "value"} {
+		if _, ok := columns[name]; !ok {
+			return nil, errors.Newf(errors.BadInput, "the file is missing the column %s", name)
+		}
+	}
+	covariateColumn, hasCovariate := columns["covariate"]
+
+	observations := []MetricObservation{}
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Newf(errors.BadInput, "error reading the file: %s", err.Error())
+		}
+		line, _ := csvReader.FieldPos(0)
+
+		value, err := strconv.ParseFloat(record[columns["value"]], 64)
+		if err != nil {
+			return nil, errors.Newf(errors.BadInput, "invalid value on line %d: %s", line, err.Error())
+		}
+		observation := MetricObservation{
+			Metric: record[columns["metric"]],
+			UnitID: record[columns["unit_id"]],
+			Value:  value,
+		}
+		if hasCovariate && record[covariateColumn] != "" {
+			covariate, err := strconv.ParseFloat(record[covariateColumn], 64)
+			if err != nil {
+				return nil, errors.Newf(errors.BadInput, "invalid covariate on line %d: %s", line, err.Error())
+			}
+			observation.Covariate = &covariate
+		}
+		observations = append(observations, observation)
+	}
+
+	return observations, nil
+}
+
+func (svc *analysisService) ComputeExperimentResult(
+	ctx context.Context,
+	experiment *models.Experiment,
+	params ComputeExperimentResultParams,
+) (*models.ExperimentResult, error) {
+	if params.Metric == "" {
+		return nil, errors.Newf(errors.BadInput, "metric must be set")
+	}
+	version := experiment.Version
+	if params.ExperimentVersion != nil {
+		version = *params.ExperimentVersion
+	}
+	treatments, err := svc.getVersionTreatments(ctx, experiment, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Default to the treatment named "control", else the first treatment of the experiment version
+	var controlTreatment string
+	switch {
+	case params.ControlTreatment != nil:
+		controlTreatment = *params.ControlTreatment
+	case hasTreatment(treatments, defaultControlTreatment):
+		controlTreatment = defaultControlTreatment
+	case len(treatments) > 0:
+		controlTreatment = treatments[0].Name
+	}
+	if !hasTreatment(treatments, controlTreatment) {
+		return nil, errors.Newf(errors.BadInput,
+			"treatment %s does not exist in version %d of the experiment", controlTreatment, version)
+	}
+	confidenceLevel := defaultConfidenceLevel
+	if params.ConfidenceLevel != nil {
+		confidenceLevel = *params.ConfidenceLevel
+	}
+
+	// Join the observations of the metric with the assignments, excluding the units without observations
+	var observations []analysis.Observation
+	err = svc.query(ctx).Table("experiment_assignments AS a").
+		Select("a.treatment, o.value, o.covariate").
+		Joins("JOIN metric_observations AS o ON o.experiment_id = a.experiment_id AND o.unit_id = a.unit_id").
+		Where("a.experiment_id = ? AND a.experiment_version = ? AND o.metric = ?", experiment.ID, version, params.Metric).
+		Scan(&observations).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(observations) == 0 {
+		return nil, errors.Newf(errors.BadInput,
+			"no observations of metric %s for the units assigned in version %d of the experiment", params.Metric, version)
+	}
+
+	statistics, err := analysis.Analyze(observations, analysis.Params{
+		ControlTreatment: controlTreatment,
+		CUPED:            params.CUPED,
+		ConfidenceLevel:  confidenceLevel,
+	})
+	if err != nil {
+		return nil, errors.Newf(errors.BadInput, err.Error())
+	}
+
+	// Replace the existing result, if any
+	result := &models.ExperimentResult{}
+	err = svc.query(ctx).Where("experiment_id = ? AND experiment_version = ? AND metric = ?", experiment.ID, version, params.Metric).
+		First(result).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	result.ExperimentID = experiment.ID
+	result.ExperimentVersion = version
+	result.Metric = params.Metric
+	result.ControlTreatment = controlTreatment
+	result.CUPED = params.CUPED
+	result.ConfidenceLevel = confidenceLevel
+	result.Treatments = statistics
+	if err := svc.query(ctx).Save(result).Error; err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (svc *analysisService) ListExperimentResults(
+	ctx context.Context,
+	experiment *models.Experiment,
+	params ListExperimentResultsParams,
+) ([]*models.ExperimentResult, error) {
+	version := experiment.Version
+	if params.ExperimentVersion != nil {
+		version = *params.ExperimentVersion
+	}
+
+	var results []*models.ExperimentResult
+	query := svc.query(ctx).Where("experiment_id = ? AND experiment_version = ?", experiment.ID, version)
+	if params.Metric != nil {
+		query = query.Where("metric = ?", *params.Metric)
+	}
+	err := query.Order("metric").Find(&results).Error
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// getVersionTreatments returns the treatments of the given version of the experiment
+func (svc *analysisService) getVersionTreatments(
+	ctx context.Context,
+	experiment *models.Experiment,
+	version int64,
+) (models.ExperimentTreatments, error) {
+	if version == experiment.Version {
+		return experiment.Treatments, nil
+	}
+	history, err := svc.services.ExperimentHistoryService.GetDBRecord(ctx, experiment.ID, version)
+	if err != nil {
+		return nil, errors.Newf(errors.NotFound, "version %d of the experiment not found", version)
+	}
+	return history.Treatments, nil
+}
+
+func (svc *analysisService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
+}
+
+func hasTreatment(treatments models.ExperimentTreatments, name string) bool {
+	for _, treatment := range treatments {
+		if treatment.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,181 @@
+//go:build integration
+
+package services_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/xp/management-service/errors"
+	tu "github.com/caraml-dev/xp/management-service/internal/testutils"
+	"github.com/caraml-dev/xp/management-service/models"
+	"github.com/caraml-dev/xp/management-service/services"
+)
+
+type AnalysisServiceTestSuite struct {
+	suite.Suite
+	services.AnalysisService
+	db          *gorm.DB
+	Experiment  *models.Experiment
+	CleanUpFunc func()
+}
+
+func (s *AnalysisServiceTestSuite) SetupSuite() {
+	s.Suite.T().Log("Setting up AnalysisServiceTestSuite")
+
+	// Create test DB, save the DB clean up function to be executed on tear down
+	db, cleanup, err := tu.CreateTestDB(tu.MigrationsPath)
+	if err != nil {
+		s.Suite.T().Fatalf("Could not create test DB: %v", err)
+	}
+	s.db = db
+	s.CleanUpFunc = cleanup
+
+	// Create test experiment and history
+	experiments, _, err := createTestExperimentHistory(db)
+	if err != nil {
+		s.Suite.T().Fatalf("Could not set up test data: %v", err)
+	}
+	// The service uses the treatments of the current version from the given experiment record
+	s.Experiment = experiments[0]
+	s.Experiment.Version = 3
+	s.Experiment.Treatments = models.ExperimentTreatments{{Name: "control"}, {Name: "treatment"}}
+
+	allServices := &services.Services{
+		ExperimentHistoryService: services.NewExperimentHistoryService(db),
+	}
+	s.AnalysisService = services.NewAnalysisService(allServices, db)
+}
+
+func (s *AnalysisServiceTestSuite) TearDownSuite() {
+	s.Suite.T().Log("Cleaning up AnalysisServiceTestSuite")
+	s.CleanUpFunc()
+}
+
+func TestAnalysisService(t *testing.T) {
+	suite.Run(t, new(AnalysisServiceTestSuite))
+}
+
+func (s *AnalysisServiceTestSuite) TestComputeExperimentResult() {
+	// Ingest the assignments and observations
+	assignments := []services.ExperimentAssignment{}
+	observations := []services.MetricObservation{}
+	controlValues := []float64{10, 11, 9, 10, 12, 8}
+	treatmentValues := []float64{13, 12, 14, 13, 15, 11}
+	for i := range controlValues {
+		controlUnit, treatmentUnit := "c"+string(rune('a'+i)), "t"+string(rune('a'+i))
+		assignments = append(assignments,
+			services.ExperimentAssignment{UnitID: controlUnit, ExperimentVersion: 3, Treatment: "control"},
+			services.ExperimentAssignment{UnitID: treatmentUnit, ExperimentVersion: 3, Treatment: "treatment"},
+		)
+		observations = append(observations,
+			services.MetricObservation{Metric: "revenue", UnitID: controlUnit, Value: controlValues[i]},
+			services.MetricObservation{Metric: "revenue", UnitID: treatmentUnit, Value: treatmentValues[i]},
+		)
+	}
+	// Units without assignments are excluded
+	observations = append(observations, services.MetricObservation{Metric: "revenue", UnitID: "x", Value: 100})
+	s.Suite.Require().NoError(s.AnalysisService.IngestAssignments(context.Background(), s.Experiment, assignments))
+	s.Suite.Require().NoError(s.AnalysisService.IngestObservations(context.Background(), s.Experiment, observations))
+
+	// Re-ingesting replaces the existing records
+	s.Suite.Require().NoError(s.AnalysisService.IngestObservations(context.Background(), s.Experiment, observations[:1]))
+
+	result, err := s.AnalysisService.ComputeExperimentResult(context.Background(), s.Experiment, services.ComputeExperimentResultParams{
+		Metric: "revenue",
+	})
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(int64(3), result.ExperimentVersion)
+	s.Suite.Assert().Equal("control", result.ControlTreatment)
+	s.Suite.Assert().Equal(0.95, result.ConfidenceLevel)
+	s.Suite.Require().Len(result.Treatments, 2)
+	s.Suite.Assert().Equal(6, result.Treatments[0].Count)
+	s.Suite.Assert().Equal(10.0, result.Treatments[0].Mean)
+	s.Suite.Assert().Equal(13.0, result.Treatments[1].Mean)
+	s.Suite.Assert().InDelta(3.0, *result.Treatments[1].Difference, 1e-9)
+	s.Suite.Assert().InDelta(0.3, *result.Treatments[1].RelativeLift, 1e-9)
+	s.Suite.Assert().Less(*result.Treatments[1].PValue, 0.05)
+
+	// Computing again replaces the stored result
+	confidenceLevel := 0.9
+	recomputed, err := s.AnalysisService.ComputeExperimentResult(context.Background(), s.Experiment, services.ComputeExperimentResultParams{
+		Metric:          "revenue",
+		ConfidenceLevel: &confidenceLevel,
+	})
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(result.ID, recomputed.ID)
+
+	results, err := s.AnalysisService.ListExperimentResults(context.Background(), s.Experiment, services.ListExperimentResultsParams{})
+	s.Suite.Require().NoError(err)
+	s.Suite.Require().Len(results, 1)
+	s.Suite.Assert().Equal(0.9, results[0].ConfidenceLevel)
+
+	// No observations of the metric
+	_, err = s.AnalysisService.ComputeExperimentResult(context.Background(), s.Experiment, services.ComputeExperimentResultParams{
+		Metric: "conversion",
+	})
+	s.Suite.Assert().EqualError(err, "no observations of metric conversion for the units assigned in version 3 of the experiment")
+	s.Suite.Assert().Equal(errors.BadInput, errors.GetType(err))
+}
+
+func (s *AnalysisServiceTestSuite) TestIngestRepeatedUnits() {
+	// The last record of a repeated unit is kept
+	s.Suite.Require().NoError(s.AnalysisService.IngestAssignments(context.Background(), s.Experiment, []services.ExperimentAssignment{
+		{UnitID: "repeated", ExperimentVersion: 3, Treatment: "control"},
+		{UnitID: "other", ExperimentVersion: 3, Treatment: "control"},
+		{UnitID: "repeated", ExperimentVersion: 3, Treatment: "treatment"},
+	}))
+	s.Suite.Require().NoError(s.AnalysisService.IngestObservations(context.Background(), s.Experiment, []services.MetricObservation{
+		{Metric: "clicks", UnitID: "repeated", Value: 1},
+		{Metric: "clicks", UnitID: "other", Value: 2},
+		{Metric: "clicks", UnitID: "repeated", Value: 3},
+	}))
+
+	var assignment models.ExperimentAssignment
+	s.Suite.Require().NoError(s.db.
+		Where("experiment_id = ? AND experiment_version = ? AND unit_id = ?", s.Experiment.ID, 3, "repeated").
+		First(&assignment).Error)
+	s.Suite.Assert().Equal("treatment", assignment.Treatment)
+
+	var observation models.MetricObservation
+	s.Suite.Require().NoError(s.db.
+		Where("experiment_id = ? AND metric = ? AND unit_id = ?", s.Experiment.ID, "clicks", "repeated").
+		First(&observation).Error)
+	s.Suite.Assert().Equal(3.0, observation.Value)
+}
+
+func (s *AnalysisServiceTestSuite) TestIngestAssignmentsInvalidTreatment() {
+	// Version 1 of the experiment has no treatments
+	err := s.AnalysisService.IngestAssignments(context.Background(), s.Experiment, []services.ExperimentAssignment{
+		{UnitID: "a", ExperimentVersion: 1, Treatment: "control"},
+	})
+	s.Suite.Assert().EqualError(err, "treatment control does not exist in version 1 of the experiment")
+
+	err = s.AnalysisService.IngestAssignments(context.Background(), s.Experiment, []services.ExperimentAssignment{
+		{UnitID: "a", ExperimentVersion: 5, Treatment: "control"},
+	})
+	s.Suite.Assert().EqualError(err, "version 5 of the experiment not found")
+	s.Suite.Assert().Equal(errors.NotFound, errors.GetType(err))
+}
+
+func (s *AnalysisServiceTestSuite) TestParseObservationsCSV() {
+	observations, err := s.AnalysisService.ParseObservationsCSV(
+		strings.NewReader("unit_id,metric,value,covariate\na,revenue,1.5,2\nb,revenue,3,\n"),
+	)
+	s.Suite.Require().NoError(err)
+	covariate := 2.0
+	s.Suite.Assert().Equal([]services.MetricObservation{
+		{Metric: "revenue", UnitID: "a", Value: 1.5, Covariate: &covariate},
+		{Metric: "revenue", UnitID: "b", Value: 3},
+	}, observations)
+
+	_, err = s.AnalysisService.ParseObservationsCSV(strings.NewReader("unit_id,value\na,1\n"))
+	s.Suite.Assert().EqualError(err, "the file is missing the column metric")
+
+	_, err = s.AnalysisService.ParseObservationsCSV(strings.NewReader("metric,unit_id,value\nrevenue,a,x\n"))
+	s.Suite.Assert().EqualError(err, "invalid value on line 2: strconv.ParseFloat: parsing \"x\": invalid syntax")
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -14,10 +16,16 @@ type ListExperimentHistoryParams struct {
 }
 
 type ExperimentHistoryService interface {
-	ListExperimentHistory(experimentId int64, params ListExperimentHistoryParams) ([]*models.ExperimentHistory, *pagination.Paging, error)
-	GetExperimentHistory(experimentId int64, version int64) (*models.ExperimentHistory, error)
-	CreateExperimentHistory(*models.Experiment) (*models.ExperimentHistory, error)
-	GetDBRecord(experimentId models.ID, version int64) (*models.ExperimentHistory, error)
+	ListExperimentHistory(
+		ctx context.Context,
+		experimentId int64,
+		params ListExperimentHistoryParams,
+	) ([]*models.ExperimentHistory, *pagination.Paging, error)
+	GetExperimentHistory(ctx context.Context, experimentId int64, version int64) (*models.ExperimentHistory, error)
+	CreateExperimentHistory(ctx context.Context, experiment *models.Experiment) (*models.ExperimentHistory, error)
+	// CreateExperimentHistoryInTx copies the experiment as a history record within the given transaction
+	CreateExperimentHistoryInTx(tx *gorm.DB, experiment *models.Experiment) (*models.ExperimentHistory, error)
+	GetDBRecord(ctx context.Context, experimentId models.ID, version int64) (*models.ExperimentHistory, error)
 }
 
 type experimentHistoryService struct {
@@ -31,11 +39,12 @@ func NewExperimentHistoryService(db *gorm.DB) ExperimentHistoryService {
 }
 
 func (svc *experimentHistoryService) ListExperimentHistory(
+	ctx context.Context,
 	experimentId int64,
 	params ListExperimentHistoryParams,
 ) ([]*models.ExperimentHistory, *pagination.Paging, error) {
 	var history []*models.ExperimentHistory
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("experiment_id = ?", experimentId).
 		Order("updated_at desc")
 
@@ -70,10 +79,11 @@ func (svc *experimentHistoryService) ListExperimentHistory(
 }
 
 func (svc *experimentHistoryService) GetExperimentHistory(
+	ctx context.Context,
 	experimentId int64,
 	version int64,
 ) (*models.ExperimentHistory, error) {
-	history, err := svc.GetDBRecord(models.ID(experimentId), version)
+	history, err := svc.GetDBRecord(ctx, models.ID(experimentId), version)
 	if err != nil {
 		return nil, errors.Newf(errors.NotFound, err.Error())
 	}
@@ -81,8 +91,18 @@ func (svc *experimentHistoryService) GetExperimentHistory(
 	return history, nil
 }
 
-func (svc *experimentHistoryService) CreateExperimentHistory(experiment *models.Experiment) (*models.ExperimentHistory, error) {
-	return svc.save(&models.ExperimentHistory{
+func (svc *experimentHistoryService) CreateExperimentHistory(
+	ctx context.Context,
+	experiment *models.Experiment,
+) (*models.ExperimentHistory, error) {
+	return svc.CreateExperimentHistoryInTx(svc.query(ctx), experiment)
+}
+
+func (svc *experimentHistoryService) CreateExperimentHistoryInTx(
+	tx *gorm.DB,
+	experiment *models.Experiment,
+) (*models.ExperimentHistory, error) {
+	return svc.save(tx, &models.ExperimentHistory{
 		Model: models.Model{
 			CreatedAt: experiment.UpdatedAt,
 		},
@@ -93,8 +113,10 @@ func (svc *experimentHistoryService) CreateExperimentHistory(experiment *models.
 		Interval:     experiment.Interval,
 		Name:         experiment.Name,
 		Segment:      experiment.Segment,
+		S2Covering:   experiment.S2Covering,
 		Status:       experiment.Status,
 		Treatments:   experiment.Treatments,
+		Guardrails:   experiment.Guardrails,
 		Tier:         experiment.Tier,
 		Type:         experiment.Type,
 		StartTime:    experiment.StartTime,
@@ -103,11 +125,20 @@ func (svc *experimentHistoryService) CreateExperimentHistory(experiment *models.
 }
 
 func (svc *experimentHistoryService) GetDBRecord(
+	ctx context.Context,
+	experimentId models.ID,
+	version int64,
+) (*models.ExperimentHistory, error) {
+	return svc.getDBRecord(svc.query(ctx), experimentId, version)
+}
+
+func (svc *experimentHistoryService) getDBRecord(
+	db *gorm.DB,
 	experimentId models.ID,
 	version int64,
 ) (*models.ExperimentHistory, error) {
 	var history models.ExperimentHistory
-	query := svc.query().
+	query := db.
 		Where("experiment_id = ?", experimentId).
 		Where("version = ?", version).
 		First(&history)
@@ -117,15 +148,18 @@ func (svc *experimentHistoryService) GetDBRecord(
 	return &history, nil
 }
 
-func (svc *experimentHistoryService) query() *gorm.DB {
-	return svc.db
+func (svc *experimentHistoryService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
 }
 
-func (svc *experimentHistoryService) save(history *models.ExperimentHistory) (*models.ExperimentHistory, error) {
-	if err := svc.query().Clauses(clause.OnConflict{
+func (svc *experimentHistoryService) save(
+	tx *gorm.DB,
+	history *models.ExperimentHistory,
+) (*models.ExperimentHistory, error) {
+	if err := tx.Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(history).Error; err != nil {
 		return nil, err
 	}
-	return svc.GetDBRecord(history.ExperimentID, history.Version)
+	return svc.getDBRecord(tx, history.ExperimentID, history.Version)
 }
@@ -3,6 +3,7 @@
 package services_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -53,11 +54,11 @@ func (s *ExperimentHistoryServiceTestSuite) TearDownSuite() {
 
 func (s *ExperimentHistoryServiceTestSuite) TestExperimentHistoryServiceGetIntegration() {
 	// Successful get
-	histResponse, err := s.ExperimentHistoryService.GetExperimentHistory(1, 1)
+	histResponse, err := s.ExperimentHistoryService.GetExperimentHistory(context.Background(), 1, 1)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.ExperimentHistory[0], histResponse)
 	// Invalid version
-	_, err = s.ExperimentHistoryService.GetExperimentHistory(1, 200)
+	_, err = s.ExperimentHistoryService.GetExperimentHistory(context.Background(), 1, 200)
 	s.Suite.Require().EqualError(err, "record not found")
 }
 
@@ -68,13 +69,13 @@ func (s *ExperimentHistoryServiceTestSuite) TestExperimentHistoryServiceListCrea
 }
 
 func testListExperimentHistory(s *ExperimentHistoryServiceTestSuite) {
-	histResponse, paging, err := s.ExperimentHistoryService.ListExperimentHistory(1, services.ListExperimentHistoryParams{})
+	histResponse, paging, err := s.ExperimentHistoryService.ListExperimentHistory(context.Background(), 1, services.ListExperimentHistoryParams{})
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.ExperimentHistory, histResponse)
 	tu.AssertEqualValues(s.Suite.T(), &pagination.Paging{Page: 1, Pages: 1, Total: 2}, paging)
 	// Pagination
 	var page, pageSize int32 = 2, 1
-	histResponse, paging, err = s.ExperimentHistoryService.ListExperimentHistory(1, services.ListExperimentHistoryParams{
+	histResponse, paging, err = s.ExperimentHistoryService.ListExperimentHistory(context.Background(), 1, services.ListExperimentHistoryParams{
 		pagination.PaginationOptions{
 			Page:     &page,
 			PageSize: &pageSize,
@@ -84,7 +85,7 @@ func testListExperimentHistory(s *ExperimentHistoryServiceTestSuite) {
 	tu.AssertEqualValues(s.Suite.T(), []*models.ExperimentHistory{s.ExperimentHistory[1]}, histResponse)
 	tu.AssertEqualValues(s.Suite.T(), &pagination.Paging{Page: 2, Pages: 2, Total: 2}, paging)
 	// No history
-	histResponse, paging, err = s.ExperimentHistoryService.ListExperimentHistory(2, services.ListExperimentHistoryParams{})
+	histResponse, paging, err = s.ExperimentHistoryService.ListExperimentHistory(context.Background(), 2, services.ListExperimentHistoryParams{})
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), []*models.ExperimentHistory{}, histResponse)
 	tu.AssertEqualValues(s.Suite.T(), &pagination.Paging{Page: 1, Pages: 0, Total: 0}, paging)
@@ -92,7 +93,7 @@ func testListExperimentHistory(s *ExperimentHistoryServiceTestSuite) {
 
 func testCreateExperimentHistory(s *ExperimentHistoryServiceTestSuite) {
 	experiment := s.Experiments[1]
-	expHist, err := s.ExperimentHistoryService.CreateExperimentHistory(experiment)
+	expHist, err := s.ExperimentHistoryService.CreateExperimentHistory(context.Background(), experiment)
 
 	s.Suite.Require().NoError(err)
 
@@ -124,7 +125,7 @@ func testCreateExperimentHistory(s *ExperimentHistoryServiceTestSuite) {
 	s.Suite.Assert().JSONEq(string(expectedJSON), string(expHistJSON))
 
 	// Get the newly created experiment history and verify
-	expHist, err = s.ExperimentHistoryService.GetExperimentHistory(int64(s.Experiments[1].ID), 1)
+	expHist, err = s.ExperimentHistoryService.GetExperimentHistory(context.Background(), int64(s.Experiments[1].ID), 1)
 	s.Suite.Require().NoError(err)
 	// JSON Marshal to compare, to bypasss timezone precision issues
 	expHistJSON, _ = json.Marshal(expHist)
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	"github.com/caraml-dev/xp/common/api/schema"
 	"github.com/caraml-dev/xp/management-service/errors"
 	"github.com/caraml-dev/xp/management-service/models"
 	"github.com/caraml-dev/xp/management-service/pagination"
@@ -35,6 +37,7 @@ type CreateExperimentRequestBody struct {
 	StartTime   time.Time                   `json:"start_time" validate:"required"`
 	Status      models.ExperimentStatus     `json:"status" validate:"required,oneof=inactive active"`
 	Treatments  models.ExperimentTreatments `json:"treatments" validate:"unique=Name,dive,required,notBlank"`
+	Guardrails  models.ExperimentGuardrails `json:"guardrails" validate:"dive"`
 	Tier        models.ExperimentTier       `json:"tier" validate:"required,oneof=default override"`
 	Type        models.ExperimentType       `json:"type" validate:"required,oneof=A/B Switchback"`
 	UpdatedBy   *string                     `json:"updated_by,omitempty"`
@@ -48,6 +51,7 @@ type UpdateExperimentRequestBody struct {
 	StartTime   time.Time                   `json:"start_time" validate:"required"`
 	Status      models.ExperimentStatus     `json:"status" validate:"required,oneof=inactive active"`
 	Treatments  models.ExperimentTreatments `json:"treatments" validate:"unique=Name,dive,required,notBlank"`
+	Guardrails  models.ExperimentGuardrails `json:"guardrails" validate:"dive"`
 	Tier        models.ExperimentTier       `json:"tier" validate:"required,oneof=default override"`
 	Type        models.ExperimentType       `json:"type" validate:"required,oneof=A/B Switchback"`
 	UpdatedBy   *string                     `json:"updated_by,omitempty"`
@@ -71,19 +75,23 @@ type ListExperimentsParams struct {
 
 type ExperimentService interface {
 	ListExperiments(
+		ctx context.Context,
 		projectId int64,
 		params ListExperimentsParams,
 	) ([]*models.Experiment, *pagination.Paging, error)
-	ListAllExperiments(projectId models.ID, params ListExperimentsParams) ([]*models.Experiment, error)
-	GetExperiment(projectId int64, experimentId int64) (*models.Experiment, error)
-	CreateExperiment(settings models.Settings, expData CreateExperimentRequestBody) (*models.Experiment, error)
-	UpdateExperiment(settings models.Settings, experimentId int64, expData UpdateExperimentRequestBody) (*models.Experiment, error)
-	EnableExperiment(settings models.Settings, experimentId int64) error
-	DisableExperiment(projectId int64, experimentId int64) error
-	ValidatePairwiseExperimentOrthogonality(projectId int64, experiments []*models.Experiment, segmenters []string) error
-	ValidateProjectExperimentSegmentersExist(projectId int64, experiments []*models.Experiment, segmenters []string) error
-
-	GetDBRecord(projectId models.ID, experimentId models.ID) (*models.Experiment, error)
+	ListAllExperiments(ctx context.Context, projectId models.ID, params ListExperimentsParams) ([]*models.Experiment, error)
+	GetExperiment(ctx context.Context, projectId int64, experimentId int64) (*models.Experiment, error)
+	CreateExperiment(ctx context.Context, settings models.Settings, expData CreateExperimentRequestBody) (*models.Experiment, error)
+	UpdateExperiment(ctx context.Context, settings models.Settings, experimentId int64, expData UpdateExperimentRequestBody) (*models.Experiment, error)
+	EnableExperiment(ctx context.Context, settings models.Settings, experimentId int64) error
+	DisableExperiment(ctx context.Context, projectId int64, experimentId int64) error
+	// DisableExperimentInTx disables the experiment within the given transaction, so that the change is
+	// committed together with the other writes of the caller
+	DisableExperimentInTx(tx *gorm.DB, projectId int64, experimentId int64) error
+	ValidatePairwiseExperimentOrthogonality(ctx context.Context, projectId int64, experiments []*models.Experiment, segmenters []string) error
+	ValidateProjectExperimentSegmentersExist(ctx context.Context, projectId int64, experiments []*models.Experiment, segmenters []string) error
+
+	GetDBRecord(ctx context.Context, projectId models.ID, experimentId models.ID) (*models.Experiment, error)
 	RunCustomValidation(
 		experiment models.Experiment,
 		settings models.Settings,
@@ -108,13 +116,14 @@ func NewExperimentService(
 }
 
 func (svc *experimentService) ListExperiments(
+	ctx context.Context,
 	projectId int64,
 	params ListExperimentsParams,
 ) ([]*models.Experiment, *pagination.Paging, error) {
 	var err error
 	var exps []*models.Experiment
 
-	query := svc.query()
+	query := svc.query(ctx)
 
 	// Handle Field values
 	query, err = svc.filterFieldValues(query, params)
@@ -132,11 +141,11 @@ func (svc *experimentService) ListExperiments(
 	}
 	// Handle StatusFriendly values
 	if len(params.StatusFriendly) > 0 {
-		query = svc.filterExperimentStatusFriendly(query, params.StatusFriendly)
+		query = svc.filterExperimentStatusFriendly(ctx, query, params.StatusFriendly)
 	}
 
 	// Handle Start and EndTime values
-	query, err = svc.filterStartEndTimeValues(query, params)
+	query, err = svc.filterStartEndTimeValues(ctx, query, params)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -195,8 +204,8 @@ func (svc *experimentService) ListExperiments(
 	return exps, pagingResponse, nil
 }
 
-func (svc *experimentService) GetExperiment(projectId int64, experimentId int64) (*models.Experiment, error) {
-	exp, err := svc.GetDBRecord(models.ID(projectId), models.ID(experimentId))
+func (svc *experimentService) GetExperiment(ctx context.Context, projectId int64, experimentId int64) (*models.Experiment, error) {
+	exp, err := svc.GetDBRecord(ctx, models.ID(projectId), models.ID(experimentId))
 	if err != nil {
 		return nil, errors.Newf(errors.NotFound, err.Error())
 	}
@@ -205,6 +214,7 @@ func (svc *experimentService) GetExperiment(projectId int64, experimentId int64)
 }
 
 func (svc *experimentService) CreateExperiment(
+	ctx context.Context,
 	settings models.Settings,
 	expData CreateExperimentRequestBody,
 ) (*models.Experiment, error) {
@@ -214,8 +224,16 @@ func (svc *experimentService) CreateExperiment(
 		return nil, errors.Newf(errors.BadInput, err.Error())
 	}
 
+	// Compute the segmenter values given as GeoJSON geometries
+	segment, s2Covering, err := svc.services.SegmenterService.CoverSegmentGeometries(expData.Segment)
+	if err != nil {
+		return nil, errors.Newf(errors.BadInput, err.Error())
+	}
+	expData.Segment = segment
+
 	// Validate Segmenter data
 	err = svc.services.SegmenterService.ValidateExperimentSegment(
+		ctx,
 		int64(settings.ProjectID),
 		settings.Config.Segmenters.Names,
 		expData.Segment,
@@ -227,7 +245,7 @@ func (svc *experimentService) CreateExperiment(
 	// If new experiment is active, get other experiments active in the same time range
 	// and validate segment orthogonality
 	if expData.Status == models.ExperimentStatusActive {
-		err = svc.validateExperimentOrthogonalityInDuration(nil, settings, expData.Segment, expData.Tier, expData.StartTime, expData.EndTime)
+		err = svc.validateExperimentOrthogonalityInDuration(ctx, nil, settings, expData.Segment, expData.Tier, expData.StartTime, expData.EndTime)
 		if err != nil {
 			return nil, err
 		}
@@ -243,7 +261,7 @@ func (svc *experimentService) CreateExperiment(
 		}
 	}
 
-	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(int64(settings.ProjectID))
+	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(ctx, int64(settings.ProjectID))
 	if err != nil {
 		return nil, err
 	}
@@ -260,7 +278,9 @@ func (svc *experimentService) CreateExperiment(
 		Type:        expData.Type,
 		Interval:    expData.Interval,
 		Treatments:  expData.Treatments,
+		Guardrails:  expData.Guardrails,
 		Segment:     segmenterStorageSchema,
+		S2Covering:  s2Covering,
 		Status:      expData.Status,
 		StartTime:   expData.StartTime,
 		EndTime:     expData.EndTime,
@@ -279,26 +299,12 @@ func (svc *experimentService) CreateExperiment(
 		return nil, errors.Newf(errors.BadInput, err.Error())
 	}
 
-	// Save to DB
-	expDBRecord, err := svc.save(experiment)
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert to the format expected by the Message Queue
-	protoExpResponse, err := expDBRecord.ToProtoSchema(segmenterTypes)
-	if err != nil {
-		return nil, err
-	}
-	err = svc.services.MessageQueueService.PublishExperimentMessage("create", protoExpResponse)
-	if err != nil {
-		return nil, err
-	}
-
-	return expDBRecord, nil
+	// Save to DB, together with the message to be published
+	return svc.saveAndPublish(ctx, experiment, segmenterTypes, "create")
 }
 
 func (svc *experimentService) UpdateExperiment(
+	ctx context.Context,
 	settings models.Settings,
 	experimentId int64,
 	expData UpdateExperimentRequestBody,
@@ -309,7 +315,15 @@ func (svc *experimentService) UpdateExperiment(
 		return nil, errors.Newf(errors.BadInput, err.Error())
 	}
 
+	// Compute the segmenter values given as GeoJSON geometries
+	segment, s2Covering, err := svc.services.SegmenterService.CoverSegmentGeometries(expData.Segment)
+	if err != nil {
+		return nil, errors.Newf(errors.BadInput, err.Error())
+	}
+	expData.Segment = segment
+
 	err = svc.services.SegmenterService.ValidateExperimentSegment(
+		ctx,
 		int64(settings.ProjectID),
 		settings.Config.Segmenters.Names,
 		expData.Segment,
@@ -319,7 +333,7 @@ func (svc *experimentService) UpdateExperiment(
 	}
 
 	// Get current experiment
-	curExperiment, err := svc.GetDBRecord(settings.ProjectID, models.ID(experimentId))
+	curExperiment, err := svc.GetDBRecord(ctx, settings.ProjectID, models.ID(experimentId))
 	if err != nil {
 		return nil, err
 	}
@@ -327,7 +341,7 @@ func (svc *experimentService) UpdateExperiment(
 	// If new experiment is active, get other experiments active in the same time range
 	// and validate segment orthogonality
 	if expData.Status == models.ExperimentStatusActive {
-		err = svc.validateExperimentOrthogonalityInDuration(&experimentId, settings, expData.Segment, expData.Tier, expData.StartTime, expData.EndTime)
+		err = svc.validateExperimentOrthogonalityInDuration(ctx, &experimentId, settings, expData.Segment, expData.Tier, expData.StartTime, expData.EndTime)
 		if err != nil {
 			return nil, err
 		}
@@ -349,12 +363,12 @@ func (svc *experimentService) UpdateExperiment(
 	}
 
 	//  Copy current experiment's contents as experiment history
-	_, err = svc.services.ExperimentHistoryService.CreateExperimentHistory(curExperiment)
+	_, err = svc.services.ExperimentHistoryService.CreateExperimentHistory(ctx, curExperiment)
 	if err != nil {
 		return nil, err
 	}
 
-	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(int64(settings.ProjectID))
+	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(ctx, int64(settings.ProjectID))
 	if err != nil {
 		return nil, err
 	}
@@ -374,7 +388,9 @@ func (svc *experimentService) UpdateExperiment(
 		Description: expData.Description,
 		Interval:    expData.Interval,
 		Treatments:  expData.Treatments,
+		Guardrails:  expData.Guardrails,
 		Segment:     segmenterStorageSchema,
+		S2Covering:  s2Covering,
 		Status:      expData.Status,
 		StartTime:   expData.StartTime,
 		Tier:        expData.Tier,
@@ -393,28 +409,13 @@ func (svc *experimentService) UpdateExperiment(
 		return nil, errors.Newf(errors.BadInput, err.Error())
 	}
 
-	// Update current experiment and save to DB
-	expDBRecord, err := svc.save(newExperiment)
-	if err != nil {
-		return nil, err
-	}
-
-	// Publish pubsub update message
-	protoExpResponse, err := expDBRecord.ToProtoSchema(segmenterTypes)
-	if err != nil {
-		return nil, err
-	}
-	err = svc.services.MessageQueueService.PublishExperimentMessage("update", protoExpResponse)
-	if err != nil {
-		return nil, err
-	}
-
-	return expDBRecord, nil
+	// Update current experiment and save to DB, together with the message to be published
+	return svc.saveAndPublish(ctx, newExperiment, segmenterTypes, "update")
 }
 
-func (svc *experimentService) EnableExperiment(settings models.Settings, experimentId int64) error {
+func (svc *experimentService) EnableExperiment(ctx context.Context, settings models.Settings, experimentId int64) error {
 	// Get experiment
-	experiment, err := svc.GetDBRecord(settings.ProjectID, models.ID(experimentId))
+	experiment, err := svc.GetDBRecord(ctx, settings.ProjectID, models.ID(experimentId))
 	if err != nil {
 		return err
 	}
@@ -424,7 +425,7 @@ func (svc *experimentService) EnableExperiment(settings models.Settings, experim
 		return errors.Newf(errors.BadInput, fmt.Sprintf("experiment id %d is already active", experimentId))
 	}
 
-	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(int64(settings.ProjectID))
+	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(ctx, int64(settings.ProjectID))
 	if err != nil {
 		return err
 	}
@@ -452,41 +453,33 @@ func (svc *experimentService) EnableExperiment(settings models.Settings, experim
 		return err
 	}
 
-	err = svc.validateExperimentOrthogonalityInDuration(&experimentId, settings,
+	err = svc.validateExperimentOrthogonalityInDuration(ctx, &experimentId, settings,
 		rawSegments, experiment.Tier, experiment.StartTime, experiment.EndTime)
 	if err != nil {
 		return err
 	}
 
 	//  Copy current experiment's contents as experiment history
-	_, err = svc.services.ExperimentHistoryService.CreateExperimentHistory(experiment)
+	_, err = svc.services.ExperimentHistoryService.CreateExperimentHistory(ctx, experiment)
 	if err != nil {
 		return err
 	}
 
 	// Update Experiment
 	experiment.Status = models.ExperimentStatusActive
-	expDBRecord, err := svc.save(experiment)
-	if err != nil {
-		return err
-	}
-
-	// Convert to the format expected by the Message Queue
-	protoExpResponse, err := expDBRecord.ToProtoSchema(segmenterTypes)
-	if err != nil {
-		return err
-	}
-	err = svc.services.MessageQueueService.PublishExperimentMessage("update", protoExpResponse)
-	if err != nil {
-		return err
-	}
+	_, err = svc.saveAndPublish(ctx, experiment, segmenterTypes, "update")
+	return err
+}
 
-	return nil
+func (svc *experimentService) DisableExperiment(ctx context.Context, projectId int64, experimentId int64) error {
+	return svc.query(ctx).Transaction(func(tx *gorm.DB) error {
+		return svc.DisableExperimentInTx(tx, projectId, experimentId)
+	})
 }
 
-func (svc *experimentService) DisableExperiment(projectId int64, experimentId int64) error {
+func (svc *experimentService) DisableExperimentInTx(tx *gorm.DB, projectId int64, experimentId int64) error {
 	// Get experiment
-	experiment, err := svc.GetDBRecord(models.ID(projectId), models.ID(experimentId))
+	experiment, err := svc.getDBRecord(tx, models.ID(projectId), models.ID(experimentId))
 	if err != nil {
 		return err
 	}
@@ -497,38 +490,32 @@ func (svc *experimentService) DisableExperiment(projectId int64, experimentId in
 	}
 
 	//  Copy current experiment's contents as experiment history
-	_, err = svc.services.ExperimentHistoryService.CreateExperimentHistory(experiment)
+	_, err = svc.services.ExperimentHistoryService.CreateExperimentHistoryInTx(tx, experiment)
 	if err != nil {
 		return err
 	}
 
 	// Update Experiment
-	experiment.Status = models.ExperimentStatusInactive
-	expDBRecord, err := svc.save(experiment)
-	if err != nil {
-		return err
-	}
-
-	// Convert to the format expected by the Message Queue
-	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(projectId)
-	if err != nil {
-		return err
-	}
-	protoExpResponse, err := expDBRecord.ToProtoSchema(segmenterTypes)
-	if err != nil {
-		return err
-	}
-	err = svc.services.MessageQueueService.PublishExperimentMessage("update", protoExpResponse)
+	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(tx.Statement.Context, projectId)
 	if err != nil {
 		return err
 	}
+	experiment.Status = models.ExperimentStatusInactive
+	_, err = svc.saveAndPublishInTx(tx, experiment, segmenterTypes, "update")
+	return err
+}
 
-	return nil
+func (svc *experimentService) GetDBRecord(ctx context.Context, projectId models.ID, experimentId models.ID) (*models.Experiment, error) {
+	return svc.getDBRecord(svc.query(ctx), projectId, experimentId)
 }
 
-func (svc *experimentService) GetDBRecord(projectId models.ID, experimentId models.ID) (*models.Experiment, error) {
+func (svc *experimentService) getDBRecord(
+	db *gorm.DB,
+	projectId models.ID,
+	experimentId models.ID,
+) (*models.Experiment, error) {
 	var exp models.Experiment
-	query := svc.query().
+	query := db.
 		Where("project_id = ?", projectId).
 		Where("id = ?", experimentId).
 		First(&exp)
@@ -538,17 +525,63 @@ func (svc *experimentService) GetDBRecord(projectId models.ID, experimentId mode
 	return &exp, nil
 }
 
-func (svc *experimentService) query() *gorm.DB {
-	return svc.db
+func (svc *experimentService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
 }
 
-func (svc *experimentService) save(exp *models.Experiment) (*models.Experiment, error) {
-	if err := svc.query().Clauses(clause.OnConflict{
+func (svc *experimentService) save(tx *gorm.DB, exp *models.Experiment) (*models.Experiment, error) {
+	if err := tx.Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(exp).Error; err != nil {
 		return nil, err
 	}
-	return svc.GetDBRecord(exp.ProjectID, exp.ID)
+	return svc.getDBRecord(tx, exp.ProjectID, exp.ID)
+}
+
+// saveAndPublish saves the experiment and writes the corresponding message queue update to the
+// outbox in a single transaction, so that subscribers are notified of every committed change.
+func (svc *experimentService) saveAndPublish(
+	ctx context.Context,
+	exp *models.Experiment,
+	segmenterTypes map[string]schema.SegmenterType,
+	updateType string,
+) (*models.Experiment, error) {
+	var expDBRecord *models.Experiment
+	err := svc.query(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		expDBRecord, err = svc.saveAndPublishInTx(tx, exp, segmenterTypes, updateType)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return expDBRecord, nil
+}
+
+// saveAndPublishInTx saves the experiment and writes the corresponding message queue update to the
+// outbox within the given transaction.
+func (svc *experimentService) saveAndPublishInTx(
+	tx *gorm.DB,
+	exp *models.Experiment,
+	segmenterTypes map[string]schema.SegmenterType,
+	updateType string,
+) (*models.Experiment, error) {
+	expDBRecord, err := svc.save(tx, exp)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to the format expected by the Message Queue
+	protoExpResponse, err := expDBRecord.ToProtoSchema(segmenterTypes)
+	if err != nil {
+		return nil, err
+	}
+	err = svc.services.OutboxService.AddExperimentMessage(tx, updateType, protoExpResponse)
+	if err != nil {
+		return nil, err
+	}
+	return expDBRecord, nil
 }
 
 func (svc *experimentService) filterFieldValues(query *gorm.DB, params ListExperimentsParams) (*gorm.DB, error) {
@@ -587,10 +620,14 @@ func (svc *experimentService) filterFieldValues(query *gorm.DB, params ListExper
 	return query, nil
 }
 
-func (svc *experimentService) filterExperimentStatusFriendly(query *gorm.DB, statusesFriendly []ExperimentStatusFriendly) *gorm.DB {
-	orPredicates := svc.query().Where("false") // start with false and build OR query dynamically
+func (svc *experimentService) filterExperimentStatusFriendly(
+	ctx context.Context,
+	query *gorm.DB,
+	statusesFriendly []ExperimentStatusFriendly,
+) *gorm.DB {
+	orPredicates := svc.query(ctx).Where("false") // start with false and build OR query dynamically
 	for _, statusFriendly := range statusesFriendly {
-		predicates := svc.query()
+		predicates := svc.query(ctx)
 		if statusFriendly == ExperimentStatusFriendlyDeactivated {
 			predicates = predicates.Where("status = ?", models.ExperimentStatusInactive)
 		} else {
@@ -610,7 +647,7 @@ func (svc *experimentService) filterExperimentStatusFriendly(query *gorm.DB, sta
 	return query.Where(orPredicates)
 }
 
-func (svc *experimentService) filterStartEndTimeValues(query *gorm.DB, params ListExperimentsParams) (*gorm.DB, error) {
+func (svc *experimentService) filterStartEndTimeValues(ctx context.Context, query *gorm.DB, params ListExperimentsParams) (*gorm.DB, error) {
 	if params.StartTime != nil && !params.StartTime.IsZero() && (params.EndTime == nil || params.EndTime.IsZero()) {
 		return nil, errors.Newf(errors.BadInput, "end_time parameter must be supplied as well")
 	}
@@ -629,7 +666,7 @@ func (svc *experimentService) filterStartEndTimeValues(query *gorm.DB, params Li
 			// * the end_time parameter should fall within the experiment's (start and end) times
 			// * the experiment starts and ends within the [start_time and end_time) duration
 			query = query.Where(
-				svc.query().
+				svc.query(ctx).
 					Where("tstzrange(start_time, end_time, '[)') @> tstzrange(?, ?, '[]')", params.StartTime, params.StartTime).
 					Or("tstzrange(start_time, end_time, '()') @> tstzrange(?, ?, '[]')", params.EndTime, params.EndTime).
 					Or("tstzrange(?, ?, '[]') @> tstzrange(start_time, end_time, '[)')", params.StartTime, params.EndTime),
@@ -669,6 +706,7 @@ func validateListExperimentFieldNames(fields []models.ExperimentField) error {
 }
 
 func (svc *experimentService) validateExperimentOrthogonality(
+	ctx context.Context,
 	projectId int64,
 	experimentId *int64,
 	segment models.ExperimentSegmentRaw,
@@ -688,7 +726,7 @@ func (svc *experimentService) validateExperimentOrthogonality(
 		filteredExps = append(filteredExps, *exp)
 	}
 	if len(filteredExps) > 0 {
-		err = svc.services.SegmenterService.ValidateSegmentOrthogonality(projectId, segmenters, segment, filteredExps)
+		err = svc.services.SegmenterService.ValidateSegmentOrthogonality(ctx, projectId, segmenters, segment, filteredExps)
 		if err != nil {
 			return errors.Newf(errors.BadInput, err.Error())
 		}
@@ -720,9 +758,14 @@ func filterSegmenterAnyOfPredicate(query *gorm.DB, name string, values []string,
 
 // ListAllExperiments returns a list of all experiments based on the filters specified in params parameter,
 // to be used for performing orthogonality checks on.
-func (svc *experimentService) ListAllExperiments(projectId models.ID, params ListExperimentsParams) ([]*models.Experiment, error) {
+func (svc *experimentService) ListAllExperiments(
+	ctx context.Context,
+	projectId models.ID,
+	params ListExperimentsParams,
+) ([]*models.Experiment, error) {
 	// Get the first page of active experiments
 	filteredExperiments, paging, err := svc.ListExperiments(
+		ctx,
 		projectId.ToApiSchema(),
 		params,
 	)
@@ -737,6 +780,7 @@ func (svc *experimentService) ListAllExperiments(projectId models.ID, params Lis
 	// If there are multiple pages, get the subsequent pages
 	for i := int32(2); i <= paging.Pages; i++ {
 		exps, _, err := svc.ListExperiments(
+			ctx,
 			projectId.ToApiSchema(),
 			ListExperimentsParams{
 				StartTime: params.StartTime,
@@ -757,6 +801,7 @@ func (svc *experimentService) ListAllExperiments(projectId models.ID, params Lis
 }
 
 func (svc *experimentService) validateExperimentOrthogonalityInDuration(
+	ctx context.Context,
 	experimentId *int64,
 	settings models.Settings,
 	segment models.ExperimentSegmentRaw,
@@ -766,11 +811,12 @@ func (svc *experimentService) validateExperimentOrthogonalityInDuration(
 ) error {
 	status := models.ExperimentStatusActive
 	listExpParams := ListExperimentsParams{StartTime: &startTime, EndTime: &endTime, Status: &status, Tier: &tier}
-	exps, err := svc.ListAllExperiments(settings.ProjectID, listExpParams)
+	exps, err := svc.ListAllExperiments(ctx, settings.ProjectID, listExpParams)
 	if err != nil {
 		return err
 	}
 	return svc.validateExperimentOrthogonality(
+		ctx,
 		int64(settings.ProjectID),
 		experimentId,
 		segment,
@@ -780,11 +826,12 @@ func (svc *experimentService) validateExperimentOrthogonalityInDuration(
 }
 
 func (svc *experimentService) ValidatePairwiseExperimentOrthogonality(
+	ctx context.Context,
 	projectId int64,
 	experiments []*models.Experiment,
 	segmenters []string,
 ) error {
-	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(ctx, projectId)
 	if err != nil {
 		return err
 	}
@@ -809,6 +856,7 @@ func (svc *experimentService) ValidatePairwiseExperimentOrthogonality(
 			return err
 		}
 		err = svc.validateExperimentOrthogonality(
+			ctx,
 			projectId,
 			&experimentId,
 			rawSegments,
@@ -829,11 +877,12 @@ func (svc *experimentService) ValidatePairwiseExperimentOrthogonality(
 // ValidateProjectExperimentSegmentersExist checks if the set of segmenters given contains all the segments specified
 // by all the experiments
 func (svc *experimentService) ValidateProjectExperimentSegmentersExist(
+	ctx context.Context,
 	projectId int64,
 	experiments []*models.Experiment,
 	segmenters []string,
 ) error {
-	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(ctx, projectId)
 	if err != nil {
 		return err
 	}
@@ -3,6 +3,7 @@
 package services_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -11,12 +12,12 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/management-service/config"
 	"github.com/caraml-dev/xp/management-service/errors"
 	tu "github.com/caraml-dev/xp/management-service/internal/testutils"
 	"github.com/caraml-dev/xp/management-service/models"
 	"github.com/caraml-dev/xp/management-service/pagination"
 	"github.com/caraml-dev/xp/management-service/services"
-	"github.com/caraml-dev/xp/management-service/services/messagequeue"
 	"github.com/caraml-dev/xp/management-service/services/mocks"
 )
 
@@ -43,7 +44,6 @@ func (s *ExperimentServiceTestSuite) SetupSuite() {
 	// Init mock services
 	segmenterSvc := setupMockSegmenterService()
 	validationSvc := setupMockValidationService()
-	messageQueueSvc := setupMockMessageQueueService()
 	configuredTreatmentSvc := setupMockTreatmentService()
 
 	// Init experiment history svc, mock calls will be set up during the test
@@ -54,8 +54,8 @@ func (s *ExperimentServiceTestSuite) SetupSuite() {
 		ValidationService:        validationSvc,
 		ExperimentHistoryService: s.ExperimentHistoryService,
 		SegmenterService:         segmenterSvc,
-		MessageQueueService:      messageQueueSvc,
 	}
+	allServices.OutboxService = services.NewOutboxService(allServices, config.OutboxConfig{}, db)
 
 	// Init experiment service
 	s.ExperimentService = services.NewExperimentService(allServices, db)
@@ -119,6 +119,7 @@ func (s *ExperimentServiceTestSuite) TestValidateProjectExperimentSegmentersExis
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
 			err := s.ExperimentService.ValidateProjectExperimentSegmentersExist(
+				context.Background(),
 				data.projectId,
 				data.experiments,
 				data.segmenters,
@@ -133,7 +134,7 @@ func (s *ExperimentServiceTestSuite) TestValidateProjectExperimentSegmentersExis
 }
 
 func (s *ExperimentServiceTestSuite) TestExperimentServiceGetIntegration() {
-	expResponse, err := s.ExperimentService.GetExperiment(1, 1)
+	expResponse, err := s.ExperimentService.GetExperiment(context.Background(), 1, 1)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.Experiments[0], expResponse)
 }
@@ -167,19 +168,19 @@ func testListExperiments(s *ExperimentServiceTestSuite) {
 	var nilPagingResponse *pagination.Paging
 
 	// All experiments under a settings
-	actualResponsesList, pagingResponse, err := svc.ListExperiments(1, services.ListExperimentsParams{})
+	actualResponsesList, pagingResponse, err := svc.ListExperiments(context.Background(), 1, services.ListExperimentsParams{})
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(t, &pagination.Paging{Page: 1, Pages: 1, Total: 3}, pagingResponse)
 	tu.AssertEqualValues(t, []*models.Experiment{s.Experiments[0], s.Experiments[1], s.Experiments[2]}, actualResponsesList)
 
 	// No experiments filtered
-	actualResponsesList, pagingResponse, err = svc.ListExperiments(3, services.ListExperimentsParams{})
+	actualResponsesList, pagingResponse, err = svc.ListExperiments(context.Background(), 3, services.ListExperimentsParams{})
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(t, &pagination.Paging{Page: 1, Pages: 0, Total: 0}, pagingResponse)
 	tu.AssertEqualValues(t, []*models.Experiment{}, actualResponsesList)
 
 	// Filter by a single parameter
-	actualResponsesList, pagingResponse, err = svc.ListExperiments(1,
+	actualResponsesList, pagingResponse, err = svc.ListExperiments(context.Background(), 1,
 		services.ListExperimentsParams{Status: &testStatus},
 	)
 	s.Suite.Require().NoError(err)
@@ -187,7 +188,7 @@ func testListExperiments(s *ExperimentServiceTestSuite) {
 	tu.AssertEqualValues(t, []*models.Experiment{s.Experiments[0], s.Experiments[2]}, actualResponsesList)
 
 	// Filter by all parameters
-	actualResponsesList, pagingResponse, err = svc.ListExperiments(1, services.ListExperimentsParams{
+	actualResponsesList, pagingResponse, err = svc.ListExperiments(context.Background(), 1, services.ListExperimentsParams{
 		Type:      &testExpType,
 		Status:    &testStatus,
 		StartTime: &testStartTime,
@@ -215,7 +216,7 @@ func testListExperiments(s *ExperimentServiceTestSuite) {
 
 	// Use the same start and end times
 	testExactTimestamp := time.Date(2021, 2, 2, 3, 5, 7, 0, time.UTC)
-	actualResponsesList, pagingResponse, err = svc.ListExperiments(1,
+	actualResponsesList, pagingResponse, err = svc.ListExperiments(context.Background(), 1,
 		services.ListExperimentsParams{
 			StartTime: &testExactTimestamp,
 			EndTime:   &testExactTimestamp,
@@ -226,7 +227,7 @@ func testListExperiments(s *ExperimentServiceTestSuite) {
 	tu.AssertEqualValues(t, []*models.Experiment{s.Experiments[2]}, actualResponsesList)
 
 	// Partial match of segmenter on multiple experiments
-	actualResponsesList, pagingResponse, err = svc.ListExperiments(1,
+	actualResponsesList, pagingResponse, err = svc.ListExperiments(context.Background(), 1,
 		services.ListExperimentsParams{
 			Segment: models.ExperimentSegment{
 				"float_segmenter": float2Segmenter,
@@ -241,7 +242,7 @@ func testListExperiments(s *ExperimentServiceTestSuite) {
 	)
 
 	// Weak match of segmenters
-	actualResponsesList, pagingResponse, err = svc.ListExperiments(1,
+	actualResponsesList, pagingResponse, err = svc.ListExperiments(context.Background(), 1,
 		services.ListExperimentsParams{
 			Segment: models.ExperimentSegment{
 				"float_segmenter": floatSegmenter,
@@ -258,7 +259,7 @@ func testListExperiments(s *ExperimentServiceTestSuite) {
 
 	// Match name or description
 	testDesc := "-1"
-	actualResponsesList, _, err = svc.ListExperiments(1,
+	actualResponsesList, _, err = svc.ListExperiments(context.Background(), 1,
 		services.ListExperimentsParams{
 			Search: &testDesc,
 		},
@@ -267,7 +268,7 @@ func testListExperiments(s *ExperimentServiceTestSuite) {
 	tu.AssertEqualValues(t, []*models.Experiment{s.Experiments[0], s.Experiments[2]}, actualResponsesList)
 
 	// Match friendly status + start time
-	actualResponsesList, _, err = svc.ListExperiments(1,
+	actualResponsesList, _, err = svc.ListExperiments(context.Background(), 1,
 		services.ListExperimentsParams{
 			StatusFriendly: []services.ExperimentStatusFriendly{
 				services.ExperimentStatusFriendlyCompleted,
@@ -281,7 +282,7 @@ func testListExperiments(s *ExperimentServiceTestSuite) {
 	tu.AssertEqualValues(t, []*models.Experiment{s.Experiments[0], s.Experiments[1]}, actualResponsesList)
 
 	// Specify selected fields in ListExperimentsParams
-	actualResponsesList, pagingResponse, err = svc.ListExperiments(1,
+	actualResponsesList, pagingResponse, err = svc.ListExperiments(context.Background(), 1,
 		services.ListExperimentsParams{
 			Fields: &[]models.ExperimentField{
 				models.ExperimentFieldName,
@@ -330,7 +331,7 @@ func testCreateUpdateExperiment(s *ExperimentServiceTestSuite) {
 		"string_segmenter": stringSegmenter,
 	}
 	updatedBy := "integration-test"
-	expResponse, err := svc.CreateExperiment(s.Settings, services.CreateExperimentRequestBody{
+	expResponse, err := svc.CreateExperiment(context.Background(), s.Settings, services.CreateExperimentRequestBody{
 		Description: &description,
 		EndTime:     time.Date(2021, 2, 2, 4, 0, 0, 0, time.UTC),
 		Interval:    &interval,
@@ -373,9 +374,9 @@ func testCreateUpdateExperiment(s *ExperimentServiceTestSuite) {
 	}, *expResponse)
 
 	// Update Experiment
-	s.ExperimentHistoryService.On("CreateExperimentHistory", expResponse).Return(nil, nil)
+	s.ExperimentHistoryService.On("CreateExperimentHistory", mock.Anything, expResponse).Return(nil, nil)
 	newDescription := "New Test description, tier"
-	expResponse, err = svc.UpdateExperiment(s.Settings, experimentId, services.UpdateExperimentRequestBody{
+	expResponse, err = svc.UpdateExperiment(context.Background(), s.Settings, experimentId, services.UpdateExperimentRequestBody{
 		Description: &newDescription,
 		EndTime:     time.Date(2021, 2, 2, 4, 0, 0, 0, time.UTC),
 		Interval:    &interval,
@@ -393,18 +394,18 @@ func testCreateUpdateExperiment(s *ExperimentServiceTestSuite) {
 	s.Suite.Assert().Equal(models.ExperimentStatusActive, expResponse.Status)
 
 	// Disable Experiment
-	s.ExperimentHistoryService.On("CreateExperimentHistory", expResponse).Return(nil, nil)
-	err = svc.DisableExperiment(projectId, experimentId)
+	s.ExperimentHistoryService.On("CreateExperimentHistoryInTx", mock.Anything, expResponse).Return(nil, nil)
+	err = svc.DisableExperiment(context.Background(), projectId, experimentId)
 	s.Suite.Require().NoError(err)
-	exp, err := svc.GetExperiment(projectId, experimentId)
+	exp, err := svc.GetExperiment(context.Background(), projectId, experimentId)
 	s.Suite.Require().NoError(err)
 	s.Suite.Require().Equal(models.ExperimentStatusInactive, exp.Status)
 
 	// Enable Experiment
-	s.ExperimentHistoryService.On("CreateExperimentHistory", exp).Return(nil, nil)
-	err = svc.EnableExperiment(s.Settings, experimentId)
+	s.ExperimentHistoryService.On("CreateExperimentHistory", mock.Anything, exp).Return(nil, nil)
+	err = svc.EnableExperiment(context.Background(), s.Settings, experimentId)
 	s.Suite.Require().NoError(err)
-	exp, err = svc.GetExperiment(projectId, experimentId)
+	exp, err = svc.GetExperiment(context.Background(), projectId, experimentId)
 	s.Suite.Require().NoError(err)
 	s.Suite.Require().Equal(models.ExperimentStatusActive, exp.Status)
 }
@@ -685,12 +686,15 @@ func setupMockSegmenterService() services.SegmenterService {
 		"string_segmenter": rawString2Segmenter,
 	}
 	segmenterSvc := &mocks.SegmenterService{}
-	segmenterSvc.On("GetFormattedSegmenters", models.ExperimentSegmentRaw(nil)).
+	segmenterSvc.
+		On("CoverSegmentGeometries", mock.Anything).
+		Return(func(segment models.ExperimentSegmentRaw) models.ExperimentSegmentRaw { return segment }, nil, nil)
+	segmenterSvc.On("GetFormattedSegmenters", mock.Anything, models.ExperimentSegmentRaw(nil)).
 		Return(map[string]*[]interface{}{}, nil)
-	segmenterSvc.On("GetFormattedSegmenters", models.ExperimentSegmentRaw{}).
+	segmenterSvc.On("GetFormattedSegmenters", mock.Anything, models.ExperimentSegmentRaw{}).
 		Return(map[string]*[]interface{}{}, nil)
 	segmenterSvc.
-		On("GetFormattedSegmenters", models.ExperimentSegmentRaw{
+		On("GetFormattedSegmenters", mock.Anything, models.ExperimentSegmentRaw{
 			"integer_segmenter":   rawIntegerSegmenter,
 			"integer_2_segmenter": rawInteger2Segmenter,
 			"float_segmenter":     rawFloatSegmenter,
@@ -705,27 +709,27 @@ func setupMockSegmenterService() services.SegmenterService {
 			"bool_segmenter":      &respBoolSegmenter,
 		}, nil)
 	segmenterSvc.
-		On("GetFormattedSegmenters", models.ExperimentSegmentRaw{
+		On("GetFormattedSegmenters", mock.Anything, models.ExperimentSegmentRaw{
 			"float_segmenter": rawFloatSegmenter,
 		}).
 		Return(map[string]*[]interface{}{
 			"float_segmenter": &respFloatSegmenter,
 		}, nil)
 	segmenterSvc.
-		On("GetFormattedSegmenters", models.ExperimentSegmentRaw{
+		On("GetFormattedSegmenters", mock.Anything, models.ExperimentSegmentRaw{
 			"float_segmenter": rawFloat2Segmenter,
 		}).
 		Return(map[string]*[]interface{}{
 			"float_segmenter": &respFloat2Segmenter,
 		}, nil)
 	segmenterSvc.
-		On("GetSegmenterTypes", int64(0)).
+		On("GetSegmenterTypes", mock.Anything, int64(0)).
 		Return(
 			nil,
 			gorm.ErrRecordNotFound,
 		)
 	segmenterSvc.
-		On("GetSegmenterTypes", int64(1)).
+		On("GetSegmenterTypes", mock.Anything, int64(1)).
 		Return(
 			map[string]schema.SegmenterType{
 				"integer_segmenter":   schema.SegmenterTypeInteger,
@@ -737,7 +741,7 @@ func setupMockSegmenterService() services.SegmenterService {
 			nil,
 		)
 	segmenterSvc.
-		On("GetSegmenterTypes", int64(2)).
+		On("GetSegmenterTypes", mock.Anything, int64(2)).
 		Return(
 			map[string]schema.SegmenterType{
 				"bool_segmenter": schema.SegmenterTypeBool,
@@ -747,6 +751,7 @@ func setupMockSegmenterService() services.SegmenterService {
 	segmenterSvc.
 		On(
 			"ValidateExperimentSegment",
+			mock.Anything,
 			int64(1),
 			[]string{"string_segmenter"},
 			createExpSegmentRaw).
@@ -756,6 +761,7 @@ func setupMockSegmenterService() services.SegmenterService {
 	segmenterSvc.
 		On(
 			"ValidateSegmentOrthogonality",
+			mock.Anything,
 			int64(1),
 			[]string{"string_segmenter"},
 			createExpSegmentRaw,
@@ -882,21 +888,6 @@ func setupMockValidationService() services.ValidationService {
 	return validationSvc
 }
 
-func setupMockMessageQueueService() messagequeue.MessageQueueService {
-	messageQueueSvc := &mocks.MessageQueueService{}
-	messageQueueSvc.On(
-		"PublishExperimentMessage",
-		"create",
-		mock.Anything,
-	).Return(nil)
-	messageQueueSvc.On(
-		"PublishExperimentMessage",
-		"update",
-		mock.Anything,
-	).Return(nil)
-	return messageQueueSvc
-}
-
 func setupMockTreatmentService() services.TreatmentService {
 	treatmentSvc := &mocks.TreatmentService{}
 	treatmentSvc.On(
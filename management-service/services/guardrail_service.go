@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/xp/management-service/config"
+	"github.com/caraml-dev/xp/management-service/models"
+)
+
+// guardrailEvaluatorLockID is the key of the Postgres advisory lock that ensures that only one
+// replica of the Management Service evaluates the guardrails at any time.
+const guardrailEvaluatorLockID = 7_140_003
+
+type GuardrailService interface {
+	// EvaluateGuardrails computes the mean value of each guardrail metric per treatment, over the units
+	// assigned in the current version of the running experiments, and disables the experiments with a
+	// breached guardrail. It returns the number of experiments that were disabled.
+	EvaluateGuardrails() (int, error)
+	// ListGuardrailBreaches returns the breaches recorded for the experiment, most recent first.
+	ListGuardrailBreaches(ctx context.Context, experiment *models.Experiment) ([]*models.GuardrailBreach, error)
+}
+
+type guardrailService struct {
+	services *Services
+	db       *gorm.DB
+}
+
+func NewGuardrailService(services *Services, db *gorm.DB) GuardrailService {
+	return &guardrailService{
+		services: services,
+		db:       db,
+	}
+}
+
+func (svc *guardrailService) EvaluateGuardrails() (int, error) {
+	disabled := 0
+	var errs []error
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		// Skip this round if another replica is already evaluating
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", guardrailEvaluatorLockID).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var experiments []*models.Experiment
+		// Experiments without guardrails store an empty array or null
+		err := runningExperiments(tx, time.Now()).
+			Where("guardrails @> '[{}]'").
+			Find(&experiments).Error
+		if err != nil {
+			return err
+		}
+
+		for _, experiment := range experiments {
+			// Evaluate each experiment under its own savepoint, so that an error rolls back only that
+			// experiment and does not abort the transaction for the others
+			var breaches []models.GuardrailBreach
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				var err error
+				breaches, err = svc.findBreaches(tx, experiment)
+				if err != nil || len(breaches) == 0 {
+					return err
+				}
+
+				// Record the breaches and disable the experiment atomically. The experiment is disabled through
+				// the regular path, so that the change is versioned and published.
+				if err := tx.Create(&breaches).Error; err != nil {
+					return fmt.Errorf("error recording the guardrail breaches: %w", err)
+				}
+				err = svc.services.ExperimentService.DisableExperimentInTx(
+					tx, int64(experiment.ProjectID), int64(experiment.ID),
+				)
+				if err != nil {
+					return fmt.Errorf("error disabling the experiment: %w", err)
+				}
+				return nil
+			})
+			if err != nil {
+				// Continue with the other experiments, and fail the evaluation once they are done
+				errs = append(errs, fmt.Errorf("error evaluating the guardrails of experiment %d: %w", experiment.ID, err))
+				continue
+			}
+			if len(breaches) == 0 {
+				continue
+			}
+
+			disabled++
+			for _, breach := range breaches {
+				log.Printf("Disabled experiment %d: the mean of metric %s for treatment %s is %v, %s the threshold %v",
+					experiment.ID, breach.Metric, breach.Treatment, breach.Value, breach.Comparator, breach.Threshold)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return disabled, err
+	}
+	return disabled, errors.Join(errs...)
+}
+
+func (svc *guardrailService) ListGuardrailBreaches(ctx context.Context, experiment *models.Experiment) ([]*models.GuardrailBreach, error) {
+	var breaches []*models.GuardrailBreach
+	err := svc.db.WithContext(ctx).Where("experiment_id = ?", experiment.ID).Order("id desc").Find(&breaches).Error
+	if err != nil {
+		return nil, err
+	}
+	return breaches, nil
+}
+
+// findBreaches returns the guardrails of the current version of the experiment that are breached by any treatment
+func (svc *guardrailService) findBreaches(
+	tx *gorm.DB,
+	experiment *models.Experiment,
+) ([]models.GuardrailBreach, error) {
+	breaches := []models.GuardrailBreach{}
+	for _, guardrail := range experiment.Guardrails {
+		var rows []struct {
+			Treatment string
+			Mean      float64
+			Count     int64
+		}
+		err := tx.Table("experiment_assignments AS a").
+			Select("a.treatment, AVG(o.value) AS mean, COUNT(*) AS count").
+			Joins("JOIN metric_observations AS o ON o.experiment_id = a.experiment_id AND o.unit_id = a.unit_id").
+			Where("a.experiment_id = ? AND a.experiment_version = ? AND o.metric = ?",
+				experiment.ID, experiment.Version, guardrail.Metric).
+			Group("a.treatment").
+			Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		sort.Slice(rows, func(i, j int) bool { return rows[i].Treatment < rows[j].Treatment })
+
+		minSampleSize := int64(1)
+		if guardrail.MinSampleSize != nil {
+			minSampleSize = *guardrail.MinSampleSize
+		}
+		for _, row := range rows {
+			if row.Count < minSampleSize || !guardrail.Comparator.IsBreached(row.Mean, guardrail.Threshold) {
+				continue
+			}
+			breaches = append(breaches, models.GuardrailBreach{
+				ExperimentID:      experiment.ID,
+				ExperimentVersion: experiment.Version,
+				Metric:            guardrail.Metric,
+				Treatment:         row.Treatment,
+				Comparator:        guardrail.Comparator,
+				Threshold:         guardrail.Threshold,
+				Value:             row.Mean,
+				SampleSize:        row.Count,
+			})
+		}
+	}
+	return breaches, nil
+}
+
+// GuardrailEvaluator periodically evaluates the guardrails of the running experiments
+type GuardrailEvaluator struct {
+	guardrailService   GuardrailService
+	evaluationInterval time.Duration
+	stopChannel        chan struct{}
+}
+
+// NewGuardrailEvaluator creates a new GuardrailEvaluator that evaluates the guardrails at the configured interval.
+func NewGuardrailEvaluator(cfg config.GuardrailConfig, guardrailService GuardrailService) *GuardrailEvaluator {
+	return &GuardrailEvaluator{
+		guardrailService:   guardrailService,
+		evaluationInterval: cfg.EvaluationInterval,
+		stopChannel:        make(chan struct{}),
+	}
+}
+
+func (e *GuardrailEvaluator) Start() {
+	log.Println("Starting guardrail evaluator...")
+	ticker := time.NewTicker(e.evaluationInterval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := e.guardrailService.EvaluateGuardrails(); err != nil {
+					log.Printf("Error evaluating the guardrails of the experiments: %v", err)
+				}
+			case <-e.stopChannel:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+func (e *GuardrailEvaluator) Stop() {
+	close(e.stopChannel)
+}
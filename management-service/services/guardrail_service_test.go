@@ -0,0 +1,162 @@
+//go:build integration
+
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	tu "github.com/caraml-dev/xp/management-service/internal/testutils"
+	"github.com/caraml-dev/xp/management-service/models"
+	"github.com/caraml-dev/xp/management-service/services"
+	"github.com/caraml-dev/xp/management-service/services/mocks"
+)
+
+type GuardrailServiceTestSuite struct {
+	suite.Suite
+	services.GuardrailService
+	db                *gorm.DB
+	ExperimentService *mocks.ExperimentService
+	Experiments       []*models.Experiment
+	CleanUpFunc       func()
+}
+
+func (s *GuardrailServiceTestSuite) SetupSuite() {
+	s.Suite.T().Log("Setting up GuardrailServiceTestSuite")
+
+	// Create test DB, save the DB clean up function to be executed on tear down
+	db, cleanup, err := tu.CreateTestDB(tu.MigrationsPath)
+	if err != nil {
+		s.Suite.T().Fatalf("Could not create test DB: %v", err)
+	}
+	s.db = db
+	s.CleanUpFunc = cleanup
+
+	// Create running experiments with an error rate guardrail, where the treatment of the "breached"
+	// experiment has a higher error rate than the threshold
+	err = db.Create(&models.Settings{ProjectID: models.ID(1)}).Error
+	if err != nil {
+		s.Suite.T().Fatalf("Could not set up test data: %v", err)
+	}
+	var minSampleSize int64 = 10
+	for i, name := range []string{"healthy", "breached", "no-guardrails"} {
+		experiment := &models.Experiment{
+			ProjectID:  models.ID(1),
+			Name:       name,
+			Type:       models.ExperimentTypeAB,
+			Tier:       models.ExperimentTierDefault,
+			Treatments: models.ExperimentTreatments{{Name: "control"}, {Name: "treatment"}},
+			Guardrails: models.ExperimentGuardrails{
+				{
+					Metric:        "error_rate",
+					Comparator:    models.GuardrailComparatorGreaterThan,
+					Threshold:     0.1,
+					MinSampleSize: &minSampleSize,
+				},
+			},
+			Segment:   models.ExperimentSegment{},
+			Status:    models.ExperimentStatusActive,
+			StartTime: time.Now().Add(-time.Hour),
+			EndTime:   time.Now().Add(time.Hour),
+			UpdatedBy: "test-user",
+			Version:   1,
+		}
+		if i == 2 {
+			experiment.Guardrails = nil
+		}
+		if err := db.Create(experiment).Error; err != nil {
+			s.Suite.T().Fatalf("Could not set up test data: %v", err)
+		}
+		s.Experiments = append(s.Experiments, experiment)
+
+		// 20 units per treatment, with 1 or 5 errors in the treatment
+		treatmentErrors := 1
+		if i > 0 {
+			treatmentErrors = 5
+		}
+		for unit := 0; unit < 40; unit++ {
+			treatment, value := "control", 0.0
+			if unit >= 20 {
+				treatment = "treatment"
+				if unit < 20+treatmentErrors {
+					value = 1
+				}
+			}
+			unitID := fmt.Sprintf("unit-%d", unit)
+			err := db.Create(&models.ExperimentAssignment{
+				ExperimentID:      experiment.ID,
+				ExperimentVersion: 1,
+				UnitID:            unitID,
+				Treatment:         treatment,
+			}).Error
+			if err != nil {
+				s.Suite.T().Fatalf("Could not set up test data: %v", err)
+			}
+			err = db.Create(&models.MetricObservation{
+				ExperimentID: experiment.ID,
+				Metric:       "error_rate",
+				UnitID:       unitID,
+				Value:        value,
+			}).Error
+			if err != nil {
+				s.Suite.T().Fatalf("Could not set up test data: %v", err)
+			}
+		}
+	}
+
+	s.ExperimentService = &mocks.ExperimentService{}
+	s.GuardrailService = services.NewGuardrailService(&services.Services{
+		ExperimentService: s.ExperimentService,
+	}, db)
+}
+
+func (s *GuardrailServiceTestSuite) TearDownSuite() {
+	s.Suite.T().Log("Cleaning up GuardrailServiceTestSuite")
+	s.CleanUpFunc()
+}
+
+func TestGuardrailService(t *testing.T) {
+	suite.Run(t, new(GuardrailServiceTestSuite))
+}
+
+func (s *GuardrailServiceTestSuite) TestEvaluateGuardrails() {
+	s.ExperimentService.On("DisableExperimentInTx", mock.Anything, int64(1), int64(s.Experiments[1].ID)).
+		Return(fmt.Errorf("test disable error")).Once()
+	s.ExperimentService.On("DisableExperimentInTx", mock.Anything, int64(1), int64(s.Experiments[1].ID)).
+		Return(nil).Once()
+
+	// The breaches are not recorded if the experiment cannot be disabled
+	disabled, err := s.GuardrailService.EvaluateGuardrails()
+	s.Suite.Assert().EqualError(err, fmt.Sprintf("error evaluating the guardrails of experiment %d: "+
+		"error disabling the experiment: test disable error", s.Experiments[1].ID))
+	s.Suite.Assert().Equal(0, disabled)
+	breaches, err := s.GuardrailService.ListGuardrailBreaches(context.Background(), s.Experiments[1])
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Empty(breaches)
+
+	disabled, err = s.GuardrailService.EvaluateGuardrails()
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(1, disabled)
+	s.ExperimentService.AssertNumberOfCalls(s.Suite.T(), "DisableExperimentInTx", 2)
+
+	// The reason is recorded for the disabled experiment only
+	breaches, err = s.GuardrailService.ListGuardrailBreaches(context.Background(), s.Experiments[1])
+	s.Suite.Require().NoError(err)
+	s.Suite.Require().Len(breaches, 1)
+	s.Suite.Assert().Equal("error_rate", breaches[0].Metric)
+	s.Suite.Assert().Equal("treatment", breaches[0].Treatment)
+	s.Suite.Assert().Equal(models.GuardrailComparatorGreaterThan, breaches[0].Comparator)
+	s.Suite.Assert().Equal(0.1, breaches[0].Threshold)
+	s.Suite.Assert().Equal(0.25, breaches[0].Value)
+	s.Suite.Assert().Equal(int64(20), breaches[0].SampleSize)
+
+	breaches, err = s.GuardrailService.ListGuardrailBreaches(context.Background(), s.Experiments[0])
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Empty(breaches)
+}
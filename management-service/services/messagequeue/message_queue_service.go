@@ -1,6 +1,7 @@
 package messagequeue
 
 import (
+	"context"
 	"fmt"
 
 	common_mq_config "github.com/caraml-dev/xp/common/messagequeue"
@@ -12,6 +13,9 @@ type MessageQueueService interface {
 	PublishProjectSettingsMessage(updateType string, settings *_pubsub.ProjectSettings) error
 	PublishExperimentMessage(updateType string, experiment *_pubsub.Experiment) error
 	PublishProjectSegmenterMessage(updateType string, segmenter *segmenters.SegmenterConfiguration, projectId int64) error
+	// PublishMessage publishes a payload that has already been serialized, such as one stored in the outbox,
+	// as part of the trace in the given context, if any
+	PublishMessage(ctx context.Context, payload []byte) error
 }
 
 func NewMessageQueueService(mqConfig common_mq_config.MessageQueueConfig) (MessageQueueService, error) {
@@ -0,0 +1,132 @@
+package messagequeue
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	_pubsub "github.com/caraml-dev/xp/common/pubsub"
+	"github.com/caraml-dev/xp/common/segmenters"
+)
+
+// SerializeProjectSettingsMessage converts a project settings change into the payload that is
+// published to the message queue.
+func SerializeProjectSettingsMessage(updateType string, settings *_pubsub.ProjectSettings) ([]byte, error) {
+	switch updateType {
+	case "create":
+		return serializeCreateSettings(settings)
+	case "update":
+		return serializeUpdateSettings(settings)
+	}
+	return nil, fmt.Errorf("invalid update type (%s) for project settings message", updateType)
+}
+
+// SerializeExperimentMessage converts an experiment change into the payload that is published
+// to the message queue.
+func SerializeExperimentMessage(updateType string, experiment *_pubsub.Experiment) ([]byte, error) {
+	switch updateType {
+	case "create":
+		return serializeCreateExperiment(experiment)
+	case "update":
+		return serializeUpdateExperiment(experiment)
+	}
+	return nil, fmt.Errorf("invalid update type (%s) for experiment message", updateType)
+}
+
+// SerializeProjectSegmenterMessage converts a project segmenter change into the payload that is
+// published to the message queue.
+func SerializeProjectSegmenterMessage(
+	updateType string,
+	segmenter *segmenters.SegmenterConfiguration,
+	projectId int64,
+) ([]byte, error) {
+	switch updateType {
+	case "create":
+		return serializeCreateSegmenter(segmenter, projectId)
+	case "update":
+		return serializeUpdateSegmenter(segmenter, projectId)
+	case "delete":
+		return serializeDeleteSegmenter(segmenter, projectId)
+	}
+	return nil, fmt.Errorf("invalid update type (%s) for project segmenter message", updateType)
+}
+
+func serializeCreateExperiment(experiment *_pubsub.Experiment) ([]byte, error) {
+	updateClientState := _pubsub.MessagePublishState{
+		Update: &_pubsub.MessagePublishState_ExperimentCreated{
+			ExperimentCreated: &_pubsub.ExperimentCreated{
+				Experiment: experiment,
+			},
+		},
+	}
+	return proto.Marshal(&updateClientState)
+}
+
+func serializeUpdateExperiment(experiment *_pubsub.Experiment) ([]byte, error) {
+	updateClientState := _pubsub.MessagePublishState{
+		Update: &_pubsub.MessagePublishState_ExperimentUpdated{
+			ExperimentUpdated: &_pubsub.ExperimentUpdated{
+				Experiment: experiment,
+			},
+		},
+	}
+	return proto.Marshal(&updateClientState)
+}
+
+func serializeCreateSettings(settings *_pubsub.ProjectSettings) ([]byte, error) {
+	updateClientState := _pubsub.MessagePublishState{
+		Update: &_pubsub.MessagePublishState_ProjectSettingsCreated{
+			ProjectSettingsCreated: &_pubsub.ProjectSettingsCreated{
+				ProjectSettings: settings,
+			},
+		},
+	}
+	return proto.Marshal(&updateClientState)
+}
+
+func serializeUpdateSettings(settings *_pubsub.ProjectSettings) ([]byte, error) {
+	updateClientState := _pubsub.MessagePublishState{
+		Update: &_pubsub.MessagePublishState_ProjectSettingsUpdated{
+			ProjectSettingsUpdated: &_pubsub.ProjectSettingsUpdated{
+				ProjectSettings: settings,
+			},
+		},
+	}
+	return proto.Marshal(&updateClientState)
+}
+
+func serializeCreateSegmenter(segmenter *segmenters.SegmenterConfiguration, projectId int64) ([]byte, error) {
+	updateClientState := _pubsub.MessagePublishState{
+		Update: &_pubsub.MessagePublishState_ProjectSegmenterCreated{
+			ProjectSegmenterCreated: &segmenters.ProjectSegmenterCreated{
+				ProjectId:        projectId,
+				ProjectSegmenter: segmenter,
+			},
+		},
+	}
+	return proto.Marshal(&updateClientState)
+}
+
+func serializeUpdateSegmenter(segmenter *segmenters.SegmenterConfiguration, projectId int64) ([]byte, error) {
+	updateClientState := _pubsub.MessagePublishState{
+		Update: &_pubsub.MessagePublishState_ProjectSegmenterUpdated{
+			ProjectSegmenterUpdated: &segmenters.ProjectSegmenterUpdated{
+				ProjectId:        projectId,
+				ProjectSegmenter: segmenter,
+			},
+		},
+	}
+	return proto.Marshal(&updateClientState)
+}
+
+func serializeDeleteSegmenter(segmenter *segmenters.SegmenterConfiguration, projectId int64) ([]byte, error) {
+	updateClientState := _pubsub.MessagePublishState{
+		Update: &_pubsub.MessagePublishState_ProjectSegmenterDeleted{
+			ProjectSegmenterDeleted: &segmenters.ProjectSegmenterDeleted{
+				ProjectId:     projectId,
+				SegmenterName: segmenter.Name,
+			},
+		},
+	}
+	return proto.Marshal(&updateClientState)
+}
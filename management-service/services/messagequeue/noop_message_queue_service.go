@@ -1,6 +1,8 @@
 package messagequeue
 
 import (
+	"context"
+
 	_pubsub "github.com/caraml-dev/xp/common/pubsub"
 	"github.com/caraml-dev/xp/common/segmenters"
 )
@@ -24,3 +26,7 @@ func (k *noopMQ) PublishExperimentMessage(updateType string, experiment *_pubsub
 func (k *noopMQ) PublishProjectSegmenterMessage(updateType string, segmenter *segmenters.SegmenterConfiguration, projectId int64) error {
 	return nil
 }
+
+func (k *noopMQ) PublishMessage(ctx context.Context, payload []byte) error {
+	return nil
+}
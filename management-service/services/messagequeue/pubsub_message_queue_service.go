@@ -4,14 +4,20 @@ import (
 	"context"
 
 	"cloud.google.com/go/pubsub"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/iterator"
-	"google.golang.org/protobuf/proto"
 
 	common_mq_config "github.com/caraml-dev/xp/common/messagequeue"
 	_pubsub "github.com/caraml-dev/xp/common/pubsub"
 	"github.com/caraml-dev/xp/common/segmenters"
+	"github.com/caraml-dev/xp/common/tracing"
 )
 
+var tracer = otel.Tracer("github.com/caraml-dev/xp/management-service/services/messagequeue")
+
 type pubSubMessageQueueService struct {
 	context context.Context
 	config  common_mq_config.PubSubConfig
@@ -56,100 +62,20 @@ func NewPubSubMQService(config common_mq_config.PubSubConfig) (MessageQueueServi
 	return &pubSubPublisher, nil
 }
 
-func serializeCreateExperiment(experiment *_pubsub.Experiment) ([]byte, error) {
-	updateClientState := _pubsub.MessagePublishState{
-		Update: &_pubsub.MessagePublishState_ExperimentCreated{
-			ExperimentCreated: &_pubsub.ExperimentCreated{
-				Experiment: experiment,
-			},
-		},
-	}
-	return proto.Marshal(&updateClientState)
-}
-
-func serializeUpdateExperiment(experiment *_pubsub.Experiment) ([]byte, error) {
-	updateClientState := _pubsub.MessagePublishState{
-		Update: &_pubsub.MessagePublishState_ExperimentUpdated{
-			ExperimentUpdated: &_pubsub.ExperimentUpdated{
-				Experiment: experiment,
-			},
-		},
-	}
-	return proto.Marshal(&updateClientState)
-}
-
-func serializeCreateSettings(settings *_pubsub.ProjectSettings) ([]byte, error) {
-	updateClientState := _pubsub.MessagePublishState{
-		Update: &_pubsub.MessagePublishState_ProjectSettingsCreated{
-			ProjectSettingsCreated: &_pubsub.ProjectSettingsCreated{
-				ProjectSettings: settings,
-			},
-		},
-	}
-	return proto.Marshal(&updateClientState)
-}
-
-func serializeUpdateSettings(settings *_pubsub.ProjectSettings) ([]byte, error) {
-	updateClientState := _pubsub.MessagePublishState{
-		Update: &_pubsub.MessagePublishState_ProjectSettingsUpdated{
-			ProjectSettingsUpdated: &_pubsub.ProjectSettingsUpdated{
-				ProjectSettings: settings,
-			},
-		},
-	}
-	return proto.Marshal(&updateClientState)
-}
-
 func (p *pubSubMessageQueueService) PublishProjectSettingsMessage(updateType string, settings *_pubsub.ProjectSettings) error {
-	var payload []byte
-	var err error
-
-	switch updateType {
-	case "create":
-		payload, err = serializeCreateSettings(settings)
-	case "update":
-		payload, err = serializeUpdateSettings(settings)
-	}
-
-	if err != nil {
-		return err
-	}
-	message := pubsub.Message{
-		Data: payload,
-	}
-
-	_, err = p.topic.Publish(p.context, &message).Get(p.context)
+	payload, err := SerializeProjectSettingsMessage(updateType, settings)
 	if err != nil {
 		return err
 	}
-
-	return nil
+	return p.PublishMessage(p.context, payload)
 }
 
 func (p *pubSubMessageQueueService) PublishExperimentMessage(updateType string, experiment *_pubsub.Experiment) error {
-	var payload []byte
-	var err error
-
-	switch updateType {
-	case "create":
-		payload, err = serializeCreateExperiment(experiment)
-	case "update":
-		payload, err = serializeUpdateExperiment(experiment)
-	}
-
+	payload, err := SerializeExperimentMessage(updateType, experiment)
 	if err != nil {
 		return err
 	}
-	message := pubsub.Message{
-		Data: payload,
-	}
-
-	_, err = p.topic.Publish(p.context, &message).Get(p.context)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return p.PublishMessage(p.context, payload)
 }
 
 func (p *pubSubMessageQueueService) PublishProjectSegmenterMessage(
@@ -157,66 +83,31 @@ func (p *pubSubMessageQueueService) PublishProjectSegmenterMessage(
 	segmenter *segmenters.SegmenterConfiguration,
 	projectId int64,
 ) error {
-
-	var payload []byte
-	var err error
-
-	switch updateType {
-	case "create":
-		payload, err = serializeCreateSegmenter(segmenter, projectId)
-	case "update":
-		payload, err = serializeUpdateSegmenter(segmenter, projectId)
-	case "delete":
-		payload, err = serializeDeleteSegmenter(segmenter, projectId)
-	}
-
+	payload, err := SerializeProjectSegmenterMessage(updateType, segmenter, projectId)
 	if err != nil {
 		return err
 	}
+	return p.PublishMessage(p.context, payload)
+}
+
+func (p *pubSubMessageQueueService) PublishMessage(ctx context.Context, payload []byte) error {
+	ctx, span := tracer.Start(ctx, "PubSub.PublishMessage",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(semconv.MessagingSystemGCPPubsub, semconv.MessagingDestinationName(p.config.TopicName)),
+	)
+	// Propagate the trace context to the subscribers in the message attributes
+	attributes := map[string]string{}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(attributes))
 	message := pubsub.Message{
-		Data: payload,
+		Data:       payload,
+		Attributes: attributes,
 	}
 
-	_, err = p.topic.Publish(p.context, &message).Get(p.context)
+	_, err := p.topic.Publish(ctx, &message).Get(ctx)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
-
-func serializeCreateSegmenter(segmenter *segmenters.SegmenterConfiguration, projectId int64) ([]byte, error) {
-	updateClientState := _pubsub.MessagePublishState{
-		Update: &_pubsub.MessagePublishState_ProjectSegmenterCreated{
-			ProjectSegmenterCreated: &segmenters.ProjectSegmenterCreated{
-				ProjectId:        projectId,
-				ProjectSegmenter: segmenter,
-			},
-		},
-	}
-	return proto.Marshal(&updateClientState)
-}
-
-func serializeUpdateSegmenter(segmenter *segmenters.SegmenterConfiguration, projectId int64) ([]byte, error) {
-	updateClientState := _pubsub.MessagePublishState{
-		Update: &_pubsub.MessagePublishState_ProjectSegmenterUpdated{
-			ProjectSegmenterUpdated: &segmenters.ProjectSegmenterUpdated{
-				ProjectId:        projectId,
-				ProjectSegmenter: segmenter,
-			},
-		},
-	}
-	return proto.Marshal(&updateClientState)
-}
-
-func serializeDeleteSegmenter(segmenter *segmenters.SegmenterConfiguration, projectId int64) ([]byte, error) {
-	updateClientState := _pubsub.MessagePublishState{
-		Update: &_pubsub.MessagePublishState_ProjectSegmenterDeleted{
-			ProjectSegmenterDeleted: &segmenters.ProjectSegmenterDeleted{
-				ProjectId:     projectId,
-				SegmenterName: segmenter.Name,
-			},
-		},
-	}
-	return proto.Marshal(&updateClientState)
-}
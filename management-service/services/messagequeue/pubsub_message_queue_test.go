@@ -95,6 +95,9 @@ func (s *PubSubServiceTestSuite) SetupSuite() {
 	respBoolSegmenter := []interface{}{"true"}
 
 	segmenterSvc := &mocks.SegmenterService{}
+	segmenterSvc.
+		On("CoverSegmentGeometries", mock.Anything).
+		Return(func(segment models.ExperimentSegmentRaw) models.ExperimentSegmentRaw { return segment }, nil, nil)
 	segmenterSvc.On("GetFormattedSegmenters", models.ExperimentSegmentRaw{}).Return(map[string]*[]interface{}{}, nil)
 	segmenterSvc.On("GetFormattedSegmenters", models.ExperimentSegmentRaw{
 		"string_segmenter": rawString2Segmenter,
@@ -147,7 +150,7 @@ func (s *PubSubServiceTestSuite) SetupSuite() {
 		On("ValidateExperimentSegment", int64(1), mock.Anything, mock.Anything).
 		Return(nil)
 	segmenterSvc.
-		On("ValidateExperimentVariables", int64(2), mock.Anything).
+		On("ValidateExperimentVariables", int64(2), mock.Anything, mock.Anything).
 		Return(nil)
 	segmenterSvc.
 		On("ValidatePrereqSegmenters", int64(2), mock.Anything).
@@ -161,6 +164,7 @@ func (s *PubSubServiceTestSuite) SetupSuite() {
 
 	expHistSvc := &mocks.ExperimentHistoryService{}
 	expHistSvc.On("CreateExperimentHistory", mock.Anything).Return(nil, nil)
+	expHistSvc.On("CreateExperimentHistoryInTx", mock.Anything, mock.Anything).Return(nil, nil)
 
 	treatmentHistSvc := &mocks.TreatmentHistoryService{}
 	treatmentHistSvc.On("CreateTreatmentHistory", mock.Anything).Return(nil, nil)
@@ -315,7 +319,7 @@ func (s *PubSubServiceTestSuite) TestExperimentServiceCreateUpdatePublish() {
 		"string_segmenter": stringSegmenter,
 	}
 	updatedBy := "integration-test"
-	expResponse, err := svc.CreateExperiment(s.Settings, services.CreateExperimentRequestBody{
+	expResponse, err := svc.CreateExperiment(context.Background(), s.Settings, services.CreateExperimentRequestBody{
 		Description: &description,
 		EndTime:     time.Date(2021, 2, 2, 4, 5, 6, 0, time.UTC),
 		Interval:    &interval,
@@ -329,7 +333,7 @@ func (s *PubSubServiceTestSuite) TestExperimentServiceCreateUpdatePublish() {
 		UpdatedBy:   &updatedBy,
 	})
 	s.Suite.Require().NoError(err)
-	exp, err := svc.GetExperiment(projectId, experimentId)
+	exp, err := svc.GetExperiment(context.Background(), projectId, experimentId)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(t, models.Experiment{
 		Model: models.Model{
@@ -359,7 +363,7 @@ func (s *PubSubServiceTestSuite) TestExperimentServiceCreateUpdatePublish() {
 	s.Suite.Require().Equal(experimentId, publishedUpdate.GetExperimentCreated().GetExperiment().Id)
 
 	// Disable Experiment
-	err = svc.DisableExperiment(projectId, experimentId)
+	err = svc.DisableExperiment(context.Background(), projectId, experimentId)
 	s.Suite.Require().NoError(err)
 
 	// Check Published Update message
@@ -383,6 +387,7 @@ func (s *PubSubServiceTestSuite) TestProjectSettingsServiceCreateUpdatePublish()
 	}
 	randomizationKey := "rand-3"
 	settingsResponse, err := s.ProjectSettingsService.CreateProjectSettings(
+		context.Background(),
 		projectId, services.CreateProjectSettingsRequestBody{
 			Username: userName,
 			Segmenters: models.ProjectSegmenters{
@@ -418,7 +423,7 @@ func (s *PubSubServiceTestSuite) TestProjectSettingsServiceCreateUpdatePublish()
 		},
 	}
 	newRandomizationKey := "rand-4"
-	settingsResponse, err = s.ProjectSettingsService.UpdateProjectSettings(projectId, services.UpdateProjectSettingsRequestBody{
+	settingsResponse, err = s.ProjectSettingsService.UpdateProjectSettings(context.Background(), projectId, services.UpdateProjectSettingsRequestBody{
 		Segmenters:       newSegmenters,
 		RandomizationKey: newRandomizationKey,
 	})
@@ -3,6 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
 	models "github.com/caraml-dev/xp/management-service/models"
 	pagination "github.com/caraml-dev/xp/management-service/pagination"
 	mock "github.com/stretchr/testify/mock"
@@ -15,13 +19,36 @@ type ExperimentHistoryService struct {
 	mock.Mock
 }
 
-// CreateExperimentHistory provides a mock function with given fields: _a0
-func (_m *ExperimentHistoryService) CreateExperimentHistory(_a0 *models.Experiment) (*models.ExperimentHistory, error) {
-	ret := _m.Called(_a0)
+// CreateExperimentHistory provides a mock function with given fields: ctx, _a0
+func (_m *ExperimentHistoryService) CreateExperimentHistory(ctx context.Context, _a0 *models.Experiment) (*models.ExperimentHistory, error) {
+	ret := _m.Called(ctx, _a0)
+
+	var r0 *models.ExperimentHistory
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Experiment) *models.ExperimentHistory); ok {
+		r0 = rf(ctx, _a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ExperimentHistory)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Experiment) error); ok {
+		r1 = rf(ctx, _a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateExperimentHistoryInTx provides a mock function with given fields: tx, experiment
+func (_m *ExperimentHistoryService) CreateExperimentHistoryInTx(tx *gorm.DB, experiment *models.Experiment) (*models.ExperimentHistory, error) {
+	ret := _m.Called(tx, experiment)
 
 	var r0 *models.ExperimentHistory
-	if rf, ok := ret.Get(0).(func(*models.Experiment) *models.ExperimentHistory); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *models.Experiment) *models.ExperimentHistory); ok {
+		r0 = rf(tx, experiment)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.ExperimentHistory)
@@ -29,8 +56,8 @@ func (_m *ExperimentHistoryService) CreateExperimentHistory(_a0 *models.Experime
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.Experiment) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(*gorm.DB, *models.Experiment) error); ok {
+		r1 = rf(tx, experiment)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,13 +65,13 @@ func (_m *ExperimentHistoryService) CreateExperimentHistory(_a0 *models.Experime
 	return r0, r1
 }
 
-// GetDBRecord provides a mock function with given fields: experimentId, version
-func (_m *ExperimentHistoryService) GetDBRecord(experimentId models.ID, version int64) (*models.ExperimentHistory, error) {
-	ret := _m.Called(experimentId, version)
+// GetDBRecord provides a mock function with given fields: ctx, experimentId, version
+func (_m *ExperimentHistoryService) GetDBRecord(ctx context.Context, experimentId models.ID, version int64) (*models.ExperimentHistory, error) {
+	ret := _m.Called(ctx, experimentId, version)
 
 	var r0 *models.ExperimentHistory
-	if rf, ok := ret.Get(0).(func(models.ID, int64) *models.ExperimentHistory); ok {
-		r0 = rf(experimentId, version)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, int64) *models.ExperimentHistory); ok {
+		r0 = rf(ctx, experimentId, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.ExperimentHistory)
@@ -52,8 +79,8 @@ func (_m *ExperimentHistoryService) GetDBRecord(experimentId models.ID, version
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.ID, int64) error); ok {
-		r1 = rf(experimentId, version)
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, int64) error); ok {
+		r1 = rf(ctx, experimentId, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -61,13 +88,13 @@ func (_m *ExperimentHistoryService) GetDBRecord(experimentId models.ID, version
 	return r0, r1
 }
 
-// GetExperimentHistory provides a mock function with given fields: experimentId, version
-func (_m *ExperimentHistoryService) GetExperimentHistory(experimentId int64, version int64) (*models.ExperimentHistory, error) {
-	ret := _m.Called(experimentId, version)
+// GetExperimentHistory provides a mock function with given fields: ctx, experimentId, version
+func (_m *ExperimentHistoryService) GetExperimentHistory(ctx context.Context, experimentId int64, version int64) (*models.ExperimentHistory, error) {
+	ret := _m.Called(ctx, experimentId, version)
 
 	var r0 *models.ExperimentHistory
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.ExperimentHistory); ok {
-		r0 = rf(experimentId, version)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.ExperimentHistory); ok {
+		r0 = rf(ctx, experimentId, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.ExperimentHistory)
@@ -75,8 +102,8 @@ func (_m *ExperimentHistoryService) GetExperimentHistory(experimentId int64, ver
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(experimentId, version)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, experimentId, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -84,13 +111,13 @@ func (_m *ExperimentHistoryService) GetExperimentHistory(experimentId int64, ver
 	return r0, r1
 }
 
-// ListExperimentHistory provides a mock function with given fields: experimentId, params
-func (_m *ExperimentHistoryService) ListExperimentHistory(experimentId int64, params services.ListExperimentHistoryParams) ([]*models.ExperimentHistory, *pagination.Paging, error) {
-	ret := _m.Called(experimentId, params)
+// ListExperimentHistory provides a mock function with given fields: ctx, experimentId, params
+func (_m *ExperimentHistoryService) ListExperimentHistory(ctx context.Context, experimentId int64, params services.ListExperimentHistoryParams) ([]*models.ExperimentHistory, *pagination.Paging, error) {
+	ret := _m.Called(ctx, experimentId, params)
 
 	var r0 []*models.ExperimentHistory
-	if rf, ok := ret.Get(0).(func(int64, services.ListExperimentHistoryParams) []*models.ExperimentHistory); ok {
-		r0 = rf(experimentId, params)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.ListExperimentHistoryParams) []*models.ExperimentHistory); ok {
+		r0 = rf(ctx, experimentId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.ExperimentHistory)
@@ -98,8 +125,8 @@ func (_m *ExperimentHistoryService) ListExperimentHistory(experimentId int64, pa
 	}
 
 	var r1 *pagination.Paging
-	if rf, ok := ret.Get(1).(func(int64, services.ListExperimentHistoryParams) *pagination.Paging); ok {
-		r1 = rf(experimentId, params)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.ListExperimentHistoryParams) *pagination.Paging); ok {
+		r1 = rf(ctx, experimentId, params)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*pagination.Paging)
@@ -107,8 +134,8 @@ func (_m *ExperimentHistoryService) ListExperimentHistory(experimentId int64, pa
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int64, services.ListExperimentHistoryParams) error); ok {
-		r2 = rf(experimentId, params)
+	if rf, ok := ret.Get(2).(func(context.Context, int64, services.ListExperimentHistoryParams) error); ok {
+		r2 = rf(ctx, experimentId, params)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -3,6 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
+	gorm "gorm.io/gorm"
+
 	models "github.com/caraml-dev/xp/management-service/models"
 	pagination "github.com/caraml-dev/xp/management-service/pagination"
 	mock "github.com/stretchr/testify/mock"
@@ -15,13 +19,13 @@ type ExperimentService struct {
 	mock.Mock
 }
 
-// CreateExperiment provides a mock function with given fields: settings, expData
-func (_m *ExperimentService) CreateExperiment(settings models.Settings, expData services.CreateExperimentRequestBody) (*models.Experiment, error) {
-	ret := _m.Called(settings, expData)
+// CreateExperiment provides a mock function with given fields: ctx, settings, expData
+func (_m *ExperimentService) CreateExperiment(ctx context.Context, settings models.Settings, expData services.CreateExperimentRequestBody) (*models.Experiment, error) {
+	ret := _m.Called(ctx, settings, expData)
 
 	var r0 *models.Experiment
-	if rf, ok := ret.Get(0).(func(models.Settings, services.CreateExperimentRequestBody) *models.Experiment); ok {
-		r0 = rf(settings, expData)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Settings, services.CreateExperimentRequestBody) *models.Experiment); ok {
+		r0 = rf(ctx, settings, expData)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Experiment)
@@ -29,8 +33,8 @@ func (_m *ExperimentService) CreateExperiment(settings models.Settings, expData
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.Settings, services.CreateExperimentRequestBody) error); ok {
-		r1 = rf(settings, expData)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Settings, services.CreateExperimentRequestBody) error); ok {
+		r1 = rf(ctx, settings, expData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,13 +42,27 @@ func (_m *ExperimentService) CreateExperiment(settings models.Settings, expData
 	return r0, r1
 }
 
-// DisableExperiment provides a mock function with given fields: projectId, experimentId
-func (_m *ExperimentService) DisableExperiment(projectId int64, experimentId int64) error {
-	ret := _m.Called(projectId, experimentId)
+// DisableExperiment provides a mock function with given fields: ctx, projectId, experimentId
+func (_m *ExperimentService) DisableExperiment(ctx context.Context, projectId int64, experimentId int64) error {
+	ret := _m.Called(ctx, projectId, experimentId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, projectId, experimentId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DisableExperimentInTx provides a mock function with given fields: tx, projectId, experimentId
+func (_m *ExperimentService) DisableExperimentInTx(tx *gorm.DB, projectId int64, experimentId int64) error {
+	ret := _m.Called(tx, projectId, experimentId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
-		r0 = rf(projectId, experimentId)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, int64, int64) error); ok {
+		r0 = rf(tx, projectId, experimentId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,13 +70,13 @@ func (_m *ExperimentService) DisableExperiment(projectId int64, experimentId int
 	return r0
 }
 
-// EnableExperiment provides a mock function with given fields: settings, experimentId
-func (_m *ExperimentService) EnableExperiment(settings models.Settings, experimentId int64) error {
-	ret := _m.Called(settings, experimentId)
+// EnableExperiment provides a mock function with given fields: ctx, settings, experimentId
+func (_m *ExperimentService) EnableExperiment(ctx context.Context, settings models.Settings, experimentId int64) error {
+	ret := _m.Called(ctx, settings, experimentId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(models.Settings, int64) error); ok {
-		r0 = rf(settings, experimentId)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Settings, int64) error); ok {
+		r0 = rf(ctx, settings, experimentId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -66,13 +84,13 @@ func (_m *ExperimentService) EnableExperiment(settings models.Settings, experime
 	return r0
 }
 
-// GetDBRecord provides a mock function with given fields: projectId, experimentId
-func (_m *ExperimentService) GetDBRecord(projectId models.ID, experimentId models.ID) (*models.Experiment, error) {
-	ret := _m.Called(projectId, experimentId)
+// GetDBRecord provides a mock function with given fields: ctx, projectId, experimentId
+func (_m *ExperimentService) GetDBRecord(ctx context.Context, projectId models.ID, experimentId models.ID) (*models.Experiment, error) {
+	ret := _m.Called(ctx, projectId, experimentId)
 
 	var r0 *models.Experiment
-	if rf, ok := ret.Get(0).(func(models.ID, models.ID) *models.Experiment); ok {
-		r0 = rf(projectId, experimentId)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, models.ID) *models.Experiment); ok {
+		r0 = rf(ctx, projectId, experimentId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Experiment)
@@ -80,8 +98,8 @@ func (_m *ExperimentService) GetDBRecord(projectId models.ID, experimentId model
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.ID, models.ID) error); ok {
-		r1 = rf(projectId, experimentId)
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, models.ID) error); ok {
+		r1 = rf(ctx, projectId, experimentId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -89,13 +107,13 @@ func (_m *ExperimentService) GetDBRecord(projectId models.ID, experimentId model
 	return r0, r1
 }
 
-// GetExperiment provides a mock function with given fields: projectId, experimentId
-func (_m *ExperimentService) GetExperiment(projectId int64, experimentId int64) (*models.Experiment, error) {
-	ret := _m.Called(projectId, experimentId)
+// GetExperiment provides a mock function with given fields: ctx, projectId, experimentId
+func (_m *ExperimentService) GetExperiment(ctx context.Context, projectId int64, experimentId int64) (*models.Experiment, error) {
+	ret := _m.Called(ctx, projectId, experimentId)
 
 	var r0 *models.Experiment
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.Experiment); ok {
-		r0 = rf(projectId, experimentId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Experiment); ok {
+		r0 = rf(ctx, projectId, experimentId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Experiment)
@@ -103,8 +121,8 @@ func (_m *ExperimentService) GetExperiment(projectId int64, experimentId int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(projectId, experimentId)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, projectId, experimentId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -112,13 +130,13 @@ func (_m *ExperimentService) GetExperiment(projectId int64, experimentId int64)
 	return r0, r1
 }
 
-// ListAllExperiments provides a mock function with given fields: projectId, params
-func (_m *ExperimentService) ListAllExperiments(projectId models.ID, params services.ListExperimentsParams) ([]*models.Experiment, error) {
-	ret := _m.Called(projectId, params)
+// ListAllExperiments provides a mock function with given fields: ctx, projectId, params
+func (_m *ExperimentService) ListAllExperiments(ctx context.Context, projectId models.ID, params services.ListExperimentsParams) ([]*models.Experiment, error) {
+	ret := _m.Called(ctx, projectId, params)
 
 	var r0 []*models.Experiment
-	if rf, ok := ret.Get(0).(func(models.ID, services.ListExperimentsParams) []*models.Experiment); ok {
-		r0 = rf(projectId, params)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, services.ListExperimentsParams) []*models.Experiment); ok {
+		r0 = rf(ctx, projectId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Experiment)
@@ -126,8 +144,8 @@ func (_m *ExperimentService) ListAllExperiments(projectId models.ID, params serv
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.ID, services.ListExperimentsParams) error); ok {
-		r1 = rf(projectId, params)
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, services.ListExperimentsParams) error); ok {
+		r1 = rf(ctx, projectId, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -135,13 +153,13 @@ func (_m *ExperimentService) ListAllExperiments(projectId models.ID, params serv
 	return r0, r1
 }
 
-// ListExperiments provides a mock function with given fields: projectId, params
-func (_m *ExperimentService) ListExperiments(projectId int64, params services.ListExperimentsParams) ([]*models.Experiment, *pagination.Paging, error) {
-	ret := _m.Called(projectId, params)
+// ListExperiments provides a mock function with given fields: ctx, projectId, params
+func (_m *ExperimentService) ListExperiments(ctx context.Context, projectId int64, params services.ListExperimentsParams) ([]*models.Experiment, *pagination.Paging, error) {
+	ret := _m.Called(ctx, projectId, params)
 
 	var r0 []*models.Experiment
-	if rf, ok := ret.Get(0).(func(int64, services.ListExperimentsParams) []*models.Experiment); ok {
-		r0 = rf(projectId, params)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.ListExperimentsParams) []*models.Experiment); ok {
+		r0 = rf(ctx, projectId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Experiment)
@@ -149,8 +167,8 @@ func (_m *ExperimentService) ListExperiments(projectId int64, params services.Li
 	}
 
 	var r1 *pagination.Paging
-	if rf, ok := ret.Get(1).(func(int64, services.ListExperimentsParams) *pagination.Paging); ok {
-		r1 = rf(projectId, params)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.ListExperimentsParams) *pagination.Paging); ok {
+		r1 = rf(ctx, projectId, params)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*pagination.Paging)
@@ -158,8 +176,8 @@ func (_m *ExperimentService) ListExperiments(projectId int64, params services.Li
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int64, services.ListExperimentsParams) error); ok {
-		r2 = rf(projectId, params)
+	if rf, ok := ret.Get(2).(func(context.Context, int64, services.ListExperimentsParams) error); ok {
+		r2 = rf(ctx, projectId, params)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -181,13 +199,13 @@ func (_m *ExperimentService) RunCustomValidation(experiment models.Experiment, s
 	return r0
 }
 
-// UpdateExperiment provides a mock function with given fields: settings, experimentId, expData
-func (_m *ExperimentService) UpdateExperiment(settings models.Settings, experimentId int64, expData services.UpdateExperimentRequestBody) (*models.Experiment, error) {
-	ret := _m.Called(settings, experimentId, expData)
+// UpdateExperiment provides a mock function with given fields: ctx, settings, experimentId, expData
+func (_m *ExperimentService) UpdateExperiment(ctx context.Context, settings models.Settings, experimentId int64, expData services.UpdateExperimentRequestBody) (*models.Experiment, error) {
+	ret := _m.Called(ctx, settings, experimentId, expData)
 
 	var r0 *models.Experiment
-	if rf, ok := ret.Get(0).(func(models.Settings, int64, services.UpdateExperimentRequestBody) *models.Experiment); ok {
-		r0 = rf(settings, experimentId, expData)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Settings, int64, services.UpdateExperimentRequestBody) *models.Experiment); ok {
+		r0 = rf(ctx, settings, experimentId, expData)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Experiment)
@@ -195,8 +213,8 @@ func (_m *ExperimentService) UpdateExperiment(settings models.Settings, experime
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.Settings, int64, services.UpdateExperimentRequestBody) error); ok {
-		r1 = rf(settings, experimentId, expData)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Settings, int64, services.UpdateExperimentRequestBody) error); ok {
+		r1 = rf(ctx, settings, experimentId, expData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -204,13 +222,13 @@ func (_m *ExperimentService) UpdateExperiment(settings models.Settings, experime
 	return r0, r1
 }
 
-// ValidatePairwiseExperimentOrthogonality provides a mock function with given fields: projectId, experiments, segmenters
-func (_m *ExperimentService) ValidatePairwiseExperimentOrthogonality(projectId int64, experiments []*models.Experiment, segmenters []string) error {
-	ret := _m.Called(projectId, experiments, segmenters)
+// ValidatePairwiseExperimentOrthogonality provides a mock function with given fields: ctx, projectId, experiments, segmenters
+func (_m *ExperimentService) ValidatePairwiseExperimentOrthogonality(ctx context.Context, projectId int64, experiments []*models.Experiment, segmenters []string) error {
+	ret := _m.Called(ctx, projectId, experiments, segmenters)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, []*models.Experiment, []string) error); ok {
-		r0 = rf(projectId, experiments, segmenters)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []*models.Experiment, []string) error); ok {
+		r0 = rf(ctx, projectId, experiments, segmenters)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -218,13 +236,13 @@ func (_m *ExperimentService) ValidatePairwiseExperimentOrthogonality(projectId i
 	return r0
 }
 
-// ValidateProjectExperimentSegmentersExist provides a mock function with given fields: projectId, experiments, segmenters
-func (_m *ExperimentService) ValidateProjectExperimentSegmentersExist(projectId int64, experiments []*models.Experiment, segmenters []string) error {
-	ret := _m.Called(projectId, experiments, segmenters)
+// ValidateProjectExperimentSegmentersExist provides a mock function with given fields: ctx, projectId, experiments, segmenters
+func (_m *ExperimentService) ValidateProjectExperimentSegmentersExist(ctx context.Context, projectId int64, experiments []*models.Experiment, segmenters []string) error {
+	ret := _m.Called(ctx, projectId, experiments, segmenters)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, []*models.Experiment, []string) error); ok {
-		r0 = rf(projectId, experiments, segmenters)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []*models.Experiment, []string) error); ok {
+		r0 = rf(ctx, projectId, experiments, segmenters)
 	} else {
 		r0 = ret.Error(0)
 	}
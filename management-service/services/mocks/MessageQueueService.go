@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	pubsub "github.com/caraml-dev/xp/common/pubsub"
 	mock "github.com/stretchr/testify/mock"
 
@@ -28,6 +30,20 @@ func (_m *MessageQueueService) PublishExperimentMessage(updateType string, exper
 	return r0
 }
 
+// PublishMessage provides a mock function with given fields: ctx, payload
+func (_m *MessageQueueService) PublishMessage(ctx context.Context, payload []byte) error {
+	ret := _m.Called(ctx, payload)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PublishProjectSegmenterMessage provides a mock function with given fields: updateType, segmenter, projectId
 func (_m *MessageQueueService) PublishProjectSegmenterMessage(updateType string, segmenter *segmenters.SegmenterConfiguration, projectId int64) error {
 	ret := _m.Called(updateType, segmenter, projectId)
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/caraml-dev/xp/management-service/models"
 	services "github.com/caraml-dev/xp/management-service/services"
 	mock "github.com/stretchr/testify/mock"
@@ -13,13 +15,36 @@ type ProjectSettingsService struct {
 	mock.Mock
 }
 
-// CreateProjectSettings provides a mock function with given fields: projectId, settings
-func (_m *ProjectSettingsService) CreateProjectSettings(projectId int64, settings services.CreateProjectSettingsRequestBody) (*models.Settings, error) {
-	ret := _m.Called(projectId, settings)
+// CreatePasskey provides a mock function with given fields: ctx, projectId, passkey
+func (_m *ProjectSettingsService) CreatePasskey(ctx context.Context, projectId int64, passkey services.CreatePasskeyRequestBody) (*models.Passkey, error) {
+	ret := _m.Called(ctx, projectId, passkey)
+
+	var r0 *models.Passkey
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.CreatePasskeyRequestBody) *models.Passkey); ok {
+		r0 = rf(ctx, projectId, passkey)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Passkey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.CreatePasskeyRequestBody) error); ok {
+		r1 = rf(ctx, projectId, passkey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateProjectSettings provides a mock function with given fields: ctx, projectId, settings
+func (_m *ProjectSettingsService) CreateProjectSettings(ctx context.Context, projectId int64, settings services.CreateProjectSettingsRequestBody) (*models.Settings, error) {
+	ret := _m.Called(ctx, projectId, settings)
 
 	var r0 *models.Settings
-	if rf, ok := ret.Get(0).(func(int64, services.CreateProjectSettingsRequestBody) *models.Settings); ok {
-		r0 = rf(projectId, settings)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.CreateProjectSettingsRequestBody) *models.Settings); ok {
+		r0 = rf(ctx, projectId, settings)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Settings)
@@ -27,8 +52,8 @@ func (_m *ProjectSettingsService) CreateProjectSettings(projectId int64, setting
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, services.CreateProjectSettingsRequestBody) error); ok {
-		r1 = rf(projectId, settings)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.CreateProjectSettingsRequestBody) error); ok {
+		r1 = rf(ctx, projectId, settings)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -36,13 +61,13 @@ func (_m *ProjectSettingsService) CreateProjectSettings(projectId int64, setting
 	return r0, r1
 }
 
-// GetDBRecord provides a mock function with given fields: projectId
-func (_m *ProjectSettingsService) GetDBRecord(projectId models.ID) (*models.Settings, error) {
-	ret := _m.Called(projectId)
+// GetDBRecord provides a mock function with given fields: ctx, projectId
+func (_m *ProjectSettingsService) GetDBRecord(ctx context.Context, projectId models.ID) (*models.Settings, error) {
+	ret := _m.Called(ctx, projectId)
 
 	var r0 *models.Settings
-	if rf, ok := ret.Get(0).(func(models.ID) *models.Settings); ok {
-		r0 = rf(projectId)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID) *models.Settings); ok {
+		r0 = rf(ctx, projectId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Settings)
@@ -50,8 +75,8 @@ func (_m *ProjectSettingsService) GetDBRecord(projectId models.ID) (*models.Sett
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.ID) error); ok {
-		r1 = rf(projectId)
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID) error); ok {
+		r1 = rf(ctx, projectId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -59,13 +84,13 @@ func (_m *ProjectSettingsService) GetDBRecord(projectId models.ID) (*models.Sett
 	return r0, r1
 }
 
-// GetExperimentVariables provides a mock function with given fields: projectId
-func (_m *ProjectSettingsService) GetExperimentVariables(projectId int64) (*[]string, error) {
-	ret := _m.Called(projectId)
+// GetExperimentVariables provides a mock function with given fields: ctx, projectId
+func (_m *ProjectSettingsService) GetExperimentVariables(ctx context.Context, projectId int64) (*[]string, error) {
+	ret := _m.Called(ctx, projectId)
 
 	var r0 *[]string
-	if rf, ok := ret.Get(0).(func(int64) *[]string); ok {
-		r0 = rf(projectId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *[]string); ok {
+		r0 = rf(ctx, projectId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]string)
@@ -73,8 +98,8 @@ func (_m *ProjectSettingsService) GetExperimentVariables(projectId int64) (*[]st
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(projectId)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, projectId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -82,13 +107,13 @@ func (_m *ProjectSettingsService) GetExperimentVariables(projectId int64) (*[]st
 	return r0, r1
 }
 
-// GetProjectSettings provides a mock function with given fields: projectId
-func (_m *ProjectSettingsService) GetProjectSettings(projectId int64) (*models.Settings, error) {
-	ret := _m.Called(projectId)
+// GetProjectSettings provides a mock function with given fields: ctx, projectId
+func (_m *ProjectSettingsService) GetProjectSettings(ctx context.Context, projectId int64) (*models.Settings, error) {
+	ret := _m.Called(ctx, projectId)
 
 	var r0 *models.Settings
-	if rf, ok := ret.Get(0).(func(int64) *models.Settings); ok {
-		r0 = rf(projectId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) *models.Settings); ok {
+		r0 = rf(ctx, projectId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Settings)
@@ -96,8 +121,8 @@ func (_m *ProjectSettingsService) GetProjectSettings(projectId int64) (*models.S
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(projectId)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, projectId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,13 +130,36 @@ func (_m *ProjectSettingsService) GetProjectSettings(projectId int64) (*models.S
 	return r0, r1
 }
 
-// ListProjects provides a mock function with given fields:
-func (_m *ProjectSettingsService) ListProjects() (*[]models.Project, error) {
-	ret := _m.Called()
+// ListPasskeys provides a mock function with given fields: ctx, projectId
+func (_m *ProjectSettingsService) ListPasskeys(ctx context.Context, projectId int64) ([]models.Passkey, error) {
+	ret := _m.Called(ctx, projectId)
+
+	var r0 []models.Passkey
+	if rf, ok := ret.Get(0).(func(context.Context, int64) []models.Passkey); ok {
+		r0 = rf(ctx, projectId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Passkey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, projectId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListProjects provides a mock function with given fields: ctx
+func (_m *ProjectSettingsService) ListProjects(ctx context.Context) (*[]models.Project, error) {
+	ret := _m.Called(ctx)
 
 	var r0 *[]models.Project
-	if rf, ok := ret.Get(0).(func() *[]models.Project); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *[]models.Project); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]models.Project)
@@ -119,8 +167,8 @@ func (_m *ProjectSettingsService) ListProjects() (*[]models.Project, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -128,13 +176,27 @@ func (_m *ProjectSettingsService) ListProjects() (*[]models.Project, error) {
 	return r0, r1
 }
 
-// UpdateProjectSettings provides a mock function with given fields: projectId, settings
-func (_m *ProjectSettingsService) UpdateProjectSettings(projectId int64, settings services.UpdateProjectSettingsRequestBody) (*models.Settings, error) {
-	ret := _m.Called(projectId, settings)
+// RevokePasskey provides a mock function with given fields: ctx, projectId, name
+func (_m *ProjectSettingsService) RevokePasskey(ctx context.Context, projectId int64, name string) error {
+	ret := _m.Called(ctx, projectId, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, projectId, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProjectSettings provides a mock function with given fields: ctx, projectId, settings
+func (_m *ProjectSettingsService) UpdateProjectSettings(ctx context.Context, projectId int64, settings services.UpdateProjectSettingsRequestBody) (*models.Settings, error) {
+	ret := _m.Called(ctx, projectId, settings)
 
 	var r0 *models.Settings
-	if rf, ok := ret.Get(0).(func(int64, services.UpdateProjectSettingsRequestBody) *models.Settings); ok {
-		r0 = rf(projectId, settings)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.UpdateProjectSettingsRequestBody) *models.Settings); ok {
+		r0 = rf(ctx, projectId, settings)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Settings)
@@ -142,8 +204,8 @@ func (_m *ProjectSettingsService) UpdateProjectSettings(projectId int64, setting
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, services.UpdateProjectSettingsRequestBody) error); ok {
-		r1 = rf(projectId, settings)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.UpdateProjectSettingsRequestBody) error); ok {
+		r1 = rf(ctx, projectId, settings)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/caraml-dev/xp/management-service/models"
 	pagination "github.com/caraml-dev/xp/management-service/pagination"
 	mock "github.com/stretchr/testify/mock"
@@ -15,13 +17,13 @@ type SegmentHistoryService struct {
 	mock.Mock
 }
 
-// CreateSegmentHistory provides a mock function with given fields: _a0
-func (_m *SegmentHistoryService) CreateSegmentHistory(_a0 *models.Segment) (*models.SegmentHistory, error) {
-	ret := _m.Called(_a0)
+// CreateSegmentHistory provides a mock function with given fields: ctx, _a0
+func (_m *SegmentHistoryService) CreateSegmentHistory(ctx context.Context, _a0 *models.Segment) (*models.SegmentHistory, error) {
+	ret := _m.Called(ctx, _a0)
 
 	var r0 *models.SegmentHistory
-	if rf, ok := ret.Get(0).(func(*models.Segment) *models.SegmentHistory); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Segment) *models.SegmentHistory); ok {
+		r0 = rf(ctx, _a0)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.SegmentHistory)
@@ -29,8 +31,8 @@ func (_m *SegmentHistoryService) CreateSegmentHistory(_a0 *models.Segment) (*mod
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.Segment) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Segment) error); ok {
+		r1 = rf(ctx, _a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,13 +40,13 @@ func (_m *SegmentHistoryService) CreateSegmentHistory(_a0 *models.Segment) (*mod
 	return r0, r1
 }
 
-// DeleteSegmentHistory provides a mock function with given fields: segmentId
-func (_m *SegmentHistoryService) DeleteSegmentHistory(segmentId int64) error {
-	ret := _m.Called(segmentId)
+// DeleteSegmentHistory provides a mock function with given fields: ctx, segmentId
+func (_m *SegmentHistoryService) DeleteSegmentHistory(ctx context.Context, segmentId int64) error {
+	ret := _m.Called(ctx, segmentId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(segmentId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, segmentId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,13 +54,13 @@ func (_m *SegmentHistoryService) DeleteSegmentHistory(segmentId int64) error {
 	return r0
 }
 
-// GetDBRecord provides a mock function with given fields: segmentId, version
-func (_m *SegmentHistoryService) GetDBRecord(segmentId models.ID, version int64) (*models.SegmentHistory, error) {
-	ret := _m.Called(segmentId, version)
+// GetDBRecord provides a mock function with given fields: ctx, segmentId, version
+func (_m *SegmentHistoryService) GetDBRecord(ctx context.Context, segmentId models.ID, version int64) (*models.SegmentHistory, error) {
+	ret := _m.Called(ctx, segmentId, version)
 
 	var r0 *models.SegmentHistory
-	if rf, ok := ret.Get(0).(func(models.ID, int64) *models.SegmentHistory); ok {
-		r0 = rf(segmentId, version)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, int64) *models.SegmentHistory); ok {
+		r0 = rf(ctx, segmentId, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.SegmentHistory)
@@ -66,8 +68,8 @@ func (_m *SegmentHistoryService) GetDBRecord(segmentId models.ID, version int64)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.ID, int64) error); ok {
-		r1 = rf(segmentId, version)
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, int64) error); ok {
+		r1 = rf(ctx, segmentId, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,13 +77,13 @@ func (_m *SegmentHistoryService) GetDBRecord(segmentId models.ID, version int64)
 	return r0, r1
 }
 
-// GetSegmentHistory provides a mock function with given fields: segmentId, version
-func (_m *SegmentHistoryService) GetSegmentHistory(segmentId int64, version int64) (*models.SegmentHistory, error) {
-	ret := _m.Called(segmentId, version)
+// GetSegmentHistory provides a mock function with given fields: ctx, segmentId, version
+func (_m *SegmentHistoryService) GetSegmentHistory(ctx context.Context, segmentId int64, version int64) (*models.SegmentHistory, error) {
+	ret := _m.Called(ctx, segmentId, version)
 
 	var r0 *models.SegmentHistory
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.SegmentHistory); ok {
-		r0 = rf(segmentId, version)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.SegmentHistory); ok {
+		r0 = rf(ctx, segmentId, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.SegmentHistory)
@@ -89,8 +91,8 @@ func (_m *SegmentHistoryService) GetSegmentHistory(segmentId int64, version int6
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(segmentId, version)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, segmentId, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,13 +100,13 @@ func (_m *SegmentHistoryService) GetSegmentHistory(segmentId int64, version int6
 	return r0, r1
 }
 
-// ListSegmentHistory provides a mock function with given fields: segmentId, params
-func (_m *SegmentHistoryService) ListSegmentHistory(segmentId int64, params services.ListSegmentHistoryParams) ([]*models.SegmentHistory, *pagination.Paging, error) {
-	ret := _m.Called(segmentId, params)
+// ListSegmentHistory provides a mock function with given fields: ctx, segmentId, params
+func (_m *SegmentHistoryService) ListSegmentHistory(ctx context.Context, segmentId int64, params services.ListSegmentHistoryParams) ([]*models.SegmentHistory, *pagination.Paging, error) {
+	ret := _m.Called(ctx, segmentId, params)
 
 	var r0 []*models.SegmentHistory
-	if rf, ok := ret.Get(0).(func(int64, services.ListSegmentHistoryParams) []*models.SegmentHistory); ok {
-		r0 = rf(segmentId, params)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.ListSegmentHistoryParams) []*models.SegmentHistory); ok {
+		r0 = rf(ctx, segmentId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.SegmentHistory)
@@ -112,8 +114,8 @@ func (_m *SegmentHistoryService) ListSegmentHistory(segmentId int64, params serv
 	}
 
 	var r1 *pagination.Paging
-	if rf, ok := ret.Get(1).(func(int64, services.ListSegmentHistoryParams) *pagination.Paging); ok {
-		r1 = rf(segmentId, params)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.ListSegmentHistoryParams) *pagination.Paging); ok {
+		r1 = rf(ctx, segmentId, params)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*pagination.Paging)
@@ -121,8 +123,8 @@ func (_m *SegmentHistoryService) ListSegmentHistory(segmentId int64, params serv
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int64, services.ListSegmentHistoryParams) error); ok {
-		r2 = rf(segmentId, params)
+	if rf, ok := ret.Get(2).(func(context.Context, int64, services.ListSegmentHistoryParams) error); ok {
+		r2 = rf(ctx, segmentId, params)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/caraml-dev/xp/management-service/models"
 	pagination "github.com/caraml-dev/xp/management-service/pagination"
 	mock "github.com/stretchr/testify/mock"
@@ -15,13 +17,13 @@ type SegmentService struct {
 	mock.Mock
 }
 
-// CreateSegment provides a mock function with given fields: settings, segmentData
-func (_m *SegmentService) CreateSegment(settings models.Settings, segmentData services.CreateSegmentRequestBody) (*models.Segment, error) {
-	ret := _m.Called(settings, segmentData)
+// CreateSegment provides a mock function with given fields: ctx, settings, segmentData
+func (_m *SegmentService) CreateSegment(ctx context.Context, settings models.Settings, segmentData services.CreateSegmentRequestBody) (*models.Segment, error) {
+	ret := _m.Called(ctx, settings, segmentData)
 
 	var r0 *models.Segment
-	if rf, ok := ret.Get(0).(func(models.Settings, services.CreateSegmentRequestBody) *models.Segment); ok {
-		r0 = rf(settings, segmentData)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Settings, services.CreateSegmentRequestBody) *models.Segment); ok {
+		r0 = rf(ctx, settings, segmentData)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Segment)
@@ -29,8 +31,8 @@ func (_m *SegmentService) CreateSegment(settings models.Settings, segmentData se
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.Settings, services.CreateSegmentRequestBody) error); ok {
-		r1 = rf(settings, segmentData)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Settings, services.CreateSegmentRequestBody) error); ok {
+		r1 = rf(ctx, settings, segmentData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,13 +40,13 @@ func (_m *SegmentService) CreateSegment(settings models.Settings, segmentData se
 	return r0, r1
 }
 
-// DeleteSegment provides a mock function with given fields: projectId, segmentId
-func (_m *SegmentService) DeleteSegment(projectId int64, segmentId int64) error {
-	ret := _m.Called(projectId, segmentId)
+// DeleteSegment provides a mock function with given fields: ctx, projectId, segmentId
+func (_m *SegmentService) DeleteSegment(ctx context.Context, projectId int64, segmentId int64) error {
+	ret := _m.Called(ctx, projectId, segmentId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
-		r0 = rf(projectId, segmentId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, projectId, segmentId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,13 +54,13 @@ func (_m *SegmentService) DeleteSegment(projectId int64, segmentId int64) error
 	return r0
 }
 
-// GetDBRecord provides a mock function with given fields: projectId, segmentId
-func (_m *SegmentService) GetDBRecord(projectId models.ID, segmentId models.ID) (*models.Segment, error) {
-	ret := _m.Called(projectId, segmentId)
+// GetDBRecord provides a mock function with given fields: ctx, projectId, segmentId
+func (_m *SegmentService) GetDBRecord(ctx context.Context, projectId models.ID, segmentId models.ID) (*models.Segment, error) {
+	ret := _m.Called(ctx, projectId, segmentId)
 
 	var r0 *models.Segment
-	if rf, ok := ret.Get(0).(func(models.ID, models.ID) *models.Segment); ok {
-		r0 = rf(projectId, segmentId)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, models.ID) *models.Segment); ok {
+		r0 = rf(ctx, projectId, segmentId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Segment)
@@ -66,8 +68,8 @@ func (_m *SegmentService) GetDBRecord(projectId models.ID, segmentId models.ID)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.ID, models.ID) error); ok {
-		r1 = rf(projectId, segmentId)
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, models.ID) error); ok {
+		r1 = rf(ctx, projectId, segmentId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,13 +77,13 @@ func (_m *SegmentService) GetDBRecord(projectId models.ID, segmentId models.ID)
 	return r0, r1
 }
 
-// GetSegment provides a mock function with given fields: projectId, segmentId
-func (_m *SegmentService) GetSegment(projectId int64, segmentId int64) (*models.Segment, error) {
-	ret := _m.Called(projectId, segmentId)
+// GetSegment provides a mock function with given fields: ctx, projectId, segmentId
+func (_m *SegmentService) GetSegment(ctx context.Context, projectId int64, segmentId int64) (*models.Segment, error) {
+	ret := _m.Called(ctx, projectId, segmentId)
 
 	var r0 *models.Segment
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.Segment); ok {
-		r0 = rf(projectId, segmentId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Segment); ok {
+		r0 = rf(ctx, projectId, segmentId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Segment)
@@ -89,8 +91,8 @@ func (_m *SegmentService) GetSegment(projectId int64, segmentId int64) (*models.
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(projectId, segmentId)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, projectId, segmentId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,13 +100,13 @@ func (_m *SegmentService) GetSegment(projectId int64, segmentId int64) (*models.
 	return r0, r1
 }
 
-// ListSegments provides a mock function with given fields: projectId, params
-func (_m *SegmentService) ListSegments(projectId int64, params services.ListSegmentsParams) ([]*models.Segment, *pagination.Paging, error) {
-	ret := _m.Called(projectId, params)
+// ListSegments provides a mock function with given fields: ctx, projectId, params
+func (_m *SegmentService) ListSegments(ctx context.Context, projectId int64, params services.ListSegmentsParams) ([]*models.Segment, *pagination.Paging, error) {
+	ret := _m.Called(ctx, projectId, params)
 
 	var r0 []*models.Segment
-	if rf, ok := ret.Get(0).(func(int64, services.ListSegmentsParams) []*models.Segment); ok {
-		r0 = rf(projectId, params)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.ListSegmentsParams) []*models.Segment); ok {
+		r0 = rf(ctx, projectId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Segment)
@@ -112,8 +114,8 @@ func (_m *SegmentService) ListSegments(projectId int64, params services.ListSegm
 	}
 
 	var r1 *pagination.Paging
-	if rf, ok := ret.Get(1).(func(int64, services.ListSegmentsParams) *pagination.Paging); ok {
-		r1 = rf(projectId, params)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.ListSegmentsParams) *pagination.Paging); ok {
+		r1 = rf(ctx, projectId, params)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*pagination.Paging)
@@ -121,8 +123,8 @@ func (_m *SegmentService) ListSegments(projectId int64, params services.ListSegm
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int64, services.ListSegmentsParams) error); ok {
-		r2 = rf(projectId, params)
+	if rf, ok := ret.Get(2).(func(context.Context, int64, services.ListSegmentsParams) error); ok {
+		r2 = rf(ctx, projectId, params)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -130,13 +132,13 @@ func (_m *SegmentService) ListSegments(projectId int64, params services.ListSegm
 	return r0, r1, r2
 }
 
-// UpdateSegment provides a mock function with given fields: settings, segmentId, segmentData
-func (_m *SegmentService) UpdateSegment(settings models.Settings, segmentId int64, segmentData services.UpdateSegmentRequestBody) (*models.Segment, error) {
-	ret := _m.Called(settings, segmentId, segmentData)
+// UpdateSegment provides a mock function with given fields: ctx, settings, segmentId, segmentData
+func (_m *SegmentService) UpdateSegment(ctx context.Context, settings models.Settings, segmentId int64, segmentData services.UpdateSegmentRequestBody) (*models.Segment, error) {
+	ret := _m.Called(ctx, settings, segmentId, segmentData)
 
 	var r0 *models.Segment
-	if rf, ok := ret.Get(0).(func(models.Settings, int64, services.UpdateSegmentRequestBody) *models.Segment); ok {
-		r0 = rf(settings, segmentId, segmentData)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Settings, int64, services.UpdateSegmentRequestBody) *models.Segment); ok {
+		r0 = rf(ctx, settings, segmentId, segmentData)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Segment)
@@ -144,8 +146,8 @@ func (_m *SegmentService) UpdateSegment(settings models.Settings, segmentId int6
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.Settings, int64, services.UpdateSegmentRequestBody) error); ok {
-		r1 = rf(settings, segmentId, segmentData)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Settings, int64, services.UpdateSegmentRequestBody) error); ok {
+		r1 = rf(ctx, settings, segmentId, segmentData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	schema "github.com/caraml-dev/xp/common/api/schema"
 	models "github.com/caraml-dev/xp/management-service/models"
 	mock "github.com/stretchr/testify/mock"
@@ -17,13 +19,45 @@ type SegmenterService struct {
 	mock.Mock
 }
 
-// CreateCustomSegmenter provides a mock function with given fields: projectId, customSegmenterData
-func (_m *SegmenterService) CreateCustomSegmenter(projectId int64, customSegmenterData services.CreateCustomSegmenterRequestBody) (*models.CustomSegmenter, error) {
-	ret := _m.Called(projectId, customSegmenterData)
+// CoverSegmentGeometries provides a mock function with given fields: expSegment
+func (_m *SegmenterService) CoverSegmentGeometries(expSegment models.ExperimentSegmentRaw) (models.ExperimentSegmentRaw, *models.S2Covering, error) {
+	ret := _m.Called(expSegment)
+
+	var r0 models.ExperimentSegmentRaw
+	if rf, ok := ret.Get(0).(func(models.ExperimentSegmentRaw) models.ExperimentSegmentRaw); ok {
+		r0 = rf(expSegment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.ExperimentSegmentRaw)
+		}
+	}
+
+	var r1 *models.S2Covering
+	if rf, ok := ret.Get(1).(func(models.ExperimentSegmentRaw) *models.S2Covering); ok {
+		r1 = rf(expSegment)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*models.S2Covering)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(models.ExperimentSegmentRaw) error); ok {
+		r2 = rf(expSegment)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// CreateCustomSegmenter provides a mock function with given fields: ctx, projectId, customSegmenterData
+func (_m *SegmenterService) CreateCustomSegmenter(ctx context.Context, projectId int64, customSegmenterData services.CreateCustomSegmenterRequestBody) (*models.CustomSegmenter, error) {
+	ret := _m.Called(ctx, projectId, customSegmenterData)
 
 	var r0 *models.CustomSegmenter
-	if rf, ok := ret.Get(0).(func(int64, services.CreateCustomSegmenterRequestBody) *models.CustomSegmenter); ok {
-		r0 = rf(projectId, customSegmenterData)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.CreateCustomSegmenterRequestBody) *models.CustomSegmenter); ok {
+		r0 = rf(ctx, projectId, customSegmenterData)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.CustomSegmenter)
@@ -31,8 +65,8 @@ func (_m *SegmenterService) CreateCustomSegmenter(projectId int64, customSegment
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, services.CreateCustomSegmenterRequestBody) error); ok {
-		r1 = rf(projectId, customSegmenterData)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.CreateCustomSegmenterRequestBody) error); ok {
+		r1 = rf(ctx, projectId, customSegmenterData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -40,13 +74,13 @@ func (_m *SegmenterService) CreateCustomSegmenter(projectId int64, customSegment
 	return r0, r1
 }
 
-// DeleteCustomSegmenter provides a mock function with given fields: projectId, name
-func (_m *SegmenterService) DeleteCustomSegmenter(projectId int64, name string) error {
-	ret := _m.Called(projectId, name)
+// DeleteCustomSegmenter provides a mock function with given fields: ctx, projectId, name
+func (_m *SegmenterService) DeleteCustomSegmenter(ctx context.Context, projectId int64, name string) error {
+	ret := _m.Called(ctx, projectId, name)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
-		r0 = rf(projectId, name)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, projectId, name)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -54,13 +88,13 @@ func (_m *SegmenterService) DeleteCustomSegmenter(projectId int64, name string)
 	return r0
 }
 
-// GetCustomSegmenter provides a mock function with given fields: projectId, name
-func (_m *SegmenterService) GetCustomSegmenter(projectId int64, name string) (*models.CustomSegmenter, error) {
-	ret := _m.Called(projectId, name)
+// GetCustomSegmenter provides a mock function with given fields: ctx, projectId, name
+func (_m *SegmenterService) GetCustomSegmenter(ctx context.Context, projectId int64, name string) (*models.CustomSegmenter, error) {
+	ret := _m.Called(ctx, projectId, name)
 
 	var r0 *models.CustomSegmenter
-	if rf, ok := ret.Get(0).(func(int64, string) *models.CustomSegmenter); ok {
-		r0 = rf(projectId, name)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *models.CustomSegmenter); ok {
+		r0 = rf(ctx, projectId, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.CustomSegmenter)
@@ -68,8 +102,8 @@ func (_m *SegmenterService) GetCustomSegmenter(projectId int64, name string) (*m
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
-		r1 = rf(projectId, name)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, projectId, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -77,13 +111,13 @@ func (_m *SegmenterService) GetCustomSegmenter(projectId int64, name string) (*m
 	return r0, r1
 }
 
-// GetDBRecord provides a mock function with given fields: projectId, name
-func (_m *SegmenterService) GetDBRecord(projectId models.ID, name string) (*models.CustomSegmenter, error) {
-	ret := _m.Called(projectId, name)
+// GetDBRecord provides a mock function with given fields: ctx, projectId, name
+func (_m *SegmenterService) GetDBRecord(ctx context.Context, projectId models.ID, name string) (*models.CustomSegmenter, error) {
+	ret := _m.Called(ctx, projectId, name)
 
 	var r0 *models.CustomSegmenter
-	if rf, ok := ret.Get(0).(func(models.ID, string) *models.CustomSegmenter); ok {
-		r0 = rf(projectId, name)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, string) *models.CustomSegmenter); ok {
+		r0 = rf(ctx, projectId, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.CustomSegmenter)
@@ -91,8 +125,8 @@ func (_m *SegmenterService) GetDBRecord(projectId models.ID, name string) (*mode
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.ID, string) error); ok {
-		r1 = rf(projectId, name)
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, string) error); ok {
+		r1 = rf(ctx, projectId, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -100,13 +134,13 @@ func (_m *SegmenterService) GetDBRecord(projectId models.ID, name string) (*mode
 	return r0, r1
 }
 
-// GetFormattedSegmenters provides a mock function with given fields: projectId, expSegment
-func (_m *SegmenterService) GetFormattedSegmenters(projectId int64, expSegment models.ExperimentSegmentRaw) (map[string]*[]interface{}, error) {
-	ret := _m.Called(projectId, expSegment)
+// GetFormattedSegmenters provides a mock function with given fields: ctx, projectId, expSegment
+func (_m *SegmenterService) GetFormattedSegmenters(ctx context.Context, projectId int64, expSegment models.ExperimentSegmentRaw) (map[string]*[]interface{}, error) {
+	ret := _m.Called(ctx, projectId, expSegment)
 
 	var r0 map[string]*[]interface{}
-	if rf, ok := ret.Get(0).(func(int64, models.ExperimentSegmentRaw) map[string]*[]interface{}); ok {
-		r0 = rf(projectId, expSegment)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.ExperimentSegmentRaw) map[string]*[]interface{}); ok {
+		r0 = rf(ctx, projectId, expSegment)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string]*[]interface{})
@@ -114,8 +148,8 @@ func (_m *SegmenterService) GetFormattedSegmenters(projectId int64, expSegment m
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, models.ExperimentSegmentRaw) error); ok {
-		r1 = rf(projectId, expSegment)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, models.ExperimentSegmentRaw) error); ok {
+		r1 = rf(ctx, projectId, expSegment)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -123,13 +157,13 @@ func (_m *SegmenterService) GetFormattedSegmenters(projectId int64, expSegment m
 	return r0, r1
 }
 
-// GetSegmenter provides a mock function with given fields: projectId, name
-func (_m *SegmenterService) GetSegmenter(projectId int64, name string) (*schema.Segmenter, error) {
-	ret := _m.Called(projectId, name)
+// GetSegmenter provides a mock function with given fields: ctx, projectId, name
+func (_m *SegmenterService) GetSegmenter(ctx context.Context, projectId int64, name string) (*schema.Segmenter, error) {
+	ret := _m.Called(ctx, projectId, name)
 
 	var r0 *schema.Segmenter
-	if rf, ok := ret.Get(0).(func(int64, string) *schema.Segmenter); ok {
-		r0 = rf(projectId, name)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) *schema.Segmenter); ok {
+		r0 = rf(ctx, projectId, name)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*schema.Segmenter)
@@ -137,8 +171,8 @@ func (_m *SegmenterService) GetSegmenter(projectId int64, name string) (*schema.
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
-		r1 = rf(projectId, name)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string) error); ok {
+		r1 = rf(ctx, projectId, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -146,13 +180,13 @@ func (_m *SegmenterService) GetSegmenter(projectId int64, name string) (*schema.
 	return r0, r1
 }
 
-// GetSegmenterConfigurations provides a mock function with given fields: projectId, segmenterNames
-func (_m *SegmenterService) GetSegmenterConfigurations(projectId int64, segmenterNames []string) ([]*segmenters.SegmenterConfiguration, error) {
-	ret := _m.Called(projectId, segmenterNames)
+// GetSegmenterConfigurations provides a mock function with given fields: ctx, projectId, segmenterNames
+func (_m *SegmenterService) GetSegmenterConfigurations(ctx context.Context, projectId int64, segmenterNames []string) ([]*segmenters.SegmenterConfiguration, error) {
+	ret := _m.Called(ctx, projectId, segmenterNames)
 
 	var r0 []*segmenters.SegmenterConfiguration
-	if rf, ok := ret.Get(0).(func(int64, []string) []*segmenters.SegmenterConfiguration); ok {
-		r0 = rf(projectId, segmenterNames)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) []*segmenters.SegmenterConfiguration); ok {
+		r0 = rf(ctx, projectId, segmenterNames)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*segmenters.SegmenterConfiguration)
@@ -160,8 +194,8 @@ func (_m *SegmenterService) GetSegmenterConfigurations(projectId int64, segmente
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, []string) error); ok {
-		r1 = rf(projectId, segmenterNames)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, []string) error); ok {
+		r1 = rf(ctx, projectId, segmenterNames)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -169,13 +203,13 @@ func (_m *SegmenterService) GetSegmenterConfigurations(projectId int64, segmente
 	return r0, r1
 }
 
-// GetSegmenterTypes provides a mock function with given fields: projectId
-func (_m *SegmenterService) GetSegmenterTypes(projectId int64) (map[string]schema.SegmenterType, error) {
-	ret := _m.Called(projectId)
+// GetSegmenterTypes provides a mock function with given fields: ctx, projectId
+func (_m *SegmenterService) GetSegmenterTypes(ctx context.Context, projectId int64) (map[string]schema.SegmenterType, error) {
+	ret := _m.Called(ctx, projectId)
 
 	var r0 map[string]schema.SegmenterType
-	if rf, ok := ret.Get(0).(func(int64) map[string]schema.SegmenterType); ok {
-		r0 = rf(projectId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) map[string]schema.SegmenterType); ok {
+		r0 = rf(ctx, projectId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string]schema.SegmenterType)
@@ -183,8 +217,8 @@ func (_m *SegmenterService) GetSegmenterTypes(projectId int64) (map[string]schem
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64) error); ok {
-		r1 = rf(projectId)
+	if rf, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = rf(ctx, projectId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -215,13 +249,13 @@ func (_m *SegmenterService) ListGlobalSegmenters() ([]*schema.Segmenter, error)
 	return r0, r1
 }
 
-// ListSegmenters provides a mock function with given fields: projectId, params
-func (_m *SegmenterService) ListSegmenters(projectId int64, params services.ListSegmentersParams) ([]*schema.Segmenter, error) {
-	ret := _m.Called(projectId, params)
+// ListSegmenters provides a mock function with given fields: ctx, projectId, params
+func (_m *SegmenterService) ListSegmenters(ctx context.Context, projectId int64, params services.ListSegmentersParams) ([]*schema.Segmenter, error) {
+	ret := _m.Called(ctx, projectId, params)
 
 	var r0 []*schema.Segmenter
-	if rf, ok := ret.Get(0).(func(int64, services.ListSegmentersParams) []*schema.Segmenter); ok {
-		r0 = rf(projectId, params)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.ListSegmentersParams) []*schema.Segmenter); ok {
+		r0 = rf(ctx, projectId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*schema.Segmenter)
@@ -229,8 +263,8 @@ func (_m *SegmenterService) ListSegmenters(projectId int64, params services.List
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, services.ListSegmentersParams) error); ok {
-		r1 = rf(projectId, params)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.ListSegmentersParams) error); ok {
+		r1 = rf(ctx, projectId, params)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -238,13 +272,13 @@ func (_m *SegmenterService) ListSegmenters(projectId int64, params services.List
 	return r0, r1
 }
 
-// UpdateCustomSegmenter provides a mock function with given fields: projectId, name, customSegmenterData
-func (_m *SegmenterService) UpdateCustomSegmenter(projectId int64, name string, customSegmenterData services.UpdateCustomSegmenterRequestBody) (*models.CustomSegmenter, error) {
-	ret := _m.Called(projectId, name, customSegmenterData)
+// UpdateCustomSegmenter provides a mock function with given fields: ctx, projectId, name, customSegmenterData
+func (_m *SegmenterService) UpdateCustomSegmenter(ctx context.Context, projectId int64, name string, customSegmenterData services.UpdateCustomSegmenterRequestBody) (*models.CustomSegmenter, error) {
+	ret := _m.Called(ctx, projectId, name, customSegmenterData)
 
 	var r0 *models.CustomSegmenter
-	if rf, ok := ret.Get(0).(func(int64, string, services.UpdateCustomSegmenterRequestBody) *models.CustomSegmenter); ok {
-		r0 = rf(projectId, name, customSegmenterData)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, services.UpdateCustomSegmenterRequestBody) *models.CustomSegmenter); ok {
+		r0 = rf(ctx, projectId, name, customSegmenterData)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.CustomSegmenter)
@@ -252,8 +286,8 @@ func (_m *SegmenterService) UpdateCustomSegmenter(projectId int64, name string,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, string, services.UpdateCustomSegmenterRequestBody) error); ok {
-		r1 = rf(projectId, name, customSegmenterData)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, string, services.UpdateCustomSegmenterRequestBody) error); ok {
+		r1 = rf(ctx, projectId, name, customSegmenterData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -261,13 +295,13 @@ func (_m *SegmenterService) UpdateCustomSegmenter(projectId int64, name string,
 	return r0, r1
 }
 
-// ValidateExperimentSegment provides a mock function with given fields: projectId, userSegmenters, expSegment
-func (_m *SegmenterService) ValidateExperimentSegment(projectId int64, userSegmenters []string, expSegment models.ExperimentSegmentRaw) error {
-	ret := _m.Called(projectId, userSegmenters, expSegment)
+// ValidateExperimentSegment provides a mock function with given fields: ctx, projectId, userSegmenters, expSegment
+func (_m *SegmenterService) ValidateExperimentSegment(ctx context.Context, projectId int64, userSegmenters []string, expSegment models.ExperimentSegmentRaw) error {
+	ret := _m.Called(ctx, projectId, userSegmenters, expSegment)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, []string, models.ExperimentSegmentRaw) error); ok {
-		r0 = rf(projectId, userSegmenters, expSegment)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, models.ExperimentSegmentRaw) error); ok {
+		r0 = rf(ctx, projectId, userSegmenters, expSegment)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -275,13 +309,13 @@ func (_m *SegmenterService) ValidateExperimentSegment(projectId int64, userSegme
 	return r0
 }
 
-// ValidateExperimentVariables provides a mock function with given fields: projectId, projectSegmenters
-func (_m *SegmenterService) ValidateExperimentVariables(projectId int64, projectSegmenters models.ProjectSegmenters) error {
-	ret := _m.Called(projectId, projectSegmenters)
+// ValidateExperimentVariables provides a mock function with given fields: ctx, projectId, projectSegmenters, randomizationKey
+func (_m *SegmenterService) ValidateExperimentVariables(ctx context.Context, projectId int64, projectSegmenters models.ProjectSegmenters, randomizationKey string) error {
+	ret := _m.Called(ctx, projectId, projectSegmenters, randomizationKey)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, models.ProjectSegmenters) error); ok {
-		r0 = rf(projectId, projectSegmenters)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, models.ProjectSegmenters, string) error); ok {
+		r0 = rf(ctx, projectId, projectSegmenters, randomizationKey)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -289,13 +323,13 @@ func (_m *SegmenterService) ValidateExperimentVariables(projectId int64, project
 	return r0
 }
 
-// ValidatePrereqSegmenters provides a mock function with given fields: projectId, _a1
-func (_m *SegmenterService) ValidatePrereqSegmenters(projectId int64, _a1 []string) error {
-	ret := _m.Called(projectId, _a1)
+// ValidatePrereqSegmenters provides a mock function with given fields: ctx, projectId, _a1
+func (_m *SegmenterService) ValidatePrereqSegmenters(ctx context.Context, projectId int64, _a1 []string) error {
+	ret := _m.Called(ctx, projectId, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, []string) error); ok {
-		r0 = rf(projectId, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) error); ok {
+		r0 = rf(ctx, projectId, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -303,13 +337,13 @@ func (_m *SegmenterService) ValidatePrereqSegmenters(projectId int64, _a1 []stri
 	return r0
 }
 
-// ValidateRequiredSegmenters provides a mock function with given fields: projectId, _a1
-func (_m *SegmenterService) ValidateRequiredSegmenters(projectId int64, _a1 []string) error {
-	ret := _m.Called(projectId, _a1)
+// ValidateRequiredSegmenters provides a mock function with given fields: ctx, projectId, _a1
+func (_m *SegmenterService) ValidateRequiredSegmenters(ctx context.Context, projectId int64, _a1 []string) error {
+	ret := _m.Called(ctx, projectId, _a1)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, []string) error); ok {
-		r0 = rf(projectId, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string) error); ok {
+		r0 = rf(ctx, projectId, _a1)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -317,13 +351,13 @@ func (_m *SegmenterService) ValidateRequiredSegmenters(projectId int64, _a1 []st
 	return r0
 }
 
-// ValidateSegmentOrthogonality provides a mock function with given fields: projectId, userSegmenters, expSegment, allExps
-func (_m *SegmenterService) ValidateSegmentOrthogonality(projectId int64, userSegmenters []string, expSegment models.ExperimentSegmentRaw, allExps []models.Experiment) error {
-	ret := _m.Called(projectId, userSegmenters, expSegment, allExps)
+// ValidateSegmentOrthogonality provides a mock function with given fields: ctx, projectId, userSegmenters, expSegment, allExps
+func (_m *SegmenterService) ValidateSegmentOrthogonality(ctx context.Context, projectId int64, userSegmenters []string, expSegment models.ExperimentSegmentRaw, allExps []models.Experiment) error {
+	ret := _m.Called(ctx, projectId, userSegmenters, expSegment, allExps)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, []string, models.ExperimentSegmentRaw, []models.Experiment) error); ok {
-		r0 = rf(projectId, userSegmenters, expSegment, allExps)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, []string, models.ExperimentSegmentRaw, []models.Experiment) error); ok {
+		r0 = rf(ctx, projectId, userSegmenters, expSegment, allExps)
 	} else {
 		r0 = ret.Error(0)
 	}
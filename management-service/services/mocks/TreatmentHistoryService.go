@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/caraml-dev/xp/management-service/models"
 	pagination "github.com/caraml-dev/xp/management-service/pagination"
 	mock "github.com/stretchr/testify/mock"
@@ -15,13 +17,13 @@ type TreatmentHistoryService struct {
 	mock.Mock
 }
 
-// CreateTreatmentHistory provides a mock function with given fields: _a0
-func (_m *TreatmentHistoryService) CreateTreatmentHistory(_a0 *models.Treatment) (*models.TreatmentHistory, error) {
-	ret := _m.Called(_a0)
+// CreateTreatmentHistory provides a mock function with given fields: ctx, _a0
+func (_m *TreatmentHistoryService) CreateTreatmentHistory(ctx context.Context, _a0 *models.Treatment) (*models.TreatmentHistory, error) {
+	ret := _m.Called(ctx, _a0)
 
 	var r0 *models.TreatmentHistory
-	if rf, ok := ret.Get(0).(func(*models.Treatment) *models.TreatmentHistory); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Treatment) *models.TreatmentHistory); ok {
+		r0 = rf(ctx, _a0)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.TreatmentHistory)
@@ -29,8 +31,8 @@ func (_m *TreatmentHistoryService) CreateTreatmentHistory(_a0 *models.Treatment)
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(*models.Treatment) error); ok {
-		r1 = rf(_a0)
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Treatment) error); ok {
+		r1 = rf(ctx, _a0)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,13 +40,13 @@ func (_m *TreatmentHistoryService) CreateTreatmentHistory(_a0 *models.Treatment)
 	return r0, r1
 }
 
-// DeleteTreatmentHistory provides a mock function with given fields: treatmentId
-func (_m *TreatmentHistoryService) DeleteTreatmentHistory(treatmentId int64) error {
-	ret := _m.Called(treatmentId)
+// DeleteTreatmentHistory provides a mock function with given fields: ctx, treatmentId
+func (_m *TreatmentHistoryService) DeleteTreatmentHistory(ctx context.Context, treatmentId int64) error {
+	ret := _m.Called(ctx, treatmentId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64) error); ok {
-		r0 = rf(treatmentId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, treatmentId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,13 +54,13 @@ func (_m *TreatmentHistoryService) DeleteTreatmentHistory(treatmentId int64) err
 	return r0
 }
 
-// GetDBRecord provides a mock function with given fields: treatmentId, version
-func (_m *TreatmentHistoryService) GetDBRecord(treatmentId models.ID, version int64) (*models.TreatmentHistory, error) {
-	ret := _m.Called(treatmentId, version)
+// GetDBRecord provides a mock function with given fields: ctx, treatmentId, version
+func (_m *TreatmentHistoryService) GetDBRecord(ctx context.Context, treatmentId models.ID, version int64) (*models.TreatmentHistory, error) {
+	ret := _m.Called(ctx, treatmentId, version)
 
 	var r0 *models.TreatmentHistory
-	if rf, ok := ret.Get(0).(func(models.ID, int64) *models.TreatmentHistory); ok {
-		r0 = rf(treatmentId, version)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, int64) *models.TreatmentHistory); ok {
+		r0 = rf(ctx, treatmentId, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.TreatmentHistory)
@@ -66,8 +68,8 @@ func (_m *TreatmentHistoryService) GetDBRecord(treatmentId models.ID, version in
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.ID, int64) error); ok {
-		r1 = rf(treatmentId, version)
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, int64) error); ok {
+		r1 = rf(ctx, treatmentId, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,13 +77,13 @@ func (_m *TreatmentHistoryService) GetDBRecord(treatmentId models.ID, version in
 	return r0, r1
 }
 
-// GetTreatmentHistory provides a mock function with given fields: treatmentId, version
-func (_m *TreatmentHistoryService) GetTreatmentHistory(treatmentId int64, version int64) (*models.TreatmentHistory, error) {
-	ret := _m.Called(treatmentId, version)
+// GetTreatmentHistory provides a mock function with given fields: ctx, treatmentId, version
+func (_m *TreatmentHistoryService) GetTreatmentHistory(ctx context.Context, treatmentId int64, version int64) (*models.TreatmentHistory, error) {
+	ret := _m.Called(ctx, treatmentId, version)
 
 	var r0 *models.TreatmentHistory
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.TreatmentHistory); ok {
-		r0 = rf(treatmentId, version)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.TreatmentHistory); ok {
+		r0 = rf(ctx, treatmentId, version)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.TreatmentHistory)
@@ -89,8 +91,8 @@ func (_m *TreatmentHistoryService) GetTreatmentHistory(treatmentId int64, versio
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(treatmentId, version)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, treatmentId, version)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,13 +100,13 @@ func (_m *TreatmentHistoryService) GetTreatmentHistory(treatmentId int64, versio
 	return r0, r1
 }
 
-// ListTreatmentHistory provides a mock function with given fields: treatmentId, params
-func (_m *TreatmentHistoryService) ListTreatmentHistory(treatmentId int64, params services.ListTreatmentHistoryParams) ([]*models.TreatmentHistory, *pagination.Paging, error) {
-	ret := _m.Called(treatmentId, params)
+// ListTreatmentHistory provides a mock function with given fields: ctx, treatmentId, params
+func (_m *TreatmentHistoryService) ListTreatmentHistory(ctx context.Context, treatmentId int64, params services.ListTreatmentHistoryParams) ([]*models.TreatmentHistory, *pagination.Paging, error) {
+	ret := _m.Called(ctx, treatmentId, params)
 
 	var r0 []*models.TreatmentHistory
-	if rf, ok := ret.Get(0).(func(int64, services.ListTreatmentHistoryParams) []*models.TreatmentHistory); ok {
-		r0 = rf(treatmentId, params)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.ListTreatmentHistoryParams) []*models.TreatmentHistory); ok {
+		r0 = rf(ctx, treatmentId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.TreatmentHistory)
@@ -112,8 +114,8 @@ func (_m *TreatmentHistoryService) ListTreatmentHistory(treatmentId int64, param
 	}
 
 	var r1 *pagination.Paging
-	if rf, ok := ret.Get(1).(func(int64, services.ListTreatmentHistoryParams) *pagination.Paging); ok {
-		r1 = rf(treatmentId, params)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.ListTreatmentHistoryParams) *pagination.Paging); ok {
+		r1 = rf(ctx, treatmentId, params)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*pagination.Paging)
@@ -121,8 +123,8 @@ func (_m *TreatmentHistoryService) ListTreatmentHistory(treatmentId int64, param
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int64, services.ListTreatmentHistoryParams) error); ok {
-		r2 = rf(treatmentId, params)
+	if rf, ok := ret.Get(2).(func(context.Context, int64, services.ListTreatmentHistoryParams) error); ok {
+		r2 = rf(ctx, treatmentId, params)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	models "github.com/caraml-dev/xp/management-service/models"
 	pagination "github.com/caraml-dev/xp/management-service/pagination"
 	mock "github.com/stretchr/testify/mock"
@@ -15,13 +17,13 @@ type TreatmentService struct {
 	mock.Mock
 }
 
-// CreateTreatment provides a mock function with given fields: settings, treatmentData
-func (_m *TreatmentService) CreateTreatment(settings models.Settings, treatmentData services.CreateTreatmentRequestBody) (*models.Treatment, error) {
-	ret := _m.Called(settings, treatmentData)
+// CreateTreatment provides a mock function with given fields: ctx, settings, treatmentData
+func (_m *TreatmentService) CreateTreatment(ctx context.Context, settings models.Settings, treatmentData services.CreateTreatmentRequestBody) (*models.Treatment, error) {
+	ret := _m.Called(ctx, settings, treatmentData)
 
 	var r0 *models.Treatment
-	if rf, ok := ret.Get(0).(func(models.Settings, services.CreateTreatmentRequestBody) *models.Treatment); ok {
-		r0 = rf(settings, treatmentData)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Settings, services.CreateTreatmentRequestBody) *models.Treatment); ok {
+		r0 = rf(ctx, settings, treatmentData)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Treatment)
@@ -29,8 +31,8 @@ func (_m *TreatmentService) CreateTreatment(settings models.Settings, treatmentD
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.Settings, services.CreateTreatmentRequestBody) error); ok {
-		r1 = rf(settings, treatmentData)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Settings, services.CreateTreatmentRequestBody) error); ok {
+		r1 = rf(ctx, settings, treatmentData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -38,13 +40,13 @@ func (_m *TreatmentService) CreateTreatment(settings models.Settings, treatmentD
 	return r0, r1
 }
 
-// DeleteTreatment provides a mock function with given fields: projectId, treatmentId
-func (_m *TreatmentService) DeleteTreatment(projectId int64, treatmentId int64) error {
-	ret := _m.Called(projectId, treatmentId)
+// DeleteTreatment provides a mock function with given fields: ctx, projectId, treatmentId
+func (_m *TreatmentService) DeleteTreatment(ctx context.Context, projectId int64, treatmentId int64) error {
+	ret := _m.Called(ctx, projectId, treatmentId)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int64, int64) error); ok {
-		r0 = rf(projectId, treatmentId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) error); ok {
+		r0 = rf(ctx, projectId, treatmentId)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -52,13 +54,13 @@ func (_m *TreatmentService) DeleteTreatment(projectId int64, treatmentId int64)
 	return r0
 }
 
-// GetDBRecord provides a mock function with given fields: projectId, treatmentId
-func (_m *TreatmentService) GetDBRecord(projectId models.ID, treatmentId models.ID) (*models.Treatment, error) {
-	ret := _m.Called(projectId, treatmentId)
+// GetDBRecord provides a mock function with given fields: ctx, projectId, treatmentId
+func (_m *TreatmentService) GetDBRecord(ctx context.Context, projectId models.ID, treatmentId models.ID) (*models.Treatment, error) {
+	ret := _m.Called(ctx, projectId, treatmentId)
 
 	var r0 *models.Treatment
-	if rf, ok := ret.Get(0).(func(models.ID, models.ID) *models.Treatment); ok {
-		r0 = rf(projectId, treatmentId)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ID, models.ID) *models.Treatment); ok {
+		r0 = rf(ctx, projectId, treatmentId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Treatment)
@@ -66,8 +68,8 @@ func (_m *TreatmentService) GetDBRecord(projectId models.ID, treatmentId models.
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.ID, models.ID) error); ok {
-		r1 = rf(projectId, treatmentId)
+	if rf, ok := ret.Get(1).(func(context.Context, models.ID, models.ID) error); ok {
+		r1 = rf(ctx, projectId, treatmentId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -75,13 +77,13 @@ func (_m *TreatmentService) GetDBRecord(projectId models.ID, treatmentId models.
 	return r0, r1
 }
 
-// GetTreatment provides a mock function with given fields: projectId, treatmentId
-func (_m *TreatmentService) GetTreatment(projectId int64, treatmentId int64) (*models.Treatment, error) {
-	ret := _m.Called(projectId, treatmentId)
+// GetTreatment provides a mock function with given fields: ctx, projectId, treatmentId
+func (_m *TreatmentService) GetTreatment(ctx context.Context, projectId int64, treatmentId int64) (*models.Treatment, error) {
+	ret := _m.Called(ctx, projectId, treatmentId)
 
 	var r0 *models.Treatment
-	if rf, ok := ret.Get(0).(func(int64, int64) *models.Treatment); ok {
-		r0 = rf(projectId, treatmentId)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int64) *models.Treatment); ok {
+		r0 = rf(ctx, projectId, treatmentId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Treatment)
@@ -89,8 +91,8 @@ func (_m *TreatmentService) GetTreatment(projectId int64, treatmentId int64) (*m
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
-		r1 = rf(projectId, treatmentId)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int64) error); ok {
+		r1 = rf(ctx, projectId, treatmentId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -98,13 +100,13 @@ func (_m *TreatmentService) GetTreatment(projectId int64, treatmentId int64) (*m
 	return r0, r1
 }
 
-// ListTreatments provides a mock function with given fields: projectId, params
-func (_m *TreatmentService) ListTreatments(projectId int64, params services.ListTreatmentsParams) ([]*models.Treatment, *pagination.Paging, error) {
-	ret := _m.Called(projectId, params)
+// ListTreatments provides a mock function with given fields: ctx, projectId, params
+func (_m *TreatmentService) ListTreatments(ctx context.Context, projectId int64, params services.ListTreatmentsParams) ([]*models.Treatment, *pagination.Paging, error) {
+	ret := _m.Called(ctx, projectId, params)
 
 	var r0 []*models.Treatment
-	if rf, ok := ret.Get(0).(func(int64, services.ListTreatmentsParams) []*models.Treatment); ok {
-		r0 = rf(projectId, params)
+	if rf, ok := ret.Get(0).(func(context.Context, int64, services.ListTreatmentsParams) []*models.Treatment); ok {
+		r0 = rf(ctx, projectId, params)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Treatment)
@@ -112,8 +114,8 @@ func (_m *TreatmentService) ListTreatments(projectId int64, params services.List
 	}
 
 	var r1 *pagination.Paging
-	if rf, ok := ret.Get(1).(func(int64, services.ListTreatmentsParams) *pagination.Paging); ok {
-		r1 = rf(projectId, params)
+	if rf, ok := ret.Get(1).(func(context.Context, int64, services.ListTreatmentsParams) *pagination.Paging); ok {
+		r1 = rf(ctx, projectId, params)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*pagination.Paging)
@@ -121,8 +123,8 @@ func (_m *TreatmentService) ListTreatments(projectId int64, params services.List
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(int64, services.ListTreatmentsParams) error); ok {
-		r2 = rf(projectId, params)
+	if rf, ok := ret.Get(2).(func(context.Context, int64, services.ListTreatmentsParams) error); ok {
+		r2 = rf(ctx, projectId, params)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -144,13 +146,13 @@ func (_m *TreatmentService) RunCustomValidation(treatmentConfig map[string]inter
 	return r0
 }
 
-// UpdateTreatment provides a mock function with given fields: settings, treatmentId, treatmentData
-func (_m *TreatmentService) UpdateTreatment(settings models.Settings, treatmentId int64, treatmentData services.UpdateTreatmentRequestBody) (*models.Treatment, error) {
-	ret := _m.Called(settings, treatmentId, treatmentData)
+// UpdateTreatment provides a mock function with given fields: ctx, settings, treatmentId, treatmentData
+func (_m *TreatmentService) UpdateTreatment(ctx context.Context, settings models.Settings, treatmentId int64, treatmentData services.UpdateTreatmentRequestBody) (*models.Treatment, error) {
+	ret := _m.Called(ctx, settings, treatmentId, treatmentData)
 
 	var r0 *models.Treatment
-	if rf, ok := ret.Get(0).(func(models.Settings, int64, services.UpdateTreatmentRequestBody) *models.Treatment); ok {
-		r0 = rf(settings, treatmentId, treatmentData)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Settings, int64, services.UpdateTreatmentRequestBody) *models.Treatment); ok {
+		r0 = rf(ctx, settings, treatmentId, treatmentData)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Treatment)
@@ -158,8 +160,8 @@ func (_m *TreatmentService) UpdateTreatment(settings models.Settings, treatmentI
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(models.Settings, int64, services.UpdateTreatmentRequestBody) error); ok {
-		r1 = rf(settings, treatmentId, treatmentData)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Settings, int64, services.UpdateTreatmentRequestBody) error); ok {
+		r1 = rf(ctx, settings, treatmentId, treatmentData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,339 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"gorm.io/gorm"
+
+	_pubsub "github.com/caraml-dev/xp/common/pubsub"
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	"github.com/caraml-dev/xp/management-service/config"
+	"github.com/caraml-dev/xp/management-service/errors"
+	"github.com/caraml-dev/xp/management-service/models"
+	"github.com/caraml-dev/xp/management-service/pagination"
+	"github.com/caraml-dev/xp/management-service/services/messagequeue"
+)
+
+// outboxDispatcherLockID is the key of the Postgres advisory lock that ensures that only one
+// replica of the Management Service dispatches the outbox messages at any time, so that the
+// messages are published in the order in which they were written.
+const outboxDispatcherLockID = 7_140_001
+
+type ListOutboxMessagesParams struct {
+	pagination.PaginationOptions
+	Status *models.OutboxMessageStatus `json:"status,omitempty"`
+}
+
+type OutboxService interface {
+	// AddProjectSettingsMessage, AddExperimentMessage and AddProjectSegmenterMessage write the
+	// message for the given change to the outbox, using the transaction in which the change is saved.
+	AddProjectSettingsMessage(tx *gorm.DB, updateType string, settings *_pubsub.ProjectSettings) error
+	AddExperimentMessage(tx *gorm.DB, updateType string, experiment *_pubsub.Experiment) error
+	AddProjectSegmenterMessage(
+		tx *gorm.DB,
+		updateType string,
+		segmenter *_segmenters.SegmenterConfiguration,
+		projectId int64,
+	) error
+
+	ListOutboxMessages(ctx context.Context, params ListOutboxMessagesParams) ([]*models.OutboxMessage, *pagination.Paging, error)
+	ReplayOutboxMessage(ctx context.Context, messageId int64) (*models.OutboxMessage, error)
+	// DispatchOutboxMessages publishes the pending messages that are due, in the order in which
+	// they were written, and returns the number of messages that were sent.
+	DispatchOutboxMessages() (int, error)
+	GetDBRecord(ctx context.Context, messageId models.ID) (*models.OutboxMessage, error)
+}
+
+type outboxService struct {
+	services *Services
+	cfg      config.OutboxConfig
+	db       *gorm.DB
+}
+
+func NewOutboxService(services *Services, cfg config.OutboxConfig, db *gorm.DB) OutboxService {
+	return &outboxService{
+		services: services,
+		cfg:      cfg,
+		db:       db,
+	}
+}
+
+func (svc *outboxService) AddProjectSettingsMessage(
+	tx *gorm.DB,
+	updateType string,
+	settings *_pubsub.ProjectSettings,
+) error {
+	payload, err := messagequeue.SerializeProjectSettingsMessage(updateType, settings)
+	if err != nil {
+		return err
+	}
+	return svc.add(tx, models.ID(settings.GetProjectId()), models.OutboxMessageTypeProjectSettings, nil, updateType, payload)
+}
+
+func (svc *outboxService) AddExperimentMessage(tx *gorm.DB, updateType string, experiment *_pubsub.Experiment) error {
+	payload, err := messagequeue.SerializeExperimentMessage(updateType, experiment)
+	if err != nil {
+		return err
+	}
+	entityId := strconv.FormatInt(experiment.GetId(), 10)
+	return svc.add(tx, models.ID(experiment.GetProjectId()), models.OutboxMessageTypeExperiment, &entityId, updateType, payload)
+}
+
+func (svc *outboxService) AddProjectSegmenterMessage(
+	tx *gorm.DB,
+	updateType string,
+	segmenter *_segmenters.SegmenterConfiguration,
+	projectId int64,
+) error {
+	payload, err := messagequeue.SerializeProjectSegmenterMessage(updateType, segmenter, projectId)
+	if err != nil {
+		return err
+	}
+	return svc.add(tx, models.ID(projectId), models.OutboxMessageTypeProjectSegmenter, &segmenter.Name, updateType, payload)
+}
+
+func (svc *outboxService) ListOutboxMessages(
+	ctx context.Context,
+	params ListOutboxMessagesParams,
+) ([]*models.OutboxMessage, *pagination.Paging, error) {
+	var messages []*models.OutboxMessage
+	query := svc.query(ctx).Order("id desc")
+	if params.Status != nil {
+		query = query.Where("status = ?", *params.Status)
+	}
+
+	// Pagination
+	var pagingResponse *pagination.Paging
+	var count int64
+	err := pagination.ValidatePaginationParams(params.Page, params.PageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	pageOpts := pagination.NewPaginationOptions(params.Page, params.PageSize)
+	// Count total
+	query.Model(&messages).Count(&count)
+	// Add offset and limit
+	query = query.Offset(int((*pageOpts.Page - 1) * *pageOpts.PageSize))
+	query = query.Limit(int(*pageOpts.PageSize))
+	// Format opts into paging response
+	pagingResponse = pagination.ToPaging(pageOpts, int(count))
+	if pagingResponse.Page > 1 && pagingResponse.Pages < pagingResponse.Page {
+		// Invalid query - total pages is less than the requested page
+		return nil, nil, errors.Newf(errors.BadInput,
+			"Requested page number %d exceeds total pages: %d.", pagingResponse.Page, pagingResponse.Pages)
+	}
+
+	err = query.Find(&messages).Error
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return messages, pagingResponse, nil
+}
+
+func (svc *outboxService) ReplayOutboxMessage(ctx context.Context, messageId int64) (*models.OutboxMessage, error) {
+	message, err := svc.GetDBRecord(ctx, models.ID(messageId))
+	if err != nil {
+		return nil, errors.Newf(errors.NotFound, err.Error())
+	}
+	if message.Status == models.OutboxMessageStatusSent {
+		return nil, errors.Newf(errors.BadInput, "outbox message id %d has already been sent", messageId)
+	}
+	// Replaying the message after a newer one for the same entity would roll the consumers back to a
+	// stale state. The messages that do not record their entity are compared by project and type only.
+	newer := svc.query(ctx).
+		Where("project_id = ? AND message_type = ? AND id > ?", message.ProjectID, message.MessageType, message.ID)
+	if message.EntityID != nil {
+		newer = newer.Where("entity_id = ?", *message.EntityID)
+	}
+	var newerMessage models.OutboxMessage
+	err = newer.Order("id desc").Limit(1).Find(&newerMessage).Error
+	if err != nil {
+		return nil, err
+	}
+	if newerMessage.ID != 0 {
+		return nil, errors.Newf(errors.BadInput,
+			"outbox message id %d is stale, as it has been superseded by outbox message id %d", messageId, newerMessage.ID)
+	}
+
+	// Reschedule the message with a fresh set of attempts
+	message.Status = models.OutboxMessageStatusPending
+	message.Attempts = 0
+	message.NextAttemptAt = time.Now()
+	if err := svc.query(ctx).Save(message).Error; err != nil {
+		return nil, err
+	}
+	return svc.GetDBRecord(ctx, message.ID)
+}
+
+func (svc *outboxService) DispatchOutboxMessages() (int, error) {
+	var sent int
+	err := svc.query(context.Background()).Transaction(func(tx *gorm.DB) error {
+		// Skip this round if another replica is already dispatching
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", outboxDispatcherLockID).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var messages []*models.OutboxMessage
+		err := tx.
+			Where("status = ?", models.OutboxMessageStatusPending).
+			Order("id").
+			Limit(svc.cfg.BatchSize).
+			Find(&messages).Error
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, message := range messages {
+			// Messages are published strictly in order, so a message that is waiting to be retried
+			// holds back the ones after it.
+			if message.NextAttemptAt.After(now) {
+				return nil
+			}
+
+			// Publish the message as part of the trace of the request that wrote it, if any
+			ctx := context.Background()
+			if message.TraceContext != nil {
+				ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(*message.TraceContext))
+			}
+			if publishErr := svc.services.MessageQueueService.PublishMessage(ctx, message.Payload); publishErr != nil {
+				svc.recordFailure(message, publishErr, now)
+				if err := tx.Save(message).Error; err != nil {
+					return err
+				}
+				if message.Status == models.OutboxMessageStatusPending {
+					return nil
+				}
+				continue
+			}
+
+			message.Status = models.OutboxMessageStatusSent
+			message.SentAt = &now
+			if err := tx.Save(message).Error; err != nil {
+				return err
+			}
+			sent++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return sent, nil
+}
+
+func (svc *outboxService) GetDBRecord(ctx context.Context, messageId models.ID) (*models.OutboxMessage, error) {
+	var message models.OutboxMessage
+	query := svc.query(ctx).
+		Where("id = ?", messageId).
+		First(&message)
+	if err := query.Error; err != nil {
+		return nil, err
+	}
+	return &message, nil
+}
+
+func (svc *outboxService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
+}
+
+func (svc *outboxService) add(
+	tx *gorm.DB,
+	projectId models.ID,
+	messageType models.OutboxMessageType,
+	entityId *string,
+	updateType string,
+	payload []byte,
+) error {
+	message := &models.OutboxMessage{
+		ProjectID:     projectId,
+		MessageType:   messageType,
+		EntityID:      entityId,
+		UpdateType:    updateType,
+		Payload:       payload,
+		Status:        models.OutboxMessageStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+	// Store the trace context of the request, as the message is published asynchronously
+	traceContext := models.TraceContext{}
+	otel.GetTextMapPropagator().Inject(tx.Statement.Context, propagation.MapCarrier(traceContext))
+	if len(traceContext) > 0 {
+		message.TraceContext = &traceContext
+	}
+	if err := tx.Create(message).Error; err != nil {
+		return errors.Wrapf(err, "failed to write %s message to the outbox", messageType)
+	}
+	return nil
+}
+
+// recordFailure updates the message after a failed publish attempt, either scheduling the next
+// attempt with an exponential backoff or marking the message as failed once the attempts are exhausted.
+func (svc *outboxService) recordFailure(message *models.OutboxMessage, publishErr error, now time.Time) {
+	lastError := publishErr.Error()
+	message.Attempts++
+	message.LastError = &lastError
+
+	if message.Attempts >= svc.cfg.MaxAttempts {
+		message.Status = models.OutboxMessageStatusFailed
+		return
+	}
+
+	backoff := svc.cfg.InitialBackoff
+	for i := int32(1); i < message.Attempts && backoff < svc.cfg.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > svc.cfg.MaxBackoff {
+		backoff = svc.cfg.MaxBackoff
+	}
+	message.NextAttemptAt = now.Add(backoff)
+}
+
+// OutboxDispatcher periodically publishes the pending outbox messages
+type OutboxDispatcher struct {
+	outboxService OutboxService
+	pollInterval  time.Duration
+	stopChannel   chan struct{}
+}
+
+// NewOutboxDispatcher creates a new OutboxDispatcher that polls the outbox at the configured interval.
+func NewOutboxDispatcher(cfg config.OutboxConfig, outboxService OutboxService) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		outboxService: outboxService,
+		pollInterval:  cfg.PollInterval,
+		stopChannel:   make(chan struct{}),
+	}
+}
+
+func (d *OutboxDispatcher) Start() {
+	log.Println("Starting outbox dispatcher...")
+	ticker := time.NewTicker(d.pollInterval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := d.outboxService.DispatchOutboxMessages(); err != nil {
+					// Messages remain in the outbox and will be picked up in the next round
+					log.Printf("Error dispatching outbox messages: %v", err)
+				}
+			case <-d.stopChannel:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+func (d *OutboxDispatcher) Stop() {
+	close(d.stopChannel)
+}
@@ -0,0 +1,274 @@
+//go:build integration
+
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+
+	_pubsub "github.com/caraml-dev/xp/common/pubsub"
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	"github.com/caraml-dev/xp/management-service/config"
+	tu "github.com/caraml-dev/xp/management-service/internal/testutils"
+	"github.com/caraml-dev/xp/management-service/models"
+	"github.com/caraml-dev/xp/management-service/services"
+	"github.com/caraml-dev/xp/management-service/services/mocks"
+)
+
+type OutboxServiceTestSuite struct {
+	suite.Suite
+	services.OutboxService
+	db                  *gorm.DB
+	messageQueueService *mocks.MessageQueueService
+	CleanUpFunc         func()
+}
+
+func (s *OutboxServiceTestSuite) SetupTest() {
+	s.Suite.T().Log("Setting up OutboxServiceTestSuite")
+
+	// Create test DB for each test, as the tests depend on the state of the whole outbox
+	db, cleanup, err := tu.CreateTestDB(tu.MigrationsPath)
+	if err != nil {
+		s.Suite.T().Fatalf("Could not create test DB: %v", err)
+	}
+	s.db = db
+	s.CleanUpFunc = cleanup
+
+	// Init mock message queue service, calls will be set up during the test
+	s.messageQueueService = &mocks.MessageQueueService{}
+	allServices := &services.Services{
+		MessageQueueService: s.messageQueueService,
+	}
+	s.OutboxService = services.NewOutboxService(
+		allServices,
+		config.OutboxConfig{
+			BatchSize:      10,
+			MaxAttempts:    2,
+			InitialBackoff: time.Minute,
+			MaxBackoff:     time.Hour,
+		},
+		db,
+	)
+}
+
+func (s *OutboxServiceTestSuite) TearDownTest() {
+	s.Suite.T().Log("Cleaning up OutboxServiceTestSuite")
+	s.CleanUpFunc()
+}
+
+func TestOutboxService(t *testing.T) {
+	suite.Run(t, new(OutboxServiceTestSuite))
+}
+
+func (s *OutboxServiceTestSuite) TestAddMessages() {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.OutboxService.AddProjectSettingsMessage(
+			tx, "create", &_pubsub.ProjectSettings{ProjectId: 1}); err != nil {
+			return err
+		}
+		if err := s.OutboxService.AddExperimentMessage(
+			tx, "update", &_pubsub.Experiment{Id: 2, ProjectId: 1}); err != nil {
+			return err
+		}
+		return s.OutboxService.AddProjectSegmenterMessage(
+			tx, "delete", &_segmenters.SegmenterConfiguration{Name: "seg-1"}, 1)
+	})
+	s.Suite.Require().NoError(err)
+
+	messages, paging, err := s.OutboxService.ListOutboxMessages(context.Background(), services.ListOutboxMessagesParams{})
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(int32(3), paging.Total)
+	s.Suite.Require().Len(messages, 3)
+
+	// Messages are listed from the latest
+	s.Suite.Assert().Equal(models.OutboxMessageTypeProjectSegmenter, messages[0].MessageType)
+	s.Suite.Assert().Equal("delete", messages[0].UpdateType)
+	s.Suite.Assert().Equal(models.OutboxMessageTypeExperiment, messages[1].MessageType)
+	s.Suite.Assert().Equal(models.OutboxMessageTypeProjectSettings, messages[2].MessageType)
+	for _, message := range messages {
+		s.Suite.Assert().Equal(models.ID(1), message.ProjectID)
+		s.Suite.Assert().Equal(models.OutboxMessageStatusPending, message.Status)
+	}
+
+	// Verify the serialized payload
+	var payload _pubsub.MessagePublishState
+	s.Suite.Require().NoError(proto.Unmarshal(messages[1].Payload, &payload))
+	s.Suite.Assert().Equal(int64(2), payload.GetExperimentUpdated().GetExperiment().GetId())
+
+	// Invalid update types are rejected
+	err = s.OutboxService.AddExperimentMessage(s.db, "delete", &_pubsub.Experiment{Id: 2, ProjectId: 1})
+	s.Suite.Assert().EqualError(err, "invalid update type (delete) for experiment message")
+}
+
+func (s *OutboxServiceTestSuite) TestAddMessageRolledBack() {
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.OutboxService.AddExperimentMessage(
+			tx, "create", &_pubsub.Experiment{Id: 1, ProjectId: 1}); err != nil {
+			return err
+		}
+		return fmt.Errorf("test save error")
+	})
+	s.Suite.Require().EqualError(err, "test save error")
+
+	messages, _, err := s.OutboxService.ListOutboxMessages(context.Background(), services.ListOutboxMessagesParams{})
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Len(messages, 0)
+}
+
+func (s *OutboxServiceTestSuite) TestDispatchOutboxMessagesWithTraceContext() {
+	propagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(propagator)
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36},
+		SpanID:     trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanContext)
+
+	// The trace context of the request is stored with the message
+	err := s.OutboxService.AddExperimentMessage(
+		s.db.WithContext(ctx), "create", &_pubsub.Experiment{Id: 1, ProjectId: 1})
+	s.Suite.Require().NoError(err)
+	messages, _, err := s.OutboxService.ListOutboxMessages(context.Background(), services.ListOutboxMessagesParams{})
+	s.Suite.Require().NoError(err)
+	s.Suite.Require().Len(messages, 1)
+	s.Suite.Require().NotNil(messages[0].TraceContext)
+	s.Suite.Assert().Equal(
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", (*messages[0].TraceContext)["traceparent"])
+
+	// And the message is published as part of the same trace
+	s.messageQueueService.On("PublishMessage", mock.MatchedBy(func(ctx context.Context) bool {
+		return trace.SpanContextFromContext(ctx).TraceID() == spanContext.TraceID()
+	}), messages[0].Payload).Return(nil)
+	sent, err := s.OutboxService.DispatchOutboxMessages()
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(1, sent)
+}
+
+func (s *OutboxServiceTestSuite) TestDispatchOutboxMessages() {
+	for i := 1; i <= 3; i++ {
+		err := s.OutboxService.AddExperimentMessage(
+			s.db, "create", &_pubsub.Experiment{Id: int64(i), ProjectId: 1})
+		s.Suite.Require().NoError(err)
+	}
+	messages, _, err := s.OutboxService.ListOutboxMessages(context.Background(), services.ListOutboxMessagesParams{})
+	s.Suite.Require().NoError(err)
+	firstMessage, secondMessage, thirdMessage := messages[2], messages[1], messages[0]
+
+	// The second message fails to be published, holding back the third one
+	s.messageQueueService.On("PublishMessage", mock.Anything, firstMessage.Payload).Return(nil)
+	s.messageQueueService.On("PublishMessage", mock.Anything, secondMessage.Payload).Return(fmt.Errorf("test publish error")).Once()
+	sent, err := s.OutboxService.DispatchOutboxMessages()
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(1, sent)
+	s.messageQueueService.AssertNotCalled(s.Suite.T(), "PublishMessage", mock.Anything, thirdMessage.Payload)
+
+	message, err := s.OutboxService.GetDBRecord(context.Background(), firstMessage.ID)
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(models.OutboxMessageStatusSent, message.Status)
+	s.Suite.Assert().NotNil(message.SentAt)
+
+	message, err = s.OutboxService.GetDBRecord(context.Background(), secondMessage.ID)
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(models.OutboxMessageStatusPending, message.Status)
+	s.Suite.Assert().Equal(int32(1), message.Attempts)
+	s.Suite.Assert().Equal("test publish error", *message.LastError)
+	s.Suite.Assert().True(message.NextAttemptAt.After(time.Now().Add(50 * time.Second)))
+
+	// The message is not retried before its backoff has elapsed
+	sent, err = s.OutboxService.DispatchOutboxMessages()
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(0, sent)
+
+	// Replay the message and publish the remaining messages
+	s.messageQueueService.On("PublishMessage", mock.Anything, secondMessage.Payload).Return(nil)
+	s.messageQueueService.On("PublishMessage", mock.Anything, thirdMessage.Payload).Return(nil)
+	message, err = s.OutboxService.ReplayOutboxMessage(context.Background(), int64(secondMessage.ID))
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(int32(0), message.Attempts)
+	sent, err = s.OutboxService.DispatchOutboxMessages()
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(2, sent)
+
+	// Sent messages cannot be replayed
+	_, err = s.OutboxService.ReplayOutboxMessage(context.Background(), int64(thirdMessage.ID))
+	s.Suite.Assert().EqualError(err, fmt.Sprintf("outbox message id %d has already been sent", thirdMessage.ID))
+}
+
+func (s *OutboxServiceTestSuite) TestReplayStaleOutboxMessage() {
+	err := s.OutboxService.AddExperimentMessage(s.db, "create", &_pubsub.Experiment{Id: 1, ProjectId: 1})
+	s.Suite.Require().NoError(err)
+	err = s.OutboxService.AddExperimentMessage(s.db, "update", &_pubsub.Experiment{Id: 1, ProjectId: 1})
+	s.Suite.Require().NoError(err)
+	err = s.OutboxService.AddExperimentMessage(s.db, "create", &_pubsub.Experiment{Id: 2, ProjectId: 1})
+	s.Suite.Require().NoError(err)
+	messages, _, err := s.OutboxService.ListOutboxMessages(context.Background(), services.ListOutboxMessagesParams{})
+	s.Suite.Require().NoError(err)
+	staleMessage, latestMessage, otherMessage := messages[2], messages[1], messages[0]
+	s.Suite.Require().NotNil(staleMessage.EntityID)
+	s.Suite.Assert().Equal("1", *staleMessage.EntityID)
+
+	// The message that has been superseded by a newer message for the same experiment cannot be replayed
+	err = s.db.Model(staleMessage).Update("status", models.OutboxMessageStatusFailed).Error
+	s.Suite.Require().NoError(err)
+	_, err = s.OutboxService.ReplayOutboxMessage(context.Background(), int64(staleMessage.ID))
+	s.Suite.Assert().EqualError(err, fmt.Sprintf(
+		"outbox message id %d is stale, as it has been superseded by outbox message id %d", staleMessage.ID, latestMessage.ID))
+
+	// The latest message for the experiment can be, regardless of the messages for other experiments
+	err = s.db.Model(latestMessage).Update("status", models.OutboxMessageStatusFailed).Error
+	s.Suite.Require().NoError(err)
+	message, err := s.OutboxService.ReplayOutboxMessage(context.Background(), int64(latestMessage.ID))
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(models.OutboxMessageStatusPending, message.Status)
+
+	// The messages that do not record their entity are compared by project and type only
+	err = s.db.Model(latestMessage).Updates(map[string]interface{}{
+		"status":    models.OutboxMessageStatusFailed,
+		"entity_id": nil,
+	}).Error
+	s.Suite.Require().NoError(err)
+	_, err = s.OutboxService.ReplayOutboxMessage(context.Background(), int64(latestMessage.ID))
+	s.Suite.Assert().EqualError(err, fmt.Sprintf(
+		"outbox message id %d is stale, as it has been superseded by outbox message id %d", latestMessage.ID, otherMessage.ID))
+}
+
+func (s *OutboxServiceTestSuite) TestDispatchOutboxMessagesMaxAttempts() {
+	err := s.OutboxService.AddProjectSettingsMessage(s.db, "update", &_pubsub.ProjectSettings{ProjectId: 1})
+	s.Suite.Require().NoError(err)
+	err = s.OutboxService.AddProjectSettingsMessage(s.db, "update", &_pubsub.ProjectSettings{ProjectId: 2})
+	s.Suite.Require().NoError(err)
+	messages, _, err := s.OutboxService.ListOutboxMessages(context.Background(), services.ListOutboxMessagesParams{})
+	s.Suite.Require().NoError(err)
+	failingMessage, nextMessage := messages[1], messages[0]
+
+	// Make the failing message due on its last attempt
+	err = s.db.Model(failingMessage).Update("attempts", 1).Error
+	s.Suite.Require().NoError(err)
+
+	s.messageQueueService.On("PublishMessage", mock.Anything, failingMessage.Payload).Return(fmt.Errorf("test publish error"))
+	s.messageQueueService.On("PublishMessage", mock.Anything, nextMessage.Payload).Return(nil)
+	sent, err := s.OutboxService.DispatchOutboxMessages()
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(1, sent)
+
+	// The message that has exhausted its attempts no longer holds back the others
+	failedStatus := models.OutboxMessageStatusFailed
+	messages, _, err = s.OutboxService.ListOutboxMessages(context.Background(), services.ListOutboxMessagesParams{Status: &failedStatus})
+	s.Suite.Require().NoError(err)
+	s.Suite.Require().Len(messages, 1)
+	s.Suite.Assert().Equal(failingMessage.ID, messages[0].ID)
+	s.Suite.Assert().Equal(int32(2), messages[0].Attempts)
+}
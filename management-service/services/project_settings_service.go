@@ -1,12 +1,14 @@
 package services
 
 import (
+	"context"
 	"time"
 
 	"github.com/golang-collections/collections/set"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
+	_utils "github.com/caraml-dev/xp/common/utils"
 	"github.com/caraml-dev/xp/management-service/errors"
 	"github.com/caraml-dev/xp/management-service/models"
 	"github.com/caraml-dev/xp/management-service/utils"
@@ -14,6 +16,9 @@ import (
 
 const PASSKEY_LENGTH = 32
 
+// defaultPasskeyName is the name of the passkey that is generated when the project is set up
+const defaultPasskeyName = "default"
+
 type CreateProjectSettingsRequestBody struct {
 	EnableS2idClustering *bool                    `json:"enable_s2id_clustering,omitempty"`
 	RandomizationKey     string                   `json:"randomization_key" validate:"required,notBlank"`
@@ -31,15 +36,29 @@ type UpdateProjectSettingsRequestBody struct {
 	ValidationUrl        *string                  `json:"validation_url" validate:"omitempty,url"`
 }
 
-type ProjectSettingsService interface {
-	ListProjects() (*[]models.Project, error)
-
-	GetProjectSettings(projectId int64) (*models.Settings, error)
-	GetExperimentVariables(projectId int64) (*[]string, error)
-	CreateProjectSettings(projectId int64, settings CreateProjectSettingsRequestBody) (*models.Settings, error)
-	UpdateProjectSettings(projectId int64, settings UpdateProjectSettingsRequestBody) (*models.Settings, error)
+type CreatePasskeyRequestBody struct {
+	Name      string     `json:"name" validate:"required,notBlank,max=64"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
 
-	GetDBRecord(projectId models.ID) (*models.Settings, error)
+type ProjectSettingsService interface {
+	ListProjects(ctx context.Context) (*[]models.Project, error)
+
+	GetProjectSettings(ctx context.Context, projectId int64) (*models.Settings, error)
+	GetExperimentVariables(ctx context.Context, projectId int64) (*[]string, error)
+	CreateProjectSettings(ctx context.Context, projectId int64, settings CreateProjectSettingsRequestBody) (*models.Settings, error)
+	UpdateProjectSettings(ctx context.Context, projectId int64, settings UpdateProjectSettingsRequestBody) (*models.Settings, error)
+
+	// ListPasskeys returns the passkeys of the project that have not been revoked, including the expired ones
+	ListPasskeys(ctx context.Context, projectId int64) ([]models.Passkey, error)
+	// CreatePasskey generates a new passkey for the project. The plaintext value of the key is only
+	// available in the returned passkey.
+	CreatePasskey(ctx context.Context, projectId int64, passkey CreatePasskeyRequestBody) (*models.Passkey, error)
+	// RevokePasskey deletes the passkey of the project with the given name. The last active passkey of
+	// the project cannot be revoked.
+	RevokePasskey(ctx context.Context, projectId int64, name string) error
+
+	GetDBRecord(ctx context.Context, projectId models.ID) (*models.Settings, error)
 }
 
 type projectSettingsService struct {
@@ -54,9 +73,9 @@ func NewProjectSettingsService(services *Services, db *gorm.DB) ProjectSettingsS
 	}
 }
 
-func (svc *projectSettingsService) ListProjects() (*[]models.Project, error) {
+func (svc *projectSettingsService) ListProjects(ctx context.Context) (*[]models.Project, error) {
 	var dbRecords []*models.Settings
-	err := svc.query().Find(&dbRecords).Error
+	err := svc.query(ctx).Find(&dbRecords).Error
 	if err != nil {
 		return nil, err
 	}
@@ -76,16 +95,16 @@ func (svc *projectSettingsService) ListProjects() (*[]models.Project, error) {
 	return &projectsResponse, nil
 }
 
-func (svc *projectSettingsService) GetProjectSettings(projectId int64) (*models.Settings, error) {
-	dbRecord, err := svc.GetDBRecord(models.ID(projectId))
+func (svc *projectSettingsService) GetProjectSettings(ctx context.Context, projectId int64) (*models.Settings, error) {
+	dbRecord, err := svc.GetDBRecord(ctx, models.ID(projectId))
 	if err != nil {
 		return nil, errors.Newf(errors.NotFound, err.Error())
 	}
 	return dbRecord, nil
 }
 
-func (svc *projectSettingsService) GetExperimentVariables(projectId int64) (*[]string, error) {
-	dbRecord, err := svc.GetDBRecord(models.ID(projectId))
+func (svc *projectSettingsService) GetExperimentVariables(ctx context.Context, projectId int64) (*[]string, error) {
+	dbRecord, err := svc.GetDBRecord(ctx, models.ID(projectId))
 	if err != nil {
 		return nil, errors.Newf(errors.NotFound, err.Error())
 	}
@@ -109,11 +128,12 @@ func (svc *projectSettingsService) GetExperimentVariables(projectId int64) (*[]s
 }
 
 func (svc *projectSettingsService) CreateProjectSettings(
+	ctx context.Context,
 	projectId int64,
 	settings CreateProjectSettingsRequestBody,
 ) (*models.Settings, error) {
 	// Check that the given project doesn't already have settings in the DB
-	_, err := svc.GetDBRecord(models.ID(projectId))
+	_, err := svc.GetDBRecord(ctx, models.ID(projectId))
 	if err == nil {
 		return nil, errors.Newf(errors.BadInput, "Project has already been set up")
 	}
@@ -125,25 +145,30 @@ func (svc *projectSettingsService) CreateProjectSettings(
 	}
 
 	// Validate segmenter are recognized and experiment variable mapping are accepted as system allowed
-	err = svc.services.SegmenterService.ValidateExperimentVariables(projectId, settings.Segmenters)
+	err = svc.services.SegmenterService.ValidateExperimentVariables(
+		ctx,
+		projectId,
+		settings.Segmenters,
+		settings.RandomizationKey,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify required segmenters are provided
-	err = svc.services.SegmenterService.ValidateRequiredSegmenters(projectId, settings.Segmenters.Names)
+	err = svc.services.SegmenterService.ValidateRequiredSegmenters(ctx, projectId, settings.Segmenters.Names)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify dependent segmenters are provided
-	err = svc.services.SegmenterService.ValidatePrereqSegmenters(projectId, settings.Segmenters.Names)
+	err = svc.services.SegmenterService.ValidatePrereqSegmenters(ctx, projectId, settings.Segmenters.Names)
 	if err != nil {
 		return nil, err
 	}
 
 	// Generate random Passkey
-	passkey, err := utils.GenerateRandomBase16String(PASSKEY_LENGTH)
+	passkey, err := generatePasskey(models.ID(projectId), defaultPasskeyName, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +177,6 @@ func (svc *projectSettingsService) CreateProjectSettings(
 	settingsRecord := &models.Settings{
 		ProjectID: models.ID(projectId),
 		Username:  settings.Username,
-		Passkey:   passkey,
 		Config: &models.ExperimentationConfig{
 			Segmenters: models.ProjectSegmenters{
 				Names:     settings.Segmenters.Names,
@@ -167,28 +191,28 @@ func (svc *projectSettingsService) CreateProjectSettings(
 		settingsRecord.Config.S2IDClusteringEnabled = *(settings.EnableS2idClustering)
 	}
 
-	// Save to DB
-	dbRecord, err := svc.save(settingsRecord)
+	// Save to DB, together with the passkey and the message to be published
+	dbRecord, err := svc.saveAndPublish(ctx, settingsRecord, "create", passkey)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to the format expected by the Message Queue
-	protoExpResponse := dbRecord.ToProtoSchema()
-	err = svc.services.MessageQueueService.PublishProjectSettingsMessage("create", &protoExpResponse)
-	if err != nil {
-		return nil, err
+	// Return the plaintext passkey, which is not stored
+	for i := range dbRecord.Passkeys {
+		if dbRecord.Passkeys[i].Name == passkey.Name {
+			dbRecord.Passkeys[i].Value = passkey.Value
+		}
 	}
-
 	return dbRecord, nil
 }
 
 func (svc *projectSettingsService) UpdateProjectSettings(
+	ctx context.Context,
 	projectId int64,
 	settings UpdateProjectSettingsRequestBody,
 ) (*models.Settings, error) {
 	// Get the existing settings from the DB
-	dbRecord, err := svc.GetDBRecord(models.ID(projectId))
+	dbRecord, err := svc.GetDBRecord(ctx, models.ID(projectId))
 	if err != nil {
 		return nil, errors.Newf(errors.NotFound, err.Error())
 	}
@@ -200,31 +224,36 @@ func (svc *projectSettingsService) UpdateProjectSettings(
 	}
 
 	// Validate segmenter are recognized and experiment variable mapping are accepted as system allowed
-	err = svc.services.SegmenterService.ValidateExperimentVariables(projectId, settings.Segmenters)
+	err = svc.services.SegmenterService.ValidateExperimentVariables(
+		ctx,
+		projectId,
+		settings.Segmenters,
+		settings.RandomizationKey,
+	)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify Segmenter names are recognised
-	_, err = svc.services.SegmenterService.GetSegmenterConfigurations(projectId, settings.Segmenters.Names)
+	_, err = svc.services.SegmenterService.GetSegmenterConfigurations(ctx, projectId, settings.Segmenters.Names)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify required segmenters are provided
-	err = svc.services.SegmenterService.ValidateRequiredSegmenters(projectId, settings.Segmenters.Names)
+	err = svc.services.SegmenterService.ValidateRequiredSegmenters(ctx, projectId, settings.Segmenters.Names)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify dependent segmenters are provided
-	err = svc.services.SegmenterService.ValidatePrereqSegmenters(projectId, settings.Segmenters.Names)
+	err = svc.services.SegmenterService.ValidatePrereqSegmenters(ctx, projectId, settings.Segmenters.Names)
 	if err != nil {
 		return nil, err
 	}
 
 	// Verify pairwise orthogonality checks are valid for all experiments
-	err = svc.validateProjectSettingsUpdate(projectId, dbRecord.Config.Segmenters.Names, settings.Segmenters.Names)
+	err = svc.validateProjectSettingsUpdate(ctx, projectId, dbRecord.Config.Segmenters.Names, settings.Segmenters.Names)
 	if err != nil {
 		return nil, err
 	}
@@ -238,25 +267,112 @@ func (svc *projectSettingsService) UpdateProjectSettings(
 	dbRecord.TreatmentSchema = settings.TreatmentSchema
 	dbRecord.ValidationUrl = settings.ValidationUrl
 
-	// Save to the DB
-	dbRecord, err = svc.save(dbRecord)
+	// Save to DB, together with the message to be published
+	return svc.saveAndPublish(ctx, dbRecord, "update")
+}
+
+func (svc *projectSettingsService) ListPasskeys(ctx context.Context, projectId int64) ([]models.Passkey, error) {
+	dbRecord, err := svc.GetDBRecord(ctx, models.ID(projectId))
+	if err != nil {
+		return nil, errors.Newf(errors.NotFound, err.Error())
+	}
+	return dbRecord.Passkeys, nil
+}
+
+func (svc *projectSettingsService) CreatePasskey(
+	ctx context.Context,
+	projectId int64,
+	body CreatePasskeyRequestBody,
+) (*models.Passkey, error) {
+	dbRecord, err := svc.GetDBRecord(ctx, models.ID(projectId))
+	if err != nil {
+		return nil, errors.Newf(errors.NotFound, err.Error())
+	}
+
+	// Validate passkey data
+	err = svc.services.ValidationService.Validate(body)
+	if err != nil {
+		return nil, errors.Newf(errors.BadInput, err.Error())
+	}
+	if body.ExpiresAt != nil && !body.ExpiresAt.After(time.Now()) {
+		return nil, errors.Newf(errors.BadInput, "passkey expiry must be in the future")
+	}
+	for _, p := range dbRecord.Passkeys {
+		if p.Name == body.Name {
+			return nil, errors.Newf(errors.BadInput, "passkey %s already exists", body.Name)
+		}
+	}
+
+	passkey, err := generatePasskey(dbRecord.ProjectID, body.Name, body.ExpiresAt)
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert to the format expected by the Message Queue
-	protoExpResponse := dbRecord.ToProtoSchema()
-	err = svc.services.MessageQueueService.PublishProjectSettingsMessage("update", &protoExpResponse)
+	// Save the passkey and publish the updated settings, so that the Treatment Service accepts the new key
+	err = svc.query(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(passkey).Error; err != nil {
+			return err
+		}
+		_, err := svc.publish(tx, dbRecord.ProjectID, "update")
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return dbRecord, nil
+	return passkey, nil
+}
+
+func (svc *projectSettingsService) RevokePasskey(ctx context.Context, projectId int64, name string) error {
+	return svc.query(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the settings, so that concurrent revocations cannot remove all the active passkeys
+		dbRecord, err := svc.getDBRecord(tx.Clauses(clause.Locking{Strength: "UPDATE"}), models.ID(projectId))
+		if err != nil {
+			return errors.Newf(errors.NotFound, err.Error())
+		}
+
+		var passkey *models.Passkey
+		activePasskeys := 0
+		now := time.Now()
+		for i, p := range dbRecord.Passkeys {
+			if p.Name == name {
+				passkey = &dbRecord.Passkeys[i]
+			}
+			if p.IsActive(now) {
+				activePasskeys++
+			}
+		}
+		if passkey == nil {
+			return errors.Newf(errors.NotFound, "passkey %s not found", name)
+		}
+		if passkey.IsActive(now) && activePasskeys == 1 {
+			return errors.Newf(errors.BadInput, "passkey %s is the only active passkey of the project", name)
+		}
+
+		if err := tx.Delete(passkey).Error; err != nil {
+			return err
+		}
+		// Stop publishing the legacy plaintext passkey, if it is the one revoked
+		if dbRecord.LegacyPasskey != "" && _utils.HashPasskey(dbRecord.LegacyPasskey) == passkey.Hash {
+			if err := tx.Model(dbRecord).Update("passkey", "").Error; err != nil {
+				return err
+			}
+		}
+		_, err = svc.publish(tx, dbRecord.ProjectID, "update")
+		return err
+	})
+}
+
+func (svc *projectSettingsService) GetDBRecord(ctx context.Context, projectId models.ID) (*models.Settings, error) {
+	return svc.getDBRecord(svc.query(ctx), projectId)
 }
 
-func (svc *projectSettingsService) GetDBRecord(projectId models.ID) (*models.Settings, error) {
+func (svc *projectSettingsService) getDBRecord(db *gorm.DB, projectId models.ID) (*models.Settings, error) {
 	var settings models.Settings
-	query := svc.query().
+	query := db.
+		Preload("Passkeys", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at, id")
+		}).
 		Where("project_id = ?", projectId).
 		First(&settings)
 	if err := query.Error; err != nil {
@@ -265,20 +381,83 @@ func (svc *projectSettingsService) GetDBRecord(projectId models.ID) (*models.Set
 	return &settings, nil
 }
 
-func (svc *projectSettingsService) query() *gorm.DB {
-	return svc.db
+func (svc *projectSettingsService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
 }
 
-func (svc *projectSettingsService) save(settings *models.Settings) (*models.Settings, error) {
-	if err := svc.query().Clauses(clause.OnConflict{
+func (svc *projectSettingsService) save(tx *gorm.DB, settings *models.Settings) (*models.Settings, error) {
+	// The passkeys are created and revoked separately
+	if err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(settings).Error; err != nil {
 		return nil, err
 	}
-	return svc.GetDBRecord(settings.ProjectID)
+	return svc.getDBRecord(tx, settings.ProjectID)
+}
+
+// saveAndPublish saves the settings and the new passkeys, if any, and writes the corresponding message
+// queue update to the outbox in a single transaction.
+func (svc *projectSettingsService) saveAndPublish(
+	ctx context.Context,
+	settings *models.Settings,
+	updateType string,
+	passkeys ...*models.Passkey,
+) (*models.Settings, error) {
+	var dbRecord *models.Settings
+	err := svc.query(ctx).Transaction(func(tx *gorm.DB) error {
+		_, err := svc.save(tx, settings)
+		if err != nil {
+			return err
+		}
+		for _, passkey := range passkeys {
+			if err := tx.Create(passkey).Error; err != nil {
+				return err
+			}
+		}
+
+		dbRecord, err = svc.publish(tx, settings.ProjectID, updateType)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dbRecord, nil
+}
+
+// publish writes the message queue update of the project settings, with its passkeys, to the outbox.
+func (svc *projectSettingsService) publish(tx *gorm.DB, projectId models.ID, updateType string) (*models.Settings, error) {
+	dbRecord, err := svc.getDBRecord(tx, projectId)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert to the format expected by the Message Queue
+	protoSettings := dbRecord.ToProtoSchema()
+	err = svc.services.OutboxService.AddProjectSettingsMessage(tx, updateType, &protoSettings)
+	if err != nil {
+		return nil, err
+	}
+	return dbRecord, nil
+}
+
+// generatePasskey generates a random passkey, of which only the hash is stored
+func generatePasskey(projectId models.ID, name string, expiresAt *time.Time) (*models.Passkey, error) {
+	value, err := utils.GenerateRandomBase16String(PASSKEY_LENGTH)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Passkey{
+		ProjectID: projectId,
+		Name:      name,
+		Hash:      _utils.HashPasskey(value),
+		ExpiresAt: expiresAt,
+		Value:     value,
+	}, nil
 }
 
 func (svc *projectSettingsService) validateProjectSettingsUpdate(
+	ctx context.Context,
 	projectId int64,
 	currentSegmenters []string,
 	updatedSegmenters []string,
@@ -295,17 +474,17 @@ func (svc *projectSettingsService) validateProjectSettingsUpdate(
 
 	if hasSetDifferences.Len() > 0 {
 		id := models.ID(projectId)
-		exps, err := svc.services.ExperimentService.ListAllExperiments(id, listExpParams)
+		exps, err := svc.services.ExperimentService.ListAllExperiments(ctx, id, listExpParams)
 		if err != nil {
 			return err
 		}
 
-		err = svc.services.ExperimentService.ValidatePairwiseExperimentOrthogonality(projectId, exps, updatedSegmenters)
+		err = svc.services.ExperimentService.ValidatePairwiseExperimentOrthogonality(ctx, projectId, exps, updatedSegmenters)
 		if err != nil {
 			return err
 		}
 		// Check if the set of updated segmenters contains all the segments specified by all the experiments
-		err = svc.services.ExperimentService.ValidateProjectExperimentSegmentersExist(projectId, exps, updatedSegmenters)
+		err = svc.services.ExperimentService.ValidateProjectExperimentSegmentersExist(ctx, projectId, exps, updatedSegmenters)
 		if err != nil {
 			return err
 		}
@@ -3,6 +3,7 @@
 package services_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -12,6 +13,8 @@ import (
 	"gorm.io/gorm"
 
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
+	"github.com/caraml-dev/xp/management-service/config"
 	"github.com/caraml-dev/xp/management-service/errors"
 	tu "github.com/caraml-dev/xp/management-service/internal/testutils"
 	"github.com/caraml-dev/xp/management-service/models"
@@ -48,26 +51,26 @@ func (s *ProjectSettingsServiceTestSuite) SetupSuite() {
 	// Init mock experiment service
 	expSvc := &mocks.ExperimentService{}
 	expSvc.
-		On("ListAllExperiments",
+		On("ListAllExperiments", mock.Anything,
 			models.ID(2),
 			mock.Anything,
 		).
 		Return(nil, gorm.ErrRecordNotFound)
 	expSvc.
-		On("ListAllExperiments",
+		On("ListAllExperiments", mock.Anything,
 			models.ID(1),
 			mock.Anything,
 		).
 		Return([]*models.Experiment{}, nil)
 	expSvc.
-		On("ValidatePairwiseExperimentOrthogonality",
+		On("ValidatePairwiseExperimentOrthogonality", mock.Anything,
 			int64(1),
 			mock.Anything,
 			[]string{"seg1"},
 		).
 		Return(nil)
 	expSvc.
-		On("ValidateProjectExperimentSegmentersExist",
+		On("ValidateProjectExperimentSegmentersExist", mock.Anything,
 			int64(1),
 			mock.Anything,
 			[]string{"seg1"},
@@ -83,38 +86,38 @@ func (s *ProjectSettingsServiceTestSuite) SetupSuite() {
 	// Init mock segmenter service
 	segmenterSvc := &mocks.SegmenterService{}
 	segmenterSvc.
-		On("GetSegmenterConfigurations", int64(3), []string{"seg5", "seg6"}).
+		On("GetSegmenterConfigurations", mock.Anything, int64(3), []string{"seg5", "seg6"}).
 		Return(nil, nil)
 	segmenterSvc.
-		On("GetSegmenterConfigurations", int64(2), []string{"seg1"}).
+		On("GetSegmenterConfigurations", mock.Anything, int64(2), []string{"seg1"}).
 		Return(nil, nil)
 	segmenterSvc.
-		On("GetSegmenterConfigurations", int64(1), []string{"seg1"}).
+		On("GetSegmenterConfigurations", mock.Anything, int64(1), []string{"seg1"}).
 		Return(nil, nil)
 	segmenterSvc.
 		On("ListGlobalSegmentersNames").
 		Return([]string{"seg-3"})
 	segmenterSvc.
-		On("ValidateRequiredSegmenters", int64(3), mock.Anything).
+		On("ValidateRequiredSegmenters", mock.Anything, int64(3), mock.Anything).
 		Return(nil)
 	segmenterSvc.
-		On("ValidateRequiredSegmenters", int64(2), mock.Anything).
+		On("ValidateRequiredSegmenters", mock.Anything, int64(2), mock.Anything).
 		Return(nil)
 	segmenterSvc.
-		On("ValidateRequiredSegmenters", int64(1), []string{"seg1"}).
+		On("ValidateRequiredSegmenters", mock.Anything, int64(1), []string{"seg1"}).
 		Return(nil)
 	segmenterSvc.
-		On("ValidatePrereqSegmenters", int64(3), mock.Anything).
+		On("ValidatePrereqSegmenters", mock.Anything, int64(3), mock.Anything).
 		Return(nil)
 	segmenterSvc.
-		On("ValidatePrereqSegmenters", int64(2), mock.Anything).
+		On("ValidatePrereqSegmenters", mock.Anything, int64(2), mock.Anything).
 		Return(nil)
 	segmenterSvc.
-		On("ValidatePrereqSegmenters", int64(1), []string{"seg1"}).
+		On("ValidatePrereqSegmenters", mock.Anything, int64(1), []string{"seg1"}).
 		Return(nil)
-	segmenterSvc.On("ValidateExperimentVariables", int64(3), mock.Anything).Return(nil)
-	segmenterSvc.On("ValidateExperimentVariables", int64(2), mock.Anything).Return(nil)
-	segmenterSvc.On("ValidateExperimentVariables", int64(1), mock.Anything).Return(nil)
+	segmenterSvc.On("ValidateExperimentVariables", mock.Anything, int64(3), mock.Anything, mock.Anything).Return(nil)
+	segmenterSvc.On("ValidateExperimentVariables", mock.Anything, int64(2), mock.Anything, mock.Anything).Return(nil)
+	segmenterSvc.On("ValidateExperimentVariables", mock.Anything, int64(1), mock.Anything, mock.Anything).Return(nil)
 
 	// Init mock validation service
 	validationSvc := &mocks.ValidationService{}
@@ -174,26 +177,14 @@ func (s *ProjectSettingsServiceTestSuite) SetupSuite() {
 			RandomizationKey: "rand-2",
 		},
 	).Return(nil)
-
-	// Init mock pubsub service
-	messageQueueSvc := &mocks.MessageQueueService{}
-	messageQueueSvc.On(
-		"PublishProjectSettingsMessage",
-		"create",
-		mock.Anything,
-	).Return(nil)
-	messageQueueSvc.On(
-		"PublishProjectSettingsMessage",
-		"update",
-		mock.Anything,
-	).Return(nil)
+	validationSvc.On("Validate", mock.AnythingOfType("services.CreatePasskeyRequestBody")).Return(nil)
 
 	allServices := &services.Services{
-		ExperimentService:   expSvc,
-		ValidationService:   validationSvc,
-		MessageQueueService: messageQueueSvc,
-		SegmenterService:    segmenterSvc,
+		ExperimentService: expSvc,
+		ValidationService: validationSvc,
+		SegmenterService:  segmenterSvc,
 	}
+	allServices.OutboxService = services.NewOutboxService(allServices, config.OutboxConfig{}, db)
 
 	// Init user service
 	s.ProjectSettingsService = services.NewProjectSettingsService(allServices, db)
@@ -215,24 +206,24 @@ func TestProjectSettingsService(t *testing.T) {
 }
 
 func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceGetIntegration() {
-	settingsResponse, err := s.ProjectSettingsService.GetProjectSettings(1)
+	settingsResponse, err := s.ProjectSettingsService.GetProjectSettings(context.Background(), 1)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.ProjectSettings[0], *settingsResponse)
-	settingsResponse, err = s.ProjectSettingsService.GetProjectSettings(2)
+	settingsResponse, err = s.ProjectSettingsService.GetProjectSettings(context.Background(), 2)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.ProjectSettings[1], *settingsResponse)
-	settingsResponse, err = s.ProjectSettingsService.GetProjectSettings(4)
+	settingsResponse, err = s.ProjectSettingsService.GetProjectSettings(context.Background(), 4)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.ProjectSettings[2], *settingsResponse)
 }
 
 func (s *ProjectSettingsServiceTestSuite) TestGetExperimentVariablesIntegration() {
 	// Get request parameters for valid global segmenters, seg2 is duplicate, GetExperimentVariables should not return duplicate
-	params, err := s.ProjectSettingsService.GetExperimentVariables(1)
+	params, err := s.ProjectSettingsService.GetExperimentVariables(context.Background(), 1)
 	s.Suite.Require().NoError(err)
 	assert.ElementsMatch(s.Suite.T(), []string{"exp-var-1", "exp-var-2", "exp-var-3", "rand-1"}, *params)
 	// Get request parameters for valid global segmenters
-	params, err = s.ProjectSettingsService.GetExperimentVariables(2)
+	params, err = s.ProjectSettingsService.GetExperimentVariables(context.Background(), 2)
 	s.Suite.Require().NoError(err)
 	assert.ElementsMatch(s.Suite.T(), []string{"exp-var-3", "exp-var-4", "rand-2"}, *params)
 }
@@ -264,8 +255,16 @@ func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceListCreateUp
 			UpdatedAt:        s.ProjectSettings[2].UpdatedAt,
 			Username:         s.ProjectSettings[2].Username,
 		},
+		{
+			CreatedAt:        s.ProjectSettings[3].CreatedAt,
+			Id:               s.ProjectSettings[3].ProjectID.ToApiSchema(),
+			RandomizationKey: s.ProjectSettings[3].Config.RandomizationKey,
+			Segmenters:       s.ProjectSettings[3].Config.Segmenters.Names,
+			UpdatedAt:        s.ProjectSettings[3].UpdatedAt,
+			Username:         s.ProjectSettings[3].Username,
+		},
 	}
-	projectsResponse, err := s.ProjectSettingsService.ListProjects()
+	projectsResponse, err := s.ProjectSettingsService.ListProjects(context.Background())
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), expectedProjects, *projectsResponse)
 
@@ -273,6 +272,7 @@ func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceListCreateUp
 	projectId := int64(3)
 	s2idClusterEnabled := true
 	settingsResponse, err := s.ProjectSettingsService.CreateProjectSettings(
+		context.Background(),
 		projectId,
 		services.CreateProjectSettingsRequestBody{
 			Username: "client-3",
@@ -296,7 +296,7 @@ func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceListCreateUp
 		},
 		ProjectID: models.ID(projectId),
 		Username:  "client-3",
-		Passkey:   settingsResponse.Passkey, // Copy the passkey from the result
+		Passkeys:  settingsResponse.Passkeys, // Copy the generated passkey from the result
 		Config: &models.ExperimentationConfig{
 			Segmenters: models.ProjectSegmenters{
 				Names: []string{"seg5", "seg6"},
@@ -311,10 +311,17 @@ func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceListCreateUp
 			Rules: []models.Rule{},
 		},
 	}, *settingsResponse)
-	s.Suite.Require().True(len(settingsResponse.Passkey) == 32)
+	// Only the hash of the generated passkey is stored
+	s.Suite.Require().Len(settingsResponse.Passkeys, 1)
+	passkey := settingsResponse.Passkeys[0]
+	s.Suite.Assert().Equal("default", passkey.Name)
+	s.Suite.Require().True(len(passkey.Value) == 32)
+	s.Suite.Assert().Equal(_utils.HashPasskey(passkey.Value), passkey.Hash)
+	passkey.Value = ""
 
 	// Update Settings
 	settingsResponse, err = s.ProjectSettingsService.UpdateProjectSettings(
+		context.Background(),
 		projectId,
 		services.UpdateProjectSettingsRequestBody{
 			Segmenters: models.ProjectSegmenters{
@@ -346,7 +353,7 @@ func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceListCreateUp
 		},
 		ProjectID: models.ID(projectId),
 		Username:  "client-3",
-		Passkey:   settingsResponse.Passkey, // Copy the passkey from the result
+		Passkeys:  []models.Passkey{passkey},
 		Config: &models.ExperimentationConfig{
 			Segmenters: models.ProjectSegmenters{
 				Names: []string{"seg5", "seg6"},
@@ -376,6 +383,7 @@ func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceListCreateUp
 func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceUpdateExperimentsNotFound() {
 	// Update Settings
 	settingsResponse, err := s.ProjectSettingsService.UpdateProjectSettings(
+		context.Background(),
 		int64(2),
 		services.UpdateProjectSettingsRequestBody{
 			Segmenters: models.ProjectSegmenters{
@@ -393,6 +401,7 @@ func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceUpdateExperi
 func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceUpdateInvalidRequiredSegmenterRemoval() {
 	// Update Settings
 	settingsResponse, err := s.ProjectSettingsService.UpdateProjectSettings(
+		context.Background(),
 		int64(1),
 		services.UpdateProjectSettingsRequestBody{
 			Segmenters: models.ProjectSegmenters{
@@ -408,6 +417,67 @@ func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServiceUpdateInvali
 	s.Suite.Require().Nil(settingsResponse)
 }
 
+func (s *ProjectSettingsServiceTestSuite) TestProjectSettingsServicePasskeysIntegration() {
+	projectId := int64(5)
+
+	passkeys, err := s.ProjectSettingsService.ListPasskeys(context.Background(), projectId)
+	s.Suite.Require().NoError(err)
+	tu.AssertEqualValues(s.Suite.T(), s.ProjectSettings[3].Passkeys, passkeys)
+
+	_, err = s.ProjectSettingsService.ListPasskeys(context.Background(), int64(6))
+	s.Suite.Assert().EqualError(err, "record not found")
+
+	// Create passkeys
+	_, err = s.ProjectSettingsService.CreatePasskey(context.Background(), projectId, services.CreatePasskeyRequestBody{Name: "default"})
+	s.Suite.Assert().EqualError(err, "passkey default already exists")
+
+	pastExpiry := time.Now().Add(-time.Hour)
+	_, err = s.ProjectSettingsService.CreatePasskey(context.Background(), projectId, services.CreatePasskeyRequestBody{
+		Name:      "rotated",
+		ExpiresAt: &pastExpiry,
+	})
+	s.Suite.Assert().EqualError(err, "passkey expiry must be in the future")
+
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second).UTC()
+	passkey, err := s.ProjectSettingsService.CreatePasskey(context.Background(), projectId, services.CreatePasskeyRequestBody{
+		Name:      "rotated",
+		ExpiresAt: &expiresAt,
+	})
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal("rotated", passkey.Name)
+	s.Suite.Require().True(len(passkey.Value) == 32)
+	s.Suite.Assert().Equal(_utils.HashPasskey(passkey.Value), passkey.Hash)
+
+	passkeys, err = s.ProjectSettingsService.ListPasskeys(context.Background(), projectId)
+	s.Suite.Require().NoError(err)
+	s.Suite.Require().Len(passkeys, 2)
+	s.Suite.Assert().Equal("default", passkeys[0].Name)
+	s.Suite.Assert().Equal("rotated", passkeys[1].Name)
+	s.Suite.Assert().Equal(passkey.Hash, passkeys[1].Hash)
+	s.Suite.Assert().Equal(expiresAt, *passkeys[1].ExpiresAt)
+	s.Suite.Assert().Empty(passkeys[1].Value)
+
+	// Revoke passkeys
+	err = s.ProjectSettingsService.RevokePasskey(context.Background(), projectId, "unknown")
+	s.Suite.Assert().EqualError(err, "passkey unknown not found")
+
+	err = s.ProjectSettingsService.RevokePasskey(context.Background(), projectId, "default")
+	s.Suite.Require().NoError(err)
+
+	// The legacy plaintext passkey is no longer published once the default passkey is revoked
+	settings, err := s.ProjectSettingsService.GetDBRecord(context.Background(), models.ID(projectId))
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Empty(settings.LegacyPasskey)
+
+	err = s.ProjectSettingsService.RevokePasskey(context.Background(), projectId, "rotated")
+	s.Suite.Assert().EqualError(err, "passkey rotated is the only active passkey of the project")
+
+	passkeys, err = s.ProjectSettingsService.ListPasskeys(context.Background(), projectId)
+	s.Suite.Require().NoError(err)
+	s.Suite.Require().Len(passkeys, 1)
+	s.Suite.Assert().Equal("rotated", passkeys[0].Name)
+}
+
 func createTestUsers(db *gorm.DB) ([]models.Settings, error) {
 	testValidationUrl := "https://test-validation-url.io"
 	// Set up test settings records
@@ -418,7 +488,9 @@ func createTestUsers(db *gorm.DB) ([]models.Settings, error) {
 				UpdatedAt: time.Date(2020, 1, 2, 3, 3, 3, 0, time.UTC),
 			},
 			Username: "client-1",
-			Passkey:  "passkey-1",
+			Passkeys: []models.Passkey{
+				testPasskey(models.ID(1), "default", "passkey-1", time.Date(2020, 1, 1, 2, 3, 4, 0, time.UTC)),
+			},
 			Config: &models.ExperimentationConfig{
 				Segmenters: models.ProjectSegmenters{
 					Names: []string{"seg1", "seg2"},
@@ -436,7 +508,9 @@ func createTestUsers(db *gorm.DB) ([]models.Settings, error) {
 				UpdatedAt: time.Date(2021, 1, 2, 3, 3, 3, 0, time.UTC),
 			},
 			Username: "client-2",
-			Passkey:  "passkey-2",
+			Passkeys: []models.Passkey{
+				testPasskey(models.ID(2), "default", "passkey-2", time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC)),
+			},
 			Config: &models.ExperimentationConfig{
 				Segmenters: models.ProjectSegmenters{
 					Names: []string{"seg3", "seg4"},
@@ -468,7 +542,9 @@ func createTestUsers(db *gorm.DB) ([]models.Settings, error) {
 				UpdatedAt: time.Date(2021, 1, 2, 3, 3, 3, 0, time.UTC),
 			},
 			Username: "client-4",
-			Passkey:  "passkey-4",
+			Passkeys: []models.Passkey{
+				testPasskey(models.ID(4), "default", "passkey-4", time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC)),
+			},
 			Config: &models.ExperimentationConfig{
 				Segmenters: models.ProjectSegmenters{
 					Names: []string{"seg7", "seg8"},
@@ -485,11 +561,30 @@ func createTestUsers(db *gorm.DB) ([]models.Settings, error) {
 			ValidationUrl: &testValidationUrl,
 			ProjectID:     models.ID(4),
 		},
+		{
+			Model: models.Model{
+				CreatedAt: time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC),
+				UpdatedAt: time.Date(2021, 1, 2, 3, 3, 3, 0, time.UTC),
+			},
+			Username: "client-5",
+			Passkeys: []models.Passkey{
+				testPasskey(models.ID(5), "default", "passkey-5", time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC)),
+			},
+			LegacyPasskey: "passkey-5",
+			Config: &models.ExperimentationConfig{
+				Segmenters: models.ProjectSegmenters{
+					Names:     []string{"seg9"},
+					Variables: map[string][]string{"seg9": {"exp-var-9"}},
+				},
+				RandomizationKey: "rand-5",
+			},
+			ProjectID: models.ID(5),
+		},
 	}
 
-	// Create settings records
-	for _, settings := range settingsRecords {
-		err := db.Create(&settings).Error
+	// Create settings records, together with the passkeys
+	for i := range settingsRecords {
+		err := db.Create(&settingsRecords[i]).Error
 		if err != nil {
 			return []models.Settings{}, err
 		}
@@ -498,3 +593,15 @@ func createTestUsers(db *gorm.DB) ([]models.Settings, error) {
 	// Return expected user responses
 	return settingsRecords, nil
 }
+
+func testPasskey(projectId models.ID, name string, value string, createdAt time.Time) models.Passkey {
+	return models.Passkey{
+		Model: models.Model{
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+		},
+		ProjectID: projectId,
+		Name:      name,
+		Hash:      _utils.HashPasskey(value),
+	}
+}
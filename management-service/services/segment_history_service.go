@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -14,11 +16,15 @@ type ListSegmentHistoryParams struct {
 }
 
 type SegmentHistoryService interface {
-	ListSegmentHistory(segmentId int64, params ListSegmentHistoryParams) ([]*models.SegmentHistory, *pagination.Paging, error)
-	GetSegmentHistory(segmentId int64, version int64) (*models.SegmentHistory, error)
-	CreateSegmentHistory(*models.Segment) (*models.SegmentHistory, error)
-	GetDBRecord(segmentId models.ID, version int64) (*models.SegmentHistory, error)
-	DeleteSegmentHistory(segmentId int64) error
+	ListSegmentHistory(
+		ctx context.Context,
+		segmentId int64,
+		params ListSegmentHistoryParams,
+	) ([]*models.SegmentHistory, *pagination.Paging, error)
+	GetSegmentHistory(ctx context.Context, segmentId int64, version int64) (*models.SegmentHistory, error)
+	CreateSegmentHistory(ctx context.Context, segment *models.Segment) (*models.SegmentHistory, error)
+	GetDBRecord(ctx context.Context, segmentId models.ID, version int64) (*models.SegmentHistory, error)
+	DeleteSegmentHistory(ctx context.Context, segmentId int64) error
 }
 
 type segmentHistoryService struct {
@@ -32,11 +38,12 @@ func NewSegmentHistoryService(db *gorm.DB) SegmentHistoryService {
 }
 
 func (svc *segmentHistoryService) ListSegmentHistory(
+	ctx context.Context,
 	segmentId int64,
 	params ListSegmentHistoryParams,
 ) ([]*models.SegmentHistory, *pagination.Paging, error) {
 	var history []*models.SegmentHistory
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("segment_id = ?", segmentId).
 		Order("updated_at desc")
 
@@ -71,10 +78,11 @@ func (svc *segmentHistoryService) ListSegmentHistory(
 }
 
 func (svc *segmentHistoryService) GetSegmentHistory(
+	ctx context.Context,
 	segmentId int64,
 	version int64,
 ) (*models.SegmentHistory, error) {
-	history, err := svc.GetDBRecord(models.ID(segmentId), version)
+	history, err := svc.GetDBRecord(ctx, models.ID(segmentId), version)
 	if err != nil {
 		return nil, errors.Newf(errors.NotFound, err.Error())
 	}
@@ -82,24 +90,28 @@ func (svc *segmentHistoryService) GetSegmentHistory(
 	return history, nil
 }
 
-func (svc *segmentHistoryService) CreateSegmentHistory(segment *models.Segment) (*models.SegmentHistory, error) {
+func (svc *segmentHistoryService) CreateSegmentHistory(
+	ctx context.Context,
+	segment *models.Segment,
+) (*models.SegmentHistory, error) {
 	var history []*models.SegmentHistory
 	var count int64
 	// Begin transaction - so that getting the current count and creating the new record are
 	// done in a single transaction.
-	tx := svc.db.Begin()
+	tx := svc.query(ctx).Begin()
 	// Get the count of the existing segment history records
-	svc.query().Where("segment_id = ?", segment.ID).Model(&history).Count(&count)
+	svc.query(ctx).Where("segment_id = ?", segment.ID).Model(&history).Count(&count)
 	// Create the new history record
-	newHistoryRecord, err := svc.save(&models.SegmentHistory{
+	newHistoryRecord, err := svc.save(ctx, &models.SegmentHistory{
 		Model: models.Model{
 			CreatedAt: segment.UpdatedAt,
 		},
-		SegmentID: segment.ID,
-		Version:   count + 1,
-		Name:      segment.Name,
-		Segment:   segment.Segment,
-		UpdatedBy: segment.UpdatedBy,
+		SegmentID:  segment.ID,
+		Version:    count + 1,
+		Name:       segment.Name,
+		Segment:    segment.Segment,
+		S2Covering: segment.S2Covering,
+		UpdatedBy:  segment.UpdatedBy,
 	})
 	if err != nil {
 		return nil, err
@@ -107,8 +119,8 @@ func (svc *segmentHistoryService) CreateSegmentHistory(segment *models.Segment)
 	return newHistoryRecord, tx.Commit().Error
 }
 
-func (svc *segmentHistoryService) DeleteSegmentHistory(segmentId int64) error {
-	query := svc.query().
+func (svc *segmentHistoryService) DeleteSegmentHistory(ctx context.Context, segmentId int64) error {
+	query := svc.query(ctx).
 		Where("segment_id = ?", segmentId).
 		Unscoped().
 		Delete(models.SegmentHistory{})
@@ -119,11 +131,12 @@ func (svc *segmentHistoryService) DeleteSegmentHistory(segmentId int64) error {
 }
 
 func (svc *segmentHistoryService) GetDBRecord(
+	ctx context.Context,
 	segmentId models.ID,
 	version int64,
 ) (*models.SegmentHistory, error) {
 	var history models.SegmentHistory
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("segment_id = ?", segmentId).
 		Where("version = ?", version).
 		First(&history)
@@ -133,15 +146,15 @@ func (svc *segmentHistoryService) GetDBRecord(
 	return &history, nil
 }
 
-func (svc *segmentHistoryService) query() *gorm.DB {
-	return svc.db
+func (svc *segmentHistoryService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
 }
 
-func (svc *segmentHistoryService) save(history *models.SegmentHistory) (*models.SegmentHistory, error) {
-	if err := svc.query().Clauses(clause.OnConflict{
+func (svc *segmentHistoryService) save(ctx context.Context, history *models.SegmentHistory) (*models.SegmentHistory, error) {
+	if err := svc.query(ctx).Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(history).Error; err != nil {
 		return nil, err
 	}
-	return svc.GetDBRecord(history.SegmentID, history.Version)
+	return svc.GetDBRecord(ctx, history.SegmentID, history.Version)
 }
@@ -3,6 +3,7 @@
 package services_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -53,11 +54,11 @@ func (s *SegmentHistoryServiceTestSuite) TearDownSuite() {
 
 func (s *SegmentHistoryServiceTestSuite) TestSegmentHistoryServiceGetIntegration() {
 	// Successful get
-	histResponse, err := s.SegmentHistoryService.GetSegmentHistory(1, 1)
+	histResponse, err := s.SegmentHistoryService.GetSegmentHistory(context.Background(), 1, 1)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.SegmentHistory[0], histResponse)
 	// Invalid version
-	_, err = s.SegmentHistoryService.GetSegmentHistory(1, 200)
+	_, err = s.SegmentHistoryService.GetSegmentHistory(context.Background(), 1, 200)
 	s.Suite.Require().EqualError(err, "record not found")
 }
 
@@ -69,13 +70,13 @@ func (s *SegmentHistoryServiceTestSuite) TestSegmentHistoryServiceListCreateInte
 }
 
 func testListSegmentHistory(s *SegmentHistoryServiceTestSuite) {
-	histResponse, paging, err := s.SegmentHistoryService.ListSegmentHistory(1, services.ListSegmentHistoryParams{})
+	histResponse, paging, err := s.SegmentHistoryService.ListSegmentHistory(context.Background(), 1, services.ListSegmentHistoryParams{})
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.SegmentHistory, histResponse)
 	tu.AssertEqualValues(s.Suite.T(), &pagination.Paging{Page: 1, Pages: 1, Total: 2}, paging)
 	// Pagination
 	var page, pageSize int32 = 2, 1
-	histResponse, paging, err = s.SegmentHistoryService.ListSegmentHistory(1, services.ListSegmentHistoryParams{
+	histResponse, paging, err = s.SegmentHistoryService.ListSegmentHistory(context.Background(), 1, services.ListSegmentHistoryParams{
 		pagination.PaginationOptions{
 			Page:     &page,
 			PageSize: &pageSize,
@@ -85,7 +86,7 @@ func testListSegmentHistory(s *SegmentHistoryServiceTestSuite) {
 	tu.AssertEqualValues(s.Suite.T(), []*models.SegmentHistory{s.SegmentHistory[1]}, histResponse)
 	tu.AssertEqualValues(s.Suite.T(), &pagination.Paging{Page: 2, Pages: 2, Total: 2}, paging)
 	// No history
-	histResponse, paging, err = s.SegmentHistoryService.ListSegmentHistory(2, services.ListSegmentHistoryParams{})
+	histResponse, paging, err = s.SegmentHistoryService.ListSegmentHistory(context.Background(), 2, services.ListSegmentHistoryParams{})
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), []*models.SegmentHistory{}, histResponse)
 	tu.AssertEqualValues(s.Suite.T(), &pagination.Paging{Page: 1, Pages: 0, Total: 0}, paging)
@@ -93,7 +94,7 @@ func testListSegmentHistory(s *SegmentHistoryServiceTestSuite) {
 
 func testCreateSegmentHistory(s *SegmentHistoryServiceTestSuite) {
 	segment := s.Segments[1]
-	segmentHist, err := s.SegmentHistoryService.CreateSegmentHistory(segment)
+	segmentHist, err := s.SegmentHistoryService.CreateSegmentHistory(context.Background(), segment)
 
 	s.Suite.Require().NoError(err)
 
@@ -117,7 +118,7 @@ func testCreateSegmentHistory(s *SegmentHistoryServiceTestSuite) {
 	s.Suite.Assert().JSONEq(string(expectedJSON), string(segmentHistJSON))
 
 	// Get the newly created segment history and verify
-	segmentHist, err = s.SegmentHistoryService.GetSegmentHistory(int64(s.Segments[1].ID), 1)
+	segmentHist, err = s.SegmentHistoryService.GetSegmentHistory(context.Background(), int64(s.Segments[1].ID), 1)
 	s.Suite.Require().NoError(err)
 	// JSON Marshal to compare, to bypass timezone precision issues
 	segmentHistJSON, _ = json.Marshal(segmentHist)
@@ -127,7 +128,7 @@ func testCreateSegmentHistory(s *SegmentHistoryServiceTestSuite) {
 func testDeleteSegmentHistory(s *SegmentHistoryServiceTestSuite) {
 	// Delete SegmentHistory
 	deletedSegmentId := int64(3)
-	err := s.SegmentHistoryService.DeleteSegmentHistory(deletedSegmentId)
+	err := s.SegmentHistoryService.DeleteSegmentHistory(context.Background(), deletedSegmentId)
 	s.Suite.Require().NoError(err)
 }
 
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/golang-collections/collections/set"
@@ -32,15 +33,16 @@ type ListSegmentsParams struct {
 
 type SegmentService interface {
 	ListSegments(
+		ctx context.Context,
 		projectId int64,
 		params ListSegmentsParams,
 	) ([]*models.Segment, *pagination.Paging, error)
-	GetSegment(projectId int64, segmentId int64) (*models.Segment, error)
-	CreateSegment(settings models.Settings, segmentData CreateSegmentRequestBody) (*models.Segment, error)
-	UpdateSegment(settings models.Settings, segmentId int64, segmentData UpdateSegmentRequestBody) (*models.Segment, error)
-	DeleteSegment(projectId int64, segmentId int64) error
+	GetSegment(ctx context.Context, projectId int64, segmentId int64) (*models.Segment, error)
+	CreateSegment(ctx context.Context, settings models.Settings, segmentData CreateSegmentRequestBody) (*models.Segment, error)
+	UpdateSegment(ctx context.Context, settings models.Settings, segmentId int64, segmentData UpdateSegmentRequestBody) (*models.Segment, error)
+	DeleteSegment(ctx context.Context, projectId int64, segmentId int64) error
 
-	GetDBRecord(projectId models.ID, segmentId models.ID) (*models.Segment, error)
+	GetDBRecord(ctx context.Context, projectId models.ID, segmentId models.ID) (*models.Segment, error)
 }
 
 type segmentService struct {
@@ -56,13 +58,14 @@ func NewSegmentService(services *Services, db *gorm.DB) SegmentService {
 }
 
 func (svc *segmentService) ListSegments(
+	ctx context.Context,
 	projectId int64,
 	params ListSegmentsParams,
 ) ([]*models.Segment, *pagination.Paging, error) {
 	var err error
 	var segments []*models.Segment
 
-	query := svc.query()
+	query := svc.query(ctx)
 	if params.Fields != nil && len(*params.Fields) != 0 {
 		err = validateListSegmentFieldNames(*params.Fields)
 		if err != nil {
@@ -124,8 +127,8 @@ func (svc *segmentService) ListSegments(
 	return segments, pagingResponse, nil
 }
 
-func (svc *segmentService) GetSegment(projectId int64, segmentId int64) (*models.Segment, error) {
-	segment, err := svc.GetDBRecord(models.ID(projectId), models.ID(segmentId))
+func (svc *segmentService) GetSegment(ctx context.Context, projectId int64, segmentId int64) (*models.Segment, error) {
+	segment, err := svc.GetDBRecord(ctx, models.ID(projectId), models.ID(segmentId))
 	if err != nil {
 		return nil, errors.Newf(errors.NotFound, err.Error())
 	}
@@ -134,6 +137,7 @@ func (svc *segmentService) GetSegment(projectId int64, segmentId int64) (*models
 }
 
 func (svc *segmentService) CreateSegment(
+	ctx context.Context,
 	settings models.Settings,
 	segmentData CreateSegmentRequestBody,
 ) (*models.Segment, error) {
@@ -143,8 +147,16 @@ func (svc *segmentService) CreateSegment(
 		return nil, errors.Newf(errors.BadInput, err.Error())
 	}
 
+	// Compute the segmenter values given as GeoJSON geometries
+	segmentValues, s2Covering, err := svc.services.SegmenterService.CoverSegmentGeometries(segmentData.Segment)
+	if err != nil {
+		return nil, errors.Newf(errors.BadInput, err.Error())
+	}
+	segmentData.Segment = segmentValues
+
 	// Validate segmenters
 	err = svc.services.SegmenterService.ValidateExperimentSegment(
+		ctx,
 		int64(settings.ProjectID),
 		settings.Config.Segmenters.Names,
 		segmentData.Segment,
@@ -154,7 +166,7 @@ func (svc *segmentService) CreateSegment(
 	}
 
 	// Create the segment record
-	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(int64(settings.ProjectID))
+	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(ctx, int64(settings.ProjectID))
 	if err != nil {
 		return nil, err
 	}
@@ -163,14 +175,15 @@ func (svc *segmentService) CreateSegment(
 		return nil, err
 	}
 	segment := &models.Segment{
-		ProjectID: settings.ProjectID,
-		Name:      segmentData.Name,
-		Segment:   segmenterStorageSchema,
-		UpdatedBy: *segmentData.UpdatedBy,
+		ProjectID:  settings.ProjectID,
+		Name:       segmentData.Name,
+		Segment:    segmenterStorageSchema,
+		S2Covering: s2Covering,
+		UpdatedBy:  *segmentData.UpdatedBy,
 	}
 
 	// Save to DB
-	segmentDBRecord, err := svc.save(segment)
+	segmentDBRecord, err := svc.save(ctx, segment)
 	if err != nil {
 		return nil, err
 	}
@@ -179,6 +192,7 @@ func (svc *segmentService) CreateSegment(
 }
 
 func (svc *segmentService) UpdateSegment(
+	ctx context.Context,
 	settings models.Settings,
 	segmentId int64,
 	segmentData UpdateSegmentRequestBody,
@@ -189,8 +203,16 @@ func (svc *segmentService) UpdateSegment(
 		return nil, errors.Newf(errors.BadInput, err.Error())
 	}
 
+	// Compute the segmenter values given as GeoJSON geometries
+	segmentValues, s2Covering, err := svc.services.SegmenterService.CoverSegmentGeometries(segmentData.Segment)
+	if err != nil {
+		return nil, errors.Newf(errors.BadInput, err.Error())
+	}
+	segmentData.Segment = segmentValues
+
 	// Validate segmenters
 	err = svc.services.SegmenterService.ValidateExperimentSegment(
+		ctx,
 		int64(settings.ProjectID),
 		settings.Config.Segmenters.Names,
 		segmentData.Segment,
@@ -200,13 +222,14 @@ func (svc *segmentService) UpdateSegment(
 	}
 
 	// Get current segment
-	curSegment, err := svc.GetDBRecord(settings.ProjectID, models.ID(segmentId))
+	curSegment, err := svc.GetDBRecord(ctx, settings.ProjectID, models.ID(segmentId))
 	if err != nil {
 		return nil, err
 	}
 
 	// Validate segmenters
 	err = svc.services.SegmenterService.ValidateExperimentSegment(
+		ctx,
 		int64(settings.ProjectID),
 		settings.Config.Segmenters.Names,
 		segmentData.Segment,
@@ -216,13 +239,13 @@ func (svc *segmentService) UpdateSegment(
 	}
 
 	// Copy current segment's contents as segment history
-	_, err = svc.services.SegmentHistoryService.CreateSegmentHistory(curSegment)
+	_, err = svc.services.SegmentHistoryService.CreateSegmentHistory(ctx, curSegment)
 	if err != nil {
 		return nil, err
 	}
 
 	// Update current segment and save to DB
-	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(int64(settings.ProjectID))
+	segmenterTypes, err := svc.services.SegmenterService.GetSegmenterTypes(ctx, int64(settings.ProjectID))
 	if err != nil {
 		return nil, err
 	}
@@ -230,14 +253,15 @@ func (svc *segmentService) UpdateSegment(
 	if err != nil {
 		return nil, err
 	}
-	segmentDBRecord, err := svc.save(&models.Segment{
+	segmentDBRecord, err := svc.save(ctx, &models.Segment{
 		// Copy the ID and the fixed fields
 		ID:        curSegment.ID,
 		ProjectID: curSegment.ProjectID,
 		Name:      curSegment.Name,
 		// Add the new data
-		Segment:   segmenterStorageSchema,
-		UpdatedBy: *segmentData.UpdatedBy,
+		Segment:    segmenterStorageSchema,
+		S2Covering: s2Covering,
+		UpdatedBy:  *segmentData.UpdatedBy,
 	})
 	if err != nil {
 		return nil, err
@@ -246,9 +270,9 @@ func (svc *segmentService) UpdateSegment(
 	return segmentDBRecord, nil
 }
 
-func (svc *segmentService) DeleteSegment(projectId int64, segmentId int64) error {
+func (svc *segmentService) DeleteSegment(ctx context.Context, projectId int64, segmentId int64) error {
 	// TODO: Explicitly delete all segment history first
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("project_id = ?", projectId).
 		Where("id = ?", segmentId).
 		Unscoped().
@@ -259,9 +283,9 @@ func (svc *segmentService) DeleteSegment(projectId int64, segmentId int64) error
 	return nil
 }
 
-func (svc *segmentService) GetDBRecord(projectId models.ID, segmentId models.ID) (*models.Segment, error) {
+func (svc *segmentService) GetDBRecord(ctx context.Context, projectId models.ID, segmentId models.ID) (*models.Segment, error) {
 	var segment models.Segment
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("project_id = ?", projectId).
 		Where("id = ?", segmentId).
 		First(&segment)
@@ -271,17 +295,17 @@ func (svc *segmentService) GetDBRecord(projectId models.ID, segmentId models.ID)
 	return &segment, nil
 }
 
-func (svc *segmentService) query() *gorm.DB {
-	return svc.db
+func (svc *segmentService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
 }
 
-func (svc *segmentService) save(segment *models.Segment) (*models.Segment, error) {
-	if err := svc.query().Clauses(clause.OnConflict{
+func (svc *segmentService) save(ctx context.Context, segment *models.Segment) (*models.Segment, error) {
+	if err := svc.query(ctx).Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(segment).Error; err != nil {
 		return nil, err
 	}
-	return svc.GetDBRecord(segment.ProjectID, segment.ID)
+	return svc.GetDBRecord(ctx, segment.ProjectID, segment.ID)
 }
 
 func validateListSegmentFieldNames(fields []models.SegmentField) error {
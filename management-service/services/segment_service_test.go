@@ -3,6 +3,7 @@
 package services_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -45,6 +46,9 @@ func (s *SegmentServiceTestSuite) SetupSuite() {
 
 	// Init services
 	s.SegmenterService = &mocks.SegmenterService{}
+	s.SegmenterService.
+		On("CoverSegmentGeometries", mock.Anything).
+		Return(func(segment models.ExperimentSegmentRaw) models.ExperimentSegmentRaw { return segment }, nil, nil)
 	s.ValidationService = &mocks.ValidationService{}
 	// Init segment history svc, mock calls will be set up during the test
 	s.SegmentHistoryService = &mocks.SegmentHistoryService{}
@@ -75,7 +79,7 @@ func TestSegmentService(t *testing.T) {
 }
 
 func (s *SegmentServiceTestSuite) TestSegmentServiceGetIntegration() {
-	segmentResponse, err := s.SegmentService.GetSegment(1, 1)
+	segmentResponse, err := s.SegmentService.GetSegment(context.Background(), 1, 1)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.Segments[0], segmentResponse)
 }
@@ -151,7 +155,7 @@ func testListSegments(s *SegmentServiceTestSuite) {
 	// Run test
 	for _, data := range tests {
 		t.Run(data.name, func(t *testing.T) {
-			segments, paging, err := svc.ListSegments(data.projectId, data.params)
+			segments, paging, err := svc.ListSegments(context.Background(), data.projectId, data.params)
 			if data.expectedError == "" {
 				assert.NoError(t, err)
 				tu.AssertEqualValues(t, data.expectedSegments, segments)
@@ -191,9 +195,9 @@ func testCreateUpdateDeleteSegment(s *SegmentServiceTestSuite) {
 		UpdatedBy: &updatedBy,
 	}
 	s.ValidationService.On("Validate", createSegmentBody).Return(nil)
-	s.SegmenterService.On("ValidateExperimentSegment", int64(1), mock.Anything, mock.Anything).Return(nil)
+	s.SegmenterService.On("ValidateExperimentSegment", mock.Anything, int64(1), mock.Anything, mock.Anything).Return(nil)
 	s.SegmenterService.
-		On("GetSegmenterTypes", int64(1)).
+		On("GetSegmenterTypes", mock.Anything, int64(1)).
 		Return(
 			map[string]schema.SegmenterType{
 				"string_segmenter":  schema.SegmenterTypeString,
@@ -201,7 +205,7 @@ func testCreateUpdateDeleteSegment(s *SegmentServiceTestSuite) {
 			},
 			nil,
 		)
-	segmentResponse, err := svc.CreateSegment(s.Settings, createSegmentBody)
+	segmentResponse, err := svc.CreateSegment(context.Background(), s.Settings, createSegmentBody)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(t, models.Segment{
 		ID: models.ID(segmentId),
@@ -216,7 +220,7 @@ func testCreateUpdateDeleteSegment(s *SegmentServiceTestSuite) {
 	}, *segmentResponse)
 
 	// Update Segment
-	s.SegmentHistoryService.On("CreateSegmentHistory", segmentResponse).Return(nil, nil)
+	s.SegmentHistoryService.On("CreateSegmentHistory", mock.Anything, segmentResponse).Return(nil, nil)
 	newExpSegmentRaw := models.ExperimentSegmentRaw{"string_segmenter": rawStringSegmenter}
 	newExpSegment := models.ExperimentSegment{"string_segmenter": stringSegmenter}
 	updateSegmentBody := services.UpdateSegmentRequestBody{
@@ -224,15 +228,15 @@ func testCreateUpdateDeleteSegment(s *SegmentServiceTestSuite) {
 		UpdatedBy: &updatedBy,
 	}
 	s.ValidationService.On("Validate", updateSegmentBody).Return(nil)
-	s.SegmenterService.On("ValidateExperimentSegment", s.Settings.Config.Segmenters, newExpSegment).Return(nil)
-	segmentResponse, err = svc.UpdateSegment(s.Settings, segmentId, updateSegmentBody)
+	s.SegmenterService.On("ValidateExperimentSegment", mock.Anything, s.Settings.Config.Segmenters, newExpSegment).Return(nil)
+	segmentResponse, err = svc.UpdateSegment(context.Background(), s.Settings, segmentId, updateSegmentBody)
 	s.Suite.Require().NoError(err)
 	s.Suite.Assert().Equal(models.ID(3), segmentResponse.ID)
 	s.Suite.Assert().Equal(newExpSegment, segmentResponse.Segment)
 
 	// Delete Segment
 	deletedSegmentId := int64(3)
-	err = svc.DeleteSegment(projectId, deletedSegmentId)
+	err = svc.DeleteSegment(context.Background(), projectId, deletedSegmentId)
 	s.Suite.Require().NoError(err)
 }
 
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	"github.com/caraml-dev/xp/management-service/segmenters"
+)
+
+// quote formats the values of a string segmenter as GetFormattedSegmenters does
+func quote(values ...string) []interface{} {
+	formattedValues := []interface{}{}
+	for _, val := range values {
+		formattedValues = append(formattedValues, fmt.Sprintf("%q", val))
+	}
+	return formattedValues
+}
+
+func TestStringPatternsOverlap(t *testing.T) {
+	tests := map[string]struct {
+		patterns      []interface{}
+		otherPatterns []interface{}
+		mode          _segmenters.StringMatchMode
+		expected      bool
+	}{
+		"nested prefixes": {
+			patterns:      quote("ID-"),
+			otherPatterns: quote("ID-JK"),
+			mode:          _segmenters.StringMatchMode_PREFIX,
+			expected:      true,
+		},
+		"disjoint prefixes": {
+			patterns:      quote("ID-", "SG-"),
+			otherPatterns: quote("TH-"),
+			mode:          _segmenters.StringMatchMode_PREFIX,
+			expected:      false,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, data.expected, stringPatternsOverlap(data.patterns, data.otherPatterns, data.mode))
+		})
+	}
+}
+
+func TestDatesOverlap(t *testing.T) {
+	segmenter, err := segmenters.NewDatesSegmenter(nil)
+	require.NoError(t, err)
+	expander := segmenter.(segmenters.DatesExpander)
+
+	tests := map[string]struct {
+		values      []interface{}
+		otherValues []interface{}
+		expected    bool
+	}{
+		"same dates": {
+			values:      quote("2024-12-25"),
+			otherValues: quote("2024-12-25"),
+			expected:    true,
+		},
+		"date within range": {
+			values:      quote("2024-12-20/2024-12-31"),
+			otherValues: quote("2024-12-25"),
+			expected:    true,
+		},
+		"disjoint dates and ranges": {
+			values:      quote("2024-12-20/2024-12-31"),
+			otherValues: quote("2024-12-19", "2025-01-01/2025-01-07"),
+			expected:    false,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, data.expected, datesOverlap(expander, data.values, data.otherValues))
+		})
+	}
+}
+
+func TestH3CellsIntersect(t *testing.T) {
+	segmenter, err := segmenters.NewH3IDSegmenter(nil)
+	require.NoError(t, err)
+	indexer := segmenter.(segmenters.H3Indexer)
+
+	tests := map[string]struct {
+		cellIds      []interface{}
+		otherCellIds []interface{}
+		expected     bool
+	}{
+		"ancestor cell": {
+			cellIds:      quote("85283083fffffff"),
+			otherCellIds: quote("8928308280fffff"),
+			expected:     true,
+		},
+		"disjoint cells": {
+			cellIds:      quote("8928308280fffff"),
+			otherCellIds: quote("878c106a4ffffff", "858c106bfffffff"),
+			expected:     false,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, data.expected, h3CellsIntersect(indexer, data.cellIds, data.otherCellIds))
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/golang-collections/collections/set"
+	"github.com/golang/geo/s2"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"gorm.io/gorm"
@@ -13,6 +16,7 @@ import (
 
 	"github.com/caraml-dev/xp/common/api/schema"
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
 	"github.com/caraml-dev/xp/management-service/errors"
 	"github.com/caraml-dev/xp/management-service/models"
 	"github.com/caraml-dev/xp/management-service/segmenters"
@@ -45,13 +49,16 @@ var (
 )
 
 type CreateCustomSegmenterRequestBody struct {
-	Name        string              `json:"name" validate:"required,notBlank"`
-	Type        string              `json:"type" validate:"notBlank"`
-	Options     *models.Options     `json:"options"`
-	MultiValued bool                `json:"multi_valued"`
-	Constraints *models.Constraints `json:"constraints"`
-	Required    bool                `json:"required"`
-	Description *string             `json:"description,omitempty"`
+	Name          string                `json:"name" validate:"required,notBlank"`
+	Type          string                `json:"type" validate:"notBlank"`
+	MatchMode     string                `json:"match_mode"`
+	Expression    *string               `json:"expression,omitempty"`
+	RequestFields *models.RequestFields `json:"request_fields,omitempty"`
+	Options       *models.Options       `json:"options"`
+	MultiValued   bool                  `json:"multi_valued"`
+	Constraints   *models.Constraints   `json:"constraints"`
+	Required      bool                  `json:"required"`
+	Description   *string               `json:"description,omitempty"`
 }
 
 type UpdateCustomSegmenterRequestBody struct {
@@ -69,34 +76,45 @@ type ListSegmentersParams struct {
 }
 
 type SegmenterService interface {
-	GetFormattedSegmenters(projectId int64, expSegment models.ExperimentSegmentRaw) (map[string]*[]interface{}, error)
-	GetSegmenterConfigurations(projectId int64, segmenterNames []string) ([]*_segmenters.SegmenterConfiguration, error)
-	ValidateExperimentSegment(projectId int64, userSegmenters []string, expSegment models.ExperimentSegmentRaw) error
+	GetFormattedSegmenters(ctx context.Context, projectId int64, expSegment models.ExperimentSegmentRaw) (map[string]*[]interface{}, error)
+	// CoverSegmentGeometries replaces the GeoJSON geometry given as the s2_ids of the segment, if any, with the ids
+	// of the S2 cells covering it. The covering is returned, to be stored with the segment.
+	CoverSegmentGeometries(expSegment models.ExperimentSegmentRaw) (models.ExperimentSegmentRaw, *models.S2Covering, error)
+	GetSegmenterConfigurations(ctx context.Context, projectId int64, segmenterNames []string) ([]*_segmenters.SegmenterConfiguration, error)
+	ValidateExperimentSegment(ctx context.Context, projectId int64, userSegmenters []string, expSegment models.ExperimentSegmentRaw) error
 	ValidateSegmentOrthogonality(
+		ctx context.Context,
 		projectId int64,
 		userSegmenters []string,
 		expSegment models.ExperimentSegmentRaw,
 		allExps []models.Experiment,
 	) error
-	ValidatePrereqSegmenters(projectId int64, segmenters []string) error
-	ValidateRequiredSegmenters(projectId int64, segmenters []string) error
-	ValidateExperimentVariables(projectId int64, projectSegmenters models.ProjectSegmenters) error
-	GetSegmenter(projectId int64, name string) (*schema.Segmenter, error)
-	ListSegmenters(projectId int64, params ListSegmentersParams) ([]*schema.Segmenter, error)
+	ValidatePrereqSegmenters(ctx context.Context, projectId int64, segmenters []string) error
+	ValidateRequiredSegmenters(ctx context.Context, projectId int64, segmenters []string) error
+	ValidateExperimentVariables(
+		ctx context.Context,
+		projectId int64,
+		projectSegmenters models.ProjectSegmenters,
+		randomizationKey string,
+	) error
+	GetSegmenter(ctx context.Context, projectId int64, name string) (*schema.Segmenter, error)
+	ListSegmenters(ctx context.Context, projectId int64, params ListSegmentersParams) ([]*schema.Segmenter, error)
 	ListGlobalSegmenters() ([]*schema.Segmenter, error)
-	GetCustomSegmenter(projectId int64, name string) (*models.CustomSegmenter, error)
+	GetCustomSegmenter(ctx context.Context, projectId int64, name string) (*models.CustomSegmenter, error)
 	CreateCustomSegmenter(
+		ctx context.Context,
 		projectId int64,
 		customSegmenterData CreateCustomSegmenterRequestBody,
 	) (*models.CustomSegmenter, error)
 	UpdateCustomSegmenter(
+		ctx context.Context,
 		projectId int64,
 		name string,
 		customSegmenterData UpdateCustomSegmenterRequestBody,
 	) (*models.CustomSegmenter, error)
-	DeleteCustomSegmenter(projectId int64, name string) error
-	GetDBRecord(projectId models.ID, name string) (*models.CustomSegmenter, error)
-	GetSegmenterTypes(projectId int64) (map[string]schema.SegmenterType, error)
+	DeleteCustomSegmenter(ctx context.Context, projectId int64, name string) error
+	GetDBRecord(ctx context.Context, projectId models.ID, name string) (*models.CustomSegmenter, error)
+	GetSegmenterTypes(ctx context.Context, projectId int64) (map[string]schema.SegmenterType, error)
 }
 
 type segmenterService struct {
@@ -140,9 +158,9 @@ func NewSegmenterService(
 	}, nil
 }
 
-func (svc *segmenterService) GetSegmenter(projectId int64, name string) (*schema.Segmenter, error) {
+func (svc *segmenterService) GetSegmenter(ctx context.Context, projectId int64, name string) (*schema.Segmenter, error) {
 	// Get Active Segmenters
-	activeSegmenterNames, err := svc.getActiveSegmenterNames(projectId)
+	activeSegmenterNames, err := svc.getActiveSegmenterNames(ctx, projectId)
 	if err != nil {
 		return nil, err
 	}
@@ -160,7 +178,7 @@ func (svc *segmenterService) GetSegmenter(projectId int64, name string) (*schema
 		return formattedGlobalSegmenter, nil
 	}
 	// Check custom segmenters if a segmenter with a matching name exists
-	customSegmenter, err := svc.GetCustomSegmenter(projectId, name)
+	customSegmenter, err := svc.GetCustomSegmenter(ctx, projectId, name)
 	if err != nil {
 		return nil, err
 	}
@@ -179,14 +197,14 @@ func (svc *segmenterService) GetSegmenter(projectId int64, name string) (*schema
 }
 
 // GetBaseSegmenter retrieves the global/custom segmenter with the given name, formatted as a BaseSegmenter
-func (svc *segmenterService) GetBaseSegmenter(projectId int64, name string) (*segmenters.Segmenter, error) {
+func (svc *segmenterService) GetBaseSegmenter(ctx context.Context, projectId int64, name string) (*segmenters.Segmenter, error) {
 	// Check global segmenters if a segmenter with a matching name exists
 	if globalSegmenter, err := svc.getGlobalSegmenter(name); globalSegmenter != nil && err == nil {
 		return globalSegmenter, nil
 	}
 	// Check custom segmenters if a segmenter with a matching name exists
 	var customSegmenter segmenters.Segmenter
-	customSegmenter, err := svc.GetCustomSegmenter(projectId, name)
+	customSegmenter, err := svc.GetCustomSegmenter(ctx, projectId, name)
 	if err != nil {
 		return nil, err
 	}
@@ -195,13 +213,14 @@ func (svc *segmenterService) GetBaseSegmenter(projectId int64, name string) (*se
 }
 
 func (svc *segmenterService) ListSegmenters(
+	ctx context.Context,
 	projectId int64,
 	params ListSegmentersParams,
 ) ([]*schema.Segmenter, error) {
 	allSegmenters := make([]*schema.Segmenter, 0)
 
 	// Get Active Segmenters
-	activeSegmenterNames, err := svc.getActiveSegmenterNames(projectId)
+	activeSegmenterNames, err := svc.getActiveSegmenterNames(ctx, projectId)
 	if err != nil {
 		return nil, err
 	}
@@ -211,7 +230,7 @@ func (svc *segmenterService) ListSegmenters(
 	}
 
 	// Get the list of segmenter types for all segmenters
-	segmenterTypes, err := svc.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.GetSegmenterTypes(ctx, projectId)
 	if err != nil {
 		return nil, err
 	}
@@ -233,7 +252,7 @@ func (svc *segmenterService) ListSegmenters(
 
 	// Get all custom segmenters
 	if params.Scope == nil || *params.Scope == SegmenterScopeProject {
-		customSegmenters, err := svc.getCustomSegmenters(projectId)
+		customSegmenters, err := svc.getCustomSegmenters(ctx, projectId)
 		if err != nil {
 			return nil, err
 		}
@@ -312,8 +331,8 @@ func (svc *segmenterService) getGlobalSegmenters() []segmenters.Segmenter {
 	return globalSegmenters
 }
 
-func (svc *segmenterService) GetCustomSegmenter(projectId int64, name string) (*models.CustomSegmenter, error) {
-	dbCustomSegmenter, err := svc.GetDBRecord(models.ID(projectId), name)
+func (svc *segmenterService) GetCustomSegmenter(ctx context.Context, projectId int64, name string) (*models.CustomSegmenter, error) {
+	dbCustomSegmenter, err := svc.GetDBRecord(ctx, models.ID(projectId), name)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("unknown segmenter: %s", name)
@@ -321,7 +340,7 @@ func (svc *segmenterService) GetCustomSegmenter(projectId int64, name string) (*
 		return nil, err
 	}
 	// Convert custom segmenter from DB schema
-	segmenterTypes, err := svc.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.GetSegmenterTypes(ctx, projectId)
 	if err != nil {
 		return nil, err
 	}
@@ -331,10 +350,10 @@ func (svc *segmenterService) GetCustomSegmenter(projectId int64, name string) (*
 	return dbCustomSegmenter, nil
 }
 
-func (svc *segmenterService) getCustomSegmenters(projectId int64) ([]models.CustomSegmenter, error) {
+func (svc *segmenterService) getCustomSegmenters(ctx context.Context, projectId int64) ([]models.CustomSegmenter, error) {
 	var customSegmenters []models.CustomSegmenter
 
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("project_id = ?", projectId).
 		Order("updated_at desc").
 		Find(&customSegmenters)
@@ -346,16 +365,17 @@ func (svc *segmenterService) getCustomSegmenters(projectId int64) ([]models.Cust
 }
 
 func (svc *segmenterService) CreateCustomSegmenter(
+	ctx context.Context,
 	projectId int64,
 	customSegmenterData CreateCustomSegmenterRequestBody,
 ) (*models.CustomSegmenter, error) {
 	// Check all segmenters to ensure a segmenter with a matching name does not exist
-	if segmenter, err := svc.GetSegmenter(projectId, customSegmenterData.Name); segmenter != nil && err == nil {
+	if segmenter, err := svc.GetSegmenter(ctx, projectId, customSegmenterData.Name); segmenter != nil && err == nil {
 		return nil, errors.Newf(errors.BadInput, "a segmenter with the name %s already exists", customSegmenterData.Name)
 	}
 
 	// Get the list of segmenter types for all segmenters
-	segmenterTypes, err := svc.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.GetSegmenterTypes(ctx, projectId)
 	if err != nil {
 		return nil, err
 	}
@@ -365,6 +385,9 @@ func (svc *segmenterService) CreateCustomSegmenter(
 		models.ID(projectId),
 		customSegmenterData.Name,
 		models.SegmenterValueType(customSegmenterData.Type),
+		models.SegmenterMatchMode(customSegmenterData.MatchMode),
+		customSegmenterData.Expression,
+		customSegmenterData.RequestFields,
 		customSegmenterData.Description,
 		customSegmenterData.Required,
 		customSegmenterData.MultiValued,
@@ -382,44 +405,29 @@ func (svc *segmenterService) CreateCustomSegmenter(
 		return nil, err
 	}
 
-	// Save to DB
-	customSegmenterDBRecord, err := svc.save(
-		newCustomSegmenter,
-	)
+	// Save to DB, together with the message to be published
+	customSegmenterDBRecord, err := svc.saveAndPublish(ctx, newCustomSegmenter, segmenterTypes, "create")
 	if err != nil {
 		return nil, err
 	}
 
-	// Convert custom segmenter from DB schema
-	if err := customSegmenterDBRecord.FromStorageSchema(segmenterTypes); err != nil {
-		return nil, err
-	}
-
-	// Get SegmenterConfiguration expected by the Message Queue
-	protoSegmenterConfig, err := customSegmenterDBRecord.GetConfiguration()
-	if err != nil {
-		return nil, err
-	}
-	if err = svc.services.MessageQueueService.PublishProjectSegmenterMessage("create", protoSegmenterConfig, projectId); err != nil {
-		return nil, err
-	}
-
 	return customSegmenterDBRecord, nil
 }
 
 func (svc *segmenterService) UpdateCustomSegmenter(
+	ctx context.Context,
 	projectId int64,
 	name string,
 	customSegmenterData UpdateCustomSegmenterRequestBody,
 ) (*models.CustomSegmenter, error) {
 	// Check custom segmenters to ensure a segmenter with a matching name exists
-	curCustomSegmenter, err := svc.GetCustomSegmenter(projectId, name)
+	curCustomSegmenter, err := svc.GetCustomSegmenter(ctx, projectId, name)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get the list of segmenter types for all segmenters
-	segmenterTypes, err := svc.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.GetSegmenterTypes(ctx, projectId)
 	if err != nil {
 		return nil, err
 	}
@@ -429,6 +437,9 @@ func (svc *segmenterService) UpdateCustomSegmenter(
 		curCustomSegmenter.ProjectID,
 		curCustomSegmenter.Name,
 		curCustomSegmenter.Type,
+		curCustomSegmenter.MatchMode,
+		curCustomSegmenter.Expression,
+		curCustomSegmenter.RequestFields,
 		customSegmenterData.Description,
 		customSegmenterData.Required,
 		customSegmenterData.MultiValued,
@@ -446,38 +457,22 @@ func (svc *segmenterService) UpdateCustomSegmenter(
 		return nil, err
 	}
 
-	// Save to DB
-	customSegmenterDBRecord, err := svc.save(
-		updatedCustomSegmenter,
-	)
+	// Save to DB, together with the message to be published
+	customSegmenterDBRecord, err := svc.saveAndPublish(ctx, updatedCustomSegmenter, segmenterTypes, "update")
 	if err != nil {
 		return nil, err
 	}
-
-	// Convert custom segmenter from DB schema
-	if err := customSegmenterDBRecord.FromStorageSchema(segmenterTypes); err != nil {
-		return nil, err
-	}
-
-	// Get SegmenterConfiguration expected by the Message Queue
-	protoSegmenterConfig, err := customSegmenterDBRecord.GetConfiguration()
-	if err != nil {
-		return nil, err
-	}
-	if err = svc.services.MessageQueueService.PublishProjectSegmenterMessage("update", protoSegmenterConfig, projectId); err != nil {
-		return nil, err
-	}
 	return customSegmenterDBRecord, nil
 }
 
-func (svc *segmenterService) DeleteCustomSegmenter(projectId int64, name string) error {
+func (svc *segmenterService) DeleteCustomSegmenter(ctx context.Context, projectId int64, name string) error {
 	// Check custom segmenters if a segmenter with a matching name exists
-	customSegmenter, err := svc.GetCustomSegmenter(projectId, name)
+	customSegmenter, err := svc.GetCustomSegmenter(ctx, projectId, name)
 	if err != nil {
 		return err
 	}
 	// Check if selected custom segmenter is currently in use in the project settings
-	activeProjectSegmenterNames, err := svc.getActiveSegmenterNames(projectId)
+	activeProjectSegmenterNames, err := svc.getActiveSegmenterNames(ctx, projectId)
 	if err != nil {
 		return err
 	}
@@ -491,28 +486,32 @@ func (svc *segmenterService) DeleteCustomSegmenter(projectId int64, name string)
 		}
 	}
 
-	query := svc.query().
-		Where("project_id = ?", projectId).
-		Where("name = ?", name).
-		Unscoped().
-		Delete(models.CustomSegmenter{})
-	if err := query.Error; err != nil {
-		return err
-	}
 	// Get SegmenterConfiguration expected by the Message Queue
 	protoSegmenterConfig, err := customSegmenter.GetConfiguration()
 	if err != nil {
 		return err
 	}
-	if err = svc.services.MessageQueueService.PublishProjectSegmenterMessage("delete", protoSegmenterConfig, projectId); err != nil {
-		return err
-	}
-	return nil
+
+	return svc.query(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.
+			Where("project_id = ?", projectId).
+			Where("name = ?", name).
+			Unscoped().
+			Delete(models.CustomSegmenter{})
+		if err := query.Error; err != nil {
+			return err
+		}
+		return svc.services.OutboxService.AddProjectSegmenterMessage(tx, "delete", protoSegmenterConfig, projectId)
+	})
+}
+
+func (svc *segmenterService) GetDBRecord(ctx context.Context, projectId models.ID, name string) (*models.CustomSegmenter, error) {
+	return svc.getDBRecord(svc.query(ctx), projectId, name)
 }
 
-func (svc *segmenterService) GetDBRecord(projectId models.ID, name string) (*models.CustomSegmenter, error) {
+func (svc *segmenterService) getDBRecord(db *gorm.DB, projectId models.ID, name string) (*models.CustomSegmenter, error) {
 	var customSegmenter models.CustomSegmenter
-	query := svc.query().
+	query := db.
 		Where("project_id = ?", projectId).
 		Where("name = ?", name).
 		First(&customSegmenter)
@@ -523,7 +522,7 @@ func (svc *segmenterService) GetDBRecord(projectId models.ID, name string) (*mod
 	return &customSegmenter, nil
 }
 
-func (svc *segmenterService) GetSegmenterTypes(projectId int64) (map[string]schema.SegmenterType, error) {
+func (svc *segmenterService) GetSegmenterTypes(ctx context.Context, projectId int64) (map[string]schema.SegmenterType, error) {
 	segmenterTypes := map[string]schema.SegmenterType{}
 
 	for key, val := range svc.globalSegmenters {
@@ -539,7 +538,7 @@ func (svc *segmenterService) GetSegmenterTypes(projectId int64) (map[string]sche
 		}
 	}
 
-	customSegmenters, err := svc.getCustomSegmenters(projectId)
+	customSegmenters, err := svc.getCustomSegmenters(ctx, projectId)
 	if err != nil {
 		return nil, err
 	}
@@ -561,6 +560,7 @@ func (svc *segmenterService) GetSegmenterTypes(projectId int64) (map[string]sche
 }
 
 func (svc *segmenterService) GetSegmenterConfigurations(
+	ctx context.Context,
 	projectId int64,
 	segmenterNames []string,
 ) ([]*_segmenters.SegmenterConfiguration, error) {
@@ -568,7 +568,7 @@ func (svc *segmenterService) GetSegmenterConfigurations(
 	segmenterConfigList := []*_segmenters.SegmenterConfiguration{}
 
 	for _, segmenterName := range segmenterNames {
-		segmenter, err := svc.GetBaseSegmenter(projectId, segmenterName)
+		segmenter, err := svc.GetBaseSegmenter(ctx, projectId, segmenterName)
 		if err != nil {
 			return segmenterConfigList, err
 		}
@@ -584,10 +584,11 @@ func (svc *segmenterService) GetSegmenterConfigurations(
 }
 
 func (svc *segmenterService) GetFormattedSegmenters(
+	ctx context.Context,
 	projectId int64,
 	expSegment models.ExperimentSegmentRaw,
 ) (map[string]*[]interface{}, error) {
-	segmenterTypes, err := svc.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.GetSegmenterTypes(ctx, projectId)
 	if err != nil {
 		return nil, err
 	}
@@ -601,7 +602,7 @@ func (svc *segmenterService) GetFormattedSegmenters(
 	formattedMap := map[string]*[]interface{}{}
 
 	for segmenterName, values := range inputSegmenters {
-		segmenter, err := svc.GetBaseSegmenter(projectId, segmenterName)
+		segmenter, err := svc.GetBaseSegmenter(ctx, projectId, segmenterName)
 		if err != nil {
 			return formattedMap, err
 		}
@@ -617,10 +618,16 @@ func (svc *segmenterService) GetFormattedSegmenters(
 					formattedValues = append(formattedValues, fmt.Sprintf("%q", val.GetString_()))
 				case _segmenters.SegmenterValueType_BOOL:
 					formattedValues = append(formattedValues, val.GetBool())
-				case _segmenters.SegmenterValueType_INTEGER:
-					formattedValues = append(formattedValues, val.GetInteger())
-				case _segmenters.SegmenterValueType_REAL:
-					formattedValues = append(formattedValues, val.GetReal())
+				case _segmenters.SegmenterValueType_INTEGER, _segmenters.SegmenterValueType_REAL:
+					switch val.GetValue().(type) {
+					case *_segmenters.SegmenterValue_Integer:
+						formattedValues = append(formattedValues, val.GetInteger())
+					case *_segmenters.SegmenterValue_Real:
+						formattedValues = append(formattedValues, val.GetReal())
+					default:
+						// Ranges are formatted in the interval notation
+						formattedValues = append(formattedValues, _utils.FormatSegmenterRange(val))
+					}
 				}
 			}
 			formattedMap[segmenterName] = &formattedValues
@@ -629,12 +636,58 @@ func (svc *segmenterService) GetFormattedSegmenters(
 	return formattedMap, nil
 }
 
+func (svc *segmenterService) CoverSegmentGeometries(
+	expSegment models.ExperimentSegmentRaw,
+) (models.ExperimentSegmentRaw, *models.S2Covering, error) {
+	var coveredSegment models.ExperimentSegmentRaw
+	var covering *models.S2Covering
+	for name, value := range expSegment {
+		geometry, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		coverer, ok := svc.globalSegmenters[name].(segmenters.S2Coverer)
+		if !ok {
+			return nil, nil, fmt.Errorf("segmenter %s does not accept GeoJSON geometries", name)
+		}
+		s2Covering, err := coverer.Cover(geometry)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to cover the %s geometry: %s", name, err)
+		}
+
+		// Integer segmenter values are decoded from JSON as float64, which holds the cell ids exactly, as the
+		// covering is limited to segmenters.MaxS2CoveringLevel
+		cellIds := []interface{}{}
+		for _, cellId := range s2Covering.CellIDs {
+			cellIds = append(cellIds, float64(int64(cellId)))
+		}
+		coveredSegment = models.ExperimentSegmentRaw{}
+		for key, val := range expSegment {
+			coveredSegment[key] = val
+		}
+		coveredSegment[name] = cellIds
+		covering = &models.S2Covering{
+			Geometry:      geometry,
+			MinLevel:      s2Covering.MinLevel,
+			MaxLevel:      s2Covering.MaxLevel,
+			CellCount:     len(cellIds),
+			CoverageError: s2Covering.CoverageError,
+		}
+	}
+
+	if covering == nil {
+		return expSegment, nil, nil
+	}
+	return coveredSegment, covering, nil
+}
+
 func (svc *segmenterService) ValidateExperimentSegment(
+	ctx context.Context,
 	projectId int64,
 	userSegmenters []string,
 	expSegment models.ExperimentSegmentRaw,
 ) error {
-	segmenterTypes, err := svc.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.GetSegmenterTypes(ctx, projectId)
 	if err != nil {
 		return err
 	}
@@ -645,7 +698,7 @@ func (svc *segmenterService) ValidateExperimentSegment(
 	}
 	// For each user segmenter, check the detailed segmenter config
 	for _, s := range userSegmenters {
-		segmenter, err := svc.GetBaseSegmenter(projectId, s)
+		segmenter, err := svc.GetBaseSegmenter(ctx, projectId, s)
 		if err != nil {
 			return err
 		}
@@ -660,19 +713,31 @@ func (svc *segmenterService) ValidateExperimentSegment(
 // ValidateSegmentOrthogonality checks that the given experiment's segment does not overlap
 // with other given experiments. A segment is considered to overlap with another if each
 // segmenter has one or more common values. The reverse makes them orthogonal - at least
-// one segmenter has no common values.
+// one segmenter has no common values. The s2_ids values are common if their cells intersect,
+// which allows comparing the cells at different levels, and likewise the h3_ids values are common
+// if one cell is an ancestor of, or the same as, the other. The app_version values are common
+// if their version constraints are satisfied by any of the same versions. The dates values are
+// common if their dates, date ranges and calendars have any date in common. The values of string
+// segmenters in the prefix match mode are common if one is a prefix of the other, and the values
+// in the regex match mode are always considered common, since any string may match both.
 func (svc *segmenterService) ValidateSegmentOrthogonality(
+	ctx context.Context,
 	projectId int64,
 	userSegmenters []string,
 	expSegment models.ExperimentSegmentRaw,
 	allExps []models.Experiment,
 ) error {
-	expSegmentFormatted, err := svc.GetFormattedSegmenters(projectId, expSegment)
+	expSegmentFormatted, err := svc.GetFormattedSegmenters(ctx, projectId, expSegment)
 	if err != nil {
 		return err
 	}
 
-	segmenterTypes, err := svc.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.GetSegmenterTypes(ctx, projectId)
+	if err != nil {
+		return err
+	}
+
+	matchModes, err := svc.getStringMatchModes(ctx, projectId, userSegmenters, segmenterTypes)
 	if err != nil {
 		return err
 	}
@@ -682,7 +747,7 @@ func (svc *segmenterService) ValidateSegmentOrthogonality(
 		if err != nil {
 			return err
 		}
-		otherSegmentFormatted, err := svc.GetFormattedSegmenters(projectId, rawSegments)
+		otherSegmentFormatted, err := svc.GetFormattedSegmenters(ctx, projectId, rawSegments)
 		if err != nil {
 			return err
 		}
@@ -704,6 +769,56 @@ func (svc *segmenterService) ValidateSegmentOrthogonality(
 			// If only one of the values is empty, we can skip further checks.
 			// If both empty, nothing to do.
 			if !isCurrValEmpty && !isOtherValEmpty {
+				if _, ok := svc.globalSegmenters[name].(segmenters.S2Coverer); ok {
+					// S2 cells overlap when one contains the other, so compare the areas of the cells
+					if !s2CellsIntersect(*currValues, *otherValues) {
+						segmentsOverlap = false
+						break
+					}
+					continue
+				}
+				if indexer, ok := svc.globalSegmenters[name].(segmenters.H3Indexer); ok {
+					// H3 cells overlap when one is an ancestor of the other, so compare the cells at the coarser resolution
+					if !h3CellsIntersect(indexer, *currValues, *otherValues) {
+						segmentsOverlap = false
+						break
+					}
+					continue
+				}
+				if expander, ok := svc.globalSegmenters[name].(segmenters.DatesExpander); ok {
+					// Dates overlap when they have any date in common, so compare the dates of the ranges and calendars
+					if !datesOverlap(expander, *currValues, *otherValues) {
+						segmentsOverlap = false
+						break
+					}
+					continue
+				}
+				if name == _utils.AppVersionSegmenter {
+					// Version constraints overlap when they have any version in common, so compare their ranges
+					if !versionConstraintsOverlap(expSegment[name], rawSegments[name]) {
+						segmentsOverlap = false
+						break
+					}
+					continue
+				}
+				if matchMode, ok := matchModes[name]; ok {
+					// Patterns overlap when any string may match both, so compare them pairwise
+					if !stringPatternsOverlap(*currValues, *otherValues, matchMode) {
+						segmentsOverlap = false
+						break
+					}
+					continue
+				}
+				segmenterType := segmenterTypes[name]
+				isNumeric := segmenterType == schema.SegmenterTypeInteger || segmenterType == schema.SegmenterTypeReal
+				if isNumeric && (containsRanges(*currValues) || containsRanges(*otherValues)) {
+					// Ranges overlap when they have any value in common, so compare their intervals
+					if !numericValuesOverlap(*currValues, *otherValues, segmenterType) {
+						segmentsOverlap = false
+						break
+					}
+					continue
+				}
 				currentSet := set.New(*currValues...)
 				otherSet := set.New(*otherValues...)
 				if currentSet.Intersection(otherSet).Len() == 0 {
@@ -726,11 +841,189 @@ func (svc *segmenterService) ValidateSegmentOrthogonality(
 	return nil
 }
 
-func (svc *segmenterService) ValidateRequiredSegmenters(projectId int64, segmenterNames []string) error {
+// getStringMatchModes returns the match modes of the given string segmenters that match their values as
+// patterns rather than exactly
+func (svc *segmenterService) getStringMatchModes(
+	ctx context.Context,
+	projectId int64,
+	segmenterNames []string,
+	segmenterTypes map[string]schema.SegmenterType,
+) (map[string]_segmenters.StringMatchMode, error) {
+	matchModes := map[string]_segmenters.StringMatchMode{}
+	for _, name := range segmenterNames {
+		if segmenterTypes[name] != schema.SegmenterTypeString {
+			continue
+		}
+		segmenter, err := svc.GetBaseSegmenter(ctx, projectId, name)
+		if err != nil {
+			return nil, err
+		}
+		config, err := (*segmenter).GetConfiguration()
+		if err != nil {
+			return nil, err
+		}
+		if config.MatchMode != _segmenters.StringMatchMode_EXACT {
+			matchModes[name] = config.MatchMode
+		}
+	}
+	return matchModes, nil
+}
+
+// stringPatternsOverlap checks if any string may match any of the patterns and any of the other patterns
+func stringPatternsOverlap(patterns []interface{}, otherPatterns []interface{}, mode _segmenters.StringMatchMode) bool {
+	for _, pattern := range patterns {
+		for _, otherPattern := range otherPatterns {
+			if _utils.StringPatternsOverlap(toString(pattern), toString(otherPattern), mode) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// h3CellsIntersect checks if any of the H3 cells is the same as, or an ancestor or a descendant of, any of the
+// other cells
+func h3CellsIntersect(indexer segmenters.H3Indexer, cellIds []interface{}, otherCellIds []interface{}) bool {
+	return indexer.CellsIntersect(toStrings(cellIds), toStrings(otherCellIds))
+}
+
+// datesOverlap checks if any of the dates, date ranges and calendars has any date in common with any of the others
+func datesOverlap(expander segmenters.DatesExpander, values []interface{}, otherValues []interface{}) bool {
+	otherDates := set.New()
+	for _, date := range expander.ExpandDates(toStrings(otherValues)) {
+		otherDates.Insert(date)
+	}
+	for _, date := range expander.ExpandDates(toStrings(values)) {
+		if otherDates.Has(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// toString converts a formatted value of a string segmenter, which is quoted by GetFormattedSegmenters, back to
+// the raw string
+func toString(val interface{}) string {
+	stringVal := fmt.Sprint(val)
+	if unquoted, err := strconv.Unquote(stringVal); err == nil {
+		return unquoted
+	}
+	return stringVal
+}
+
+// toStrings converts the formatted values of a string segmenter to strings
+func toStrings(values []interface{}) []string {
+	stringValues := []string{}
+	for _, val := range values {
+		stringValues = append(stringValues, toString(val))
+	}
+	return stringValues
+}
+
+// s2CellsIntersect checks if any of the cells overlaps with any of the other cells, at any level
+func s2CellsIntersect(cellIds []interface{}, otherCellIds []interface{}) bool {
+	toCellUnion := func(ids []interface{}) s2.CellUnion {
+		cellUnion := s2.CellUnion{}
+		for _, id := range ids {
+			if intId, ok := id.(int64); ok {
+				cellUnion = append(cellUnion, s2.CellID(uint64(intId)))
+			}
+		}
+		cellUnion.Normalize()
+		return cellUnion
+	}
+
+	cellUnion := toCellUnion(cellIds)
+	return cellUnion.Intersects(toCellUnion(otherCellIds))
+}
+
+// containsRanges checks if any of the formatted integer or real values is a range in the interval notation
+func containsRanges(values []interface{}) bool {
+	for _, val := range values {
+		if _, ok := val.(string); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// numericValuesOverlap checks if any of the formatted integer or real values, which may be ranges, has any value
+// in common with any of the other values
+func numericValuesOverlap(values []interface{}, otherValues []interface{}, segmenterType schema.SegmenterType) bool {
+	valueType := _segmenters.SegmenterValueType_INTEGER
+	if segmenterType == schema.SegmenterTypeReal {
+		valueType = _segmenters.SegmenterValueType_REAL
+	}
+	toSegmenterValues := func(formattedValues []interface{}) []*_segmenters.SegmenterValue {
+		segmenterValues := []*_segmenters.SegmenterValue{}
+		for _, val := range formattedValues {
+			var segmenterValue *_segmenters.SegmenterValue
+			switch v := val.(type) {
+			case int64:
+				segmenterValue = &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Integer{Integer: v}}
+			case float64:
+				segmenterValue = &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_Real{Real: v}}
+			case string:
+				rangeVal, err := _utils.ParseSegmenterRange(v, valueType)
+				if err != nil {
+					continue
+				}
+				segmenterValue = rangeVal
+			default:
+				continue
+			}
+			segmenterValues = append(segmenterValues, segmenterValue)
+		}
+		return segmenterValues
+	}
+
+	otherSegmenterValues := toSegmenterValues(otherValues)
+	for _, val := range toSegmenterValues(values) {
+		for _, otherVal := range otherSegmenterValues {
+			if _utils.SegmenterValuesOverlap(val, otherVal) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// versionConstraintsOverlap checks if any of the version constraints is satisfied by any of the same versions
+// as any of the other constraints
+func versionConstraintsOverlap(constraints interface{}, otherConstraints interface{}) bool {
+	toSegmenterValues := func(rawValues interface{}) []*_segmenters.SegmenterValue {
+		segmenterValues := []*_segmenters.SegmenterValue{}
+		values, _ := rawValues.([]interface{})
+		for _, val := range values {
+			constraint, ok := val.(string)
+			if !ok {
+				continue
+			}
+			segmenterValue, err := _utils.ParseVersionConstraint(constraint)
+			if err != nil {
+				continue
+			}
+			segmenterValues = append(segmenterValues, segmenterValue)
+		}
+		return segmenterValues
+	}
+
+	otherSegmenterValues := toSegmenterValues(otherConstraints)
+	for _, val := range toSegmenterValues(constraints) {
+		for _, otherVal := range otherSegmenterValues {
+			if _utils.SegmenterValuesOverlap(val, otherVal) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (svc *segmenterService) ValidateRequiredSegmenters(ctx context.Context, projectId int64, segmenterNames []string) error {
 	providedSegmenterNames := utils.StringSliceToSet(segmenterNames)
 
 	// Get the list of segmenter types for all segmenters
-	segmenterTypes, err := svc.GetSegmenterTypes(projectId)
+	segmenterTypes, err := svc.GetSegmenterTypes(ctx, projectId)
 	if err != nil {
 		return err
 	}
@@ -748,7 +1041,7 @@ func (svc *segmenterService) ValidateRequiredSegmenters(projectId int64, segment
 		}
 	}
 	// Validate required custom segmenters are selected
-	customSegmenters, err := svc.getCustomSegmenters(projectId)
+	customSegmenters, err := svc.getCustomSegmenters(ctx, projectId)
 	if err != nil {
 		return err
 	}
@@ -770,7 +1063,7 @@ func (svc *segmenterService) ValidateRequiredSegmenters(projectId int64, segment
 	return nil
 }
 
-func (svc *segmenterService) ValidatePrereqSegmenters(projectId int64, segmenterNames []string) error {
+func (svc *segmenterService) ValidatePrereqSegmenters(ctx context.Context, projectId int64, segmenterNames []string) error {
 	providedSegmenterNames := set.New()
 	for _, segmenterName := range segmenterNames {
 		providedSegmenterNames.Insert(segmenterName)
@@ -778,7 +1071,7 @@ func (svc *segmenterService) ValidatePrereqSegmenters(projectId int64, segmenter
 
 	// Validate pre-requisite segmenters are selected
 	for _, segmenterName := range segmenterNames {
-		segmenter, err := svc.GetBaseSegmenter(projectId, segmenterName)
+		segmenter, err := svc.GetBaseSegmenter(ctx, projectId, segmenterName)
 		if err != nil {
 			return err
 		}
@@ -800,7 +1093,12 @@ func (svc *segmenterService) ValidatePrereqSegmenters(projectId int64, segmenter
 	return nil
 }
 
-func (svc *segmenterService) ValidateExperimentVariables(projectId int64, projectSegmenters models.ProjectSegmenters) error {
+func (svc *segmenterService) ValidateExperimentVariables(
+	ctx context.Context,
+	projectId int64,
+	projectSegmenters models.ProjectSegmenters,
+	randomizationKey string,
+) error {
 	if len(projectSegmenters.Names) != len(projectSegmenters.Variables) {
 		return fmt.Errorf("len of project segmenters does not match mapping of experiment variables")
 	}
@@ -809,7 +1107,7 @@ func (svc *segmenterService) ValidateExperimentVariables(projectId int64, projec
 		if !ok {
 			return fmt.Errorf("project segmenters does not match mapping of experiment variables")
 		}
-		segmenter, err := svc.GetBaseSegmenter(projectId, segmentersName)
+		segmenter, err := svc.GetBaseSegmenter(ctx, projectId, segmentersName)
 		if err != nil {
 			return err
 		}
@@ -820,6 +1118,10 @@ func (svc *segmenterService) ValidateExperimentVariables(projectId int64, projec
 		// flag to check if segmenter has matching variables as per segmenters setting
 		isValid := false
 		treatmentRequestFields := config.TreatmentRequestFields.GetValues()
+		if segmentersName == _utils.UnitBucketSegmenter {
+			// The buckets are computed from the randomization key of the project
+			treatmentRequestFields = []*_segmenters.ExperimentVariables{{Value: []string{randomizationKey}}}
+		}
 		less := func(a, b string) bool { return a < b }
 		for _, supportedVariables := range treatmentRequestFields {
 			if isValid {
@@ -835,21 +1137,57 @@ func (svc *segmenterService) ValidateExperimentVariables(projectId int64, projec
 	return nil
 }
 
-func (svc *segmenterService) query() *gorm.DB {
-	return svc.db
+func (svc *segmenterService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
 }
 
-func (svc *segmenterService) save(customSegmenter *models.CustomSegmenter) (*models.CustomSegmenter, error) {
-	if err := svc.query().Clauses(clause.OnConflict{
+func (svc *segmenterService) save(tx *gorm.DB, customSegmenter *models.CustomSegmenter) (*models.CustomSegmenter, error) {
+	if err := tx.Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(customSegmenter).Error; err != nil {
 		return nil, err
 	}
-	return svc.GetDBRecord(customSegmenter.ProjectID, customSegmenter.Name)
+	return svc.getDBRecord(tx, customSegmenter.ProjectID, customSegmenter.Name)
+}
+
+// saveAndPublish saves the custom segmenter and writes the corresponding message queue update to
+// the outbox in a single transaction. The returned record is converted from the DB schema.
+func (svc *segmenterService) saveAndPublish(
+	ctx context.Context,
+	customSegmenter *models.CustomSegmenter,
+	segmenterTypes map[string]schema.SegmenterType,
+	updateType string,
+) (*models.CustomSegmenter, error) {
+	var customSegmenterDBRecord *models.CustomSegmenter
+	err := svc.query(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		customSegmenterDBRecord, err = svc.save(tx, customSegmenter)
+		if err != nil {
+			return err
+		}
+
+		// Convert custom segmenter from DB schema
+		if err := customSegmenterDBRecord.FromStorageSchema(segmenterTypes); err != nil {
+			return err
+		}
+
+		// Get SegmenterConfiguration expected by the Message Queue
+		protoSegmenterConfig, err := customSegmenterDBRecord.GetConfiguration()
+		if err != nil {
+			return err
+		}
+		return svc.services.OutboxService.AddProjectSegmenterMessage(
+			tx, updateType, protoSegmenterConfig, int64(customSegmenter.ProjectID))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return customSegmenterDBRecord, nil
 }
 
-func (svc segmenterService) getActiveSegmenterNames(projectId int64) ([]string, error) {
-	dbRecord, err := svc.services.ProjectSettingsService.GetDBRecord(models.ID(projectId))
+func (svc segmenterService) getActiveSegmenterNames(ctx context.Context, projectId int64) ([]string, error) {
+	dbRecord, err := svc.services.ProjectSettingsService.GetDBRecord(ctx, models.ID(projectId))
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"reflect"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/caraml-dev/xp/common/api/schema"
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	"github.com/caraml-dev/xp/management-service/config"
 	tu "github.com/caraml-dev/xp/management-service/internal/testutils"
 	"github.com/caraml-dev/xp/management-service/models"
 	"github.com/caraml-dev/xp/management-service/segmenters"
@@ -52,7 +54,7 @@ func (s *SegmenterServiceTestSuite) SetupSuite() {
 
 	settingsSvc := mocks.ProjectSettingsService{}
 	// This will define which segmenters are active
-	settingsSvc.On("GetDBRecord", models.ID(0)).Return(
+	settingsSvc.On("GetDBRecord", mock.Anything, models.ID(0)).Return(
 		&models.Settings{
 			ProjectID: models.ID(0),
 			Config: &models.ExperimentationConfig{Segmenters: models.ProjectSegmenters{
@@ -68,7 +70,7 @@ func (s *SegmenterServiceTestSuite) SetupSuite() {
 		},
 		nil,
 	)
-	settingsSvc.On("GetDBRecord", mock.Anything).Return(
+	settingsSvc.On("GetDBRecord", mock.Anything, mock.Anything).Return(
 		&models.Settings{
 			ProjectID: models.ID(1),
 			Config: &models.ExperimentationConfig{Segmenters: models.ProjectSegmenters{
@@ -76,13 +78,10 @@ func (s *SegmenterServiceTestSuite) SetupSuite() {
 		nil,
 	)
 
-	messageQueueSvc := &mocks.MessageQueueService{}
-	messageQueueSvc.On("PublishProjectSegmenterMessage", mock.Anything, mock.Anything, mock.Anything).Return(
-		nil)
 	allServices := &services.Services{
 		ProjectSettingsService: &settingsSvc,
-		MessageQueueService:    messageQueueSvc,
 	}
+	allServices.OutboxService = services.NewOutboxService(allServices, config.OutboxConfig{}, db)
 
 	s.SegmenterService, err = services.NewSegmenterService(allServices, segmenterConfig, db)
 	if err != nil {
@@ -189,7 +188,7 @@ func (s *SegmenterServiceTestSuite) TestGetSegmenterConfigurations() {
 
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
-			got, err := s.SegmenterService.GetSegmenterConfigurations(int64(0), data.segmenterNames)
+			got, err := s.SegmenterService.GetSegmenterConfigurations(context.Background(), int64(0), data.segmenterNames)
 			s.Suite.Require().NoError(err)
 			s.Suite.Assert().True(true, reflect.DeepEqual(data.expectedFields, got))
 		})
@@ -244,7 +243,7 @@ func (s *SegmenterServiceTestSuite) TestGetFormattedSegmenters() {
 
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
-			got, err := s.SegmenterService.GetFormattedSegmenters(int64(0), data.segmenters)
+			got, err := s.SegmenterService.GetFormattedSegmenters(context.Background(), int64(0), data.segmenters)
 			if data.errString == "" {
 				s.Suite.Require().NoError(err)
 				s.Suite.Assert().Equal(data.expected, got)
@@ -301,7 +300,7 @@ func (s *SegmenterServiceTestSuite) TestValidateExperimentSegment() {
 
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
-			err := s.SegmenterService.ValidateExperimentSegment(int64(0), data.userSegmenters, data.expSegment)
+			err := s.SegmenterService.ValidateExperimentSegment(context.Background(), int64(0), data.userSegmenters, data.expSegment)
 			if data.errString == "" {
 				s.Suite.Require().NoError(err)
 			} else {
@@ -311,6 +310,87 @@ func (s *SegmenterServiceTestSuite) TestValidateExperimentSegment() {
 	}
 }
 
+func (s *SegmenterServiceTestSuite) TestCoverSegmentGeometries() {
+	geometry := map[string]interface{}{
+		"type": "Polygon",
+		"coordinates": []interface{}{
+			[]interface{}{
+				[]interface{}{103.899, 1.253},
+				[]interface{}{103.901, 1.253},
+				[]interface{}{103.901, 1.255},
+				[]interface{}{103.899, 1.255},
+				[]interface{}{103.899, 1.253},
+			},
+		},
+	}
+	tests := map[string]struct {
+		expSegment       models.ExperimentSegmentRaw
+		expectedSegment  models.ExperimentSegmentRaw
+		expectedCovering *models.S2Covering
+		errString        string
+	}{
+		"success | no geometry": {
+			expSegment: models.ExperimentSegmentRaw{
+				"s2_ids":       []interface{}{float64(3592210809859604480)},
+				"days_of_week": []interface{}{float64(1)},
+			},
+			expectedSegment: models.ExperimentSegmentRaw{
+				"s2_ids":       []interface{}{float64(3592210809859604480)},
+				"days_of_week": []interface{}{float64(1)},
+			},
+		},
+		"success | geometry": {
+			expSegment: models.ExperimentSegmentRaw{
+				"s2_ids":       geometry,
+				"days_of_week": []interface{}{float64(1)},
+			},
+			expectedSegment: models.ExperimentSegmentRaw{
+				"s2_ids":       []interface{}{float64(3592210809859604480)},
+				"days_of_week": []interface{}{float64(1)},
+			},
+			expectedCovering: &models.S2Covering{
+				Geometry:  geometry,
+				MinLevel:  14,
+				MaxLevel:  15,
+				CellCount: 1,
+			},
+		},
+		"failure | segmenter does not accept geometries": {
+			expSegment: models.ExperimentSegmentRaw{
+				"days_of_week": geometry,
+			},
+			errString: "segmenter days_of_week does not accept GeoJSON geometries",
+		},
+		"failure | invalid geometry": {
+			expSegment: models.ExperimentSegmentRaw{
+				"s2_ids": map[string]interface{}{"type": "Point", "coordinates": []interface{}{103.9, 1.25}},
+			},
+			errString: "failed to cover the s2_ids geometry: invalid GeoJSON: " +
+				"unsupported type \"Point\", only Polygon and MultiPolygon areas are supported",
+		},
+	}
+
+	for name, data := range tests {
+		s.Suite.T().Run(name, func(t *testing.T) {
+			segment, covering, err := s.SegmenterService.CoverSegmentGeometries(data.expSegment)
+			if data.errString != "" {
+				s.Suite.Assert().EqualError(err, data.errString)
+				return
+			}
+			s.Suite.Require().NoError(err)
+			s.Suite.Assert().Equal(data.expectedSegment, segment)
+			if data.expectedCovering == nil {
+				s.Suite.Assert().Nil(covering)
+				return
+			}
+			s.Suite.Require().NotNil(covering)
+			s.Suite.Assert().Greater(covering.CoverageError, float64(0))
+			covering.CoverageError = 0
+			s.Suite.Assert().Equal(data.expectedCovering, covering)
+		})
+	}
+}
+
 func (s *SegmenterServiceTestSuite) TestValidateSegmentOrthogonality() {
 	s2IdRaw := []interface{}{float64(3592210809859604480), float64(3592210814154571776)}
 	daysOfWeekRaw := []interface{}{float64(1)}
@@ -397,6 +477,108 @@ func (s *SegmenterServiceTestSuite) TestValidateSegmentOrthogonality() {
 			},
 			errString: "Segment Orthogonality check failed against experiment ID 0",
 		},
+		"failure | overlapping s2 cells of different levels": {
+			userSegmenters: []string{"s2_ids"},
+			expSegment: models.ExperimentSegmentRaw{
+				"s2_ids": []interface{}{float64(3592210806638379008)},
+			},
+			allExps: []models.Experiment{
+				{
+					Segment: models.ExperimentSegment{
+						"s2_ids": testS2Id1,
+					},
+				},
+			},
+			errString: "Segment Orthogonality check failed against experiment ID 0",
+		},
+		"failure | overlapping ranges": {
+			userSegmenters: []string{"area"},
+			expSegment: models.ExperimentSegmentRaw{
+				"area": []interface{}{map[string]interface{}{"min": float64(1), "max": float64(5)}},
+			},
+			allExps: []models.Experiment{
+				{
+					Segment: models.ExperimentSegment{
+						"area": []string{"[5,10]"},
+					},
+				},
+			},
+			errString: "Segment Orthogonality check failed against experiment ID 0",
+		},
+		"success | non-overlapping ranges": {
+			userSegmenters: []string{"area"},
+			expSegment: models.ExperimentSegmentRaw{
+				"area": []interface{}{
+					map[string]interface{}{"min": float64(1), "max": float64(5), "max_exclusive": true},
+				},
+			},
+			allExps: []models.Experiment{
+				{
+					Segment: models.ExperimentSegment{
+						"area": []string{"[5,)"},
+					},
+				},
+				{
+					Segment: models.ExperimentSegment{
+						"area": []string{"0"},
+					},
+				},
+			},
+		},
+		"failure | overlapping version constraints": {
+			userSegmenters: []string{"app_version"},
+			expSegment: models.ExperimentSegmentRaw{
+				"app_version": []interface{}{">=4.12.0, <5.0.0"},
+			},
+			allExps: []models.Experiment{
+				{
+					Segment: models.ExperimentSegment{
+						"app_version": []string{"4.x"},
+					},
+				},
+			},
+			errString: "Segment Orthogonality check failed against experiment ID 0",
+		},
+		"success | non-overlapping version constraints": {
+			userSegmenters: []string{"app_version"},
+			expSegment: models.ExperimentSegmentRaw{
+				"app_version": []interface{}{">=4.12.0, <5.0.0"},
+			},
+			allExps: []models.Experiment{
+				{
+					Segment: models.ExperimentSegment{
+						"app_version": []string{"^5.0.0", "4.11.9"},
+					},
+				},
+			},
+		},
+		"failure | overlapping dates": {
+			userSegmenters: []string{"dates"},
+			expSegment: models.ExperimentSegmentRaw{
+				"dates": []interface{}{"2024-12-20/2024-12-31"},
+			},
+			allExps: []models.Experiment{
+				{
+					Segment: models.ExperimentSegment{
+						"dates": []string{"2024-12-25"},
+					},
+				},
+			},
+			errString: "Segment Orthogonality check failed against experiment ID 0",
+		},
+		"success | non-overlapping dates": {
+			userSegmenters: []string{"dates"},
+			expSegment: models.ExperimentSegmentRaw{
+				"dates": []interface{}{"2024-12-20/2024-12-31"},
+			},
+			allExps: []models.Experiment{
+				{
+					Segment: models.ExperimentSegment{
+						"dates": []string{"2024-12-19", "2025-01-01/2025-01-07"},
+					},
+				},
+			},
+		},
 		"success | existing segmenter optional": {
 			userSegmenters: []string{"s2_ids", "days_of_week"},
 			expSegment: models.ExperimentSegmentRaw{
@@ -450,7 +632,7 @@ func (s *SegmenterServiceTestSuite) TestValidateSegmentOrthogonality() {
 
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
-			err := s.SegmenterService.ValidateSegmentOrthogonality(int64(0), data.userSegmenters, data.expSegment, data.allExps)
+			err := s.SegmenterService.ValidateSegmentOrthogonality(context.Background(), int64(0), data.userSegmenters, data.expSegment, data.allExps)
 			if data.errString == "" {
 				s.Suite.Require().NoError(err)
 			} else {
@@ -483,7 +665,7 @@ func (s *SegmenterServiceTestSuite) TestValidateRequiredSegmenters() {
 
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
-			err := s.SegmenterService.ValidateRequiredSegmenters(data.projectId, data.providedSegmenters)
+			err := s.SegmenterService.ValidateRequiredSegmenters(context.Background(), data.projectId, data.providedSegmenters)
 			if data.errString == "" {
 				s.Suite.Require().NoError(err)
 			} else {
@@ -509,7 +691,7 @@ func (s *SegmenterServiceTestSuite) TestValidateDependentSegmenters() {
 
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
-			err := s.SegmenterService.ValidatePrereqSegmenters(int64(0), data.providedSegmenters)
+			err := s.SegmenterService.ValidatePrereqSegmenters(context.Background(), int64(0), data.providedSegmenters)
 			if data.errString == "" {
 				s.Suite.Require().NoError(err)
 			} else {
@@ -577,11 +759,28 @@ func (s *SegmenterServiceTestSuite) TestValidateExperimentVariables() {
 			},
 			errString: "segmenter (area) does not have valid experiment variable(s) provided",
 		},
+		"success | unit bucket with randomization key": {
+			projectSegmenters: models.ProjectSegmenters{
+				Names: []string{"unit_bucket"},
+				Variables: map[string][]string{
+					"unit_bucket": {"customer_id"},
+				},
+			},
+		},
+		"failure | unit bucket with other variable": {
+			projectSegmenters: models.ProjectSegmenters{
+				Names: []string{"unit_bucket"},
+				Variables: map[string][]string{
+					"unit_bucket": {"session_id"},
+				},
+			},
+			errString: "segmenter (unit_bucket) does not have valid experiment variable(s) provided",
+		},
 	}
 
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
-			err := s.SegmenterService.ValidateExperimentVariables(int64(0), data.projectSegmenters)
+			err := s.SegmenterService.ValidateExperimentVariables(context.Background(), int64(0), data.projectSegmenters, "customer_id")
 			if data.errString == "" {
 				s.Suite.Require().NoError(err)
 			} else {
@@ -667,7 +866,7 @@ func (s *SegmenterServiceTestSuite) TestGetSegmenter() {
 	}
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
-			got, err := s.SegmenterService.GetSegmenter(1, data.segmenterName)
+			got, err := s.SegmenterService.GetSegmenter(context.Background(), 1, data.segmenterName)
 			if data.errString == "" {
 				s.Suite.Require().NoError(err)
 				s.Suite.Assert().NoError(err)
@@ -777,7 +976,7 @@ func (s *SegmenterServiceTestSuite) TestListSegmenters() {
 	}
 	for name, data := range tests {
 		s.Suite.T().Run(name, func(t *testing.T) {
-			got, err := s.SegmenterService.ListSegmenters(1, data.param)
+			got, err := s.SegmenterService.ListSegmenters(context.Background(), 1, data.param)
 			s.Suite.Require().Equal(data.expectedLength, len(got))
 			if data.errString == "" {
 				s.Suite.Require().NoError(err)
@@ -820,7 +1019,7 @@ func (s *SegmenterServiceTestSuite) TestCreateSegmenter() {
 		Required:    false,
 		Description: &description,
 	}
-	segmenterResponse, err := s.SegmenterService.CreateCustomSegmenter(int64(projectId), invalidTestRequest)
+	segmenterResponse, err := s.SegmenterService.CreateCustomSegmenter(context.Background(), int64(projectId), invalidTestRequest)
 	s.Suite.Assert().Nil(segmenterResponse)
 	s.Suite.Assert().EqualError(err, "received wrong type of segmenter value; true expects type bool")
 
@@ -840,7 +1039,7 @@ func (s *SegmenterServiceTestSuite) TestCreateSegmenter() {
 		Description: &description,
 	}
 
-	segmenterResponse, err = s.SegmenterService.CreateCustomSegmenter(int64(projectId), validTestRequest)
+	segmenterResponse, err = s.SegmenterService.CreateCustomSegmenter(context.Background(), int64(projectId), validTestRequest)
 	s.Suite.Require().NoError(err)
 	s.Suite.Assert().Equal(segmenterName, segmenterResponse.Name)
 	s.Suite.Assert().Equal(models.ID(projectId), segmenterResponse.ProjectID)
@@ -852,7 +1051,7 @@ func (s *SegmenterServiceTestSuite) TestCreateSegmenter() {
 	s.Suite.Assert().Equal(&description, segmenterResponse.Description)
 
 	// create again and expect error
-	_, err = s.SegmenterService.CreateCustomSegmenter(int64(projectId), validTestRequest)
+	_, err = s.SegmenterService.CreateCustomSegmenter(context.Background(), int64(projectId), validTestRequest)
 	s.Suite.Assert().EqualError(err, "a segmenter with the name test-create-segmenter-1 already exists")
 }
 
@@ -874,7 +1073,7 @@ func (s *SegmenterServiceTestSuite) TestUpdateSegmenter() {
 		Required:    false,
 		Description: &description,
 	}
-	segmenterResponse, err := s.SegmenterService.UpdateCustomSegmenter(int64(projectId), segmenterName, invalidTestRequest)
+	segmenterResponse, err := s.SegmenterService.UpdateCustomSegmenter(context.Background(), int64(projectId), segmenterName, invalidTestRequest)
 	s.Suite.Assert().Nil(segmenterResponse)
 	s.Suite.Assert().EqualError(err, "received wrong type of segmenter value; %!s(int=1) expects type real")
 
@@ -891,7 +1090,7 @@ func (s *SegmenterServiceTestSuite) TestUpdateSegmenter() {
 		Description: &description,
 	}
 
-	segmenterResponse, err = s.SegmenterService.UpdateCustomSegmenter(int64(projectId), segmenterName, validTestRequest)
+	segmenterResponse, err = s.SegmenterService.UpdateCustomSegmenter(context.Background(), int64(projectId), segmenterName, validTestRequest)
 	s.Suite.Require().NoError(err)
 	s.Suite.Assert().Equal(segmenterName, segmenterResponse.Name)
 	s.Suite.Assert().Equal(models.ID(projectId), segmenterResponse.ProjectID)
@@ -902,7 +1101,7 @@ func (s *SegmenterServiceTestSuite) TestUpdateSegmenter() {
 	s.Suite.Assert().Equal(&description, segmenterResponse.Description)
 
 	// update non existing segmenter and expect error
-	_, err = s.SegmenterService.UpdateCustomSegmenter(int64(projectId), "non-existence!", validTestRequest)
+	_, err = s.SegmenterService.UpdateCustomSegmenter(context.Background(), int64(projectId), "non-existence!", validTestRequest)
 	s.Suite.Assert().EqualError(err, "unknown segmenter: non-existence!")
 }
 
@@ -910,16 +1109,16 @@ func (s *SegmenterServiceTestSuite) TestUpdateSegmenter() {
 // "test-custom-segmenter-1" set as active segmenters
 func (s *SegmenterServiceTestSuite) TestDeleteSegmenter() {
 	// Success flow
-	err := s.SegmenterService.DeleteCustomSegmenter(int64(1), "test-custom-segmenter-for-delete")
+	err := s.SegmenterService.DeleteCustomSegmenter(context.Background(), int64(1), "test-custom-segmenter-for-delete")
 	s.Suite.Require().NoError(err)
 
 	// Error Active Segmenter
-	err = s.SegmenterService.DeleteCustomSegmenter(int64(1), "test-custom-segmenter-1")
+	err = s.SegmenterService.DeleteCustomSegmenter(context.Background(), int64(1), "test-custom-segmenter-1")
 	s.Suite.Assert().EqualError(err,
 		"custom segmenter: test-custom-segmenter-1 is currently in use in the project settings and cannot be deleted")
 
 	// Error Non existence Segmenter
-	err = s.SegmenterService.DeleteCustomSegmenter(int64(1), "unknown")
+	err = s.SegmenterService.DeleteCustomSegmenter(context.Background(), int64(1), "unknown")
 	s.Suite.Assert().EqualError(err, "unknown segmenter: unknown")
 }
 
@@ -14,7 +14,11 @@ type Services struct {
 	TreatmentHistoryService  TreatmentHistoryService
 	ValidationService        ValidationService
 	MessageQueueService      messagequeue.MessageQueueService
+	OutboxService            OutboxService
 	ConfigurationService     ConfigurationService
+	AnalysisService          AnalysisService
+	SRMService               SRMService
+	GuardrailService         GuardrailService
 }
 
 func NewServices(
@@ -29,7 +33,11 @@ func NewServices(
 	treatmentHistorySvc TreatmentHistoryService,
 	validationSvc ValidationService,
 	messageQueueSvc messagequeue.MessageQueueService,
+	outboxSvc OutboxService,
 	configurationService ConfigurationService,
+	analysisSvc AnalysisService,
+	srmSvc SRMService,
+	guardrailSvc GuardrailService,
 ) Services {
 	return Services{
 		ExperimentService:        expSvc,
@@ -37,6 +45,7 @@ func NewServices(
 		MLPService:               mlpSvc,
 		ProjectSettingsService:   projectSettingsSvc,
 		MessageQueueService:      messageQueueSvc,
+		OutboxService:            outboxSvc,
 		SegmenterService:         segmenterSvc,
 		SegmentService:           segmentSvc,
 		SegmentHistoryService:    segmentHistorySvc,
@@ -44,5 +53,8 @@ func NewServices(
 		TreatmentHistoryService:  treatmentHistorySvc,
 		ValidationService:        validationSvc,
 		ConfigurationService:     configurationService,
+		AnalysisService:          analysisSvc,
+		SRMService:               srmSvc,
+		GuardrailService:         guardrailSvc,
 	}
 }
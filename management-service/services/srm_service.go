@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/caraml-dev/xp/management-service/analysis"
+	"github.com/caraml-dev/xp/management-service/config"
+	"github.com/caraml-dev/xp/management-service/errors"
+	"github.com/caraml-dev/xp/management-service/instrumentation"
+	"github.com/caraml-dev/xp/management-service/models"
+)
+
+// srmMonitorLockID is the key of the Postgres advisory lock that ensures that only one
+// replica of the Management Service checks the experiments at any time.
+const srmMonitorLockID = 7_140_002
+
+type SRMService interface {
+	// CheckSampleRatios runs a chi-squared test of the assignment counts of the current version of each
+	// running A/B experiment against the traffic of its treatments, saves the results and returns the
+	// number of experiments that were checked. Experiments with fewer assigned units than the configured
+	// minimum sample size are skipped.
+	CheckSampleRatios() (int, error)
+	// RefreshMetrics sets the Prometheus gauges from the latest checks of the running experiments.
+	RefreshMetrics() error
+	// GetSRMCheck returns the latest check of the given version of the experiment, defaulting to the
+	// current version.
+	GetSRMCheck(ctx context.Context, experiment *models.Experiment, version *int64) (*models.SRMCheck, error)
+}
+
+type srmService struct {
+	services *Services
+	cfg      config.SRMConfig
+	db       *gorm.DB
+
+	// metricsLock guards the refreshing of the gauges and the set of published series
+	metricsLock sync.Mutex
+	// publishedSeries are the label values of the series set by the last refresh
+	publishedSeries map[srmSeries]bool
+}
+
+// srmSeries are the label values of the sample ratio mismatch gauges of an experiment
+type srmSeries struct {
+	projectID    string
+	experimentID string
+}
+
+func NewSRMService(services *Services, cfg config.SRMConfig, db *gorm.DB) SRMService {
+	return &srmService{
+		services:        services,
+		cfg:             cfg,
+		db:              db,
+		publishedSeries: map[srmSeries]bool{},
+	}
+}
+
+func (svc *srmService) CheckSampleRatios() (int, error) {
+	checked := 0
+	err := svc.db.Transaction(func(tx *gorm.DB) error {
+		// Skip this round if another replica is already checking
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", srmMonitorLockID).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			return nil
+		}
+
+		var experiments []*models.Experiment
+		now := time.Now()
+		err := runningExperiments(tx, now).
+			Where("type = ?", models.ExperimentTypeAB).
+			Find(&experiments).Error
+		if err != nil {
+			return err
+		}
+
+		for _, experiment := range experiments {
+			// Check each experiment under its own savepoint, so that an error rolls back only that experiment
+			// and does not abort the transaction for the others
+			var check *models.SRMCheck
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				var err error
+				check, err = svc.checkExperiment(tx, experiment)
+				return err
+			})
+			if err != nil {
+				// Continue with the other experiments
+				log.Printf("Error checking the sample ratio of experiment %d: %v", experiment.ID, err)
+				continue
+			}
+			if check != nil {
+				checked++
+			}
+		}
+		return nil
+	})
+	return checked, err
+}
+
+func (svc *srmService) RefreshMetrics() error {
+	var rows []struct {
+		ProjectID    int64
+		ExperimentID int64
+		PValue       float64
+		Mismatch     bool
+	}
+	err := runningExperiments(svc.db.Table("experiments"), time.Now()).
+		Select("experiments.project_id, c.experiment_id, c.p_value, c.mismatch").
+		Joins("JOIN experiment_srm_checks AS c ON c.experiment_id = experiments.id AND c.experiment_version = experiments.version").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	// Compute the values before updating the gauges, so that a scrape never sees a partial set of series
+	type srmValues struct {
+		pValue   float64
+		mismatch float64
+	}
+	values := make(map[srmSeries]srmValues, len(rows))
+	for _, row := range rows {
+		series := srmSeries{
+			projectID:    strconv.FormatInt(row.ProjectID, 10),
+			experimentID: strconv.FormatInt(row.ExperimentID, 10),
+		}
+		mismatch := 0.0
+		if row.Mismatch {
+			mismatch = 1
+		}
+		values[series] = srmValues{pValue: row.PValue, mismatch: mismatch}
+	}
+
+	svc.metricsLock.Lock()
+	defer svc.metricsLock.Unlock()
+	// Drop the series of the experiments that are no longer running, and update the rest in place
+	for series := range svc.publishedSeries {
+		if _, ok := values[series]; !ok {
+			instrumentation.ExperimentSRMMismatch.DeleteLabelValues(series.projectID, series.experimentID)
+			instrumentation.ExperimentSRMPValue.DeleteLabelValues(series.projectID, series.experimentID)
+		}
+	}
+	publishedSeries := make(map[srmSeries]bool, len(values))
+	for series, value := range values {
+		instrumentation.ExperimentSRMMismatch.WithLabelValues(series.projectID, series.experimentID).Set(value.mismatch)
+		instrumentation.ExperimentSRMPValue.WithLabelValues(series.projectID, series.experimentID).Set(value.pValue)
+		publishedSeries[series] = true
+	}
+	svc.publishedSeries = publishedSeries
+	return nil
+}
+
+func (svc *srmService) GetSRMCheck(ctx context.Context, experiment *models.Experiment, version *int64) (*models.SRMCheck, error) {
+	experimentVersion := experiment.Version
+	if version != nil {
+		experimentVersion = *version
+	}
+
+	var check models.SRMCheck
+	err := svc.db.WithContext(ctx).Where("experiment_id = ? AND experiment_version = ?", experiment.ID, experimentVersion).
+		First(&check).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, errors.Newf(errors.NotFound,
+			"sample ratio mismatch check not found for version %d of the experiment", experimentVersion)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &check, nil
+}
+
+// checkExperiment tests the assignment counts of the current version of the experiment and saves the result.
+// It returns nil if the experiment cannot be checked yet.
+func (svc *srmService) checkExperiment(tx *gorm.DB, experiment *models.Experiment) (*models.SRMCheck, error) {
+	// Every treatment needs a traffic allocation to derive the expected proportions
+	if len(experiment.Treatments) < 2 {
+		return nil, nil
+	}
+	for _, treatment := range experiment.Treatments {
+		if treatment.Traffic == nil || *treatment.Traffic <= 0 {
+			return nil, nil
+		}
+	}
+
+	var rows []struct {
+		Treatment string
+		Count     int64
+	}
+	err := tx.Table("experiment_assignments").
+		Select("treatment, COUNT(*) AS count").
+		Where("experiment_id = ? AND experiment_version = ?", experiment.ID, experiment.Version).
+		Group("treatment").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	treatmentCounts := map[string]int64{}
+	var total int64
+	for _, row := range rows {
+		treatmentCounts[row.Treatment] = row.Count
+		total += row.Count
+	}
+	if total == 0 || total < svc.cfg.MinSampleSize {
+		return nil, nil
+	}
+
+	var totalTraffic int32
+	observed := make([]int64, 0, len(experiment.Treatments))
+	weights := make([]float64, 0, len(experiment.Treatments))
+	for _, treatment := range experiment.Treatments {
+		totalTraffic += *treatment.Traffic
+		observed = append(observed, treatmentCounts[treatment.Name])
+		weights = append(weights, float64(*treatment.Traffic))
+	}
+	statistic, pValue, err := analysis.ChiSquaredTest(observed, weights)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(models.SRMTreatmentCounts, 0, len(experiment.Treatments))
+	for i, treatment := range experiment.Treatments {
+		counts = append(counts, models.SRMTreatmentCount{
+			Treatment:     treatment.Name,
+			Traffic:       *treatment.Traffic,
+			Count:         observed[i],
+			ExpectedCount: float64(total) * float64(*treatment.Traffic) / float64(totalTraffic),
+		})
+	}
+	check := &models.SRMCheck{
+		ExperimentID:      experiment.ID,
+		ExperimentVersion: experiment.Version,
+		Treatments:        counts,
+		ChiSquared:        statistic,
+		PValue:            pValue,
+		Mismatch:          pValue < svc.cfg.PValueThreshold,
+	}
+	err = tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "experiment_id"}, {Name: "experiment_version"}},
+		DoUpdates: clause.AssignmentColumns([]string{"treatments", "chi_squared", "p_value", "mismatch", "updated_at"}),
+	}).Create(check).Error
+	if err != nil {
+		return nil, err
+	}
+	return check, nil
+}
+
+// runningExperiments filters the given query to the active experiments whose schedule includes the given time
+func runningExperiments(query *gorm.DB, now time.Time) *gorm.DB {
+	return query.Where("status = ? AND start_time <= ? AND end_time > ?", models.ExperimentStatusActive, now, now)
+}
+
+// SRMMonitor periodically checks the running experiments for a sample ratio mismatch
+type SRMMonitor struct {
+	srmService    SRMService
+	checkInterval time.Duration
+	stopChannel   chan struct{}
+}
+
+// NewSRMMonitor creates a new SRMMonitor that checks the experiments at the configured interval.
+func NewSRMMonitor(cfg config.SRMConfig, srmService SRMService) *SRMMonitor {
+	return &SRMMonitor{
+		srmService:    srmService,
+		checkInterval: cfg.CheckInterval,
+		stopChannel:   make(chan struct{}),
+	}
+}
+
+func (m *SRMMonitor) Start() {
+	log.Println("Starting sample ratio mismatch monitor...")
+	ticker := time.NewTicker(m.checkInterval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := m.srmService.CheckSampleRatios(); err != nil {
+					log.Printf("Error checking the sample ratios of the experiments: %v", err)
+				}
+				// Every replica publishes the metrics, regardless of which one ran the checks
+				if err := m.srmService.RefreshMetrics(); err != nil {
+					log.Printf("Error refreshing the sample ratio mismatch metrics: %v", err)
+				}
+			case <-m.stopChannel:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+func (m *SRMMonitor) Stop() {
+	close(m.stopChannel)
+}
@@ -0,0 +1,159 @@
+//go:build integration
+
+package services_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/caraml-dev/xp/management-service/config"
+	"github.com/caraml-dev/xp/management-service/errors"
+	"github.com/caraml-dev/xp/management-service/instrumentation"
+	tu "github.com/caraml-dev/xp/management-service/internal/testutils"
+	"github.com/caraml-dev/xp/management-service/models"
+	"github.com/caraml-dev/xp/management-service/services"
+)
+
+type SRMServiceTestSuite struct {
+	suite.Suite
+	services.SRMService
+	db          *gorm.DB
+	Experiments []*models.Experiment
+	CleanUpFunc func()
+}
+
+func (s *SRMServiceTestSuite) SetupSuite() {
+	s.Suite.T().Log("Setting up SRMServiceTestSuite")
+
+	// Create test DB, save the DB clean up function to be executed on tear down
+	db, cleanup, err := tu.CreateTestDB(tu.MigrationsPath)
+	if err != nil {
+		s.Suite.T().Fatalf("Could not create test DB: %v", err)
+	}
+	s.db = db
+	s.CleanUpFunc = cleanup
+
+	// Create running experiments with a 20/80 split
+	err = db.Create(&models.Settings{ProjectID: models.ID(1)}).Error
+	if err != nil {
+		s.Suite.T().Fatalf("Could not set up test data: %v", err)
+	}
+	var controlTraffic, treatmentTraffic int32 = 20, 80
+	for i, name := range []string{"balanced", "mismatched", "too-small"} {
+		experiment := &models.Experiment{
+			ProjectID: models.ID(1),
+			Name:      name,
+			Type:      models.ExperimentTypeAB,
+			Tier:      models.ExperimentTierDefault,
+			Treatments: models.ExperimentTreatments{
+				{Name: "control", Traffic: &controlTraffic},
+				{Name: "treatment", Traffic: &treatmentTraffic},
+			},
+			Segment:   models.ExperimentSegment{},
+			Status:    models.ExperimentStatusActive,
+			StartTime: time.Now().Add(-time.Hour),
+			EndTime:   time.Now().Add(time.Hour),
+			UpdatedBy: "test-user",
+			Version:   1,
+		}
+		if err := db.Create(experiment).Error; err != nil {
+			s.Suite.T().Fatalf("Could not set up test data: %v", err)
+		}
+		s.Experiments = append(s.Experiments, experiment)
+
+		// The "balanced" experiment matches the configured traffic, "mismatched" is split 50/50
+		// and "too-small" has fewer units than the min sample size
+		controlCount, unitCount := 200, 1000
+		if i == 1 {
+			controlCount = 500
+		} else if i == 2 {
+			controlCount, unitCount = 20, 100
+		}
+		assignments := []models.ExperimentAssignment{}
+		for unit := 0; unit < unitCount; unit++ {
+			treatment := "treatment"
+			if unit < controlCount {
+				treatment = "control"
+			}
+			assignments = append(assignments, models.ExperimentAssignment{
+				ExperimentID:      experiment.ID,
+				ExperimentVersion: 1,
+				UnitID:            fmt.Sprintf("unit-%d", unit),
+				Treatment:         treatment,
+			})
+		}
+		if err := db.CreateInBatches(assignments, 500).Error; err != nil {
+			s.Suite.T().Fatalf("Could not set up test data: %v", err)
+		}
+	}
+
+	s.SRMService = services.NewSRMService(&services.Services{}, config.SRMConfig{
+		PValueThreshold: 0.001,
+		MinSampleSize:   1000,
+	}, db)
+}
+
+func (s *SRMServiceTestSuite) TearDownSuite() {
+	s.Suite.T().Log("Cleaning up SRMServiceTestSuite")
+	s.CleanUpFunc()
+}
+
+func TestSRMService(t *testing.T) {
+	suite.Run(t, new(SRMServiceTestSuite))
+}
+
+func (s *SRMServiceTestSuite) TestCheckSampleRatios() {
+	checked, err := s.SRMService.CheckSampleRatios()
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(2, checked)
+
+	balanced, err := s.SRMService.GetSRMCheck(context.Background(), s.Experiments[0], nil)
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().False(balanced.Mismatch)
+	s.Suite.Assert().Equal(0.0, balanced.ChiSquared)
+	s.Suite.Assert().Equal(models.SRMTreatmentCounts{
+		{Treatment: "control", Traffic: 20, Count: 200, ExpectedCount: 200},
+		{Treatment: "treatment", Traffic: 80, Count: 800, ExpectedCount: 800},
+	}, balanced.Treatments)
+
+	mismatched, err := s.SRMService.GetSRMCheck(context.Background(), s.Experiments[1], nil)
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().True(mismatched.Mismatch)
+	s.Suite.Assert().Less(mismatched.PValue, 0.001)
+
+	_, err = s.SRMService.GetSRMCheck(context.Background(), s.Experiments[2], nil)
+	s.Suite.Assert().EqualError(err, "sample ratio mismatch check not found for version 1 of the experiment")
+	s.Suite.Assert().Equal(errors.NotFound, errors.GetType(err))
+
+	// Checking again replaces the saved results
+	_, err = s.SRMService.CheckSampleRatios()
+	s.Suite.Require().NoError(err)
+	recheck, err := s.SRMService.GetSRMCheck(context.Background(), s.Experiments[1], nil)
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal(mismatched.ID, recheck.ID)
+
+	// Publish the metrics
+	s.Suite.Require().NoError(s.SRMService.RefreshMetrics())
+	s.Suite.Assert().Equal(2, testutil.CollectAndCount(instrumentation.ExperimentSRMMismatch))
+	s.Suite.Assert().Equal(0.0, testutil.ToFloat64(
+		instrumentation.ExperimentSRMMismatch.WithLabelValues("1", fmt.Sprint(s.Experiments[0].ID))))
+	s.Suite.Assert().Equal(1.0, testutil.ToFloat64(
+		instrumentation.ExperimentSRMMismatch.WithLabelValues("1", fmt.Sprint(s.Experiments[1].ID))))
+
+	// The series of an experiment that is no longer running are dropped, and the others are kept
+	s.Suite.Require().NoError(s.db.Model(s.Experiments[1]).Update("status", models.ExperimentStatusInactive).Error)
+	defer func() {
+		s.Suite.Require().NoError(s.db.Model(s.Experiments[1]).Update("status", models.ExperimentStatusActive).Error)
+	}()
+	s.Suite.Require().NoError(s.SRMService.RefreshMetrics())
+	s.Suite.Assert().Equal(1, testutil.CollectAndCount(instrumentation.ExperimentSRMMismatch))
+	s.Suite.Assert().Equal(1, testutil.CollectAndCount(instrumentation.ExperimentSRMPValue))
+	s.Suite.Assert().Equal(0.0, testutil.ToFloat64(
+		instrumentation.ExperimentSRMMismatch.WithLabelValues("1", fmt.Sprint(s.Experiments[0].ID))))
+}
@@ -1,6 +1,8 @@
 package services
 
 import (
+	"context"
+
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -14,11 +16,15 @@ type ListTreatmentHistoryParams struct {
 }
 
 type TreatmentHistoryService interface {
-	ListTreatmentHistory(treatmentId int64, params ListTreatmentHistoryParams) ([]*models.TreatmentHistory, *pagination.Paging, error)
-	GetTreatmentHistory(treatmentId int64, version int64) (*models.TreatmentHistory, error)
-	CreateTreatmentHistory(*models.Treatment) (*models.TreatmentHistory, error)
-	GetDBRecord(treatmentId models.ID, version int64) (*models.TreatmentHistory, error)
-	DeleteTreatmentHistory(treatmentId int64) error
+	ListTreatmentHistory(
+		ctx context.Context,
+		treatmentId int64,
+		params ListTreatmentHistoryParams,
+	) ([]*models.TreatmentHistory, *pagination.Paging, error)
+	GetTreatmentHistory(ctx context.Context, treatmentId int64, version int64) (*models.TreatmentHistory, error)
+	CreateTreatmentHistory(ctx context.Context, treatment *models.Treatment) (*models.TreatmentHistory, error)
+	GetDBRecord(ctx context.Context, treatmentId models.ID, version int64) (*models.TreatmentHistory, error)
+	DeleteTreatmentHistory(ctx context.Context, treatmentId int64) error
 }
 
 type treatmentHistoryService struct {
@@ -32,11 +38,12 @@ func NewTreatmentHistoryService(db *gorm.DB) TreatmentHistoryService {
 }
 
 func (svc *treatmentHistoryService) ListTreatmentHistory(
+	ctx context.Context,
 	treatmentId int64,
 	params ListTreatmentHistoryParams,
 ) ([]*models.TreatmentHistory, *pagination.Paging, error) {
 	var history []*models.TreatmentHistory
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("treatment_id = ?", treatmentId).
 		Order("updated_at desc")
 
@@ -71,10 +78,11 @@ func (svc *treatmentHistoryService) ListTreatmentHistory(
 }
 
 func (svc *treatmentHistoryService) GetTreatmentHistory(
+	ctx context.Context,
 	treatmentId int64,
 	version int64,
 ) (*models.TreatmentHistory, error) {
-	history, err := svc.GetDBRecord(models.ID(treatmentId), version)
+	history, err := svc.GetDBRecord(ctx, models.ID(treatmentId), version)
 	if err != nil {
 		return nil, errors.Newf(errors.NotFound, err.Error())
 	}
@@ -82,16 +90,19 @@ func (svc *treatmentHistoryService) GetTreatmentHistory(
 	return history, nil
 }
 
-func (svc *treatmentHistoryService) CreateTreatmentHistory(treatment *models.Treatment) (*models.TreatmentHistory, error) {
+func (svc *treatmentHistoryService) CreateTreatmentHistory(
+	ctx context.Context,
+	treatment *models.Treatment,
+) (*models.TreatmentHistory, error) {
 	var history []*models.TreatmentHistory
 	var count int64
 	// Begin transaction - so that getting the current count and creating the new record are
 	// done in a single transaction.
-	tx := svc.db.Begin()
+	tx := svc.query(ctx).Begin()
 	// Get the count of the existing treatment history records
-	svc.query().Where("treatment_id = ?", treatment.ID).Model(&history).Count(&count)
+	svc.query(ctx).Where("treatment_id = ?", treatment.ID).Model(&history).Count(&count)
 	// Create the new history record
-	newHistoryRecord, err := svc.save(&models.TreatmentHistory{
+	newHistoryRecord, err := svc.save(ctx, &models.TreatmentHistory{
 		Model: models.Model{
 			CreatedAt: treatment.UpdatedAt,
 		},
@@ -107,8 +118,8 @@ func (svc *treatmentHistoryService) CreateTreatmentHistory(treatment *models.Tre
 	return newHistoryRecord, tx.Commit().Error
 }
 
-func (svc *treatmentHistoryService) DeleteTreatmentHistory(treatmentId int64) error {
-	query := svc.query().
+func (svc *treatmentHistoryService) DeleteTreatmentHistory(ctx context.Context, treatmentId int64) error {
+	query := svc.query(ctx).
 		Where("treatment_id = ?", treatmentId).
 		Unscoped().
 		Delete(models.TreatmentHistory{})
@@ -119,11 +130,12 @@ func (svc *treatmentHistoryService) DeleteTreatmentHistory(treatmentId int64) er
 }
 
 func (svc *treatmentHistoryService) GetDBRecord(
+	ctx context.Context,
 	treatmentId models.ID,
 	version int64,
 ) (*models.TreatmentHistory, error) {
 	var history models.TreatmentHistory
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("treatment_id = ?", treatmentId).
 		Where("version = ?", version).
 		First(&history)
@@ -133,15 +145,15 @@ func (svc *treatmentHistoryService) GetDBRecord(
 	return &history, nil
 }
 
-func (svc *treatmentHistoryService) query() *gorm.DB {
-	return svc.db
+func (svc *treatmentHistoryService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
 }
 
-func (svc *treatmentHistoryService) save(history *models.TreatmentHistory) (*models.TreatmentHistory, error) {
-	if err := svc.query().Clauses(clause.OnConflict{
+func (svc *treatmentHistoryService) save(ctx context.Context, history *models.TreatmentHistory) (*models.TreatmentHistory, error) {
+	if err := svc.query(ctx).Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(history).Error; err != nil {
 		return nil, err
 	}
-	return svc.GetDBRecord(history.TreatmentID, history.Version)
+	return svc.GetDBRecord(ctx, history.TreatmentID, history.Version)
 }
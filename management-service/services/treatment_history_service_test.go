@@ -3,6 +3,7 @@
 package services_test
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 	"time"
@@ -53,11 +54,11 @@ func (s *TreatmentHistoryServiceTestSuite) TearDownSuite() {
 
 func (s *TreatmentHistoryServiceTestSuite) TestTreatmmentHistoryServiceGetIntegration() {
 	// Successful get
-	histResponse, err := s.TreatmentHistoryService.GetTreatmentHistory(1, 1)
+	histResponse, err := s.TreatmentHistoryService.GetTreatmentHistory(context.Background(), 1, 1)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.TreatmentHistory[0], histResponse)
 	// Invalid version
-	_, err = s.TreatmentHistoryService.GetTreatmentHistory(1, 200)
+	_, err = s.TreatmentHistoryService.GetTreatmentHistory(context.Background(), 1, 200)
 	s.Suite.Require().EqualError(err, "record not found")
 }
 
@@ -69,13 +70,13 @@ func (s *TreatmentHistoryServiceTestSuite) TestTreatmentHistoryServiceListCreate
 }
 
 func testListTreatmentHistory(s *TreatmentHistoryServiceTestSuite) {
-	histResponse, paging, err := s.TreatmentHistoryService.ListTreatmentHistory(1, services.ListTreatmentHistoryParams{})
+	histResponse, paging, err := s.TreatmentHistoryService.ListTreatmentHistory(context.Background(), 1, services.ListTreatmentHistoryParams{})
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.TreatmentHistory, histResponse)
 	tu.AssertEqualValues(s.Suite.T(), &pagination.Paging{Page: 1, Pages: 1, Total: 2}, paging)
 	// Pagination
 	var page, pageSize int32 = 2, 1
-	histResponse, paging, err = s.TreatmentHistoryService.ListTreatmentHistory(1, services.ListTreatmentHistoryParams{
+	histResponse, paging, err = s.TreatmentHistoryService.ListTreatmentHistory(context.Background(), 1, services.ListTreatmentHistoryParams{
 		pagination.PaginationOptions{
 			Page:     &page,
 			PageSize: &pageSize,
@@ -85,7 +86,7 @@ func testListTreatmentHistory(s *TreatmentHistoryServiceTestSuite) {
 	tu.AssertEqualValues(s.Suite.T(), []*models.TreatmentHistory{s.TreatmentHistory[1]}, histResponse)
 	tu.AssertEqualValues(s.Suite.T(), &pagination.Paging{Page: 2, Pages: 2, Total: 2}, paging)
 	// No history
-	histResponse, paging, err = s.TreatmentHistoryService.ListTreatmentHistory(2, services.ListTreatmentHistoryParams{})
+	histResponse, paging, err = s.TreatmentHistoryService.ListTreatmentHistory(context.Background(), 2, services.ListTreatmentHistoryParams{})
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), []*models.TreatmentHistory{}, histResponse)
 	tu.AssertEqualValues(s.Suite.T(), &pagination.Paging{Page: 1, Pages: 0, Total: 0}, paging)
@@ -93,7 +94,7 @@ func testListTreatmentHistory(s *TreatmentHistoryServiceTestSuite) {
 
 func testCreateTreatmentHistory(s *TreatmentHistoryServiceTestSuite) {
 	treatment := s.Treatments[1]
-	treatmentHist, err := s.TreatmentHistoryService.CreateTreatmentHistory(treatment)
+	treatmentHist, err := s.TreatmentHistoryService.CreateTreatmentHistory(context.Background(), treatment)
 
 	s.Suite.Require().NoError(err)
 
@@ -117,7 +118,7 @@ func testCreateTreatmentHistory(s *TreatmentHistoryServiceTestSuite) {
 	s.Suite.Assert().JSONEq(string(expectedJSON), string(treatmentHistJSON))
 
 	// Get the newly created treatment history and verify
-	treatmentHist, err = s.TreatmentHistoryService.GetTreatmentHistory(int64(s.Treatments[1].ID), 1)
+	treatmentHist, err = s.TreatmentHistoryService.GetTreatmentHistory(context.Background(), int64(s.Treatments[1].ID), 1)
 	s.Suite.Require().NoError(err)
 	// JSON Marshal to compare, to bypass timezone precision issues
 	treatmentHistJSON, _ = json.Marshal(treatmentHist)
@@ -127,7 +128,7 @@ func testCreateTreatmentHistory(s *TreatmentHistoryServiceTestSuite) {
 func testDeleteTreatmentHistory(s *TreatmentHistoryServiceTestSuite) {
 	// Delete TreatmentHistory
 	deletedTreatmentId := int64(3)
-	err := s.TreatmentHistoryService.DeleteTreatmentHistory(deletedTreatmentId)
+	err := s.TreatmentHistoryService.DeleteTreatmentHistory(context.Background(), deletedTreatmentId)
 	s.Suite.Require().NoError(err)
 }
 
@@ -2,6 +2,7 @@ package services
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"html/template"
 
@@ -36,15 +37,21 @@ type ListTreatmentsParams struct {
 
 type TreatmentService interface {
 	ListTreatments(
+		ctx context.Context,
 		projectId int64,
 		params ListTreatmentsParams,
 	) ([]*models.Treatment, *pagination.Paging, error)
-	GetTreatment(projectId int64, treatmentId int64) (*models.Treatment, error)
-	CreateTreatment(settings models.Settings, treatmentData CreateTreatmentRequestBody) (*models.Treatment, error)
-	UpdateTreatment(settings models.Settings, treatmentId int64, treatmentData UpdateTreatmentRequestBody) (*models.Treatment, error)
-	DeleteTreatment(projectId int64, treatmentId int64) error
+	GetTreatment(ctx context.Context, projectId int64, treatmentId int64) (*models.Treatment, error)
+	CreateTreatment(ctx context.Context, settings models.Settings, treatmentData CreateTreatmentRequestBody) (*models.Treatment, error)
+	UpdateTreatment(
+		ctx context.Context,
+		settings models.Settings,
+		treatmentId int64,
+		treatmentData UpdateTreatmentRequestBody,
+	) (*models.Treatment, error)
+	DeleteTreatment(ctx context.Context, projectId int64, treatmentId int64) error
 
-	GetDBRecord(projectId models.ID, treatmentId models.ID) (*models.Treatment, error)
+	GetDBRecord(ctx context.Context, projectId models.ID, treatmentId models.ID) (*models.Treatment, error)
 	RunCustomValidation(
 		treatmentConfig map[string]interface{},
 		settings models.Settings,
@@ -69,13 +76,14 @@ func NewTreatmentService(
 }
 
 func (svc *treatmentService) ListTreatments(
+	ctx context.Context,
 	projectId int64,
 	params ListTreatmentsParams,
 ) ([]*models.Treatment, *pagination.Paging, error) {
 	var err error
 	var treatments []*models.Treatment
 
-	query := svc.query()
+	query := svc.query(ctx)
 	if params.Fields != nil && len(*params.Fields) != 0 {
 		err = validateListTreatmentFieldNames(*params.Fields)
 		if err != nil {
@@ -137,8 +145,8 @@ func (svc *treatmentService) ListTreatments(
 	return treatments, pagingResponse, nil
 }
 
-func (svc *treatmentService) GetTreatment(projectId int64, treatmentId int64) (*models.Treatment, error) {
-	treatment, err := svc.GetDBRecord(models.ID(projectId), models.ID(treatmentId))
+func (svc *treatmentService) GetTreatment(ctx context.Context, projectId int64, treatmentId int64) (*models.Treatment, error) {
+	treatment, err := svc.GetDBRecord(ctx, models.ID(projectId), models.ID(treatmentId))
 	if err != nil {
 		return nil, errors.Newf(errors.NotFound, err.Error())
 	}
@@ -147,6 +155,7 @@ func (svc *treatmentService) GetTreatment(projectId int64, treatmentId int64) (*
 }
 
 func (svc *treatmentService) CreateTreatment(
+	ctx context.Context,
 	settings models.Settings,
 	treatmentData CreateTreatmentRequestBody,
 ) (*models.Treatment, error) {
@@ -171,7 +180,7 @@ func (svc *treatmentService) CreateTreatment(
 	}
 
 	// Save to DB
-	treatmentDBRecord, err := svc.save(treatment)
+	treatmentDBRecord, err := svc.save(ctx, treatment)
 	if err != nil {
 		return nil, err
 	}
@@ -180,6 +189,7 @@ func (svc *treatmentService) CreateTreatment(
 }
 
 func (svc *treatmentService) UpdateTreatment(
+	ctx context.Context,
 	settings models.Settings,
 	treatmentId int64,
 	treatmentData UpdateTreatmentRequestBody,
@@ -191,7 +201,7 @@ func (svc *treatmentService) UpdateTreatment(
 	}
 
 	// Get current treatment
-	curTreatment, err := svc.GetDBRecord(settings.ProjectID, models.ID(treatmentId))
+	curTreatment, err := svc.GetDBRecord(ctx, settings.ProjectID, models.ID(treatmentId))
 	if err != nil {
 		return nil, err
 	}
@@ -204,13 +214,13 @@ func (svc *treatmentService) UpdateTreatment(
 	}
 
 	// Copy current treatment's contents as treatment history
-	_, err = svc.services.TreatmentHistoryService.CreateTreatmentHistory(curTreatment)
+	_, err = svc.services.TreatmentHistoryService.CreateTreatmentHistory(ctx, curTreatment)
 	if err != nil {
 		return nil, err
 	}
 
 	// Update current treatment and save to DB
-	treatmentDBRecord, err := svc.save(&models.Treatment{
+	treatmentDBRecord, err := svc.save(ctx, &models.Treatment{
 		// Copy the ID and the fixed fields
 		ID:        curTreatment.ID,
 		ProjectID: curTreatment.ProjectID,
@@ -226,14 +236,14 @@ func (svc *treatmentService) UpdateTreatment(
 	return treatmentDBRecord, nil
 }
 
-func (svc *treatmentService) DeleteTreatment(projectId int64, treatmentId int64) error {
+func (svc *treatmentService) DeleteTreatment(ctx context.Context, projectId int64, treatmentId int64) error {
 	// Explicitly delete all treatment history first
-	err := svc.services.TreatmentHistoryService.DeleteTreatmentHistory(treatmentId)
+	err := svc.services.TreatmentHistoryService.DeleteTreatmentHistory(ctx, treatmentId)
 	if err != nil {
 		return err
 	}
 
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("project_id = ?", projectId).
 		Where("id = ?", treatmentId).
 		Unscoped().
@@ -244,9 +254,9 @@ func (svc *treatmentService) DeleteTreatment(projectId int64, treatmentId int64)
 	return nil
 }
 
-func (svc *treatmentService) GetDBRecord(projectId models.ID, treatmentId models.ID) (*models.Treatment, error) {
+func (svc *treatmentService) GetDBRecord(ctx context.Context, projectId models.ID, treatmentId models.ID) (*models.Treatment, error) {
 	var treatment models.Treatment
-	query := svc.query().
+	query := svc.query(ctx).
 		Where("project_id = ?", projectId).
 		Where("id = ?", treatmentId).
 		First(&treatment)
@@ -256,17 +266,17 @@ func (svc *treatmentService) GetDBRecord(projectId models.ID, treatmentId models
 	return &treatment, nil
 }
 
-func (svc *treatmentService) query() *gorm.DB {
-	return svc.db
+func (svc *treatmentService) query(ctx context.Context) *gorm.DB {
+	return svc.db.WithContext(ctx)
 }
 
-func (svc *treatmentService) save(treatment *models.Treatment) (*models.Treatment, error) {
-	if err := svc.query().Clauses(clause.OnConflict{
+func (svc *treatmentService) save(ctx context.Context, treatment *models.Treatment) (*models.Treatment, error) {
+	if err := svc.query(ctx).Clauses(clause.OnConflict{
 		UpdateAll: true,
 	}).Create(treatment).Error; err != nil {
 		return nil, err
 	}
-	return svc.GetDBRecord(treatment.ProjectID, treatment.ID)
+	return svc.GetDBRecord(ctx, treatment.ProjectID, treatment.ID)
 }
 
 func validateListTreatmentFieldNames(fields []models.TreatmentField) error {
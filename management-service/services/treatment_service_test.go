@@ -4,6 +4,7 @@
 package services_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -50,6 +51,7 @@ func (s *TreatmentServiceTestSuite) SetupSuite() {
 	s.TreatmentHistoryService = &mocks.TreatmentHistoryService{}
 	s.TreatmentHistoryService.On(
 		"DeleteTreatmentHistory",
+		mock.Anything,
 		int64(4),
 	).Return(nil)
 
@@ -78,7 +80,7 @@ func TestTreatmentService(t *testing.T) {
 }
 
 func (s *TreatmentServiceTestSuite) TestTreatmentServiceGetIntegration() {
-	treatmentResponse, err := s.TreatmentService.GetTreatment(1, 1)
+	treatmentResponse, err := s.TreatmentService.GetTreatment(context.Background(), 1, 1)
 	s.Suite.Require().NoError(err)
 	tu.AssertEqualValues(s.Suite.T(), s.Treatments[0], treatmentResponse)
 }
@@ -100,21 +102,21 @@ func testListTreatments(p *TreatmentServiceTestSuite) {
 	expectedPagingOpts := &pagination.Paging{Page: 1, Pages: 1, Total: 3}
 
 	// All treatments under default list params
-	treatmentResponsesList, pagingResponse, err := svc.ListTreatments(1, services.ListTreatmentsParams{})
+	treatmentResponsesList, pagingResponse, err := svc.ListTreatments(context.Background(), 1, services.ListTreatmentsParams{})
 	p.Suite.Require().NoError(err)
 	tu.AssertEqualValues(t, expectedPagingOpts, pagingResponse)
 	tu.AssertEqualValues(t, p.Treatments, treatmentResponsesList)
 
 	// No treatments filtered
 	expectedPagingOpts = &pagination.Paging{Page: 1, Pages: 0, Total: 0}
-	treatmentResponsesList, pagingResponse, err = svc.ListTreatments(2, services.ListTreatmentsParams{})
+	treatmentResponsesList, pagingResponse, err = svc.ListTreatments(context.Background(), 2, services.ListTreatmentsParams{})
 	p.Suite.Require().NoError(err)
 	tu.AssertEqualValues(t, expectedPagingOpts, pagingResponse)
 	tu.AssertEqualValues(t, []*models.Treatment{}, treatmentResponsesList)
 
 	// Filter by a single parameter
 	expectedPagingOpts = &pagination.Paging{Page: 1, Pages: 1, Total: 1}
-	treatmentResponsesList, pagingResponse, err = svc.ListTreatments(1,
+	treatmentResponsesList, pagingResponse, err = svc.ListTreatments(context.Background(), 1,
 		services.ListTreatmentsParams{Search: &testName},
 	)
 	p.Suite.Require().NoError(err)
@@ -122,7 +124,7 @@ func testListTreatments(p *TreatmentServiceTestSuite) {
 	tu.AssertEqualValues(t, []*models.Treatment{p.Treatments[0]}, treatmentResponsesList)
 
 	// Filter by all parameters
-	treatmentResponsesList, pagingResponse, err = svc.ListTreatments(1, services.ListTreatmentsParams{
+	treatmentResponsesList, pagingResponse, err = svc.ListTreatments(context.Background(), 1, services.ListTreatmentsParams{
 		Search: &testName,
 		PaginationOptions: pagination.PaginationOptions{
 			Page:     &testPage,
@@ -148,7 +150,7 @@ func testCreateUpdateDeleteTreatment(p *TreatmentServiceTestSuite) {
 	treatmentId := int64(4)
 	config := map[string]interface{}{"team": "business"}
 
-	treatmentResponse, err := svc.CreateTreatment(p.Settings, services.CreateTreatmentRequestBody{
+	treatmentResponse, err := svc.CreateTreatment(context.Background(), p.Settings, services.CreateTreatmentRequestBody{
 		Name:      "test-treatment-create",
 		Config:    config,
 		UpdatedBy: &updatedBy,
@@ -167,9 +169,9 @@ func testCreateUpdateDeleteTreatment(p *TreatmentServiceTestSuite) {
 	}, *treatmentResponse)
 
 	// Update Treatment
-	p.TreatmentHistoryService.On("CreateTreatmentHistory", treatmentResponse).Return(nil, nil)
+	p.TreatmentHistoryService.On("CreateTreatmentHistory", mock.Anything, treatmentResponse).Return(nil, nil)
 	newTreatmentConfig := models.TreatmentConfig{"team": "datascience"}
-	treatmentResponse, err = svc.UpdateTreatment(p.Settings, treatmentId, services.UpdateTreatmentRequestBody{
+	treatmentResponse, err = svc.UpdateTreatment(context.Background(), p.Settings, treatmentId, services.UpdateTreatmentRequestBody{
 		Config:    newTreatmentConfig,
 		UpdatedBy: &updatedBy,
 	})
@@ -179,7 +181,7 @@ func testCreateUpdateDeleteTreatment(p *TreatmentServiceTestSuite) {
 
 	// Delete Treatment
 	deletedTreatmentId := int64(4)
-	err = svc.DeleteTreatment(projectId, deletedTreatmentId)
+	err = svc.DeleteTreatment(context.Background(), projectId, deletedTreatmentId)
 	p.Suite.Require().NoError(err)
 }
 
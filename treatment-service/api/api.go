@@ -21,6 +21,9 @@ import (
 	"github.com/pkg/errors"
 )
 
+// BadRequest defines model for BadRequest.
+type BadRequest externalRef0.Error
+
 // FetchTreatmentBadRequest defines model for FetchTreatmentBadRequest.
 type FetchTreatmentBadRequest externalRef0.Error
 
@@ -29,19 +32,39 @@ type FetchTreatmentSuccess struct {
 	Data *externalRef0.SelectedTreatment `json:"data,omitempty"`
 }
 
+// FetchTreatmentTooManyRequests defines model for FetchTreatmentTooManyRequests.
+type FetchTreatmentTooManyRequests externalRef0.Error
+
 // InternalServerError defines model for InternalServerError.
 type InternalServerError externalRef0.Error
 
+// NotFound defines model for NotFound.
+type NotFound externalRef0.Error
+
 // FetchTreatmentRequestBody defines model for FetchTreatmentRequestBody.
 type FetchTreatmentRequestBody struct {
 	AdditionalProperties map[string]interface{} `json:"-"`
 }
 
+// LogExposureEventRequestBody defines model for LogExposureEventRequestBody.
+type LogExposureEventRequestBody externalRef0.ExposureEvent
+
+// LogExposureEventParams defines parameters for LogExposureEvent.
+type LogExposureEventParams struct {
+	PassKey string `json:"pass-key"`
+}
+
 // FetchTreatmentParams defines parameters for FetchTreatment.
 type FetchTreatmentParams struct {
 	PassKey string `json:"pass-key"`
+
+	// Id of the request, which is generated when not provided
+	XPRequestID *string `json:"XP-Request-ID,omitempty"`
 }
 
+// LogExposureEventJSONRequestBody defines body for LogExposureEvent for application/json ContentType.
+type LogExposureEventJSONRequestBody LogExposureEventRequestBody
+
 // FetchTreatmentJSONRequestBody defines body for FetchTreatment for application/json ContentType.
 type FetchTreatmentJSONRequestBody FetchTreatmentRequestBody
 
@@ -100,6 +123,9 @@ func (a FetchTreatmentRequestBody) MarshalJSON() ([]byte, error) {
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Log the exposure of a user to the treatment that was assigned in a Fetch Treatment request
+	// (POST /projects/{project_id}/exposure-events)
+	LogExposureEvent(w http.ResponseWriter, r *http.Request, projectId int64, params LogExposureEventParams)
 	// Fetch treatment for the given request parameters and project
 	// (POST /projects/{project_id}/fetch-treatment)
 	FetchTreatment(w http.ResponseWriter, r *http.Request, projectId int64, params FetchTreatmentParams)
@@ -113,6 +139,59 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
 
+// LogExposureEvent operation middleware
+func (siw *ServerInterfaceWrapper) LogExposureEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params LogExposureEventParams
+
+	headers := r.Header
+
+	// ------------- Required header parameter "pass-key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("pass-key")]; found {
+		var PassKey string
+		n := len(valueList)
+		if n != 1 {
+			http.Error(w, fmt.Sprintf("Expected one value for pass-key, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "pass-key", runtime.ParamLocationHeader, valueList[0], &PassKey)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid format for parameter pass-key: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.PassKey = PassKey
+
+	} else {
+		http.Error(w, fmt.Sprintf("Header parameter pass-key is required, but not found: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.LogExposureEvent(w, r, projectId, params)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // FetchTreatment operation middleware
 func (siw *ServerInterfaceWrapper) FetchTreatment(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -155,6 +234,25 @@ func (siw *ServerInterfaceWrapper) FetchTreatment(w http.ResponseWriter, r *http
 		return
 	}
 
+	// ------------- Optional header parameter "XP-Request-ID" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("XP-Request-ID")]; found {
+		var XPRequestID string
+		n := len(valueList)
+		if n != 1 {
+			http.Error(w, fmt.Sprintf("Expected one value for XP-Request-ID, got %d", n), http.StatusBadRequest)
+			return
+		}
+
+		err = runtime.BindStyledParameterWithLocation("simple", false, "XP-Request-ID", runtime.ParamLocationHeader, valueList[0], &XPRequestID)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Invalid format for parameter XP-Request-ID: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		params.XPRequestID = &XPRequestID
+
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
 		siw.Handler.FetchTreatment(w, r, projectId, params)
 	}
@@ -203,6 +301,9 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		HandlerMiddlewares: options.Middlewares,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/projects/{project_id}/exposure-events", wrapper.LogExposureEvent)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/projects/{project_id}/fetch-treatment", wrapper.FetchTreatment)
 	})
@@ -213,23 +314,28 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/8RWwW7jNhD9FWLao2TZsVuguiXYLeBD0aDOocAm2B2LI4kbiVRJyonX0L8XJGVJdp00",
-	"6aF7M0b0mzdv3gx5gEzVjZIkrYH0AJr+asnYG8UF+cCvZLPyThPamqT9Y/i8dx8zJS1J635i01QiQyuU",
-	"TL4aJV2MnrFuqoCDVbWhwmGQ9gFOJtOicX+AFK4l60+zngLbKr5nplRPQhbMlsSEbFrL6LkhLRwQ26EW",
-	"uK3IREzkDKuKmSEFQ02sNcRn93ItmS2FOWaIPJpGyVUtvnnK7JH2TBj/4YvSnHQs+JcZuyuJKVuSHnN5",
-	"4EzJHWlLnFl1Lz0cmYYyK3Y0JZEpmYui1cSZkB690eorZZah5KxGm5U+mintAJTkrtixxBm7lxDBDquW",
-	"nGgVWmFbTpAuZsvl1SqCSsniGJqvZvPlL4sIjhVACrjNFldLiMB+czIbgclGyAIbpQm6rovAZCXV6HvE",
-	"uXBqYHWrVUPa9h5Qkn7PIf10ALtvCFIwVgtZQBcdIFe6RgspCGl/Xrk84YiQlgrS/kwf2ipVEUroHrrh",
-	"mNo6NQIR13ihiUNqdUsh4jQxl5x4g7w34xuMOJb4o6bcFeADsz3W1Q/JOAFJiJvko9ZKB1anPr1BfjQo",
-	"RFAS8t7Of97GPZ94/eGCv1urCpKk0ZmmbQVnudKMMCuZL4wNlU3wR95nwntmp4Js2iwjY945llJ9HMz2",
-	"m7Ojk//V4QwdCdPpSngqSTKpGAbzT8YzU23F2ZaCzylU7NxeiB3JyZjcSzcNtmxN5JDsoMSAgMaIQvph",
-	"noyDE2E4fN0f+S/0Ub6Dd9+eKLDGCV1hXiAKHC0G6Y9ZPrvpXFwtV9E0KLF2bV5/iMdg/ET0yHEfL2BS",
-	"bt9nv10wFBoar1UFaY6VoQieSBSlhXQ+W3YR9OADRHztATHPRQbp1bzrzvZBc7IFjjW8cYQ2VFFmiQ8G",
-	"he7y2J82KwzDqKnTHnvl+9353Qdv7VwrsdqQ3pEOu+L/XELH/CwQYJODQubqggi3a1917go+XqejlSKw",
-	"wlauzHEZhGtx1Ob6du0MTdoEyN0CughUQxIbASksZ/OZM2iDtvR9SfpumeTQ//oseJd4CvGJjRsVlrgz",
-	"m0+75kcj3E04NqixpvB8+HQA4Vi4bDA4e8wD57fJtKX/emW5O8vDB5tNEqAx8SPtX4U/d8xDNHlV7Sf9",
-	"P2n6ycMrefnVdX4rXs3nL0P255LLN0UXwer9/55cvF0EP70F4NK8+E3T1jXq/QtT/4+Ny0YH+OfTuA4s",
-	"Fs4UkJ/CwEMEz3GmOBUk4x4ldqs/7hv6is6eoCcc/NbqClJInO8fur8DAAD//y/tQaw3CwAA",
+	"H4sIAAAAAAAC/9RY3W7bOBN9FWK+71Kyndi7QHWXoi1gYNsNmlws0ATtWBxJbGVSS1J23EDvviBFSZbt",
+	"pMmiW7R3BkXNnPk7c+R7SNW6UpKkNZDcg6a/azL2peKC/MEbsmlxrQntmqR93z/euYepkpakdT+xqkqR",
+	"ohVKTj8bJd0Z3eG6Kls7WJZXlDsbpP0BJ5NqUbkXIIELycJtFiCwleI7Zgq1FTJntiAmZFVbRncVaeEM",
+	"sQ1qgauSTMRExrAsmeldMNTEakN8ciOXktlCmM5D5K1plFytxVcPmX2hHRPGP/ikNCcdC/5pwq4LYsoW",
+	"pAdf3nCq5Ia0Jc6supHeHJmKUis2tA8iVTITea2JMyG99Uqrz5RahpKzNdq08Kep0s6AktwFO4Q4YTcS",
+	"IthgWZNLWolW2JoTJGeT+fx8EUGpZN4dzRaT2fzFWQRdBJAArtKz8zlEYL+6NBuB0yshc6yUJmiaJgKT",
+	"FrRGXyPOhcsGlpdaVaRt6AEl6c8Mkg/3YHcVQQLGaiFzaKJ7yJReo4UEhLS/LyDqrghpKSft74SjlVIl",
+	"oYTmtumvqZXLRgvEFV5o4pBYXVMTwR8qf31XKVNrer15dvcNcf1fUwZJOJjscF3+bzq0/bQ9N9ORr5OQ",
+	"/Ikrk2kT8xJ5wPSf4NFa6RbHeFheIu+mBJroYER/AkwRFIQ8zPlfl3HAEy9fHQ9+eMYEZyprZwHLknTE",
+	"lGYoGdZW5SRJo5u2uhY8YpnSjDAtmI+c9aHvARgCO2hZD32csas6TcmYZxKaVK/7MX3rBpn4cXQjWmsb",
+	"p+U1F8K2IMmkYtjSxh6xpaouOVtRyxDE/XWXm1xsSO4RzI10PGKL2kTOku0z0VtAY0QuPQ3uEYlLQn/5",
+	"Ilz5N/BRPgN3KE/UosY9uMI8ABQ4WmxT33n56Hjt7Hy+iPYPJa4JEli+iofDeEv0heMuPoO9cEOdPS9j",
+	"G6g/sFqVkGRYGopgSyIvLCSzybyJIBjvTcQX3iBmmUghOZ81zQGTViP+7GJ44oxdUUmpJd43KDSnCXNc",
+	"rHYYhpy63GPIfNg6v9pkXiv1FuUuIDE/lNCuvUawxEqxFrZLQbe+t+jkRErEiY+z+p6s3sUXmSV9nNN3",
+	"auIsGUqV5IZZxbYoLFtRpjQx7V7t1M4jGet3axP9lEVcSktaYnlFekO6zfCPLF3nn7UAWLgYwTtl36ha",
+	"8h+K5j0ZVeuUmFRuKJ17f0vITJ2g3MulL0Dmct/1wsBdEVhhS4IEhu3TKtihTBeXS8egpE1rcnPmYlcV",
+	"SawEJDCfzCaOESu0hW/ZaehqM70Pvz4K3kwp6KGYNt3nQaVaWeHYzbtdckiOdJq3rXFNrdb/cA/C4XD+",
+	"oCfTwRMc6qz9/vqmvmyiYL6dwT0HaEz8hXaPmj9s39sI9FhlhvKPaj76Spo+JlMPFeP5bHFcc0c1XbKZ",
+	"T7bnF0xTqmzYoqXK81Zzw2I2exhY8DXdk4H+lcW3X+mno4ngt6f4ODXnfg/W6zXqXdsZzO5HpzKG7rNM",
+	"O+4btTazBYa4gxJwn0z4CAlZzF13wTh1Bm4dhgd62o9VPNICp3t6vIh+4Y6ODrtt2a+CTo+xbSHSwomw",
+	"YRUEcWrdxtuIdsudBDVeQN97th7+++F4sp7QsaeF/1Nn6sEPLWfg/MVzDRyqm+83dqek4JEMZ0NH+38j",
+	"Bo3YDVY2NgO3EdzFqeKUk4yDldh9D8ShFx6plgfoAbfzU+sSEpi63XTb/DMAw/3YmIYSAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
@@ -25,6 +25,8 @@ type AppContext struct {
 	AssignedTreatmentLogger *monitoring.AssignedTreatmentLogger
 	LocalStorage            *models.LocalStorage
 	PollerService           *services.PollerService
+	RateLimitService        services.RateLimitService
+	EnrichmentService       services.EnrichmentService
 }
 
 func NewAppContext(cfg *config.Config) (*AppContext, error) {
@@ -67,23 +69,27 @@ func NewAppContext(cfg *config.Config) (*AppContext, error) {
 	if err != nil {
 		return nil, err
 	}
+	// The initial sync of the local storage precedes the metrics collector
+	localStorage.SyncStatus.PublishMetrics()
 
 	log.Println("Initializing assigned treatment logger...")
 	loggerConfig := cfg.AssignedTreatmentLogger
+	loggerOptions := monitoring.NewAssignedTreatmentLoggerOptions(loggerConfig)
 	var logger *monitoring.AssignedTreatmentLogger
 
 	switch loggerConfig.Kind {
 	case config.KafkaLogger:
-		logger, err = monitoring.NewKafkaAssignedTreatmentLogger(
-			*loggerConfig.KafkaConfig,
-			loggerConfig.QueueLength, time.Duration(loggerConfig.FlushIntervalSeconds)*time.Second)
+		logger, err = monitoring.NewKafkaAssignedTreatmentLogger(*loggerConfig.KafkaConfig, loggerOptions)
 	case config.BQLogger:
 		logger, err = monitoring.NewBQAssignedTreatmentLogger(
 			*loggerConfig.BQConfig,
-			loggerConfig.QueueLength,
-			time.Duration(loggerConfig.FlushIntervalSeconds)*time.Second,
+			loggerOptions,
 			cfg.DeploymentConfig.GoogleApplicationCredentialsEnvVar,
 		)
+	case config.FileLogger:
+		logger, err = monitoring.NewFileAssignedTreatmentLogger(*loggerConfig.FileConfig, loggerOptions)
+	case config.StdoutLogger:
+		logger, err = monitoring.NewStdoutAssignedTreatmentLogger(*loggerConfig.StdoutConfig, loggerOptions)
 	case config.NoopLogger:
 		logger, err = monitoring.NewNoopAssignedTreatmentLogger()
 	default:
@@ -101,7 +107,6 @@ func NewAppContext(cfg *config.Config) (*AppContext, error) {
 			context.Background(),
 			localStorage,
 			cfg.MessageQueueConfig,
-			cfg.GetProjectIds(),
 			cfg.DeploymentConfig.GoogleApplicationCredentialsEnvVar,
 		)
 	case common_mq_config.PubSubMQ:
@@ -112,7 +117,6 @@ func NewAppContext(cfg *config.Config) (*AppContext, error) {
 			pubsubInitContext,
 			localStorage,
 			cfg.MessageQueueConfig,
-			cfg.GetProjectIds(),
 			cfg.DeploymentConfig.GoogleApplicationCredentialsEnvVar,
 		)
 	default:
@@ -127,6 +131,24 @@ func NewAppContext(cfg *config.Config) (*AppContext, error) {
 		pollerService = services.NewPollerService(cfg.ManagementServicePollerConfig, localStorage)
 	}
 
+	var rateLimitService services.RateLimitService
+	if cfg.RateLimitConfig.Enabled {
+		log.Println("Initializing rate limit service...")
+		rateLimitService, err = services.NewRateLimitService(cfg.RateLimitConfig, metricService)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var enrichmentService services.EnrichmentService
+	if cfg.EnrichmentConfig.Enabled {
+		log.Println("Initializing enrichment service...")
+		enrichmentService, err = services.NewEnrichmentService(cfg.EnrichmentConfig, metricService)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	appContext := &AppContext{
 		ExperimentService:       experimentSvc,
 		MetricService:           metricService,
@@ -137,6 +159,8 @@ func NewAppContext(cfg *config.Config) (*AppContext, error) {
 		MessageQueueService:     messageQueueService,
 		LocalStorage:            localStorage,
 		PollerService:           pollerService,
+		RateLimitService:        rateLimitService,
+		EnrichmentService:       enrichmentService,
 	}
 
 	return appContext, nil
@@ -150,7 +150,7 @@ func SetupTest(
 	if err != nil {
 		return nil, nil, fmt.Errorf("fail to instantiate message queue")
 	}
-	store, err := service.NewInMemoryStore(make([]schema.Experiment, 0), projectSettings, queue, segmentersType)
+	store, err := service.NewInMemoryStore(make([]schema.Experiment, 0), projectSettings, nil, queue, segmentersType)
 	if err != nil {
 		return nil, nil, fmt.Errorf("fail to instantiate experiment store")
 	}
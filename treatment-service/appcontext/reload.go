@@ -0,0 +1,78 @@
+package appcontext
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+
+	"github.com/caraml-dev/xp/treatment-service/config"
+	"github.com/caraml-dev/xp/treatment-service/monitoring"
+	"github.com/caraml-dev/xp/treatment-service/services"
+)
+
+// Reload applies the changes of the reloadable fields of the config to the components of the AppContext,
+// without interrupting the requests that are being served. The segmenters and the assigned treatment logger
+// are reverted if either fails to be reloaded, while the metric labels and the poller cannot fail.
+func (appCtx *AppContext) Reload(current *config.Config, updated *config.Config) error {
+	segmenterChanged := !reflect.DeepEqual(current.SegmenterConfig, updated.SegmenterConfig)
+	if segmenterChanged {
+		if err := appCtx.SegmenterService.SetConfig(updated.SegmenterConfig); err != nil {
+			return fmt.Errorf("failed to reload the segmenter config: %s", err)
+		}
+	}
+	revertSegmenters := func() {
+		if !segmenterChanged {
+			return
+		}
+		if err := appCtx.SegmenterService.SetConfig(current.SegmenterConfig); err != nil {
+			log.Printf("Failed to revert the segmenter config: %s", err)
+		}
+	}
+
+	loggerChanged := !reflect.DeepEqual(current.AssignedTreatmentLogger, updated.AssignedTreatmentLogger)
+	if loggerChanged && appCtx.AssignedTreatmentLogger != nil {
+		publisher, err := monitoring.NewAssignedTreatmentPublisher(
+			updated.AssignedTreatmentLogger,
+			updated.DeploymentConfig.GoogleApplicationCredentialsEnvVar,
+		)
+		if err != nil {
+			revertSegmenters()
+			return fmt.Errorf("failed to create the assigned treatment log publisher: %s", err)
+		}
+		err = appCtx.AssignedTreatmentLogger.Reload(
+			publisher,
+			monitoring.NewAssignedTreatmentLoggerOptions(updated.AssignedTreatmentLogger),
+		)
+		if err != nil {
+			if closer, ok := publisher.(io.Closer); ok {
+				closer.Close()
+			}
+			revertSegmenters()
+			return fmt.Errorf("failed to reload the assigned treatment logger: %s", err)
+		}
+		log.Println("Reloaded the assigned treatment logger")
+	}
+	if segmenterChanged {
+		log.Println("Reloaded the segmenter config")
+	}
+
+	if !reflect.DeepEqual(current.MonitoringConfig.MetricLabels, updated.MonitoringConfig.MetricLabels) {
+		appCtx.MetricService.SetMetricLabels(updated.MonitoringConfig.MetricLabels)
+		log.Println("Reloaded the metric labels")
+	}
+
+	if !reflect.DeepEqual(current.ManagementServicePollerConfig, updated.ManagementServicePollerConfig) {
+		if appCtx.PollerService != nil {
+			appCtx.PollerService.Stop()
+			appCtx.PollerService = nil
+		}
+		if updated.ManagementServicePollerConfig.Enabled {
+			appCtx.PollerService = services.NewPollerService(updated.ManagementServicePollerConfig, appCtx.LocalStorage)
+			appCtx.PollerService.Start()
+		}
+		log.Println("Reloaded the management service poller")
+	}
+
+	return nil
+}
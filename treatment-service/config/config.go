@@ -8,15 +8,38 @@ import (
 	"github.com/caraml-dev/mlp/api/pkg/instrumentation/sentry"
 	common_config "github.com/caraml-dev/xp/common/config"
 	common_mq_config "github.com/caraml-dev/xp/common/messagequeue"
+	"github.com/caraml-dev/xp/common/tracing"
 	"github.com/caraml-dev/xp/treatment-service/models"
 )
 
 type AssignedTreatmentLoggerKind = string
 
 const (
-	KafkaLogger AssignedTreatmentLoggerKind = "kafka"
-	BQLogger    AssignedTreatmentLoggerKind = "bq"
-	NoopLogger  AssignedTreatmentLoggerKind = ""
+	KafkaLogger  AssignedTreatmentLoggerKind = "kafka"
+	BQLogger     AssignedTreatmentLoggerKind = "bq"
+	FileLogger   AssignedTreatmentLoggerKind = "file"
+	StdoutLogger AssignedTreatmentLoggerKind = "stdout"
+	NoopLogger   AssignedTreatmentLoggerKind = ""
+)
+
+type QueueFullPolicy = string
+
+const (
+	// BlockPolicy waits for space in the queue, delaying the request
+	BlockPolicy QueueFullPolicy = "block"
+	// DropNewestPolicy discards the log that is being added
+	DropNewestPolicy QueueFullPolicy = "drop_newest"
+	// DropOldestPolicy discards the oldest log in the queue to make space for the new one
+	DropOldestPolicy QueueFullPolicy = "drop_oldest"
+)
+
+type LogFormat = string
+
+const (
+	// JSONLogFormat writes each log as a line of JSON
+	JSONLogFormat LogFormat = "json"
+	// ProtobufLogFormat writes each log as a size-delimited TreatmentServiceResultLogMessage
+	ProtobufLogFormat LogFormat = "protobuf"
 )
 
 type Config struct {
@@ -27,6 +50,7 @@ type Config struct {
 	DebugConfig                   DebugConfig                         `json:"debug_config" validate:"required,dive"`
 	NewRelicConfig                newrelic.Config                     `json:"new_relic_config"`
 	SentryConfig                  sentry.Config                       `json:"sentry_config"`
+	TracingConfig                 tracing.Config                      `json:"tracing_config"`
 	DeploymentConfig              DeploymentConfig                    `json:"deployment_config" validate:"required,dive"`
 	MessageQueueConfig            common_mq_config.MessageQueueConfig `json:"message_queue_config" validate:"required,dive"`
 	ManagementService             ManagementServiceConfig             `json:"management_service" validate:"required,dive"`
@@ -34,15 +58,40 @@ type Config struct {
 	SwaggerConfig                 SwaggerConfig                       `json:"swagger_config" validate:"required,dive"`
 	SegmenterConfig               map[string]interface{}              `json:"segmenter_config"`
 	ManagementServicePollerConfig ManagementServicePollerConfig       `json:"management_service_poller_config" validate:"required,dive"`
+	RateLimitConfig               RateLimitConfig                     `json:"rate_limit_config"`
+	EnrichmentConfig              EnrichmentConfig                    `json:"enrichment_config"`
+	HotReloadConfig               HotReloadConfig                     `json:"hot_reload_config"`
 }
 
 type AssignedTreatmentLoggerConfig struct {
 	Kind                 AssignedTreatmentLoggerKind `json:"kind" default:""`
 	QueueLength          int                         `json:"queue_length" default:"100"`
 	FlushIntervalSeconds int                         `json:"flush_interval_seconds" default:"1"`
+	// QueueFullPolicy determines what happens to a new log when the queue is full
+	QueueFullPolicy QueueFullPolicy `json:"queue_full_policy" default:"block"`
+	// MaxPublishRetries is the no. of times a failed batch is retried before its logs are dropped
+	MaxPublishRetries      int `json:"max_publish_retries" default:"3"`
+	PublishRetryBackoffMS  int `json:"publish_retry_backoff_ms" default:"100"`
+	PublishMaxBackoffMS    int `json:"publish_max_backoff_ms" default:"5000"`
+	ShutdownTimeoutSeconds int `json:"shutdown_timeout_seconds" default:"10"`
 
-	BQConfig    *BigqueryConfig `json:"bq_config"`
-	KafkaConfig *KafkaConfig    `json:"kafka_config"`
+	SamplingConfig LogSamplingConfig `json:"sampling_config"`
+
+	BQConfig     *BigqueryConfig     `json:"bq_config"`
+	KafkaConfig  *KafkaConfig        `json:"kafka_config"`
+	FileConfig   *FileLoggerConfig   `json:"file_config"`
+	StdoutConfig *StdoutLoggerConfig `json:"stdout_config"`
+}
+
+// LogSamplingConfig captures the fraction of the requests whose assigned treatments are logged.
+// The rate of an experiment takes precedence over the rate of its project, which takes precedence
+// over the default rate.
+type LogSamplingConfig struct {
+	DefaultRate float64 `json:"default_rate" default:"1"`
+	// ProjectRates is a map of project ids to their sampling rates
+	ProjectRates map[string]float64 `json:"project_rates"`
+	// ExperimentRates is a map of experiment ids to their sampling rates
+	ExperimentRates map[string]float64 `json:"experiment_rates"`
 }
 
 type BigqueryConfig struct {
@@ -59,6 +108,21 @@ type KafkaConfig struct {
 	ConnectTimeoutMS int    `json:"connect_timeout_ms" default:"1000"`
 }
 
+// FileLoggerConfig captures the config for writing the assigned treatment logs to a local file,
+// which is rotated when it exceeds the max size or is older than the rotation interval.
+// Rotated files are renamed with the rotation timestamp as the suffix.
+type FileLoggerConfig struct {
+	Path                    string    `json:"path" default:"/tmp/xp-assigned-treatments.log"`
+	Format                  LogFormat `json:"format" default:"json"`
+	MaxSizeMB               int       `json:"max_size_mb" default:"100"`
+	RotationIntervalSeconds int       `json:"rotation_interval_seconds" default:"3600"`
+	Compress                bool      `json:"compress" default:"false"`
+}
+
+type StdoutLoggerConfig struct {
+	Format LogFormat `json:"format" default:"json"`
+}
+
 type DebugConfig struct {
 	OutputPath string `json:"output_path" default:"/tmp" validate:"required"`
 }
@@ -74,6 +138,10 @@ type DeploymentConfig struct {
 	EnvironmentType                    string `json:"environment_type" default:"local" validate:"required"`
 	MaxGoRoutines                      int    `json:"max_go_routines" default:"100" validate:"required"`
 	GoogleApplicationCredentialsEnvVar string `json:"google_application_credentials_env_var"`
+	// MaxSyncStalenessSeconds is the max time since the last successful poll or message queue update, beyond
+	// which the service is not ready. It should exceed the poll interval, or the expected gap between updates
+	// when only the message queue is used. Set to 0 to disable the check.
+	MaxSyncStalenessSeconds int `json:"max_sync_staleness_seconds" default:"0"`
 }
 
 type MetricSinkKind = string
@@ -99,6 +167,82 @@ type ManagementServicePollerConfig struct {
 	PollIntervalSeconds int  `json:"poll_interval" default:"30"`
 }
 
+// RateLimitConfig captures the per-project limits on the rate of Fetch Treatment requests. The limit of
+// a project takes precedence over the default limit. In shadow mode, the requests exceeding the limits
+// are only counted, and not rejected.
+type RateLimitConfig struct {
+	Enabled      bool      `json:"enabled" default:"false"`
+	ShadowMode   bool      `json:"shadow_mode" default:"false"`
+	DefaultLimit RateLimit `json:"default_limit"`
+	// ProjectLimits is a map of project ids to their rate limits
+	ProjectLimits map[string]RateLimit `json:"project_limits"`
+}
+
+// RateLimit is a token bucket that is refilled at RequestsPerSecond, up to Burst tokens
+type RateLimit struct {
+	// RequestsPerSecond is the sustained rate of requests. Set to 0 for no limit.
+	RequestsPerSecond float64 `json:"requests_per_second" default:"0"`
+	// Burst is the max no. of requests that can be made at once. Defaults to the rate of requests
+	// (rounded up), when not set.
+	Burst int `json:"burst" default:"0"`
+}
+
+// EnrichmentConfig captures the lookups of the variables that are not sent in the Fetch Treatment requests, such
+// as the tier or country of a user, from a key in the requests. The looked up variables are merged into the request
+// values passed to the segmenters, and never replace the values sent by the clients. The settings of a project take
+// precedence over the default settings.
+type EnrichmentConfig struct {
+	Enabled         bool               `json:"enabled" default:"false"`
+	Lookups         []EnrichmentLookup `json:"lookups"`
+	DefaultSettings EnrichmentSettings `json:"default_settings"`
+	// ProjectSettings is a map of project ids to their enrichment settings
+	ProjectSettings map[string]EnrichmentSettings `json:"project_settings"`
+}
+
+type EnrichmentLookupKind string
+
+const (
+	// FileEnrichmentLookup looks up the variables in a local JSON file, of keys to objects of variables
+	FileEnrichmentLookup EnrichmentLookupKind = "file"
+	// HTTPEnrichmentLookup looks up the variables with a GET request to an enrichment service, which responds
+	// with an object of variables, or a 404 status code for unknown keys
+	HTTPEnrichmentLookup EnrichmentLookupKind = "http"
+)
+
+type EnrichmentLookup struct {
+	Kind EnrichmentLookupKind `json:"kind"`
+	// KeyField is the request field whose value is looked up, eg. user_id
+	KeyField string `json:"key_field"`
+	// Fields are the looked up variables merged into the requests. All variables are merged when empty.
+	Fields []string `json:"fields"`
+	// ProjectIds are the projects whose requests are enriched. The requests of all projects are enriched when empty.
+	ProjectIds []string `json:"project_ids"`
+	// Path is the path of the file of a file lookup
+	Path string `json:"path"`
+	// URL is the url of the enrichment service of a http lookup, in which {key} is replaced by the looked up value
+	URL string `json:"url"`
+	// Headers are added to the requests to the enrichment service
+	Headers map[string]string `json:"headers"`
+}
+
+// EnrichmentSettings limit the time spent on the lookups of a request. The lookups exceeding the timeout or failing
+// are skipped, and the segmenters that need the missing variables are weakly matched.
+type EnrichmentSettings struct {
+	TimeoutMS int `json:"timeout_ms" default:"50"`
+	// CacheTTLSeconds is the duration for which looked up variables are cached. Set to 0 to disable the cache.
+	CacheTTLSeconds int `json:"cache_ttl_seconds" default:"60"`
+	// MaxCacheSize is the max no. of cached keys of each lookup
+	MaxCacheSize int `json:"max_cache_size" default:"10000"`
+}
+
+// HotReloadConfig captures the config for watching the config files, to apply the changes without a restart.
+// Only the SegmenterConfig, the metric labels, the poller config and the assigned treatment logger config can be
+// reloaded, except for the queue length of the logger and switching the logger to or from the noop kind.
+type HotReloadConfig struct {
+	Enabled             bool `json:"enabled" default:"false"`
+	PollIntervalSeconds int  `json:"poll_interval_seconds" default:"10"`
+}
+
 func (c *Config) GetProjectIds() []models.ProjectId {
 	projectIds := make([]models.ProjectId, 0)
 	for _, projectIdString := range c.ProjectIds {
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	common_mq_config "github.com/caraml-dev/xp/common/messagequeue"
+	"github.com/caraml-dev/xp/common/tracing"
 )
 
 func TestDefaultConfigs(t *testing.T) {
@@ -31,10 +32,20 @@ func TestDefaultConfigs(t *testing.T) {
 			MaxGoRoutines:   100,
 		},
 		AssignedTreatmentLogger: AssignedTreatmentLoggerConfig{
-			Kind:                 "",
-			QueueLength:          100,
-			FlushIntervalSeconds: 1,
-			BQConfig:             &BigqueryConfig{},
+			Kind:                   "",
+			QueueLength:            100,
+			FlushIntervalSeconds:   1,
+			QueueFullPolicy:        "block",
+			MaxPublishRetries:      3,
+			PublishRetryBackoffMS:  100,
+			PublishMaxBackoffMS:    5000,
+			ShutdownTimeoutSeconds: 10,
+			SamplingConfig: LogSamplingConfig{
+				DefaultRate:     1,
+				ProjectRates:    map[string]float64{},
+				ExperimentRates: map[string]float64{},
+			},
+			BQConfig: &BigqueryConfig{},
 			KafkaConfig: &KafkaConfig{
 				Brokers:          "",
 				Topic:            "",
@@ -42,6 +53,13 @@ func TestDefaultConfigs(t *testing.T) {
 				CompressionType:  "none",
 				ConnectTimeoutMS: 1000,
 			},
+			FileConfig: &FileLoggerConfig{
+				Path:                    "/tmp/xp-assigned-treatments.log",
+				Format:                  "json",
+				MaxSizeMB:               100,
+				RotationIntervalSeconds: 3600,
+			},
+			StdoutConfig: &StdoutLoggerConfig{Format: "json"},
 		},
 		DebugConfig: DebugConfig{
 			OutputPath: "/tmp",
@@ -62,12 +80,31 @@ func TestDefaultConfigs(t *testing.T) {
 			IgnoreStatusCodes: []int{},
 			Labels:            emptyInterfaceMap,
 		},
-		SentryConfig:    sentry.Config{Enabled: false, Labels: emptyStringMap},
+		SentryConfig: sentry.Config{Enabled: false, Labels: emptyStringMap},
+		TracingConfig: tracing.Config{
+			Enabled:     false,
+			Endpoint:    "localhost:4317",
+			Protocol:    "grpc",
+			Insecure:    true,
+			SampleRatio: 1,
+		},
 		SegmenterConfig: make(map[string]interface{}),
 		ManagementServicePollerConfig: ManagementServicePollerConfig{
 			Enabled:             false,
 			PollIntervalSeconds: 30,
 		},
+		RateLimitConfig: RateLimitConfig{
+			ProjectLimits: map[string]RateLimit{},
+		},
+		EnrichmentConfig: EnrichmentConfig{
+			Lookups:         []EnrichmentLookup{},
+			DefaultSettings: EnrichmentSettings{TimeoutMS: 50, CacheTTLSeconds: 60, MaxCacheSize: 10000},
+			ProjectSettings: map[string]EnrichmentSettings{},
+		},
+		HotReloadConfig: HotReloadConfig{
+			Enabled:             false,
+			PollIntervalSeconds: 10,
+		},
 	}
 	cfg, err := Load()
 	require.NoError(t, err)
@@ -94,11 +131,22 @@ func TestLoadMultipleConfigs(t *testing.T) {
 			EnvironmentType:                    "dev",
 			MaxGoRoutines:                      200,
 			GoogleApplicationCredentialsEnvVar: "GOOGLE_APPLICATION_CREDENTIALS_EXPERIMENT_ENGINE",
+			MaxSyncStalenessSeconds:            300,
 		},
 		AssignedTreatmentLogger: AssignedTreatmentLoggerConfig{
-			Kind:                 "bq",
-			QueueLength:          100,
-			FlushIntervalSeconds: 1,
+			Kind:                   "bq",
+			QueueLength:            100,
+			FlushIntervalSeconds:   1,
+			QueueFullPolicy:        "block",
+			MaxPublishRetries:      3,
+			PublishRetryBackoffMS:  100,
+			PublishMaxBackoffMS:    5000,
+			ShutdownTimeoutSeconds: 10,
+			SamplingConfig: LogSamplingConfig{
+				DefaultRate:     0.5,
+				ProjectRates:    map[string]float64{"1": 0.1},
+				ExperimentRates: map[string]float64{"10": 1},
+			},
 			BQConfig: &BigqueryConfig{
 				Project: "dev",
 				Dataset: "xp-test-dataset",
@@ -109,6 +157,13 @@ func TestLoadMultipleConfigs(t *testing.T) {
 				CompressionType:  "none",
 				ConnectTimeoutMS: 1000,
 			},
+			FileConfig: &FileLoggerConfig{
+				Path:                    "/tmp/xp-assigned-treatments.log",
+				Format:                  "json",
+				MaxSizeMB:               100,
+				RotationIntervalSeconds: 3600,
+			},
+			StdoutConfig: &StdoutLoggerConfig{Format: "json"},
 		},
 		DebugConfig: DebugConfig{
 			OutputPath: "/tmp1",
@@ -129,12 +184,42 @@ func TestLoadMultipleConfigs(t *testing.T) {
 			IgnoreStatusCodes: []int{403, 404, 405},
 			Labels:            map[string]interface{}{"env": "dev"},
 		},
-		SentryConfig:    sentry.Config{Enabled: true, DSN: "my.amazing.sentry.dsn", Labels: map[string]string{"app": "xp-treatment-service"}},
+		SentryConfig: sentry.Config{Enabled: true, DSN: "my.amazing.sentry.dsn", Labels: map[string]string{"app": "xp-treatment-service"}},
+		TracingConfig: tracing.Config{
+			Enabled:     true,
+			Endpoint:    "otel-collector:4318",
+			Protocol:    "http",
+			Insecure:    true,
+			SampleRatio: 0.1,
+		},
 		SegmenterConfig: map[string]interface{}{"s2_ids": map[string]interface{}{"mins2celllevel": 9, "maxs2celllevel": 15}},
 		ManagementServicePollerConfig: ManagementServicePollerConfig{
 			Enabled:             false,
 			PollIntervalSeconds: 30,
 		},
+		RateLimitConfig: RateLimitConfig{
+			Enabled:      true,
+			ShadowMode:   true,
+			DefaultLimit: RateLimit{RequestsPerSecond: 100},
+			ProjectLimits: map[string]RateLimit{
+				"1": {RequestsPerSecond: 10, Burst: 20},
+			},
+		},
+		EnrichmentConfig: EnrichmentConfig{
+			Enabled: true,
+			Lookups: []EnrichmentLookup{{
+				Kind:     HTTPEnrichmentLookup,
+				KeyField: "user_id",
+				Fields:   []string{"tier"},
+				URL:      "http://profiles/users/{key}",
+			}},
+			DefaultSettings: EnrichmentSettings{TimeoutMS: 50, CacheTTLSeconds: 60, MaxCacheSize: 10000},
+			ProjectSettings: map[string]EnrichmentSettings{"1": {TimeoutMS: 20}},
+		},
+		HotReloadConfig: HotReloadConfig{
+			Enabled:             true,
+			PollIntervalSeconds: 5,
+		},
 	}
 
 	cfg, err := Load(configFiles...)
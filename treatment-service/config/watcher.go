@@ -0,0 +1,147 @@
+package config
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// reloadableFields are the fields of the Config that can be changed without a restart. The changes to
+// the other fields, and to the parts of these fields listed in NonReloadableChanges, are rejected.
+var reloadableFields = map[string]bool{
+	"AssignedTreatmentLogger":       true,
+	"MonitoringConfig":              true,
+	"SegmenterConfig":               true,
+	"ManagementServicePollerConfig": true,
+}
+
+// NonReloadableChanges returns the names of the fields that differ between the configs, and cannot be reloaded
+func NonReloadableChanges(current *Config, updated *Config) []string {
+	changes := []string{}
+
+	currentValue := reflect.ValueOf(*current)
+	updatedValue := reflect.ValueOf(*updated)
+	for i := 0; i < currentValue.NumField(); i++ {
+		name := currentValue.Type().Field(i).Name
+		if reloadableFields[name] {
+			continue
+		}
+		if !reflect.DeepEqual(currentValue.Field(i).Interface(), updatedValue.Field(i).Interface()) {
+			changes = append(changes, name)
+		}
+	}
+
+	// The metrics collector and the queue of the logs are created once
+	if current.MonitoringConfig.Kind != updated.MonitoringConfig.Kind {
+		changes = append(changes, "MonitoringConfig.Kind")
+	}
+	if (current.AssignedTreatmentLogger.Kind == NoopLogger) != (updated.AssignedTreatmentLogger.Kind == NoopLogger) {
+		changes = append(changes, "AssignedTreatmentLogger.Kind")
+	}
+	if current.AssignedTreatmentLogger.QueueLength != updated.AssignedTreatmentLogger.QueueLength {
+		changes = append(changes, "AssignedTreatmentLogger.QueueLength")
+	}
+	return changes
+}
+
+// Watcher polls the config files and loads the config when their content changes. Updates that only change
+// the reloadable fields are passed to the onChange function, while the other updates are rejected.
+type Watcher struct {
+	filepaths []string
+	interval  time.Duration
+	onChange  func(current *Config, updated *Config) error
+
+	current     *Config
+	checksum    string
+	stopChannel chan struct{}
+}
+
+func NewWatcher(
+	filepaths []string,
+	interval time.Duration,
+	onChange func(current *Config, updated *Config) error,
+) (*Watcher, error) {
+	checksum, err := checksumFiles(filepaths)
+	if err != nil {
+		return nil, err
+	}
+	current, err := Load(filepaths...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Watcher{
+		filepaths:   filepaths,
+		interval:    interval,
+		onChange:    onChange,
+		current:     current,
+		checksum:    checksum,
+		stopChannel: make(chan struct{}),
+	}, nil
+}
+
+func (w *Watcher) Start() {
+	log.Printf("Watching the config files %s for changes...", strings.Join(w.filepaths, ", "))
+	ticker := time.NewTicker(w.interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				w.Check()
+			case <-w.stopChannel:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+func (w *Watcher) Stop() {
+	close(w.stopChannel)
+}
+
+// Check loads the config files, if they have changed since the last check, and applies the update
+func (w *Watcher) Check() {
+	checksum, err := checksumFiles(w.filepaths)
+	if err != nil {
+		log.Printf("Failed to read the config files: %s", err)
+		return
+	}
+	if checksum == w.checksum {
+		return
+	}
+	w.checksum = checksum
+
+	updated, err := Load(w.filepaths...)
+	if err != nil {
+		log.Printf("Rejected the config update: %s", err)
+		return
+	}
+	if changes := NonReloadableChanges(w.current, updated); len(changes) > 0 {
+		log.Printf("Rejected the config update, since these fields cannot be changed without a restart: %s",
+			strings.Join(changes, ", "))
+		return
+	}
+	if err := w.onChange(w.current, updated); err != nil {
+		log.Printf("Rejected the config update, since it could not be applied: %s", err)
+		return
+	}
+	w.current = updated
+	log.Println("Reloaded the config")
+}
+
+func checksumFiles(filepaths []string) (string, error) {
+	hash := sha256.New()
+	for _, filepath := range filepaths {
+		content, err := os.ReadFile(filepath)
+		if err != nil {
+			return "", err
+		}
+		hash.Write(content)
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonReloadableChanges(t *testing.T) {
+	tests := map[string]struct {
+		update   func(cfg *Config)
+		expected []string
+	}{
+		"reloadable fields": {
+			update: func(cfg *Config) {
+				cfg.SegmenterConfig = map[string]interface{}{"s2_ids": map[string]interface{}{"mins2celllevel": 10}}
+				cfg.MonitoringConfig.MetricLabels = []string{"country"}
+				cfg.ManagementServicePollerConfig.PollIntervalSeconds = 60
+				cfg.AssignedTreatmentLogger.Kind = FileLogger
+				cfg.AssignedTreatmentLogger.FlushIntervalSeconds = 5
+			},
+			expected: []string{},
+		},
+		"non-reloadable fields": {
+			update: func(cfg *Config) {
+				cfg.Port = 9090
+				cfg.ProjectIds = []string{"1"}
+				cfg.MonitoringConfig.Kind = NoopMetricSink
+				cfg.AssignedTreatmentLogger.QueueLength = 10
+			},
+			expected: []string{
+				"Port", "ProjectIds", "MonitoringConfig.Kind", "AssignedTreatmentLogger.QueueLength",
+			},
+		},
+		"switch to the noop logger": {
+			update: func(cfg *Config) {
+				cfg.AssignedTreatmentLogger.Kind = NoopLogger
+			},
+			expected: []string{"AssignedTreatmentLogger.Kind"},
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			current := Config{
+				Port:             8080,
+				MonitoringConfig: Monitoring{Kind: PrometheusMetricSink},
+				AssignedTreatmentLogger: AssignedTreatmentLoggerConfig{
+					Kind:        StdoutLogger,
+					QueueLength: 100,
+				},
+			}
+			updated := current
+			data.update(&updated)
+
+			assert.Equal(t, data.expected, NonReloadableChanges(&current, &updated))
+		})
+	}
+}
+
+func TestWatcherCheck(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(content string) {
+		require.NoError(t, os.WriteFile(configFile, []byte(content), 0600))
+	}
+	writeConfig("Port: 8080\nManagementServicePollerConfig:\n  PollIntervalSeconds: 30\n")
+
+	var updates []*Config
+	var onChangeErr error
+	watcher, err := NewWatcher([]string{configFile}, time.Hour, func(current *Config, updated *Config) error {
+		if onChangeErr != nil {
+			return onChangeErr
+		}
+		updates = append(updates, updated)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The config is not reloaded if the files have not changed
+	watcher.Check()
+	assert.Empty(t, updates)
+
+	// The reloadable changes are applied
+	writeConfig("Port: 8080\nManagementServicePollerConfig:\n  PollIntervalSeconds: 60\n")
+	watcher.Check()
+	require.Len(t, updates, 1)
+	assert.Equal(t, 60, updates[0].ManagementServicePollerConfig.PollIntervalSeconds)
+
+	// The non-reloadable changes are rejected
+	writeConfig("Port: 9090\nManagementServicePollerConfig:\n  PollIntervalSeconds: 90\n")
+	watcher.Check()
+	assert.Len(t, updates, 1)
+
+	// The updates that fail to be applied are rejected, and the current config is kept
+	onChangeErr = errors.New("reload error")
+	writeConfig("Port: 8080\nManagementServicePollerConfig:\n  PollIntervalSeconds: 90\n")
+	watcher.Check()
+	assert.Len(t, updates, 1)
+	assert.Equal(t, 60, watcher.current.ManagementServicePollerConfig.PollIntervalSeconds)
+}
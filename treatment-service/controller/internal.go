@@ -1,13 +1,20 @@
 package controller
 
 import (
+	"fmt"
 	"net/http"
 	_ "net/http/pprof"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/heptiolabs/healthcheck"
 
+	common_mq_config "github.com/caraml-dev/xp/common/messagequeue"
 	"github.com/caraml-dev/xp/treatment-service/appcontext"
 	"github.com/caraml-dev/xp/treatment-service/config"
+	"github.com/caraml-dev/xp/treatment-service/models"
 )
 
 type InternalController struct {
@@ -19,10 +26,37 @@ type InternalController struct {
 func NewInternalController(ctx *appcontext.AppContext, cfg *config.Config) *InternalController {
 	healthCheckHandler := healthcheck.NewHandler()
 	healthCheckHandler.AddLivenessCheck("goroutine-threshold", healthcheck.GoroutineCountCheck(cfg.DeploymentConfig.MaxGoRoutines))
+	// The local storage must be in sync with the Management Service, to serve the current experiments
+	syncStatus := &ctx.LocalStorage.SyncStatus
+	healthCheckHandler.AddReadinessCheck("initial-sync", syncStatus.CheckInitialSync)
+	if cfg.DeploymentConfig.MaxSyncStalenessSeconds > 0 {
+		maxStaleness := time.Duration(cfg.DeploymentConfig.MaxSyncStalenessSeconds) * time.Second
+		healthCheckHandler.AddReadinessCheck("sync-staleness", func() error {
+			return syncStatus.CheckStaleness(maxStaleness)
+		})
+	}
+	if cfg.MessageQueueConfig.Kind != common_mq_config.NoopMQ {
+		healthCheckHandler.AddReadinessCheck("subscriber", syncStatus.CheckSubscriber)
+	}
 
 	mux := http.NewServeMux()
 	mux.Handle("/health/", http.StripPrefix("/health", healthCheckHandler))
 	mux.Handle("/debug/dump", NewCacheDumpHandler(ctx, cfg))
+	mux.Handle("/debug/cache", NewCacheHandler(ctx.LocalStorage, func(snapshot models.CacheSnapshot) interface{} {
+		return snapshot
+	}))
+	mux.Handle("/debug/cache/project-settings", NewCacheHandler(ctx.LocalStorage, func(snapshot models.CacheSnapshot) interface{} {
+		return snapshot.ProjectSettings
+	}))
+	mux.Handle("/debug/cache/segmenters", NewCacheHandler(ctx.LocalStorage, func(snapshot models.CacheSnapshot) interface{} {
+		return snapshot.Segmenters
+	}))
+	mux.Handle("/debug/cache/experiments", NewCacheHandler(ctx.LocalStorage, func(snapshot models.CacheSnapshot) interface{} {
+		return snapshot.Experiments
+	}))
+	subscriptionHandler := NewSubscriptionHandler(ctx.LocalStorage)
+	mux.Handle("/admin/subscriptions", subscriptionHandler)
+	mux.Handle("/admin/subscriptions/", subscriptionHandler)
 	// For profiling. net/http/pprof will register itself to http.DefaultServeMux.
 	mux.Handle("/debug/pprof/", http.DefaultServeMux)
 	return &InternalController{Handler: mux, AppContext: ctx, Config: cfg}
@@ -45,3 +79,110 @@ func (h *debugHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	Ok(w, map[string]interface{}{"filepath": filepath}, nil)
 }
+
+// CacheResponse is the data in the local storage, with the version of the cache at the time it was read
+type CacheResponse struct {
+	Version     uint64      `json:"version"`
+	LastUpdated time.Time   `json:"last_updated"`
+	Data        interface{} `json:"data"`
+}
+
+type cacheHandler struct {
+	localStorage *models.LocalStorage
+	selectData   func(models.CacheSnapshot) interface{}
+}
+
+// NewCacheHandler creates a handler that responds with the data in the local storage, selected from a snapshot
+// of the cache. The project_id and experiment_id query parameters filter the data.
+func NewCacheHandler(localStorage *models.LocalStorage, selectData func(models.CacheSnapshot) interface{}) http.Handler {
+	return &cacheHandler{localStorage: localStorage, selectData: selectData}
+}
+
+func (h *cacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	filter, err := parseCacheFilter(r.URL.Query())
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err, nil)
+		return
+	}
+	snapshot := h.localStorage.Snapshot(filter)
+	Ok(w, CacheResponse{
+		Version:     snapshot.Version,
+		LastUpdated: snapshot.LastUpdated,
+		Data:        h.selectData(snapshot),
+	}, nil)
+}
+
+func parseCacheFilter(query url.Values) (models.CacheFilter, error) {
+	filter := models.CacheFilter{}
+	if value := query.Get("project_id"); value != "" {
+		projectId, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return filter, fmt.Errorf("invalid project_id: %s", value)
+		}
+		id := models.ProjectId(projectId)
+		filter.ProjectId = &id
+	}
+	if value := query.Get("experiment_id"); value != "" {
+		experimentId, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return filter, fmt.Errorf("invalid experiment_id: %s", value)
+		}
+		filter.ExperimentId = &experimentId
+	}
+	return filter, nil
+}
+
+// SubscriptionsResponse is the list of projects whose data is stored by the Treatment Service
+type SubscriptionsResponse struct {
+	AllProjects bool               `json:"all_projects"`
+	ProjectIds  []models.ProjectId `json:"project_ids"`
+}
+
+type subscriptionHandler struct {
+	localStorage *models.LocalStorage
+}
+
+// NewSubscriptionHandler creates a handler that manages the subscribed projects at runtime. GET lists the subscribed
+// projects, while PUT and DELETE on /admin/subscriptions/{project_id} subscribe to and unsubscribe from a project.
+func NewSubscriptionHandler(localStorage *models.LocalStorage) http.Handler {
+	return &subscriptionHandler{localStorage: localStorage}
+}
+
+func (h *subscriptionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/admin/subscriptions"), "/")
+	if path == "" {
+		if r.Method != http.MethodGet {
+			ErrorResponse(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method), nil)
+			return
+		}
+		h.writeSubscriptions(w)
+		return
+	}
+
+	projectId, err := strconv.ParseUint(path, 10, 32)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, fmt.Errorf("invalid project_id: %s", path), nil)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		if err := h.localStorage.SubscribeProject(models.ProjectId(projectId)); err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err, nil)
+			return
+		}
+	case http.MethodDelete:
+		if err := h.localStorage.UnsubscribeProject(models.ProjectId(projectId)); err != nil {
+			ErrorResponse(w, http.StatusBadRequest, err, nil)
+			return
+		}
+	default:
+		ErrorResponse(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method), nil)
+		return
+	}
+	h.writeSubscriptions(w)
+}
+
+func (h *subscriptionHandler) writeSubscriptions(w http.ResponseWriter) {
+	projectIds := h.localStorage.SubscribedProjectIds()
+	Ok(w, SubscriptionsResponse{AllProjects: len(projectIds) == 0, ProjectIds: projectIds}, nil)
+}
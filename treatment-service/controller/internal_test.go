@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	_pubsub "github.com/caraml-dev/xp/common/pubsub"
+	"github.com/caraml-dev/xp/treatment-service/models"
+)
+
+func TestCacheHandler(t *testing.T) {
+	storage := &models.LocalStorage{
+		Experiments: map[models.ProjectId][]*models.ExperimentIndex{},
+		ProjectSettings: []*_pubsub.ProjectSettings{
+			{
+				ProjectId: 1,
+				Passkey:   "legacy-secret",
+				Passkeys:  []*_pubsub.ProjectPasskey{{Name: "default", Hash: "secret-1"}},
+			},
+			{ProjectId: 2, Passkeys: []*_pubsub.ProjectPasskey{{Name: "default", Hash: "secret-2"}}},
+		},
+		ProjectSegmenters: map[models.ProjectId]map[string]schema.SegmenterType{
+			1: {"string_segmenter": "string"},
+		},
+	}
+	storage.UpdateProjectSettings(&_pubsub.ProjectSettings{
+		ProjectId: 2,
+		Passkeys:  []*_pubsub.ProjectPasskey{{Name: "default", Hash: "secret-3"}},
+	})
+	handler := NewCacheHandler(storage, func(snapshot models.CacheSnapshot) interface{} {
+		return snapshot.ProjectSettings
+	})
+
+	tests := map[string]struct {
+		query          string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"success | all projects": {
+			expectedStatus: http.StatusOK,
+			expectedBody: `[
+				{"project_id":1,"passkeys":[{"name":"default","hash":"<redacted>"}]},
+				{"project_id":2,"passkeys":[{"name":"default","hash":"<redacted>"}]}
+			]`,
+		},
+		"success | filter by project": {
+			query:          "?project_id=2",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"project_id":2,"passkeys":[{"name":"default","hash":"<redacted>"}]}]`,
+		},
+		"failure | invalid project id": {
+			query:          "?project_id=abc",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"failure | invalid experiment id": {
+			query:          "?experiment_id=1.5",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/debug/cache/project-settings"+data.query, nil))
+			resp := w.Result()
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			require.Equal(t, data.expectedStatus, resp.StatusCode)
+			if data.expectedStatus != http.StatusOK {
+				return
+			}
+			var cacheResp struct {
+				Version     uint64          `json:"version"`
+				LastUpdated string          `json:"last_updated"`
+				Data        json.RawMessage `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(body, &cacheResp))
+			assert.Equal(t, uint64(1), cacheResp.Version)
+			assert.NotEmpty(t, cacheResp.LastUpdated)
+			assert.JSONEq(t, data.expectedBody, string(cacheResp.Data))
+			assert.NotContains(t, string(body), "legacy-secret")
+		})
+	}
+}
+
+func TestSubscriptionHandler(t *testing.T) {
+	handler := NewSubscriptionHandler(&models.LocalStorage{})
+
+	tests := map[string]struct {
+		method         string
+		path           string
+		expectedStatus int
+		expectedBody   string
+	}{
+		"success | list subscriptions": {
+			method:         http.MethodGet,
+			path:           "/admin/subscriptions",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"all_projects":true,"project_ids":[]}`,
+		},
+		"failure | invalid project id": {
+			method:         http.MethodPut,
+			path:           "/admin/subscriptions/abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"code":"400","error":"invalid project_id: abc","message":"invalid project_id: abc"}`,
+		},
+		"failure | unsubscribe when subscribed to all projects": {
+			method:         http.MethodDelete,
+			path:           "/admin/subscriptions/1",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody: `{
+				"code":"400",
+				"error":"all projects are subscribed to, when no project ids are configured",
+				"message":"all projects are subscribed to, when no project ids are configured"
+			}`,
+		},
+		"failure | method not allowed": {
+			method:         http.MethodPost,
+			path:           "/admin/subscriptions",
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   `{"code":"405","error":"method POST is not allowed","message":"method POST is not allowed"}`,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(data.method, data.path, nil))
+			resp := w.Result()
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+
+			require.Equal(t, data.expectedStatus, resp.StatusCode)
+			assert.JSONEq(t, data.expectedBody, string(body))
+		})
+	}
+}
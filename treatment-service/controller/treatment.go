@@ -5,15 +5,20 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/caraml-dev/xp/common/api/schema"
 	"github.com/caraml-dev/xp/common/pubsub"
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	"github.com/caraml-dev/xp/common/tracing"
 	"github.com/caraml-dev/xp/treatment-service/api"
 	"github.com/caraml-dev/xp/treatment-service/appcontext"
 	"github.com/caraml-dev/xp/treatment-service/config"
@@ -23,6 +28,8 @@ import (
 	"github.com/caraml-dev/xp/treatment-service/services"
 )
 
+var tracer = otel.Tracer("github.com/caraml-dev/xp/treatment-service/controller")
+
 type TreatmentController struct {
 	*appcontext.AppContext
 	Config *config.Config
@@ -36,7 +43,16 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 	w.Header().Set("ProjectId", strconv.Itoa(int(projectId_)))
 
 	projectId := models.NewProjectId(projectId_)
+	// Use the request id of the caller, if any, so that the logs can be correlated across services
 	requestId := uuid.New().String()
+	if params.XPRequestID != nil && *params.XPRequestID != "" {
+		requestId = *params.XPRequestID
+	}
+	ctx := r.Context()
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int64("xp.project_id", projectId_),
+		attribute.String("xp.request_id", requestId),
+	)
 
 	// Initialize metric / log variables
 	begin := time.Now()
@@ -64,6 +80,7 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 	var lookupRequestFilters []models.SegmentFilter
 	var errorLog *monitoring.ErrorResponseLog
 	var switchbackWindowId *int64
+	var randomizationKeyValue *string
 	if t.AppContext.AssignedTreatmentLogger != nil {
 		defer func() {
 			// Capture potential errors from other calls to service layer and prevent it from
@@ -91,6 +108,7 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 			}
 
 			assignedTreatmentLog := &monitoring.AssignedTreatmentLog{
+				EventType:  monitoring.AssignmentEvent,
 				ProjectID:  projectId,
 				RequestID:  requestId,
 				Experiment: filteredExperiment,
@@ -110,7 +128,19 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 				assignedTreatmentLog.Error = errorLog
 			}
 
-			_ = t.AppContext.AssignedTreatmentLogger.Append(assignedTreatmentLog)
+			// The randomization key is only looked up when there is a matching experiment. Otherwise, retrieve it
+			// for sampling the log, if the request filter could be generated (i.e., the project settings exist).
+			if randomizationKeyValue == nil && requestFilter != nil {
+				randomizationKeyValue, _ = t.SchemaService.GetRandomizationKeyValue(
+					projectId, filterParams.AdditionalProperties,
+				)
+			}
+			if randomizationKeyValue != nil {
+				assignedTreatmentLog.RandomizationKey = *randomizationKeyValue
+			}
+
+			_, span := tracer.Start(ctx, "AssignedTreatmentLogger.Append")
+			tracing.EndSpan(span, t.AppContext.AssignedTreatmentLogger.Append(assignedTreatmentLog))
 		}()
 	}
 
@@ -126,6 +156,15 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 		ErrorResponse(w, statusCode, err, &requestId)
 		return
 	}
+	if t.RateLimitService != nil {
+		if allowed, retryAfter := t.RateLimitService.Allow(projectId); !allowed {
+			statusCode = http.StatusTooManyRequests
+			err = fmt.Errorf("rate limit of project %d exceeded", projectId)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			ErrorResponse(w, statusCode, err, &requestId)
+			return
+		}
+	}
 
 	filterParams = api.FetchTreatmentRequestBody{}
 	err = json.NewDecoder(r.Body).Decode(&filterParams)
@@ -134,8 +173,19 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	requestValues := filterParams.AdditionalProperties
+	if t.EnrichmentService != nil {
+		// Failed lookups are skipped, so that the segmenters needing their variables are weakly matched
+		enrichCtx, span := tracer.Start(ctx, "EnrichmentService.Enrich")
+		var enrichErr error
+		requestValues, enrichErr = t.EnrichmentService.Enrich(enrichCtx, projectId, requestValues)
+		tracing.EndSpan(span, enrichErr)
+	}
+
 	// Use the S2ID at the max configured level (most granular level) to generate the filter
-	requestFilter, err = t.SchemaService.GetRequestFilter(projectId, filterParams.AdditionalProperties)
+	_, span := tracer.Start(ctx, "SchemaService.GetRequestFilter")
+	requestFilter, err = t.SchemaService.GetRequestFilter(projectId, requestValues)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		switch err.(type) {
 		default:
@@ -147,7 +197,12 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 			return
 		}
 	}
+	_, span = tracer.Start(ctx, "ExperimentService.GetExperiment")
 	lookupRequestFilters, filteredExperiment, err = t.ExperimentService.GetExperiment(projectId, requestFilter)
+	if filteredExperiment != nil {
+		span.SetAttributes(attribute.Int64("xp.experiment_id", filteredExperiment.Id))
+	}
+	tracing.EndSpan(span, err)
 	if err != nil {
 		statusCode = http.StatusInternalServerError
 		ErrorResponse(w, statusCode, err, &requestId)
@@ -165,7 +220,7 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	randomizationKeyValue, err := t.SchemaService.GetRandomizationKeyValue(
+	randomizationKeyValue, err = t.SchemaService.GetRandomizationKeyValue(
 		projectId, filterParams.AdditionalProperties,
 	)
 	if err != nil {
@@ -173,7 +228,9 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	_, span = tracer.Start(ctx, "TreatmentService.GetTreatment")
 	selectedTreatment, switchbackWindowId, err = t.TreatmentService.GetTreatment(filteredExperiment, randomizationKeyValue)
+	tracing.EndSpan(span, err)
 	if err != nil {
 		switch err.(type) {
 		case *services.RandomizationKeyNotFoundError:
@@ -206,6 +263,66 @@ func (t TreatmentController) FetchTreatment(w http.ResponseWriter, r *http.Reque
 	Ok(w, response, &requestId)
 }
 
+func (t TreatmentController) LogExposureEvent(w http.ResponseWriter, r *http.Request, projectId_ int64, params api.LogExposureEventParams) {
+	projectId := models.NewProjectId(projectId_)
+
+	err := t.SchemaService.ValidatePasskey(projectId, params.PassKey)
+	if err != nil {
+		switch err.(type) {
+		case *services.ProjectSettingsNotFoundError:
+			ErrorResponse(w, http.StatusNotFound, err, nil)
+		default:
+			ErrorResponse(w, http.StatusBadRequest, err, nil)
+		}
+		return
+	}
+
+	event := api.LogExposureEventJSONRequestBody{}
+	err = json.NewDecoder(r.Body).Decode(&event)
+	if err != nil {
+		ErrorResponse(w, http.StatusBadRequest, err, nil)
+		return
+	}
+	if event.RequestId == "" {
+		ErrorResponse(w, http.StatusBadRequest, errors.New("request_id must be provided"), nil)
+		return
+	}
+
+	// Check that the event refers to a known experiment and treatment
+	experiment, treatment, err := t.ExperimentService.GetExperimentTreatment(
+		projectId, event.ExperimentId, event.TreatmentName,
+	)
+	if err != nil {
+		ErrorResponse(w, http.StatusNotFound, err, &event.RequestId)
+		return
+	}
+
+	if t.AppContext.AssignedTreatmentLogger != nil {
+		bodyJson, err := json.Marshal(event)
+		if err != nil {
+			ErrorResponse(w, http.StatusInternalServerError, err, &event.RequestId)
+			return
+		}
+		clientTimestamp := event.ClientTimestamp
+		_ = t.AppContext.AssignedTreatmentLogger.Append(&monitoring.AssignedTreatmentLog{
+			EventType:  monitoring.ExposureEvent,
+			ProjectID:  projectId,
+			RequestID:  event.RequestId,
+			Experiment: experiment,
+			Treatment:  treatment,
+			TreatmentMetadata: &monitoring.TreatmentMetadata{
+				ExperimentVersion: experiment.Version,
+				ExperimentType:    string(models.ProtobufExperimentTypeToOpenAPI(experiment.Type)),
+			},
+			Request:         &monitoring.Request{Body: string(bodyJson)},
+			ClientTimestamp: &clientTimestamp,
+		})
+	}
+
+	w.Header().Set(xpRequestIDHeaderKey, event.RequestId)
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func LogFetchTreatmentError(
 	projectId uint32,
 	statusCode int,
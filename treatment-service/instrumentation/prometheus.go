@@ -18,6 +18,20 @@ const (
 	FetchTreatmentRequestCount metrics.MetricName = "fetch_treatment_request_count"
 	// NoMatchingExperimentRequestCount is the key to measure no. of fetch treatment requests with no matching experiments
 	NoMatchingExperimentRequestCount metrics.MetricName = "no_matching_experiment_request_count"
+	// AssignedTreatmentLogQueueDepth is the key to measure the no. of logs waiting to be published
+	AssignedTreatmentLogQueueDepth metrics.MetricName = "assigned_treatment_log_queue_depth"
+	// AssignedTreatmentLogDroppedCount is the key to measure no. of assigned treatment logs that were discarded
+	AssignedTreatmentLogDroppedCount metrics.MetricName = "assigned_treatment_log_dropped_count"
+	// AssignedTreatmentLogPublishDurationMs is the key to measure the duration of publishing a batch of logs
+	AssignedTreatmentLogPublishDurationMs metrics.MetricName = "assigned_treatment_log_publish_duration_ms"
+	// LastSyncTimestamp is the key to measure the time of the last successful sync of a project with the Management Service
+	LastSyncTimestamp metrics.MetricName = "last_sync_timestamp"
+	// SyncErrorCount is the key to measure no. of failed syncs of a project with the Management Service
+	SyncErrorCount metrics.MetricName = "sync_errors"
+	// RateLimitedRequestCount is the key to measure no. of fetch treatment requests exceeding the rate limit
+	RateLimitedRequestCount metrics.MetricName = "rate_limited_request_count"
+	// EnrichmentErrorCount is the key to measure no. of failed lookups of the request enrichment
+	EnrichmentErrorCount metrics.MetricName = "enrichment_error_count"
 	// FetchTreatmentRequestDurationMsHelpString is the help string of the FetchTreatmentRequestDurationMs metric
 	FetchTreatmentRequestDurationMsHelpString string = "Histogram for the runtime (in milliseconds) of Fetch Treatment requests"
 	// ExperimentLookupDurationMsHelpString is the help string of the ExperimentLookupDurationMs metric
@@ -26,6 +40,20 @@ const (
 	FetchTreatmentRequestCountHelpString string = "Counter for no. of Fetch Treatment requests with matching experiments"
 	// NoMatchingExperimentRequestCountHelpString is the help string of the NoMatchingExperimentRequestCount metric
 	NoMatchingExperimentRequestCountHelpString string = "Counter for no. of Fetch Treatment requests with no matching experiments"
+	// AssignedTreatmentLogQueueDepthHelpString is the help string of the AssignedTreatmentLogQueueDepth metric
+	AssignedTreatmentLogQueueDepthHelpString string = "Gauge for no. of assigned treatment logs waiting to be published"
+	// AssignedTreatmentLogDroppedCountHelpString is the help string of the AssignedTreatmentLogDroppedCount metric
+	AssignedTreatmentLogDroppedCountHelpString string = "Counter for no. of assigned treatment logs that were dropped"
+	// AssignedTreatmentLogPublishDurationMsHelpString is the help string of the AssignedTreatmentLogPublishDurationMs metric
+	AssignedTreatmentLogPublishDurationMsHelpString string = "Histogram for the runtime (in milliseconds) of publishing assigned treatment logs"
+	// LastSyncTimestampHelpString is the help string of the LastSyncTimestamp metric
+	LastSyncTimestampHelpString string = "Gauge for the Unix time (in seconds) of the last successful sync of a project"
+	// SyncErrorCountHelpString is the help string of the SyncErrorCount metric
+	SyncErrorCountHelpString string = "Counter for no. of failed syncs of a project"
+	// RateLimitedRequestCountHelpString is the help string of the RateLimitedRequestCount metric
+	RateLimitedRequestCountHelpString string = "Counter for no. of Fetch Treatment requests exceeding the rate limit of the project"
+	// EnrichmentErrorCountHelpString is the help string of the EnrichmentErrorCount metric
+	EnrichmentErrorCountHelpString string = "Counter for no. of failed or timed out lookups of the request enrichment"
 )
 
 // RequestLatencyBuckets defines the buckets used in the custom Histogram metrics
@@ -49,38 +77,117 @@ var FetchTreatmentRequestDurationMsLabels = []string{"project_name", "experiment
 // ExperimentLookupDurationMsLabels defines additional labels needed for the ExperimentLookupDurationMs histogram map
 var ExperimentLookupDurationMsLabels = []string{"project_name"}
 
-var GaugeMap = map[metrics.MetricName]metrics.PrometheusGaugeVec{}
+// AssignedTreatmentLogDroppedCountLabels defines labels needed for the AssignedTreatmentLogDroppedCount counter map
+var AssignedTreatmentLogDroppedCountLabels = []string{"reason"}
 
-func GetCounterMap(labels []string) map[metrics.MetricName]metrics.PrometheusCounterVec {
-	allLabels := append(
-		labels, AdditionalFetchTreatmentRequestCountLabels...,
-	)
-	noMatchingExperimentlabels := append(
-		labels, AdditionalNoMatchingExperimentRequestCountLabels...,
-	)
+// AssignedTreatmentLogPublishDurationMsLabels defines labels needed for the AssignedTreatmentLogPublishDurationMs
+// histogram map
+var AssignedTreatmentLogPublishDurationMsLabels = []string{"status"}
+
+// SyncLabels defines labels needed for the LastSyncTimestamp gauge and the SyncErrorCount counter maps
+var SyncLabels = []string{"project_id"}
+
+// RateLimitedRequestCountLabels defines labels needed for the RateLimitedRequestCount counter map. The shadow_mode
+// label is true for the requests that were counted but not rejected.
+var RateLimitedRequestCountLabels = []string{"project_name", "shadow_mode"}
+
+// EnrichmentErrorCountLabels defines labels needed for the EnrichmentErrorCount counter map. The kind label is the
+// kind of the failed lookup.
+var EnrichmentErrorCountLabels = []string{"project_name", "kind"}
 
+var GaugeMap = map[metrics.MetricName]metrics.PrometheusGaugeVec{
+	AssignedTreatmentLogQueueDepth: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: Subsystem,
+		Help:      AssignedTreatmentLogQueueDepthHelpString,
+		Name:      string(AssignedTreatmentLogQueueDepth),
+	},
+		[]string{},
+	),
+	LastSyncTimestamp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Subsystem: Subsystem,
+		Help:      LastSyncTimestampHelpString,
+		Name:      string(LastSyncTimestamp),
+	},
+		SyncLabels,
+	),
+}
+
+func GetCounterMap(labels []string) map[metrics.MetricName]metrics.PrometheusCounterVec {
 	counterMap := map[metrics.MetricName]metrics.PrometheusCounterVec{
-		FetchTreatmentRequestCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+		FetchTreatmentRequestCount: NewReloadableCounterVec(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Subsystem: Subsystem,
 			Help:      FetchTreatmentRequestCountHelpString,
 			Name:      string(FetchTreatmentRequestCount),
 		},
-			allLabels,
+			fetchTreatmentRequestCountLabels(labels),
 		),
-		NoMatchingExperimentRequestCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+		NoMatchingExperimentRequestCount: NewReloadableCounterVec(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Subsystem: Subsystem,
 			Help:      NoMatchingExperimentRequestCountHelpString,
 			Name:      string(NoMatchingExperimentRequestCount),
 		},
-			noMatchingExperimentlabels,
+			noMatchingExperimentRequestCountLabels(labels),
+		),
+		AssignedTreatmentLogDroppedCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Help:      AssignedTreatmentLogDroppedCountHelpString,
+			Name:      string(AssignedTreatmentLogDroppedCount),
+		},
+			AssignedTreatmentLogDroppedCountLabels,
+		),
+		SyncErrorCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Help:      SyncErrorCountHelpString,
+			Name:      string(SyncErrorCount),
+		},
+			SyncLabels,
+		),
+		RateLimitedRequestCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Help:      RateLimitedRequestCountHelpString,
+			Name:      string(RateLimitedRequestCount),
+		},
+			RateLimitedRequestCountLabels,
+		),
+		EnrichmentErrorCount: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Help:      EnrichmentErrorCountHelpString,
+			Name:      string(EnrichmentErrorCount),
+		},
+			EnrichmentErrorCountLabels,
 		),
 	}
 
 	return counterMap
 }
 
+// SetCounterMapLabels replaces the segmenter labels of the request count metrics in a map created by
+// GetCounterMap. The counts of these metrics are reset.
+func SetCounterMapLabels(counterMap map[metrics.MetricName]metrics.PrometheusCounterVec, labels []string) {
+	if counter, ok := counterMap[FetchTreatmentRequestCount].(*ReloadableCounterVec); ok {
+		counter.SetLabels(fetchTreatmentRequestCountLabels(labels))
+	}
+	if counter, ok := counterMap[NoMatchingExperimentRequestCount].(*ReloadableCounterVec); ok {
+		counter.SetLabels(noMatchingExperimentRequestCountLabels(labels))
+	}
+}
+
+func fetchTreatmentRequestCountLabels(labels []string) []string {
+	return append(append([]string{}, labels...), AdditionalFetchTreatmentRequestCountLabels...)
+}
+
+func noMatchingExperimentRequestCountLabels(labels []string) []string {
+	return append(append([]string{}, labels...), AdditionalNoMatchingExperimentRequestCountLabels...)
+}
+
 func GetHistogramMap() map[metrics.MetricName]metrics.PrometheusHistogramVec {
 
 	histogramMap := map[metrics.MetricName]metrics.PrometheusHistogramVec{
@@ -102,6 +209,15 @@ func GetHistogramMap() map[metrics.MetricName]metrics.PrometheusHistogramVec {
 		},
 			ExperimentLookupDurationMsLabels,
 		),
+		AssignedTreatmentLogPublishDurationMs: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      string(AssignedTreatmentLogPublishDurationMs),
+			Help:      AssignedTreatmentLogPublishDurationMsHelpString,
+			Buckets:   RequestLatencyBuckets,
+		},
+			AssignedTreatmentLogPublishDurationMsLabels,
+		),
 	}
 
 	return histogramMap
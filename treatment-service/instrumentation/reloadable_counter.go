@@ -0,0 +1,46 @@
+package instrumentation
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ReloadableCounterVec is a counter whose label names can be changed after it has been registered. Since the
+// Prometheus registry does not allow a metric to change its label names, it is registered as an unchecked
+// collector, i.e., Describe does not send any descriptors.
+type ReloadableCounterVec struct {
+	mu   sync.RWMutex
+	opts prometheus.CounterOpts
+	vec  *prometheus.CounterVec
+}
+
+func NewReloadableCounterVec(opts prometheus.CounterOpts, labels []string) *ReloadableCounterVec {
+	return &ReloadableCounterVec{
+		opts: opts,
+		vec:  prometheus.NewCounterVec(opts, labels),
+	}
+}
+
+// SetLabels replaces the label names of the counter, which resets its counts
+func (c *ReloadableCounterVec) SetLabels(labels []string) {
+	vec := prometheus.NewCounterVec(c.opts, labels)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.vec = vec
+}
+
+func (c *ReloadableCounterVec) GetMetricWith(labels prometheus.Labels) (prometheus.Counter, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.vec.GetMetricWith(labels)
+}
+
+func (c *ReloadableCounterVec) Describe(chan<- *prometheus.Desc) {}
+
+func (c *ReloadableCounterVec) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.vec.Collect(ch)
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/caraml-dev/xp/clients/treatment"
 	"github.com/caraml-dev/xp/common/api/schema"
 	"github.com/caraml-dev/xp/common/testutils"
+	_utils "github.com/caraml-dev/xp/common/utils"
 	"github.com/caraml-dev/xp/treatment-service/models"
 	"github.com/caraml-dev/xp/treatment-service/monitoring"
 	"github.com/caraml-dev/xp/treatment-service/server"
@@ -231,6 +232,7 @@ func generateExperiments() []schema.Experiment {
 
 func setupManagementServiceClient() (*management.ClientWithResponses, *httptest.Server) {
 	projectSettings := []schema.ProjectSettings{}
+	passkeyHashes := map[int64][]schema.ProjectPasskeyHash{}
 	segmenters := map[int64]schema.ProjectSegmenters{
 		1: {
 			Names: []string{"s2_ids"},
@@ -276,10 +278,15 @@ func setupManagementServiceClient() (*management.ClientWithResponses, *httptest.
 			ProjectId:            int64(i),
 			Username:             fmt.Sprintf("ProjectSettings%v", i),
 			RandomizationKey:     "order-id",
-			Passkey:              "test_project_1234",
-			Segmenters:           segmenters[int64(i)],
+			Passkeys: &[]schema.ProjectPasskey{
+				{Name: "default", Fingerprint: _utils.HashPasskey("test_project_1234")[:8]},
+			},
+			Segmenters: segmenters[int64(i)],
 		}
 		projectSettings = append(projectSettings, settings)
+		passkeyHashes[int64(i)] = []schema.ProjectPasskeyHash{
+			{Name: "default", Hash: _utils.HashPasskey("test_project_1234")},
+		}
 	}
 
 	experiments := generateExperiments()
@@ -297,7 +304,7 @@ func setupManagementServiceClient() (*management.ClientWithResponses, *httptest.
 	if err != nil {
 		log.Fatalf("fail to instantiate message queue: %s", err.Error())
 	}
-	store, err := mgmtSvc.NewInMemoryStore(experiments, projectSettings, messageQueue, segmentersType)
+	store, err := mgmtSvc.NewInMemoryStore(experiments, projectSettings, passkeyHashes, messageQueue, segmentersType)
 	if err != nil {
 		log.Fatalf("fail to instantiate in memory store: %s", err.Error())
 	}
@@ -745,6 +752,30 @@ func (suite *TreatmentServiceTestSuite) TestNoExperiment() {
 	suite.Require().Nil(resp.JSON200.Data)
 }
 
+func (suite *TreatmentServiceTestSuite) TestIncomingRequestId() {
+	projectId := int64(1)
+	requestId := "caller-request-id"
+	params := treatment.FetchTreatmentParams{PassKey: "test_project_1234", XPRequestID: &requestId}
+
+	postBody, _ := json.Marshal(request{
+		Longitude: 179.8998991137485,
+		Latitude:  1.2537040223936706,
+		OrderId:   &orderId,
+		Timezone:  &tz,
+	})
+	resp, err := suite.treatmentServiceClient.FetchTreatmentWithBodyWithResponse(
+		suite.ctx,
+		projectId,
+		&params,
+		"application/json",
+		bytes.NewReader(postBody),
+	)
+
+	suite.Require().NoError(err)
+	suite.Require().Equal(200, resp.StatusCode())
+	suite.Require().Equal(requestId, resp.HTTPResponse.Header.Get("XP-Request-ID"))
+}
+
 func (suite *TreatmentServiceTestSuite) TestIncorrectRequestParam() {
 	projectId := int64(1)
 	params := treatment.FetchTreatmentParams{PassKey: "test_project_1234"}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/caraml-dev/xp/common/utils"
+)
+
+// TracingMiddleware starts a span for each request, named after its route pattern, as a child of
+// the trace context propagated by the caller, if any.
+func TracingMiddleware() func(next http.Handler) http.Handler {
+	return otelhttp.NewMiddleware("",
+		otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return r.Method + " " + utils.GetRoutePattern(r)
+		}),
+	)
+}
@@ -8,6 +8,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -17,7 +18,9 @@ import (
 	"github.com/caraml-dev/xp/common/api/schema"
 	"github.com/caraml-dev/xp/common/pubsub"
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
 	"github.com/golang-collections/collections/set"
+	"google.golang.org/protobuf/proto"
 )
 
 type ProjectId = uint32
@@ -53,12 +56,29 @@ type ExperimentStorage interface {
 
 type LocalStorage struct {
 	sync.RWMutex
-	Experiments          map[ProjectId][]*ExperimentIndex
-	ProjectSettings      []*pubsub.ProjectSettings
-	managementClient     *managementClient.ClientWithResponses
+	Experiments      map[ProjectId][]*ExperimentIndex
+	ProjectSettings  []*pubsub.ProjectSettings
+	managementClient *managementClient.ClientWithResponses
+	// subscribedProjectIds are the projects whose data is stored. All projects are subscribed to when it is empty.
 	subscribedProjectIds []ProjectId
 	Segmenters           map[string]schema.SegmenterType
 	ProjectSegmenters    map[ProjectId]map[string]schema.SegmenterType
+	// ProjectSegmenterMatchModes holds the match modes of the string segmenters whose values are patterns
+	ProjectSegmenterMatchModes map[ProjectId]map[string]_segmenters.StringMatchMode
+	// ProjectSegmenterExpressions holds the expressions of the segmenters whose values are computed from the request
+	ProjectSegmenterExpressions map[ProjectId]map[string]string
+	// ProjectSegmenterRequestFields holds the alternative request fields, in priority order, of the segmenters
+	// that are not read from the request field with their name
+	ProjectSegmenterRequestFields map[ProjectId]map[string][]string
+	SyncStatus                    SyncStatus
+
+	// loadLock serializes the loads of data from the Management Service, so that a load does not overwrite
+	// the data of the projects subscribed to during another
+	loadLock sync.Mutex
+
+	// version is incremented on every change to the cached data, at lastUpdated
+	version     uint64
+	lastUpdated time.Time
 }
 
 type Match struct {
@@ -76,6 +96,13 @@ type ExperimentIndex struct {
 	intSets    map[string]*set.Set
 	realSets   map[string]*set.Set
 	boolSets   map[string]*set.Set
+	// intRanges and realRanges hold the range values of the integer and real segmenters, which are matched
+	// in addition to the exact values in the sets
+	intRanges  map[string][]*_segmenters.IntegerRange
+	realRanges map[string][]*_segmenters.RealRange
+	// stringMatchers hold the values of the string segmenters in the prefix and regex match modes, which are
+	// matched instead of the values in the sets
+	stringMatchers map[string]*_utils.StringMatcher
 
 	StartTime time.Time
 	EndTime   time.Time
@@ -89,6 +116,11 @@ type ExperimentIndexLog struct {
 	StringSets map[string][]interface{}
 	IntSets    map[string][]interface{}
 	RealSets   map[string][]interface{}
+	// IntRanges and RealRanges are formatted in the interval notation
+	IntRanges  map[string][]string `json:",omitempty"`
+	RealRanges map[string][]string `json:",omitempty"`
+	// StringPatterns are the prefixes and regular expressions of the string segmenters that use them
+	StringPatterns map[string][]string `json:",omitempty"`
 
 	StartTime time.Time
 	EndTime   time.Time
@@ -126,12 +158,37 @@ func (i *ExperimentIndex) MarshalJSON() ([]byte, error) {
 		realSets[k] = values
 	}
 
+	intRanges := map[string][]string{}
+	for k, v := range i.intRanges {
+		for _, r := range v {
+			intRanges[k] = append(intRanges[k], _utils.FormatSegmenterRange(
+				&_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_IntegerRange{IntegerRange: r}},
+			))
+		}
+	}
+	realRanges := map[string][]string{}
+	for k, v := range i.realRanges {
+		for _, r := range v {
+			realRanges[k] = append(realRanges[k], _utils.FormatSegmenterRange(
+				&_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_RealRange{RealRange: r}},
+			))
+		}
+	}
+
+	stringPatterns := map[string][]string{}
+	for k, v := range i.stringMatchers {
+		stringPatterns[k] = v.Patterns()
+	}
+
 	idx := ExperimentIndexLog{
-		StringSets: stringSets,
-		IntSets:    intSets,
-		RealSets:   realSets,
-		StartTime:  i.StartTime,
-		EndTime:    i.EndTime,
+		StringSets:     stringSets,
+		IntSets:        intSets,
+		RealSets:       realSets,
+		IntRanges:      intRanges,
+		RealRanges:     realRanges,
+		StringPatterns: stringPatterns,
+		StartTime:      i.StartTime,
+		EndTime:        i.EndTime,
 	}
 
 	// Store experiment info
@@ -158,6 +215,13 @@ func (i *ExperimentIndex) matchFlagSetSegment(segmentName string, value bool) Ma
 }
 
 func (i *ExperimentIndex) matchStringSetSegment(segmentName string, value string) MatchStrength {
+	if matcher, exists := i.stringMatchers[segmentName]; exists {
+		if matcher.Match(value) {
+			return MatchStrengthExact
+		}
+		return MatchStrengthNone
+	}
+
 	set, exists := i.stringSets[segmentName]
 	if !exists || set.Len() == 0 {
 		// Optional segmenter
@@ -172,27 +236,39 @@ func (i *ExperimentIndex) matchStringSetSegment(segmentName string, value string
 
 func (i *ExperimentIndex) matchIntSetSegment(segmentName string, value int64) MatchStrength {
 	set, exists := i.intSets[segmentName]
-	if !exists || set.Len() == 0 {
+	ranges := i.intRanges[segmentName]
+	if (!exists || set.Len() == 0) && len(ranges) == 0 {
 		// Optional segmenter
 		return MatchStrengthWeak
 	}
 
-	if set.Has(value) {
+	if exists && set.Has(value) {
 		return MatchStrengthExact
 	}
+	for _, r := range ranges {
+		if _utils.IntegerRangeContains(r, value) {
+			return MatchStrengthExact
+		}
+	}
 	return MatchStrengthNone
 }
 
 func (i *ExperimentIndex) matchRealSetSegment(segmentName string, value float64) MatchStrength {
 	set, exists := i.realSets[segmentName]
-	if !exists || set.Len() == 0 {
+	ranges := i.realRanges[segmentName]
+	if (!exists || set.Len() == 0) && len(ranges) == 0 {
 		// Optional segmenter
 		return MatchStrengthWeak
 	}
 
-	if set.Has(value) {
+	if exists && set.Has(value) {
 		return MatchStrengthExact
 	}
+	for _, r := range ranges {
+		if _utils.RealRangeContains(r, value) {
+			return MatchStrengthExact
+		}
+	}
 	return MatchStrengthNone
 }
 
@@ -225,14 +301,34 @@ func (i *ExperimentIndex) matchSegment(segmentName string, values []*_segmenters
 }
 
 func (i *ExperimentIndex) isActive() bool {
-	if i.Experiment.Status != pubsub.Experiment_Active {
+	return isExperimentActive(i.Experiment.Status, i.StartTime, i.EndTime)
+}
+
+// IsExperimentActive returns whether the experiment is active and within its schedule, i.e. whether it is
+// considered for the treatment assignment at this time
+func IsExperimentActive(experiment *pubsub.Experiment) bool {
+	return isExperimentActive(
+		experiment.Status,
+		time.Unix(experiment.StartTime.GetSeconds(), 0).UTC(),
+		time.Unix(experiment.EndTime.GetSeconds(), 0).UTC(),
+	)
+}
+
+func isExperimentActive(status pubsub.Experiment_Status, startTime time.Time, endTime time.Time) bool {
+	if status != pubsub.Experiment_Active {
 		return false
 	}
 
-	return (i.StartTime.Before(time.Now()) || i.StartTime.Equal(time.Now())) && i.EndTime.After(time.Now())
+	return (startTime.Before(time.Now()) || startTime.Equal(time.Now())) && endTime.After(time.Now())
 }
 
 func (i *ExperimentIndex) checkSegmentHasWeakMatch(segmentName string) bool {
+	if len(i.intRanges[segmentName]) > 0 || len(i.realRanges[segmentName]) > 0 {
+		return false
+	}
+	if _, exists := i.stringMatchers[segmentName]; exists {
+		return false
+	}
 	if set, exists := i.stringSets[segmentName]; exists {
 		if set.Len() > 0 {
 			return false
@@ -261,15 +357,33 @@ func (s *LocalStorage) InsertProjectSettings(projectSettings *pubsub.ProjectSett
 	}
 
 	// Update project segmenters on creation
-	newSegmenters, err := s.fetchProjectSegmenters([]*pubsub.ProjectSettings{projectSettings})
+	newSegmenters, newMatchModes, newExpressions, newRequestFields, err := s.fetchProjectSegmenters(
+		[]*pubsub.ProjectSettings{projectSettings},
+	)
 	if err != nil {
 		return err
 	}
 
 	s.Lock()
 	defer s.Unlock()
-	s.ProjectSegmenters = newSegmenters
+	s.ProjectSegmenters[ProjectId(projectSettings.GetProjectId())] = newSegmenters[ProjectId(projectSettings.GetProjectId())]
+	if s.ProjectSegmenterMatchModes == nil {
+		s.ProjectSegmenterMatchModes = map[ProjectId]map[string]_segmenters.StringMatchMode{}
+	}
+	s.ProjectSegmenterMatchModes[ProjectId(projectSettings.GetProjectId())] =
+		newMatchModes[ProjectId(projectSettings.GetProjectId())]
+	if s.ProjectSegmenterExpressions == nil {
+		s.ProjectSegmenterExpressions = map[ProjectId]map[string]string{}
+	}
+	s.ProjectSegmenterExpressions[ProjectId(projectSettings.GetProjectId())] =
+		newExpressions[ProjectId(projectSettings.GetProjectId())]
+	if s.ProjectSegmenterRequestFields == nil {
+		s.ProjectSegmenterRequestFields = map[ProjectId]map[string][]string{}
+	}
+	s.ProjectSegmenterRequestFields[ProjectId(projectSettings.GetProjectId())] =
+		newRequestFields[ProjectId(projectSettings.GetProjectId())]
 	s.ProjectSettings = append(s.ProjectSettings, projectSettings)
+	s.markUpdated()
 	return nil
 }
 
@@ -280,60 +394,175 @@ func (s *LocalStorage) UpdateProjectSettings(updatedProjectSettings *pubsub.Proj
 	for index, settings := range s.ProjectSettings {
 		if updatedProjectSettings.ProjectId == settings.ProjectId {
 			s.ProjectSettings[index] = updatedProjectSettings
+			s.markUpdated()
 		}
 	}
 }
 
 func (s *LocalStorage) FindProjectSettingsWithId(projectId ProjectId) *pubsub.ProjectSettings {
-	projectSettings := s.findSubscribedProjectSettingsById(projectId)
+	if !s.IsSubscribed(projectId) {
+		return nil
+	}
+	projectSettings := s.findProjectSettingsById(projectId)
 	if projectSettings != nil {
 		return projectSettings
 	}
 
 	// In case new project was just created and we are subscribed to its ID
 	// we'll try to retrieve it from management service
-	projectSettings, err := s.fetchProjectSettingsWithId(projectId)
-	if err != nil {
+	if err := s.loadMissingProject(projectId); err != nil {
 		return nil
 	}
-	return projectSettings
+	return s.findProjectSettingsById(projectId)
 }
 
-func (s *LocalStorage) findSubscribedProjectSettingsById(projectId ProjectId) *pubsub.ProjectSettings {
+func (s *LocalStorage) findProjectSettingsById(projectId ProjectId) *pubsub.ProjectSettings {
 	s.RLock()
 	defer s.RUnlock()
 
-	if !ContainsProjectId(s.subscribedProjectIds, projectId) {
-		return nil
+	for _, settings := range s.ProjectSettings {
+		if ProjectId(settings.ProjectId) == projectId {
+			return settings
+		}
 	}
+	return nil
+}
 
-	return s.findProjectSettingsById(projectId)
+// IsSubscribed determines if the data of the project is stored
+func (s *LocalStorage) IsSubscribed(projectId ProjectId) bool {
+	s.RLock()
+	defer s.RUnlock()
+	return ContainsProjectId(s.subscribedProjectIds, projectId)
 }
 
-func (s *LocalStorage) findProjectSettingsById(projectId ProjectId) *pubsub.ProjectSettings {
+// SubscribedProjectIds returns the ids of the subscribed projects, which is empty if all projects are subscribed to
+func (s *LocalStorage) SubscribedProjectIds() []ProjectId {
 	s.RLock()
 	defer s.RUnlock()
+	return append([]ProjectId{}, s.subscribedProjectIds...)
+}
+
+// SubscribeProject loads the settings, segmenters and experiments of the project from the Management Service,
+// and stores the subsequent updates of the project. The data of a project that is already subscribed to is reloaded.
+func (s *LocalStorage) SubscribeProject(projectId ProjectId) error {
+	s.loadLock.Lock()
+	defer s.loadLock.Unlock()
+	if err := s.loadProject(projectId); err != nil {
+		s.SyncStatus.RecordSyncError(projectId)
+		return err
+	}
+	return nil
+}
+
+// UnsubscribeProject removes the data of the project and stops storing its updates. The last subscribed project
+// cannot be removed, since all projects are subscribed to when there are none.
+func (s *LocalStorage) UnsubscribeProject(projectId ProjectId) error {
+	s.loadLock.Lock()
+	defer s.loadLock.Unlock()
+	s.Lock()
+	defer s.Unlock()
 
+	if len(s.subscribedProjectIds) == 0 {
+		return errors.New("all projects are subscribed to, when no project ids are configured")
+	}
+	subscribedProjectIds := []ProjectId{}
+	for _, id := range s.subscribedProjectIds {
+		if id != projectId {
+			subscribedProjectIds = append(subscribedProjectIds, id)
+		}
+	}
+	if len(subscribedProjectIds) == len(s.subscribedProjectIds) {
+		return fmt.Errorf("project %d is not subscribed to", projectId)
+	}
+	if len(subscribedProjectIds) == 0 {
+		return fmt.Errorf("project %d is the only subscribed project", projectId)
+	}
+
+	projectSettings := []*pubsub.ProjectSettings{}
 	for _, settings := range s.ProjectSettings {
-		if ProjectId(settings.ProjectId) == projectId {
-			return settings
+		if ProjectId(settings.ProjectId) != projectId {
+			projectSettings = append(projectSettings, settings)
 		}
 	}
+	s.subscribedProjectIds = subscribedProjectIds
+	s.ProjectSettings = projectSettings
+	delete(s.ProjectSegmenters, projectId)
+	delete(s.ProjectSegmenterMatchModes, projectId)
+	delete(s.ProjectSegmenterExpressions, projectId)
+	delete(s.ProjectSegmenterRequestFields, projectId)
+	delete(s.Experiments, projectId)
+	s.markUpdated()
 	return nil
 }
 
-func (s *LocalStorage) fetchProjectSettingsWithId(projectId ProjectId) (*pubsub.ProjectSettings, error) {
-	projectSettingsResponse, err := s.managementClient.GetProjectSettingsWithResponse(
-		context.Background(), int64(projectId))
+// loadMissingProject loads a subscribed project that is not in the local storage, such as a project created
+// after the initial sync, when all projects are subscribed to
+func (s *LocalStorage) loadMissingProject(projectId ProjectId) error {
+	s.loadLock.Lock()
+	defer s.loadLock.Unlock()
+
+	// The project may have been loaded while waiting for the lock
+	if s.findProjectSettingsById(projectId) != nil {
+		return nil
+	}
+	return s.loadProject(projectId)
+}
+
+// loadProject retrieves the data of the project from the Management Service and replaces the data in the local
+// storage at once, so that the project is never partially loaded. The caller must hold the load lock.
+func (s *LocalStorage) loadProject(projectId ProjectId) error {
+	projectSettings, err := s.getProjectSettings([]ProjectId{projectId})
 	if err != nil {
-		return nil, err
+		return err
+	}
+	newSegmenters, newMatchModes, newExpressions, newRequestFields, err := s.fetchProjectSegmenters(projectSettings)
+	if err != nil {
+		return err
+	}
+	newExperiments, err := s.fetchExperiments(projectSettings, newSegmenters, newMatchModes)
+	if err != nil {
+		return err
 	}
 
-	project := OpenAPIProjectSettingsSpecToProtobuf(projectSettingsResponse.JSON200.Data)
 	s.Lock()
-	defer s.Unlock()
-	s.ProjectSettings = append(s.ProjectSettings, project)
-	return project, nil
+	replaced := false
+	for index, settings := range s.ProjectSettings {
+		if ProjectId(settings.ProjectId) == projectId {
+			s.ProjectSettings[index] = projectSettings[0]
+			replaced = true
+		}
+	}
+	if !replaced {
+		s.ProjectSettings = append(s.ProjectSettings, projectSettings[0])
+	}
+	if s.ProjectSegmenters == nil {
+		s.ProjectSegmenters = map[ProjectId]map[string]schema.SegmenterType{}
+	}
+	s.ProjectSegmenters[projectId] = newSegmenters[projectId]
+	if s.ProjectSegmenterMatchModes == nil {
+		s.ProjectSegmenterMatchModes = map[ProjectId]map[string]_segmenters.StringMatchMode{}
+	}
+	s.ProjectSegmenterMatchModes[projectId] = newMatchModes[projectId]
+	if s.ProjectSegmenterExpressions == nil {
+		s.ProjectSegmenterExpressions = map[ProjectId]map[string]string{}
+	}
+	s.ProjectSegmenterExpressions[projectId] = newExpressions[projectId]
+	if s.ProjectSegmenterRequestFields == nil {
+		s.ProjectSegmenterRequestFields = map[ProjectId]map[string][]string{}
+	}
+	s.ProjectSegmenterRequestFields[projectId] = newRequestFields[projectId]
+	if s.Experiments == nil {
+		s.Experiments = map[ProjectId][]*ExperimentIndex{}
+	}
+	s.Experiments[projectId] = newExperiments[projectId]
+	if !ContainsProjectId(s.subscribedProjectIds, projectId) {
+		s.subscribedProjectIds = append(s.subscribedProjectIds, projectId)
+	}
+	s.markUpdated()
+	s.Unlock()
+
+	s.SyncStatus.RecordSync(projectId)
+	return nil
 }
 
 func (s *LocalStorage) GetSegmentersTypeMapping(projectId ProjectId) (map[string]schema.SegmenterType, error) {
@@ -347,6 +576,25 @@ func (s *LocalStorage) GetSegmentersTypeMapping(projectId ProjectId) (map[string
 	}
 }
 
+// GetSegmenterExpression returns the expression that computes the value of the project segmenter, if any
+func (s *LocalStorage) GetSegmenterExpression(projectId ProjectId, segmenterName string) (string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	expression, ok := s.ProjectSegmenterExpressions[projectId][segmenterName]
+	return expression, ok
+}
+
+// GetSegmenterRequestFields returns the alternative request fields of the project segmenter, in priority order,
+// if it is not read from the request field with its name
+func (s *LocalStorage) GetSegmenterRequestFields(projectId ProjectId, segmenterName string) ([]string, bool) {
+	s.RLock()
+	defer s.RUnlock()
+
+	requestFields, ok := s.ProjectSegmenterRequestFields[projectId][segmenterName]
+	return requestFields, ok
+}
+
 func (s *LocalStorage) FindExperiments(projectId ProjectId, filters []SegmentFilter) []*ExperimentMatch {
 	s.RLock()
 	defer s.RUnlock()
@@ -399,11 +647,18 @@ func (s *LocalStorage) FindExperimentWithId(projectId ProjectId, experimentId in
 	return nil
 }
 
-func NewExperimentIndex(experiment *pubsub.Experiment) *ExperimentIndex {
+// NewExperimentIndex indexes the segment of the experiment for matching. The values of the string segmenters with
+// the given match modes are compiled into matchers.
+func NewExperimentIndex(
+	experiment *pubsub.Experiment,
+	matchModes map[string]_segmenters.StringMatchMode,
+) *ExperimentIndex {
 	stringSets := make(map[string]*set.Set)
 	intSets := make(map[string]*set.Set)
 	realSets := make(map[string]*set.Set)
 	boolSets := make(map[string]*set.Set)
+	intRanges := make(map[string][]*_segmenters.IntegerRange)
+	realRanges := make(map[string][]*_segmenters.RealRange)
 
 	for key, segment := range experiment.Segments {
 		for _, val := range segment.Values {
@@ -432,10 +687,35 @@ func NewExperimentIndex(experiment *pubsub.Experiment) *ExperimentIndex {
 					boolSets[key] = set.New()
 				}
 				boolSets[key].Insert(val.GetBool())
+			case *_segmenters.SegmenterValue_IntegerRange:
+				intRanges[key] = append(intRanges[key], val.GetIntegerRange())
+			case *_segmenters.SegmenterValue_RealRange:
+				realRanges[key] = append(realRanges[key], val.GetRealRange())
 			}
 		}
 	}
 
+	stringMatchers := make(map[string]*_utils.StringMatcher)
+	for key, matchMode := range matchModes {
+		stringSet, ok := stringSets[key]
+		if !ok || matchMode == _segmenters.StringMatchMode_EXACT {
+			continue
+		}
+		patterns := []string{}
+		stringSet.Do(func(item interface{}) {
+			patterns = append(patterns, item.(string))
+		})
+		sort.Strings(patterns)
+		matcher, err := _utils.NewStringMatcher(patterns, matchMode)
+		if err != nil {
+			// The patterns are validated by the Management Service, so this should not happen. Fall back to
+			// matching the values exactly.
+			log.Printf("error compiling the values of segmenter %s of experiment %d: %s", key, experiment.Id, err)
+			continue
+		}
+		stringMatchers[key] = matcher
+	}
+
 	// Delete all segments since they have already been converted to the various sets stored in ExperimentIndex,
 	// and are no longer used by the Treatment Service
 	// TODO: To make the ExperimentIndex store only the relevant data using appropriate structs rather than
@@ -443,13 +723,16 @@ func NewExperimentIndex(experiment *pubsub.Experiment) *ExperimentIndex {
 	experiment.Segments = nil
 
 	return &ExperimentIndex{
-		Experiment: experiment,
-		stringSets: stringSets,
-		intSets:    intSets,
-		realSets:   realSets,
-		boolSets:   boolSets,
-		StartTime:  time.Unix(experiment.StartTime.Seconds, 0).UTC(),
-		EndTime:    time.Unix(experiment.EndTime.Seconds, 0).UTC(),
+		Experiment:     experiment,
+		stringSets:     stringSets,
+		intSets:        intSets,
+		realSets:       realSets,
+		boolSets:       boolSets,
+		intRanges:      intRanges,
+		realRanges:     realRanges,
+		stringMatchers: stringMatchers,
+		StartTime:      time.Unix(experiment.StartTime.Seconds, 0).UTC(),
+		EndTime:        time.Unix(experiment.EndTime.Seconds, 0).UTC(),
 	}
 }
 
@@ -470,15 +753,17 @@ func (s *LocalStorage) InsertExperiment(experiment *pubsub.Experiment) {
 		}
 	}
 
-	newIndex := NewExperimentIndex(experiment)
+	newIndex := NewExperimentIndex(experiment, s.ProjectSegmenterMatchModes[projectId])
 	s.Experiments[projectId] = append(s.Experiments[projectId], newIndex)
+	s.markUpdated()
 }
 
 func (s *LocalStorage) UpdateExperiment(experiment *pubsub.Experiment) {
 	projectId := ProjectId(experiment.ProjectId)
 	s.Lock()
 	defer s.Unlock()
-	newIndex := NewExperimentIndex(experiment)
+	defer s.markUpdated()
+	newIndex := NewExperimentIndex(experiment, s.ProjectSegmenterMatchModes[projectId])
 
 	experimentIndexes := s.Experiments[projectId]
 	for idx, experimentIndex := range experimentIndexes {
@@ -512,41 +797,148 @@ func (s *LocalStorage) DumpExperiments(filepath string) error {
 	return os.WriteFile(filepath, file, 0644)
 }
 
+// CacheFilter restricts the data of the local storage returned in a CacheSnapshot. Unset fields match all.
+type CacheFilter struct {
+	ProjectId    *ProjectId
+	ExperimentId *int64
+}
+
+// CacheSnapshot is a copy of the data in the local storage, for debugging, with the passkey hashes redacted
+type CacheSnapshot struct {
+	Version         uint64                                        `json:"version"`
+	LastUpdated     time.Time                                     `json:"last_updated"`
+	ProjectSettings []*pubsub.ProjectSettings                     `json:"project_settings"`
+	Segmenters      map[ProjectId]map[string]schema.SegmenterType `json:"segmenters"`
+	Experiments     map[ProjectId][]*ExperimentIndex              `json:"experiments"`
+}
+
+const redactedPasskey = "<redacted>"
+
+// Snapshot copies the data in the local storage that matches the filter
+func (s *LocalStorage) Snapshot(filter CacheFilter) CacheSnapshot {
+	s.RLock()
+	defer s.RUnlock()
+
+	matchesProject := func(projectId ProjectId) bool {
+		return filter.ProjectId == nil || *filter.ProjectId == projectId
+	}
+	snapshot := CacheSnapshot{
+		Version:         s.version,
+		LastUpdated:     s.lastUpdated,
+		ProjectSettings: []*pubsub.ProjectSettings{},
+		Segmenters:      map[ProjectId]map[string]schema.SegmenterType{},
+		Experiments:     map[ProjectId][]*ExperimentIndex{},
+	}
+	for _, settings := range s.ProjectSettings {
+		if !matchesProject(ProjectId(settings.ProjectId)) {
+			continue
+		}
+		settingsCopy := proto.Clone(settings).(*pubsub.ProjectSettings)
+		for _, passkey := range settingsCopy.Passkeys {
+			passkey.Hash = redactedPasskey
+		}
+		// The legacy plaintext passkey is omitted altogether
+		settingsCopy.Passkey = ""
+		snapshot.ProjectSettings = append(snapshot.ProjectSettings, settingsCopy)
+	}
+	for projectId, segmenters := range s.ProjectSegmenters {
+		if !matchesProject(projectId) {
+			continue
+		}
+		segmentersCopy := make(map[string]schema.SegmenterType, len(segmenters))
+		for name, segmenterType := range segmenters {
+			segmentersCopy[name] = segmenterType
+		}
+		snapshot.Segmenters[projectId] = segmentersCopy
+	}
+	for projectId, indexes := range s.Experiments {
+		if !matchesProject(projectId) {
+			continue
+		}
+		// The indexes are replaced rather than modified on update, so they can be shared
+		indexesCopy := []*ExperimentIndex{}
+		for _, index := range indexes {
+			if filter.ExperimentId == nil || (index.Experiment != nil && index.Experiment.Id == *filter.ExperimentId) {
+				indexesCopy = append(indexesCopy, index)
+			}
+		}
+		snapshot.Experiments[projectId] = indexesCopy
+	}
+	return snapshot
+}
+
+// markUpdated records a change to the cached data. The caller must hold the write lock.
+func (s *LocalStorage) markUpdated() {
+	s.version++
+	s.lastUpdated = time.Now()
+}
+
 func (s *LocalStorage) Init() error {
+	s.loadLock.Lock()
+	defer s.loadLock.Unlock()
+
+	subscribedProjectIds := s.SubscribedProjectIds()
 	var subscribedProjectSettings []*pubsub.ProjectSettings
 	var err error
-	if len(s.subscribedProjectIds) > 0 {
-		subscribedProjectSettings, err = s.getProjectSettings(s.subscribedProjectIds)
+	if len(subscribedProjectIds) > 0 {
+		subscribedProjectSettings, err = s.getProjectSettings(subscribedProjectIds)
 	} else {
 		subscribedProjectSettings, err = s.getAllProjects()
 	}
 	if err != nil {
+		s.SyncStatus.RecordSyncError(s.syncedProjectIds()...)
 		return err
 	}
 
-	if len(s.subscribedProjectIds) > 0 && len(subscribedProjectSettings) != len(s.subscribedProjectIds) {
+	if len(subscribedProjectIds) > 0 && len(subscribedProjectSettings) != len(subscribedProjectIds) {
+		s.SyncStatus.RecordSyncError(s.syncedProjectIds()...)
 		return errors.New("not all subscribed project ids are found")
 	}
 
-	newSegmenters, err := s.fetchProjectSegmenters(subscribedProjectSettings)
+	newSegmenters, newMatchModes, newExpressions, newRequestFields, err := s.fetchProjectSegmenters(
+		subscribedProjectSettings,
+	)
 	if err != nil {
+		s.SyncStatus.RecordSyncError(s.syncedProjectIds()...)
 		return err
 	}
 
-	newExperiments, err := s.fetchExperiments(subscribedProjectSettings, newSegmenters)
+	newExperiments, err := s.fetchExperiments(subscribedProjectSettings, newSegmenters, newMatchModes)
 	if err != nil {
+		s.SyncStatus.RecordSyncError(s.syncedProjectIds()...)
 		return err
 	}
 
 	s.Lock()
-	defer s.Unlock()
 	s.ProjectSegmenters = newSegmenters
+	s.ProjectSegmenterMatchModes = newMatchModes
+	s.ProjectSegmenterExpressions = newExpressions
+	s.ProjectSegmenterRequestFields = newRequestFields
 	s.Experiments = newExperiments
 	s.ProjectSettings = subscribedProjectSettings
+	s.markUpdated()
+	s.Unlock()
 
+	s.SyncStatus.RecordInitialSync(s.syncedProjectIds())
 	return nil
 }
 
+// syncedProjectIds returns the ids of the projects that are synced with the Management Service, which are
+// the subscribed projects, if configured, or else all the projects in the local storage
+func (s *LocalStorage) syncedProjectIds() []ProjectId {
+	s.RLock()
+	defer s.RUnlock()
+	if len(s.subscribedProjectIds) > 0 {
+		return append([]ProjectId{}, s.subscribedProjectIds...)
+	}
+
+	projectIds := []ProjectId{}
+	for _, settings := range s.ProjectSettings {
+		projectIds = append(projectIds, ProjectId(settings.ProjectId))
+	}
+	return projectIds
+}
+
 func (s *LocalStorage) getProjectSettings(projectIds []ProjectId) ([]*pubsub.ProjectSettings, error) {
 	subscribedProjectSettings := make([]*pubsub.ProjectSettings, 0)
 	log.Println("retrieving project settings...")
@@ -556,9 +948,24 @@ func (s *LocalStorage) getProjectSettings(projectIds []ProjectId) ([]*pubsub.Pro
 		if err != nil {
 			return nil, err
 		}
+		if projectSettingsResponse.JSON200 == nil {
+			return nil, fmt.Errorf("error retrieving settings of project %d from xp (%d)", projectId,
+				projectSettingsResponse.StatusCode())
+		}
+		// The passkey hashes are not part of the project settings, and are retrieved separately
+		passkeyHashesResponse, err := s.managementClient.ListProjectPasskeyHashesWithResponse(
+			context.Background(), int64(projectId),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if passkeyHashesResponse.JSON200 == nil {
+			return nil, fmt.Errorf("error retrieving passkey hashes of project %d from xp (%d)", projectId,
+				passkeyHashesResponse.StatusCode())
+		}
 		subscribedProjectSettings = append(
 			subscribedProjectSettings,
-			OpenAPIProjectSettingsSpecToProtobuf(projectSettingsResponse.JSON200.Data),
+			OpenAPIProjectSettingsSpecToProtobuf(projectSettingsResponse.JSON200.Data, passkeyHashesResponse.JSON200.Data),
 		)
 	}
 	return subscribedProjectSettings, nil
@@ -630,6 +1037,7 @@ func NewLocalStorage(
 func (s *LocalStorage) fetchExperiments(
 	subscribedProjectSettings []*pubsub.ProjectSettings,
 	projectSegmenters map[ProjectId]map[string]schema.SegmenterType,
+	projectMatchModes map[ProjectId]map[string]_segmenters.StringMatchMode,
 ) (map[ProjectId][]*ExperimentIndex, error) {
 	log.Println("retrieving project experiments...")
 	index := make(map[ProjectId][]*ExperimentIndex)
@@ -641,6 +1049,7 @@ func (s *LocalStorage) fetchExperiments(
 		activeStatus := schema.ExperimentStatusActive
 
 		segmentersType := projectSegmenters[projectId]
+		matchModes := projectMatchModes[projectId]
 		resp, err := s.managementClient.ListExperimentsWithResponse(
 			context.TODO(),
 			projectSettings.ProjectId,
@@ -653,7 +1062,7 @@ func (s *LocalStorage) fetchExperiments(
 		if resp.StatusCode() == 200 {
 			projectExperiments := resp.JSON200.Data
 			index[projectId] = make([]*ExperimentIndex, 0)
-			index, err = flattenProjectExperiments(projectId, index, projectExperiments, segmentersType)
+			index, err = flattenProjectExperiments(projectId, index, projectExperiments, segmentersType, matchModes)
 			if err != nil {
 				return nil, err
 			}
@@ -674,7 +1083,7 @@ func (s *LocalStorage) fetchExperiments(
 				}
 				if resp.StatusCode() == 200 {
 					projectExperiments := resp.JSON200.Data
-					index, err = flattenProjectExperiments(projectId, index, projectExperiments, segmentersType)
+					index, err = flattenProjectExperiments(projectId, index, projectExperiments, segmentersType, matchModes)
 					if err != nil {
 						return nil, err
 					}
@@ -686,8 +1095,20 @@ func (s *LocalStorage) fetchExperiments(
 	return index, nil
 }
 
-func (s *LocalStorage) fetchProjectSegmenters(settings []*pubsub.ProjectSettings) (map[ProjectId]map[string]schema.SegmenterType, error) {
+// fetchProjectSegmenters retrieves the types of the segmenters of the projects, the match modes of the string
+// segmenters whose values are patterns, the expressions of the segmenters computed from the request, and the
+// request fields of the segmenters that are not read from the field with their name
+func (s *LocalStorage) fetchProjectSegmenters(settings []*pubsub.ProjectSettings) (
+	map[ProjectId]map[string]schema.SegmenterType,
+	map[ProjectId]map[string]_segmenters.StringMatchMode,
+	map[ProjectId]map[string]string,
+	map[ProjectId]map[string][]string,
+	error,
+) {
 	projectSegmenters := make(map[uint32]map[string]schema.SegmenterType)
+	projectMatchModes := make(map[ProjectId]map[string]_segmenters.StringMatchMode)
+	projectExpressions := make(map[ProjectId]map[string]string)
+	projectRequestFields := make(map[ProjectId]map[string][]string)
 	for _, projectSettings := range settings {
 		log.Printf("retrieving project segmenters for %d", projectSettings.ProjectId)
 		segmentersResp, err := s.managementClient.ListSegmentersWithResponse(
@@ -696,28 +1117,86 @@ func (s *LocalStorage) fetchProjectSegmenters(settings []*pubsub.ProjectSettings
 			&managementClient.ListSegmentersParams{},
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, nil, err
 		}
 		segmenters := map[string]schema.SegmenterType{}
+		matchModes := map[string]_segmenters.StringMatchMode{}
+		expressions := map[string]string{}
+		requestFields := map[string][]string{}
 		for _, v := range segmentersResp.JSON200.Data {
 			segmenters[v.Name] = schema.SegmenterType(strings.ToLower(string(v.Type)))
+			if v.MatchMode != nil {
+				matchMode := _segmenters.StringMatchMode(
+					_segmenters.StringMatchMode_value[strings.ToUpper(string(*v.MatchMode))],
+				)
+				if matchMode != _segmenters.StringMatchMode_EXACT {
+					matchModes[v.Name] = matchMode
+				}
+			}
+			if v.Expression != nil && *v.Expression != "" {
+				expressions[v.Name] = *v.Expression
+			}
+			if v.RequestFields != nil && len(*v.RequestFields) > 0 {
+				requestFields[v.Name] = *v.RequestFields
+			}
 		}
 		projectSegmenters[ProjectId(projectSettings.ProjectId)] = segmenters
+		projectMatchModes[ProjectId(projectSettings.ProjectId)] = matchModes
+		projectExpressions[ProjectId(projectSettings.ProjectId)] = expressions
+		projectRequestFields[ProjectId(projectSettings.ProjectId)] = requestFields
 	}
 
-	return projectSegmenters, nil
+	return projectSegmenters, projectMatchModes, projectExpressions, projectRequestFields, nil
 }
 
 func (s *LocalStorage) UpdateProjectSegmenters(segmenter *_segmenters.SegmenterConfiguration, projectId int64) {
 	s.Lock()
 	defer s.Unlock()
 	s.ProjectSegmenters[ProjectId(projectId)][segmenter.Name] = schema.SegmenterType(strings.ToLower(segmenter.Type.String()))
+	if s.ProjectSegmenterMatchModes == nil {
+		s.ProjectSegmenterMatchModes = map[ProjectId]map[string]_segmenters.StringMatchMode{}
+	}
+	if _, ok := s.ProjectSegmenterMatchModes[ProjectId(projectId)]; !ok {
+		s.ProjectSegmenterMatchModes[ProjectId(projectId)] = map[string]_segmenters.StringMatchMode{}
+	}
+	if segmenter.MatchMode != _segmenters.StringMatchMode_EXACT {
+		s.ProjectSegmenterMatchModes[ProjectId(projectId)][segmenter.Name] = segmenter.MatchMode
+	} else {
+		delete(s.ProjectSegmenterMatchModes[ProjectId(projectId)], segmenter.Name)
+	}
+	if s.ProjectSegmenterExpressions == nil {
+		s.ProjectSegmenterExpressions = map[ProjectId]map[string]string{}
+	}
+	if _, ok := s.ProjectSegmenterExpressions[ProjectId(projectId)]; !ok {
+		s.ProjectSegmenterExpressions[ProjectId(projectId)] = map[string]string{}
+	}
+	if segmenter.Expression != "" {
+		s.ProjectSegmenterExpressions[ProjectId(projectId)][segmenter.Name] = segmenter.Expression
+	} else {
+		delete(s.ProjectSegmenterExpressions[ProjectId(projectId)], segmenter.Name)
+	}
+	if s.ProjectSegmenterRequestFields == nil {
+		s.ProjectSegmenterRequestFields = map[ProjectId]map[string][]string{}
+	}
+	if _, ok := s.ProjectSegmenterRequestFields[ProjectId(projectId)]; !ok {
+		s.ProjectSegmenterRequestFields[ProjectId(projectId)] = map[string][]string{}
+	}
+	if len(segmenter.RequestFields) > 0 {
+		s.ProjectSegmenterRequestFields[ProjectId(projectId)][segmenter.Name] = segmenter.RequestFields
+	} else {
+		delete(s.ProjectSegmenterRequestFields[ProjectId(projectId)], segmenter.Name)
+	}
+	s.markUpdated()
 }
 
 func (s *LocalStorage) DeleteProjectSegmenters(segmenterName string, projectId int64) {
 	s.Lock()
 	defer s.Unlock()
 	delete(s.ProjectSegmenters[ProjectId(projectId)], segmenterName)
+	delete(s.ProjectSegmenterMatchModes[ProjectId(projectId)], segmenterName)
+	delete(s.ProjectSegmenterExpressions[ProjectId(projectId)], segmenterName)
+	delete(s.ProjectSegmenterRequestFields[ProjectId(projectId)], segmenterName)
+	s.markUpdated()
 }
 
 func NewProjectId(id int64) ProjectId {
@@ -729,6 +1208,7 @@ func flattenProjectExperiments(
 	projectExperiments map[ProjectId][]*ExperimentIndex,
 	experiments []schema.Experiment,
 	segmentersType map[string]schema.SegmenterType,
+	matchModes map[string]_segmenters.StringMatchMode,
 ) (map[ProjectId][]*ExperimentIndex, error) {
 	for _, projectExperiment := range experiments {
 		protoRecord, err := OpenAPIExperimentSpecToProtobuf(projectExperiment, segmentersType)
@@ -737,7 +1217,7 @@ func flattenProjectExperiments(
 		}
 		projectExperiments[projectId] = append(
 			projectExperiments[projectId],
-			NewExperimentIndex(protoRecord),
+			NewExperimentIndex(protoRecord, matchModes),
 		)
 	}
 
@@ -18,6 +18,7 @@ import (
 	"github.com/golang/geo/s2"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -28,6 +29,7 @@ import (
 	_pubsub "github.com/caraml-dev/xp/common/pubsub"
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
 	tu "github.com/caraml-dev/xp/common/testutils"
+	_utils "github.com/caraml-dev/xp/common/utils"
 )
 
 type LocalStorageLookupSuite struct {
@@ -76,7 +78,6 @@ func newTestXPExperiment(
 
 func newProjectSettings(
 	enableS2idClustering bool,
-	passkey string,
 	projectId int64,
 	randomizationKey string,
 	segmenters []string,
@@ -96,7 +97,6 @@ func newProjectSettings(
 	return schema.ProjectSettings{
 		CreatedAt:            createdAt,
 		EnableS2idClustering: enableS2idClustering,
-		Passkey:              passkey,
 		ProjectId:            projectId,
 		RandomizationKey:     randomizationKey,
 		Segmenters:           formattedSegmenters,
@@ -141,18 +141,19 @@ func (suite *LocalStorageLookupSuite) SetupTest() {
 		e, err := OpenAPIExperimentSpecToProtobuf(experiment, segmentersType)
 		suite.Require().NoError(err)
 		suite.testExperiments = append(suite.testExperiments, e)
-		suite.storage.Experiments[projectId] = append(suite.storage.Experiments[projectId], NewExperimentIndex(e))
+		suite.storage.Experiments[projectId] = append(suite.storage.Experiments[projectId], NewExperimentIndex(e, nil))
 	}
-	addProjectSettings := func(projectSettings schema.ProjectSettings) {
-		e := OpenAPIProjectSettingsSpecToProtobuf(projectSettings)
+	addProjectSettings := func(projectSettings schema.ProjectSettings, passkey string) {
+		passkeyHashes := []schema.ProjectPasskeyHash{{Name: "default", Hash: _utils.HashPasskey(passkey)}}
+		e := OpenAPIProjectSettingsSpecToProtobuf(projectSettings, passkeyHashes)
 		suite.storage.ProjectSettings = append(suite.storage.ProjectSettings, e)
 	}
 
 	// Add Projects
 	addProjectSettings(newProjectSettings(
-		false, "passkey", 1, "randomkey", []string{"string_segmenter", "integer_segmenter", "integer_segmenter_2"}, "user1"))
+		false, 1, "randomkey", []string{"string_segmenter", "integer_segmenter", "integer_segmenter_2"}, "user1"), "passkey")
 	addProjectSettings(newProjectSettings(
-		false, "passkey", 2, "randomkey", []string{"string_segmenter"}, "user2"))
+		false, 2, "randomkey", []string{"string_segmenter"}, "user2"), "passkey")
 
 	// Add Experiments
 	suite.location = s2.CellIDFromLatLng(s2.LatLngFromDegrees(1.4093768560366384, 103.79392188731705))
@@ -459,7 +460,8 @@ func (suite *LocalStorageLookupSuite) TestGetProjectSettings() {
 	projectSettings := suite.storage.FindProjectSettingsWithId(1)
 
 	suite.Require().Equal("user1", projectSettings.Username)
-	suite.Require().Equal("passkey", projectSettings.Passkey)
+	suite.Require().Len(projectSettings.Passkeys, 1)
+	suite.Require().Equal(_utils.HashPasskey("passkey"), projectSettings.Passkeys[0].Hash)
 }
 
 func (suite *LocalStorageLookupSuite) TestInsertAndUpdateProjectSettings() {
@@ -528,7 +530,7 @@ func TestDumpExperiments(t *testing.T) {
 	e.Id = 81
 	storage := LocalStorage{
 		Experiments: map[ProjectId][]*ExperimentIndex{
-			1: {NewExperimentIndex(e)},
+			1: {NewExperimentIndex(e, nil)},
 		},
 	}
 
@@ -555,6 +557,65 @@ func TestDumpExperiments(t *testing.T) {
 	assert.Equal(t, true, reflect.DeepEqual(string(baselineBytes), string(actualBytes)))
 }
 
+func TestSnapshot(t *testing.T) {
+	newExperiment := func(projectId int64, id int64) *_pubsub.Experiment {
+		return &_pubsub.Experiment{
+			ProjectId: projectId,
+			Id:        id,
+			Status:    _pubsub.Experiment_Active,
+			StartTime: timestamppb.New(time.Date(2021, 1, 2, 3, 5, 7, 0, time.UTC)),
+			EndTime:   timestamppb.New(time.Date(2022, 1, 2, 3, 5, 7, 0, time.UTC)),
+		}
+	}
+	storage := LocalStorage{
+		Experiments: map[ProjectId][]*ExperimentIndex{},
+		ProjectSettings: []*_pubsub.ProjectSettings{
+			{
+				ProjectId: 1,
+				Passkey:   "legacy-secret",
+				Passkeys:  []*_pubsub.ProjectPasskey{{Name: "default", Hash: "secret-1"}},
+			},
+			{ProjectId: 2, Passkeys: []*_pubsub.ProjectPasskey{{Name: "default", Hash: "secret-2"}}},
+		},
+		ProjectSegmenters: map[ProjectId]map[string]schema.SegmenterType{
+			1: {"string_segmenter": "string"},
+			2: {"integer_segmenter": "integer"},
+		},
+	}
+	storage.InsertExperiment(newExperiment(1, 10))
+	storage.InsertExperiment(newExperiment(1, 11))
+	storage.InsertExperiment(newExperiment(2, 20))
+
+	// All data, with the passkey hashes redacted and the legacy passkey omitted
+	snapshot := storage.Snapshot(CacheFilter{})
+	assert.Equal(t, uint64(3), snapshot.Version)
+	assert.False(t, snapshot.LastUpdated.IsZero())
+	require.Len(t, snapshot.ProjectSettings, 2)
+	assert.Equal(t, redactedPasskey, snapshot.ProjectSettings[0].Passkeys[0].Hash)
+	assert.Equal(t, "secret-1", storage.ProjectSettings[0].Passkeys[0].Hash)
+	assert.Empty(t, snapshot.ProjectSettings[0].Passkey)
+	assert.Equal(t, "legacy-secret", storage.ProjectSettings[0].Passkey)
+	assert.Len(t, snapshot.Segmenters, 2)
+	assert.Len(t, snapshot.Experiments[1], 2)
+	assert.Len(t, snapshot.Experiments[2], 1)
+
+	// Filter by project and experiment
+	projectId := ProjectId(1)
+	experimentId := int64(11)
+	snapshot = storage.Snapshot(CacheFilter{ProjectId: &projectId, ExperimentId: &experimentId})
+	require.Len(t, snapshot.ProjectSettings, 1)
+	assert.Equal(t, int64(1), snapshot.ProjectSettings[0].ProjectId)
+	assert.Equal(t, map[ProjectId]map[string]schema.SegmenterType{1: {"string_segmenter": "string"}}, snapshot.Segmenters)
+	require.Len(t, snapshot.Experiments, 1)
+	require.Len(t, snapshot.Experiments[1], 1)
+	assert.Equal(t, int64(11), snapshot.Experiments[1][0].Experiment.Id)
+
+	// Changes to the cache are not reflected in the snapshot
+	storage.DeleteProjectSegmenters("string_segmenter", 1)
+	assert.Len(t, snapshot.Segmenters[1], 1)
+	assert.Equal(t, uint64(4), storage.Snapshot(CacheFilter{}).Version)
+}
+
 func TestExperimentLookupSuite(t *testing.T) {
 	suite.Run(t, new(LocalStorageLookupSuite))
 }
@@ -684,6 +745,157 @@ func TestExperimentIndexMatchSegment(t *testing.T) {
 	}
 }
 
+func TestExperimentIndexMatchRangeSegment(t *testing.T) {
+	segmentersType := map[string]schema.SegmenterType{
+		"age":         "integer",
+		"order_value": "real",
+	}
+	e, err := OpenAPIExperimentSpecToProtobuf(newTestXPExperiment(
+		1,
+		schema.ExperimentSegment{
+			"age": []interface{}{
+				map[string]interface{}{"min": float64(18), "max": float64(25), "max_exclusive": true},
+				float64(30),
+			},
+			"order_value": []interface{}{
+				map[string]interface{}{"min": float64(50), "min_exclusive": true},
+			},
+		},
+		time.Now(),
+		time.Now().Add(time.Hour),
+	), segmentersType)
+	require.NoError(t, err)
+	experimentIndex := NewExperimentIndex(e, nil)
+
+	intValue := func(value int64) []*_segmenters.SegmenterValue {
+		return []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_Integer{Integer: value}}}
+	}
+	realValue := func(value float64) []*_segmenters.SegmenterValue {
+		return []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_Real{Real: value}}}
+	}
+
+	tests := map[string]struct {
+		segmentName string
+		value       []*_segmenters.SegmenterValue
+		want        MatchStrength
+	}{
+		"integer | inclusive bound": {
+			segmentName: "age",
+			value:       intValue(18),
+			want:        MatchStrengthExact,
+		},
+		"integer | exclusive bound": {
+			segmentName: "age",
+			value:       intValue(25),
+			want:        MatchStrengthNone,
+		},
+		"integer | exact value": {
+			segmentName: "age",
+			value:       intValue(30),
+			want:        MatchStrengthExact,
+		},
+		"integer | no value": {
+			segmentName: "age",
+			want:        MatchStrengthNone,
+		},
+		"real | exclusive bound": {
+			segmentName: "order_value",
+			value:       realValue(50),
+			want:        MatchStrengthNone,
+		},
+		"real | open-ended range": {
+			segmentName: "order_value",
+			value:       realValue(1e6),
+			want:        MatchStrengthExact,
+		},
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, data.want, experimentIndex.matchSegment(data.segmentName, data.value).Strength)
+		})
+	}
+
+	// The ranges are included in the index log
+	indexJSON, err := json.Marshal(experimentIndex)
+	require.NoError(t, err)
+	assert.Contains(t, string(indexJSON), `"IntRanges":{"age":["[18,25)"]},"RealRanges":{"order_value":["(50,)"]}`)
+}
+
+func TestExperimentIndexMatchStringPatternSegment(t *testing.T) {
+	segmentersType := map[string]schema.SegmenterType{
+		"merchant_id": "string",
+		"device":      "string",
+		"country":     "string",
+	}
+	matchModes := map[string]_segmenters.StringMatchMode{
+		"merchant_id": _segmenters.StringMatchMode_PREFIX,
+		"device":      _segmenters.StringMatchMode_REGEX,
+	}
+	e, err := OpenAPIExperimentSpecToProtobuf(newTestXPExperiment(
+		1,
+		schema.ExperimentSegment{
+			"merchant_id": []interface{}{"ID-", "SG-12"},
+			"device":      []interface{}{"^android-1[0-3]$"},
+			"country":     []interface{}{"ID"},
+		},
+		time.Now(),
+		time.Now().Add(time.Hour),
+	), segmentersType)
+	require.NoError(t, err)
+	experimentIndex := NewExperimentIndex(e, matchModes)
+
+	stringValue := func(value string) []*_segmenters.SegmenterValue {
+		return []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_String_{String_: value}}}
+	}
+
+	tests := map[string]struct {
+		segmentName string
+		value       []*_segmenters.SegmenterValue
+		want        MatchStrength
+	}{
+		"prefix | match": {
+			segmentName: "merchant_id",
+			value:       stringValue("SG-1234"),
+			want:        MatchStrengthExact,
+		},
+		"prefix | no match": {
+			segmentName: "merchant_id",
+			value:       stringValue("SG-2234"),
+			want:        MatchStrengthNone,
+		},
+		"prefix | no value": {
+			segmentName: "merchant_id",
+			want:        MatchStrengthNone,
+		},
+		"regex | match": {
+			segmentName: "device",
+			value:       stringValue("android-12"),
+			want:        MatchStrengthExact,
+		},
+		"regex | no match": {
+			segmentName: "device",
+			value:       stringValue("android-14"),
+			want:        MatchStrengthNone,
+		},
+		"exact | value is not a prefix": {
+			segmentName: "country",
+			value:       stringValue("IDN"),
+			want:        MatchStrengthNone,
+		},
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, data.want, experimentIndex.matchSegment(data.segmentName, data.value).Strength)
+		})
+	}
+
+	// The patterns are included in the index log
+	indexJSON, err := json.Marshal(experimentIndex)
+	require.NoError(t, err)
+	assert.Contains(t, string(indexJSON),
+		`"StringPatterns":{"device":["^android-1[0-3]$"],"merchant_id":["ID-","SG-12"]}`)
+}
+
 func TestCustomSegmenter(t *testing.T) {
 
 	projectId := ProjectId(1)
@@ -721,7 +933,7 @@ func TestCustomSegmenter(t *testing.T) {
 	e, err := OpenAPIExperimentSpecToProtobuf(experiment, segmenterTypeMapping)
 	assert.NoError(t, err)
 	storage.Experiments[projectId] = make([]*ExperimentIndex, 0)
-	storage.Experiments[projectId] = append(storage.Experiments[projectId], NewExperimentIndex(e))
+	storage.Experiments[projectId] = append(storage.Experiments[projectId], NewExperimentIndex(e, nil))
 
 	experimentmatch := storage.FindExperiments(
 		projectId,
@@ -735,3 +947,116 @@ func TestCustomSegmenter(t *testing.T) {
 			{Key: segmenterName, Value: []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_String_{String_: "stringval"}}}}})
 	assert.Equal(t, 1, len(experimentmatch))
 }
+
+func TestSubscribeAndUnsubscribeProject(t *testing.T) {
+	jsonResponse := func(body string) *http.Response {
+		return &http.Response{
+			StatusCode: 200,
+			Header:     map[string][]string{"Content-Type": {"json"}},
+			Body:       io.NopCloser(bytes.NewBufferString(body)),
+		}
+	}
+	experiment := newTestXPExperiment(2, schema.ExperimentSegment{}, time.Now(), time.Now().Add(time.Hour))
+	experimentJson, err := json.Marshal(experiment)
+	require.NoError(t, err)
+
+	mockManagementClientInterface := mocks.ClientInterface{}
+	// Project 2 is loaded twice, and the response bodies can only be read once
+	for i := 0; i < 2; i++ {
+		mockManagementClientInterface.On("GetProjectSettings", mock.Anything, int64(2)).
+			Return(jsonResponse(`{"data": {"project_id": 2, "username": "user2"}}`), nil).Once()
+		mockManagementClientInterface.On("ListProjectPasskeyHashes", mock.Anything, int64(2)).
+			Return(jsonResponse(`{"data": [{"name": "default", "hash": "hash-2"}]}`), nil).Once()
+		mockManagementClientInterface.On("ListSegmenters", mock.Anything, int64(2), mock.Anything).
+			Return(jsonResponse(`{"data": [{"name": "string_segmenter", "type": "STRING"}]}`), nil).Once()
+		mockManagementClientInterface.On("ListExperiments", mock.Anything, int64(2), mock.Anything).
+			Return(jsonResponse(fmt.Sprintf(`{"data": [%s]}`, experimentJson)), nil).Once()
+	}
+	mockManagementClientInterface.On("GetProjectSettings", mock.Anything, int64(3)).
+		Return(&http.Response{StatusCode: 404, Body: io.NopCloser(bytes.NewBufferString(""))}, nil)
+
+	storage := LocalStorage{
+		Experiments:          map[ProjectId][]*ExperimentIndex{1: {}},
+		ProjectSettings:      []*_pubsub.ProjectSettings{{ProjectId: 1}},
+		ProjectSegmenters:    map[ProjectId]map[string]schema.SegmenterType{1: {}},
+		managementClient:     &managementClient.ClientWithResponses{ClientInterface: &mockManagementClientInterface},
+		subscribedProjectIds: []ProjectId{1},
+	}
+	assert.False(t, storage.IsSubscribed(2))
+	assert.Nil(t, storage.FindProjectSettingsWithId(2))
+
+	// Subscribing loads all the data of the project
+	require.NoError(t, storage.SubscribeProject(2))
+	assert.True(t, storage.IsSubscribed(2))
+	assert.Equal(t, []ProjectId{1, 2}, storage.SubscribedProjectIds())
+	require.NotNil(t, storage.FindProjectSettingsWithId(2))
+	assert.Equal(t, "user2", storage.FindProjectSettingsWithId(2).Username)
+	require.Len(t, storage.FindProjectSettingsWithId(2).Passkeys, 1)
+	assert.Equal(t, "hash-2", storage.FindProjectSettingsWithId(2).Passkeys[0].Hash)
+	assert.Equal(t, map[string]schema.SegmenterType{"string_segmenter": "string"}, storage.ProjectSegmenters[2])
+	require.Len(t, storage.Experiments[2], 1)
+	assert.Equal(t, *experiment.Id, storage.Experiments[2][0].Experiment.Id)
+
+	// Subscribing again reloads the project, without duplicating it
+	require.NoError(t, storage.SubscribeProject(2))
+	assert.Equal(t, []ProjectId{1, 2}, storage.SubscribedProjectIds())
+	assert.Len(t, storage.ProjectSettings, 2)
+
+	// A project that does not exist is not subscribed to
+	assert.EqualError(t, storage.SubscribeProject(3), "error retrieving settings of project 3 from xp (404)")
+	assert.False(t, storage.IsSubscribed(3))
+
+	// Unsubscribing removes the data of the project
+	require.NoError(t, storage.UnsubscribeProject(2))
+	assert.False(t, storage.IsSubscribed(2))
+	assert.Nil(t, storage.FindProjectSettingsWithId(2))
+	assert.NotContains(t, storage.ProjectSegmenters, ProjectId(2))
+	assert.NotContains(t, storage.Experiments, ProjectId(2))
+
+	assert.EqualError(t, storage.UnsubscribeProject(2), "project 2 is not subscribed to")
+	assert.EqualError(t, storage.UnsubscribeProject(1), "project 1 is the only subscribed project")
+}
+
+func TestFindProjectSettingsLoadsNewProject(t *testing.T) {
+	mockManagementClientInterface := mocks.ClientInterface{}
+	mockManagementClientInterface.On("GetProjectSettings", mock.Anything, int64(2)).
+		Return(&http.Response{
+			StatusCode: 200,
+			Header:     map[string][]string{"Content-Type": {"json"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": {"project_id": 2, "username": "user2"}}`)),
+		}, nil).Once()
+	mockManagementClientInterface.On("ListProjectPasskeyHashes", mock.Anything, int64(2)).
+		Return(&http.Response{
+			StatusCode: 200,
+			Header:     map[string][]string{"Content-Type": {"json"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": []}`)),
+		}, nil).Once()
+	mockManagementClientInterface.On("ListSegmenters", mock.Anything, int64(2), mock.Anything).
+		Return(&http.Response{
+			StatusCode: 200,
+			Header:     map[string][]string{"Content-Type": {"json"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": []}`)),
+		}, nil).Once()
+	mockManagementClientInterface.On("ListExperiments", mock.Anything, int64(2), mock.Anything).
+		Return(&http.Response{
+			StatusCode: 200,
+			Header:     map[string][]string{"Content-Type": {"json"}},
+			Body:       io.NopCloser(bytes.NewBufferString(`{"data": []}`)),
+		}, nil).Once()
+
+	// All projects are subscribed to, so a project created after the initial sync is loaded on its first request
+	storage := LocalStorage{
+		Experiments:       map[ProjectId][]*ExperimentIndex{},
+		ProjectSegmenters: map[ProjectId]map[string]schema.SegmenterType{},
+		managementClient:  &managementClient.ClientWithResponses{ClientInterface: &mockManagementClientInterface},
+	}
+	for i := 0; i < 2; i++ {
+		projectSettings := storage.FindProjectSettingsWithId(2)
+		require.NotNil(t, projectSettings)
+		assert.Equal(t, "user2", projectSettings.Username)
+	}
+	assert.Contains(t, storage.ProjectSegmenters, ProjectId(2))
+	assert.Contains(t, storage.Experiments, ProjectId(2))
+	assert.Empty(t, storage.SubscribedProjectIds())
+	mockManagementClientInterface.AssertExpectations(t)
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/caraml-dev/mlp/api/pkg/instrumentation/metrics"
+
+	"github.com/caraml-dev/xp/treatment-service/instrumentation"
+)
+
+// SyncStatus tracks the synchronisation of the local storage with the Management Service, through the
+// initial sync, the poller and the message queue subscriber.
+type SyncStatus struct {
+	sync.RWMutex
+
+	initialSyncCompleted bool
+	// lastSyncTime is the time of the last successful poll or message, across all projects
+	lastSyncTime     time.Time
+	projectSyncTimes map[ProjectId]time.Time
+
+	subscriberRunning bool
+	subscriberErr     error
+}
+
+// RecordInitialSync marks the initial sync of the given projects as completed
+func (s *SyncStatus) RecordInitialSync(projectIds []ProjectId) {
+	s.Lock()
+	s.initialSyncCompleted = true
+	s.Unlock()
+	s.RecordSync(projectIds...)
+}
+
+// RecordSync records a successful sync of the given projects. A sync without project ids, such as
+// a message of a project that is not subscribed to, only shows that the Management Service is reachable.
+func (s *SyncStatus) RecordSync(projectIds ...ProjectId) {
+	now := time.Now()
+	s.Lock()
+	s.lastSyncTime = now
+	if s.projectSyncTimes == nil {
+		s.projectSyncTimes = map[ProjectId]time.Time{}
+	}
+	for _, projectId := range projectIds {
+		s.projectSyncTimes[projectId] = now
+	}
+	s.Unlock()
+
+	for _, projectId := range projectIds {
+		recordLastSyncTimestamp(projectId, now)
+	}
+}
+
+// RecordSyncError records a failed sync of the given projects
+func (s *SyncStatus) RecordSyncError(projectIds ...ProjectId) {
+	for _, projectId := range projectIds {
+		err := metrics.Glob().Inc(instrumentation.SyncErrorCount, projectLabels(projectId))
+		if err != nil {
+			log.Printf("error while logging %s metrics: %s", instrumentation.SyncErrorCount, err)
+		}
+	}
+}
+
+// PublishMetrics records the time of the last successful sync of each project, for the syncs that
+// happened before the metrics collector was initialised
+func (s *SyncStatus) PublishMetrics() {
+	s.RLock()
+	defer s.RUnlock()
+	for projectId, syncTime := range s.projectSyncTimes {
+		recordLastSyncTimestamp(projectId, syncTime)
+	}
+}
+
+// SetSubscriberRunning records that the message queue subscriber has started receiving messages
+func (s *SyncStatus) SetSubscriberRunning() {
+	s.Lock()
+	defer s.Unlock()
+	s.subscriberRunning = true
+	s.subscriberErr = nil
+}
+
+// SetSubscriberStopped records that the message queue subscriber has stopped, with the error, if any
+func (s *SyncStatus) SetSubscriberStopped(err error) {
+	s.Lock()
+	defer s.Unlock()
+	s.subscriberRunning = false
+	s.subscriberErr = err
+}
+
+// CheckInitialSync returns an error if the initial sync has not completed
+func (s *SyncStatus) CheckInitialSync() error {
+	s.RLock()
+	defer s.RUnlock()
+	if !s.initialSyncCompleted {
+		return errors.New("the initial sync with the Management Service has not completed")
+	}
+	return nil
+}
+
+// CheckStaleness returns an error if the last successful sync is older than the given duration
+func (s *SyncStatus) CheckStaleness(maxStaleness time.Duration) error {
+	s.RLock()
+	defer s.RUnlock()
+	if s.lastSyncTime.IsZero() {
+		return errors.New("the local storage has not been synced")
+	}
+	if staleness := time.Since(s.lastSyncTime); staleness > maxStaleness {
+		return fmt.Errorf("the local storage was last synced %s ago, exceeding the limit of %s",
+			staleness.Truncate(time.Second), maxStaleness)
+	}
+	return nil
+}
+
+// CheckSubscriber returns an error if the message queue subscriber is not running
+func (s *SyncStatus) CheckSubscriber() error {
+	s.RLock()
+	defer s.RUnlock()
+	if s.subscriberRunning {
+		return nil
+	}
+	if s.subscriberErr != nil {
+		return fmt.Errorf("the message queue subscriber has stopped: %w", s.subscriberErr)
+	}
+	return errors.New("the message queue subscriber is not running")
+}
+
+func recordLastSyncTimestamp(projectId ProjectId, syncTime time.Time) {
+	err := metrics.Glob().RecordGauge(
+		instrumentation.LastSyncTimestamp, float64(syncTime.Unix()), projectLabels(projectId),
+	)
+	if err != nil {
+		log.Printf("error while logging %s metrics: %s", instrumentation.LastSyncTimestamp, err)
+	}
+}
+
+func projectLabels(projectId ProjectId) map[string]string {
+	return map[string]string{"project_id": strconv.Itoa(int(projectId))}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncStatusInitialSync(t *testing.T) {
+	status := SyncStatus{}
+	assert.EqualError(t, status.CheckInitialSync(), "the initial sync with the Management Service has not completed")
+	assert.EqualError(t, status.CheckStaleness(time.Minute), "the local storage has not been synced")
+
+	status.RecordInitialSync([]ProjectId{1, 2})
+	assert.NoError(t, status.CheckInitialSync())
+	assert.NoError(t, status.CheckStaleness(time.Minute))
+	assert.Len(t, status.projectSyncTimes, 2)
+}
+
+func TestSyncStatusStaleness(t *testing.T) {
+	status := SyncStatus{}
+	status.RecordSync(1)
+	status.lastSyncTime = time.Now().Add(-2 * time.Minute)
+	assert.ErrorContains(t, status.CheckStaleness(time.Minute), "exceeding the limit of 1m0s")
+
+	// Messages of any project show that the local storage is in sync
+	status.RecordSync()
+	assert.NoError(t, status.CheckStaleness(time.Minute))
+	assert.Len(t, status.projectSyncTimes, 1)
+}
+
+func TestSyncStatusSubscriber(t *testing.T) {
+	status := SyncStatus{}
+	assert.EqualError(t, status.CheckSubscriber(), "the message queue subscriber is not running")
+
+	status.SetSubscriberRunning()
+	assert.NoError(t, status.CheckSubscriber())
+
+	status.SetSubscriberStopped(errors.New("subscription not found"))
+	assert.EqualError(t, status.CheckSubscriber(), "the message queue subscriber has stopped: subscription not found")
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"fmt"
 	"reflect"
 	"time"
 
@@ -19,7 +20,12 @@ type ExperimentTreatment struct {
 	Traffic       *int32                 `json:"traffic,omitempty"`
 }
 
-func OpenAPIProjectSettingsSpecToProtobuf(projectSettings schema.ProjectSettings) *_pubsub.ProjectSettings {
+// OpenAPIProjectSettingsSpecToProtobuf converts the project settings, together with the passkey hashes of the
+// project, which are retrieved separately, to the format of the message queue
+func OpenAPIProjectSettingsSpecToProtobuf(
+	projectSettings schema.ProjectSettings,
+	passkeyHashes []schema.ProjectPasskeyHash,
+) *_pubsub.ProjectSettings {
 	variables := map[string]*_pubsub.ExperimentVariables{}
 	for k, v := range projectSettings.Segmenters.Variables.AdditionalProperties {
 		experimentVariables := []string{}
@@ -32,16 +38,24 @@ func OpenAPIProjectSettingsSpecToProtobuf(projectSettings schema.ProjectSettings
 		Names:     projectSettings.Segmenters.Names,
 		Variables: variables,
 	}
+	passkeys := []*_pubsub.ProjectPasskey{}
+	for _, p := range passkeyHashes {
+		passkey := &_pubsub.ProjectPasskey{Name: p.Name, Hash: p.Hash}
+		if p.ExpiresAt != nil {
+			passkey.ExpiresAt = timestamppb.New(*p.ExpiresAt)
+		}
+		passkeys = append(passkeys, passkey)
+	}
 
 	return &_pubsub.ProjectSettings{
 		ProjectId:            projectSettings.ProjectId,
 		CreatedAt:            &timestamppb.Timestamp{Seconds: projectSettings.CreatedAt.Unix()},
 		UpdatedAt:            &timestamppb.Timestamp{Seconds: projectSettings.UpdatedAt.Unix()},
 		Username:             projectSettings.Username,
-		Passkey:              projectSettings.Passkey,
 		EnableS2IdClustering: projectSettings.EnableS2idClustering,
 		Segmenters:           segmenters,
 		RandomizationKey:     projectSettings.RandomizationKey,
+		Passkeys:             passkeys,
 	}
 }
 
@@ -89,29 +103,68 @@ func OpenAPIExperimentSpecToProtobuf(
 			vals := val.([]interface{})
 			switch segmentersType[key] {
 			case "string":
+				if key == _utils.AppVersionSegmenter {
+					// Version constraints are matched as the ranges of the encoded versions
+					versionVals := []*_segmenters.SegmenterValue{}
+					for _, val := range vals {
+						segmenterValue, err := _utils.ParseVersionConstraint(val.(string))
+						if err != nil {
+							return nil, fmt.Errorf("invalid value for segmenter %s: %s", key, err)
+						}
+						versionVals = append(versionVals, segmenterValue)
+					}
+					segments[key] = &_segmenters.ListSegmenterValue{Values: versionVals}
+					continue
+				}
 				stringVals := []string{}
 				for _, val := range vals {
 					stringVals = append(stringVals, val.(string))
 				}
+				if key == _utils.DatesSegmenter {
+					// Date ranges are matched as the dates they contain
+					stringVals = _utils.ExpandDateValues(stringVals)
+				}
 				segments[key] = _utils.StringSliceToListSegmenterValue(&stringVals)
 			case "integer":
-				intVals := []int64{}
+				intVals := []*_segmenters.SegmenterValue{}
 				for _, val := range vals {
+					if rangeVal, ok := val.(map[string]interface{}); ok {
+						segmenterValue, err := _utils.NewSegmenterRange(rangeVal, _segmenters.SegmenterValueType_INTEGER)
+						if err != nil {
+							return nil, fmt.Errorf("invalid range for segmenter %s: %s", key, err)
+						}
+						intVals = append(intVals, segmenterValue)
+						continue
+					}
 					reflectedVal := reflect.ValueOf(val)
 					switch reflectedVal.Kind() {
 					case reflect.Float32, reflect.Float64:
-						intVals = append(intVals, int64(reflectedVal.Float()))
+						intVals = append(intVals, &_segmenters.SegmenterValue{
+							Value: &_segmenters.SegmenterValue_Integer{Integer: int64(reflectedVal.Float())},
+						})
 					case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-						intVals = append(intVals, reflectedVal.Int())
+						intVals = append(intVals, &_segmenters.SegmenterValue{
+							Value: &_segmenters.SegmenterValue_Integer{Integer: reflectedVal.Int()},
+						})
 					}
 				}
-				segments[key] = _utils.Int64ListToListSegmenterValue(&intVals)
+				segments[key] = &_segmenters.ListSegmenterValue{Values: intVals}
 			case "real":
-				floatVals := []float64{}
+				floatVals := []*_segmenters.SegmenterValue{}
 				for _, val := range vals {
-					floatVals = append(floatVals, val.(float64))
+					if rangeVal, ok := val.(map[string]interface{}); ok {
+						segmenterValue, err := _utils.NewSegmenterRange(rangeVal, _segmenters.SegmenterValueType_REAL)
+						if err != nil {
+							return nil, fmt.Errorf("invalid range for segmenter %s: %s", key, err)
+						}
+						floatVals = append(floatVals, segmenterValue)
+						continue
+					}
+					floatVals = append(floatVals, &_segmenters.SegmenterValue{
+						Value: &_segmenters.SegmenterValue_Real{Real: val.(float64)},
+					})
 				}
-				segments[key] = _utils.FloatListToListSegmenterValue(&floatVals)
+				segments[key] = &_segmenters.ListSegmenterValue{Values: floatVals}
 			case "bool":
 				boolVals := []bool{}
 				for _, val := range vals {
@@ -15,6 +15,7 @@ import (
 )
 
 func TestOpenAPIProjectSettingsSpecToProtobuf(t *testing.T) {
+	expiresAt := time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
 	protoSegmenters := &pubsub.Segmenters{
 		Names: []string{"string_segmenter", "integer_segmenter"},
 		Variables: map[string]*pubsub.ExperimentVariables{
@@ -24,9 +25,10 @@ func TestOpenAPIProjectSettingsSpecToProtobuf(t *testing.T) {
 	}
 
 	tests := []struct {
-		Name     string
-		Settings schema.ProjectSettings
-		Expected *pubsub.ProjectSettings
+		Name          string
+		Settings      schema.ProjectSettings
+		PasskeyHashes []schema.ProjectPasskeyHash
+		Expected      *pubsub.ProjectSettings
 	}{
 		{
 			Name: "basic",
@@ -35,7 +37,10 @@ func TestOpenAPIProjectSettingsSpecToProtobuf(t *testing.T) {
 				UpdatedAt: time.Date(2021, 1, 2, 3, 3, 3, 0, time.UTC),
 				ProjectId: 1,
 				Username:  "client-1",
-				Passkey:   "passkey-1",
+				Passkeys: &[]schema.ProjectPasskey{
+					{Name: "default", Fingerprint: "hash-1"},
+					{Name: "rotated", Fingerprint: "hash-2", ExpiresAt: &expiresAt},
+				},
 				Segmenters: schema.ProjectSegmenters{
 					Names: []string{"string_segmenter", "integer_segmenter"},
 					Variables: schema.ProjectSegmenters_Variables{
@@ -48,6 +53,10 @@ func TestOpenAPIProjectSettingsSpecToProtobuf(t *testing.T) {
 				RandomizationKey:     "rand-1",
 				EnableS2idClustering: true,
 			},
+			PasskeyHashes: []schema.ProjectPasskeyHash{
+				{Name: "default", Hash: "hash-1"},
+				{Name: "rotated", Hash: "hash-2", ExpiresAt: &expiresAt},
+			},
 			Expected: &pubsub.ProjectSettings{
 				ProjectId:            1,
 				CreatedAt:            timestamppb.New(time.Date(2021, 1, 1, 2, 3, 4, 0, time.UTC)),
@@ -55,8 +64,11 @@ func TestOpenAPIProjectSettingsSpecToProtobuf(t *testing.T) {
 				Segmenters:           protoSegmenters,
 				UpdatedAt:            timestamppb.New(time.Date(2021, 1, 2, 3, 3, 3, 0, time.UTC)),
 				Username:             "client-1",
-				Passkey:              "passkey-1",
 				EnableS2IdClustering: true,
+				Passkeys: []*pubsub.ProjectPasskey{
+					{Name: "default", Hash: "hash-1"},
+					{Name: "rotated", Hash: "hash-2", ExpiresAt: timestamppb.New(expiresAt)},
+				},
 			},
 		},
 	}
@@ -64,7 +76,7 @@ func TestOpenAPIProjectSettingsSpecToProtobuf(t *testing.T) {
 	// Run tests
 	for _, data := range tests {
 		t.Run(data.Name, func(t *testing.T) {
-			assert.Equal(t, data.Expected, models.OpenAPIProjectSettingsSpecToProtobuf(data.Settings))
+			assert.Equal(t, data.Expected, models.OpenAPIProjectSettingsSpecToProtobuf(data.Settings, data.PasskeyHashes))
 		})
 	}
 }
@@ -88,7 +100,11 @@ func TestOpenAPIExperimentSpecToProtobuf(t *testing.T) {
 	interval := int32(60)
 	segmentersType := map[string]schema.SegmenterType{
 		"string_segmenter": "string",
+		"app_version":      "string",
+		"dates":            "string",
 	}
+	// The range of the encoded versions 4.12.x
+	minVersion, maxVersion := int64(4<<42+12<<21), int64(4<<42+13<<21-1)
 	pubsubCfg, _ := structpb.NewStruct(map[string]interface{}{
 		"key": "value",
 	})
@@ -107,6 +123,8 @@ func TestOpenAPIExperimentSpecToProtobuf(t *testing.T) {
 				Name:      &name,
 				Segment: &schema.ExperimentSegment{
 					"string_segmenter": []interface{}{"ID"},
+					"app_version":      []interface{}{"4.12.x"},
+					"dates":            []interface{}{"2024-12-31/2025-01-01", "id_public_holidays"},
 				},
 				Status: &statusActive,
 				Treatments: &[]schema.ExperimentTreatment{
@@ -137,6 +155,20 @@ func TestOpenAPIExperimentSpecToProtobuf(t *testing.T) {
 							{Value: &_segmenters.SegmenterValue_String_{String_: "ID"}},
 						},
 					},
+					"app_version": {
+						Values: []*_segmenters.SegmenterValue{
+							{Value: &_segmenters.SegmenterValue_IntegerRange{
+								IntegerRange: &_segmenters.IntegerRange{Min: &minVersion, Max: &maxVersion},
+							}},
+						},
+					},
+					"dates": {
+						Values: []*_segmenters.SegmenterValue{
+							{Value: &_segmenters.SegmenterValue_String_{String_: "2024-12-31"}},
+							{Value: &_segmenters.SegmenterValue_String_{String_: "2025-01-01"}},
+							{Value: &_segmenters.SegmenterValue_String_{String_: "id_public_holidays"}},
+						},
+					},
 				},
 				Status: pubsub.Experiment_Active,
 				Treatments: []*pubsub.ExperimentTreatment{
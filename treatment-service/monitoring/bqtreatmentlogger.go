@@ -17,7 +17,8 @@ import (
 )
 
 type BQLogPublisher struct {
-	table *bigquery.Table
+	client *bigquery.Client
+	table  *bigquery.Table
 }
 
 type BQLogRow struct {
@@ -37,6 +38,10 @@ type BQLogRow struct {
 	TreatmentMetadata string `bigquery:"treatment_metadata"`
 
 	Error string `bigquery:"error"`
+
+	SamplingRate    float64                `bigquery:"sampling_rate"`
+	EventType       string                 `bigquery:"event_type"`
+	ClientTimestamp bigquery.NullTimestamp `bigquery:"client_timestamp"`
 }
 
 func (p *BQLogPublisher) Publish(logs []*AssignedTreatmentLog) error {
@@ -63,6 +68,12 @@ func (p *BQLogPublisher) Publish(logs []*AssignedTreatmentLog) error {
 			RequestId:      l.RequestID,
 			Request:        l.Request,
 			Segment:        string(segmentsJson),
+			SamplingRate:   l.SamplingRate,
+			EventType:      l.EventType,
+		}
+
+		if l.ClientTimestamp != nil {
+			bqlogRow.ClientTimestamp = bigquery.NullTimestamp{Timestamp: *l.ClientTimestamp, Valid: true}
 		}
 
 		if l.Experiment != nil {
@@ -148,7 +159,7 @@ func setupBQTable(
 
 	// Check if the table exists
 	table := dataset.Table(tableName)
-	_, err = table.Metadata(*ctx)
+	metadata, err := table.Metadata(*ctx)
 
 	// If not, create
 	if err != nil && err.(*googleapi.Error).Code == 404 {
@@ -156,11 +167,35 @@ func setupBQTable(
 		if err != nil {
 			return nil, err
 		}
+	} else if err == nil {
+		// Otherwise, add the columns that were introduced after the table was created
+		if missingFields := getMissingBQFields(metadata.Schema, *schema); len(missingFields) > 0 {
+			update := bigquery.TableMetadataToUpdate{Schema: append(metadata.Schema, missingFields...)}
+			if _, err = table.Update(*ctx, update, metadata.ETag); err != nil {
+				return nil, fmt.Errorf("Couldn't update BQ Log table schema: %v", err)
+			}
+		}
 	}
 
 	return table, nil
 }
 
+// getMissingBQFields returns the top-level fields of the expected schema that are not in the actual schema
+func getMissingBQFields(actual bigquery.Schema, expected bigquery.Schema) bigquery.Schema {
+	actualFields := make(map[string]bool, len(actual))
+	for _, field := range actual {
+		actualFields[field.Name] = true
+	}
+
+	missingFields := bigquery.Schema{}
+	for _, field := range expected {
+		if !actualFields[field.Name] {
+			missingFields = append(missingFields, field)
+		}
+	}
+	return missingFields
+}
+
 func NewBQLogPublisher(
 	project string,
 	dataset string,
@@ -185,10 +220,16 @@ func NewBQLogPublisher(
 
 	table, err := setupBQTable(&ctx, client, schema, dataset, tableName)
 	if err != nil {
+		client.Close()
 		return nil, err
 	}
 
-	return &BQLogPublisher{table: table}, nil
+	return &BQLogPublisher{client: client, table: table}, nil
+}
+
+// Close closes the BigQuery client
+func (p *BQLogPublisher) Close() error {
+	return p.client.Close()
 }
 
 // getLogResultTableSchema returns the expected schema defined for logging results to BigQuery
@@ -0,0 +1,195 @@
+package monitoring
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/caraml-dev/xp/treatment-service/config"
+)
+
+// rotatedFileTimeFormat is the format of the timestamp suffix of rotated log files
+const rotatedFileTimeFormat = "20060102T150405.000000000"
+
+// FileLogPublisher writes the assigned treatment logs to an io.Writer, one record at a time,
+// either as newline-delimited JSON or as size-delimited TreatmentServiceResultLogMessage protobufs.
+type FileLogPublisher struct {
+	writer io.Writer
+	format config.LogFormat
+}
+
+func (p *FileLogPublisher) Publish(logs []*AssignedTreatmentLog) error {
+	for _, l := range logs {
+		message, err := newTreatmentServiceResultLogMessage(l, timestamppb.Now())
+		if err != nil {
+			return err
+		}
+
+		switch p.format {
+		case config.ProtobufLogFormat:
+			_, err = protodelim.MarshalTo(p.writer, message)
+		default:
+			var record []byte
+			record, err = protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+			if err == nil {
+				_, err = p.writer.Write(append(record, '\n'))
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("unable to write log entry, %s", err)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the underlying writer, unless it is the standard output
+func (p *FileLogPublisher) Close() error {
+	if closer, ok := p.writer.(io.Closer); ok && p.writer != os.Stdout {
+		return closer.Close()
+	}
+	return nil
+}
+
+func NewFileLogPublisher(writer io.Writer, format config.LogFormat) (*FileLogPublisher, error) {
+	if format != config.JSONLogFormat && format != config.ProtobufLogFormat {
+		return nil, fmt.Errorf("unrecognized log format: %s", format)
+	}
+	return &FileLogPublisher{
+		writer: writer,
+		format: format,
+	}, nil
+}
+
+// rotatingFileWriter is an io.Writer that appends to the file at the given path, and rotates it
+// once it exceeds the max size or has been open for longer than the rotation interval. A zero
+// max size or rotation interval disables the respective rotation trigger.
+type rotatingFileWriter struct {
+	path             string
+	maxSize          int64
+	rotationInterval time.Duration
+	compress         bool
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+
+	// now is overridden in unit tests
+	now func() time.Time
+}
+
+func newRotatingFileWriter(
+	path string,
+	maxSize int64,
+	rotationInterval time.Duration,
+	compress bool,
+) (*rotatingFileWriter, error) {
+	w := &rotatingFileWriter{
+		path:             path,
+		maxSize:          maxSize,
+		rotationInterval: rotationInterval,
+		compress:         compress,
+		now:              time.Now,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *rotatingFileWriter) Write(p []byte) (int, error) {
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingFileWriter) Close() error {
+	return w.file.Close()
+}
+
+func (w *rotatingFileWriter) shouldRotate(writeSize int) bool {
+	// Never rotate an empty file, so that a single record larger than the max size is still written
+	if w.size == 0 {
+		return false
+	}
+	if w.maxSize > 0 && w.size+int64(writeSize) > w.maxSize {
+		return true
+	}
+	return w.rotationInterval > 0 && w.now().Sub(w.openedAt) >= w.rotationInterval
+}
+
+func (w *rotatingFileWriter) open() error {
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open log file %s, %s", w.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("unable to stat log file %s, %s", w.path, err)
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.openedAt = w.now()
+	return nil
+}
+
+func (w *rotatingFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	rotatedPath := fmt.Sprintf("%s.%s", w.path, w.now().UTC().Format(rotatedFileTimeFormat))
+	if err := os.Rename(w.path, rotatedPath); err != nil {
+		return fmt.Errorf("unable to rotate log file %s, %s", w.path, err)
+	}
+	if w.compress {
+		// A failure to compress does not lose any logs, the uncompressed file is kept
+		if err := gzipFile(rotatedPath); err != nil {
+			log.Printf("Failed to compress rotated log file %s: %v", rotatedPath, err)
+		}
+	}
+
+	return w.open()
+}
+
+// gzipFile compresses the file at the given path to path.gz, and removes the original file
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	if _, err = io.Copy(gz, src); err == nil {
+		err = gz.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path + ".gz")
+		return err
+	}
+
+	return os.Remove(path)
+}
@@ -0,0 +1,176 @@
+package monitoring
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
+
+	_pubsub "github.com/caraml-dev/xp/common/pubsub"
+	"github.com/caraml-dev/xp/treatment-service/config"
+)
+
+func testAssignedTreatmentLogs() []*AssignedTreatmentLog {
+	clientTimestamp := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	return []*AssignedTreatmentLog{
+		{
+			ProjectID:  1,
+			RequestID:  "1",
+			Experiment: &_pubsub.Experiment{Id: 1, Name: "test-exp"},
+			Treatment:  &_pubsub.ExperimentTreatment{Name: "control"},
+			Request:    &Request{},
+		},
+		{
+			ProjectID: 1,
+			RequestID: "2",
+			Request:   &Request{},
+			Error:     &ErrorResponseLog{Code: 400, Error: "bad request"},
+		},
+		{
+			EventType:       ExposureEvent,
+			ProjectID:       1,
+			RequestID:       "1",
+			Experiment:      &_pubsub.Experiment{Id: 1, Name: "test-exp"},
+			Treatment:       &_pubsub.ExperimentTreatment{Name: "control"},
+			Request:         &Request{},
+			ClientTimestamp: &clientTimestamp,
+		},
+	}
+}
+
+func TestFileLogPublisherJSON(t *testing.T) {
+	var buf bytes.Buffer
+	publisher, err := NewFileLogPublisher(&buf, config.JSONLogFormat)
+	require.NoError(t, err)
+
+	err = publisher.Publish(testAssignedTreatmentLogs())
+	require.NoError(t, err)
+
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record map[string]interface{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.Len(t, records, 3)
+	assert.Equal(t, "1", records[0]["request_id"])
+	assert.Equal(t, "1", records[0]["experiment_id"])
+	assert.Equal(t, "control", records[0]["treatment_name"])
+	assert.Equal(t, "2", records[1]["request_id"])
+	assert.Equal(t, "{\"Code\":400,\"Error\":\"bad request\"}", records[1]["error"])
+	assert.Equal(t, "exposure", records[2]["event_type"])
+	assert.Equal(t, "2022-01-01T10:00:00Z", records[2]["client_timestamp"])
+}
+
+func TestFileLogPublisherProtobuf(t *testing.T) {
+	var buf bytes.Buffer
+	publisher, err := NewFileLogPublisher(&buf, config.ProtobufLogFormat)
+	require.NoError(t, err)
+
+	err = publisher.Publish(testAssignedTreatmentLogs())
+	require.NoError(t, err)
+
+	reader := bufio.NewReader(&buf)
+	var requestIds []string
+	for {
+		message := &TreatmentServiceResultLogMessage{}
+		err := protodelim.UnmarshalFrom(reader, message)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		requestIds = append(requestIds, message.RequestId)
+	}
+	assert.Equal(t, []string{"1", "2", "1"}, requestIds)
+}
+
+func TestNewFileLogPublisherInvalidFormat(t *testing.T) {
+	_, err := NewFileLogPublisher(&bytes.Buffer{}, "csv")
+	assert.EqualError(t, err, "unrecognized log format: csv")
+}
+
+func TestRotatingFileWriter(t *testing.T) {
+	tests := map[string]struct {
+		maxSize          int64
+		rotationInterval time.Duration
+		compress         bool
+		advance          time.Duration
+		expectedFiles    int
+		expectedSuffix   string
+	}{
+		"no rotation": {
+			maxSize:          100,
+			rotationInterval: time.Hour,
+			expectedFiles:    1,
+		},
+		"rotate by size": {
+			maxSize:       8,
+			expectedFiles: 2,
+		},
+		"rotate by time": {
+			rotationInterval: time.Hour,
+			advance:          time.Hour,
+			expectedFiles:    2,
+		},
+		"rotate and compress": {
+			maxSize:        8,
+			compress:       true,
+			expectedFiles:  2,
+			expectedSuffix: ".gz",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "treatments.log")
+			now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			w, err := newRotatingFileWriter(path, data.maxSize, data.rotationInterval, data.compress)
+			require.NoError(t, err)
+			w.now = func() time.Time { return now }
+			w.openedAt = now
+			defer w.Close()
+
+			_, err = w.Write([]byte("first\n"))
+			require.NoError(t, err)
+			now = now.Add(data.advance)
+			_, err = w.Write([]byte("second\n"))
+			require.NoError(t, err)
+
+			files, err := filepath.Glob(path + "*")
+			require.NoError(t, err)
+			require.Len(t, files, data.expectedFiles)
+
+			current, err := os.ReadFile(path)
+			require.NoError(t, err)
+			if data.expectedFiles == 1 {
+				assert.Equal(t, "first\nsecond\n", string(current))
+				return
+			}
+			assert.Equal(t, "second\n", string(current))
+
+			rotatedPath := path + "." + now.Format(rotatedFileTimeFormat) + data.expectedSuffix
+			rotated, err := os.Open(rotatedPath)
+			require.NoError(t, err)
+			defer rotated.Close()
+			var reader io.Reader = rotated
+			if data.compress {
+				reader, err = gzip.NewReader(rotated)
+				require.NoError(t, err)
+			}
+			contents, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, "first\n", string(contents))
+		})
+	}
+}
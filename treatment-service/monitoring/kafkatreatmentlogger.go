@@ -1,21 +1,23 @@
 package monitoring
 
 import (
-	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
-
-	_utils "github.com/caraml-dev/xp/common/utils"
 )
 
-// kafkaProducer contains GetMetadata and Produce methods for mocking in unit tests
+// kafkaFlushTimeoutMS is the max time to wait for the outstanding messages to be delivered, when closing the producer
+const kafkaFlushTimeoutMS = 10000
+
+// kafkaProducer contains the methods of the Kafka producer that are used, for mocking in unit tests
 type kafkaProducer interface {
 	GetMetadata(*string, bool, int) (*kafka.Metadata, error)
 	Produce(*kafka.Message, chan kafka.Event) error
+	Flush(int) int
+	Close()
 }
 
 type KafkaLogPublisher struct {
@@ -56,6 +58,16 @@ func (p *KafkaLogPublisher) Publish(logs []*AssignedTreatmentLog) error {
 	return nil
 }
 
+// Close waits for the outstanding messages to be delivered, up to kafkaFlushTimeoutMS, and closes the producer
+func (p *KafkaLogPublisher) Close() error {
+	remaining := p.producer.Flush(kafkaFlushTimeoutMS)
+	p.producer.Close()
+	if remaining > 0 {
+		return fmt.Errorf("%d messages were not delivered before the Kafka producer was closed", remaining)
+	}
+	return nil
+}
+
 func NewKafkaLogPublisher(
 	kafkaBrokers string,
 	kafkaTopic string,
@@ -72,6 +84,7 @@ func NewKafkaLogPublisher(
 	// does not already exist on the broker, this should create it.
 	_, err = producer.GetMetadata(&kafkaTopic, false, KafkaConnectTimeoutMS)
 	if err != nil {
+		producer.Close()
 		return nil, fmt.Errorf("error Querying topic %s from Kafka broker(s): %s", kafkaTopic, err)
 	}
 	// Create Kafka Logger
@@ -116,59 +129,10 @@ func newProtobufKafkaLogEntry(
 		return nil, nil, fmt.Errorf("unable to marshal log entry key, %s", err)
 	}
 
-	segments := make(map[string]interface{})
-	for _, s := range log.Segmenters {
-		allValues := []interface{}{}
-		for _, v := range s.Value {
-			allValues = append(allValues, _utils.SegmenterValueToInterface(v))
-		}
-		segments[s.Key] = allValues
-	}
-
-	segmentsJson, err := json.Marshal(segments)
+	message, err := newTreatmentServiceResultLogMessage(log, timestamp)
 	if err != nil {
 		return nil, nil, err
 	}
-	message := &TreatmentServiceResultLogMessage{
-		EventTimestamp: timestamp,
-		ProjectId:      log.ProjectID,
-		RequestId:      log.RequestID,
-		Request:        log.Request,
-		Segment:        string(segmentsJson),
-	}
-
-	if log.Experiment != nil {
-		message.ExperimentId = log.Experiment.Id
-		message.ExperimentName = log.Experiment.Name
-	}
-
-	if log.Treatment != nil {
-		treatmentConfigJson, err := json.Marshal(log.Treatment.Config)
-		if err != nil {
-			return nil, nil, err
-		}
-		treatmentConfig := string(treatmentConfigJson)
-
-		message.TreatmentName = log.Treatment.Name
-		message.TreatmentConfig = treatmentConfig
-	}
-
-	if log.TreatmentMetadata != nil {
-		treatmentMetadata, err := json.Marshal(log.TreatmentMetadata)
-		if err != nil {
-			return nil, nil, err
-		}
-		message.TreatmentMetadata = string(treatmentMetadata)
-	}
-
-	if log.Error != nil {
-		errorJson, err := json.Marshal(log.Error)
-		if err != nil {
-			return nil, nil, err
-		}
-
-		message.Error = string(errorJson)
-	}
 
 	// Marshal the message
 	valueBytes, err = proto.Marshal(message)
@@ -0,0 +1,112 @@
+package monitoring
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/caraml-dev/xp/treatment-service/config"
+	"github.com/caraml-dev/xp/treatment-service/models"
+)
+
+// LogSampler decides which of the assigned treatment logs are published, based on the sampling rate
+// configured for the project or experiment of the log
+type LogSampler struct {
+	defaultRate     float64
+	projectRates    map[models.ProjectId]float64
+	experimentRates map[int64]float64
+}
+
+func NewLogSampler(cfg config.LogSamplingConfig) (*LogSampler, error) {
+	if err := validateSamplingRate(cfg.DefaultRate); err != nil {
+		return nil, fmt.Errorf("invalid default sampling rate: %s", err)
+	}
+
+	projectRates := make(map[models.ProjectId]float64, len(cfg.ProjectRates))
+	for key, rate := range cfg.ProjectRates {
+		projectId, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project id %s in sampling config: %s", key, err)
+		}
+		if err := validateSamplingRate(rate); err != nil {
+			return nil, fmt.Errorf("invalid sampling rate for project %s: %s", key, err)
+		}
+		projectRates[models.NewProjectId(int64(projectId))] = rate
+	}
+
+	experimentRates := make(map[int64]float64, len(cfg.ExperimentRates))
+	for key, rate := range cfg.ExperimentRates {
+		experimentId, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid experiment id %s in sampling config: %s", key, err)
+		}
+		if err := validateSamplingRate(rate); err != nil {
+			return nil, fmt.Errorf("invalid sampling rate for experiment %s: %s", key, err)
+		}
+		experimentRates[experimentId] = rate
+	}
+
+	return &LogSampler{
+		defaultRate:     cfg.DefaultRate,
+		projectRates:    projectRates,
+		experimentRates: experimentRates,
+	}, nil
+}
+
+// Sample determines if the log should be published and sets the sampling rate that applies to it.
+// The decision is deterministic on the randomization key of the log (or the request id, if the
+// randomization key is not available), so that all the requests of a sampled unit are logged.
+func (s *LogSampler) Sample(log *AssignedTreatmentLog) bool {
+	rate := s.samplingRate(log)
+	log.SamplingRate = rate
+
+	switch rate {
+	case 0:
+		return false
+	case 1:
+		return true
+	}
+
+	key := log.RandomizationKey
+	if key == "" {
+		key = log.RequestID
+	}
+	// Salt the key with the project id, so that the sampled units are independent of the treatment
+	// allocation, which hashes the key with the experiment id. Using the same hash for all the rates
+	// of a project means that the units sampled at a lower rate are also sampled at higher rates.
+	hashed := samplingHash(fmt.Sprintf("sampling-%d-%s", log.ProjectID, key))
+	return float64(hashed>>11)/(1<<53) < rate
+}
+
+func (s *LogSampler) samplingRate(log *AssignedTreatmentLog) float64 {
+	if log.Experiment != nil {
+		if rate, ok := s.experimentRates[log.Experiment.Id]; ok {
+			return rate
+		}
+	}
+	if rate, ok := s.projectRates[log.ProjectID]; ok {
+		return rate
+	}
+	return s.defaultRate
+}
+
+// samplingHash is the 64-bit FNV-1a hash of the key, followed by the MurmurHash3 finalizer. FNV on its own
+// does not spread similar keys (e.g., sequential ids) evenly enough for the sampled fraction to match the rate.
+func samplingHash(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	x := h.Sum64()
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+func validateSamplingRate(rate float64) error {
+	if rate < 0 || rate > 1 {
+		return fmt.Errorf("%v is not between 0 and 1", rate)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package monitoring
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	_pubsub "github.com/caraml-dev/xp/common/pubsub"
+	"github.com/caraml-dev/xp/treatment-service/config"
+)
+
+func TestNewLogSamplerInvalidConfig(t *testing.T) {
+	tests := map[string]struct {
+		cfg         config.LogSamplingConfig
+		expectedErr string
+	}{
+		"invalid default rate": {
+			cfg:         config.LogSamplingConfig{DefaultRate: 1.5},
+			expectedErr: "invalid default sampling rate: 1.5 is not between 0 and 1",
+		},
+		"invalid project id": {
+			cfg: config.LogSamplingConfig{
+				DefaultRate:  1,
+				ProjectRates: map[string]float64{"abc": 0.5},
+			},
+			expectedErr: "invalid project id abc in sampling config: strconv.ParseUint: parsing \"abc\": invalid syntax",
+		},
+		"invalid experiment rate": {
+			cfg: config.LogSamplingConfig{
+				DefaultRate:     1,
+				ExperimentRates: map[string]float64{"2": -0.1},
+			},
+			expectedErr: "invalid sampling rate for experiment 2: -0.1 is not between 0 and 1",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewLogSampler(data.cfg)
+			assert.EqualError(t, err, data.expectedErr)
+		})
+	}
+}
+
+func TestLogSamplerSamplingRate(t *testing.T) {
+	sampler, err := NewLogSampler(config.LogSamplingConfig{
+		DefaultRate:     1,
+		ProjectRates:    map[string]float64{"1": 0.5, "2": 0},
+		ExperimentRates: map[string]float64{"10": 0.2},
+	})
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		log          *AssignedTreatmentLog
+		expectedRate float64
+	}{
+		"default rate": {
+			log:          &AssignedTreatmentLog{ProjectID: 3},
+			expectedRate: 1,
+		},
+		"project rate": {
+			log:          &AssignedTreatmentLog{ProjectID: 1, Experiment: &_pubsub.Experiment{Id: 11}},
+			expectedRate: 0.5,
+		},
+		"experiment rate": {
+			log:          &AssignedTreatmentLog{ProjectID: 1, Experiment: &_pubsub.Experiment{Id: 10}},
+			expectedRate: 0.2,
+		},
+		"disabled project": {
+			log:          &AssignedTreatmentLog{ProjectID: 2},
+			expectedRate: 0,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			sampled := sampler.Sample(data.log)
+			assert.Equal(t, data.expectedRate, data.log.SamplingRate)
+			if data.expectedRate == 0 {
+				assert.False(t, sampled)
+			}
+			if data.expectedRate == 1 {
+				assert.True(t, sampled)
+			}
+		})
+	}
+}
+
+func TestLogSamplerDeterministic(t *testing.T) {
+	sampler, err := NewLogSampler(config.LogSamplingConfig{
+		DefaultRate:     0.5,
+		ExperimentRates: map[string]float64{"10": 0.1},
+	})
+	require.NoError(t, err)
+
+	sampledCount := 0
+	for i := 0; i < 1000; i++ {
+		key := fmt.Sprintf("unit-%d", i)
+		sampled := sampler.Sample(&AssignedTreatmentLog{ProjectID: 1, RequestID: "a", RandomizationKey: key})
+
+		// The same unit is always sampled, regardless of the request
+		assert.Equal(t, sampled,
+			sampler.Sample(&AssignedTreatmentLog{ProjectID: 1, RequestID: "b", RandomizationKey: key}))
+
+		// Units sampled at the lower experiment rate are also sampled at the project's rate
+		if sampler.Sample(&AssignedTreatmentLog{
+			ProjectID: 1, RandomizationKey: key, Experiment: &_pubsub.Experiment{Id: 10},
+		}) {
+			assert.True(t, sampled)
+		}
+
+		if sampled {
+			sampledCount++
+		}
+	}
+	assert.InDelta(t, 500, sampledCount, 60)
+}
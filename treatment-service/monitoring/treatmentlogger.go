@@ -1,11 +1,22 @@
 package monitoring
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"sync"
 	"time"
 
+	"github.com/caraml-dev/mlp/api/pkg/instrumentation/metrics"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	_pubsub "github.com/caraml-dev/xp/common/pubsub"
+	_utils "github.com/caraml-dev/xp/common/utils"
 	"github.com/caraml-dev/xp/treatment-service/config"
+	"github.com/caraml-dev/xp/treatment-service/instrumentation"
 	"github.com/caraml-dev/xp/treatment-service/models"
 )
 
@@ -21,7 +32,17 @@ type TreatmentMetadata struct {
 	SwitchbackWindowId *int64 `json:"switchback_window_id"`
 }
 
+type EventType = string
+
+const (
+	// AssignmentEvent is the type of the logs of the treatments assigned in Fetch Treatment requests
+	AssignmentEvent EventType = "assignment"
+	// ExposureEvent is the type of the logs of the treatment exposures reported by the clients
+	ExposureEvent EventType = "exposure"
+)
+
 type AssignedTreatmentLog struct {
+	EventType         EventType
 	ProjectID         models.ProjectId
 	RequestID         string
 	Experiment        *_pubsub.Experiment
@@ -30,94 +51,497 @@ type AssignedTreatmentLog struct {
 	Request           *Request
 	Segmenters        []models.SegmentFilter
 	Error             *ErrorResponseLog
+	// RandomizationKey is the value of the project's randomization key in the request, if available,
+	// which is used to sample the logs
+	RandomizationKey string
+	// SamplingRate is the fraction of the requests that are logged, set when the log is sampled
+	SamplingRate float64
+	// ClientTimestamp is the time of the event as reported by the client, for exposure events
+	ClientTimestamp *time.Time
 }
 
 type AssignedTreatmentPublisher interface {
 	Publish(log []*AssignedTreatmentLog) error
 }
 
+// AssignedTreatmentLoggerOptions captures the queueing and publishing behaviour of the AssignedTreatmentLogger,
+// which is common to all the log publishers
+type AssignedTreatmentLoggerOptions struct {
+	QueueLength     int
+	FlushInterval   time.Duration
+	QueueFullPolicy config.QueueFullPolicy
+	// MaxPublishRetries is the no. of times a failed batch is retried, with an exponential backoff
+	// starting at PublishRetryBackoff and capped at PublishMaxBackoff
+	MaxPublishRetries   int
+	PublishRetryBackoff time.Duration
+	PublishMaxBackoff   time.Duration
+	// ShutdownTimeout is the max duration that Stop waits for the queued logs to be flushed
+	ShutdownTimeout time.Duration
+	// Sampling is the config of the fraction of the logs that are published. If it is not set,
+	// all the logs are published.
+	Sampling *config.LogSamplingConfig
+}
+
+// NewAssignedTreatmentLoggerOptions creates the AssignedTreatmentLoggerOptions from the logger config
+func NewAssignedTreatmentLoggerOptions(cfg config.AssignedTreatmentLoggerConfig) AssignedTreatmentLoggerOptions {
+	return AssignedTreatmentLoggerOptions{
+		QueueLength:         cfg.QueueLength,
+		FlushInterval:       time.Duration(cfg.FlushIntervalSeconds) * time.Second,
+		QueueFullPolicy:     cfg.QueueFullPolicy,
+		MaxPublishRetries:   cfg.MaxPublishRetries,
+		PublishRetryBackoff: time.Duration(cfg.PublishRetryBackoffMS) * time.Millisecond,
+		PublishMaxBackoff:   time.Duration(cfg.PublishMaxBackoffMS) * time.Millisecond,
+		ShutdownTimeout:     time.Duration(cfg.ShutdownTimeoutSeconds) * time.Second,
+		Sampling:            &cfg.SamplingConfig,
+	}
+}
+
+// Reasons for dropping logs, used as the label of the AssignedTreatmentLogDroppedCount metric
+const (
+	droppedQueueFull     = "queue_full"
+	droppedPublishFailed = "publish_failed"
+	droppedShutdown      = "shutdown"
+)
+
 type AssignedTreatmentLogger struct {
 	queue     chan *AssignedTreatmentLog
 	publisher AssignedTreatmentPublisher
+	// mu guards the options and the sampler, which are only replaced by the worker on reload
+	mu      sync.RWMutex
+	options AssignedTreatmentLoggerOptions
+	sampler *LogSampler
 
-	flushInterval time.Duration
+	// reloadChannel passes the reload requests to the worker
+	reloadChannel chan loggerReload
+	// stopChannel is closed when the logger is stopped, and doneChannel is closed when
+	// the worker has flushed the remaining logs and exited
+	stopChannel chan struct{}
+	doneChannel chan struct{}
+	stopOnce    sync.Once
 }
 
-func (l *AssignedTreatmentLogger) Append(log *AssignedTreatmentLog) error {
-	l.queue <- log
+// loggerReload is a request to the worker to replace the publisher and the options of the logger. The done
+// channel is closed once they have been replaced.
+type loggerReload struct {
+	publisher AssignedTreatmentPublisher
+	options   AssignedTreatmentLoggerOptions
+	sampler   *LogSampler
+	done      chan struct{}
+}
+
+func newAssignedTreatmentLogger(
+	publisher AssignedTreatmentPublisher,
+	options AssignedTreatmentLoggerOptions,
+) (*AssignedTreatmentLogger, error) {
+	sampler, err := validateLoggerOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	logger := &AssignedTreatmentLogger{
+		queue:         make(chan *AssignedTreatmentLog, options.QueueLength),
+		publisher:     publisher,
+		options:       options,
+		sampler:       sampler,
+		reloadChannel: make(chan loggerReload),
+		stopChannel:   make(chan struct{}),
+		doneChannel:   make(chan struct{}),
+	}
+
+	go logger.worker()
+
+	return logger, nil
+}
+
+// validateLoggerOptions checks the queueing options, and creates the sampler of the logs if configured
+func validateLoggerOptions(options AssignedTreatmentLoggerOptions) (*LogSampler, error) {
+	switch options.QueueFullPolicy {
+	case config.BlockPolicy, config.DropNewestPolicy, config.DropOldestPolicy:
+	default:
+		return nil, fmt.Errorf("unrecognized queue full policy: %s", options.QueueFullPolicy)
+	}
+	if options.QueueFullPolicy == config.DropOldestPolicy && options.QueueLength < 1 {
+		return nil, fmt.Errorf("queue length must be positive for the %s policy", config.DropOldestPolicy)
+	}
+
+	if options.Sampling == nil {
+		return nil, nil
+	}
+	return NewLogSampler(*options.Sampling)
+}
+
+// Reload replaces the publisher and the options of the logger. The queued logs are published with the
+// current publisher, which is then closed. The length of the queue cannot be changed.
+func (l *AssignedTreatmentLogger) Reload(
+	publisher AssignedTreatmentPublisher,
+	options AssignedTreatmentLoggerOptions,
+) error {
+	if options.QueueLength != cap(l.queue) {
+		return fmt.Errorf("queue length cannot be changed from %d to %d", cap(l.queue), options.QueueLength)
+	}
+	sampler, err := validateLoggerOptions(options)
+	if err != nil {
+		return err
+	}
+
+	reload := loggerReload{
+		publisher: publisher,
+		options:   options,
+		sampler:   sampler,
+		done:      make(chan struct{}),
+	}
+	select {
+	case l.reloadChannel <- reload:
+	case <-l.stopChannel:
+		return errors.New("logger has been stopped")
+	}
+	<-reload.done
 	return nil
 }
 
-func (l *AssignedTreatmentLogger) worker() {
-	for range time.Tick(l.flushInterval) {
-		logs := make([]*AssignedTreatmentLog, 0)
+// Append adds the log to the queue, to be published in the next flush, if it is sampled. When the
+// queue is full, the log is handled according to the configured QueueFullPolicy.
+func (l *AssignedTreatmentLogger) Append(log *AssignedTreatmentLog) error {
+	// Exposure events do not carry the randomization key, so they cannot be sampled consistently with
+	// the assignments. They are always logged, and can be filtered by joining them to the assignments.
+	l.mu.RLock()
+	sampler, queueFullPolicy := l.sampler, l.options.QueueFullPolicy
+	l.mu.RUnlock()
 
-	collection:
+	if sampler == nil || log.EventType == ExposureEvent {
+		log.SamplingRate = 1
+	} else if !sampler.Sample(log) {
+		return nil
+	}
+
+	switch queueFullPolicy {
+	case config.DropNewestPolicy:
+		select {
+		case l.queue <- log:
+		default:
+			l.recordDropped(droppedQueueFull, 1)
+		}
+	case config.DropOldestPolicy:
 		for {
 			select {
-			case log := <-l.queue:
-				logs = append(logs, log)
+			case l.queue <- log:
+				return nil
+			default:
+			}
+			// Make space by discarding the oldest log, unless the worker has just done so
+			select {
+			case <-l.queue:
+				l.recordDropped(droppedQueueFull, 1)
 			default:
-				break collection
 			}
 		}
+	default:
+		select {
+		case l.queue <- log:
+		case <-l.stopChannel:
+			// The worker may no longer be consuming the queue
+			l.recordDropped(droppedShutdown, 1)
+		}
+	}
+	return nil
+}
 
-		if len(logs) > 0 {
-			err := l.publisher.Publish(logs)
-			if err != nil {
-				log.Println("Failed to publish log:", err)
-			}
+// Stop flushes the queued logs and stops the worker, waiting up to the configured ShutdownTimeout.
+func (l *AssignedTreatmentLogger) Stop() {
+	l.stopOnce.Do(func() { close(l.stopChannel) })
+
+	l.mu.RLock()
+	shutdownTimeout := l.options.ShutdownTimeout
+	l.mu.RUnlock()
+
+	select {
+	case <-l.doneChannel:
+	case <-time.After(shutdownTimeout):
+		log.Println("Timed out flushing assigned treatment logs")
+	}
+}
+
+func (l *AssignedTreatmentLogger) worker() {
+	defer close(l.doneChannel)
+
+	ticker := time.NewTicker(l.options.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			l.flush()
+		case reload := <-l.reloadChannel:
+			l.flush()
+			l.closePublisher()
+
+			l.mu.Lock()
+			l.publisher = reload.publisher
+			l.options = reload.options
+			l.sampler = reload.sampler
+			l.mu.Unlock()
+
+			ticker.Reset(reload.options.FlushInterval)
+			close(reload.done)
+		case <-l.stopChannel:
+			l.flush()
+			l.closePublisher()
+			return
+		}
+	}
+}
+
+func (l *AssignedTreatmentLogger) closePublisher() {
+	if closer, ok := l.publisher.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			log.Println("Failed to close log publisher:", err)
+		}
+	}
+}
+
+// flush publishes all the logs that are currently in the queue
+func (l *AssignedTreatmentLogger) flush() {
+	l.recordQueueDepth()
+
+	logs := make([]*AssignedTreatmentLog, 0)
+
+collection:
+	for {
+		select {
+		case log := <-l.queue:
+			logs = append(logs, log)
+		default:
+			break collection
+		}
+	}
+
+	if len(logs) > 0 {
+		err := l.publishWithRetry(logs)
+		if err != nil {
+			log.Println("Failed to publish log:", err)
+			l.recordDropped(droppedPublishFailed, len(logs))
 		}
 	}
 }
 
+// publishWithRetry publishes the logs, retrying the whole batch with an exponential backoff on failure.
+// Publishers may have delivered part of a failed batch, so retried logs can be published more than once.
+func (l *AssignedTreatmentLogger) publishWithRetry(logs []*AssignedTreatmentLog) error {
+	backoff := l.options.PublishRetryBackoff
+	for attempt := 0; ; attempt++ {
+		begin := time.Now()
+		err := l.publisher.Publish(logs)
+		l.recordPublishDuration(begin, err == nil)
+		if err == nil || attempt >= l.options.MaxPublishRetries {
+			return err
+		}
+
+		log.Printf("Failed to publish log (attempt %d), retrying in %s: %v", attempt+1, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > l.options.PublishMaxBackoff {
+			backoff = l.options.PublishMaxBackoff
+		}
+	}
+}
+
+func (l *AssignedTreatmentLogger) recordQueueDepth() {
+	err := metrics.Glob().RecordGauge(
+		instrumentation.AssignedTreatmentLogQueueDepth, float64(len(l.queue)), map[string]string{},
+	)
+	if err != nil {
+		log.Printf("error while logging %s metrics: %s", instrumentation.AssignedTreatmentLogQueueDepth, err)
+	}
+}
+
+func (l *AssignedTreatmentLogger) recordDropped(reason string, count int) {
+	for i := 0; i < count; i++ {
+		err := metrics.Glob().Inc(
+			instrumentation.AssignedTreatmentLogDroppedCount, map[string]string{"reason": reason},
+		)
+		if err != nil {
+			log.Printf("error while logging %s metrics: %s", instrumentation.AssignedTreatmentLogDroppedCount, err)
+			return
+		}
+	}
+}
+
+func (l *AssignedTreatmentLogger) recordPublishDuration(begin time.Time, success bool) {
+	err := metrics.Glob().MeasureDurationMsSince(
+		instrumentation.AssignedTreatmentLogPublishDurationMs,
+		begin,
+		map[string]string{"status": metrics.GetStatusString(success)},
+	)
+	if err != nil {
+		log.Printf("error while logging %s metrics: %s", instrumentation.AssignedTreatmentLogPublishDurationMs, err)
+	}
+}
+
+// newTreatmentServiceResultLogMessage converts a given AssignedTreatmentLog to the Protobuf log message
+// format that is shared by all the log publishers
+func newTreatmentServiceResultLogMessage(
+	log *AssignedTreatmentLog,
+	timestamp *timestamppb.Timestamp,
+) (*TreatmentServiceResultLogMessage, error) {
+	segments := make(map[string]interface{})
+	for _, s := range log.Segmenters {
+		allValues := []interface{}{}
+		for _, v := range s.Value {
+			allValues = append(allValues, _utils.SegmenterValueToInterface(v))
+		}
+		segments[s.Key] = allValues
+	}
+
+	segmentsJson, err := json.Marshal(segments)
+	if err != nil {
+		return nil, err
+	}
+	message := &TreatmentServiceResultLogMessage{
+		EventTimestamp: timestamp,
+		ProjectId:      log.ProjectID,
+		RequestId:      log.RequestID,
+		Request:        log.Request,
+		Segment:        string(segmentsJson),
+		SamplingRate:   log.SamplingRate,
+		EventType:      log.EventType,
+	}
+
+	if log.ClientTimestamp != nil {
+		message.ClientTimestamp = timestamppb.New(*log.ClientTimestamp)
+	}
+
+	if log.Experiment != nil {
+		message.ExperimentId = log.Experiment.Id
+		message.ExperimentName = log.Experiment.Name
+	}
+
+	if log.Treatment != nil {
+		treatmentConfigJson, err := json.Marshal(log.Treatment.Config)
+		if err != nil {
+			return nil, err
+		}
+		treatmentConfig := string(treatmentConfigJson)
+
+		message.TreatmentName = log.Treatment.Name
+		message.TreatmentConfig = treatmentConfig
+	}
+
+	if log.TreatmentMetadata != nil {
+		treatmentMetadata, err := json.Marshal(log.TreatmentMetadata)
+		if err != nil {
+			return nil, err
+		}
+		message.TreatmentMetadata = string(treatmentMetadata)
+	}
+
+	if log.Error != nil {
+		errorJson, err := json.Marshal(log.Error)
+		if err != nil {
+			return nil, err
+		}
+
+		message.Error = string(errorJson)
+	}
+
+	return message, nil
+}
+
+// NewAssignedTreatmentPublisher creates the publisher of the given logger config, which must not be of
+// the noop kind
+func NewAssignedTreatmentPublisher(
+	cfg config.AssignedTreatmentLoggerConfig,
+	googleApplicationCredentialsEnvVar string,
+) (AssignedTreatmentPublisher, error) {
+	switch cfg.Kind {
+	case config.KafkaLogger:
+		return newKafkaPublisher(*cfg.KafkaConfig)
+	case config.BQLogger:
+		return newBQPublisher(*cfg.BQConfig, googleApplicationCredentialsEnvVar)
+	case config.FileLogger:
+		return newFilePublisher(*cfg.FileConfig)
+	case config.StdoutLogger:
+		return NewFileLogPublisher(os.Stdout, cfg.StdoutConfig.Format)
+	default:
+		return nil, fmt.Errorf("unrecognized Treatment Logger Kind: %s", cfg.Kind)
+	}
+}
+
 func NewNoopAssignedTreatmentLogger() (*AssignedTreatmentLogger, error) {
 	return nil, nil
 }
 
 func NewBQAssignedTreatmentLogger(
 	config config.BigqueryConfig,
-	queueLength int,
-	flushInterval time.Duration,
+	options AssignedTreatmentLoggerOptions,
 	googleApplicationCredentialsEnvVar string,
 ) (*AssignedTreatmentLogger, error) {
-
-	c := make(chan *AssignedTreatmentLog, queueLength)
-	publisher, err := NewBQLogPublisher(config.Project, config.Dataset, config.Table, googleApplicationCredentialsEnvVar)
+	publisher, err := newBQPublisher(config, googleApplicationCredentialsEnvVar)
 	if err != nil {
 		return nil, err
 	}
-	logger := &AssignedTreatmentLogger{
-		queue:         c,
-		publisher:     publisher,
-		flushInterval: flushInterval,
-	}
-
-	go logger.worker()
+	return newAssignedTreatmentLogger(publisher, options)
+}
 
-	return logger, nil
+func newBQPublisher(
+	config config.BigqueryConfig,
+	googleApplicationCredentialsEnvVar string,
+) (AssignedTreatmentPublisher, error) {
+	return NewBQLogPublisher(config.Project, config.Dataset, config.Table, googleApplicationCredentialsEnvVar)
 }
 
 func NewKafkaAssignedTreatmentLogger(
 	config config.KafkaConfig,
-	queueLength int,
-	flushInterval time.Duration,
+	options AssignedTreatmentLoggerOptions,
 ) (*AssignedTreatmentLogger, error) {
+	publisher, err := newKafkaPublisher(config)
+	if err != nil {
+		return nil, err
+	}
+	return newAssignedTreatmentLogger(publisher, options)
+}
 
-	c := make(chan *AssignedTreatmentLog, queueLength)
-	publisher, err := NewKafkaLogPublisher(
+func newKafkaPublisher(config config.KafkaConfig) (AssignedTreatmentPublisher, error) {
+	return NewKafkaLogPublisher(
 		config.Brokers, config.Topic, config.MaxMessageBytes, config.CompressionType, config.ConnectTimeoutMS,
 	)
+}
+
+func NewFileAssignedTreatmentLogger(
+	config config.FileLoggerConfig,
+	options AssignedTreatmentLoggerOptions,
+) (*AssignedTreatmentLogger, error) {
+	publisher, err := newFilePublisher(config)
+	if err != nil {
+		return nil, err
+	}
+	return newAssignedTreatmentLogger(publisher, options)
+}
+
+func newFilePublisher(config config.FileLoggerConfig) (AssignedTreatmentPublisher, error) {
+	writer, err := newRotatingFileWriter(
+		config.Path,
+		int64(config.MaxSizeMB)*1024*1024,
+		time.Duration(config.RotationIntervalSeconds)*time.Second,
+		config.Compress,
+	)
 	if err != nil {
 		return nil, err
 	}
-	logger := &AssignedTreatmentLogger{
-		queue:         c,
-		publisher:     publisher,
-		flushInterval: flushInterval,
+	publisher, err := NewFileLogPublisher(writer, config.Format)
+	if err != nil {
+		writer.Close()
+		return nil, err
 	}
+	return publisher, nil
+}
 
-	go logger.worker()
-
-	return logger, nil
+func NewStdoutAssignedTreatmentLogger(
+	config config.StdoutLoggerConfig,
+	options AssignedTreatmentLoggerOptions,
+) (*AssignedTreatmentLogger, error) {
+	publisher, err := NewFileLogPublisher(os.Stdout, config.Format)
+	if err != nil {
+		return nil, err
+	}
+	return newAssignedTreatmentLogger(publisher, options)
 }
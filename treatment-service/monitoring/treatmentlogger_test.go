@@ -6,12 +6,15 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"cloud.google.com/go/bigquery"
+	"github.com/confluentinc/confluent-kafka-go/kafka"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
@@ -106,6 +109,300 @@ func TestNewProtobufKafkaLogEntry(t *testing.T) {
 	assert.JSONEq(t, string(expectedValueJSON), string(actualValueJSON))
 }
 
+type mockPublisher struct {
+	sync.Mutex
+	// failures is the no. of calls to Publish that should fail
+	failures  int
+	calls     int
+	published []string
+}
+
+func (p *mockPublisher) Publish(logs []*AssignedTreatmentLog) error {
+	p.Lock()
+	defer p.Unlock()
+	p.calls++
+	if p.calls <= p.failures {
+		return fmt.Errorf("publish error")
+	}
+	for _, l := range logs {
+		p.published = append(p.published, l.RequestID)
+	}
+	return nil
+}
+
+func (p *mockPublisher) getPublished() []string {
+	p.Lock()
+	defer p.Unlock()
+	return p.published
+}
+
+// mockKafkaProducer delivers the produced messages immediately, and records whether it has been closed
+type mockKafkaProducer struct {
+	sync.Mutex
+	// unflushed is the no. of messages that are not delivered when flushing
+	unflushed int
+	closed    bool
+	produced  int
+}
+
+func (p *mockKafkaProducer) GetMetadata(*string, bool, int) (*kafka.Metadata, error) {
+	return &kafka.Metadata{}, nil
+}
+
+func (p *mockKafkaProducer) Produce(msg *kafka.Message, deliveryChan chan kafka.Event) error {
+	p.Lock()
+	defer p.Unlock()
+	p.produced++
+	deliveryChan <- msg
+	return nil
+}
+
+func (p *mockKafkaProducer) Flush(int) int {
+	return p.unflushed
+}
+
+func (p *mockKafkaProducer) Close() {
+	p.Lock()
+	defer p.Unlock()
+	p.closed = true
+}
+
+func (p *mockKafkaProducer) isClosed() bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.closed
+}
+
+func TestKafkaLogPublisherClose(t *testing.T) {
+	producer := &mockKafkaProducer{}
+	publisher := &KafkaLogPublisher{topic: "test-topic", producer: producer}
+	assert.NoError(t, publisher.Close())
+	assert.True(t, producer.isClosed())
+
+	// The producer is closed even if the outstanding messages could not be delivered
+	producer = &mockKafkaProducer{unflushed: 2}
+	publisher = &KafkaLogPublisher{topic: "test-topic", producer: producer}
+	assert.EqualError(t, publisher.Close(), "2 messages were not delivered before the Kafka producer was closed")
+	assert.True(t, producer.isClosed())
+}
+
+func TestNewAssignedTreatmentLoggerOptions(t *testing.T) {
+	options := NewAssignedTreatmentLoggerOptions(config.AssignedTreatmentLoggerConfig{
+		QueueLength:            10,
+		FlushIntervalSeconds:   2,
+		QueueFullPolicy:        "drop_oldest",
+		MaxPublishRetries:      3,
+		PublishRetryBackoffMS:  100,
+		PublishMaxBackoffMS:    1000,
+		ShutdownTimeoutSeconds: 5,
+		SamplingConfig:         config.LogSamplingConfig{DefaultRate: 0.5},
+	})
+	assert.Equal(t, AssignedTreatmentLoggerOptions{
+		QueueLength:         10,
+		FlushInterval:       2 * time.Second,
+		QueueFullPolicy:     "drop_oldest",
+		MaxPublishRetries:   3,
+		PublishRetryBackoff: 100 * time.Millisecond,
+		PublishMaxBackoff:   time.Second,
+		ShutdownTimeout:     5 * time.Second,
+		Sampling:            &config.LogSamplingConfig{DefaultRate: 0.5},
+	}, options)
+}
+
+func TestNewAssignedTreatmentLoggerInvalidPolicy(t *testing.T) {
+	_, err := newAssignedTreatmentLogger(&mockPublisher{}, AssignedTreatmentLoggerOptions{
+		QueueLength: 1, FlushInterval: time.Hour, QueueFullPolicy: "drop_all",
+	})
+	assert.EqualError(t, err, "unrecognized queue full policy: drop_all")
+
+	_, err = newAssignedTreatmentLogger(&mockPublisher{}, AssignedTreatmentLoggerOptions{
+		QueueLength: 0, FlushInterval: time.Hour, QueueFullPolicy: "drop_oldest",
+	})
+	assert.EqualError(t, err, "queue length must be positive for the drop_oldest policy")
+}
+
+func TestAssignedTreatmentLoggerQueueFullPolicy(t *testing.T) {
+	tests := map[string]struct {
+		policy   config.QueueFullPolicy
+		expected []string
+	}{
+		"drop newest": {
+			policy:   config.DropNewestPolicy,
+			expected: []string{"1", "2"},
+		},
+		"drop oldest": {
+			policy:   config.DropOldestPolicy,
+			expected: []string{"3", "4"},
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			publisher := &mockPublisher{}
+			// Use a long flush interval so that the logs are only published on Stop
+			logger, err := newAssignedTreatmentLogger(publisher, AssignedTreatmentLoggerOptions{
+				QueueLength:     2,
+				FlushInterval:   time.Hour,
+				QueueFullPolicy: data.policy,
+				ShutdownTimeout: time.Second,
+			})
+			require.NoError(t, err)
+
+			for _, requestId := range []string{"1", "2", "3", "4"} {
+				require.NoError(t, logger.Append(&AssignedTreatmentLog{RequestID: requestId}))
+			}
+			logger.Stop()
+
+			assert.Equal(t, data.expected, publisher.getPublished())
+		})
+	}
+}
+
+func TestAssignedTreatmentLoggerSampling(t *testing.T) {
+	publisher := &mockPublisher{}
+	logger, err := newAssignedTreatmentLogger(publisher, AssignedTreatmentLoggerOptions{
+		QueueLength:     10,
+		FlushInterval:   time.Hour,
+		QueueFullPolicy: config.BlockPolicy,
+		ShutdownTimeout: time.Second,
+		Sampling: &config.LogSamplingConfig{
+			DefaultRate:  1,
+			ProjectRates: map[string]float64{"2": 0},
+		},
+	})
+	require.NoError(t, err)
+
+	sampledLog := &AssignedTreatmentLog{ProjectID: 1, RequestID: "1"}
+	require.NoError(t, logger.Append(sampledLog))
+	require.NoError(t, logger.Append(&AssignedTreatmentLog{ProjectID: 2, RequestID: "2"}))
+	// Exposure events are not sampled
+	require.NoError(t, logger.Append(&AssignedTreatmentLog{EventType: ExposureEvent, ProjectID: 2, RequestID: "3"}))
+	logger.Stop()
+
+	assert.Equal(t, []string{"1", "3"}, publisher.getPublished())
+	assert.Equal(t, float64(1), sampledLog.SamplingRate)
+}
+
+func TestAssignedTreatmentLoggerBlockAfterStop(t *testing.T) {
+	publisher := &mockPublisher{}
+	logger, err := newAssignedTreatmentLogger(publisher, AssignedTreatmentLoggerOptions{
+		QueueLength:     0,
+		FlushInterval:   time.Hour,
+		QueueFullPolicy: config.BlockPolicy,
+		ShutdownTimeout: time.Second,
+	})
+	require.NoError(t, err)
+	logger.Stop()
+
+	// Append does not block once the logger has been stopped
+	assert.NoError(t, logger.Append(&AssignedTreatmentLog{RequestID: "1"}))
+	assert.Empty(t, publisher.getPublished())
+}
+
+func TestAssignedTreatmentLoggerPublishRetry(t *testing.T) {
+	tests := map[string]struct {
+		failures int
+		expected []string
+	}{
+		"success after retries": {
+			failures: 2,
+			expected: []string{"1"},
+		},
+		"retries exhausted": {
+			failures: 3,
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			publisher := &mockPublisher{failures: data.failures}
+			logger, err := newAssignedTreatmentLogger(publisher, AssignedTreatmentLoggerOptions{
+				QueueLength:         1,
+				FlushInterval:       time.Hour,
+				QueueFullPolicy:     config.BlockPolicy,
+				MaxPublishRetries:   2,
+				PublishRetryBackoff: time.Millisecond,
+				PublishMaxBackoff:   time.Millisecond,
+				ShutdownTimeout:     time.Second,
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, logger.Append(&AssignedTreatmentLog{RequestID: "1"}))
+			logger.Stop()
+
+			assert.Equal(t, 3, publisher.calls)
+			assert.Equal(t, data.expected, publisher.getPublished())
+		})
+	}
+}
+
+func TestAssignedTreatmentLoggerReload(t *testing.T) {
+	options := AssignedTreatmentLoggerOptions{
+		QueueLength:     2,
+		FlushInterval:   time.Hour,
+		QueueFullPolicy: config.BlockPolicy,
+		ShutdownTimeout: time.Second,
+	}
+	publisher := &mockPublisher{}
+	logger, err := newAssignedTreatmentLogger(publisher, options)
+	require.NoError(t, err)
+	require.NoError(t, logger.Append(&AssignedTreatmentLog{ProjectID: 1, RequestID: "1"}))
+
+	// Invalid options are rejected, and the current publisher is kept
+	invalidOptions := options
+	invalidOptions.QueueLength = 3
+	err = logger.Reload(&mockPublisher{}, invalidOptions)
+	assert.EqualError(t, err, "queue length cannot be changed from 2 to 3")
+	invalidOptions = options
+	invalidOptions.QueueFullPolicy = "drop_all"
+	err = logger.Reload(&mockPublisher{}, invalidOptions)
+	assert.EqualError(t, err, "unrecognized queue full policy: drop_all")
+
+	// The queued logs are published with the current publisher before the reload
+	newPublisher := &mockPublisher{}
+	newOptions := options
+	newOptions.QueueFullPolicy = config.DropNewestPolicy
+	newOptions.Sampling = &config.LogSamplingConfig{ProjectRates: map[string]float64{"1": 1}}
+	require.NoError(t, logger.Reload(newPublisher, newOptions))
+	assert.Equal(t, []string{"1"}, publisher.getPublished())
+
+	// The subsequent logs are published with the new publisher and options
+	for _, requestId := range []string{"2", "3", "4"} {
+		require.NoError(t, logger.Append(&AssignedTreatmentLog{ProjectID: 1, RequestID: requestId}))
+	}
+	require.NoError(t, logger.Append(&AssignedTreatmentLog{ProjectID: 2, RequestID: "5"}))
+	logger.Stop()
+
+	assert.Equal(t, []string{"1"}, publisher.getPublished())
+	assert.Equal(t, []string{"2", "3"}, newPublisher.getPublished())
+
+	err = logger.Reload(&mockPublisher{}, options)
+	assert.EqualError(t, err, "logger has been stopped")
+}
+
+func TestAssignedTreatmentLoggerReloadClosesPublisher(t *testing.T) {
+	options := AssignedTreatmentLoggerOptions{
+		QueueLength:     2,
+		FlushInterval:   time.Hour,
+		QueueFullPolicy: config.BlockPolicy,
+		ShutdownTimeout: time.Second,
+	}
+	producer := &mockKafkaProducer{}
+	logger, err := newAssignedTreatmentLogger(&KafkaLogPublisher{topic: "test-topic", producer: producer}, options)
+	require.NoError(t, err)
+	require.NoError(t, logger.Append(&AssignedTreatmentLog{ProjectID: 1, RequestID: "1"}))
+
+	// The queued logs are published before the replaced Kafka producer is closed
+	newProducer := &mockKafkaProducer{}
+	require.NoError(t, logger.Reload(&KafkaLogPublisher{topic: "test-topic", producer: newProducer}, options))
+	assert.Equal(t, 1, producer.produced)
+	assert.True(t, producer.isClosed())
+	assert.False(t, newProducer.isClosed())
+
+	logger.Stop()
+	assert.True(t, newProducer.isClosed())
+}
+
 type BQLoggerSuite struct {
 	suite.Suite
 
@@ -122,7 +419,12 @@ func (s *BQLoggerSuite) SetupTest() {
 		Dataset: s.target.DatasetID,
 		Table:   s.target.TableID,
 	}
-	s.logger, err = NewBQAssignedTreatmentLogger(config, 100, time.Millisecond, "")
+	options := AssignedTreatmentLoggerOptions{
+		QueueLength:     100,
+		FlushInterval:   time.Millisecond,
+		QueueFullPolicy: "block",
+	}
+	s.logger, err = NewBQAssignedTreatmentLogger(config, options, "")
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,54 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/go-cmp/cmp"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
+)
+
+func NewAppVersionRunner(_ json.RawMessage) (Runner, error) {
+	var appVersionConfig = &SegmenterConfig{
+		Name: _utils.AppVersionSegmenter,
+	}
+
+	return &appVersion{NewBaseRunner(appVersionConfig)}, nil
+}
+
+type appVersion struct {
+	Runner
+}
+
+// Transform parses the semantic version in the request into an integer, which is matched against the ranges of
+// the version constraints of the experiments
+func (s *appVersion) Transform(
+	segmenter string,
+	requestValues map[string]interface{},
+	experimentVariables []string,
+) ([]*_segmenters.SegmenterValue, error) {
+	if !cmp.Equal(experimentVariables, []string{"app_version"}) {
+		return nil, fmt.Errorf("no valid variables were provided for %s segmenter", segmenter)
+	}
+	versionString, ok := requestValues["app_version"].(string)
+	if !ok {
+		return nil, fmt.Errorf(TypeCastingErrorTmpl, "app_version", segmenter, "string")
+	}
+	version, err := _utils.ParseVersion(versionString)
+	if err != nil {
+		return nil, fmt.Errorf("provided app_version variable for %s segmenter is invalid: %s", segmenter, err)
+	}
+	segmenterValue := []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_Integer{Integer: version}}}
+
+	return segmenterValue, nil
+}
+
+func init() {
+	err := Register(_utils.AppVersionSegmenter, NewAppVersionRunner)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
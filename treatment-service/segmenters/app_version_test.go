@@ -0,0 +1,100 @@
+package segmenters
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+type AppVersionRunnerTestSuite struct {
+	suite.Suite
+
+	appVersionRunner Runner
+	name             string
+}
+
+func (suite *AppVersionRunnerTestSuite) SetupSuite() {
+	suite.name = "app_version"
+
+	s, err := NewAppVersionRunner(nil)
+	suite.Require().NoError(err)
+	suite.appVersionRunner = s
+}
+
+func TestAppVersionRunnerTestSuite(t *testing.T) {
+	suite.Run(t, new(AppVersionRunnerTestSuite))
+}
+
+func (s *AppVersionRunnerTestSuite) TestAppVersionTransform() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name                string
+		requestParam        map[string]interface{}
+		experimentVariables []string
+		expected            []*_segmenters.SegmenterValue
+		errString           string
+	}{
+		{
+			name: "failure | no valid variable",
+			requestParam: map[string]interface{}{
+				"app_version": "4.12.3",
+			},
+			experimentVariables: []string{"invalid_var"},
+			errString:           fmt.Sprintf("no valid variables were provided for %s segmenter", s.name),
+		},
+		{
+			name: "failure | invalid type app_version variable",
+			requestParam: map[string]interface{}{
+				"app_version": float64(4),
+			},
+			experimentVariables: []string{"app_version"},
+			errString:           fmt.Sprintf(TypeCastingErrorTmpl, "app_version", s.name, "string"),
+		},
+		{
+			name: "failure | invalid app_version",
+			requestParam: map[string]interface{}{
+				"app_version": "4.12.beta",
+			},
+			experimentVariables: []string{"app_version"},
+			errString: fmt.Sprintf("provided app_version variable for %s segmenter is invalid: "+
+				"invalid version \"4.12.beta\"", s.name),
+		},
+		{
+			name: "success | app_version",
+			requestParam: map[string]interface{}{
+				"app_version": "4.12.3",
+			},
+			experimentVariables: []string{"app_version"},
+			expected: []*_segmenters.SegmenterValue{
+				{Value: &_segmenters.SegmenterValue_Integer{Integer: 4<<42 + 12<<21 + 3}},
+			},
+		},
+		{
+			name: "success | app_version with pre-release",
+			requestParam: map[string]interface{}{
+				"app_version": "v5.0.0-rc.1",
+			},
+			experimentVariables: []string{"app_version"},
+			expected: []*_segmenters.SegmenterValue{
+				{Value: &_segmenters.SegmenterValue_Integer{Integer: 5 << 42}},
+			},
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			transformation, err := s.appVersionRunner.Transform(s.name, data.requestParam, data.experimentVariables)
+			if data.errString == "" {
+				s.Suite.Require().NoError(err)
+				s.Suite.Require().Equal(data.expected, transformation)
+			} else {
+				s.Suite.Assert().EqualError(err, data.errString)
+			}
+		})
+	}
+}
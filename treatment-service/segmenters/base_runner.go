@@ -2,6 +2,7 @@ package segmenters
 
 import (
 	"fmt"
+	"strings"
 
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
 	_utils "github.com/caraml-dev/xp/common/utils"
@@ -12,6 +13,9 @@ const TypeCastingErrorTmpl = "invalid type of variable (%s) was provided for %s
 type SegmenterConfig struct {
 	Name string
 	Type *_segmenters.SegmenterValueType
+	// RequestFields are the alternative request fields of the segmenter's value, in priority order, which may be
+	// dot-separated paths of nested fields. The value is read from the field with the segmenter's name when empty.
+	RequestFields []string
 }
 
 type BaseRunner struct {
@@ -36,7 +40,16 @@ func (r *BaseRunner) Transform(
 	errTmpl := fmt.Sprintf("received wrong type of segmenter value; %s expects type", segmenter)
 	transformedVals := []*_segmenters.SegmenterValue{}
 
-	convertedVal, err := _utils.InterfaceToSegmenterValue(requestValues[segmenter], segmenter, r.config.Type)
+	requestValue := requestValues[segmenter]
+	if len(r.config.RequestFields) > 0 {
+		var ok bool
+		requestValue, ok = resolveRequestFields(requestValues, r.config.RequestFields)
+		if !ok {
+			// None of the fields were provided, so only the experiments that do not use the segmenter are matched
+			return transformedVals, nil
+		}
+	}
+	convertedVal, err := _utils.InterfaceToSegmenterValue(requestValue, segmenter, r.config.Type)
 	if err != nil {
 		return nil, err
 	}
@@ -70,3 +83,32 @@ func (r *BaseRunner) Transform(
 	transformedVals = append(transformedVals, convertedVal)
 	return transformedVals, nil
 }
+
+// resolveRequestFields returns the value of the first of the request fields that is provided in the request
+func resolveRequestFields(requestValues map[string]interface{}, requestFields []string) (interface{}, bool) {
+	for _, field := range requestFields {
+		if value, ok := getRequestField(requestValues, field); ok && value != nil {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// getRequestField returns the value of a request field, which is a dot-separated path for nested fields. A field
+// whose name contains dots is matched as is first.
+func getRequestField(requestValues map[string]interface{}, field string) (interface{}, bool) {
+	if value, ok := requestValues[field]; ok {
+		return value, true
+	}
+	var value interface{} = requestValues
+	for _, name := range strings.Split(field, ".") {
+		nestedValues, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = nestedValues[name]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
@@ -0,0 +1,113 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/cast"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	"github.com/caraml-dev/xp/common/utils"
+	"github.com/caraml-dev/xp/treatment-service/util"
+)
+
+type DatesSegmenterConfig struct {
+	// CalendarFiles are the paths to the YAML or JSON files of the named calendars, each of which maps the names
+	// of calendars to the lists of their dates
+	CalendarFiles []string `json:"calendarfiles"`
+}
+
+func NewDatesRunner(configData json.RawMessage) (Runner, error) {
+	segmenterErrTpl := "failed to create segmenter (dates): %s"
+	var config DatesSegmenterConfig
+
+	// The segmenter has no calendars if it is not configured
+	if len(configData) > 0 {
+		err := json.Unmarshal(configData, &config)
+		if err != nil {
+			return nil, fmt.Errorf(segmenterErrTpl, err)
+		}
+	}
+	calendars, err := utils.LoadCalendars(config.CalendarFiles)
+	if err != nil {
+		return nil, fmt.Errorf(segmenterErrTpl, err)
+	}
+
+	datesConfig := &SegmenterConfig{
+		Name: utils.DatesSegmenter,
+	}
+
+	return &dates{NewBaseRunner(datesConfig), calendars}, nil
+}
+
+type dates struct {
+	Runner
+	calendars utils.Calendars
+}
+
+// Transform returns the date of the request in its timezone, followed by the names of the calendars that contain
+// the date. The date is that of the request timestamp, if provided, or else that of the current time.
+func (s *dates) Transform(
+	segmenter string,
+	requestValues map[string]interface{},
+	experimentVariables []string,
+) ([]*_segmenters.SegmenterValue, error) {
+	requestTime := time.Now()
+	switch {
+	case cmp.Equal(experimentVariables, []string{"tz"}):
+	case cmp.Diff(experimentVariables, []string{"timestamp", "tz"}, cmpopts.SortSlices(utils.Less)) == "":
+		timestamp, err := parseTimestamp(requestValues["timestamp"])
+		if err != nil {
+			return nil, fmt.Errorf("provided timestamp variable for %s segmenter is invalid: %s", segmenter, err)
+		}
+		requestTime = timestamp
+	default:
+		return nil, fmt.Errorf("no valid variables were provided for %s segmenter", segmenter)
+	}
+	tzString, ok := requestValues["tz"].(string)
+	if !ok {
+		return nil, fmt.Errorf(TypeCastingErrorTmpl, "tz", segmenter, "string")
+	}
+	timeLoc, err := util.RetrieveTimezone(tzString)
+	if err != nil {
+		return nil, err
+	}
+
+	date := requestTime.In(timeLoc).Format(utils.DateLayout)
+	segmenterValues := []*_segmenters.SegmenterValue{
+		{Value: &_segmenters.SegmenterValue_String_{String_: date}},
+	}
+	for _, calendar := range s.calendars.Contains(date) {
+		segmenterValues = append(segmenterValues, &_segmenters.SegmenterValue{
+			Value: &_segmenters.SegmenterValue_String_{String_: calendar},
+		})
+	}
+
+	return segmenterValues, nil
+}
+
+// parseTimestamp parses an RFC 3339 timestamp, or the no. of seconds since the Unix epoch
+func parseTimestamp(value interface{}) (time.Time, error) {
+	if timestamp, ok := value.(string); ok {
+		if parsed, err := time.Parse(time.RFC3339, timestamp); err == nil {
+			return parsed, nil
+		}
+	}
+	seconds, err := cast.ToInt64E(value)
+	if err != nil {
+		return time.Time{}, errors.New("expected an RFC 3339 timestamp or the seconds since the Unix epoch")
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+func init() {
+	err := Register(utils.DatesSegmenter, NewDatesRunner)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,137 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+type DatesRunnerTestSuite struct {
+	suite.Suite
+
+	datesRunner Runner
+	name        string
+}
+
+func (suite *DatesRunnerTestSuite) SetupSuite() {
+	suite.name = "dates"
+
+	path := filepath.Join(suite.T().TempDir(), "calendars.yaml")
+	err := os.WriteFile(path, []byte("id_public_holidays:\n  - 2024-12-25\nxmas_sale:\n  - 2024-12-25\n"), 0644)
+	suite.Require().NoError(err)
+	configData, err := json.Marshal(DatesSegmenterConfig{CalendarFiles: []string{path}})
+	suite.Require().NoError(err)
+
+	s, err := NewDatesRunner(configData)
+	suite.Require().NoError(err)
+	suite.datesRunner = s
+}
+
+func TestDatesRunnerTestSuite(t *testing.T) {
+	suite.Run(t, new(DatesRunnerTestSuite))
+}
+
+func (s *DatesRunnerTestSuite) TestDatesTransform() {
+	t := s.Suite.T()
+
+	stringValues := func(values ...string) []*_segmenters.SegmenterValue {
+		segmenterValues := []*_segmenters.SegmenterValue{}
+		for _, value := range values {
+			segmenterValues = append(segmenterValues, &_segmenters.SegmenterValue{
+				Value: &_segmenters.SegmenterValue_String_{String_: value},
+			})
+		}
+		return segmenterValues
+	}
+	jakarta, _ := time.LoadLocation("Asia/Jakarta")
+
+	tests := []struct {
+		name                string
+		requestParam        map[string]interface{}
+		experimentVariables []string
+		expected            []*_segmenters.SegmenterValue
+		errString           string
+	}{
+		{
+			name: "failure | no valid variable",
+			requestParam: map[string]interface{}{
+				"tz": "Asia/Jakarta",
+			},
+			experimentVariables: []string{"invalid_var"},
+			errString:           fmt.Sprintf("no valid variables were provided for %s segmenter", s.name),
+		},
+		{
+			name: "failure | invalid type tz variable",
+			requestParam: map[string]interface{}{
+				"tz": 7,
+			},
+			experimentVariables: []string{"tz"},
+			errString:           fmt.Sprintf(TypeCastingErrorTmpl, "tz", s.name, "string"),
+		},
+		{
+			name: "failure | invalid timestamp",
+			requestParam: map[string]interface{}{
+				"tz":        "Asia/Jakarta",
+				"timestamp": "25 Dec 2024",
+			},
+			experimentVariables: []string{"tz", "timestamp"},
+			errString: fmt.Sprintf("provided timestamp variable for %s segmenter is invalid: "+
+				"expected an RFC 3339 timestamp or the seconds since the Unix epoch", s.name),
+		},
+		{
+			name: "success | tz",
+			requestParam: map[string]interface{}{
+				"tz": "Asia/Jakarta",
+			},
+			experimentVariables: []string{"tz"},
+			expected:            stringValues(time.Now().In(jakarta).Format("2006-01-02")),
+		},
+		{
+			name: "success | RFC 3339 timestamp in the calendars",
+			requestParam: map[string]interface{}{
+				"tz":        "Asia/Jakarta",
+				"timestamp": "2024-12-24T17:30:00Z",
+			},
+			experimentVariables: []string{"timestamp", "tz"},
+			expected:            stringValues("2024-12-25", "id_public_holidays", "xmas_sale"),
+		},
+		{
+			name: "success | unix timestamp",
+			requestParam: map[string]interface{}{
+				"tz":        "UTC",
+				"timestamp": float64(1735084800),
+			},
+			experimentVariables: []string{"tz", "timestamp"},
+			expected:            stringValues("2024-12-25", "id_public_holidays", "xmas_sale"),
+		},
+		{
+			name: "success | unix timestamp on the previous date",
+			requestParam: map[string]interface{}{
+				"tz":        "America/New_York",
+				"timestamp": float64(1735084800),
+			},
+			experimentVariables: []string{"tz", "timestamp"},
+			expected:            stringValues("2024-12-24"),
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			transformation, err := s.datesRunner.Transform(s.name, data.requestParam, data.experimentVariables)
+			if data.errString == "" {
+				s.Suite.Require().NoError(err)
+				s.Suite.Require().Equal(data.expected, transformation)
+			} else {
+				s.Suite.Assert().EqualError(err, data.errString)
+			}
+		})
+	}
+}
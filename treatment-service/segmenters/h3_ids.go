@@ -0,0 +1,124 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/spf13/cast"
+	"github.com/uber/h3-go/v4"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	"github.com/caraml-dev/xp/common/utils"
+	"github.com/caraml-dev/xp/treatment-service/util"
+)
+
+const (
+	// MinH3Resolution is the permissible minimum value for H3 cell resolution
+	MinH3Resolution = 0
+	// MaxH3Resolution is the permissible maximum value for H3 cell resolution
+	MaxH3Resolution = h3.MaxResolution
+)
+
+type H3IDSegmenterConfig struct {
+	MinH3Resolution int `json:"minh3resolution"`
+	MaxH3Resolution int `json:"maxh3resolution"`
+}
+
+func NewH3IDRunner(configData json.RawMessage) (Runner, error) {
+	segmenterErrTpl := "failed to create segmenter (h3_ids): %s"
+	config := H3IDSegmenterConfig{MinH3Resolution: MinH3Resolution, MaxH3Resolution: MaxH3Resolution}
+
+	// All resolutions are used if the segmenter is not configured
+	if len(configData) > 0 {
+		err := json.Unmarshal(configData, &config)
+		if err != nil {
+			return nil, fmt.Errorf(segmenterErrTpl, err)
+		}
+	}
+
+	// Verify configured resolutions
+	if config.MinH3Resolution < MinH3Resolution ||
+		config.MinH3Resolution > MaxH3Resolution ||
+		config.MaxH3Resolution < MinH3Resolution ||
+		config.MaxH3Resolution > MaxH3Resolution {
+		return nil, fmt.Errorf(segmenterErrTpl,
+			fmt.Sprintf("H3 cell resolutions should be in the range %d - %d", MinH3Resolution, MaxH3Resolution))
+	}
+
+	h3IDConfig := &SegmenterConfig{
+		Name: "h3_ids",
+	}
+
+	return &h3ids{
+		NewBaseRunner(h3IDConfig), config.MinH3Resolution, config.MaxH3Resolution,
+	}, nil
+}
+
+type h3ids struct {
+	Runner
+	MinH3Resolution int
+	MaxH3Resolution int
+}
+
+func (s *h3ids) Transform(
+	segmenter string,
+	requestValues map[string]interface{},
+	experimentVariables []string,
+) ([]*_segmenters.SegmenterValue, error) {
+	var h3Cell h3.Cell
+	switch {
+	case cmp.Diff(experimentVariables, []string{"latitude", "longitude"}, cmpopts.SortSlices(utils.Less)) == "":
+		// Convert latitude to appropriate float64 type
+		latitude, err := cast.ToFloat64E(requestValues["latitude"])
+		if err != nil {
+			return nil, err
+		}
+		// Convert longitude to appropriate float64 type
+		longitude, err := cast.ToFloat64E(requestValues["longitude"])
+		if err != nil {
+			return nil, err
+		}
+		// Generate H3 cell for the supplied resolution
+		retrievedH3id, err := util.GetH3ID(latitude, longitude, s.MaxH3Resolution)
+		if err != nil {
+			return nil, err
+		}
+		h3Cell = retrievedH3id
+	case cmp.Equal(experimentVariables, []string{"h3id"}):
+		h3id, ok := requestValues["h3id"].(string)
+		if !ok {
+			return nil, fmt.Errorf(TypeCastingErrorTmpl, "h3id", segmenter, "string")
+		}
+		h3Cell = h3.Cell(h3.IndexFromString(h3id))
+		if !h3Cell.IsValid() {
+			return nil, fmt.Errorf("provided h3id variable for %s segmenter is invalid", segmenter)
+		}
+	default:
+		return nil, fmt.Errorf("no valid variables were provided for %s segmenter", segmenter)
+	}
+	segmenterValues := []*_segmenters.SegmenterValue{}
+
+	// Order defines H3 cell matching priority, i.e match H3 cell based on decreasing granularity. A provided
+	// cell is matched from its own resolution, if it is coarser than the max resolution.
+	maxResolution := s.MaxH3Resolution
+	if h3Cell.Resolution() < maxResolution {
+		maxResolution = h3Cell.Resolution()
+	}
+	for i := maxResolution; i >= s.MinH3Resolution; i-- {
+		h3IdAtResolution := h3Cell.Parent(i).String()
+		segmenterValue := &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_String_{String_: h3IdAtResolution}}
+		segmenterValues = append(segmenterValues, segmenterValue)
+	}
+
+	return segmenterValues, nil
+}
+
+func init() {
+	err := Register("h3_ids", NewH3IDRunner)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
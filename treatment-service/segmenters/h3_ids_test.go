@@ -0,0 +1,151 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+type H3IDsRunnerTestSuite struct {
+	suite.Suite
+
+	runner Runner
+	name   string
+	config map[string]interface{}
+}
+
+func (suite *H3IDsRunnerTestSuite) SetupSuite() {
+	suite.config = map[string]interface{}{
+		"minh3resolution": 5,
+		"maxh3resolution": 9,
+	}
+	suite.name = "h3_ids"
+
+	configJSON, err := json.Marshal(suite.config)
+	suite.Require().NoError(err)
+	s, err := NewH3IDRunner(configJSON)
+	suite.Require().NoError(err)
+	suite.runner = s
+}
+
+func TestH3IDsRunnerTestSuite(t *testing.T) {
+	suite.Run(t, new(H3IDsRunnerTestSuite))
+}
+
+func (s *H3IDsRunnerTestSuite) TestNewH3IDRunner() {
+	_, err := NewH3IDRunner(json.RawMessage(`{"minh3resolution": 5, "maxh3resolution": 16}`))
+	s.Suite.Assert().EqualError(err, "failed to create segmenter (h3_ids): "+
+		"H3 cell resolutions should be in the range 0 - 15")
+}
+
+func (s *H3IDsRunnerTestSuite) TestTransform() {
+	t := s.Suite.T()
+
+	h3idValues := func(values ...string) []*_segmenters.SegmenterValue {
+		segmenterValues := []*_segmenters.SegmenterValue{}
+		for _, value := range values {
+			segmenterValues = append(segmenterValues, &_segmenters.SegmenterValue{
+				Value: &_segmenters.SegmenterValue_String_{String_: value},
+			})
+		}
+		return segmenterValues
+	}
+
+	tests := []struct {
+		name                string
+		requestParam        map[string]interface{}
+		experimentVariables []string
+		expected            []*_segmenters.SegmenterValue
+		errString           string
+	}{
+		{
+			name: "failure | no valid variable",
+			requestParam: map[string]interface{}{
+				"h3id": "8928308280fffff",
+			},
+			experimentVariables: []string{"invalid_var"},
+			errString:           fmt.Sprintf("no valid variables were provided for %s segmenter", s.name),
+		},
+		{
+			name: "failure | invalid h3id",
+			requestParam: map[string]interface{}{
+				"h3id": "zz",
+			},
+			experimentVariables: []string{"h3id"},
+			errString:           fmt.Sprintf("provided h3id variable for %s segmenter is invalid", s.name),
+		},
+		{
+			name: "failure | invalid type h3id variable",
+			requestParam: map[string]interface{}{
+				"h3id": float64(617700169958293503),
+			},
+			experimentVariables: []string{"h3id"},
+			errString:           fmt.Sprintf(TypeCastingErrorTmpl, "h3id", s.name, "string"),
+		},
+		{
+			name: "failure | invalid latitude",
+			requestParam: map[string]interface{}{
+				"latitude":  float64(95),
+				"longitude": -122.41795063018799,
+			},
+			experimentVariables: []string{"latitude", "longitude"},
+			errString:           "received invalid latitude, longitude values",
+		},
+		{
+			name: "success | lat-long + ordering",
+			requestParam: map[string]interface{}{
+				"latitude":  37.775938728915946,
+				"longitude": -122.41795063018799,
+			},
+			experimentVariables: []string{"longitude", "latitude"},
+			expected: h3idValues(
+				"8928308280fffff", "8828308281fffff", "872830828ffffff", "86283082fffffff", "85283083fffffff",
+			),
+		},
+		{
+			name: "success | h3id",
+			requestParam: map[string]interface{}{
+				"h3id": "8928308280fffff",
+			},
+			experimentVariables: []string{"h3id"},
+			expected: h3idValues(
+				"8928308280fffff", "8828308281fffff", "872830828ffffff", "86283082fffffff", "85283083fffffff",
+			),
+		},
+		{
+			name: "success | h3id finer than the max resolution",
+			requestParam: map[string]interface{}{
+				"h3id": "8a28308280f7fff",
+			},
+			experimentVariables: []string{"h3id"},
+			expected: h3idValues(
+				"8928308280fffff", "8828308281fffff", "872830828ffffff", "86283082fffffff", "85283083fffffff",
+			),
+		},
+		{
+			name: "success | h3id coarser than the max resolution",
+			requestParam: map[string]interface{}{
+				"h3id": "872830828ffffff",
+			},
+			experimentVariables: []string{"h3id"},
+			expected:            h3idValues("872830828ffffff", "86283082fffffff", "85283083fffffff"),
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			transformation, err := s.runner.Transform(s.name, data.requestParam, data.experimentVariables)
+			if data.errString == "" {
+				s.Suite.Require().NoError(err)
+				s.Suite.Require().Equal(data.expected, transformation)
+			} else {
+				s.Suite.Assert().EqualError(err, data.errString)
+			}
+		})
+	}
+}
@@ -135,3 +135,73 @@ func (s *RunnersTestSuite) TestBaseRunnerTransform() {
 		})
 	}
 }
+
+func (s *RunnersTestSuite) TestBaseRunnerTransformRequestFields() {
+	t := s.Suite.T()
+	segmenterName := "tier"
+	protoStringType := _segmenters.SegmenterValueType_STRING
+	requestFields := []string{"user.profile.tier", "customer.tier", "tier_name"}
+	tests := []struct {
+		testName      string
+		requestValues map[string]interface{}
+		expected      []*_segmenters.SegmenterValue
+		errString     string
+	}{
+		{
+			testName: "success | nested field",
+			requestValues: map[string]interface{}{
+				"user":      map[string]interface{}{"profile": map[string]interface{}{"tier": "gold"}},
+				"tier_name": "silver",
+			},
+			expected: []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_String_{String_: "gold"}}},
+		},
+		{
+			testName: "success | field named with dots",
+			requestValues: map[string]interface{}{
+				"customer.tier": "gold",
+				"tier_name":     "silver",
+			},
+			expected: []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_String_{String_: "gold"}}},
+		},
+		{
+			testName: "success | lower priority field",
+			requestValues: map[string]interface{}{
+				"user":      map[string]interface{}{"profile": nil},
+				"tier_name": "silver",
+			},
+			expected: []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_String_{String_: "silver"}}},
+		},
+		{
+			testName: "success | missing fields",
+			requestValues: map[string]interface{}{
+				segmenterName: "gold",
+				"user":        "1234",
+			},
+			expected: []*_segmenters.SegmenterValue{},
+		},
+		{
+			testName: "failure | type",
+			requestValues: map[string]interface{}{
+				"tier_name": 1,
+			},
+			errString: "segmenter type for tier is not supported",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			runner := NewBaseRunner(&SegmenterConfig{
+				Name:          segmenterName,
+				Type:          &protoStringType,
+				RequestFields: requestFields,
+			})
+			res, err := runner.Transform(segmenterName, test.requestValues, []string{requestFields[0]})
+			if test.errString == "" {
+				s.Assert().NoError(err)
+				s.Assert().Equal(test.expected, res)
+			} else {
+				s.Assert().EqualError(err, test.errString)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
+	"github.com/caraml-dev/xp/treatment-service/util"
+)
+
+type UnitBucketSegmenterConfig struct {
+	// Salt is combined with the randomization key before hashing, so that changing it reshuffles the buckets
+	Salt string `json:"salt"`
+	// NumBuckets is the no. of buckets that the randomization keys are hashed into
+	NumBuckets int64 `json:"numbuckets"`
+}
+
+func NewUnitBucketRunner(configData json.RawMessage) (Runner, error) {
+	segmenterErrTpl := "failed to create segmenter (unit_bucket): %s"
+	config := UnitBucketSegmenterConfig{Salt: _utils.DefaultUnitBucketSalt, NumBuckets: _utils.DefaultUnitBuckets}
+
+	// The default salt and no. of buckets are used if the segmenter is not configured
+	if len(configData) > 0 {
+		err := json.Unmarshal(configData, &config)
+		if err != nil {
+			return nil, fmt.Errorf(segmenterErrTpl, err)
+		}
+	}
+	if config.NumBuckets < 1 || config.NumBuckets > _utils.MaxUnitBuckets {
+		return nil, fmt.Errorf(segmenterErrTpl,
+			fmt.Sprintf("the no. of buckets should be in the range 1 - %d", int64(_utils.MaxUnitBuckets)))
+	}
+
+	unitBucketConfig := &SegmenterConfig{
+		Name: _utils.UnitBucketSegmenter,
+	}
+
+	return &unitBucket{NewBaseRunner(unitBucketConfig), config.Salt, uint32(config.NumBuckets)}, nil
+}
+
+type unitBucket struct {
+	Runner
+	salt       string
+	numBuckets uint32
+}
+
+// Transform hashes the randomization key of the request with the salt into one of the buckets. The experiment
+// variable is the randomization key of the project, as validated by the Management Service.
+func (s *unitBucket) Transform(
+	segmenter string,
+	requestValues map[string]interface{},
+	experimentVariables []string,
+) ([]*_segmenters.SegmenterValue, error) {
+	if len(experimentVariables) != 1 {
+		return nil, fmt.Errorf("no valid variables were provided for %s segmenter", segmenter)
+	}
+	randomizationKey := experimentVariables[0]
+
+	// The randomization value is formatted as for the experiments' own splits
+	var randomizationValue string
+	switch value := requestValues[randomizationKey].(type) {
+	case string:
+		randomizationValue = value
+	case float64:
+		randomizationValue = strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return nil, fmt.Errorf(TypeCastingErrorTmpl, randomizationKey, segmenter, "string or number")
+	}
+	bucket := util.Hash(fmt.Sprintf("%s-%s", s.salt, randomizationValue)) % s.numBuckets
+	segmenterValue := []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_Integer{Integer: int64(bucket)}}}
+
+	return segmenterValue, nil
+}
+
+func init() {
+	err := Register(_utils.UnitBucketSegmenter, NewUnitBucketRunner)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
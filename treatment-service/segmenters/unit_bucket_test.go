@@ -0,0 +1,115 @@
+package segmenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+)
+
+type UnitBucketRunnerTestSuite struct {
+	suite.Suite
+
+	unitBucketRunner Runner
+	name             string
+}
+
+func (suite *UnitBucketRunnerTestSuite) SetupSuite() {
+	suite.name = "unit_bucket"
+
+	s, err := NewUnitBucketRunner(nil)
+	suite.Require().NoError(err)
+	suite.unitBucketRunner = s
+}
+
+func TestUnitBucketRunnerTestSuite(t *testing.T) {
+	suite.Run(t, new(UnitBucketRunnerTestSuite))
+}
+
+func (s *UnitBucketRunnerTestSuite) TestNewUnitBucketRunner() {
+	configData, err := json.Marshal(UnitBucketSegmenterConfig{NumBuckets: 0})
+	s.Suite.Require().NoError(err)
+	_, err = NewUnitBucketRunner(configData)
+	s.Suite.Assert().EqualError(err, "failed to create segmenter (unit_bucket): "+
+		"the no. of buckets should be in the range 1 - 4294967295")
+
+	// The salt and the no. of buckets change the bucket of the same randomization key
+	configData, err = json.Marshal(UnitBucketSegmenterConfig{Salt: "market_split", NumBuckets: 10})
+	s.Suite.Require().NoError(err)
+	runner, err := NewUnitBucketRunner(configData)
+	s.Suite.Require().NoError(err)
+	transformation, err := runner.Transform(s.name, map[string]interface{}{"customer_id": "1234"}, []string{"customer_id"})
+	s.Suite.Require().NoError(err)
+	s.Suite.Assert().Equal([]*_segmenters.SegmenterValue{
+		{Value: &_segmenters.SegmenterValue_Integer{Integer: 7}},
+	}, transformation)
+}
+
+func (s *UnitBucketRunnerTestSuite) TestUnitBucketTransform() {
+	t := s.Suite.T()
+
+	tests := []struct {
+		name                string
+		requestParam        map[string]interface{}
+		experimentVariables []string
+		expected            []*_segmenters.SegmenterValue
+		errString           string
+	}{
+		{
+			name: "failure | no valid variable",
+			requestParam: map[string]interface{}{
+				"customer_id": "1234",
+			},
+			experimentVariables: []string{"customer_id", "session_id"},
+			errString:           fmt.Sprintf("no valid variables were provided for %s segmenter", s.name),
+		},
+		{
+			name: "failure | invalid type",
+			requestParam: map[string]interface{}{
+				"customer_id": true,
+			},
+			experimentVariables: []string{"customer_id"},
+			errString:           fmt.Sprintf(TypeCastingErrorTmpl, "customer_id", s.name, "string or number"),
+		},
+		{
+			name: "success | string randomization key",
+			requestParam: map[string]interface{}{
+				"customer_id": "1234",
+			},
+			experimentVariables: []string{"customer_id"},
+			expected:            []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_Integer{Integer: 97}}},
+		},
+		{
+			name: "success | numeric randomization key",
+			requestParam: map[string]interface{}{
+				"customer_id": float64(1234),
+			},
+			experimentVariables: []string{"customer_id"},
+			expected:            []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_Integer{Integer: 97}}},
+		},
+		{
+			name: "success | other randomization key",
+			requestParam: map[string]interface{}{
+				"session_id": "abc",
+			},
+			experimentVariables: []string{"session_id"},
+			expected:            []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_Integer{Integer: 27}}},
+		},
+	}
+
+	// Run tests
+	for _, data := range tests {
+		t.Run(data.name, func(t *testing.T) {
+			transformation, err := s.unitBucketRunner.Transform(s.name, data.requestParam, data.experimentVariables)
+			if data.errString == "" {
+				s.Suite.Require().NoError(err)
+				s.Suite.Require().Equal(data.expected, transformation)
+			} else {
+				s.Suite.Assert().EqualError(err, data.errString)
+			}
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 	_ "go.uber.org/automaxprocs"
 
 	common_mq_config "github.com/caraml-dev/xp/common/messagequeue"
+	"github.com/caraml-dev/xp/common/tracing"
 	"github.com/caraml-dev/xp/common/web"
 	"github.com/caraml-dev/xp/treatment-service/api"
 	"github.com/caraml-dev/xp/treatment-service/appcontext"
@@ -29,6 +30,8 @@ import (
 type Server struct {
 	*http.Server
 	appContext *appcontext.AppContext
+	// configWatcher reloads the config files when they change, if hot reloading is enabled
+	configWatcher *config.Watcher
 	// subscribe captures config of whether to subscribe to a message queue topic
 	subscribe bool
 	// cleanup captures all the actions to be executed on server shut down
@@ -62,11 +65,31 @@ func NewServer(configFiles []string) (*Server, error) {
 		cleanup = append(cleanup, func() { sentry.Close() })
 	}
 
+	// Init OpenTelemetry tracing
+	if cfg.TracingConfig.Enabled {
+		shutdownTracer, err := tracing.InitTracer("xp-treatment-service", cfg.TracingConfig)
+		if err != nil {
+			log.Println(fmt.Errorf("failed initializing tracing: %s", err))
+		} else {
+			cleanup = append(cleanup, func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				if err := shutdownTracer(ctx); err != nil {
+					log.Println(fmt.Errorf("failed flushing the traces: %s", err))
+				}
+			})
+		}
+	}
+
 	// Init AppContext
 	appCtx, err := appcontext.NewAppContext(cfg)
 	if err != nil {
 		log.Panicf("Failed initializing application appcontext: %v", err)
 	}
+	// Flush the assigned treatment logs that are still queued
+	if appCtx.AssignedTreatmentLogger != nil {
+		cleanup = append(cleanup, appCtx.AssignedTreatmentLogger.Stop)
+	}
 
 	// Create Chi router and add middlewares
 	router := chi.NewRouter()
@@ -83,6 +106,10 @@ func NewServer(configFiles []string) (*Server, error) {
 	if cfg.NewRelicConfig.Enabled {
 		router.Use(middleware.NewRelicMiddleware())
 	}
+	// Add tracing middleware
+	if cfg.TracingConfig.Enabled {
+		router.Use(middleware.TracingMiddleware())
+	}
 
 	// Configure controllers
 	treatmentController := controller.NewTreatmentController(*appCtx, *cfg)
@@ -101,6 +128,18 @@ func NewServer(configFiles []string) (*Server, error) {
 		mux.Handle("/schema.yaml", web.FileHandler(path.Join(cfg.SwaggerConfig.OpenAPISpecsPath, "schema.yaml"), false))
 	}
 
+	var configWatcher *config.Watcher
+	if cfg.HotReloadConfig.Enabled && len(configFiles) > 0 {
+		configWatcher, err = config.NewWatcher(
+			configFiles,
+			time.Duration(cfg.HotReloadConfig.PollIntervalSeconds)*time.Second,
+			appCtx.Reload,
+		)
+		if err != nil {
+			log.Panicf("Failed initializing config watcher: %v", err)
+		}
+	}
+
 	subscribe := false
 	if cfg.MessageQueueConfig.Kind != common_mq_config.NoopMQ {
 		subscribe = true
@@ -112,10 +151,11 @@ func NewServer(configFiles []string) (*Server, error) {
 	}
 
 	return &Server{
-		Server:     &srv,
-		appContext: appCtx,
-		subscribe:  subscribe,
-		cleanup:    cleanup,
+		Server:        &srv,
+		appContext:    appCtx,
+		configWatcher: configWatcher,
+		subscribe:     subscribe,
+		cleanup:       cleanup,
 	}, nil
 }
 
@@ -144,6 +184,16 @@ func (srv *Server) Start() {
 	}
 	log.Println("Shutting down server...")
 	cancelBackgroundSvc()
+	// Stop reloading the config, before the components are cleaned up
+	if srv.configWatcher != nil {
+		srv.configWatcher.Stop()
+	}
+
+	// Stop serving requests before the clean up actions, so that no more treatments are logged
+	// after the logger has been flushed
+	if err := srv.Shutdown(context.Background()); err != nil {
+		panic(err)
+	}
 
 	// Execute clean up actions
 	for _, cleanupFunc := range srv.cleanup {
@@ -155,9 +205,6 @@ func (srv *Server) Start() {
 			log.Printf("Failed to delete subscriptions when shutting down: %s", err)
 		}
 	}
-	if err := srv.Shutdown(context.Background()); err != nil {
-		panic(err)
-	}
 	log.Println("Server gracefully stopped")
 }
 
@@ -184,5 +231,10 @@ func (srv *Server) startBackgroundService(errChannel chan error) context.CancelF
 		srv.appContext.PollerService.Start()
 	}
 
+	// Start watching the config after the poller, which may be replaced when the config is reloaded
+	if srv.configWatcher != nil {
+		srv.configWatcher.Start()
+	}
+
 	return cancel
 }
@@ -0,0 +1,388 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/caraml-dev/xp/treatment-service/config"
+	"github.com/caraml-dev/xp/treatment-service/instrumentation"
+	"github.com/caraml-dev/xp/treatment-service/models"
+)
+
+type EnrichmentService interface {
+	// Enrich returns the request values merged with the variables looked up for the given project. The values
+	// sent in the request are never replaced. The lookups that fail or exceed the timeout of the project are
+	// skipped, and their errors are returned together with the values enriched by the other lookups.
+	Enrich(
+		ctx context.Context,
+		projectId models.ProjectId,
+		requestValues map[string]interface{},
+	) (map[string]interface{}, error)
+}
+
+// enrichmentSource retrieves the variables of a key. Unknown keys have no variables.
+type enrichmentSource interface {
+	lookup(ctx context.Context, key string) (map[string]interface{}, error)
+}
+
+type enrichmentLookup struct {
+	sync.Mutex
+
+	kind     config.EnrichmentLookupKind
+	source   enrichmentSource
+	keyField string
+	fields   []string
+	// projectIds is nil when the lookup applies to all projects
+	projectIds map[models.ProjectId]bool
+	// caches are created on the first lookup of each project
+	caches map[models.ProjectId]map[string]cachedVariables
+}
+
+type cachedVariables struct {
+	variables map[string]interface{}
+	expiry    time.Time
+}
+
+type enrichmentService struct {
+	lookups         []*enrichmentLookup
+	defaultSettings config.EnrichmentSettings
+	projectSettings map[models.ProjectId]config.EnrichmentSettings
+
+	metricService MetricService
+	now           func() time.Time
+}
+
+func NewEnrichmentService(cfg config.EnrichmentConfig, metricService MetricService) (EnrichmentService, error) {
+	if err := validateEnrichmentSettings(cfg.DefaultSettings); err != nil {
+		return nil, fmt.Errorf("invalid default enrichment settings: %s", err)
+	}
+
+	projectSettings := make(map[models.ProjectId]config.EnrichmentSettings, len(cfg.ProjectSettings))
+	for key, settings := range cfg.ProjectSettings {
+		projectId, err := parseEnrichmentProjectId(key)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateEnrichmentSettings(settings); err != nil {
+			return nil, fmt.Errorf("invalid enrichment settings for project %s: %s", key, err)
+		}
+		projectSettings[projectId] = settings
+	}
+
+	lookups := make([]*enrichmentLookup, 0, len(cfg.Lookups))
+	for i, lookupConfig := range cfg.Lookups {
+		lookup, err := newEnrichmentLookup(lookupConfig)
+		if err != nil {
+			return nil, fmt.Errorf("invalid enrichment lookup %d: %s", i, err)
+		}
+		lookups = append(lookups, lookup)
+	}
+
+	svc := &enrichmentService{
+		lookups:         lookups,
+		defaultSettings: cfg.DefaultSettings,
+		projectSettings: projectSettings,
+		metricService:   metricService,
+		now:             time.Now,
+	}
+
+	return svc, nil
+}
+
+func (es *enrichmentService) Enrich(
+	ctx context.Context,
+	projectId models.ProjectId,
+	requestValues map[string]interface{},
+) (map[string]interface{}, error) {
+	settings, ok := es.projectSettings[projectId]
+	if !ok {
+		settings = es.defaultSettings
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(settings.TimeoutMS)*time.Millisecond)
+	defer cancel()
+
+	// Run the lookups concurrently, so that the timeout applies to all of them at once
+	results := make([]map[string]interface{}, len(es.lookups))
+	errs := make([]error, len(es.lookups))
+	var wg sync.WaitGroup
+	for i, lookup := range es.lookups {
+		if lookup.projectIds != nil && !lookup.projectIds[projectId] {
+			continue
+		}
+		key, ok := requestValues[lookup.keyField]
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, lookup *enrichmentLookup) {
+			defer wg.Done()
+			results[i], errs[i] = es.lookup(ctx, lookup, projectId, settings, key)
+		}(i, lookup)
+	}
+	wg.Wait()
+
+	var enrichedValues map[string]interface{}
+	for i, lookup := range es.lookups {
+		if errs[i] != nil {
+			labels := es.metricService.GetProjectNameLabel(projectId)
+			labels["kind"] = string(lookup.kind)
+			es.metricService.LogRequestCount(labels, instrumentation.EnrichmentErrorCount)
+			errs[i] = fmt.Errorf("failed to look up %s: %s", lookup.keyField, errs[i])
+			continue
+		}
+		// The variables of the earlier lookups take precedence
+		for name, value := range results[i] {
+			if _, ok := requestValues[name]; ok {
+				continue
+			}
+			if _, ok := enrichedValues[name]; ok {
+				continue
+			}
+			if enrichedValues == nil {
+				enrichedValues = make(map[string]interface{}, len(requestValues))
+				for k, v := range requestValues {
+					enrichedValues[k] = v
+				}
+			}
+			enrichedValues[name] = value
+		}
+	}
+	if enrichedValues == nil {
+		enrichedValues = requestValues
+	}
+
+	return enrichedValues, errors.Join(errs...)
+}
+
+func (es *enrichmentService) lookup(
+	ctx context.Context,
+	lookup *enrichmentLookup,
+	projectId models.ProjectId,
+	settings config.EnrichmentSettings,
+	keyValue interface{},
+) (map[string]interface{}, error) {
+	var key string
+	switch value := keyValue.(type) {
+	case string:
+		key = value
+	case float64:
+		key = strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return nil, fmt.Errorf("invalid type of key (%T); expected string or number", keyValue)
+	}
+
+	if variables, ok := lookup.getCached(projectId, key, es.now()); ok {
+		return variables, nil
+	}
+	variables, err := lookup.source.lookup(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	variables = lookup.filterFields(variables)
+	if settings.CacheTTLSeconds > 0 && settings.MaxCacheSize > 0 {
+		ttl := time.Duration(settings.CacheTTLSeconds) * time.Second
+		lookup.setCached(projectId, key, variables, es.now(), ttl, settings.MaxCacheSize)
+	}
+	return variables, nil
+}
+
+func (l *enrichmentLookup) getCached(projectId models.ProjectId, key string, now time.Time) (map[string]interface{}, bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	cached, ok := l.caches[projectId][key]
+	if !ok || !now.Before(cached.expiry) {
+		return nil, false
+	}
+	return cached.variables, true
+}
+
+func (l *enrichmentLookup) setCached(
+	projectId models.ProjectId,
+	key string,
+	variables map[string]interface{},
+	now time.Time,
+	ttl time.Duration,
+	maxSize int,
+) {
+	l.Lock()
+	defer l.Unlock()
+
+	cache, ok := l.caches[projectId]
+	if !ok {
+		cache = map[string]cachedVariables{}
+		l.caches[projectId] = cache
+	}
+	if _, ok := cache[key]; !ok && len(cache) >= maxSize {
+		// Evict the expired keys, or an arbitrary key when none has expired
+		for k, v := range cache {
+			if !now.Before(v.expiry) {
+				delete(cache, k)
+			}
+		}
+		for k := range cache {
+			if len(cache) < maxSize {
+				break
+			}
+			delete(cache, k)
+		}
+	}
+	cache[key] = cachedVariables{variables: variables, expiry: now.Add(ttl)}
+}
+
+func (l *enrichmentLookup) filterFields(variables map[string]interface{}) map[string]interface{} {
+	if len(l.fields) == 0 {
+		return variables
+	}
+	filtered := make(map[string]interface{}, len(l.fields))
+	for _, field := range l.fields {
+		if value, ok := variables[field]; ok {
+			filtered[field] = value
+		}
+	}
+	return filtered
+}
+
+func newEnrichmentLookup(cfg config.EnrichmentLookup) (*enrichmentLookup, error) {
+	if cfg.KeyField == "" {
+		return nil, errors.New("key field cannot be empty")
+	}
+
+	var source enrichmentSource
+	var err error
+	switch cfg.Kind {
+	case config.FileEnrichmentLookup:
+		source, err = newFileEnrichmentSource(cfg.Path)
+	case config.HTTPEnrichmentLookup:
+		source, err = newHTTPEnrichmentSource(cfg.URL, cfg.Headers)
+	default:
+		err = fmt.Errorf("unrecognized lookup kind: %s", cfg.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var projectIds map[models.ProjectId]bool
+	if len(cfg.ProjectIds) > 0 {
+		projectIds = make(map[models.ProjectId]bool, len(cfg.ProjectIds))
+		for _, key := range cfg.ProjectIds {
+			projectId, err := parseEnrichmentProjectId(key)
+			if err != nil {
+				return nil, err
+			}
+			projectIds[projectId] = true
+		}
+	}
+
+	return &enrichmentLookup{
+		kind:       cfg.Kind,
+		source:     source,
+		keyField:   cfg.KeyField,
+		fields:     cfg.Fields,
+		projectIds: projectIds,
+		caches:     map[models.ProjectId]map[string]cachedVariables{},
+	}, nil
+}
+
+func parseEnrichmentProjectId(key string) (models.ProjectId, error) {
+	projectId, err := strconv.ParseUint(key, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid project id %s in enrichment config: %s", key, err)
+	}
+	return models.NewProjectId(int64(projectId)), nil
+}
+
+func validateEnrichmentSettings(settings config.EnrichmentSettings) error {
+	if settings.TimeoutMS <= 0 {
+		return fmt.Errorf("timeout %d is not positive", settings.TimeoutMS)
+	}
+	if settings.CacheTTLSeconds < 0 {
+		return fmt.Errorf("cache ttl %d is negative", settings.CacheTTLSeconds)
+	}
+	if settings.MaxCacheSize < 0 {
+		return fmt.Errorf("max cache size %d is negative", settings.MaxCacheSize)
+	}
+	return nil
+}
+
+// fileEnrichmentSource holds the variables of a local JSON file, of keys to objects of variables, which is read
+// once at startup.
+type fileEnrichmentSource struct {
+	variables map[string]map[string]interface{}
+}
+
+func newFileEnrichmentSource(path string) (*fileEnrichmentSource, error) {
+	if path == "" {
+		return nil, errors.New("path of file lookup cannot be empty")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	variables := map[string]map[string]interface{}{}
+	if err := json.Unmarshal(data, &variables); err != nil {
+		return nil, fmt.Errorf("invalid file %s: %s", path, err)
+	}
+	return &fileEnrichmentSource{variables: variables}, nil
+}
+
+func (s *fileEnrichmentSource) lookup(_ context.Context, key string) (map[string]interface{}, error) {
+	return s.variables[key], nil
+}
+
+// httpEnrichmentSource retrieves the variables with a GET request to an enrichment service
+type httpEnrichmentSource struct {
+	client  *http.Client
+	url     string
+	headers map[string]string
+}
+
+func newHTTPEnrichmentSource(rawURL string, headers map[string]string) (*httpEnrichmentSource, error) {
+	if rawURL == "" {
+		return nil, errors.New("url of http lookup cannot be empty")
+	}
+	if _, err := url.Parse(rawURL); err != nil {
+		return nil, err
+	}
+	return &httpEnrichmentSource{client: &http.Client{}, url: rawURL, headers: headers}, nil
+}
+
+func (s *httpEnrichmentSource) lookup(ctx context.Context, key string) (map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(
+		ctx, http.MethodGet, strings.ReplaceAll(s.url, "{key}", url.PathEscape(key)), nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range s.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	variables := map[string]interface{}{}
+	if err := json.NewDecoder(resp.Body).Decode(&variables); err != nil {
+		return nil, fmt.Errorf("invalid response: %s", err)
+	}
+	return variables, nil
+}
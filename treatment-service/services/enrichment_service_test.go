@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/treatment-service/config"
+	"github.com/caraml-dev/xp/treatment-service/models"
+)
+
+var testEnrichmentSettings = config.EnrichmentSettings{TimeoutMS: 1000, CacheTTLSeconds: 60, MaxCacheSize: 100}
+
+func newTestEnrichmentService(t *testing.T, cfg config.EnrichmentConfig, now *time.Time) EnrichmentService {
+	storage := models.LocalStorage{}
+	for _, projectId := range []int64{1, 2} {
+		storage.ProjectSettings = append(storage.ProjectSettings, models.OpenAPIProjectSettingsSpecToProtobuf(
+			schema.ProjectSettings{ProjectId: projectId, Username: "user"},
+			nil,
+		))
+	}
+	metricService, err := NewMetricService(config.Monitoring{Kind: config.NoopMetricSink}, &storage)
+	require.NoError(t, err)
+
+	svc, err := NewEnrichmentService(cfg, metricService)
+	require.NoError(t, err)
+	svc.(*enrichmentService).now = func() time.Time { return *now }
+	return svc
+}
+
+func writeTestEnrichmentFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "users.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestNewEnrichmentService(t *testing.T) {
+	path := writeTestEnrichmentFile(t, `{"1": {"tier": "gold"}}`)
+
+	tests := map[string]struct {
+		cfg    config.EnrichmentConfig
+		errMsg string
+	}{
+		"success": {
+			cfg: config.EnrichmentConfig{
+				Lookups: []config.EnrichmentLookup{
+					{Kind: config.FileEnrichmentLookup, KeyField: "user_id", Path: path},
+					{Kind: config.HTTPEnrichmentLookup, KeyField: "user_id", URL: "http://profiles/users/{key}"},
+				},
+				DefaultSettings: testEnrichmentSettings,
+				ProjectSettings: map[string]config.EnrichmentSettings{"1": {TimeoutMS: 10}},
+			},
+		},
+		"failure | timeout": {
+			cfg:    config.EnrichmentConfig{},
+			errMsg: "invalid default enrichment settings: timeout 0 is not positive",
+		},
+		"failure | project id": {
+			cfg: config.EnrichmentConfig{
+				DefaultSettings: testEnrichmentSettings,
+				ProjectSettings: map[string]config.EnrichmentSettings{"abc": testEnrichmentSettings},
+			},
+			errMsg: `invalid project id abc in enrichment config: strconv.ParseUint: parsing "abc": invalid syntax`,
+		},
+		"failure | kind": {
+			cfg: config.EnrichmentConfig{
+				Lookups:         []config.EnrichmentLookup{{Kind: "redis", KeyField: "user_id"}},
+				DefaultSettings: testEnrichmentSettings,
+			},
+			errMsg: "invalid enrichment lookup 0: unrecognized lookup kind: redis",
+		},
+		"failure | key field": {
+			cfg: config.EnrichmentConfig{
+				Lookups:         []config.EnrichmentLookup{{Kind: config.FileEnrichmentLookup, Path: path}},
+				DefaultSettings: testEnrichmentSettings,
+			},
+			errMsg: "invalid enrichment lookup 0: key field cannot be empty",
+		},
+		"failure | invalid file": {
+			cfg: config.EnrichmentConfig{
+				Lookups: []config.EnrichmentLookup{{
+					Kind: config.FileEnrichmentLookup, KeyField: "user_id", Path: writeTestEnrichmentFile(t, "[]"),
+				}},
+				DefaultSettings: testEnrichmentSettings,
+			},
+			errMsg: "invalid enrichment lookup 0: invalid file",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewEnrichmentService(data.cfg, nil)
+			if data.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), data.errMsg)
+			}
+		})
+	}
+}
+
+func TestEnrichmentServiceEnrichFromFile(t *testing.T) {
+	now := time.Now()
+	svc := newTestEnrichmentService(t, config.EnrichmentConfig{
+		Lookups: []config.EnrichmentLookup{
+			{
+				Kind:     config.FileEnrichmentLookup,
+				KeyField: "user_id",
+				Fields:   []string{"tier", "country"},
+				Path: writeTestEnrichmentFile(t,
+					`{"1234": {"tier": "gold", "country": "SG", "cohort": 3}, "5678": {"tier": "silver"}}`),
+			},
+			{
+				Kind:       config.FileEnrichmentLookup,
+				KeyField:   "user_id",
+				ProjectIds: []string{"2"},
+				Path:       writeTestEnrichmentFile(t, `{"1234": {"tier": "bronze", "cohort": 3}}`),
+			},
+		},
+		DefaultSettings: testEnrichmentSettings,
+	}, &now)
+
+	tests := map[string]struct {
+		projectId models.ProjectId
+		request   map[string]interface{}
+		expected  map[string]interface{}
+	}{
+		"filtered fields": {
+			projectId: 1,
+			request:   map[string]interface{}{"user_id": "1234"},
+			expected:  map[string]interface{}{"user_id": "1234", "tier": "gold", "country": "SG"},
+		},
+		"numeric key": {
+			projectId: 1,
+			request:   map[string]interface{}{"user_id": float64(5678)},
+			expected:  map[string]interface{}{"user_id": float64(5678), "tier": "silver"},
+		},
+		"request values take precedence": {
+			projectId: 1,
+			request:   map[string]interface{}{"user_id": "1234", "country": "ID"},
+			expected:  map[string]interface{}{"user_id": "1234", "tier": "gold", "country": "ID"},
+		},
+		"earlier lookups take precedence": {
+			projectId: 2,
+			request:   map[string]interface{}{"user_id": "1234"},
+			expected:  map[string]interface{}{"user_id": "1234", "tier": "gold", "country": "SG", "cohort": float64(3)},
+		},
+		"unknown key": {
+			projectId: 1,
+			request:   map[string]interface{}{"user_id": "0000"},
+			expected:  map[string]interface{}{"user_id": "0000"},
+		},
+		"missing key": {
+			projectId: 1,
+			request:   map[string]interface{}{"country": "SG"},
+			expected:  map[string]interface{}{"country": "SG"},
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			values, err := svc.Enrich(context.Background(), data.projectId, data.request)
+			require.NoError(t, err)
+			assert.Equal(t, data.expected, values)
+		})
+	}
+}
+
+func TestEnrichmentServiceEnrichFromHTTP(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/users/1234":
+			_, _ = w.Write([]byte(`{"tier": "gold"}`))
+		case "/users/slow":
+			time.Sleep(200 * time.Millisecond)
+			_, _ = w.Write([]byte(`{"tier": "gold"}`))
+		case "/users/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	svc := newTestEnrichmentService(t, config.EnrichmentConfig{
+		Lookups: []config.EnrichmentLookup{{
+			Kind:     config.HTTPEnrichmentLookup,
+			KeyField: "user_id",
+			URL:      server.URL + "/users/{key}",
+			Headers:  map[string]string{"Authorization": "secret"},
+		}},
+		DefaultSettings: testEnrichmentSettings,
+		ProjectSettings: map[string]config.EnrichmentSettings{"2": {TimeoutMS: 50}},
+	}, &now)
+
+	// The looked up variables are cached
+	for i := 0; i < 2; i++ {
+		values, err := svc.Enrich(context.Background(), 1, map[string]interface{}{"user_id": "1234"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"user_id": "1234", "tier": "gold"}, values)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// Unknown keys are cached too
+	for i := 0; i < 2; i++ {
+		values, err := svc.Enrich(context.Background(), 1, map[string]interface{}{"user_id": "0000"})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"user_id": "0000"}, values)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+
+	// The cache expires, and is not shared between projects
+	now = now.Add(time.Minute)
+	_, err := svc.Enrich(context.Background(), 1, map[string]interface{}{"user_id": "1234"})
+	require.NoError(t, err)
+	_, err = svc.Enrich(context.Background(), 2, map[string]interface{}{"user_id": "1234"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+
+	// Failed lookups return the request values as is
+	values, err := svc.Enrich(context.Background(), 1, map[string]interface{}{"user_id": "error"})
+	assert.EqualError(t, err, "failed to look up user_id: unexpected status code 500")
+	assert.Equal(t, map[string]interface{}{"user_id": "error"}, values)
+
+	values, err = svc.Enrich(context.Background(), 1, map[string]interface{}{"user_id": true})
+	assert.EqualError(t, err, "failed to look up user_id: invalid type of key (bool); expected string or number")
+	assert.Equal(t, map[string]interface{}{"user_id": true}, values)
+
+	// The lookups exceeding the timeout of the project are skipped
+	values, err = svc.Enrich(context.Background(), 2, map[string]interface{}{"user_id": "slow"})
+	assert.ErrorContains(t, err, "context deadline exceeded")
+	assert.Equal(t, map[string]interface{}{"user_id": "slow"}, values)
+}
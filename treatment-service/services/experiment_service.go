@@ -19,11 +19,32 @@ type ExperimentService interface {
 		projectId models.ProjectId,
 		requestFilter map[string][]*_segmenters.SegmenterValue,
 	) ([]models.SegmentFilter, *_pubsub.Experiment, error)
+	// GetExperimentTreatment returns the experiment with the given id and its treatment with the given name,
+	// from the local storage, if the experiment is active and within its schedule
+	GetExperimentTreatment(
+		projectId models.ProjectId,
+		experimentId int64,
+		treatmentName string,
+	) (*_pubsub.Experiment, *_pubsub.ExperimentTreatment, error)
 	// DumpExperiments dumps the data in the local storage as a JSON file, in the specified location,
 	// and responds with the full file path
 	DumpExperiments(directory string) (string, error)
 }
 
+type ExperimentTreatmentNotFoundError struct {
+	message string
+}
+
+func ExperimentTreatmentNotFound(message string) *ExperimentTreatmentNotFoundError {
+	return &ExperimentTreatmentNotFoundError{
+		message: message,
+	}
+}
+
+func (e *ExperimentTreatmentNotFoundError) Error() string {
+	return e.message
+}
+
 type experimentService struct {
 	localStorage *models.LocalStorage
 }
@@ -89,6 +110,30 @@ func (es *experimentService) GetExperiment(
 	return lookupRequestFilters, nil, nil
 }
 
+func (es *experimentService) GetExperimentTreatment(
+	projectId models.ProjectId,
+	experimentId int64,
+	treatmentName string,
+) (*_pubsub.Experiment, *_pubsub.ExperimentTreatment, error) {
+	experiment := es.localStorage.FindExperimentWithId(projectId, experimentId)
+	if experiment == nil {
+		return nil, nil, ExperimentTreatmentNotFound(
+			fmt.Sprintf("unable to find experiment id %d in project id %d", experimentId, projectId))
+	}
+	// Only the experiments that treatments can currently be assigned from are exposed
+	if !models.IsExperimentActive(experiment) {
+		return nil, nil, ExperimentTreatmentNotFound(
+			fmt.Sprintf("experiment id %d in project id %d is not running", experimentId, projectId))
+	}
+	for _, treatment := range experiment.Treatments {
+		if treatment.Name == treatmentName {
+			return experiment, treatment, nil
+		}
+	}
+	return experiment, nil, ExperimentTreatmentNotFound(
+		fmt.Sprintf("unable to find treatment %s in experiment id %d", treatmentName, experimentId))
+}
+
 func (es *experimentService) generateLookupRequest(requestFilter map[string][]*_segmenters.SegmenterValue) []models.SegmentFilter {
 	filters := []models.SegmentFilter{}
 
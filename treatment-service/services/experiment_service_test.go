@@ -291,6 +291,113 @@ func (s *ExperimentServiceTestSuite) TestGetExperiment() {
 	}
 }
 
+func TestFilterByLookupOrderH3IDs(t *testing.T) {
+	h3idValue := func(value string) *_segmenters.SegmenterValue {
+		return &_segmenters.SegmenterValue{Value: &_segmenters.SegmenterValue_String_{String_: value}}
+	}
+	// The request's cells from the finest to the coarsest resolution, as computed by the h3_ids segmenter
+	requestFilter := map[string][]*_segmenters.SegmenterValue{
+		"h3_ids": {h3idValue("8928308280fffff"), h3idValue("872830828ffffff"), h3idValue("85283083fffffff")},
+	}
+	coarseMatch := &models.ExperimentMatch{
+		Experiment: &_pubsub.Experiment{Id: 1},
+		SegmenterMatches: map[string]models.Match{
+			"h3_ids": {Strength: models.MatchStrengthExact, Value: h3idValue("85283083fffffff")},
+		},
+	}
+	fineMatch := &models.ExperimentMatch{
+		Experiment: &_pubsub.Experiment{Id: 2},
+		SegmenterMatches: map[string]models.Match{
+			"h3_ids": {Strength: models.MatchStrengthExact, Value: h3idValue("872830828ffffff")},
+		},
+	}
+
+	es := &experimentService{}
+	filtered := es.filterByLookupOrder(
+		[]*models.ExperimentMatch{coarseMatch, fineMatch},
+		requestFilter,
+		[]string{"h3_ids"},
+		map[string]schema.SegmenterType{"h3_ids": schema.SegmenterTypeString},
+	)
+	assert.Equal(t, []*models.ExperimentMatch{fineMatch}, filtered)
+}
+
+func (s *ExperimentServiceTestSuite) TestGetExperimentTreatment() {
+	tests := map[string]struct {
+		projectId     models.ProjectId
+		experimentId  int64
+		treatmentName string
+		expTreatment  *_pubsub.ExperimentTreatment
+		expError      string
+	}{
+		"success": {
+			projectId:     2,
+			experimentId:  2,
+			treatmentName: "treatment",
+			expTreatment:  s.LocalStorage.Experiments[2][1].Experiment.Treatments[1],
+		},
+		"unknown project": {
+			projectId:     10,
+			experimentId:  1,
+			treatmentName: "control",
+			expError:      "unable to find experiment id 1 in project id 10",
+		},
+		"unknown experiment": {
+			projectId:     1,
+			experimentId:  2,
+			treatmentName: "control",
+			expError:      "unable to find experiment id 2 in project id 1",
+		},
+		"unknown treatment": {
+			projectId:     1,
+			experimentId:  1,
+			treatmentName: "treatment-B",
+			expError:      "unable to find treatment treatment-B in experiment id 1",
+		},
+	}
+
+	for name, tt := range tests {
+		s.Suite.T().Run(name, func(t *testing.T) {
+			experiment, treatment, err := s.ExperimentService.GetExperimentTreatment(
+				tt.projectId, tt.experimentId, tt.treatmentName)
+
+			if tt.expError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.experimentId, experiment.Id)
+				assert.Equal(t, tt.expTreatment, treatment)
+			} else {
+				assert.EqualError(t, err, tt.expError)
+				assert.IsType(t, &ExperimentTreatmentNotFoundError{}, err)
+				assert.Nil(t, treatment)
+			}
+		})
+	}
+}
+
+func TestGetExperimentTreatmentNotRunning(t *testing.T) {
+	inactiveExperiment := makeExperimentIndex(1, 1, nil, _pubsub.Experiment_Default)
+	inactiveExperiment.Experiment.Status = _pubsub.Experiment_Inactive
+	endedExperiment := makeExperimentIndex(1, 2, nil, _pubsub.Experiment_Default)
+	endedExperiment.Experiment.EndTime = timestamppb.New(time.Now().Add(-time.Minute))
+	scheduledExperiment := makeExperimentIndex(1, 3, nil, _pubsub.Experiment_Default)
+	scheduledExperiment.Experiment.StartTime = timestamppb.New(time.Now().Add(time.Hour))
+
+	svc, err := NewExperimentService(&models.LocalStorage{
+		Experiments: map[models.ProjectId][]*models.ExperimentIndex{
+			1: {inactiveExperiment, endedExperiment, scheduledExperiment},
+		},
+	})
+	assert.NoError(t, err)
+
+	for _, experimentId := range []int64{1, 2, 3} {
+		experiment, treatment, err := svc.GetExperimentTreatment(1, experimentId, "control")
+		assert.EqualError(t, err, fmt.Sprintf("experiment id %d in project id 1 is not running", experimentId))
+		assert.IsType(t, &ExperimentTreatmentNotFoundError{}, err)
+		assert.Nil(t, experiment)
+		assert.Nil(t, treatment)
+	}
+}
+
 func (s *ExperimentServiceTestSuite) TestDumpExperiments() {
 	filename, err := s.ExperimentService.DumpExperiments("/tmp")
 	s.Suite.T().Log(filename)
@@ -342,7 +449,11 @@ func makeExperimentIndex(
 		Status:    _pubsub.Experiment_Active,
 		StartTime: &timestamppb.Timestamp{Seconds: hourStart.Unix()},
 		EndTime:   &timestamppb.Timestamp{Seconds: hourEnd.Unix()},
-	})
+		Treatments: []*_pubsub.ExperimentTreatment{
+			{Name: "control", Traffic: 50},
+			{Name: "treatment", Traffic: 50},
+		},
+	}, nil)
 }
 
 func makeSegment(
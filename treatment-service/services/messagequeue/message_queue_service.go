@@ -17,7 +17,6 @@ func NewMessageQueueService(
 	ctx context.Context,
 	storage *models.LocalStorage,
 	mqConfig common_mq_config.MessageQueueConfig,
-	projectIds []uint32,
 	googleApplicationCredentialsEnvVar string,
 ) (MessageQueueService, error) {
 	var mq MessageQueueService
@@ -29,7 +28,6 @@ func NewMessageQueueService(
 		pubsubConfig := PubsubSubscriberConfig{
 			Project:         mqConfig.PubSubConfig.Project,
 			UpdateTopicName: mqConfig.PubSubConfig.TopicName,
-			ProjectIds:      projectIds,
 		}
 		mq, err = NewPubsubMQService(ctx, storage, pubsubConfig, googleApplicationCredentialsEnvVar)
 	default:
@@ -9,6 +9,9 @@ import (
 
 	"cloud.google.com/go/pubsub"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/option"
 	"google.golang.org/protobuf/proto"
 
@@ -16,16 +19,16 @@ import (
 	"github.com/caraml-dev/xp/treatment-service/models"
 )
 
+var tracer = otel.Tracer("github.com/caraml-dev/xp/treatment-service/services/messagequeue")
+
 type PubsubSubscriber struct {
 	localStorage *models.LocalStorage
 	subscription *pubsub.Subscription
-	projectIds   []models.ProjectId
 }
 
 type PubsubSubscriberConfig struct {
 	Project         string
 	UpdateTopicName string
-	ProjectIds      []models.ProjectId
 }
 
 func newSubscriptionId(topic string) string {
@@ -67,53 +70,87 @@ func NewPubsubMQService(
 	return &PubsubSubscriber{
 		localStorage: storage,
 		subscription: subscription,
-		projectIds:   config.ProjectIds,
 	}, nil
 }
 
 func (u *PubsubSubscriber) SubscribeToManagementService(ctx context.Context) error {
-	return u.subscription.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+	u.localStorage.SyncStatus.SetSubscriberRunning()
+	err := u.subscription.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
 		defer msg.Ack()
+		// Continue the trace of the Management Service that published the message
+		ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(msg.Attributes))
+		_, span := tracer.Start(ctx, "PubsubSubscriber.ProcessMessage", trace.WithSpanKind(trace.SpanKindConsumer))
+		defer span.End()
+
 		update := _pubsub.MessagePublishState{}
 		err := proto.Unmarshal(msg.Data, &update)
 		if err != nil {
 			log.Println("Warning: unable to unmarshal message for new experiment:", err)
-			msg.Ack()
+			return
 		}
 
+		// The subscribed projects can change at runtime, so they are checked on each message
+		var projectId models.ProjectId
 		updateType := update.Update
 		switch updateType.(type) {
 		case *_pubsub.MessagePublishState_ExperimentCreated:
 			experiment := update.GetExperimentCreated().Experiment
-			if models.ContainsProjectId(u.projectIds, models.ProjectId(experiment.ProjectId)) {
+			projectId = models.ProjectId(experiment.ProjectId)
+			if u.localStorage.IsSubscribed(projectId) {
 				u.localStorage.InsertExperiment(experiment)
 			}
 		case *_pubsub.MessagePublishState_ExperimentUpdated:
 			experiment := update.GetExperimentUpdated().Experiment
-			if models.ContainsProjectId(u.projectIds, models.ProjectId(experiment.ProjectId)) {
+			projectId = models.ProjectId(experiment.ProjectId)
+			if u.localStorage.IsSubscribed(projectId) {
 				u.localStorage.UpdateExperiment(experiment)
 			}
 		case *_pubsub.MessagePublishState_ProjectSettingsCreated:
-			if err := u.localStorage.InsertProjectSettings(update.GetProjectSettingsCreated().ProjectSettings); err != nil {
-				log.Println("Warning: unable to insert segmenters for new project settings:", err)
-				return
+			projectId = models.ProjectId(update.GetProjectSettingsCreated().ProjectSettings.ProjectId)
+			if u.localStorage.IsSubscribed(projectId) {
+				if err := u.localStorage.InsertProjectSettings(update.GetProjectSettingsCreated().ProjectSettings); err != nil {
+					log.Println("Warning: unable to insert segmenters for new project settings:", err)
+					u.localStorage.SyncStatus.RecordSyncError(projectId)
+					return
+				}
 			}
 		case *_pubsub.MessagePublishState_ProjectSettingsUpdated:
-			u.localStorage.UpdateProjectSettings(update.GetProjectSettingsUpdated().ProjectSettings)
+			projectId = models.ProjectId(update.GetProjectSettingsUpdated().ProjectSettings.ProjectId)
+			if u.localStorage.IsSubscribed(projectId) {
+				u.localStorage.UpdateProjectSettings(update.GetProjectSettingsUpdated().ProjectSettings)
+			}
 		case *_pubsub.MessagePublishState_ProjectSegmenterCreated:
-			u.localStorage.UpdateProjectSegmenters(
-				update.GetProjectSegmenterCreated().ProjectSegmenter,
-				update.GetProjectSegmenterCreated().ProjectId)
+			projectId = models.ProjectId(update.GetProjectSegmenterCreated().ProjectId)
+			if u.localStorage.IsSubscribed(projectId) {
+				u.localStorage.UpdateProjectSegmenters(
+					update.GetProjectSegmenterCreated().ProjectSegmenter,
+					update.GetProjectSegmenterCreated().ProjectId)
+			}
 		case *_pubsub.MessagePublishState_ProjectSegmenterUpdated:
-			u.localStorage.UpdateProjectSegmenters(
-				update.GetProjectSegmenterUpdated().ProjectSegmenter,
-				update.GetProjectSegmenterUpdated().ProjectId)
+			projectId = models.ProjectId(update.GetProjectSegmenterUpdated().ProjectId)
+			if u.localStorage.IsSubscribed(projectId) {
+				u.localStorage.UpdateProjectSegmenters(
+					update.GetProjectSegmenterUpdated().ProjectSegmenter,
+					update.GetProjectSegmenterUpdated().ProjectId)
+			}
 		case *_pubsub.MessagePublishState_ProjectSegmenterDeleted:
-			u.localStorage.DeleteProjectSegmenters(
-				update.GetProjectSegmenterDeleted().SegmenterName,
-				update.GetProjectSegmenterDeleted().ProjectId)
+			projectId = models.ProjectId(update.GetProjectSegmenterDeleted().ProjectId)
+			if u.localStorage.IsSubscribed(projectId) {
+				u.localStorage.DeleteProjectSegmenters(
+					update.GetProjectSegmenterDeleted().SegmenterName,
+					update.GetProjectSegmenterDeleted().ProjectId)
+			}
+		}
+
+		// Messages of the other projects still show that the subscription is receiving updates
+		if projectId != 0 && u.localStorage.IsSubscribed(projectId) {
+			u.localStorage.SyncStatus.RecordSync(projectId)
+		} else {
+			u.localStorage.SyncStatus.RecordSync()
 		}
 	})
+	u.localStorage.SyncStatus.SetSubscriberStopped(err)
+	return err
 }
 
 func (u *PubsubSubscriber) DeleteSubscriptions(ctx context.Context) error {
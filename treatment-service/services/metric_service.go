@@ -5,6 +5,7 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/caraml-dev/mlp/api/pkg/instrumentation/metrics"
@@ -33,21 +34,30 @@ type MetricService interface {
 	GetLabels(projectId models.ProjectId, treatment schema.SelectedTreatment, statusCode int,
 		requestFilter map[string][]*_segmenters.SegmenterValue, withSegmenters bool) map[string]string
 	SetMetricsCollector(collector metrics.Collector)
+	// SetMetricLabels replaces the segmenters used as labels of the request count metrics, which resets
+	// the counts of these metrics
+	SetMetricLabels(labels []string)
 }
 
 type metricService struct {
+	sync.RWMutex
+
 	Kind         config.MetricSinkKind
 	LocalStorage *models.LocalStorage
 	MetricLabels []string
+
+	// counterMap is the Prometheus counters, kept to change the labels of the request counts
+	counterMap map[metrics.MetricName]metrics.PrometheusCounterVec
 }
 
 func NewMetricService(cfg config.Monitoring, localStorage *models.LocalStorage) (MetricService, error) {
+	var counterMap map[metrics.MetricName]metrics.PrometheusCounterVec
 	switch cfg.Kind {
 	case config.NoopMetricSink, config.RPCMetricSink:
 	case config.PrometheusMetricSink:
 		// Init metrics collector
 		histogramMap := instrumentation.GetHistogramMap()
-		counterMap := instrumentation.GetCounterMap(cfg.MetricLabels)
+		counterMap = instrumentation.GetCounterMap(cfg.MetricLabels)
 		err := metrics.InitPrometheusMetricsCollector(instrumentation.GaugeMap, histogramMap, counterMap)
 		if err != nil {
 			return nil, errors.New("failed to initialize Prometheus-based MetricService")
@@ -58,6 +68,7 @@ func NewMetricService(cfg config.Monitoring, localStorage *models.LocalStorage)
 		Kind:         cfg.Kind,
 		LocalStorage: localStorage,
 		MetricLabels: cfg.MetricLabels,
+		counterMap:   counterMap,
 	}
 
 	return svc, nil
@@ -67,6 +78,17 @@ func (ms *metricService) SetMetricsCollector(collector metrics.Collector) {
 	metrics.SetGlobMetricsCollector(collector)
 }
 
+func (ms *metricService) SetMetricLabels(labels []string) {
+	ms.Lock()
+	defer ms.Unlock()
+
+	if ms.Kind == config.PrometheusMetricSink {
+		instrumentation.SetCounterMapLabels(ms.counterMap, labels)
+	}
+
+	ms.MetricLabels = labels
+}
+
 func (ms *metricService) LogLatencyHistogram(begin time.Time, labels map[string]string, loggingMetric metrics.MetricName) {
 	var err error
 	switch ms.Kind {
@@ -102,6 +124,14 @@ func (ms *metricService) LogRequestCount(labels map[string]string, loggingMetric
 			err = metrics.Glob().Inc(
 				instrumentation.NoMatchingExperimentRequestCount, labels,
 			)
+		case instrumentation.RateLimitedRequestCount:
+			err = metrics.Glob().Inc(
+				instrumentation.RateLimitedRequestCount, labels,
+			)
+		case instrumentation.EnrichmentErrorCount:
+			err = metrics.Glob().Inc(
+				instrumentation.EnrichmentErrorCount, labels,
+			)
 		}
 		if err != nil {
 			log.Printf("error while logging metrics (request_count): %s", err)
@@ -117,6 +147,8 @@ func (ms *metricService) GetProjectNameLabel(projectId models.ProjectId) map[str
 }
 
 func (ms *metricService) GetMetricLabels() []string {
+	ms.RLock()
+	defer ms.RUnlock()
 	return ms.MetricLabels
 }
 
@@ -137,7 +169,7 @@ func (ms *metricService) GetLabels(
 
 	if withSegmenters {
 		// Set default value for required labels
-		for _, label := range ms.MetricLabels {
+		for _, label := range ms.GetMetricLabels() {
 			labels[label] = ""
 			if filterValues, ok := requestFilter[label]; ok {
 				// Do the convert and set labels[label]
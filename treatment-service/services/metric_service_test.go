@@ -24,7 +24,6 @@ type MetricServiceTestSuite struct {
 
 func newProjectSettings(
 	enableS2idClustering bool,
-	passkey string,
 	projectId int64,
 	randomizationKey string,
 	segmenterNames []string,
@@ -41,7 +40,6 @@ func newProjectSettings(
 	return schema.ProjectSettings{
 		CreatedAt:            createdAt,
 		EnableS2idClustering: enableS2idClustering,
-		Passkey:              passkey,
 		ProjectId:            projectId,
 		RandomizationKey:     randomizationKey,
 		Segmenters:           segmenters,
@@ -54,7 +52,7 @@ func (s *MetricServiceTestSuite) SetupSuite() {
 	s.Suite.T().Log("Setting up MetricServiceTestSuite")
 
 	addProjectSettings := func(projectSettings schema.ProjectSettings) {
-		e := models.OpenAPIProjectSettingsSpecToProtobuf(projectSettings)
+		e := models.OpenAPIProjectSettingsSpecToProtobuf(projectSettings, nil)
 		s.storage.ProjectSettings = append(s.storage.ProjectSettings, e)
 	}
 	segmenterNames := []string{"days_of_week", "hours_of_day"}
@@ -63,7 +61,7 @@ func (s *MetricServiceTestSuite) SetupSuite() {
 		"hours_of_day": {"tz"},
 	}
 	addProjectSettings(newProjectSettings(
-		false, "passkey", 0, "randomkey", segmenterNames, segmenters, "user1"))
+		false, 0, "randomkey", segmenterNames, segmenters, "user1"))
 
 	var err error
 	s.cfg = config.Config{
@@ -176,3 +174,31 @@ func (s *MetricServiceTestSuite) TestLogRequestCount() {
 	expectedErrorStdOut := "error while logging metrics (request_count)"
 	s.Suite.Require().Contains(stdout, expectedErrorStdOut)
 }
+
+func (s *MetricServiceTestSuite) TestSetMetricLabels() {
+	defer s.MetricService.SetMetricLabels(s.cfg.MonitoringConfig.MetricLabels)
+
+	s.MetricService.SetMetricLabels([]string{"test_label3"})
+	s.Suite.Require().Equal([]string{"test_label3"}, s.MetricService.GetMetricLabels())
+
+	// The request counts are logged with the new labels
+	label := map[string]string{
+		"project_name":    "user1",
+		"experiment_name": "test_exp",
+		"treatment_name":  "test_treatment",
+		"response_code":   strconv.Itoa(200),
+		"test_label3":     "",
+	}
+	stdout := testutils.CaptureStderrLogs(func() {
+		s.MetricService.LogRequestCount(label, instrumentation.FetchTreatmentRequestCount)
+	})
+	s.Suite.Require().Equal("", stdout)
+
+	// The other metrics are unaffected
+	stdout = testutils.CaptureStderrLogs(func() {
+		s.MetricService.LogLatencyHistogram(
+			time.Now(), map[string]string{"project_name": "test"}, instrumentation.ExperimentLookupDurationMs,
+		)
+	})
+	s.Suite.Require().Equal("", stdout)
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/caraml-dev/xp/treatment-service/config"
+	"github.com/caraml-dev/xp/treatment-service/instrumentation"
+	"github.com/caraml-dev/xp/treatment-service/models"
+)
+
+type RateLimitService interface {
+	// Allow determines if a request of the given project is within the rate limit. If not, the duration
+	// after which the request can be retried is returned. Requests exceeding the limit are always allowed
+	// in shadow mode.
+	Allow(projectId models.ProjectId) (bool, time.Duration)
+}
+
+type rateLimitService struct {
+	sync.Mutex
+
+	shadowMode    bool
+	defaultLimit  config.RateLimit
+	projectLimits map[models.ProjectId]config.RateLimit
+	// limiters are created on the first request of each project
+	limiters map[models.ProjectId]*rate.Limiter
+
+	metricService MetricService
+	now           func() time.Time
+}
+
+func NewRateLimitService(cfg config.RateLimitConfig, metricService MetricService) (RateLimitService, error) {
+	if err := validateRateLimit(cfg.DefaultLimit); err != nil {
+		return nil, fmt.Errorf("invalid default rate limit: %s", err)
+	}
+
+	projectLimits := make(map[models.ProjectId]config.RateLimit, len(cfg.ProjectLimits))
+	for key, limit := range cfg.ProjectLimits {
+		projectId, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project id %s in rate limit config: %s", key, err)
+		}
+		if err := validateRateLimit(limit); err != nil {
+			return nil, fmt.Errorf("invalid rate limit for project %s: %s", key, err)
+		}
+		projectLimits[models.NewProjectId(int64(projectId))] = limit
+	}
+
+	svc := &rateLimitService{
+		shadowMode:    cfg.ShadowMode,
+		defaultLimit:  cfg.DefaultLimit,
+		projectLimits: projectLimits,
+		limiters:      map[models.ProjectId]*rate.Limiter{},
+		metricService: metricService,
+		now:           time.Now,
+	}
+
+	return svc, nil
+}
+
+func (rs *rateLimitService) Allow(projectId models.ProjectId) (bool, time.Duration) {
+	now := rs.now()
+	reservation := rs.getLimiter(projectId).ReserveN(now, 1)
+	retryAfter := reservation.DelayFrom(now)
+	if retryAfter == 0 {
+		return true, 0
+	}
+	// Return the token, so that the rejected requests do not delay the subsequent ones
+	reservation.CancelAt(now)
+
+	labels := rs.metricService.GetProjectNameLabel(projectId)
+	labels["shadow_mode"] = strconv.FormatBool(rs.shadowMode)
+	rs.metricService.LogRequestCount(labels, instrumentation.RateLimitedRequestCount)
+
+	if rs.shadowMode {
+		return true, 0
+	}
+	return false, retryAfter
+}
+
+func (rs *rateLimitService) getLimiter(projectId models.ProjectId) *rate.Limiter {
+	rs.Lock()
+	defer rs.Unlock()
+
+	limiter, ok := rs.limiters[projectId]
+	if !ok {
+		limit, ok := rs.projectLimits[projectId]
+		if !ok {
+			limit = rs.defaultLimit
+		}
+		limiter = newLimiter(limit)
+		rs.limiters[projectId] = limiter
+	}
+	return limiter
+}
+
+func newLimiter(limit config.RateLimit) *rate.Limiter {
+	if limit.RequestsPerSecond == 0 {
+		return rate.NewLimiter(rate.Inf, 0)
+	}
+	burst := limit.Burst
+	if burst == 0 {
+		burst = int(math.Ceil(limit.RequestsPerSecond))
+	}
+	return rate.NewLimiter(rate.Limit(limit.RequestsPerSecond), burst)
+}
+
+func validateRateLimit(limit config.RateLimit) error {
+	if limit.RequestsPerSecond < 0 {
+		return fmt.Errorf("requests per second %v is negative", limit.RequestsPerSecond)
+	}
+	if limit.Burst < 0 {
+		return fmt.Errorf("burst %d is negative", limit.Burst)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/caraml-dev/xp/common/api/schema"
+	"github.com/caraml-dev/xp/treatment-service/config"
+	"github.com/caraml-dev/xp/treatment-service/models"
+)
+
+func newTestRateLimitService(t *testing.T, cfg config.RateLimitConfig, now *time.Time) RateLimitService {
+	storage := models.LocalStorage{}
+	for _, projectId := range []int64{1, 2} {
+		storage.ProjectSettings = append(storage.ProjectSettings, models.OpenAPIProjectSettingsSpecToProtobuf(
+			schema.ProjectSettings{ProjectId: projectId, Username: "user"},
+			nil,
+		))
+	}
+	metricService, err := NewMetricService(config.Monitoring{Kind: config.NoopMetricSink}, &storage)
+	require.NoError(t, err)
+
+	svc, err := NewRateLimitService(cfg, metricService)
+	require.NoError(t, err)
+	svc.(*rateLimitService).now = func() time.Time { return *now }
+	return svc
+}
+
+func TestRateLimitServiceAllow(t *testing.T) {
+	now := time.Now()
+	svc := newTestRateLimitService(t, config.RateLimitConfig{
+		Enabled:       true,
+		DefaultLimit:  config.RateLimit{RequestsPerSecond: 2},
+		ProjectLimits: map[string]config.RateLimit{"2": {RequestsPerSecond: 0.5, Burst: 3}},
+	}, &now)
+
+	// The burst of the default limit is the rate of requests
+	for i := 0; i < 2; i++ {
+		allowed, _ := svc.Allow(1)
+		assert.True(t, allowed)
+	}
+	allowed, retryAfter := svc.Allow(1)
+	assert.False(t, allowed)
+	assert.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// The rejected request does not consume a token
+	now = now.Add(500 * time.Millisecond)
+	allowed, _ = svc.Allow(1)
+	assert.True(t, allowed)
+
+	// The limit of project 2 is independent of project 1
+	for i := 0; i < 3; i++ {
+		allowed, _ := svc.Allow(2)
+		assert.True(t, allowed)
+	}
+	allowed, retryAfter = svc.Allow(2)
+	assert.False(t, allowed)
+	assert.Equal(t, 2*time.Second, retryAfter)
+}
+
+func TestRateLimitServiceNoLimit(t *testing.T) {
+	now := time.Now()
+	svc := newTestRateLimitService(t, config.RateLimitConfig{
+		Enabled:       true,
+		ProjectLimits: map[string]config.RateLimit{"2": {RequestsPerSecond: 1}},
+	}, &now)
+
+	for i := 0; i < 100; i++ {
+		allowed, _ := svc.Allow(1)
+		assert.True(t, allowed)
+	}
+}
+
+func TestRateLimitServiceShadowMode(t *testing.T) {
+	now := time.Now()
+	svc := newTestRateLimitService(t, config.RateLimitConfig{
+		Enabled:      true,
+		ShadowMode:   true,
+		DefaultLimit: config.RateLimit{RequestsPerSecond: 1},
+	}, &now)
+
+	for i := 0; i < 10; i++ {
+		allowed, retryAfter := svc.Allow(1)
+		assert.True(t, allowed)
+		assert.Zero(t, retryAfter)
+	}
+}
+
+func TestNewRateLimitServiceErrors(t *testing.T) {
+	tests := map[string]struct {
+		cfg         config.RateLimitConfig
+		expectedErr string
+	}{
+		"negative default rate": {
+			cfg:         config.RateLimitConfig{DefaultLimit: config.RateLimit{RequestsPerSecond: -1}},
+			expectedErr: "invalid default rate limit: requests per second -1 is negative",
+		},
+		"invalid project id": {
+			cfg: config.RateLimitConfig{
+				ProjectLimits: map[string]config.RateLimit{"abc": {RequestsPerSecond: 1}},
+			},
+			expectedErr: "invalid project id abc in rate limit config: " +
+				"strconv.ParseUint: parsing \"abc\": invalid syntax",
+		},
+		"negative project burst": {
+			cfg: config.RateLimitConfig{
+				ProjectLimits: map[string]config.RateLimit{"1": {RequestsPerSecond: 1, Burst: -1}},
+			},
+			expectedErr: "invalid rate limit for project 1: burst -1 is negative",
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRateLimitService(data.cfg, nil)
+			assert.EqualError(t, err, data.expectedErr)
+		})
+	}
+}
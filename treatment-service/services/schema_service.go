@@ -1,11 +1,14 @@
 package services
 
 import (
+	"crypto/subtle"
 	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
 	"github.com/caraml-dev/xp/treatment-service/models"
 )
 
@@ -64,7 +67,19 @@ func (ss *schemaService) ValidatePasskey(projectId models.ProjectId, passkey str
 	if settings == nil {
 		return ProjectSettingsNotFound(fmt.Sprintf("unable to find project id %d", projectId))
 	}
-	if passkey != settings.GetPasskey() {
+
+	// Compare the hash against every active passkey, without returning early, so that the time taken
+	// does not depend on the passkey provided
+	hash := []byte(_utils.HashPasskey(passkey))
+	now := time.Now()
+	matched := 0
+	for _, key := range settings.GetPasskeys() {
+		if key.GetExpiresAt() != nil && !now.Before(key.GetExpiresAt().AsTime()) {
+			continue
+		}
+		matched |= subtle.ConstantTimeCompare(hash, []byte(key.GetHash()))
+	}
+	if matched != 1 {
 		return errors.New("incorrect passkey was provided")
 	}
 
@@ -5,9 +5,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	_pubsub "github.com/caraml-dev/xp/common/pubsub"
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
+	_utils "github.com/caraml-dev/xp/common/utils"
 	"github.com/caraml-dev/xp/treatment-service/models"
 	"github.com/caraml-dev/xp/treatment-service/util"
 )
@@ -44,6 +46,19 @@ func newMockProjectSettingsStore() models.ProjectSettingsStorage {
 					},
 				},
 				RandomizationKey: "order-id",
+				Passkeys: []*_pubsub.ProjectPasskey{
+					{Name: "default", Hash: _utils.HashPasskey("passkey-1")},
+					{
+						Name:      "rotated",
+						Hash:      _utils.HashPasskey("passkey-2"),
+						ExpiresAt: timestamppb.New(time.Now().Add(time.Hour)),
+					},
+					{
+						Name:      "expired",
+						Hash:      _utils.HashPasskey("passkey-3"),
+						ExpiresAt: timestamppb.New(time.Now().Add(-time.Hour)),
+					},
+				},
 			},
 			models.NewProjectId(2): {
 				ProjectId:            2,
@@ -106,6 +121,59 @@ func TestSchemaServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(SchemaServiceTestSuite))
 }
 
+func (suite *SchemaServiceTestSuite) TestValidatePasskey() {
+	tests := map[string]struct {
+		projectId models.ProjectId
+		passkey   string
+		errString string
+	}{
+		"success | default passkey": {
+			projectId: 1,
+			passkey:   "passkey-1",
+		},
+		"success | passkey with expiry": {
+			projectId: 1,
+			passkey:   "passkey-2",
+		},
+		"failure | expired passkey": {
+			projectId: 1,
+			passkey:   "passkey-3",
+			errString: "incorrect passkey was provided",
+		},
+		"failure | incorrect passkey": {
+			projectId: 1,
+			passkey:   "passkey-4",
+			errString: "incorrect passkey was provided",
+		},
+		"failure | passkey hash": {
+			projectId: 1,
+			passkey:   _utils.HashPasskey("passkey-1"),
+			errString: "incorrect passkey was provided",
+		},
+		"failure | project without passkeys": {
+			projectId: 2,
+			passkey:   "",
+			errString: "incorrect passkey was provided",
+		},
+		"failure | project not found": {
+			projectId: 10,
+			passkey:   "passkey-1",
+			errString: "unable to find project id 10",
+		},
+	}
+
+	for name, data := range tests {
+		suite.Run(name, func() {
+			err := suite.schemaService.ValidatePasskey(data.projectId, data.passkey)
+			if data.errString == "" {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().EqualError(err, data.errString)
+			}
+		})
+	}
+}
+
 func (suite *SchemaServiceTestSuite) TestGetRandomizationKeyValue() {
 	filterParams := map[string]interface{}{
 		"order-id": "1234",
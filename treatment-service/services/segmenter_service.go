@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 
+	"github.com/caraml-dev/xp/common/expression"
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
 	"github.com/caraml-dev/xp/treatment-service/models"
 	"github.com/caraml-dev/xp/treatment-service/segmenters"
@@ -17,18 +19,65 @@ type SegmenterService interface {
 		segmenter string,
 		requestValues map[string]interface{},
 		experimentVariables []string) ([]*_segmenters.SegmenterValue, error)
+	// SetConfig replaces the runners of the global segmenters with the ones created from the config.
+	// The current runners are kept if the config is invalid.
+	SetConfig(cfg map[string]interface{}) error
 }
 
 type segmenterService struct {
+	sync.RWMutex
 	runners      map[string]segmenters.Runner
 	localStorage *models.LocalStorage
+
+	// expressionsLock guards expressions, the compiled expressions of the project segmenters, so that each
+	// expression is only compiled once. Each segmenter keeps only its latest expression.
+	expressionsLock sync.RWMutex
+	expressions     map[expressionKey]*compiledExpression
+}
+
+// expressionKey identifies the project segmenter of a compiled expression
+type expressionKey struct {
+	projectId models.ProjectId
+	segmenter string
+}
+
+// compiledExpression is the compiled form of the expression of a project segmenter, which is replaced when
+// the expression or the segmenter type changes
+type compiledExpression struct {
+	expression    string
+	segmenterType _segmenters.SegmenterValueType
+	compiled      *expression.Expression
 }
 
 func NewSegmenterService(
 	localStorage *models.LocalStorage,
 	cfg map[string]interface{},
 ) (SegmenterService, error) {
+	segmentersRunner, err := newSegmenterRunners(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &segmenterService{
+		runners:      segmentersRunner,
+		localStorage: localStorage,
+		expressions:  map[expressionKey]*compiledExpression{},
+	}, nil
+}
+
+func (svc *segmenterService) SetConfig(cfg map[string]interface{}) error {
+	segmentersRunner, err := newSegmenterRunners(cfg)
+	if err != nil {
+		return err
+	}
 
+	svc.Lock()
+	defer svc.Unlock()
+	svc.runners = segmentersRunner
+	return nil
+}
+
+func newSegmenterRunners(cfg map[string]interface{}) (map[string]segmenters.Runner, error) {
 	segmentersRunner := make(map[string]segmenters.Runner)
 
 	for name := range segmenters.Runners {
@@ -52,10 +101,7 @@ func NewSegmenterService(
 		segmentersRunner[name] = m
 	}
 
-	return &segmenterService{
-		runners:      segmentersRunner,
-		localStorage: localStorage,
-	}, nil
+	return segmentersRunner, nil
 }
 
 func (svc *segmenterService) GetTransformation(
@@ -64,14 +110,23 @@ func (svc *segmenterService) GetTransformation(
 	requestValues map[string]interface{},
 	experimentVariables []string,
 ) ([]*_segmenters.SegmenterValue, error) {
-	err := validateAllPresent(segmenter, requestValues, experimentVariables)
-	if err != nil {
-		// If not all variables are supplied, we will match for optional segmenters
-		// in the experiments. So we can return an empty list of segmenter values to match.
-		return []*_segmenters.SegmenterValue{}, nil
-	}
 	// Check if segmenter is a global segmenter, else use project segmenters
+	svc.RLock()
 	runner, ok := svc.runners[segmenter]
+	svc.RUnlock()
+	var requestFields []string
+	if !ok {
+		requestFields, _ = svc.localStorage.GetSegmenterRequestFields(projectId, segmenter)
+	}
+	// The runner resolves the alternative request fields of a project segmenter itself
+	if len(requestFields) == 0 {
+		err := validateAllPresent(segmenter, requestValues, experimentVariables)
+		if err != nil {
+			// If not all variables are supplied, we will match for optional segmenters
+			// in the experiments. So we can return an empty list of segmenter values to match.
+			return []*_segmenters.SegmenterValue{}, nil
+		}
+	}
 	if !ok {
 		projectSegmentersTypeMapping, err := svc.localStorage.GetSegmentersTypeMapping(projectId)
 		if err != nil {
@@ -82,9 +137,13 @@ func (svc *segmenterService) GetTransformation(
 			return nil, errors.New("Type mapping not found for Segmenter:" + segmenter)
 		}
 		projectSegmenterValueType := _segmenters.SegmenterValueType(_segmenters.SegmenterValueType_value[strings.ToUpper(string(segmenterType))])
+		if expressionString, ok := svc.localStorage.GetSegmenterExpression(projectId, segmenter); ok {
+			return svc.evaluateExpression(projectId, segmenter, expressionString, projectSegmenterValueType, requestValues)
+		}
 		runner = segmenters.NewBaseRunner(&segmenters.SegmenterConfig{
-			Name: segmenter,
-			Type: &projectSegmenterValueType,
+			Name:          segmenter,
+			Type:          &projectSegmenterValueType,
+			RequestFields: requestFields,
 		})
 	}
 	transformation, err := runner.Transform(segmenter, requestValues, experimentVariables)
@@ -95,6 +154,37 @@ func (svc *segmenterService) GetTransformation(
 	return transformation, nil
 }
 
+// evaluateExpression computes the value of a project segmenter from the request with its expression, which is
+// compiled on first use, and again whenever it changes
+func (svc *segmenterService) evaluateExpression(
+	projectId models.ProjectId,
+	segmenter string,
+	expressionString string,
+	segmenterType _segmenters.SegmenterValueType,
+	requestValues map[string]interface{},
+) ([]*_segmenters.SegmenterValue, error) {
+	key := expressionKey{projectId: projectId, segmenter: segmenter}
+	svc.expressionsLock.RLock()
+	cached, ok := svc.expressions[key]
+	svc.expressionsLock.RUnlock()
+	if !ok || cached.expression != expressionString || cached.segmenterType != segmenterType {
+		compiled, err := expression.Compile(expressionString, segmenterType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expression for %s segmenter: %s", segmenter, err)
+		}
+		cached = &compiledExpression{expression: expressionString, segmenterType: segmenterType, compiled: compiled}
+		svc.expressionsLock.Lock()
+		svc.expressions[key] = cached
+		svc.expressionsLock.Unlock()
+	}
+
+	value, err := cached.compiled.Evaluate(requestValues)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate the expression for %s segmenter: %s", segmenter, err)
+	}
+	return []*_segmenters.SegmenterValue{value}, nil
+}
+
 func validateAllPresent(segmenter string, providedVariables map[string]interface{}, requiredVariables []string) error {
 	for _, requiredVariable := range requiredVariables {
 		if _, ok := providedVariables[requiredVariable]; !ok {
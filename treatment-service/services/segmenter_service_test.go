@@ -6,6 +6,8 @@ import (
 	"github.com/caraml-dev/xp/common/api/schema"
 	_segmenters "github.com/caraml-dev/xp/common/segmenters"
 	"github.com/caraml-dev/xp/treatment-service/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -22,7 +24,15 @@ func (s *SegmenterServiceTestSuite) SetupSuite() {
 		},
 	}
 	localStorage := models.LocalStorage{
-		ProjectSegmenters: map[models.ProjectId]map[string]schema.SegmenterType{1: {}},
+		ProjectSegmenters: map[models.ProjectId]map[string]schema.SegmenterType{
+			1: {"is_adult": schema.SegmenterTypeBool, "tier": schema.SegmenterTypeString},
+		},
+		ProjectSegmenterExpressions: map[models.ProjectId]map[string]string{
+			1: {"is_adult": "request.age >= 18"},
+		},
+		ProjectSegmenterRequestFields: map[models.ProjectId]map[string][]string{
+			1: {"tier": {"user.tier", "tier_name"}},
+		},
 	}
 	var err error
 	s.SegmenterService, err = NewSegmenterService(&localStorage, segmenterConfig)
@@ -77,6 +87,50 @@ func (s *SegmenterServiceTestSuite) TestGetTransformation() {
 			expectedValue:       []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_Integer{Integer: int64(1)}}},
 			experimentVariables: []string{requiredVariableName},
 		},
+		"success | expression": {
+			projectId:     1,
+			segmenterName: "is_adult",
+			providedVariables: map[string]interface{}{
+				"age": float64(21),
+			},
+			expectedValue:       []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_Bool{Bool: true}}},
+			experimentVariables: []string{"age"},
+		},
+		"success | expression with missing experiment variables": {
+			projectId:           1,
+			segmenterName:       "is_adult",
+			providedVariables:   map[string]interface{}{},
+			expectedValue:       []*_segmenters.SegmenterValue{},
+			experimentVariables: []string{"age"},
+		},
+		"failure | expression evaluation": {
+			projectId:     1,
+			segmenterName: "is_adult",
+			providedVariables: map[string]interface{}{
+				"age": "21",
+			},
+			experimentVariables: []string{"age"},
+			errString:           "failed to evaluate the expression for is_adult segmenter: no such overload",
+		},
+		"success | request fields": {
+			projectId:     1,
+			segmenterName: "tier",
+			providedVariables: map[string]interface{}{
+				"user":      map[string]interface{}{"tier": "gold"},
+				"tier_name": "silver",
+			},
+			expectedValue:       []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_String_{String_: "gold"}}},
+			experimentVariables: []string{"tier_name"},
+		},
+		"success | request fields with missing experiment variables": {
+			projectId:     1,
+			segmenterName: "tier",
+			providedVariables: map[string]interface{}{
+				"user": map[string]interface{}{"tier": "gold"},
+			},
+			expectedValue:       []*_segmenters.SegmenterValue{{Value: &_segmenters.SegmenterValue_String_{String_: "gold"}}},
+			experimentVariables: []string{"tier_name"},
+		},
 	}
 
 	for name, data := range tests {
@@ -96,3 +150,58 @@ func (s *SegmenterServiceTestSuite) TestGetTransformation() {
 		})
 	}
 }
+
+func TestSegmenterServiceSetConfig(t *testing.T) {
+	s2IdConfig := func(minLevel int, maxLevel int) map[string]interface{} {
+		return map[string]interface{}{
+			"s2_ids": map[string]interface{}{"mins2celllevel": minLevel, "maxs2celllevel": maxLevel},
+		}
+	}
+	getS2Ids := func(svc SegmenterService) []*_segmenters.SegmenterValue {
+		values, err := svc.GetTransformation(
+			1, "s2_ids", map[string]interface{}{"latitude": 1.2485, "longitude": 103.8269}, []string{"latitude", "longitude"},
+		)
+		require.NoError(t, err)
+		return values
+	}
+
+	svc, err := NewSegmenterService(&models.LocalStorage{}, s2IdConfig(10, 14))
+	require.NoError(t, err)
+	assert.Len(t, getS2Ids(svc), 5)
+
+	// The runners are replaced
+	require.NoError(t, svc.SetConfig(s2IdConfig(12, 14)))
+	assert.Len(t, getS2Ids(svc), 3)
+
+	// The current runners are kept, when the config is invalid
+	assert.EqualError(t, svc.SetConfig(s2IdConfig(12, 31)),
+		"failed to create segmenter (s2_ids): S2 cell levels should be in the range 0 - 30")
+	assert.Len(t, getS2Ids(svc), 3)
+}
+
+func TestSegmenterServiceExpressionChanged(t *testing.T) {
+	localStorage := &models.LocalStorage{
+		ProjectSegmenters: map[models.ProjectId]map[string]schema.SegmenterType{
+			1: {"is_adult": schema.SegmenterTypeBool},
+		},
+		ProjectSegmenterExpressions: map[models.ProjectId]map[string]string{
+			1: {"is_adult": "request.age >= 18"},
+		},
+	}
+	svc, err := NewSegmenterService(localStorage, map[string]interface{}{
+		"s2_ids": map[string]interface{}{"mins2celllevel": 10, "maxs2celllevel": 14},
+	})
+	require.NoError(t, err)
+	isAdult := func() bool {
+		values, err := svc.GetTransformation(1, "is_adult", map[string]interface{}{"age": float64(20)}, []string{"age"})
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		return values[0].GetBool()
+	}
+	assert.True(t, isAdult())
+
+	// The compiled expression of the segmenter is replaced, when the expression changes
+	localStorage.ProjectSegmenterExpressions[1]["is_adult"] = "request.age >= 21"
+	assert.False(t, isAdult())
+	assert.Len(t, svc.(*segmenterService).expressions, 1)
+}
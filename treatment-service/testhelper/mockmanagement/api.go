@@ -26,6 +26,11 @@ type CreateExperimentSuccess struct {
 	Data externalRef0.Experiment `json:"data"`
 }
 
+// CreateProjectPasskeySuccess defines model for CreateProjectPasskeySuccess.
+type CreateProjectPasskeySuccess struct {
+	Data externalRef0.CreatedProjectPasskey `json:"data"`
+}
+
 // CreateProjectSettingsSuccess defines model for CreateProjectSettingsSuccess.
 type CreateProjectSettingsSuccess struct {
 	Data externalRef0.ProjectSettings `json:"data"`
@@ -81,6 +86,16 @@ type ListExperimentsSuccess struct {
 	Paging *externalRef0.Paging      `json:"paging,omitempty"`
 }
 
+// ListProjectPasskeyHashesSuccess defines model for ListProjectPasskeyHashesSuccess.
+type ListProjectPasskeyHashesSuccess struct {
+	Data []externalRef0.ProjectPasskeyHash `json:"data"`
+}
+
+// ListProjectPasskeysSuccess defines model for ListProjectPasskeysSuccess.
+type ListProjectPasskeysSuccess struct {
+	Data []externalRef0.ProjectPasskey `json:"data"`
+}
+
 // ListProjectsSuccess defines model for ListProjectsSuccess.
 type ListProjectsSuccess struct {
 	Data []externalRef0.Project `json:"data"`
@@ -94,6 +109,11 @@ type ListSegmentersSuccess struct {
 // NotFound defines model for NotFound.
 type NotFound externalRef0.Error
 
+// RevokeProjectPasskeySuccess defines model for RevokeProjectPasskeySuccess.
+type RevokeProjectPasskeySuccess struct {
+	Name *string `json:"name,omitempty"`
+}
+
 // UpdateExperimentSuccess defines model for UpdateExperimentSuccess.
 type UpdateExperimentSuccess struct {
 	Data externalRef0.Experiment `json:"data"`
@@ -111,17 +131,31 @@ type UpdateSegmenterSuccess struct {
 
 // CreateExperimentRequestBody defines model for CreateExperimentRequestBody.
 type CreateExperimentRequestBody struct {
-	Description *string                            `json:"description"`
-	EndTime     time.Time                          `json:"end_time"`
-	Interval    *int32                             `json:"interval"`
-	Name        string                             `json:"name"`
-	Segment     externalRef0.ExperimentSegment     `json:"segment"`
-	StartTime   time.Time                          `json:"start_time"`
-	Status      externalRef0.ExperimentStatus      `json:"status"`
-	Tier        *externalRef0.ExperimentTier       `json:"tier,omitempty"`
-	Treatments  []externalRef0.ExperimentTreatment `json:"treatments"`
-	Type        externalRef0.ExperimentType        `json:"type"`
-	UpdatedBy   *string                            `json:"updated_by,omitempty"`
+	Description *string                             `json:"description"`
+	EndTime     time.Time                           `json:"end_time"`
+	Guardrails  *[]externalRef0.ExperimentGuardrail `json:"guardrails,omitempty"`
+	Interval    *int32                              `json:"interval"`
+	Name        string                              `json:"name"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
+	Segment    externalRef0.ExperimentSegment     `json:"segment"`
+	StartTime  time.Time                          `json:"start_time"`
+	Status     externalRef0.ExperimentStatus      `json:"status"`
+	Tier       *externalRef0.ExperimentTier       `json:"tier,omitempty"`
+	Treatments []externalRef0.ExperimentTreatment `json:"treatments"`
+	Type       externalRef0.ExperimentType        `json:"type"`
+	UpdatedBy  *string                            `json:"updated_by,omitempty"`
+}
+
+// CreateProjectPasskeyRequestBody defines model for CreateProjectPasskeyRequestBody.
+type CreateProjectPasskeyRequestBody struct {
+
+	// Time after which the passkey is no longer accepted. The passkey does not expire if unset.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	Name      string     `json:"name"`
 }
 
 // CreateProjectSettingsRequestBody defines model for CreateProjectSettingsRequestBody.
@@ -137,27 +171,38 @@ type CreateProjectSettingsRequestBody struct {
 
 // CreateSegmenterRequestBody defines model for CreateSegmenterRequestBody.
 type CreateSegmenterRequestBody struct {
-	Constraints *[]externalRef0.Constraint     `json:"constraints,omitempty"`
-	Description *string                        `json:"description,omitempty"`
-	MultiValued bool                           `json:"multi_valued"`
-	Name        string                         `json:"name"`
-	Options     *externalRef0.SegmenterOptions `json:"options,omitempty"`
-	Required    bool                           `json:"required"`
-	Type        externalRef0.SegmenterType     `json:"type"`
+	Constraints *[]externalRef0.Constraint `json:"constraints,omitempty"`
+	Description *string                    `json:"description,omitempty"`
+	Expression  *string                    `json:"expression,omitempty"`
+
+	// How the values of a string segmenter are matched against the value in the treatment request. The values are exact strings by default, or prefixes or RE2 regular expressions when the prefix or regex mode is chosen.
+	MatchMode     *externalRef0.SegmenterMatchMode `json:"match_mode,omitempty"`
+	MultiValued   bool                             `json:"multi_valued"`
+	Name          string                           `json:"name"`
+	Options       *externalRef0.SegmenterOptions   `json:"options,omitempty"`
+	RequestFields *[]string                        `json:"request_fields,omitempty"`
+	Required      bool                             `json:"required"`
+	Type          externalRef0.SegmenterType       `json:"type"`
 }
 
 // UpdateExperimentRequestBody defines model for UpdateExperimentRequestBody.
 type UpdateExperimentRequestBody struct {
-	Description *string                            `json:"description"`
-	EndTime     time.Time                          `json:"end_time"`
-	Interval    *int32                             `json:"interval"`
-	Segment     externalRef0.ExperimentSegment     `json:"segment"`
-	StartTime   time.Time                          `json:"start_time"`
-	Status      externalRef0.ExperimentStatus      `json:"status"`
-	Tier        *externalRef0.ExperimentTier       `json:"tier,omitempty"`
-	Treatments  []externalRef0.ExperimentTreatment `json:"treatments"`
-	Type        externalRef0.ExperimentType        `json:"type"`
-	UpdatedBy   *string                            `json:"updated_by,omitempty"`
+	Description *string                             `json:"description"`
+	EndTime     time.Time                           `json:"end_time"`
+	Guardrails  *[]externalRef0.ExperimentGuardrail `json:"guardrails,omitempty"`
+	Interval    *int32                              `json:"interval"`
+
+	// Values of the segmenters that the experiment applies to. The s2_ids values may be given as a GeoJSON
+	// Polygon or MultiPolygon (or a Feature / FeatureCollection containing them) instead, which is replaced by
+	// the ids of the S2 cells covering it, between the configured min and max levels. The values of integer
+	// and real segmenters may include SegmenterRange objects, which match all the values within the range.
+	Segment    externalRef0.ExperimentSegment     `json:"segment"`
+	StartTime  time.Time                          `json:"start_time"`
+	Status     externalRef0.ExperimentStatus      `json:"status"`
+	Tier       *externalRef0.ExperimentTier       `json:"tier,omitempty"`
+	Treatments []externalRef0.ExperimentTreatment `json:"treatments"`
+	Type       externalRef0.ExperimentType        `json:"type"`
+	UpdatedBy  *string                            `json:"updated_by,omitempty"`
 }
 
 // UpdateProjectSettingsRequestBody defines model for UpdateProjectSettingsRequestBody.
@@ -243,6 +288,9 @@ type CreateExperimentJSONRequestBody CreateExperimentRequestBody
 // UpdateExperimentJSONRequestBody defines body for UpdateExperiment for application/json ContentType.
 type UpdateExperimentJSONRequestBody UpdateExperimentRequestBody
 
+// CreateProjectPasskeyJSONRequestBody defines body for CreateProjectPasskey for application/json ContentType.
+type CreateProjectPasskeyJSONRequestBody CreateProjectPasskeyRequestBody
+
 // CreateSegmenterJSONRequestBody defines body for CreateSegmenter for application/json ContentType.
 type CreateSegmenterJSONRequestBody CreateSegmenterRequestBody
 
@@ -287,6 +335,18 @@ type ServerInterface interface {
 	// List an experiment's historical versions
 	// (GET /projects/{project_id}/experiments/{experiment_id}/history/{version})
 	GetExperimentHistory(w http.ResponseWriter, r *http.Request, projectId int64, experimentId int64, version int64)
+	// List the passkeys of the given project that have not been revoked
+	// (GET /projects/{project_id}/passkeys)
+	ListProjectPasskeys(w http.ResponseWriter, r *http.Request, projectId int64)
+	// Generate a new passkey for the given project
+	// (POST /projects/{project_id}/passkeys)
+	CreateProjectPasskey(w http.ResponseWriter, r *http.Request, projectId int64)
+	// List the hashes of the passkeys of the given project, for the Treatment Service to authenticate requests
+	// (GET /projects/{project_id}/passkeys/hashes)
+	ListProjectPasskeyHashes(w http.ResponseWriter, r *http.Request, projectId int64)
+	// Revoke a passkey of the given project
+	// (DELETE /projects/{project_id}/passkeys/{name})
+	RevokeProjectPasskey(w http.ResponseWriter, r *http.Request, projectId int64, name string)
 	// Get all segmenter configurations required for generating experiments for the given project
 	// (GET /projects/{project_id}/segmenters)
 	ListSegmenters(w http.ResponseWriter, r *http.Request, projectId int64, params ListSegmentersParams)
@@ -835,6 +895,127 @@ func (siw *ServerInterfaceWrapper) GetExperimentHistory(w http.ResponseWriter, r
 	handler(w, r.WithContext(ctx))
 }
 
+// ListProjectPasskeys operation middleware
+func (siw *ServerInterfaceWrapper) ListProjectPasskeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListProjectPasskeys(w, r, projectId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// CreateProjectPasskey operation middleware
+func (siw *ServerInterfaceWrapper) CreateProjectPasskey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateProjectPasskey(w, r, projectId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ListProjectPasskeyHashes operation middleware
+func (siw *ServerInterfaceWrapper) ListProjectPasskeyHashes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListProjectPasskeyHashes(w, r, projectId)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// RevokeProjectPasskey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeProjectPasskey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "project_id" -------------
+	var projectId int64
+
+	err = runtime.BindStyledParameter("simple", false, "project_id", chi.URLParam(r, "project_id"), &projectId)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter project_id: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameter("simple", false, "name", chi.URLParam(r, "name"), &name)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid format for parameter name: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{""})
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.RevokeProjectPasskey(w, r, projectId, name)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // ListSegmenters operation middleware
 func (siw *ServerInterfaceWrapper) ListSegmenters(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -1189,6 +1370,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/projects/{project_id}/experiments/{experiment_id}/history/{version}", wrapper.GetExperimentHistory)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/projects/{project_id}/passkeys", wrapper.ListProjectPasskeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/projects/{project_id}/passkeys", wrapper.CreateProjectPasskey)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/projects/{project_id}/passkeys/hashes", wrapper.ListProjectPasskeyHashes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/projects/{project_id}/passkeys/{name}", wrapper.RevokeProjectPasskey)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/projects/{project_id}/segmenters", wrapper.ListSegmenters)
 	})
@@ -16,6 +16,7 @@ type ProjectSettings struct {
 type ProjectSettingsStore interface {
 	ListProjects() ([]schema.Project, error)
 	GetProjectSettings(projectId int64) (schema.ProjectSettings, error)
+	ListProjectPasskeyHashes(projectId int64) ([]schema.ProjectPasskeyHash, error)
 	GetProjectExperimentVariables(projectId int64) ([]string, error)
 	UpdateProjectSettings(updated schema.ProjectSettings) error
 }
@@ -43,6 +44,28 @@ func (u ProjectSettings) CreateProjectSettings(w http.ResponseWriter, r *http.Re
 	panic("implement me")
 }
 
+func (u ProjectSettings) ListProjectPasskeys(w http.ResponseWriter, r *http.Request, projectId int64) {
+	panic("implement me")
+}
+
+func (u ProjectSettings) ListProjectPasskeyHashes(w http.ResponseWriter, r *http.Request, projectId int64) {
+	passkeyHashes, err := u.ProjectSettingsStore.ListProjectPasskeyHashes(projectId)
+	if err != nil {
+		NotFound(w, err)
+		return
+	}
+	response := api.ListProjectPasskeyHashesSuccess{Data: passkeyHashes}
+	Success(w, response)
+}
+
+func (u ProjectSettings) CreateProjectPasskey(w http.ResponseWriter, r *http.Request, projectId int64) {
+	panic("implement me")
+}
+
+func (u ProjectSettings) RevokeProjectPasskey(w http.ResponseWriter, r *http.Request, projectId int64, name string) {
+	panic("implement me")
+}
+
 func (u ProjectSettings) GetProjectSettings(w http.ResponseWriter, r *http.Request, projectId int64) {
 	settings, err := u.ProjectSettingsStore.GetProjectSettings(projectId)
 	if err != nil {
@@ -164,7 +164,9 @@ func (suite *ManagementServiceTestSuite) SetupSuite() {
 		return
 	}
 	segmentersType := map[string]schema.SegmenterType{}
-	store, err := service.NewInMemoryStore(make([]schema.Experiment, 0), suite.projectSettings, queue, segmentersType)
+	store, err := service.NewInMemoryStore(
+		make([]schema.Experiment, 0), suite.projectSettings, nil, queue, segmentersType,
+	)
 	if err != nil {
 		suite.FailNow("fail to instantiate experiment store", err.Error())
 	}
@@ -14,7 +14,7 @@ import (
 type MessageQueue interface {
 	PublishNewExperiment(experiment schema.Experiment, segmentersType map[string]schema.SegmenterType) error
 	UpdateExperiment(experiment schema.Experiment, segmentersType map[string]schema.SegmenterType) error
-	UpdateProjectSettings(settings schema.ProjectSettings) error
+	UpdateProjectSettings(settings schema.ProjectSettings, passkeyHashes []schema.ProjectPasskeyHash) error
 }
 
 type PubSubMessageQueue struct {
@@ -79,11 +79,14 @@ func (i *PubSubMessageQueue) UpdateExperiment(
 	return i.publishMessage(&updateClientState)
 }
 
-func (i *PubSubMessageQueue) UpdateProjectSettings(settings schema.ProjectSettings) error {
+func (i *PubSubMessageQueue) UpdateProjectSettings(
+	settings schema.ProjectSettings,
+	passkeyHashes []schema.ProjectPasskeyHash,
+) error {
 	updateClientState := _pubsub.MessagePublishState{
 		Update: &_pubsub.MessagePublishState_ProjectSettingsUpdated{
 			ProjectSettingsUpdated: &_pubsub.ProjectSettingsUpdated{
-				ProjectSettings: models.OpenAPIProjectSettingsSpecToProtobuf(settings),
+				ProjectSettings: models.OpenAPIProjectSettingsSpecToProtobuf(settings, passkeyHashes),
 			},
 		},
 	}
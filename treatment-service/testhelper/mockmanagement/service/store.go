@@ -15,6 +15,7 @@ type InMemoryStore struct {
 	sync.RWMutex
 	Experiments     []schema.Experiment
 	ProjectSettings []schema.ProjectSettings
+	PasskeyHashes   map[int64][]schema.ProjectPasskeyHash
 	MessageQueue    MessageQueue
 	SegmentersTypes map[string]schema.SegmenterType
 }
@@ -22,12 +23,14 @@ type InMemoryStore struct {
 func NewInMemoryStore(
 	experiments []schema.Experiment,
 	settings []schema.ProjectSettings,
+	passkeyHashes map[int64][]schema.ProjectPasskeyHash,
 	queue MessageQueue,
 	segmentersType map[string]schema.SegmenterType,
 ) (*InMemoryStore, error) {
 	return &InMemoryStore{
 		Experiments:     experiments,
 		ProjectSettings: settings,
+		PasskeyHashes:   passkeyHashes,
 		MessageQueue:    queue,
 		SegmentersTypes: segmentersType,
 	}, nil
@@ -162,7 +165,7 @@ func (i *InMemoryStore) UpdateProjectSettings(updated schema.ProjectSettings) er
 	for index, settings := range i.ProjectSettings {
 		if settings.ProjectId == updated.ProjectId {
 			i.ProjectSettings[index] = updated
-			return i.MessageQueue.UpdateProjectSettings(updated)
+			return i.MessageQueue.UpdateProjectSettings(updated, i.PasskeyHashes[updated.ProjectId])
 		}
 	}
 	return InvalidProjectSettings{
@@ -181,6 +184,17 @@ func (i *InMemoryStore) GetProjectSettings(projectId int64) (schema.ProjectSetti
 	return schema.ProjectSettings{}, InvalidProjectSettings{projectId: projectId}
 }
 
+func (i *InMemoryStore) ListProjectPasskeyHashes(projectId int64) ([]schema.ProjectPasskeyHash, error) {
+	i.RLock()
+	defer i.RUnlock()
+	for _, settings := range i.ProjectSettings {
+		if settings.ProjectId == projectId {
+			return i.PasskeyHashes[projectId], nil
+		}
+	}
+	return nil, InvalidProjectSettings{projectId: projectId}
+}
+
 func (i *InMemoryStore) ListSegmenters(projectId int64) ([]schema.Segmenter, error) {
 	i.RLock()
 	defer i.RUnlock()
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/golang/geo/s2"
+	"github.com/uber/h3-go/v4"
 )
 
 func GetS2ID(lat float64, long float64, level int) (s2.CellID, error) {
@@ -20,6 +21,19 @@ func GetS2ID(lat float64, long float64, level int) (s2.CellID, error) {
 	return cell, nil
 }
 
+func GetH3ID(lat float64, long float64, resolution int) (h3.Cell, error) {
+	if !s2.LatLngFromDegrees(lat, long).IsValid() {
+		return h3.Cell(0), errors.New("received invalid latitude, longitude values")
+	}
+
+	if resolution < 0 || resolution > h3.MaxResolution {
+		return h3.Cell(0), errors.New("received invalid h3 resolution")
+	}
+
+	cell := h3.LatLngToCell(h3.NewLatLng(lat, long), resolution)
+	return cell, nil
+}
+
 func isValidLevel(level int) bool {
 	if level <= 30 && level > -1 {
 		return true
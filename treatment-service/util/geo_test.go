@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang/geo/s2"
 	"github.com/stretchr/testify/assert"
+	"github.com/uber/h3-go/v4"
 )
 
 func TestGetS2ID(t *testing.T) {
@@ -52,3 +53,46 @@ func TestGetS2ID(t *testing.T) {
 		})
 	}
 }
+
+func TestGetH3ID(t *testing.T) {
+	tests := map[string]struct {
+		lat        float64
+		long       float64
+		resolution int
+		err        error
+		expected   h3.Cell
+	}{
+		"success": {
+			lat:        37.775938728915946,
+			long:       -122.41795063018799,
+			resolution: 9,
+			expected:   h3.Cell(h3.IndexFromString("8928308280fffff")),
+		},
+		"failure | incorrect lat long": {
+			lat:        95,
+			long:       0,
+			resolution: 9,
+			err:        errors.New("received invalid latitude, longitude values"),
+		},
+		"failure | invalid resolution": {
+			lat:        37.775938728915946,
+			long:       -122.41795063018799,
+			resolution: 16,
+			err:        errors.New("received invalid h3 resolution"),
+		},
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp, err := GetH3ID(data.lat, data.long, data.resolution)
+
+			if data.err != nil {
+				assert.Equal(t, data.err, err)
+			}
+
+			if data.err == nil {
+				assert.Equal(t, data.expected, resp)
+			}
+		})
+	}
+}
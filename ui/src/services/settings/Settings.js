@@ -29,6 +29,7 @@ export class Settings {
 
     delete obj.created_at;
     delete obj.passkey;
+    delete obj.passkeys;
     delete obj.project_id;
     delete obj.updated_at;
     delete obj.username;
@@ -0,0 +1,104 @@
+import { Fragment, useRef, useState } from "react";
+
+import {
+  EuiButton,
+  EuiCode,
+  EuiFlexGroup,
+  EuiFlexItem,
+  EuiInMemoryTable,
+  EuiPanel,
+  EuiSpacer,
+  formatDate,
+} from "@elastic/eui";
+
+import { CreatePasskeyModal } from "settings/components/passkeys/CreatePasskeyModal";
+import { PasskeyCreatedModal } from "settings/components/passkeys/PasskeyCreatedModal";
+import { RevokePasskeyModal } from "settings/components/passkeys/RevokePasskeyModal";
+
+export const PasskeysSection = ({ settings, onChange }) => {
+  const revokePasskeyRef = useRef();
+  const [isCreateModalVisible, setCreateModalVisible] = useState(false);
+  const [createdPasskey, setCreatedPasskey] = useState();
+
+  // Only the hashes of the passkeys are stored, so the keys are identified by their fingerprints
+  const columns = [
+    {
+      field: "name",
+      width: "25%",
+      name: "Name",
+    },
+    {
+      field: "fingerprint",
+      width: "20%",
+      name: "Fingerprint",
+      render: (fingerprint) => <EuiCode>{fingerprint}</EuiCode>,
+    },
+    {
+      field: "created_at",
+      width: "25%",
+      name: "Created At",
+      render: (createdAt) => formatDate(createdAt),
+    },
+    {
+      field: "expires_at",
+      width: "25%",
+      name: "Expires At",
+      render: (expiresAt) => (!!expiresAt ? formatDate(expiresAt) : "-"),
+    },
+    {
+      actions: [
+        {
+          name: "Revoke",
+          description: "Revoke this passkey",
+          icon: "trash",
+          color: "danger",
+          type: "icon",
+          onClick: (passkey) => revokePasskeyRef.current(passkey),
+        },
+      ],
+    },
+  ];
+
+  return (
+    <Fragment>
+      <RevokePasskeyModal
+        projectId={settings.project_id}
+        onSuccess={onChange}
+        revokePasskeyRef={revokePasskeyRef}
+      />
+      {isCreateModalVisible && (
+        <CreatePasskeyModal
+          projectId={settings.project_id}
+          onCancel={() => setCreateModalVisible(false)}
+          onSuccess={(passkey) => {
+            setCreateModalVisible(false);
+            setCreatedPasskey(passkey);
+          }}
+        />
+      )}
+      {!!createdPasskey && (
+        <PasskeyCreatedModal
+          passkey={createdPasskey}
+          onClose={() => {
+            setCreatedPasskey(undefined);
+            onChange();
+          }}
+        />
+      )}
+      <EuiPanel>
+        <EuiInMemoryTable items={settings.passkeys || []} columns={columns} />
+        <EuiSpacer size="m" />
+        <EuiFlexGroup justifyContent="flexEnd">
+          <EuiFlexItem grow={false}>
+            <EuiButton
+              size="s"
+              iconType="plusInCircle"
+              onClick={() => setCreateModalVisible(true)}>
+              Generate Passkey
+            </EuiButton>
+          </EuiFlexItem>
+        </EuiFlexGroup>
+      </EuiPanel>
+    </Fragment>
+  );
+};
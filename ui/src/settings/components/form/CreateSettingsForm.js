@@ -34,7 +34,7 @@ export const CreateSettingsForm = ({ projectId, onCancel, onSuccess }) => {
         color: "success",
         iconType: "check",
       });
-      onSuccess(submissionResponse.data.data.id);
+      onSuccess(submissionResponse.data.data);
     }
   }, [submissionResponse, onSuccess]);
 
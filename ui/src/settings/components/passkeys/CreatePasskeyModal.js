@@ -0,0 +1,93 @@
+import { useEffect, useState } from "react";
+
+import {
+  EuiButton,
+  EuiButtonEmpty,
+  EuiDatePicker,
+  EuiFieldText,
+  EuiForm,
+  EuiFormRow,
+  EuiModal,
+  EuiModalBody,
+  EuiModalFooter,
+  EuiModalHeader,
+  EuiModalHeaderTitle,
+} from "@elastic/eui";
+
+import { useXpApi } from "hooks/useXpApi";
+import { useConfig } from "config";
+
+export const CreatePasskeyModal = ({ projectId, onCancel, onSuccess }) => {
+  const { appConfig } = useConfig();
+  const [name, setName] = useState("");
+  const [expiresAt, setExpiresAt] = useState(null);
+
+  const [submissionResponse, submitForm] = useXpApi(
+    `/projects/${projectId}/passkeys`,
+    {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+    },
+    {},
+    false
+  );
+
+  const onSubmit = () =>
+    submitForm({
+      body: JSON.stringify({
+        name,
+        ...(expiresAt && { expires_at: expiresAt.toISOString() }),
+      }),
+    });
+
+  useEffect(() => {
+    if (submissionResponse.isLoaded && !submissionResponse.error) {
+      onSuccess(submissionResponse.data.data);
+    }
+  }, [submissionResponse, onSuccess]);
+
+  return (
+    <EuiModal onClose={onCancel}>
+      <EuiModalHeader>
+        <EuiModalHeaderTitle>Generate Passkey</EuiModalHeaderTitle>
+      </EuiModalHeader>
+
+      <EuiModalBody>
+        <EuiForm>
+          <EuiFormRow
+            label="Name"
+            isInvalid={!!submissionResponse.error}
+            error={submissionResponse.error?.message}>
+            <EuiFieldText
+              value={name}
+              onChange={(e) => setName(e.target.value)}
+              isInvalid={!!submissionResponse.error}
+            />
+          </EuiFormRow>
+          <EuiFormRow
+            label="Expires At"
+            helpText="The passkey does not expire if unset.">
+            <EuiDatePicker
+              selected={expiresAt}
+              onChange={setExpiresAt}
+              onClear={() => setExpiresAt(null)}
+              showTimeSelect
+              utcOffset={appConfig.datetime.tzOffsetMinutes}
+            />
+          </EuiFormRow>
+        </EuiForm>
+      </EuiModalBody>
+
+      <EuiModalFooter>
+        <EuiButtonEmpty onClick={onCancel}>Cancel</EuiButtonEmpty>
+        <EuiButton
+          onClick={onSubmit}
+          isLoading={submissionResponse.isLoading}
+          isDisabled={!name}
+          fill>
+          Generate
+        </EuiButton>
+      </EuiModalFooter>
+    </EuiModal>
+  );
+};
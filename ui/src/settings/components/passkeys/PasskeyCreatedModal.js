@@ -0,0 +1,49 @@
+import {
+  EuiButton,
+  EuiButtonEmpty,
+  EuiCallOut,
+  EuiCode,
+  EuiCopy,
+  EuiModal,
+  EuiModalBody,
+  EuiModalFooter,
+  EuiModalHeader,
+  EuiModalHeaderTitle,
+  EuiSpacer,
+} from "@elastic/eui";
+
+// PasskeyCreatedModal shows the plaintext value of a newly generated passkey. Only the hash of the
+// passkey is stored, so the value cannot be retrieved again once the modal is closed.
+export const PasskeyCreatedModal = ({ passkey, onClose }) => (
+  <EuiModal onClose={onClose}>
+    <EuiModalHeader>
+      <EuiModalHeaderTitle>Passkey {passkey.name}</EuiModalHeaderTitle>
+    </EuiModalHeader>
+
+    <EuiModalBody>
+      <EuiCallOut
+        title="Copy the passkey now"
+        color="warning"
+        iconType="alert"
+        size="s">
+        <p>The passkey will not be shown again.</p>
+      </EuiCallOut>
+      <EuiSpacer size="m" />
+      <EuiCode>{passkey.passkey}</EuiCode>
+      <EuiSpacer size="s" />
+      <EuiCopy textToCopy={passkey.passkey}>
+        {(copy) => (
+          <EuiButtonEmpty size="s" iconType="copy" onClick={copy}>
+            Copy to clipboard
+          </EuiButtonEmpty>
+        )}
+      </EuiCopy>
+    </EuiModalBody>
+
+    <EuiModalFooter>
+      <EuiButton onClick={onClose} fill>
+        Done
+      </EuiButton>
+    </EuiModalFooter>
+  </EuiModal>
+);
@@ -0,0 +1,55 @@
+import { useEffect, useRef } from "react";
+
+import { ConfirmationModal, addToast } from "@caraml-dev/ui-lib";
+
+import { useModal } from "hooks/useModal";
+import { useXpApi } from "hooks/useXpApi";
+
+export const RevokePasskeyModal = ({ projectId, onSuccess, revokePasskeyRef }) => {
+  const closeModalRef = useRef();
+  const [passkey = {}, openModal, closeModal] = useModal(closeModalRef);
+
+  const [{ isLoading, isLoaded, error }, submitForm] = useXpApi(
+    `/projects/${projectId}/passkeys/${passkey.name}`,
+    {
+      method: "DELETE",
+      headers: { "Content-Type": "application/json" },
+    },
+    {},
+    false
+  );
+
+  useEffect(() => {
+    const _ = require("lodash");
+    if (isLoaded && !error && !_.isEmpty(passkey)) {
+      addToast({
+        id: `submit-success-revoke-${passkey.name}`,
+        title: `Passkey ${passkey.name} has been revoked!`,
+        color: "success",
+        iconType: "check",
+      });
+      onSuccess();
+      closeModal();
+    }
+  }, [isLoaded, error, passkey, onSuccess, closeModal]);
+
+  return (
+    <ConfirmationModal
+      title="Revoke Passkey"
+      onConfirm={submitForm}
+      isLoading={isLoading}
+      content={
+        <p>
+          You are about to revoke <b>{passkey.name}</b>. The requests with
+          this passkey will be rejected.
+        </p>
+      }
+      confirmButtonText="Revoke"
+      confirmButtonColor="danger">
+      {(onSubmit) =>
+        (revokePasskeyRef.current = openModal(onSubmit)) &&
+        (closeModalRef.current = onSubmit) && <span />
+      }
+    </ConfirmationModal>
+  );
+};
@@ -1,4 +1,4 @@
-import React, { useEffect } from "react";
+import React, { useEffect, useState } from "react";
 
 import {
   EuiPageTemplate,
@@ -11,11 +11,14 @@ import { PageTitle } from "components/page/PageTitle";
 import { SegmenterContextProvider } from "providers/segmenter/context";
 import { Settings } from "services/settings/Settings";
 import { CreateSettingsForm } from "settings/components/form/CreateSettingsForm";
+import { PasskeyCreatedModal } from "settings/components/passkeys/PasskeyCreatedModal";
 import { useConfig } from "config";
 
 const CreateSettingsView = () => {
   const { projectId } = useParams();
   const navigate = useNavigate();
+  // The passkey generated with the settings, which is only returned once
+  const [createdPasskey, setCreatedPasskey] = useState();
 
   const {
     appConfig: {
@@ -45,10 +48,21 @@ const CreateSettingsView = () => {
             <CreateSettingsForm
               projectId={projectId}
               onCancel={() => window.history.back()}
-              onSuccess={() => navigate(`..`)}
+              onSuccess={(settings) =>
+                setCreatedPasskey({
+                  name: settings.passkeys?.[0]?.name,
+                  passkey: settings.passkey,
+                })
+              }
             />
           </FormContextProvider>
         </SegmenterContextProvider>
+        {!!createdPasskey && (
+          <PasskeyCreatedModal
+            passkey={createdPasskey}
+            onClose={() => navigate(`..`)}
+          />
+        )}
         <EuiSpacer size="l" />
       </EuiPageTemplate.Section>
     </EuiPageTemplate>
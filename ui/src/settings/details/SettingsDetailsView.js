@@ -108,7 +108,7 @@ const SettingsDetailsView = () => {
           <Routes>
             {/* DETAILS */}
             <Route index element={<Navigate to="details" replace={true} />} />
-            <Route path="details" element={<SettingsConfigView settings={data?.data} />} />
+            <Route path="details" element={<SettingsConfigView settings={data?.data} onPasskeysChange={fetchXPSettings} />} />
             {/* CREATE */}
             <Route path="create" element={<CreateSettingsView />} />
             {/* EDIT */}
@@ -6,14 +6,23 @@ import { replaceBreadcrumbs } from "@caraml-dev/ui-lib";
 import { ConfigSection } from "components/config_section/ConfigSection";
 import { ExperimentationSection } from "settings/components/config_section/ExperimentationSection";
 import { GeneralInfoSection } from "settings/components/config_section/GeneralInfoSection";
+import { PasskeysSection } from "settings/components/config_section/PasskeysSection";
 
-export const SettingsConfigView = ({ settings }) => {
+export const SettingsConfigView = ({ settings, onPasskeysChange }) => {
   const generalInfo = {
     title: "General Info",
     iconType: "apmTrace",
     children: <GeneralInfoSection settings={settings} />,
   };
 
+  const passkeysInfo = {
+    title: "Passkeys",
+    iconType: "lock",
+    children: (
+      <PasskeysSection settings={settings} onChange={onPasskeysChange} />
+    ),
+  };
+
   const editableInfo = {
     title: "Experimentation",
     iconType: "beaker",
@@ -42,6 +51,12 @@ export const SettingsConfigView = ({ settings }) => {
               {generalInfo.children}
             </ConfigSection>
             <EuiSpacer />
+            <ConfigSection
+              title={passkeysInfo.title}
+              iconType={passkeysInfo.iconType}>
+              {passkeysInfo.children}
+            </ConfigSection>
+            <EuiSpacer />
             <ConfigSection
               title={editableInfo.title}
               iconType={editableInfo.iconType}>